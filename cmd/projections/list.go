@@ -0,0 +1,73 @@
+package projections
+
+import (
+	"context"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/query/projection"
+)
+
+func newList() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list [projection]",
+		Short: "lists the progress of the projections",
+		Long: `lists position, lag, failed and skipped events of the projections per instance
+the lag is the time between the latest event of the instance and the last event reduced by the projection`,
+		Example: `list
+list projections.users11 --instance 123456789`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			config := MustNewConfig(viper.GetViper())
+			instanceID, _ := cmd.Flags().GetString(flagInstance)
+
+			handlers, err := listHandlers(ctx, config, args)
+			if err != nil {
+				return err
+			}
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "PROJECTION\tINSTANCE\tPOSITION\tLAG\tFAILED\tSKIPPED\tPAUSED\tLAST UPDATED")
+			for _, h := range handlers {
+				statuses, err := h.Status(ctx, instanceID)
+				if err != nil {
+					return err
+				}
+				for _, status := range statuses {
+					fmt.Fprintf(w, "%s\t%s\t%f\t%s\t%d\t%d\t%t\t%s\n",
+						status.ProjectionName,
+						status.InstanceID,
+						status.Position,
+						status.Lag.Round(time.Millisecond),
+						status.FailedEvents,
+						status.SkippedEvents,
+						status.Paused,
+						status.LastUpdated.Format(time.RFC3339),
+					)
+				}
+			}
+			return w.Flush()
+		},
+	}
+	cmd.Flags().String(flagInstance, "", "id of the instance, all instances if empty")
+	return cmd
+}
+
+func listHandlers(ctx context.Context, config *Config, args []string) ([]*handler.Handler, error) {
+	if len(args) == 1 {
+		h, err := projectionHandler(ctx, config, args[0], "")
+		if err != nil {
+			return nil, err
+		}
+		return []*handler.Handler{h}, nil
+	}
+	if err := createProjections(ctx, config, ""); err != nil {
+		return nil, err
+	}
+	return projection.Projections(), nil
+}
@@ -0,0 +1,58 @@
+package projections
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func newPause() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pause <projection>",
+		Short: "stops the processing of events of the projection",
+		Long: `stops the processing of events of the projection until it's resumed
+the projection is paused for all instances if no instance is provided
+running ZITADEL instances cache the pauses, it takes up to 10 seconds until they stop processing`,
+		Example: `pause projections.users11 --instance 123456789`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := editorContext(context.Background())
+			config := MustNewConfig(viper.GetViper())
+			instanceID, _ := cmd.Flags().GetString(flagInstance)
+
+			h, err := projectionHandler(ctx, config, args[0], "")
+			if err != nil {
+				return err
+			}
+			return h.Pause(ctx, instanceID)
+		},
+	}
+	cmd.Flags().String(flagInstance, "", "id of the instance, all instances if empty")
+	return cmd
+}
+
+func newResume() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "resume <projection>",
+		Short: "continues the processing of events of a paused projection",
+		Long: `continues the processing of events of a paused projection
+a projection paused for all instances must be resumed without instance
+running ZITADEL instances cache the pauses, it takes up to 10 seconds until they continue processing`,
+		Example: `resume projections.users11 --instance 123456789`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := editorContext(context.Background())
+			config := MustNewConfig(viper.GetViper())
+			instanceID, _ := cmd.Flags().GetString(flagInstance)
+
+			h, err := projectionHandler(ctx, config, args[0], "")
+			if err != nil {
+				return err
+			}
+			return h.Resume(ctx, instanceID)
+		},
+	}
+	cmd.Flags().String(flagInstance, "", "id of the instance, all instances if empty")
+	return cmd
+}
@@ -0,0 +1,101 @@
+package projections
+
+import (
+	"context"
+	"os/user"
+
+	"github.com/spf13/cobra"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	cryptoDB "github.com/zitadel/zitadel/internal/crypto/database"
+	"github.com/zitadel/zitadel/internal/database"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	old_es "github.com/zitadel/zitadel/internal/eventstore/repository/sql"
+	new_es "github.com/zitadel/zitadel/internal/eventstore/v3"
+	"github.com/zitadel/zitadel/internal/query/projection"
+)
+
+const (
+	flagInstance = "instance"
+)
+
+func New() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "projections",
+		Short: "manage the projections",
+		Long: `inspect and operate the projections of ZITADEL
+the operations are executed on the database and are respected by all running ZITADEL instances
+Requirements:
+- cockroachdb or postgres`,
+	}
+	cmd.AddCommand(
+		newList(),
+		newRebuild(),
+		newPause(),
+		newResume(),
+		newSkipFailed(),
+	)
+	return cmd
+}
+
+// projectionHandler creates the projections and returns the handler of the projection with the name.
+// The encryption algorithms and the data keys are only required for reducing events, so they are only loaded if a master key is passed.
+func projectionHandler(ctx context.Context, config *Config, name string, masterKey string) (*handler.Handler, error) {
+	if err := createProjections(ctx, config, masterKey); err != nil {
+		return nil, err
+	}
+	h, ok := projection.ProjectionByName(name)
+	if !ok {
+		return nil, caos_errs.ThrowNotFoundf(nil, "PROJE-eiB5i", "projection %s not found", name)
+	}
+	return h, nil
+}
+
+func createProjections(ctx context.Context, config *Config, masterKey string) error {
+	zitadelDBClient, err := database.Connect(config.Database, false, false)
+	if err != nil {
+		return err
+	}
+	esPusherDBClient, err := database.Connect(config.Database, false, true)
+	if err != nil {
+		return err
+	}
+	var keyEncryptionAlgorithm, certEncryptionAlgorithm crypto.EncryptionAlgorithm
+	if masterKey != "" {
+		keyStorage, err := cryptoDB.NewKeyStorage(zitadelDBClient, masterKey)
+		if err != nil {
+			return err
+		}
+		userEncryptionAlgorithm, err := crypto.NewAESCrypto(config.EncryptionKeys.User, keyStorage)
+		if err != nil {
+			return err
+		}
+		// without the data keys, reducing sealed personal data fails
+		config.Eventstore.PersonalDataKeys = crypto.NewDataKeys(cryptoDB.NewDataKeyStorage(zitadelDBClient), userEncryptionAlgorithm)
+		if keyEncryptionAlgorithm, err = crypto.NewAESCrypto(config.EncryptionKeys.OIDC, keyStorage); err != nil {
+			return err
+		}
+		if certEncryptionAlgorithm, err = crypto.NewAESCrypto(config.EncryptionKeys.SAML, keyStorage); err != nil {
+			return err
+		}
+	}
+
+	config.Eventstore.Querier = old_es.NewCRDB(zitadelDBClient)
+	// the operations push no personal data
+	config.Eventstore.Pusher = new_es.NewEventstore(esPusherDBClient, nil)
+	eventstoreClient := eventstore.NewEventstore(config.Eventstore)
+
+	return projection.Create(ctx, zitadelDBClient, eventstoreClient, config.Projections, keyEncryptionAlgorithm, certEncryptionAlgorithm, config.SystemAPIUsers)
+}
+
+// editorContext sets the operating system user as editor of the operations
+func editorContext(ctx context.Context) context.Context {
+	editor := "cli"
+	if current, err := user.Current(); err == nil {
+		editor += ":" + current.Username
+	}
+	return authz.SetCtxData(ctx, authz.CtxData{UserID: editor})
+}
@@ -0,0 +1,41 @@
+package projections
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/zitadel/zitadel/cmd/key"
+)
+
+func newRebuild() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rebuild <projection>",
+		Short: "replays all events into the projection",
+		Long: `replays all events into shadow tables of the projection
+the rows of each instance are swapped with the rows of the shadow tables afterwards,
+the projection stays available with its previous state until the swap`,
+		Example: `rebuild projections.users11
+rebuild projections.users11 --instance 123456789 --instance 987654321`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := editorContext(context.Background())
+			config := MustNewConfig(viper.GetViper())
+			masterKey, err := key.MasterKey(cmd)
+			if err != nil {
+				return err
+			}
+			instanceIDs, _ := cmd.Flags().GetStringSlice(flagInstance)
+
+			h, err := projectionHandler(ctx, config, args[0], masterKey)
+			if err != nil {
+				return err
+			}
+			return h.Rebuild(ctx, instanceIDs...)
+		},
+	}
+	key.AddMasterKeyFlag(cmd)
+	cmd.Flags().StringSlice(flagInstance, nil, "ids of the instances to rebuild, all instances if empty")
+	return cmd
+}
@@ -0,0 +1,49 @@
+package projections
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	flagAggregateType = "aggregate-type"
+	flagAggregateID   = "aggregate-id"
+	flagSequence      = "sequence"
+)
+
+func newSkipFailed() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "skip-failed <projection>",
+		Short: "skips a failed event of the projection",
+		Long: `skips a failed event on the next processing of the projection
+the skip is recorded in projections.projection_operations`,
+		Example: `skip-failed projections.users11 --instance 123456789 --aggregate-type user --aggregate-id 123456789 --sequence 3`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := editorContext(context.Background())
+			config := MustNewConfig(viper.GetViper())
+			instanceID, _ := cmd.Flags().GetString(flagInstance)
+			aggregateType, _ := cmd.Flags().GetString(flagAggregateType)
+			aggregateID, _ := cmd.Flags().GetString(flagAggregateID)
+			sequence, _ := cmd.Flags().GetUint64(flagSequence)
+
+			h, err := projectionHandler(ctx, config, args[0], "")
+			if err != nil {
+				return err
+			}
+			return h.SkipFailedEvent(ctx, instanceID, eventstore.AggregateType(aggregateType), aggregateID, sequence)
+		},
+	}
+	cmd.Flags().String(flagInstance, "", "id of the instance of the failed event")
+	cmd.Flags().String(flagAggregateType, "", "aggregate type of the failed event")
+	cmd.Flags().String(flagAggregateID, "", "aggregate id of the failed event")
+	cmd.Flags().Uint64(flagSequence, 0, "sequence of the failed event")
+	for _, flag := range []string{flagInstance, flagAggregateType, flagAggregateID, flagSequence} {
+		_ = cmd.MarkFlagRequired(flag)
+	}
+	return cmd
+}
@@ -93,6 +93,7 @@ func (mig *FirstInstance) Execute(ctx context.Context) error {
 		0,
 		0,
 		nil,
+		nil,
 	)
 	if err != nil {
 		return err
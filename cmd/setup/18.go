@@ -0,0 +1,26 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+)
+
+var (
+	//go:embed 18.sql
+	addRecoveryCodesColumn string
+)
+
+type AddRecoveryCodesColumn struct {
+	dbClient *database.DB
+}
+
+func (mig *AddRecoveryCodesColumn) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, addRecoveryCodesColumn)
+	return err
+}
+
+func (mig *AddRecoveryCodesColumn) String() string {
+	return "18_auth_users_recovery_codes_column"
+}
@@ -0,0 +1,26 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+)
+
+var (
+	//go:embed 19.sql
+	createDataKeysTable string
+)
+
+type DataKeysTable struct {
+	dbClient *database.DB
+}
+
+func (mig *DataKeysTable) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, createDataKeysTable)
+	return err
+}
+
+func (mig *DataKeysTable) String() string {
+	return "19_data_keys_table"
+}
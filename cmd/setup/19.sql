@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS system.data_keys (
+	instance_id TEXT NOT NULL
+	, owner_id TEXT NOT NULL
+	, key JSONB NOT NULL
+	, creation_date TIMESTAMPTZ NOT NULL
+
+	, PRIMARY KEY (instance_id, owner_id)
+);
\ No newline at end of file
@@ -0,0 +1,26 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+)
+
+var (
+	//go:embed 20.sql
+	createSnapshotsTable string
+)
+
+type SnapshotsTable struct {
+	dbClient *database.DB
+}
+
+func (mig *SnapshotsTable) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, createSnapshotsTable)
+	return err
+}
+
+func (mig *SnapshotsTable) String() string {
+	return "20_snapshots_table"
+}
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS eventstore.snapshots (
+	instance_id TEXT NOT NULL
+	, model TEXT NOT NULL
+	, "key" TEXT NOT NULL
+	, "version" INT2 NOT NULL
+	, "position" DECIMAL NOT NULL
+	, payload JSONB NOT NULL
+	, created_at TIMESTAMPTZ NOT NULL
+
+	, PRIMARY KEY (instance_id, model, "key")
+);
\ No newline at end of file
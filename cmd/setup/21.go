@@ -0,0 +1,26 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+)
+
+var (
+	//go:embed 21.sql
+	createProjectionOperationsTables string
+)
+
+type ProjectionOperationsTables struct {
+	dbClient *database.DB
+}
+
+func (mig *ProjectionOperationsTables) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, createProjectionOperationsTables)
+	return err
+}
+
+func (mig *ProjectionOperationsTables) String() string {
+	return "21_projection_operations_tables"
+}
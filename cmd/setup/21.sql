@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS projections.paused_projections (
+	projection_name TEXT NOT NULL
+	, instance_id TEXT NOT NULL
+	, paused_at TIMESTAMPTZ NOT NULL
+
+	, PRIMARY KEY (projection_name, instance_id)
+);
+
+CREATE TABLE IF NOT EXISTS projections.projection_operations (
+	projection_name TEXT NOT NULL
+	, instance_id TEXT NOT NULL
+	, operation TEXT NOT NULL
+	, details TEXT NOT NULL
+	, editor TEXT NOT NULL
+	, created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS po_projection_name_idx ON projections.projection_operations (projection_name, created_at);
@@ -0,0 +1,26 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+)
+
+var (
+	//go:embed 22.sql
+	createDestroyedDataKeysTable string
+)
+
+type DestroyedDataKeysTable struct {
+	dbClient *database.DB
+}
+
+func (mig *DestroyedDataKeysTable) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, createDestroyedDataKeysTable)
+	return err
+}
+
+func (mig *DestroyedDataKeysTable) String() string {
+	return "22_destroyed_data_keys_table"
+}
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS system.destroyed_data_keys (
+	instance_id TEXT NOT NULL
+	, owner_id TEXT NOT NULL
+	, destruction_date TIMESTAMPTZ NOT NULL
+
+	, PRIMARY KEY (instance_id, owner_id)
+);
\ No newline at end of file
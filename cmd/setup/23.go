@@ -0,0 +1,26 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+)
+
+var (
+	//go:embed 23.sql
+	createProjectionRebuildsTable string
+)
+
+type ProjectionRebuildsTable struct {
+	dbClient *database.DB
+}
+
+func (mig *ProjectionRebuildsTable) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, createProjectionRebuildsTable)
+	return err
+}
+
+func (mig *ProjectionRebuildsTable) String() string {
+	return "23_projection_rebuilds_table"
+}
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS projections.projection_rebuilds (
+	projection_name TEXT NOT NULL
+	, state TEXT NOT NULL
+	, error TEXT
+	, started_at TIMESTAMPTZ NOT NULL
+	, updated_at TIMESTAMPTZ NOT NULL
+	, finished_at TIMESTAMPTZ
+
+	, PRIMARY KEY (projection_name)
+);
@@ -36,7 +36,8 @@ func Cleanup(config *Config) {
 	esPusherDBClient, err := database.Connect(config.Database, false, true)
 	logging.OnError(err).Fatal("unable to connect to database")
 
-	config.Eventstore.Pusher = new_es.NewEventstore(esPusherDBClient)
+	// the cleanup only pushes migration events, which contain no personal data
+	config.Eventstore.Pusher = new_es.NewEventstore(esPusherDBClient, nil)
 	config.Eventstore.Querier = old_es.NewCRDB(zitadelDBClient)
 	es := eventstore.NewEventstore(config.Eventstore)
 	migration.RegisterMappers(es)
@@ -34,6 +34,7 @@ type Config struct {
 	Machine         *id.Config
 	Projections     projection.Config
 	Eventstore      *eventstore.Config
+	CryptoShredding crypto.ShreddingConfig
 }
 
 func MustNewConfig(v *viper.Viper) *Config {
@@ -75,6 +76,12 @@ type Steps struct {
 	s15CurrentStates                *CurrentProjectionState
 	s16UniqueConstraintsLower       *UniqueConstraintToLower
 	s17AddOffsetToUniqueConstraints *AddOffsetToCurrentStates
+	s18AddRecoveryCodesColumn       *AddRecoveryCodesColumn
+	s19DataKeysTable                *DataKeysTable
+	s20SnapshotsTable               *SnapshotsTable
+	s21ProjectionOperationsTables   *ProjectionOperationsTables
+	s22DestroyedDataKeysTable       *DestroyedDataKeysTable
+	s23ProjectionRebuildsTable      *ProjectionRebuildsTable
 }
 
 type encryptionKeyConfig struct {
@@ -58,6 +58,7 @@ func (mig *externalConfigChange) Execute(ctx context.Context) error {
 		0,
 		0,
 		nil,
+		nil,
 	)
 
 	if err != nil {
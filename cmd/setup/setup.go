@@ -12,6 +12,8 @@ import (
 	"github.com/zitadel/zitadel/cmd/build"
 	"github.com/zitadel/zitadel/cmd/key"
 	"github.com/zitadel/zitadel/cmd/tls"
+	"github.com/zitadel/zitadel/internal/crypto"
+	crypto_db "github.com/zitadel/zitadel/internal/crypto/database"
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	old_es "github.com/zitadel/zitadel/internal/eventstore/repository/sql"
@@ -72,8 +74,18 @@ func Setup(config *Config, steps *Steps, masterKey string) {
 	esPusherDBClient, err := database.Connect(config.Database, false, true)
 	logging.OnError(err).Fatal("unable to connect to database")
 
+	personalDataKeys, err := newPersonalDataKeys(config, masterKey, zitadelDBClient)
+	logging.OnError(err).Fatal("unable to start personal data keys")
+	var sealingKeys eventstore.PersonalDataKeys
+	if config.CryptoShredding.Enabled {
+		sealingKeys = personalDataKeys
+	}
+
 	config.Eventstore.Querier = old_es.NewCRDB(zitadelDBClient)
-	config.Eventstore.Pusher = new_es.NewEventstore(esPusherDBClient)
+	config.Eventstore.Pusher = new_es.NewEventstore(esPusherDBClient, sealingKeys)
+	config.Eventstore.PersonalDataKeys = personalDataKeys
+	// the projections of running nodes are notified about the events pushed by the setup
+	config.Eventstore.Notifier = new_es.NewNotifier(zitadelDBClient, config.Eventstore.Notification)
 	eventstoreClient := eventstore.NewEventstore(config.Eventstore)
 	logging.OnError(err).Fatal("unable to start eventstore")
 	migration.RegisterMappers(eventstoreClient)
@@ -105,6 +117,12 @@ func Setup(config *Config, steps *Steps, masterKey string) {
 	steps.s15CurrentStates = &CurrentProjectionState{dbClient: zitadelDBClient}
 	steps.s16UniqueConstraintsLower = &UniqueConstraintToLower{dbClient: zitadelDBClient}
 	steps.s17AddOffsetToUniqueConstraints = &AddOffsetToCurrentStates{dbClient: zitadelDBClient}
+	steps.s18AddRecoveryCodesColumn = &AddRecoveryCodesColumn{dbClient: zitadelDBClient}
+	steps.s19DataKeysTable = &DataKeysTable{dbClient: zitadelDBClient}
+	steps.s20SnapshotsTable = &SnapshotsTable{dbClient: esPusherDBClient}
+	steps.s21ProjectionOperationsTables = &ProjectionOperationsTables{dbClient: zitadelDBClient}
+	steps.s22DestroyedDataKeysTable = &DestroyedDataKeysTable{dbClient: zitadelDBClient}
+	steps.s23ProjectionRebuildsTable = &ProjectionRebuildsTable{dbClient: zitadelDBClient}
 
 	err = projection.Create(ctx, zitadelDBClient, eventstoreClient, config.Projections, nil, nil, nil)
 	logging.OnError(err).Fatal("unable to start projections")
@@ -129,6 +147,11 @@ func Setup(config *Config, steps *Steps, masterKey string) {
 	logging.WithFields("name", steps.s1ProjectionTable.String()).OnError(err).Fatal("migration failed")
 	err = migration.Migrate(ctx, eventstoreClient, steps.s2AssetsTable)
 	logging.WithFields("name", steps.s2AssetsTable.String()).OnError(err).Fatal("migration failed")
+	// the data keys are required by the first instance if crypto-shredding is enabled
+	err = migration.Migrate(ctx, eventstoreClient, steps.s19DataKeysTable)
+	logging.WithFields("name", steps.s19DataKeysTable.String()).OnError(err).Fatal("migration failed")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s22DestroyedDataKeysTable)
+	logging.WithFields("name", steps.s22DestroyedDataKeysTable.String()).OnError(err).Fatal("migration failed")
 	err = migration.Migrate(ctx, eventstoreClient, steps.FirstInstance)
 	logging.WithFields("name", steps.FirstInstance.String()).OnError(err).Fatal("migration failed")
 	err = migration.Migrate(ctx, eventstoreClient, steps.s5LastFailed)
@@ -149,6 +172,14 @@ func Setup(config *Config, steps *Steps, masterKey string) {
 	logging.WithFields("name", steps.s16UniqueConstraintsLower.String()).OnError(err).Fatal("migration failed")
 	err = migration.Migrate(ctx, eventstoreClient, steps.s17AddOffsetToUniqueConstraints)
 	logging.WithFields("name", steps.s17AddOffsetToUniqueConstraints.String()).OnError(err).Fatal("migration failed")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s18AddRecoveryCodesColumn)
+	logging.WithFields("name", steps.s18AddRecoveryCodesColumn.String()).OnError(err).Fatal("migration failed")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s20SnapshotsTable)
+	logging.WithFields("name", steps.s20SnapshotsTable.String()).OnError(err).Fatal("migration failed")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s21ProjectionOperationsTables)
+	logging.WithFields("name", steps.s21ProjectionOperationsTables.String()).OnError(err).Fatal("migration failed")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s23ProjectionRebuildsTable)
+	logging.WithFields("name", steps.s23ProjectionRebuildsTable.String()).OnError(err).Fatal("migration failed")
 
 	for _, repeatableStep := range repeatableSteps {
 		err = migration.Migrate(ctx, eventstoreClient, repeatableStep)
@@ -160,3 +191,21 @@ func readStmt(fs embed.FS, folder, typ, filename string) (string, error) {
 	stmt, err := fs.ReadFile(folder + "/" + typ + "/" + filename)
 	return string(stmt), err
 }
+
+// newPersonalDataKeys returns the data keys of the personal data,
+// they are required by the steps reducing user events even if crypto-shredding is disabled,
+// because events might have been sealed while it was enabled
+func newPersonalDataKeys(config *Config, masterKey string, dbClient *database.DB) (*crypto.DataKeys, error) {
+	keyStorage, err := crypto_db.NewKeyStorage(dbClient, masterKey)
+	if err != nil {
+		return nil, err
+	}
+	if err = verifyKey(config.EncryptionKeys.User, keyStorage); err != nil {
+		return nil, err
+	}
+	userAlg, err := crypto.NewAESCrypto(config.EncryptionKeys.User, keyStorage)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.NewDataKeys(crypto_db.NewDataKeyStorage(dbClient), userAlg), nil
+}
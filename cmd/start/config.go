@@ -25,7 +25,9 @@ import (
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventsink"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/execution"
 	"github.com/zitadel/zitadel/internal/id"
 	"github.com/zitadel/zitadel/internal/logstore"
 	"github.com/zitadel/zitadel/internal/notification/handlers"
@@ -36,40 +38,44 @@ import (
 )
 
 type Config struct {
-	Log               *logging.Config
-	Port              uint16
-	ExternalPort      uint16
-	ExternalDomain    string
-	ExternalSecure    bool
-	TLS               network.TLS
-	HTTP2HostHeader   string
-	HTTP1HostHeader   string
-	WebAuthNName      string
-	Database          database.Config
-	Tracing           tracing.Config
-	Metrics           metrics.Config
-	Projections       projection.Config
-	Auth              auth_es.Config
-	Admin             admin_es.Config
-	UserAgentCookie   *middleware.UserAgentCookieConfig
-	OIDC              oidc.Config
-	SAML              saml.Config
-	Login             login.Config
-	Console           console.Config
-	AssetStorage      static_config.AssetStorageConfig
-	InternalAuthZ     internal_authz.Config
-	SystemDefaults    systemdefaults.SystemDefaults
-	EncryptionKeys    *encryptionKeyConfig
-	DefaultInstance   command.InstanceSetup
-	AuditLogRetention time.Duration
-	SystemAPIUsers    SystemAPIUsers
-	CustomerPortal    string
-	Machine           *id.Config
-	Actions           *actions.Config
-	Eventstore        *eventstore.Config
-	LogStore          *logstore.Configs
-	Quotas            *QuotasConfig
-	Telemetry         *handlers.TelemetryPusherConfig
+	Log                *logging.Config
+	Port               uint16
+	ExternalPort       uint16
+	ExternalDomain     string
+	ExternalSecure     bool
+	TLS                network.TLS
+	HTTP2HostHeader    string
+	HTTP1HostHeader    string
+	WebAuthNName       string
+	Database           database.Config
+	Tracing            tracing.Config
+	Metrics            metrics.Config
+	Projections        projection.Config
+	Auth               auth_es.Config
+	Admin              admin_es.Config
+	UserAgentCookie    *middleware.UserAgentCookieConfig
+	OIDC               oidc.Config
+	SAML               saml.Config
+	Login              login.Config
+	Console            console.Config
+	AssetStorage       static_config.AssetStorageConfig
+	InternalAuthZ      internal_authz.Config
+	SystemDefaults     systemdefaults.SystemDefaults
+	EncryptionKeys     *encryptionKeyConfig
+	DefaultInstance    command.InstanceSetup
+	AuditLogRetention  time.Duration
+	SystemAPIUsers     SystemAPIUsers
+	CustomerPortal     string
+	Machine            *id.Config
+	Actions            *actions.Config
+	Eventstore         *eventstore.Config
+	LogStore           *logstore.Configs
+	Quotas             *QuotasConfig
+	Telemetry          *handlers.TelemetryPusherConfig
+	NotificationOutbox *handlers.NotificationOutboxConfig
+	EventSinks         *eventsink.Config
+	Executions         *execution.Config
+	CryptoShredding    crypto.ShreddingConfig
 }
 
 type QuotasConfig struct {
@@ -110,6 +116,7 @@ func MustNewConfig(v *viper.Viper) *Config {
 
 	id.Configure(config.Machine)
 	actions.SetHTTPConfig(&config.Actions.HTTP)
+	actions.SetLimitsConfig(&config.Actions.Limits)
 
 	return config
 }
@@ -73,7 +73,7 @@ Actions:
 ` + tt.args.yaml))
 			require.NoError(t, err)
 			tt.want.Log = &logging.Config{Level: "info"}
-			tt.want.Actions = &actions.Config{HTTP: actions.HTTPConfig{DenyList: []actions.AddressChecker{}}}
+			tt.want.Actions = &actions.Config{HTTP: actions.HTTPConfig{DenyList: []actions.AddressChecker{}, AllowList: []actions.AddressChecker{}}}
 			require.NoError(t, tt.want.Log.SetLogger())
 			got := MustNewConfig(v)
 			if !reflect.DeepEqual(got, tt.want) {
@@ -33,6 +33,7 @@ import (
 	"github.com/zitadel/zitadel/internal/api"
 	"github.com/zitadel/zitadel/internal/api/assets"
 	internal_authz "github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/eventstream"
 	"github.com/zitadel/zitadel/internal/api/grpc/admin"
 	"github.com/zitadel/zitadel/internal/api/grpc/auth"
 	"github.com/zitadel/zitadel/internal/api/grpc/management"
@@ -59,14 +60,16 @@ import (
 	cryptoDB "github.com/zitadel/zitadel/internal/crypto/database"
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventsink"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	old_es "github.com/zitadel/zitadel/internal/eventstore/repository/sql"
 	new_es "github.com/zitadel/zitadel/internal/eventstore/v3"
+	"github.com/zitadel/zitadel/internal/execution"
 	"github.com/zitadel/zitadel/internal/i18n"
 	"github.com/zitadel/zitadel/internal/id"
 	"github.com/zitadel/zitadel/internal/logstore"
 	"github.com/zitadel/zitadel/internal/logstore/emitters/access"
-	"github.com/zitadel/zitadel/internal/logstore/emitters/execution"
+	execution_log "github.com/zitadel/zitadel/internal/logstore/emitters/execution"
 	"github.com/zitadel/zitadel/internal/logstore/emitters/stdout"
 	"github.com/zitadel/zitadel/internal/logstore/record"
 	"github.com/zitadel/zitadel/internal/net"
@@ -133,6 +136,10 @@ func startZitadel(config *Config, masterKey string, server chan<- *Server) error
 	if err != nil {
 		return fmt.Errorf("cannot start client for projection: %w", err)
 	}
+	queryDBClient, err := database.ConnectReplicas(config.Database, zitadelDBClient)
+	if err != nil {
+		return fmt.Errorf("cannot start client for queries: %w", err)
+	}
 
 	keyStorage, err := cryptoDB.NewKeyStorage(zitadelDBClient, masterKey)
 	if err != nil {
@@ -142,16 +149,27 @@ func startZitadel(config *Config, masterKey string, server chan<- *Server) error
 	if err != nil {
 		return err
 	}
+	// the data keys are always required to read the personal data sealed while crypto-shredding was enabled
+	personalDataKeys := crypto.NewDataKeys(cryptoDB.NewDataKeyStorage(zitadelDBClient), keys.User)
+	var sealingKeys eventstore.PersonalDataKeys
+	if config.CryptoShredding.Enabled {
+		sealingKeys = personalDataKeys
+	}
 
-	config.Eventstore.Pusher = new_es.NewEventstore(esPusherDBClient)
+	config.Eventstore.Pusher = new_es.NewEventstore(esPusherDBClient, sealingKeys)
+	config.Eventstore.PersonalDataKeys = personalDataKeys
 	config.Eventstore.Querier = old_es.NewCRDB(zitadelDBClient)
+	config.Eventstore.Notifier = new_es.NewNotifier(zitadelDBClient, config.Eventstore.Notification)
+	config.Eventstore.SnapshotStorage = new_es.NewSnapshotStorage(zitadelDBClient)
 	eventstoreClient := eventstore.NewEventstore(config.Eventstore)
+	go eventstoreClient.ListenNotifications(ctx)
 
 	sessionTokenVerifier := internal_authz.SessionTokenVerifier(keys.OIDC)
 
 	queries, err := query.StartQueries(
 		ctx,
 		eventstoreClient,
+		queryDBClient,
 		zitadelDBClient,
 		config.Projections,
 		config.SystemDefaults,
@@ -213,6 +231,7 @@ func startZitadel(config *Config, masterKey string, server chan<- *Server) error
 		config.OIDC.DefaultRefreshTokenExpiration,
 		config.OIDC.DefaultRefreshTokenIdleExpiration,
 		config.DefaultInstance.SecretGenerators,
+		queries,
 	)
 	if err != nil {
 		return fmt.Errorf("cannot start commands: %w", err)
@@ -224,13 +243,15 @@ func startZitadel(config *Config, masterKey string, server chan<- *Server) error
 	if err != nil {
 		return err
 	}
-	actionsExecutionDBEmitter, err := logstore.NewEmitter[*record.ExecutionLog](ctx, clock, config.Quotas.Execution, execution.NewDatabaseLogStorage(zitadelDBClient, commands, queries))
+	actionsExecutionDBEmitter, err := logstore.NewEmitter[*record.ExecutionLog](ctx, clock, config.Quotas.Execution, execution_log.NewDatabaseLogStorage(zitadelDBClient, commands, queries))
 	if err != nil {
 		return err
 	}
 
 	actionsLogstoreSvc := logstore.New(queries, actionsExecutionDBEmitter, actionsExecutionStdoutEmitter)
 	actions.SetLogstoreService(actionsLogstoreSvc)
+	actions.SetLimitsQuerier(queries)
+	execution.SetLogstoreService(actionsLogstoreSvc)
 
 	notification.Start(
 		ctx,
@@ -238,6 +259,9 @@ func startZitadel(config *Config, masterKey string, server chan<- *Server) error
 		config.Projections.Customizations["notificationsquotas"],
 		config.Projections.Customizations["telemetry"],
 		*config.Telemetry,
+		config.Projections.Customizations["notificationoutbox"],
+		*config.NotificationOutbox,
+		config.Projections.Customizations["passwordexpirywarnings"],
 		config.ExternalDomain,
 		config.ExternalPort,
 		config.ExternalSecure,
@@ -250,6 +274,22 @@ func startZitadel(config *Config, masterKey string, server chan<- *Server) error
 		keys.SMTP,
 		keys.SMS,
 	)
+	eventsink.Start(
+		ctx,
+		config.Projections.Customizations["eventsinks"],
+		config.EventSinks,
+		eventstoreClient,
+		queries,
+		keys.OIDC,
+	)
+	executions := execution.Start(
+		ctx,
+		config.Projections.Customizations["executionevents"],
+		config.Executions,
+		eventstoreClient,
+		queries,
+		keys.OIDC,
+	)
 
 	router := mux.NewRouter()
 	tlsConfig, err := config.TLS.Config()
@@ -269,6 +309,7 @@ func startZitadel(config *Config, masterKey string, server chan<- *Server) error
 		authZRepo,
 		keys,
 		permissionCheck,
+		executions,
 	)
 	if err != nil {
 		return err
@@ -311,6 +352,7 @@ func startAPIs(
 	authZRepo authz_repo.Repository,
 	keys *encryptionKeys,
 	permissionCheck domain.PermissionCheck,
+	executions *execution.Executions,
 ) error {
 	repo := struct {
 		authz_repo.Repository
@@ -349,7 +391,7 @@ func startAPIs(
 		http_util.WithMaxAge(int(math.Floor(config.Quotas.Access.ExhaustedCookieMaxAge.Seconds()))),
 	)
 	limitingAccessInterceptor := middleware.NewAccessInterceptor(accessSvc, exhaustedCookieHandler, &config.Quotas.Access.AccessConfig)
-	apis, err := api.New(ctx, config.Port, router, queries, verifier, config.InternalAuthZ, tlsConfig, config.HTTP2HostHeader, config.HTTP1HostHeader, limitingAccessInterceptor)
+	apis, err := api.New(ctx, config.Port, router, queries, verifier, config.InternalAuthZ, tlsConfig, config.HTTP2HostHeader, config.HTTP1HostHeader, limitingAccessInterceptor, executions)
 	if err != nil {
 		return fmt.Errorf("error creating api %w", err)
 	}
@@ -397,6 +439,7 @@ func startAPIs(
 	assetsCache := middleware.AssetsCacheInterceptor(config.AssetStorage.Cache.MaxAge, config.AssetStorage.Cache.SharedMaxAge)
 	apis.RegisterHandlerOnPrefix(assets.HandlerPrefix, assets.NewHandler(commands, verifier, config.InternalAuthZ, id.SonyFlakeGenerator(), store, queries, middleware.CallDurationHandler, instanceInterceptor.Handler, assetsCache.Handler, limitingAccessInterceptor.Handle))
 
+	apis.RegisterHandlerOnPrefix(eventstream.HandlerPrefix, eventstream.NewHandler(queries, verifier, config.InternalAuthZ, instanceInterceptor.Handler, limitingAccessInterceptor.Handle))
 	apis.RegisterHandlerOnPrefix(idp.HandlerPrefix, idp.NewHandler(commands, queries, keys.IDPConfig, config.ExternalSecure, instanceInterceptor.Handler))
 
 	userAgentInterceptor, err := middleware.NewUserAgentHandler(config.UserAgentCookie, keys.UserAgentCookieKey, id.SonyFlakeGenerator(), config.ExternalSecure, login.EndpointResources, login.EndpointExternalLoginCallbackFormPost, login.EndpointSAMLACS)
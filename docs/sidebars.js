@@ -614,6 +614,8 @@ module.exports = {
         "apis/actions/internal-authentication",
         "apis/actions/external-authentication",
         "apis/actions/complement-token",
+        "apis/actions/password-reset",
+        "apis/actions/logout",
         "apis/actions/customize-samlresponse",
         "apis/actions/objects",
       ]
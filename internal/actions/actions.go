@@ -13,7 +13,8 @@ import (
 )
 
 type Config struct {
-	HTTP HTTPConfig
+	HTTP   HTTPConfig
+	Limits LimitsConfig
 }
 
 var ErrHalt = errors.New("interrupt")
@@ -23,38 +24,64 @@ type jsAction func(fields, fields) error
 const (
 	actionStartedMessage   = "action run started"
 	actionSucceededMessage = "action run succeeded"
+
+	sandboxViolationErrorType = "sandbox_violation"
 )
 
 func actionFailedMessage(err error) string {
 	return fmt.Sprintf("action run failed: %s", err.Error())
 }
 
+func actionViolatedMessage(err *ViolationError) string {
+	return fmt.Sprintf("action run violated sandbox limit: %s", err.Error())
+}
+
 func Run(ctx context.Context, ctxParam contextFields, apiParam apiFields, script, name string, opts ...Option) (err error) {
 	config := newRunConfig(ctx, append(opts, withLogger(ctx))...)
 	if config.functionTimeout == 0 {
 		return z_errs.ThrowInternal(nil, "ACTIO-uCpCx", "Errrors.Internal")
 	}
 
+	config.limits = instanceLimits(ctx, config.instanceID)
 	remaining := logstoreService.Limit(ctx, config.instanceID)
 	config.cutTimeouts(remaining)
 
-	config.logger.Log(actionStartedMessage)
+	config.logger.log(actionStartedMessage, logrus.InfoLevel, false)
 	if remaining != nil && *remaining == 0 {
 		return z_errs.ThrowResourceExhausted(nil, "ACTIO-f19Ii", "Errors.Quota.Execution.Exhausted")
 	}
 
 	defer func() {
-		if err != nil {
+		var violationErr *ViolationError
+		switch {
+		case errors.As(err, &violationErr):
+			config.logger.logWithMetadata(actionViolatedMessage(violationErr), logrus.ErrorLevel, true, map[string]interface{}{
+				"errorType": sandboxViolationErrorType,
+				"limit":     string(violationErr.Limit),
+			})
+		case err != nil:
 			config.logger.log(actionFailedMessage(err), logrus.ErrorLevel, true)
-		} else {
+		default:
 			config.logger.log(actionSucceededMessage, logrus.InfoLevel, true)
 		}
+		config.countExecution(ctx, err)
 		if config.allowedToFail {
 			err = nil
 		}
 	}()
 
-	if err := executeScript(config, ctxParam, apiParam, script); err != nil {
+	release, err := acquireRunSlot(ctx, config.instanceID, config.limits.maxConcurrentRuns)
+	if err != nil {
+		return err
+	}
+	defer release()
+	config.logger.limitOutput(config.vm, config.limits.maxOutputSize)
+	safePoint, err := config.registerSafePoint()
+	if err != nil {
+		return err
+	}
+
+	if err := executeScript(config, ctxParam, apiParam, script, safePoint); err != nil {
 		return err
 	}
 
@@ -75,7 +102,7 @@ func Run(ctx context.Context, ctxParam contextFields, apiParam apiFields, script
 	return executeFn(config, fn)
 }
 
-func executeScript(config *runConfig, ctxParam contextFields, apiParam apiFields, script string) (err error) {
+func executeScript(config *runConfig, ctxParam contextFields, apiParam apiFields, script, safePoint string) (err error) {
 	t := config.StartScript()
 	defer func() {
 		t.Stop()
@@ -103,7 +130,15 @@ func executeScript(config *runConfig, ctxParam contextFields, apiParam apiFields
 		}
 	}()
 
-	_, err = config.vm.RunString(script)
+	if safePoint == "" {
+		_, err = config.vm.RunString(script)
+		return err
+	}
+	program, err := compileWithSafePoints(script, safePoint)
+	if err != nil {
+		return err
+	}
+	_, err = config.vm.RunProgram(program)
 	return err
 }
 
@@ -132,8 +167,27 @@ func executeFn(config *runConfig, fn jsAction) (err error) {
 	return nil
 }
 
+// DefaultModules returns the options of the modules provided to the actions of all flows,
+// the zitadel/query module is only provided if the organization of the action is known
+func DefaultModules(ctx context.Context, queries Queries, orgID string) []Option {
+	opts := []Option{
+		WithHTTP(ctx),
+		WithUUID(ctx),
+		WithCrypto(),
+		WithEncoding(),
+		WithJWT(),
+	}
+	if queries != nil && orgID != "" {
+		opts = append(opts, WithQuery(ctx, queries, orgID))
+	}
+	return opts
+}
+
 func ActionToOptions(a *query.Action) []Option {
-	opts := make([]Option, 0, 1)
+	opts := make([]Option, 0, 2)
+	if a.Version > 0 {
+		opts = append(opts, WithVersion(a.ID, a.Version, a.Rollout))
+	}
 	if a.AllowedToFail {
 		opts = append(opts, WithAllowedToFail())
 	}
@@ -21,8 +21,21 @@ func WithAllowedToFail() Option {
 	}
 }
 
+// WithVersion identifies the executed version of the action in the logs
+// and its rollout (current, canary or pinned) in the metrics
+func WithVersion(actionID string, version uint64, rollout string) Option {
+	return func(c *runConfig) {
+		c.actionID = actionID
+		c.version = version
+		c.rollout = rollout
+	}
+}
+
 type runConfig struct {
 	allowedToFail bool
+	actionID      string
+	version       uint64
+	rollout       string
 	functionTimeout,
 	scriptTimeout time.Duration
 	modules    map[string]require.ModuleLoader
@@ -31,6 +44,11 @@ type runConfig struct {
 	vm         *goja.Runtime
 	ctxParam   *ctxConfig
 	apiParam   *apiConfig
+	recorder   *Recorder
+	limits     *runLimits
+	// deadline is the end of the current execution of the script or the function,
+	// the modules must not run beyond it
+	deadline time.Time
 }
 
 func newRunConfig(ctx context.Context, opts ...Option) *runConfig {
@@ -47,6 +65,7 @@ func newRunConfig(ctx context.Context, opts ...Option) *runConfig {
 		scriptTimeout:   maxPrepareTimeout,
 		modules:         map[string]require.ModuleLoader{},
 		vm:              vm,
+		limits:          new(runLimits),
 		ctxParam: &ctxConfig{
 			FieldConfig: FieldConfig{
 				Runtime: vm,
@@ -74,6 +93,7 @@ func newRunConfig(ctx context.Context, opts ...Option) *runConfig {
 
 func (c *runConfig) StartFunction() *time.Timer {
 	c.vm.ClearInterrupt()
+	c.deadline = time.Now().Add(c.functionTimeout)
 	return time.AfterFunc(c.functionTimeout, func() {
 		c.vm.Interrupt(ErrHalt)
 	})
@@ -81,6 +101,7 @@ func (c *runConfig) StartFunction() *time.Timer {
 
 func (c *runConfig) StartScript() *time.Timer {
 	c.vm.ClearInterrupt()
+	c.deadline = time.Now().Add(c.scriptTimeout)
 	return time.AfterFunc(c.scriptTimeout, func() {
 		c.vm.Interrupt(ErrHalt)
 	})
@@ -99,3 +120,17 @@ func (c *runConfig) cutTimeouts(remainingSeconds *uint64) {
 		c.scriptTimeout = remainingDur
 	}
 }
+
+// moduleContext returns the context for a call of a module.
+// The call is halted if the deadline of the current execution is already reached,
+// otherwise the returned context is done at the deadline.
+// As the timeouts are cut to the remaining execution quota, the calls respect the quota as well.
+func (c *runConfig) moduleContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.deadline.IsZero() {
+		return context.WithCancel(ctx)
+	}
+	if !time.Now().Before(c.deadline) {
+		panic(ErrHalt)
+	}
+	return context.WithDeadline(ctx, c.deadline)
+}
@@ -0,0 +1,103 @@
+package actions
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"hash"
+
+	"github.com/dop251/goja"
+	"github.com/zitadel/logging"
+
+	z_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+const maxRandomBytes = 1024
+
+var hashAlgorithms = map[string]func() hash.Hash{
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha384": sha512.New384,
+	"sha512": sha512.New,
+}
+
+func WithCrypto() Option {
+	return func(c *runConfig) {
+		c.modules["zitadel/crypto"] = func(runtime *goja.Runtime, module *goja.Object) {
+			requireCrypto(module)
+		}
+	}
+}
+
+type cryptoModule struct{}
+
+func requireCrypto(module *goja.Object) {
+	m := new(cryptoModule)
+	o := module.Get("exports").(*goja.Object)
+	logging.OnError(o.Set("hash", m.hash)).Warn("unable to set module")
+	logging.OnError(o.Set("hmac", m.hmac)).Warn("unable to set module")
+	logging.OnError(o.Set("timingSafeEqual", m.timingSafeEqual)).Warn("unable to set module")
+	logging.OnError(o.Set("randomBytes", m.randomBytes)).Warn("unable to set module")
+}
+
+// hash returns the digest of the data,
+// the optional encoding of the digest is hex (default), base64 or base64url
+func (m *cryptoModule) hash(algorithm, data string, encoding ...string) (string, error) {
+	newHash, err := hashAlgorithm(algorithm)
+	if err != nil {
+		return "", err
+	}
+	h := newHash()
+	h.Write([]byte(data))
+	return encodeBytes(h.Sum(nil), optionalArg(encoding))
+}
+
+// hmac returns the message authentication code of the data signed with the key,
+// the optional encoding of the code is hex (default), base64 or base64url
+func (m *cryptoModule) hmac(algorithm, key, data string, encoding ...string) (string, error) {
+	newHash, err := hashAlgorithm(algorithm)
+	if err != nil {
+		return "", err
+	}
+	mac := hmac.New(newHash, []byte(key))
+	mac.Write([]byte(data))
+	return encodeBytes(mac.Sum(nil), optionalArg(encoding))
+}
+
+// timingSafeEqual compares the strings in constant time,
+// e.g. to check a signature of a webhook
+func (m *cryptoModule) timingSafeEqual(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// randomBytes returns length random bytes,
+// the optional encoding of the bytes is hex (default), base64 or base64url
+func (m *cryptoModule) randomBytes(length int, encoding ...string) (string, error) {
+	if length <= 0 || length > maxRandomBytes {
+		return "", z_errs.ThrowInvalidArgument(nil, "ACTIO-Lw2rd", "length must be between 1 and 1024")
+	}
+	data := make([]byte, length)
+	if _, err := rand.Read(data); err != nil {
+		return "", err
+	}
+	return encodeBytes(data, optionalArg(encoding))
+}
+
+func hashAlgorithm(algorithm string) (func() hash.Hash, error) {
+	newHash, ok := hashAlgorithms[algorithm]
+	if !ok {
+		return nil, z_errs.ThrowInvalidArgument(nil, "ACTIO-Vn3sk", "algorithm is invalid")
+	}
+	return newHash, nil
+}
+
+// optionalArg returns the first argument of a variadic parameter or the zero value
+func optionalArg[T any](args []T) (arg T) {
+	if len(args) == 0 {
+		return arg
+	}
+	return args[0]
+}
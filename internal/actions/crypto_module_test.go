@@ -0,0 +1,136 @@
+package actions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/logstore"
+	"github.com/zitadel/zitadel/internal/logstore/record"
+)
+
+// runModuleScript runs the body of a function, the result is passed to api.setResult
+func runModuleScript(t *testing.T, body string, opts ...Option) (result interface{}, err error) {
+	t.Helper()
+	SetLogstoreService(logstore.New[*record.ExecutionLog](nil, nil))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	api := WithAPIFields(SetFields("setResult", func(value interface{}) {
+		result = value
+	}))
+	err = Run(ctx, nil, api, "function testFunc(ctx, api) {\n"+body+"\n}", "testFunc", opts...)
+	return result, err
+}
+
+func TestCryptoModule(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    interface{}
+		wantErr bool
+	}{
+		{
+			name: "hash hex",
+			body: `api.setResult(require("zitadel/crypto").hash("sha256", "abc"))`,
+			want: "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad",
+		},
+		{
+			name: "hash base64",
+			body: `api.setResult(require("zitadel/crypto").hash("sha1", "abc", "base64"))`,
+			want: "qZk+NkcGgWq6PiVxeFDCbJzQ2J0=",
+		},
+		{
+			name:    "hash unknown algorithm",
+			body:    `require("zitadel/crypto").hash("md5", "abc")`,
+			wantErr: true,
+		},
+		{
+			name: "hmac",
+			body: `api.setResult(require("zitadel/crypto").hmac("sha256", "key", "The quick brown fox jumps over the lazy dog"))`,
+			want: "f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8",
+		},
+		{
+			name: "hmac unknown encoding",
+			body: `try {
+	require("zitadel/crypto").hmac("sha256", "key", "data", "base32");
+} catch (e) {
+	api.setResult("caught");
+}`,
+			want: "caught",
+		},
+		{
+			name: "timing safe equal",
+			body: `let crypto = require("zitadel/crypto");
+api.setResult(crypto.timingSafeEqual("abc", "abc") && !crypto.timingSafeEqual("abc", "abd"))`,
+			want: true,
+		},
+		{
+			name: "random bytes",
+			body: `api.setResult(require("zitadel/crypto").randomBytes(16).length)`,
+			want: int64(32),
+		},
+		{
+			name:    "random bytes too long",
+			body:    `require("zitadel/crypto").randomBytes(1025)`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := runModuleScript(t, tt.body, WithCrypto())
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEncodingModule(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    interface{}
+		wantErr bool
+	}{
+		{
+			name: "base64",
+			body: `let encoding = require("zitadel/encoding");
+api.setResult(encoding.base64.encode("zitadel?>") + " " + encoding.base64.decode("eml0YWRlbD8+"))`,
+			want: "eml0YWRlbD8+ zitadel?>",
+		},
+		{
+			name: "base64url",
+			body: `let encoding = require("zitadel/encoding");
+api.setResult(encoding.base64url.encode("zitadel?>") + " " + encoding.base64url.decode("eml0YWRlbD8-") + " " + encoding.base64url.decode("YQ=="))`,
+			want: "eml0YWRlbD8- zitadel?> a",
+		},
+		{
+			name: "hex",
+			body: `let encoding = require("zitadel/encoding");
+api.setResult(encoding.hex.encode("zitadel") + " " + encoding.hex.decode("7a69746164656c"))`,
+			want: "7a69746164656c zitadel",
+		},
+		{
+			name:    "invalid input",
+			body:    `require("zitadel/encoding").hex.decode("xyz")`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := runModuleScript(t, tt.body, WithEncoding())
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
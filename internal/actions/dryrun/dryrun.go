@@ -0,0 +1,296 @@
+// Package dryrun runs action scripts against a flow and trigger without side effects.
+// The api functions of the trigger record the calls as mutations instead of applying them,
+// the logs and http calls are recorded by an [actions.Recorder].
+package dryrun
+
+import (
+	"context"
+	"time"
+
+	"github.com/dop251/goja"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/actions/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+const maxTimeout = 20 * time.Second
+
+// Queries are the queries needed to provide the context of an existing user
+// and the queries of the zitadel/query module
+type Queries interface {
+	GetUserByID(ctx context.Context, shouldTriggerBulk bool, userID string, queries ...query.SearchQuery) (*query.User, error)
+	UserGrants(ctx context.Context, queries *query.UserGrantsQueries, shouldTriggerBulk, withOwnerRemoved bool) (*query.UserGrants, error)
+	actions.Queries
+}
+
+type Request struct {
+	Script string
+	// FunctionName is the function called in the script, which is the name of the action
+	FunctionName  string
+	Timeout       time.Duration
+	AllowedToFail bool
+	FlowType      domain.FlowType
+	TriggerType   domain.TriggerType
+	// LiveHTTP sends the http calls of the action,
+	// otherwise they are answered with an empty json object
+	LiveHTTP bool
+	// ResourceOwner restricts the existing user of the context to the organization
+	ResourceOwner string
+	Context       *Context
+}
+
+// Context is the synthetic or recorded context passed to the action
+type Context struct {
+	// UserID of an existing user,
+	// the user, its metadata and grants are queried and passed to the action
+	UserID string
+	// User is a synthetic user used if no UserID is set
+	User *query.User
+	// ExternalUser is the synthetic user of the identity provider of the external authentication flow
+	ExternalUser *domain.ExternalUser
+	ProviderInfo map[string]interface{}
+	// Claims are passed to the token flows respectively as claims of the identity provider
+	Claims map[string]interface{}
+	// AuthRequest and HTTPRequest are passed as they are
+	AuthRequest map[string]interface{}
+	HTTPRequest map[string]interface{}
+	AuthMethod  string
+	AuthError   string
+}
+
+// Mutation is the call of an api function of the trigger
+type Mutation struct {
+	// Function is the path of the function on the api parameter, e.g. v1.user.appendMetadata
+	Function  string
+	Arguments []interface{}
+}
+
+type Result struct {
+	Mutations []*Mutation
+	Logs      []*actions.RecordedLog
+	HTTPCalls []*actions.RecordedHTTPCall
+	Took      time.Duration
+	// Err is the error of the action run,
+	// it's nil if the action succeeded or is allowed to fail
+	Err error
+}
+
+// Run runs the script of the request for the flow and trigger.
+// An error is only returned if the request is invalid or the user could not be queried,
+// the error of the script is returned in the [Result].
+func Run(ctx context.Context, queries Queries, req *Request) (*Result, error) {
+	if !req.FlowType.Valid() {
+		return nil, errors.ThrowInvalidArgument(nil, "ACTIO-Ghe2q", "Errors.Flow.FlowTypeMissing")
+	}
+	if !req.FlowType.HasTrigger(req.TriggerType) {
+		return nil, errors.ThrowInvalidArgument(nil, "ACTIO-Tk4ow", "Errors.Flow.WrongTriggerType")
+	}
+	if req.Script == "" || req.FunctionName == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "ACTIO-M2xsd", "Errors.Action.Invalid")
+	}
+	if req.Context == nil {
+		req.Context = new(Context)
+	}
+	user, err := contextUser(ctx, queries, req.ResourceOwner, req.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	run := &dryRun{
+		queries:  queries,
+		request:  req,
+		user:     user,
+		recorder: actions.NewRecorder(req.LiveHTTP),
+	}
+	ctxFields, apiFields := run.fields(ctx)
+
+	timeout := req.Timeout
+	if timeout <= 0 || timeout > maxTimeout {
+		timeout = maxTimeout
+	}
+	actionCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	opts := append(
+		actions.DefaultModules(actionCtx, queries, req.ResourceOwner),
+		actions.WithRecorder(run.recorder),
+	)
+	if req.AllowedToFail {
+		opts = append(opts, actions.WithAllowedToFail())
+	}
+	start := time.Now()
+	err = actions.Run(
+		actionCtx,
+		actions.SetContextFields(ctxFields...),
+		actions.WithAPIFields(apiFields...),
+		req.Script,
+		req.FunctionName,
+		opts...,
+	)
+	took := time.Since(start)
+	run.recordArrays()
+
+	return &Result{
+		Mutations: run.mutations,
+		Logs:      run.recorder.Logs(),
+		HTTPCalls: run.recorder.HTTPCalls(),
+		Took:      took,
+		Err:       err,
+	}, nil
+}
+
+func contextUser(ctx context.Context, queries Queries, resourceOwner string, actionCtx *Context) (*query.User, error) {
+	if actionCtx.UserID != "" {
+		if resourceOwner == "" {
+			return queries.GetUserByID(ctx, true, actionCtx.UserID)
+		}
+		resourceOwnerQuery, err := query.NewUserResourceOwnerSearchQuery(resourceOwner, query.TextEquals)
+		if err != nil {
+			return nil, err
+		}
+		return queries.GetUserByID(ctx, true, actionCtx.UserID, resourceOwnerQuery)
+	}
+	if actionCtx.User != nil {
+		return actionCtx.User, nil
+	}
+	return &query.User{
+		Type:  domain.UserTypeHuman,
+		Human: new(query.Human),
+	}, nil
+}
+
+type dryRun struct {
+	queries   Queries
+	request   *Request
+	user      *query.User
+	recorder  *actions.Recorder
+	mutations []*Mutation
+	// arrays are the mutable arrays of the api (e.g. metadata),
+	// their content is recorded after the run
+	arrays map[string]*goja.Object
+}
+
+// record returns an api function which records its call as mutation
+func (r *dryRun) record(function string) func(c *actions.FieldConfig) interface{} {
+	return func(c *actions.FieldConfig) interface{} {
+		return func(call goja.FunctionCall) goja.Value {
+			args := make([]interface{}, len(call.Arguments))
+			for i, arg := range call.Arguments {
+				args[i] = arg.Export()
+			}
+			r.mutations = append(r.mutations, &Mutation{
+				Function:  function,
+				Arguments: args,
+			})
+			return nil
+		}
+	}
+}
+
+// array returns a mutable array of the api, which is recorded after the run if not empty
+func (r *dryRun) array(name string) func(c *actions.FieldConfig) interface{} {
+	return func(c *actions.FieldConfig) interface{} {
+		if r.arrays == nil {
+			r.arrays = make(map[string]*goja.Object)
+		}
+		array := c.Runtime.NewArray()
+		r.arrays[name] = array
+		return array
+	}
+}
+
+func (r *dryRun) recordArrays() {
+	for _, name := range []string{"metadata", "userGrants"} {
+		array, ok := r.arrays[name]
+		if !ok {
+			continue
+		}
+		entries, ok := array.Export().([]interface{})
+		if !ok || len(entries) == 0 {
+			continue
+		}
+		r.mutations = append(r.mutations, &Mutation{
+			Function:  name,
+			Arguments: entries,
+		})
+	}
+}
+
+func (r *dryRun) fields(ctx context.Context) (ctxFields []actions.FieldOption, apiFields []actions.FieldOption) {
+	actionCtx := r.request.Context
+	switch r.request.TriggerType {
+	case domain.TriggerTypePostAuthentication:
+		if r.request.FlowType == domain.FlowTypeExternalAuthentication {
+			return r.externalAuthenticationCtxFields(), append(r.recordAll(externalUserSetters...), r.metadataAPIFields()...)
+		}
+		return []actions.FieldOption{
+			actions.SetFields("v1",
+				actions.SetFields("authMethod", actionCtx.AuthMethod),
+				actions.SetFields("authError", authError(actionCtx.AuthError)),
+				actions.SetFields("authRequest", actionCtx.AuthRequest),
+				actions.SetFields("httpRequest", actionCtx.HTTPRequest),
+			),
+		}, r.metadataAPIFields()
+	case domain.TriggerTypePreCreation:
+		return r.humanCtxFields(), append(r.recordAll(humanSetters...), r.metadataAPIFields()...)
+	case domain.TriggerTypePreRegistrationValidation:
+		return r.humanCtxFields(), r.recordAll("v1.reject")
+	case domain.TriggerTypePostCreation:
+		return []actions.FieldOption{
+			actions.SetFields("v1",
+				actions.SetFields("getUser", r.getUser),
+				actions.SetFields("authRequest", actionCtx.AuthRequest),
+				actions.SetFields("httpRequest", actionCtx.HTTPRequest),
+			),
+		}, []actions.FieldOption{
+			actions.SetFields("userGrants", r.array("userGrants")),
+			actions.SetFields("v1",
+				actions.SetFields("appendUserGrant", r.record("v1.appendUserGrant")),
+			),
+		}
+	case domain.TriggerTypePreUserinfoCreation:
+		return r.tokenCtxFields(ctx), r.recordAll("v1.userinfo.setClaim", "v1.userinfo.appendLogIntoClaims", "v1.claims.setClaim", "v1.claims.appendLogIntoClaims", "v1.user.setMetadata")
+	case domain.TriggerTypePreAccessTokenCreation:
+		return r.tokenCtxFields(ctx), r.recordAll("v1.claims.setClaim", "v1.claims.appendLogIntoClaims", "v1.user.setMetadata")
+	case domain.TriggerTypePreSAMLResponseCreation:
+		return r.tokenCtxFields(ctx), r.recordAll("v1.attributes.setCustomAttribute", "v1.user.setMetadata")
+	case domain.TriggerTypePrePasswordReset:
+		return []actions.FieldOption{
+			actions.SetFields("v1",
+				actions.SetFields("getUser", r.getUser),
+				actions.SetFields("passwordReset", object.PasswordResetField(domain.NotificationTypeEmail, false)),
+				actions.SetFields("authRequest", nil),
+				actions.SetFields("httpRequest", nil),
+			),
+		}, r.recordAll("v1.reject")
+	case domain.TriggerTypePostPasswordReset:
+		return []actions.FieldOption{
+			actions.SetFields("v1",
+				actions.SetFields("getUser", r.getUser),
+				actions.SetFields("authRequest", nil),
+				actions.SetFields("httpRequest", nil),
+			),
+		}, nil
+	case domain.TriggerTypePostLogout:
+		return []actions.FieldOption{
+			actions.SetFields("v1",
+				actions.SetFields("getUser", r.getUser),
+				actions.SetFields("logout", object.LogoutField("", "", []string{r.user.ID})),
+				actions.SetFields("authRequest", nil),
+				actions.SetFields("httpRequest", nil),
+			),
+		}, nil
+	case domain.TriggerTypePreRefreshTokenCreation:
+		return []actions.FieldOption{
+			actions.SetFields("v1",
+				actions.SetFields("getUser", r.getUser),
+				actions.SetFields("refreshToken", object.RefreshTokenField("", nil, nil, nil, time.Time{})),
+			),
+		}, r.recordAll("v1.reject")
+	default:
+		return nil, nil
+	}
+}
@@ -0,0 +1,178 @@
+package dryrun
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/logstore"
+	"github.com/zitadel/zitadel/internal/logstore/record"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+type mockQueries struct {
+	user *query.User
+}
+
+func (m *mockQueries) GetUserByID(_ context.Context, _ bool, userID string, _ ...query.SearchQuery) (*query.User, error) {
+	if m.user == nil || m.user.ID != userID {
+		return nil, caos_errs.ThrowNotFound(nil, "TEST-Sfg3q", "Errors.User.NotFound")
+	}
+	return m.user, nil
+}
+
+func (m *mockQueries) SearchUserMetadata(context.Context, bool, string, *query.UserMetadataSearchQueries, bool) (*query.UserMetadataList, error) {
+	return &query.UserMetadataList{
+		SearchResponse: emptySearchResponse(),
+		Metadata:       []*query.UserMetadata{{Key: "key", Value: []byte(`"value"`)}},
+	}, nil
+}
+
+func (m *mockQueries) SearchOrgMetadata(context.Context, bool, string, *query.OrgMetadataSearchQueries, bool) (*query.OrgMetadataList, error) {
+	return &query.OrgMetadataList{SearchResponse: emptySearchResponse()}, nil
+}
+
+func (m *mockQueries) UserGrants(context.Context, *query.UserGrantsQueries, bool, bool) (*query.UserGrants, error) {
+	return &query.UserGrants{SearchResponse: emptySearchResponse()}, nil
+}
+
+func TestRun(t *testing.T) {
+	actions.SetLogstoreService(logstore.New[*record.ExecutionLog](nil, nil))
+	queries := &mockQueries{
+		user: &query.User{
+			ID:       "user1",
+			Username: "username",
+			Type:     domain.UserTypeHuman,
+			Human:    &query.Human{FirstName: "first"},
+		},
+	}
+	tests := []struct {
+		name          string
+		req           *Request
+		wantErr       error
+		wantMutations []*Mutation
+		wantRunErr    bool
+		wantHTTPCalls int
+	}{
+		{
+			name: "wrong trigger",
+			req: &Request{
+				Script:       "function action(ctx, api) {}",
+				FunctionName: "action",
+				FlowType:     domain.FlowTypeCustomiseToken,
+				TriggerType:  domain.TriggerTypePreCreation,
+			},
+			wantErr: caos_errs.ThrowInvalidArgument(nil, "ACTIO-Tk4ow", "Errors.Flow.WrongTriggerType"),
+		},
+		{
+			name: "user not found",
+			req: &Request{
+				Script:       "function action(ctx, api) {}",
+				FunctionName: "action",
+				FlowType:     domain.FlowTypeInternalAuthentication,
+				TriggerType:  domain.TriggerTypePostCreation,
+				Context:      &Context{UserID: "unknown"},
+			},
+			wantErr: caos_errs.ThrowNotFound(nil, "TEST-Sfg3q", "Errors.User.NotFound"),
+		},
+		{
+			name: "pre creation of synthetic user",
+			req: &Request{
+				Script: `function action(ctx, api) {
+	api.setFirstName(ctx.v1.user.human.firstName + "!");
+	api.v1.user.appendMetadata("key", {nested: true});
+	api.metadata.push({key: "deprecated", value: "dmFsdWU="});
+}`,
+				FunctionName: "action",
+				FlowType:     domain.FlowTypeInternalAuthentication,
+				TriggerType:  domain.TriggerTypePreCreation,
+				Context: &Context{
+					User: &query.User{Type: domain.UserTypeHuman, Human: &query.Human{FirstName: "synthetic"}},
+				},
+			},
+			wantMutations: []*Mutation{
+				{Function: "setFirstName", Arguments: []interface{}{"synthetic!"}},
+				{Function: "v1.user.appendMetadata", Arguments: []interface{}{"key", map[string]interface{}{"nested": true}}},
+				{Function: "metadata", Arguments: []interface{}{map[string]interface{}{"key": "deprecated", "value": "dmFsdWU="}}},
+			},
+		},
+		{
+			name: "access token of existing user",
+			req: &Request{
+				Script: `function action(ctx, api) {
+	let md = ctx.v1.user.getMetadata().metadata[0];
+	api.v1.claims.setClaim(ctx.v1.getUser().human.firstName, md.value);
+}`,
+				FunctionName: "action",
+				FlowType:     domain.FlowTypeCustomiseToken,
+				TriggerType:  domain.TriggerTypePreAccessTokenCreation,
+				Context:      &Context{UserID: "user1"},
+			},
+			wantMutations: []*Mutation{
+				{Function: "v1.claims.setClaim", Arguments: []interface{}{"first", "value"}},
+			},
+		},
+		{
+			name: "reject registration with stubbed http call",
+			req: &Request{
+				Script: `function action(ctx, api) {
+	let http = require("zitadel/http");
+	if (http.fetch("https://zitadel.com/blocked").status == 200) {
+		api.v1.reject("blocked");
+	}
+}`,
+				FunctionName: "action",
+				FlowType:     domain.FlowTypeExternalAuthentication,
+				TriggerType:  domain.TriggerTypePreRegistrationValidation,
+			},
+			wantMutations: []*Mutation{
+				{Function: "v1.reject", Arguments: []interface{}{"blocked"}},
+			},
+			wantHTTPCalls: 1,
+		},
+		{
+			name: "failing script",
+			req: &Request{
+				Script:       `function action(ctx, api) { throw "failed"; }`,
+				FunctionName: "action",
+				FlowType:     domain.FlowTypeLogout,
+				TriggerType:  domain.TriggerTypePostLogout,
+			},
+			wantRunErr: true,
+		},
+		{
+			name: "failing script allowed to fail",
+			req: &Request{
+				Script:        `function action(ctx, api) { throw "failed"; }`,
+				FunctionName:  "action",
+				AllowedToFail: true,
+				FlowType:      domain.FlowTypeLogout,
+				TriggerType:   domain.TriggerTypePostLogout,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Run(context.Background(), queries, tt.req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantMutations, got.Mutations)
+			assert.Equal(t, tt.wantRunErr, got.Err != nil)
+			assert.Len(t, got.HTTPCalls, tt.wantHTTPCalls)
+			for _, call := range got.HTTPCalls {
+				assert.True(t, call.Stubbed)
+			}
+			// at least the start and the end of the run are logged
+			assert.GreaterOrEqual(t, len(got.Logs), 2)
+			assert.Greater(t, got.Took, int64(0))
+		})
+	}
+}
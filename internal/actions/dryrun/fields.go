@@ -0,0 +1,214 @@
+package dryrun
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/dop251/goja"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/actions/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+var (
+	// humanSetters are the functions of the pre creation trigger changing the user
+	humanSetters = []string{
+		"setFirstName",
+		"setLastName",
+		"setNickName",
+		"setDisplayName",
+		"setPreferredLanguage",
+		"setGender",
+		"setUsername",
+		"setEmail",
+		"setEmailVerified",
+		"setPhone",
+		"setPhoneVerified",
+	}
+	// externalUserSetters are the functions of the post authentication trigger changing the external user
+	externalUserSetters = []string{
+		"setFirstName",
+		"setLastName",
+		"setNickName",
+		"setDisplayName",
+		"setPreferredLanguage",
+		"setPreferredUsername",
+		"setEmail",
+		"setEmailVerified",
+		"setPhone",
+		"setPhoneVerified",
+	}
+)
+
+// recordAll returns the fields recording the calls of the functions,
+// the path of a function is separated by dots, e.g. v1.user.appendMetadata
+func (r *dryRun) recordAll(functions ...string) []actions.FieldOption {
+	options := make([]actions.FieldOption, len(functions))
+	for i, function := range functions {
+		path := strings.Split(function, ".")
+		option := actions.SetFields(path[len(path)-1], r.record(function))
+		for j := len(path) - 2; j >= 0; j-- {
+			option = actions.SetFields(path[j], option)
+		}
+		options[i] = option
+	}
+	return options
+}
+
+func (r *dryRun) metadataAPIFields() []actions.FieldOption {
+	return append(
+		r.recordAll("v1.user.appendMetadata"),
+		actions.SetFields("metadata", r.array("metadata")),
+	)
+}
+
+func (r *dryRun) getUser(c *actions.FieldConfig) interface{} {
+	return func(call goja.FunctionCall) goja.Value {
+		return object.UserFromQuery(c, r.user)
+	}
+}
+
+func (r *dryRun) humanCtxFields() []actions.FieldOption {
+	return []actions.FieldOption{
+		actions.SetFields("v1",
+			actions.SetFields("user", func(c *actions.FieldConfig) interface{} {
+				return object.UserFromHuman(c, humanFromQuery(r.user))
+			}),
+			actions.SetFields("authRequest", r.request.Context.AuthRequest),
+			actions.SetFields("httpRequest", r.request.Context.HTTPRequest),
+		),
+	}
+}
+
+func (r *dryRun) externalAuthenticationCtxFields() []actions.FieldOption {
+	actionCtx := r.request.Context
+	externalUser := actionCtx.ExternalUser
+	if externalUser == nil {
+		externalUser = new(domain.ExternalUser)
+	}
+	return []actions.FieldOption{
+		actions.SetFields("accessToken", ""),
+		actions.SetFields("idToken", ""),
+		actions.SetFields("getClaim", func(claim string) interface{} {
+			return actionCtx.Claims[claim]
+		}),
+		actions.SetFields("claimsJSON", func() (string, error) {
+			c, err := json.Marshal(actionCtx.Claims)
+			if err != nil {
+				return "", err
+			}
+			return string(c), nil
+		}),
+		actions.SetFields("v1",
+			actions.SetFields("externalUser", func(c *actions.FieldConfig) interface{} {
+				return object.UserFromExternalUser(c, externalUser)
+			}),
+			actions.SetFields("providerInfo", func(c *actions.FieldConfig) interface{} {
+				return c.Runtime.ToValue(actionCtx.ProviderInfo)
+			}),
+			actions.SetFields("authRequest", actionCtx.AuthRequest),
+			actions.SetFields("httpRequest", actionCtx.HTTPRequest),
+			actions.SetFields("authError", authError(actionCtx.AuthError)),
+		),
+	}
+}
+
+// tokenCtxFields are the fields of the complement token and the customize SAML response flow,
+// the metadata and grants are only queried for an existing user
+func (r *dryRun) tokenCtxFields(ctx context.Context) []actions.FieldOption {
+	claims := r.request.Context.Claims
+	if claims == nil {
+		claims = make(map[string]interface{})
+	}
+	return []actions.FieldOption{
+		actions.SetFields("v1",
+			actions.SetFields("claims", func(c *actions.FieldConfig) interface{} {
+				return c.Runtime.ToValue(claims)
+			}),
+			actions.SetFields("getUser", r.getUser),
+			actions.SetFields("user",
+				actions.SetFields("getMetadata", func(c *actions.FieldConfig) interface{} {
+					return func(goja.FunctionCall) goja.Value {
+						metadata := &query.UserMetadataList{SearchResponse: emptySearchResponse()}
+						if r.request.Context.UserID != "" {
+							var err error
+							metadata, err = r.queries.SearchUserMetadata(ctx, true, r.user.ID, &query.UserMetadataSearchQueries{}, false)
+							if err != nil {
+								panic(err)
+							}
+						}
+						return object.UserMetadataListFromQuery(c, metadata)
+					}
+				}),
+				actions.SetFields("grants", func(c *actions.FieldConfig) interface{} {
+					grants, err := r.userGrants(ctx)
+					if err != nil {
+						panic(err)
+					}
+					return object.UserGrantsFromQuery(c, grants)
+				}),
+			),
+		),
+	}
+}
+
+func (r *dryRun) userGrants(ctx context.Context) (*query.UserGrants, error) {
+	if r.request.Context.UserID == "" {
+		return &query.UserGrants{SearchResponse: emptySearchResponse()}, nil
+	}
+	userIDQuery, err := query.NewUserGrantUserIDSearchQuery(r.user.ID)
+	if err != nil {
+		return nil, err
+	}
+	return r.queries.UserGrants(ctx, &query.UserGrantsQueries{Queries: []query.SearchQuery{userIDQuery}}, true, false)
+}
+
+// emptySearchResponse is the response of the queries for a synthetic user
+func emptySearchResponse() query.SearchResponse {
+	return query.SearchResponse{State: new(query.State)}
+}
+
+func authError(err string) string {
+	if err == "" {
+		return "none"
+	}
+	return err
+}
+
+func humanFromQuery(user *query.User) *domain.Human {
+	human := &domain.Human{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID:   user.ID,
+			ResourceOwner: user.ResourceOwner,
+			Sequence:      user.Sequence,
+			CreationDate:  user.CreationDate,
+			ChangeDate:    user.ChangeDate,
+		},
+		Username: user.Username,
+		State:    user.State,
+	}
+	if user.Human == nil {
+		return human
+	}
+	human.Profile = &domain.Profile{
+		FirstName:         user.Human.FirstName,
+		LastName:          user.Human.LastName,
+		NickName:          user.Human.NickName,
+		DisplayName:       user.Human.DisplayName,
+		PreferredLanguage: user.Human.PreferredLanguage,
+		Gender:            user.Human.Gender,
+	}
+	human.Email = &domain.Email{
+		EmailAddress:    user.Human.Email,
+		IsEmailVerified: user.Human.IsEmailVerified,
+	}
+	human.Phone = &domain.Phone{
+		PhoneNumber:     user.Human.Phone,
+		IsPhoneVerified: user.Human.IsPhoneVerified,
+	}
+	return human
+}
@@ -0,0 +1,79 @@
+package actions
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+
+	"github.com/dop251/goja"
+	"github.com/zitadel/logging"
+
+	z_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+func WithEncoding() Option {
+	return func(c *runConfig) {
+		c.modules["zitadel/encoding"] = func(runtime *goja.Runtime, module *goja.Object) {
+			requireEncoding(runtime, module)
+		}
+	}
+}
+
+type codec struct {
+	encode func([]byte) string
+	decode func(string) ([]byte, error)
+}
+
+var codecs = map[string]codec{
+	"base64":    {encode: base64.StdEncoding.EncodeToString, decode: base64.StdEncoding.DecodeString},
+	"base64url": {encode: base64.RawURLEncoding.EncodeToString, decode: decodeBase64URL},
+	"hex":       {encode: hex.EncodeToString, decode: hex.DecodeString},
+}
+
+// decodeBase64URL accepts padded and unpadded input
+func decodeBase64URL(s string) ([]byte, error) {
+	if len(s)%4 != 0 {
+		return base64.RawURLEncoding.DecodeString(s)
+	}
+	return base64.URLEncoding.DecodeString(s)
+}
+
+// requireEncoding provides an object with encode and decode for each codec,
+// e.g. `require("zitadel/encoding").base64.encode("text")`
+func requireEncoding(runtime *goja.Runtime, module *goja.Object) {
+	o := module.Get("exports").(*goja.Object)
+	for name, codec := range codecs {
+		codecObject := runtime.NewObject()
+		logging.OnError(codecObject.Set("encode", encodeFunc(codec))).Warn("unable to set module")
+		logging.OnError(codecObject.Set("decode", decodeFunc(codec))).Warn("unable to set module")
+		logging.OnError(o.Set(name, codecObject)).Warn("unable to set module")
+	}
+}
+
+func encodeFunc(codec codec) func(data string) string {
+	return func(data string) string {
+		return codec.encode([]byte(data))
+	}
+}
+
+// decodeFunc throws an exception in the script if the data is invalid
+func decodeFunc(codec codec) func(data string) (string, error) {
+	return func(data string) (string, error) {
+		decoded, err := codec.decode(data)
+		if err != nil {
+			return "", z_errs.ThrowInvalidArgument(err, "ACTIO-Fk2ds", "unable to decode")
+		}
+		return string(decoded), nil
+	}
+}
+
+// encodeBytes encodes the bytes with the named codec, hex is used if no name is passed
+func encodeBytes(data []byte, name string) (string, error) {
+	if name == "" {
+		name = "hex"
+	}
+	codec, ok := codecs[name]
+	if !ok {
+		return "", z_errs.ThrowInvalidArgument(nil, "ACTIO-Ms9ew", "encoding is invalid")
+	}
+	return codec.encode(data), nil
+}
@@ -0,0 +1,62 @@
+package actions
+
+import (
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	z_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+// DenyListTransport returns a transport which refuses requests to the denied hosts of the [HTTPConfig].
+// Host names resolving to denied addresses are refused on dial.
+// It's used for the calls to endpoints configured by users outside of actions, e.g. execution targets or event sinks.
+func DenyListTransport() http.RoundTripper {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = DenyListDialer(&net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}).DialContext
+	return &denyListTransport{base: transport}
+}
+
+type denyListTransport struct {
+	base http.RoundTripper
+}
+
+func (t *denyListTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if IsHostDenied(req.URL.Hostname()) {
+		return nil, z_errs.ThrowInvalidArgument(nil, "ACTIO-Gai5u", "host is denied")
+	}
+	return t.base.RoundTrip(req)
+}
+
+// DenyListDialer refuses connections to the denied addresses of the [HTTPConfig],
+// the address is checked after the host name is resolved
+func DenyListDialer(dialer *net.Dialer) *net.Dialer {
+	dialer.Control = func(_, address string, _ syscall.RawConn) error {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return err
+		}
+		if IsHostDenied(host) {
+			return z_errs.ThrowInvalidArgument(nil, "ACTIO-Ohd4a", "address is denied")
+		}
+		return nil
+	}
+	return dialer
+}
+
+// IsHostDenied checks the host name or address against the deny list of the [HTTPConfig]
+func IsHostDenied(host string) bool {
+	if httpConfig == nil {
+		return false
+	}
+	for _, denied := range httpConfig.DenyList {
+		if denied.Matches(host) {
+			return true
+		}
+	}
+	return false
+}
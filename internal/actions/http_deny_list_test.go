@@ -0,0 +1,72 @@
+package actions
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	z_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+func TestDenyListTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	localhost, err := NewIPChecker("127.0.0.1")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		denyList []AddressChecker
+		url      string
+		denied   bool
+	}{
+		{
+			name: "no deny list",
+			url:  server.URL,
+		},
+		{
+			name:     "address denied",
+			denyList: []AddressChecker{localhost},
+			url:      server.URL,
+			denied:   true,
+		},
+		{
+			name:     "host denied",
+			denyList: []AddressChecker{&DomainChecker{Domain: "localhost"}},
+			url:      "http://localhost:" + serverURL.Port(),
+			denied:   true,
+		},
+		{
+			name:     "resolved address denied",
+			denyList: []AddressChecker{localhost},
+			url:      "http://localhost:" + serverURL.Port(),
+			denied:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetHTTPConfig(&HTTPConfig{DenyList: tt.denyList})
+			defer SetHTTPConfig(nil)
+
+			client := &http.Client{Transport: DenyListTransport()}
+			resp, err := client.Get(tt.url)
+			if tt.denied {
+				invalidArgument := new(z_errs.InvalidArgumentError)
+				assert.ErrorAs(t, err, &invalidArgument)
+				return
+			}
+			require.NoError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		})
+	}
+}
@@ -19,7 +19,11 @@ import (
 func WithHTTP(ctx context.Context) Option {
 	return func(c *runConfig) {
 		c.modules["zitadel/http"] = func(runtime *goja.Runtime, module *goja.Object) {
-			requireHTTP(ctx, &http.Client{Transport: new(transport)}, runtime, module)
+			var roundTripper http.RoundTripper = &transport{allowList: c.limits.allowList}
+			if c.recorder != nil {
+				roundTripper = &recordingTransport{recorder: c.recorder, next: roundTripper}
+			}
+			requireHTTP(ctx, &http.Client{Transport: roundTripper}, runtime, module, c.limits)
 		}
 	}
 }
@@ -27,12 +31,18 @@ func WithHTTP(ctx context.Context) Option {
 type HTTP struct {
 	runtime *goja.Runtime
 	client  *http.Client
+
+	maxCalls        uint32
+	maxResponseSize uint64
+	calls           uint32
 }
 
-func requireHTTP(ctx context.Context, client *http.Client, runtime *goja.Runtime, module *goja.Object) {
+func requireHTTP(ctx context.Context, client *http.Client, runtime *goja.Runtime, module *goja.Object, limits *runLimits) {
 	c := &HTTP{
-		client:  client,
-		runtime: runtime,
+		client:          client,
+		runtime:         runtime,
+		maxCalls:        limits.maxHTTPCalls,
+		maxResponseSize: limits.maxHTTPResponseSize,
 	}
 	o := module.Get("exports").(*goja.Object)
 	logging.OnError(o.Set("fetch", c.fetch(ctx))).Warn("unable to set module")
@@ -95,6 +105,10 @@ func (r *response) Text() goja.Value {
 
 func (c *HTTP) fetch(ctx context.Context) func(call goja.FunctionCall) goja.Value {
 	return func(call goja.FunctionCall) goja.Value {
+		if c.maxCalls > 0 && c.calls >= c.maxCalls {
+			panic(violation(SandboxLimitHTTPCalls, "more than %d calls", c.maxCalls))
+		}
+		c.calls++
 		req := c.buildHTTPRequest(ctx, call.Arguments)
 		if deadline, ok := ctx.Deadline(); ok {
 			c.client.Timeout = time.Until(deadline)
@@ -107,15 +121,30 @@ func (c *HTTP) fetch(ctx context.Context) func(call goja.FunctionCall) goja.Valu
 		}
 		defer res.Body.Close()
 
-		body, err := io.ReadAll(res.Body)
+		body, err := c.readBody(res.Body)
 		if err != nil {
 			logging.WithError(err).Warn("unable to parse body")
-			panic("unable to read response body")
+			panic(err)
 		}
 		return c.runtime.ToValue(&response{Status: res.StatusCode, Body: string(body), runtime: c.runtime})
 	}
 }
 
+// readBody reads the body up to the max response size
+func (c *HTTP) readBody(body io.Reader) ([]byte, error) {
+	if c.maxResponseSize == 0 {
+		return io.ReadAll(body)
+	}
+	content, err := io.ReadAll(io.LimitReader(body, int64(c.maxResponseSize)+1))
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(content)) > c.maxResponseSize {
+		return nil, violation(SandboxLimitHTTPResponseSize, "response body larger than %d bytes", c.maxResponseSize)
+	}
+	return content, nil
+}
+
 // the first argument has to be a string and is required
 // the second agrument is optional and an object with the following fields possible:
 // - `Headers`: map with string key and value of type string or string array
@@ -170,9 +199,16 @@ func parseHeaders(headers *goja.Object) http.Header {
 	return h
 }
 
-type transport struct{}
+// transport checks the host of the request against the allow list of the run
+// and the deny list of the system before sending it
+type transport struct {
+	allowList []AddressChecker
+}
 
-func (*transport) RoundTrip(req *http.Request) (*http.Response, error) {
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !isHostAllowed(t.allowList, req.URL) {
+		return nil, violation(SandboxLimitAllowList, "host %s is not allowed", req.URL.Hostname())
+	}
 	if httpConfig == nil {
 		return http.DefaultTransport.RoundTrip(req)
 	}
@@ -191,6 +227,19 @@ func isHostBlocked(denyList []AddressChecker, address *url.URL) bool {
 	return false
 }
 
+// isHostAllowed returns true if the allow list is empty or contains the host of the address
+func isHostAllowed(allowList []AddressChecker, address *url.URL) bool {
+	if len(allowList) == 0 {
+		return true
+	}
+	for _, allowed := range allowList {
+		if allowed.Matches(address.Hostname()) {
+			return true
+		}
+	}
+	return false
+}
+
 type AddressChecker interface {
 	Matches(string) bool
 }
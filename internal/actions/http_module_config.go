@@ -16,6 +16,8 @@ var httpConfig *HTTPConfig
 
 type HTTPConfig struct {
 	DenyList []AddressChecker
+	// AllowList restricts the called hosts if not empty, the DenyList is checked anyway
+	AllowList []AddressChecker
 }
 
 func HTTPConfigDecodeHook(from, to reflect.Value) (interface{}, error) {
@@ -24,7 +26,8 @@ func HTTPConfigDecodeHook(from, to reflect.Value) (interface{}, error) {
 	}
 
 	config := struct {
-		DenyList []string
+		DenyList  []string
+		AllowList []string
 	}{}
 
 	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
@@ -41,11 +44,17 @@ func HTTPConfigDecodeHook(from, to reflect.Value) (interface{}, error) {
 	}
 
 	c := HTTPConfig{
-		DenyList: make([]AddressChecker, len(config.DenyList)),
+		DenyList:  make([]AddressChecker, len(config.DenyList)),
+		AllowList: make([]AddressChecker, len(config.AllowList)),
 	}
 
 	for i, entry := range config.DenyList {
-		if c.DenyList[i], err = parseDenyListEntry(entry); err != nil {
+		if c.DenyList[i], err = parseAddressListEntry(entry); err != nil {
+			return nil, err
+		}
+	}
+	for i, entry := range config.AllowList {
+		if c.AllowList[i], err = parseAddressListEntry(entry); err != nil {
 			return nil, err
 		}
 	}
@@ -53,7 +62,7 @@ func HTTPConfigDecodeHook(from, to reflect.Value) (interface{}, error) {
 	return c, nil
 }
 
-func parseDenyListEntry(entry string) (AddressChecker, error) {
+func parseAddressListEntry(entry string) (AddressChecker, error) {
 	if checker, err := NewIPChecker(entry); err == nil {
 		return checker, nil
 	}
@@ -0,0 +1,200 @@
+package actions
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"time"
+
+	"github.com/dop251/goja"
+	"github.com/go-jose/go-jose/v3"
+	"github.com/go-jose/go-jose/v3/jwt"
+	"github.com/zitadel/logging"
+
+	z_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+var (
+	hmacAlgorithms  = []jose.SignatureAlgorithm{jose.HS256, jose.HS384, jose.HS512}
+	rsaAlgorithms   = []jose.SignatureAlgorithm{jose.RS256, jose.RS384, jose.RS512, jose.PS256, jose.PS384, jose.PS512}
+	ecdsaAlgorithms = []jose.SignatureAlgorithm{jose.ES256, jose.ES384, jose.ES512}
+)
+
+func WithJWT() Option {
+	return func(c *runConfig) {
+		c.modules["zitadel/jwt"] = func(runtime *goja.Runtime, module *goja.Object) {
+			requireJWT(module)
+		}
+	}
+}
+
+type jwtModule struct{}
+
+func requireJWT(module *goja.Object) {
+	m := new(jwtModule)
+	o := module.Get("exports").(*goja.Object)
+	logging.OnError(o.Set("sign", m.sign)).Warn("unable to set module")
+	logging.OnError(o.Set("verify", m.verify)).Warn("unable to set module")
+	logging.OnError(o.Set("decode", m.decode)).Warn("unable to set module")
+}
+
+// sign returns the compact serialized token of the claims.
+// The key is the secret for the HS algorithms, otherwise a PEM encoded private key.
+// The optional options object contains:
+//   - `algorithm`: signature algorithm, HS256 by default
+//   - `keyId`: kid of the header
+//   - `header`: additional fields of the header
+func (m *jwtModule) sign(claims map[string]interface{}, key string, options ...map[string]interface{}) (string, error) {
+	opts := optionalArg(options)
+	algorithm := jose.HS256
+	if alg, ok := opts["algorithm"].(string); ok && alg != "" {
+		algorithm = jose.SignatureAlgorithm(alg)
+	}
+	signingKey, err := parseSigningKey(algorithm, key)
+	if err != nil {
+		return "", err
+	}
+	signerOpts := new(jose.SignerOptions).WithType("JWT")
+	if keyID, ok := opts["keyId"].(string); ok && keyID != "" {
+		signerOpts = signerOpts.WithHeader("kid", keyID)
+	}
+	if header, ok := opts["header"].(map[string]interface{}); ok {
+		for k, v := range header {
+			signerOpts = signerOpts.WithHeader(jose.HeaderKey(k), v)
+		}
+	}
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: algorithm, Key: signingKey}, signerOpts)
+	if err != nil {
+		return "", z_errs.ThrowInvalidArgument(err, "ACTIO-Qw3ng", "unable to create signer")
+	}
+	return jwt.Signed(signer).Claims(claims).CompactSerialize()
+}
+
+// verify checks the signature, the expiration and the not before time of the token and returns its claims.
+// The key is the secret for the HS algorithms, otherwise a PEM encoded public key or certificate.
+// The optional options object contains:
+//   - `algorithms`: allowed signature algorithms, all algorithms of the key type by default
+//   - `issuer`: expected iss claim
+//   - `audience`: expected entry of the aud claim
+func (m *jwtModule) verify(token, key string, options ...map[string]interface{}) (map[string]interface{}, error) {
+	opts := optionalArg(options)
+	parsed, err := jwt.ParseSigned(token)
+	if err != nil {
+		return nil, z_errs.ThrowInvalidArgument(err, "ACTIO-Xk2oe", "token is invalid")
+	}
+	verificationKey, keyAlgorithms, err := parseVerificationKey(key)
+	if err != nil {
+		return nil, err
+	}
+	algorithms := keyAlgorithms
+	if allowed, ok := opts["algorithms"].([]interface{}); ok {
+		algorithms = make([]jose.SignatureAlgorithm, 0, len(allowed))
+		for _, alg := range allowed {
+			if s, ok := alg.(string); ok && containsAlgorithm(keyAlgorithms, jose.SignatureAlgorithm(s)) {
+				algorithms = append(algorithms, jose.SignatureAlgorithm(s))
+			}
+		}
+	}
+	if len(parsed.Headers) != 1 || !containsAlgorithm(algorithms, jose.SignatureAlgorithm(parsed.Headers[0].Algorithm)) {
+		return nil, z_errs.ThrowInvalidArgument(nil, "ACTIO-Ps8va", "algorithm of token is not allowed")
+	}
+	claims := make(map[string]interface{})
+	standardClaims := new(jwt.Claims)
+	if err = parsed.Claims(verificationKey, &claims, standardClaims); err != nil {
+		return nil, z_errs.ThrowInvalidArgument(err, "ACTIO-Rw9hs", "signature is invalid")
+	}
+	expected := jwt.Expected{Time: time.Now()}
+	if issuer, ok := opts["issuer"].(string); ok {
+		expected.Issuer = issuer
+	}
+	if audience, ok := opts["audience"].(string); ok && audience != "" {
+		expected.Audience = jwt.Audience{audience}
+	}
+	if err = standardClaims.Validate(expected); err != nil {
+		return nil, z_errs.ThrowInvalidArgument(err, "ACTIO-Uo2qc", "claims are invalid")
+	}
+	return claims, nil
+}
+
+// decode returns the header and the claims of the token without verification
+func (m *jwtModule) decode(token string) (map[string]interface{}, error) {
+	parsed, err := jwt.ParseSigned(token)
+	if err != nil || len(parsed.Headers) != 1 {
+		return nil, z_errs.ThrowInvalidArgument(err, "ACTIO-Ib4mx", "token is invalid")
+	}
+	claims := make(map[string]interface{})
+	if err = parsed.UnsafeClaimsWithoutVerification(&claims); err != nil {
+		return nil, z_errs.ThrowInvalidArgument(err, "ACTIO-Ib4mx", "token is invalid")
+	}
+	header := map[string]interface{}{
+		"alg": parsed.Headers[0].Algorithm,
+	}
+	if parsed.Headers[0].KeyID != "" {
+		header["kid"] = parsed.Headers[0].KeyID
+	}
+	for k, v := range parsed.Headers[0].ExtraHeaders {
+		header[string(k)] = v
+	}
+	return map[string]interface{}{
+		"header": header,
+		"claims": claims,
+	}, nil
+}
+
+func parseSigningKey(algorithm jose.SignatureAlgorithm, key string) (interface{}, error) {
+	if containsAlgorithm(hmacAlgorithms, algorithm) {
+		return []byte(key), nil
+	}
+	block, _ := pem.Decode([]byte(key))
+	if block == nil {
+		return nil, z_errs.ThrowInvalidArgument(nil, "ACTIO-Ew4xk", "key is invalid")
+	}
+	if privateKey, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		return privateKey, nil
+	}
+	if privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return privateKey, nil
+	}
+	if privateKey, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return privateKey, nil
+	}
+	return nil, z_errs.ThrowInvalidArgument(nil, "ACTIO-Ew4xk", "key is invalid")
+}
+
+// parseVerificationKey returns the key and the algorithms of its type,
+// keys which are not PEM encoded are secrets of the HS algorithms
+func parseVerificationKey(key string) (interface{}, []jose.SignatureAlgorithm, error) {
+	block, _ := pem.Decode([]byte(key))
+	if block == nil {
+		return []byte(key), hmacAlgorithms, nil
+	}
+	var publicKey interface{}
+	if pub, err := x509.ParsePKIXPublicKey(block.Bytes); err == nil {
+		publicKey = pub
+	} else if pub, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		publicKey = pub
+	} else if cert, err := x509.ParseCertificate(block.Bytes); err == nil {
+		publicKey = cert.PublicKey
+	}
+	switch publicKey.(type) {
+	case *rsa.PublicKey:
+		return publicKey, rsaAlgorithms, nil
+	case *ecdsa.PublicKey:
+		return publicKey, ecdsaAlgorithms, nil
+	case ed25519.PublicKey:
+		return publicKey, []jose.SignatureAlgorithm{jose.EdDSA}, nil
+	default:
+		return nil, nil, z_errs.ThrowInvalidArgument(nil, "ACTIO-Ew4xk", "key is invalid")
+	}
+}
+
+func containsAlgorithm(algorithms []jose.SignatureAlgorithm, algorithm jose.SignatureAlgorithm) bool {
+	for _, alg := range algorithms {
+		if alg == algorithm {
+			return true
+		}
+	}
+	return false
+}
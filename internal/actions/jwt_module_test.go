@@ -0,0 +1,99 @@
+package actions
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJWTModule(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	privateDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+	publicDER, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	require.NoError(t, err)
+	privatePEM := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER}))
+	publicPEM := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}))
+
+	tests := []struct {
+		name    string
+		body    string
+		want    interface{}
+		wantErr bool
+	}{
+		{
+			name: "sign and verify secret",
+			body: `let jwt = require("zitadel/jwt");
+let token = jwt.sign({sub: "user", iss: "zitadel"}, "secret", {keyId: "key"});
+let claims = jwt.verify(token, "secret", {issuer: "zitadel"});
+let decoded = jwt.decode(token);
+api.setResult(claims.sub + " " + decoded.header.alg + " " + decoded.header.kid + " " + decoded.claims.iss);`,
+			want: "user HS256 key zitadel",
+		},
+		{
+			name: "sign and verify key pair",
+			body: `let jwt = require("zitadel/jwt");
+let token = jwt.sign({sub: "user"}, privateKey, {algorithm: "ES256"});
+api.setResult(jwt.verify(token, publicKey).sub);`,
+			want: "user",
+		},
+		{
+			name: "wrong secret",
+			body: `let jwt = require("zitadel/jwt");
+jwt.verify(jwt.sign({sub: "user"}, "secret"), "other");`,
+			wantErr: true,
+		},
+		{
+			name: "expired",
+			body: `let jwt = require("zitadel/jwt");
+jwt.verify(jwt.sign({sub: "user", exp: 1}, "secret"), "secret");`,
+			wantErr: true,
+		},
+		{
+			name: "wrong issuer",
+			body: `let jwt = require("zitadel/jwt");
+jwt.verify(jwt.sign({iss: "other"}, "secret"), "secret", {issuer: "zitadel"});`,
+			wantErr: true,
+		},
+		{
+			name: "algorithm not allowed",
+			body: `let jwt = require("zitadel/jwt");
+jwt.verify(jwt.sign({sub: "user"}, "secret", {algorithm: "HS512"}), "secret", {algorithms: ["HS256"]});`,
+			wantErr: true,
+		},
+		{
+			name: "secret used as public key",
+			body: `let jwt = require("zitadel/jwt");
+jwt.verify(jwt.sign({sub: "user"}, publicKey), publicKey);`,
+			wantErr: true,
+		},
+		{
+			name: "invalid token caught",
+			body: `try {
+	require("zitadel/jwt").decode("invalid");
+} catch (e) {
+	api.setResult("caught");
+}`,
+			want: "caught",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := "let privateKey = `" + privatePEM + "`;\nlet publicKey = `" + publicPEM + "`;\n" + tt.body
+			got, err := runModuleScript(t, body, WithJWT())
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
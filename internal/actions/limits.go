@@ -0,0 +1,421 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"sync"
+
+	"github.com/dop251/goja"
+	"github.com/zitadel/logging"
+
+	z_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// LimitsConfig defines the default resources a single action run can use,
+// 0 means unlimited. The limits can be overwritten per instance.
+type LimitsConfig struct {
+	// MaxAllocations is the maximum of bytes of the values referenced by the loops and functions of a run,
+	// it also limits the size of the strings, arrays and buffers created by a single call of a builtin
+	MaxAllocations uint64
+	// MaxLoopIterations is the maximum of loop iterations during a run
+	MaxLoopIterations uint64
+	// MaxOutputSize is the maximum of bytes logged by the zitadel/log module during a run
+	MaxOutputSize uint64
+	// MaxHTTPCalls is the maximum of calls using the http module during a run
+	MaxHTTPCalls uint32
+	// MaxHTTPResponseSize is the maximum of bytes read from a response body
+	MaxHTTPResponseSize uint64
+	// MaxConcurrentRuns is the maximum of runs executed at the same time per instance
+	MaxConcurrentRuns uint32
+}
+
+// LimitsQuerier returns the limits of an instance
+type LimitsQuerier interface {
+	Limits(ctx context.Context, resourceOwner string) (*query.Limits, error)
+}
+
+var (
+	limitsConfig  *LimitsConfig
+	limitsQuerier LimitsQuerier
+)
+
+func SetLimitsConfig(config *LimitsConfig) {
+	limitsConfig = config
+}
+
+func SetLimitsQuerier(querier LimitsQuerier) {
+	limitsQuerier = querier
+}
+
+// SandboxLimit is the limit of the sandbox violated by an action run
+type SandboxLimit string
+
+const (
+	SandboxLimitAllocations      SandboxLimit = "allocations"
+	SandboxLimitLoopIterations   SandboxLimit = "loop_iterations"
+	SandboxLimitOutputSize       SandboxLimit = "output_size"
+	SandboxLimitHTTPCalls        SandboxLimit = "http_calls"
+	SandboxLimitHTTPResponseSize SandboxLimit = "http_response_size"
+	SandboxLimitAllowList        SandboxLimit = "allow_list"
+	SandboxLimitConcurrency      SandboxLimit = "concurrency"
+)
+
+// ViolationError is returned if an action run exceeds a limit of the sandbox
+type ViolationError struct {
+	Limit  SandboxLimit
+	Detail string
+}
+
+func (e *ViolationError) Error() string {
+	return fmt.Sprintf("sandbox limit %s violated: %s", e.Limit, e.Detail)
+}
+
+func violation(limit SandboxLimit, format string, args ...any) *ViolationError {
+	return &ViolationError{Limit: limit, Detail: fmt.Sprintf(format, args...)}
+}
+
+// runLimits are the effective limits of a single run
+type runLimits struct {
+	maxAllocations      uint64
+	maxLoopIterations   uint64
+	maxOutputSize       uint64
+	maxHTTPCalls        uint32
+	maxHTTPResponseSize uint64
+	maxConcurrentRuns   uint32
+	allowList           []AddressChecker
+}
+
+// instanceLimits returns the limits of the system config overwritten by the limits of the instance
+func instanceLimits(ctx context.Context, instanceID string) *runLimits {
+	l := new(runLimits)
+	if limitsConfig != nil {
+		l.maxAllocations = limitsConfig.MaxAllocations
+		l.maxLoopIterations = limitsConfig.MaxLoopIterations
+		l.maxOutputSize = limitsConfig.MaxOutputSize
+		l.maxHTTPCalls = limitsConfig.MaxHTTPCalls
+		l.maxHTTPResponseSize = limitsConfig.MaxHTTPResponseSize
+		l.maxConcurrentRuns = limitsConfig.MaxConcurrentRuns
+	}
+	if httpConfig != nil {
+		l.allowList = httpConfig.AllowList
+	}
+	if limitsQuerier == nil || instanceID == "" {
+		return l
+	}
+	instance, err := limitsQuerier.Limits(ctx, instanceID)
+	if err != nil {
+		if !z_errs.IsNotFound(err) {
+			logging.WithFields("instance", instanceID).WithError(err).Warn("unable to query action limits of instance")
+		}
+		return l
+	}
+	if instance.ActionMaxAllocations != nil {
+		l.maxAllocations = *instance.ActionMaxAllocations
+	}
+	if instance.ActionMaxLoopIterations != nil {
+		l.maxLoopIterations = *instance.ActionMaxLoopIterations
+	}
+	if instance.ActionMaxOutputSize != nil {
+		l.maxOutputSize = *instance.ActionMaxOutputSize
+	}
+	if instance.ActionMaxHTTPCalls != nil {
+		l.maxHTTPCalls = *instance.ActionMaxHTTPCalls
+	}
+	if instance.ActionMaxHTTPResponseSize != nil {
+		l.maxHTTPResponseSize = *instance.ActionMaxHTTPResponseSize
+	}
+	if instance.ActionMaxConcurrentRuns != nil {
+		l.maxConcurrentRuns = *instance.ActionMaxConcurrentRuns
+	}
+	if len(instance.ActionAllowedDomains) > 0 {
+		l.allowList = make([]AddressChecker, len(instance.ActionAllowedDomains))
+		for i, domain := range instance.ActionAllowedDomains {
+			l.allowList[i], _ = parseAddressListEntry(domain)
+		}
+	}
+	return l
+}
+
+var runSlots = struct {
+	sync.Mutex
+	instances map[string]chan struct{}
+}{
+	instances: make(map[string]chan struct{}),
+}
+
+// acquireRunSlot waits until less than max runs of the instance are executed.
+// The returned function must be called as soon as the run is finished.
+func acquireRunSlot(ctx context.Context, instanceID string, max uint32) (release func(), err error) {
+	if max == 0 {
+		return func() {}, nil
+	}
+	runSlots.Lock()
+	slots, ok := runSlots.instances[instanceID]
+	// the channel is replaced if the limit changed,
+	// runs holding a slot of the previous channel release it there
+	if !ok || cap(slots) != int(max) {
+		slots = make(chan struct{}, max)
+		runSlots.instances[instanceID] = slots
+	}
+	runSlots.Unlock()
+
+	select {
+	case slots <- struct{}{}:
+		return func() { <-slots }, nil
+	case <-ctx.Done():
+		return nil, violation(SandboxLimitConcurrency, "more than %d concurrent runs", max)
+	}
+}
+
+const (
+	// valueOverhead is the estimated size of a value in bytes, strings add their length
+	valueOverhead = 16
+	// valuesPerSafePoint is the count of values measured per safe point on average,
+	// the measurement of large values is spread over the following safe points
+	valuesPerSafePoint = 16
+)
+
+// meter meters the loop iterations and the size of the values referenced at the safe points of a run.
+// It's called on the goroutine of the vm, so it only meters the run of its vm.
+type meter struct {
+	vm             *goja.Runtime
+	maxAllocations uint64
+	maxIterations  uint64
+	iterations     uint64
+	// skip is the count of safe points until the values are measured again
+	skip int
+	// measuring prevents getters called by the measurement from measuring again
+	measuring bool
+	size      valueSize
+}
+
+// registerSafePoint defines the functions called at the safe points of the script if the run is limited.
+// It returns the name of the safe point or an empty string if the run isn't metered.
+func (c *runConfig) registerSafePoint() (string, error) {
+	if c.limits.maxAllocations == 0 && c.limits.maxLoopIterations == 0 {
+		return "", nil
+	}
+	m := &meter{
+		vm:             c.vm,
+		maxAllocations: c.limits.maxAllocations,
+		maxIterations:  c.limits.maxLoopIterations,
+	}
+	global := c.vm.GlobalObject()
+	// the builtin is read before the script runs, so the script can't replace it
+	if m.size.descriptor, _ = goja.AssertFunction(m.object(global, "Object").Get("getOwnPropertyDescriptor")); m.size.descriptor == nil {
+		return "", z_errs.ThrowInternal(nil, "ACTIO-Ohb4e", "Errors.Internal")
+	}
+	m.size.vm = c.vm
+	if m.maxAllocations > 0 {
+		if err := m.limitBuiltins(); err != nil {
+			return "", err
+		}
+	}
+	name := newSafePointName()
+	if err := global.DefineDataProperty(name, c.vm.ToValue(m.safePoint), goja.FLAG_FALSE, goja.FLAG_FALSE, goja.FLAG_FALSE); err != nil {
+		return "", err
+	}
+	if err := global.DefineDataProperty(name+measureSuffix, c.vm.ToValue(m.measure), goja.FLAG_FALSE, goja.FLAG_FALSE, goja.FLAG_FALSE); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// safePoint interrupts the vm as soon as the loop iterations exceed their maximum.
+// It returns true if the referenced values must be measured.
+func (m *meter) safePoint(call goja.FunctionCall) goja.Value {
+	return m.vm.ToValue(m.countSafePoint(call.Argument(0).ToBoolean()))
+}
+
+func (m *meter) countSafePoint(loop bool) bool {
+	if m.measuring {
+		return false
+	}
+	if loop {
+		m.iterations++
+		if m.maxIterations > 0 && m.iterations > m.maxIterations {
+			m.vm.Interrupt(violation(SandboxLimitLoopIterations, "more than %d loop iterations", m.maxIterations))
+			return false
+		}
+	}
+	if m.maxAllocations == 0 {
+		return false
+	}
+	if m.skip > 0 {
+		m.skip--
+		return false
+	}
+	return true
+}
+
+// measure interrupts the vm as soon as the size of the referenced values exceeds the maximum
+func (m *meter) measure(call goja.FunctionCall) goja.Value {
+	m.measuring = true
+	defer func() { m.measuring = false }()
+	m.size.reset(m.maxAllocations)
+	if m.measureValues(call.Argument(0), call.Argument(1)) {
+		m.vm.Interrupt(violation(SandboxLimitAllocations, "values of more than %d bytes", m.maxAllocations))
+		return goja.Undefined()
+	}
+	m.skip = max(m.size.values/valuesPerSafePoint, headroomSafePoints(m.size.bytes, m.maxAllocations))
+	return goja.Undefined()
+}
+
+// headroomSafePoints returns the count of safe points which can be skipped,
+// if the size of the values doubles at each safe point and must not exceed the maximum
+func headroomSafePoints(size, max uint64) int {
+	if size == 0 || size >= max {
+		return 0
+	}
+	return bits.Len64(max/size) - 2
+}
+
+// measureValues adds the values returned by the function to the size.
+// If a variable isn't declared or initialized in the scope of the safe point,
+// the variables are read one by one and the failing ones are skipped.
+func (m *meter) measureValues(values, variables goja.Value) (exceeded bool) {
+	if read, ok := goja.AssertFunction(values); ok {
+		if value, err := read(goja.Undefined()); err == nil {
+			return m.size.addElements(value)
+		}
+	}
+	read, ok := goja.AssertFunction(variables)
+	if !ok {
+		return false
+	}
+	functions, err := read(goja.Undefined())
+	if err != nil {
+		return false
+	}
+	for _, function := range functions.Export().([]any) {
+		variable, ok := goja.AssertFunction(m.vm.ToValue(function))
+		if !ok {
+			continue
+		}
+		value, err := variable(goja.Undefined())
+		if err != nil {
+			continue
+		}
+		if m.size.add(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// valueSize estimates the size of values, each object is only counted once.
+// The properties are read by their descriptors, so the getters and proxies of the script aren't called by the measurement.
+type valueSize struct {
+	max     uint64
+	bytes   uint64
+	values  int
+	visited map[*goja.Object]bool
+
+	vm *goja.Runtime
+	// descriptor is Object.getOwnPropertyDescriptor
+	descriptor goja.Callable
+}
+
+func (s *valueSize) reset(max uint64) {
+	s.max = max
+	s.bytes = 0
+	s.values = 0
+	if s.visited == nil {
+		s.visited = make(map[*goja.Object]bool)
+	}
+	clear(s.visited)
+}
+
+// add adds the size of the value and returns true as soon as the size exceeds the maximum
+func (s *valueSize) add(value goja.Value) bool {
+	s.values++
+	s.bytes += valueOverhead
+	switch v := value.(type) {
+	case goja.String:
+		s.bytes += uint64(v.Length())
+	case *goja.Object:
+		if !s.visited[v] {
+			s.visited[v] = true
+			return s.addObject(v)
+		}
+	}
+	return s.bytes > s.max
+}
+
+// addElements adds the size of the elements of the array
+func (s *valueSize) addElements(value goja.Value) bool {
+	array, ok := value.(*goja.Object)
+	if !ok || array.ExportType() == proxyType {
+		return s.bytes > s.max
+	}
+	length, ok := s.property(array, "length")
+	if !ok {
+		return s.bytes > s.max
+	}
+	for i := int64(0); i < length.ToInteger(); i++ {
+		element, ok := s.property(array, strconv.FormatInt(i, 10))
+		if !ok {
+			continue
+		}
+		if s.add(element) {
+			return true
+		}
+	}
+	return s.bytes > s.max
+}
+
+// property returns the value of the own data property of the object.
+// Accessor properties and properties of proxies aren't read, as they would call code of the script.
+func (s *valueSize) property(object *goja.Object, key string) (goja.Value, bool) {
+	if object.ExportType() == proxyType {
+		return nil, false
+	}
+	value, err := s.descriptor(goja.Undefined(), object, s.vm.ToValue(key))
+	if err != nil {
+		return nil, false
+	}
+	descriptor, ok := value.(*goja.Object)
+	if !ok {
+		return nil, false
+	}
+	// the descriptor of an accessor has no own value,
+	// reading it would look it up in the prototype chain of the descriptor
+	for _, field := range descriptor.Keys() {
+		if field == "value" {
+			return descriptor.Get("value"), true
+		}
+	}
+	return nil, false
+}
+
+func (s *valueSize) addObject(object *goja.Object) bool {
+	// proxies would call the traps of the script
+	if object.ExportType() == proxyType {
+		return s.bytes > s.max
+	}
+	switch object.ClassName() {
+	case "Function":
+		return s.bytes > s.max
+	case "ArrayBuffer":
+		if buffer, ok := object.Export().(goja.ArrayBuffer); ok {
+			s.bytes += uint64(len(buffer.Bytes()))
+		}
+		return s.bytes > s.max
+	case "Array":
+		return s.addElements(object)
+	}
+	for _, key := range object.Keys() {
+		s.bytes += uint64(len(key))
+		value, ok := s.property(object, key)
+		if !ok {
+			continue
+		}
+		if s.add(value) {
+			return true
+		}
+	}
+	return s.bytes > s.max
+}
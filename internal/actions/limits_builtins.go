@@ -0,0 +1,295 @@
+package actions
+
+import (
+	"reflect"
+
+	"github.com/dop251/goja"
+)
+
+var proxyType = reflect.TypeOf(goja.Proxy{})
+
+// builtinSize estimates the bytes allocated by a call of a builtin before it's called.
+// It returns 0 if the size can't be estimated without calling code of the script,
+// the size of the result is checked after the call in that case.
+type builtinSize func(m *meter, this goja.Value, args []goja.Value) float64
+
+// typedArrays are the constructors of the typed arrays and the bytes per element
+var typedArrays = map[string]float64{
+	"Int8Array":         1,
+	"Uint8Array":        1,
+	"Uint8ClampedArray": 1,
+	"Int16Array":        2,
+	"Uint16Array":       2,
+	"Int32Array":        4,
+	"Uint32Array":       4,
+	"Float32Array":      4,
+	"Float64Array":      8,
+	"BigInt64Array":     8,
+	"BigUint64Array":    8,
+}
+
+// limitBuiltins replaces the builtins whose results grow with their arguments by proxies checking the size of the result.
+// The safe points only measure the values after a builtin returned,
+// so a single call (e.g. "a".repeat(1 << 30)) would allocate any size without the check.
+func (m *meter) limitBuiltins() error {
+	global := m.vm.GlobalObject()
+	stringPrototype := m.object(global, "String", "prototype")
+	arrayPrototype := m.object(global, "Array", "prototype")
+	builtins := []struct {
+		object *goja.Object
+		name   string
+		size   builtinSize
+	}{
+		{stringPrototype, "repeat", repeatSize},
+		{stringPrototype, "padStart", padSize},
+		{stringPrototype, "padEnd", padSize},
+		{stringPrototype, "concat", stringConcatSize},
+		{stringPrototype, "replace", nil},
+		{stringPrototype, "replaceAll", nil},
+		{arrayPrototype, "join", joinSize},
+		{arrayPrototype, "fill", fillSize},
+		{arrayPrototype, "concat", arrayConcatSize},
+		{m.object(global, "Array"), "from", arrayFromSize},
+		{m.object(global, "JSON"), "stringify", nil},
+	}
+	for _, builtin := range builtins {
+		if err := m.limitBuiltin(builtin.object, builtin.name, builtin.size, false); err != nil {
+			return err
+		}
+	}
+	if err := m.limitBuiltin(global, "Array", arraySize, true); err != nil {
+		return err
+	}
+	if err := m.limitBuiltin(global, "ArrayBuffer", bufferSize(1), true); err != nil {
+		return err
+	}
+	for name, bytes := range typedArrays {
+		if err := m.limitBuiltin(global, name, bufferSize(bytes), true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// limitBuiltin replaces the builtin by a proxy which checks the size before and after the call.
+// The constructor of the prototype is replaced as well for constructors,
+// so the builtin can't be reached through the constructor of its instances.
+func (m *meter) limitBuiltin(object *goja.Object, name string, size builtinSize, constructor bool) error {
+	if object == nil {
+		return nil
+	}
+	builtin, ok := object.Get(name).(*goja.Object)
+	if !ok {
+		return nil
+	}
+	traps := new(goja.ProxyTrapConfig)
+	if call, ok := goja.AssertFunction(builtin); ok {
+		traps.Apply = func(_ *goja.Object, this goja.Value, args []goja.Value) goja.Value {
+			m.checkBuiltin(name, m.estimate(size, this, args))
+			result, err := call(this, args...)
+			if err != nil {
+				panic(err)
+			}
+			m.checkBuiltin(name, m.resultSize(result))
+			return result
+		}
+	}
+	if construct, ok := goja.AssertConstructor(builtin); ok && constructor {
+		traps.Construct = func(_ *goja.Object, args []goja.Value, newTarget *goja.Object) *goja.Object {
+			m.checkBuiltin(name, m.estimate(size, goja.Undefined(), args))
+			result, err := construct(newTarget, args...)
+			if err != nil {
+				panic(err)
+			}
+			m.checkBuiltin(name, m.resultSize(result))
+			return result
+		}
+	}
+	proxy := m.vm.ToValue(m.vm.NewProxy(builtin, traps))
+	if err := object.DefineDataProperty(name, proxy, goja.FLAG_TRUE, goja.FLAG_TRUE, goja.FLAG_FALSE); err != nil {
+		return err
+	}
+	if !constructor {
+		return nil
+	}
+	prototype, ok := builtin.Get("prototype").(*goja.Object)
+	if !ok {
+		return nil
+	}
+	// instanceof isn't implemented for proxies by the runtime,
+	// the check is looked up on the builtin through the proxy
+	hasInstance := m.vm.ToValue(func(call goja.FunctionCall) goja.Value {
+		return m.vm.ToValue(inherits(call.Argument(0), prototype))
+	})
+	if err := builtin.DefineDataPropertySymbol(goja.SymHasInstance, hasInstance, goja.FLAG_FALSE, goja.FLAG_FALSE, goja.FLAG_FALSE); err != nil {
+		return err
+	}
+	return prototype.DefineDataProperty("constructor", proxy, goja.FLAG_TRUE, goja.FLAG_TRUE, goja.FLAG_FALSE)
+}
+
+// inherits reports whether the prototype is in the prototype chain of the value
+func inherits(value goja.Value, prototype *goja.Object) bool {
+	object, ok := value.(*goja.Object)
+	if !ok {
+		return false
+	}
+	for object = object.Prototype(); object != nil; object = object.Prototype() {
+		if object == prototype {
+			return true
+		}
+	}
+	return false
+}
+
+// checkBuiltin stops the run if the builtin allocates more than the maximum,
+// the violation can't be caught by the script
+func (m *meter) checkBuiltin(name string, size float64) {
+	if size > float64(m.maxAllocations) {
+		panic(violation(SandboxLimitAllocations, "%s allocates more than %d bytes", name, m.maxAllocations))
+	}
+}
+
+func (m *meter) estimate(size builtinSize, this goja.Value, args []goja.Value) float64 {
+	if size == nil {
+		return 0
+	}
+	return size(m, this, args)
+}
+
+// resultSize returns the size of the strings, arrays and buffers returned by the builtins
+func (m *meter) resultSize(result goja.Value) float64 {
+	switch v := result.(type) {
+	case goja.String:
+		return float64(v.Length())
+	case *goja.Object:
+		if v.ExportType() == proxyType {
+			return 0
+		}
+		switch v.ClassName() {
+		case "Array":
+			return m.length(v) * valueOverhead
+		case "ArrayBuffer":
+			if buffer, ok := v.Export().(goja.ArrayBuffer); ok {
+				return float64(len(buffer.Bytes()))
+			}
+			return 0
+		}
+		// typed arrays are exported as slices of their buffer
+		if exportType := v.ExportType(); exportType != nil && exportType.Kind() == reflect.Slice {
+			exported := reflect.ValueOf(v.Export())
+			return float64(exported.Len()) * float64(exportType.Elem().Size())
+		}
+	}
+	return 0
+}
+
+// object returns the object at the path of properties or nil if it doesn't exist
+func (m *meter) object(object *goja.Object, path ...string) *goja.Object {
+	for _, name := range path {
+		next, ok := object.Get(name).(*goja.Object)
+		if !ok {
+			return nil
+		}
+		object = next
+	}
+	return object
+}
+
+// length returns the length of an array or an ordinary object with a length property,
+// getters and proxies aren't called
+func (m *meter) length(value goja.Value) float64 {
+	object, ok := value.(*goja.Object)
+	if !ok {
+		return 0
+	}
+	length, ok := m.size.property(object, "length")
+	if !ok {
+		return 0
+	}
+	return number(length)
+}
+
+// number returns the value of a number, other values are converted by the builtin itself
+func number(value goja.Value) float64 {
+	if value == nil {
+		return 0
+	}
+	if _, ok := value.(*goja.Object); ok {
+		return 0
+	}
+	switch n := value.Export().(type) {
+	case int64:
+		return float64(n)
+	case float64:
+		return n
+	}
+	return 0
+}
+
+func argument(args []goja.Value, i int) goja.Value {
+	if i < len(args) {
+		return args[i]
+	}
+	return nil
+}
+
+func stringLength(value goja.Value) float64 {
+	if s, ok := value.(goja.String); ok {
+		return float64(s.Length())
+	}
+	return 0
+}
+
+func repeatSize(_ *meter, this goja.Value, args []goja.Value) float64 {
+	return stringLength(this) * number(argument(args, 0))
+}
+
+func padSize(_ *meter, _ goja.Value, args []goja.Value) float64 {
+	return number(argument(args, 0))
+}
+
+func stringConcatSize(_ *meter, this goja.Value, args []goja.Value) float64 {
+	size := stringLength(this)
+	for _, arg := range args {
+		size += stringLength(arg)
+	}
+	return size
+}
+
+func joinSize(m *meter, this goja.Value, args []goja.Value) float64 {
+	separator := float64(1)
+	if arg := argument(args, 0); arg != nil && !goja.IsUndefined(arg) {
+		separator = stringLength(arg)
+	}
+	return m.length(this) * separator
+}
+
+func fillSize(m *meter, this goja.Value, _ []goja.Value) float64 {
+	return m.length(this) * valueOverhead
+}
+
+func arrayConcatSize(m *meter, this goja.Value, args []goja.Value) float64 {
+	size := m.length(this)
+	for _, arg := range args {
+		size += max(m.length(arg), 1)
+	}
+	return size * valueOverhead
+}
+
+func arrayFromSize(m *meter, _ goja.Value, args []goja.Value) float64 {
+	return m.length(argument(args, 0)) * valueOverhead
+}
+
+// arraySize is the size of Array(length), the elements are allocated as soon as the array is filled
+func arraySize(_ *meter, _ goja.Value, args []goja.Value) float64 {
+	if len(args) != 1 {
+		return 0
+	}
+	return number(args[0]) * valueOverhead
+}
+
+func bufferSize(bytesPerElement float64) builtinSize {
+	return func(_ *meter, _ goja.Value, args []goja.Value) float64 {
+		return number(argument(args, 0)) * bytesPerElement
+	}
+}
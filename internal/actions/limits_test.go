@@ -0,0 +1,360 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dop251/goja"
+	"github.com/muhlemmer/gu"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	z_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/logstore"
+	"github.com/zitadel/zitadel/internal/logstore/record"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+type mockLimitsQuerier struct {
+	limits *query.Limits
+	err    error
+}
+
+func (m *mockLimitsQuerier) Limits(context.Context, string) (*query.Limits, error) {
+	return m.limits, m.err
+}
+
+func TestRun_Limits(t *testing.T) {
+	SetLogstoreService(logstore.New[*record.ExecutionLog](nil, nil))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"key":"` + strings.Repeat("a", 100) + `"}`))
+	}))
+	defer server.Close()
+
+	fetchScript := func(calls int) string {
+		return `function testFunc() {
+	let http = require("zitadel/http");
+	for (let i = 0; i < ` + strconv.Itoa(calls) + `; i++) {
+		http.fetch("` + server.URL + `").json();
+	}
+}`
+	}
+
+	tests := []struct {
+		name      string
+		config    *LimitsConfig
+		querier   LimitsQuerier
+		script    string
+		wantLimit SandboxLimit
+	}{
+		{
+			name:   "no limits",
+			config: &LimitsConfig{},
+			script: fetchScript(3),
+		},
+		{
+			name:      "http calls exceeded",
+			config:    &LimitsConfig{MaxHTTPCalls: 2},
+			script:    fetchScript(3),
+			wantLimit: SandboxLimitHTTPCalls,
+		},
+		{
+			name:   "http calls of instance",
+			config: &LimitsConfig{MaxHTTPCalls: 2},
+			querier: &mockLimitsQuerier{
+				limits: &query.Limits{ActionMaxHTTPCalls: gu.Ptr(uint32(3))},
+			},
+			script: fetchScript(3),
+		},
+		{
+			name:   "http calls of config if instance has no limits",
+			config: &LimitsConfig{MaxHTTPCalls: 2},
+			querier: &mockLimitsQuerier{
+				err: z_errs.ThrowNotFound(nil, "QUERY-GU1em", "Errors.Limits.NotFound"),
+			},
+			script:    fetchScript(3),
+			wantLimit: SandboxLimitHTTPCalls,
+		},
+		{
+			name:      "response size exceeded",
+			config:    &LimitsConfig{MaxHTTPResponseSize: 50},
+			script:    fetchScript(1),
+			wantLimit: SandboxLimitHTTPResponseSize,
+		},
+		{
+			name:   "host not allowed",
+			config: &LimitsConfig{},
+			querier: &mockLimitsQuerier{
+				limits: &query.Limits{ActionAllowedDomains: []string{"zitadel.com"}},
+			},
+			script:    fetchScript(1),
+			wantLimit: SandboxLimitAllowList,
+		},
+		{
+			name:   "host allowed",
+			config: &LimitsConfig{},
+			querier: &mockLimitsQuerier{
+				limits: &query.Limits{ActionAllowedDomains: []string{"127.0.0.1"}},
+			},
+			script: fetchScript(1),
+		},
+		{
+			name:   "allocations exceeded",
+			config: &LimitsConfig{MaxAllocations: 1 << 20},
+			script: `function testFunc() {
+	let values = [];
+	while (true) {
+		values.push("value" + values.length);
+	}
+}`,
+			wantLimit: SandboxLimitAllocations,
+		},
+		{
+			name:   "allocations of nested function exceeded",
+			config: &LimitsConfig{MaxAllocations: 1 << 20},
+			script: `function testFunc() {
+	let value = "a";
+	const double = () => value += value;
+	for (let i = 0; i < 30; i++) {
+		double();
+	}
+}`,
+			wantLimit: SandboxLimitAllocations,
+		},
+		{
+			name:   "allocations not retained",
+			config: &LimitsConfig{MaxAllocations: 1 << 20},
+			script: `function testFunc() {
+	let sum = 0;
+	for (let i = 0; i < 100000; i++) {
+		sum += ("value" + i).length;
+	}
+}`,
+		},
+		{
+			name:   "allocations of builtin exceeded",
+			config: &LimitsConfig{MaxAllocations: 1 << 20},
+			script: `function testFunc() {
+	return "a".repeat(1 << 27);
+}`,
+			wantLimit: SandboxLimitAllocations,
+		},
+		{
+			name:   "allocations of builtin exceeded, not catchable",
+			config: &LimitsConfig{MaxAllocations: 1 << 20},
+			script: `function testFunc() {
+	try {
+		new Uint8Array(1 << 27);
+	} catch (e) {}
+	try {
+		[1, 2].constructor(1 << 27).fill(0);
+	} catch (e) {}
+}`,
+			wantLimit: SandboxLimitAllocations,
+		},
+		{
+			name:   "allocations of builtin result exceeded",
+			config: &LimitsConfig{MaxAllocations: 1 << 20},
+			script: `function testFunc() {
+	return "a".repeat(1 << 10).replaceAll("a", "b".repeat(1 << 11));
+}`,
+			wantLimit: SandboxLimitAllocations,
+		},
+		{
+			name:   "allocations of builtins within limit",
+			config: &LimitsConfig{MaxAllocations: 1 << 20},
+			script: `function testFunc() {
+	const padded = "a".padStart(100, "b").repeat(10);
+	const buffer = new Uint8Array(1024);
+	if (!(buffer instanceof Uint8Array) || buffer.constructor !== Uint8Array || !(Array.from(buffer) instanceof Array)) {
+		throw new Error("builtins must behave the same");
+	}
+	if ([padded, JSON.stringify(Array(10).fill(padded))].join(",").length === 0) {
+		throw new Error("builtins must behave the same");
+	}
+}`,
+		},
+		{
+			name:   "loop iterations exceeded",
+			config: &LimitsConfig{MaxLoopIterations: 1000},
+			script: `function testFunc() {
+	let i = 0;
+	do {
+		i++;
+	} while (true);
+}`,
+			wantLimit: SandboxLimitLoopIterations,
+		},
+		{
+			name:   "loop iterations of instance",
+			config: &LimitsConfig{MaxLoopIterations: 10},
+			querier: &mockLimitsQuerier{
+				limits: &query.Limits{ActionMaxLoopIterations: gu.Ptr(uint64(100))},
+			},
+			script: `function testFunc() {
+	for (const i of [...Array(50).keys()]) {}
+}`,
+		},
+		{
+			name:   "output size exceeded",
+			config: &LimitsConfig{MaxOutputSize: 100},
+			script: `function testFunc() {
+	let logger = require("zitadel/log");
+	for (let i = 0; i < 20; i++) {
+		logger.log("message " + i);
+	}
+}`,
+			wantLimit: SandboxLimitOutputSize,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetLimitsConfig(tt.config)
+			SetLimitsQuerier(tt.querier)
+			defer func() {
+				SetLimitsConfig(nil)
+				SetLimitsQuerier(nil)
+			}()
+			ctx, cancel := context.WithTimeout(authz.WithInstanceID(context.Background(), "instance1"), 10*time.Second)
+			defer cancel()
+
+			err := Run(ctx, nil, nil, tt.script, "testFunc", WithHTTP(ctx))
+			if tt.wantLimit == "" {
+				require.NoError(t, err)
+				return
+			}
+			violationErr := new(ViolationError)
+			require.True(t, errors.As(err, &violationErr), "want violation error got: %v", err)
+			assert.Equal(t, tt.wantLimit, violationErr.Limit)
+		})
+	}
+}
+
+func Test_acquireRunSlot(t *testing.T) {
+	t.Cleanup(func() {
+		runSlots.instances = make(map[string]chan struct{})
+	})
+	ctx := context.Background()
+	release, err := acquireRunSlot(ctx, "instance1", 1)
+	require.NoError(t, err)
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = acquireRunSlot(timeoutCtx, "instance1", 1)
+	violationErr := new(ViolationError)
+	require.True(t, errors.As(err, &violationErr), "want violation error got: %v", err)
+	assert.Equal(t, SandboxLimitConcurrency, violationErr.Limit)
+
+	releaseOther, err := acquireRunSlot(ctx, "instance2", 1)
+	require.NoError(t, err, "other instances must not be limited")
+	releaseOther()
+
+	release()
+	release, err = acquireRunSlot(ctx, "instance1", 1)
+	require.NoError(t, err)
+	release()
+}
+
+func TestRun_LimitsPerRuntime(t *testing.T) {
+	SetLogstoreService(logstore.New[*record.ExecutionLog](nil, nil))
+	SetLimitsConfig(&LimitsConfig{MaxAllocations: 1 << 20})
+	defer SetLimitsConfig(nil)
+	ctx, cancel := context.WithTimeout(authz.WithInstanceID(context.Background(), "instance1"), 10*time.Second)
+	defer cancel()
+
+	// allocations outside of the run must not count
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				_ = strings.Repeat("a", 1<<20)
+			}
+		}
+	}()
+	err := Run(ctx, nil, nil, `function testFunc() {
+	let values = [];
+	for (let i = 0; i < 10000; i++) {
+		values.push(i);
+	}
+}`, "testFunc")
+	require.NoError(t, err)
+}
+
+func Test_compileWithSafePoints(t *testing.T) {
+	vm := goja.New()
+	var loops, calls, measures int
+	require.NoError(t, vm.Set("safePoint", func(loop bool) bool {
+		if loop {
+			loops++
+		} else {
+			calls++
+		}
+		return true
+	}))
+	require.NoError(t, vm.Set("safePoint"+measureSuffix, func(call goja.FunctionCall) goja.Value {
+		measures++
+		return goja.Undefined()
+	}))
+	program, err := compileWithSafePoints(`function strict() {
+	"use strict";
+	return this === undefined;
+}
+let sum = 0;
+outer: for (let i = 0; i < 3; i++) {
+	for (let j = 0; j < 3; j++) {
+		if (j == 1) continue outer;
+		sum += [1, 2].map(v => v * i).reduce((a, b) => a + b);
+	}
+}
+strict() ? sum : -1;`, "safePoint")
+	require.NoError(t, err)
+	result, err := vm.RunProgram(program)
+	require.NoError(t, err)
+	assert.Equal(t, int64(9), result.ToInteger())
+	assert.Equal(t, 3+3*2, loops)
+	assert.Equal(t, 1+3*(2+1), calls)
+	assert.Equal(t, loops+calls, measures)
+}
+
+func TestRun_LimitsMeasurementWithoutSideEffects(t *testing.T) {
+	SetLogstoreService(logstore.New[*record.ExecutionLog](nil, nil))
+	SetLimitsConfig(&LimitsConfig{MaxAllocations: 1 << 20})
+	defer SetLimitsConfig(nil)
+	ctx, cancel := context.WithTimeout(authz.WithInstanceID(context.Background(), "instance1"), 10*time.Second)
+	defer cancel()
+
+	err := Run(ctx, nil, nil, `function testFunc() {
+	let getterCalls = 0;
+	let trapCalls = 0;
+	const object = {
+		get value() {
+			getterCalls++;
+			return "value";
+		},
+	};
+	const proxy = new Proxy({}, {
+		get: () => { trapCalls++; return "value"; },
+		ownKeys: () => { trapCalls++; return []; },
+		getOwnPropertyDescriptor: () => { trapCalls++; return undefined; },
+	});
+	for (let i = 0; i < 500; i++) {
+		object.value;
+		proxy.value;
+	}
+	if (getterCalls !== 500 || trapCalls !== 500) {
+		throw new Error("getter called " + getterCalls + " times, traps called " + trapCalls + " times");
+	}
+}`, "testFunc")
+	require.NoError(t, err)
+}
@@ -26,6 +26,14 @@ type logger struct {
 	ctx        context.Context
 	started    time.Time
 	instanceID string
+	actionID   string
+	version    uint64
+	// recorder is set for dry runs, the logs are recorded instead of stored
+	recorder *Recorder
+	// vm is interrupted if the messages of the script exceed maxOutputSize
+	vm            *goja.Runtime
+	maxOutputSize uint64
+	outputSize    uint64
 }
 
 // newLogger returns a *logger instance that should only be used for a single action run.
@@ -39,18 +47,39 @@ func newLogger(ctx context.Context, instanceID string) *logger {
 }
 
 func (l *logger) Log(msg string) {
-	l.log(msg, logrus.InfoLevel, false)
+	l.print(msg, logrus.InfoLevel)
 }
 
 func (l *logger) Warn(msg string) {
-	l.log(msg, logrus.WarnLevel, false)
+	l.print(msg, logrus.WarnLevel)
 }
 
 func (l *logger) Error(msg string) {
-	l.log(msg, logrus.ErrorLevel, false)
+	l.print(msg, logrus.ErrorLevel)
+}
+
+// limitOutput limits the bytes of the messages logged by the script, 0 means unlimited
+func (l *logger) limitOutput(vm *goja.Runtime, max uint64) {
+	l.vm = vm
+	l.maxOutputSize = max
+}
+
+// print logs a message of the script,
+// the message is dropped and the vm is interrupted if the messages exceed the maximum output size
+func (l *logger) print(msg string, level logrus.Level) {
+	l.outputSize += uint64(len(msg))
+	if l.maxOutputSize > 0 && l.outputSize > l.maxOutputSize {
+		l.vm.Interrupt(violation(SandboxLimitOutputSize, "more than %d bytes logged", l.maxOutputSize))
+		return
+	}
+	l.log(msg, level, false)
 }
 
 func (l *logger) log(msg string, level logrus.Level, last bool) {
+	l.logWithMetadata(msg, level, last, nil)
+}
+
+func (l *logger) logWithMetadata(msg string, level logrus.Level, last bool, metadata map[string]interface{}) {
 	ts := time.Now()
 	if l.started.IsZero() {
 		l.started = ts
@@ -60,10 +89,22 @@ func (l *logger) log(msg string, level logrus.Level, last bool) {
 		InstanceID: l.instanceID,
 		Message:    msg,
 		LogLevel:   level,
+		ActionID:   l.actionID,
+		Metadata:   metadata,
+	}
+	if l.version > 0 {
+		if r.Metadata == nil {
+			r.Metadata = make(map[string]interface{}, 1)
+		}
+		r.Metadata["version"] = l.version
 	}
 	if last {
 		r.Took = ts.Sub(l.started)
 	}
+	if l.recorder != nil {
+		l.recorder.log(ts, level, msg)
+		return
+	}
 	logstoreService.Handle(l.ctx, r)
 }
 
@@ -72,6 +113,9 @@ func withLogger(ctx context.Context) Option {
 	instanceID := instance.InstanceID()
 	return func(c *runConfig) {
 		c.logger = newLogger(ctx, instanceID)
+		c.logger.recorder = c.recorder
+		c.logger.actionID = c.actionID
+		c.logger.version = c.version
 		c.instanceID = instanceID
 		c.modules["zitadel/log"] = func(runtime *goja.Runtime, module *goja.Object) {
 			console.RequireWithPrinter(c.logger)(runtime, module)
@@ -0,0 +1,42 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/zitadel/logging"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/zitadel/zitadel/internal/telemetry/metrics"
+)
+
+const (
+	ActionExecutions            = "zitadel.action.executions"
+	ActionExecutionsDescription = "Count of action executions per rollout of the executed version"
+	actionMetricsLabelInstance  = "instance"
+	actionMetricsLabelRollout   = "rollout"
+	actionMetricsLabelResult    = "result"
+	actionResultSucceeded       = "succeeded"
+	actionResultFailed          = "failed"
+)
+
+// countExecution counts the execution of a versioned action,
+// so the failures of a canary can be compared to the current version.
+// The action and its version aren't labels, as they would create a time series per version,
+// they are part of the logs of the execution.
+func (c *runConfig) countExecution(ctx context.Context, err error) {
+	if c.actionID == "" || c.recorder != nil {
+		return
+	}
+	result := actionResultSucceeded
+	if err != nil {
+		result = actionResultFailed
+	}
+	labels := map[string]attribute.Value{
+		actionMetricsLabelInstance: attribute.StringValue(c.instanceID),
+		actionMetricsLabelRollout:  attribute.StringValue(c.rollout),
+		actionMetricsLabelResult:   attribute.StringValue(result),
+	}
+	logging.OnError(metrics.RegisterCounter(ActionExecutions, ActionExecutionsDescription)).Error("unable to register action executions counter")
+	err = metrics.AddCount(ctx, ActionExecutions, 1, labels)
+	logging.WithFields("name", ActionExecutions, "labels", labels).OnError(err).Error("incrementing counter metric failed")
+}
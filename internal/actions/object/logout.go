@@ -0,0 +1,23 @@
+package object
+
+import "github.com/zitadel/zitadel/internal/actions"
+
+// LogoutField provides the information about the terminated sessions
+// to the actions of the logout flow.
+// The user agent is empty for the sessions of the session API,
+// the session is empty for the user sessions of the login UI.
+func LogoutField(userAgentID, sessionID string, userIDs []string) func(c *actions.FieldConfig) interface{} {
+	return func(c *actions.FieldConfig) interface{} {
+		return c.Runtime.ToValue(&logout{
+			UserAgentId: userAgentID,
+			SessionId:   sessionID,
+			UserIds:     copySlice(userIDs),
+		})
+	}
+}
+
+type logout struct {
+	UserAgentId string
+	SessionId   string
+	UserIds     []string
+}
@@ -0,0 +1,36 @@
+package object
+
+import (
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+// PasswordResetField provides the information about the requested password reset
+// to the actions of the password reset flow
+func PasswordResetField(notificationType domain.NotificationType, returnCode bool) func(c *actions.FieldConfig) interface{} {
+	return func(c *actions.FieldConfig) interface{} {
+		return c.Runtime.ToValue(&passwordReset{
+			NotificationType: notificationTypeFromDomain(notificationType, returnCode),
+			ReturnCode:       returnCode,
+		})
+	}
+}
+
+type passwordReset struct {
+	NotificationType string
+	ReturnCode       bool
+}
+
+func notificationTypeFromDomain(notificationType domain.NotificationType, returnCode bool) string {
+	if returnCode {
+		return "none"
+	}
+	switch notificationType {
+	case domain.NotificationTypeEmail:
+		return "email"
+	case domain.NotificationTypeSms:
+		return "sms"
+	default:
+		return "none"
+	}
+}
@@ -0,0 +1,35 @@
+package object
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/actions"
+)
+
+// RefreshTokenField provides the information of the refresh token request
+// to the actions of the pre refresh token creation trigger
+func RefreshTokenField(clientID string, scopes, audience, authMethodsReferences []string, authTime time.Time) func(c *actions.FieldConfig) interface{} {
+	return func(c *actions.FieldConfig) interface{} {
+		return c.Runtime.ToValue(&refreshToken{
+			ClientId:              clientID,
+			Scopes:                copySlice(scopes),
+			Audience:              copySlice(audience),
+			AuthMethodsReferences: copySlice(authMethodsReferences),
+			AuthTime:              authTime,
+		})
+	}
+}
+
+type refreshToken struct {
+	ClientId              string
+	Scopes                []string
+	Audience              []string
+	AuthMethodsReferences []string
+	AuthTime              time.Time
+}
+
+func copySlice(src []string) []string {
+	dst := make([]string, len(src))
+	copy(dst, src)
+	return dst
+}
@@ -0,0 +1,105 @@
+package object
+
+import (
+	"sort"
+
+	"github.com/dop251/goja"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+// Rejection is passed to the actions of triggers which are able to reject the current request,
+// e.g. the validation of a registration.
+// The first call of reject wins, the calls of the following actions are ignored.
+type Rejection struct {
+	rejected bool
+	// messages contains the localized messages by language tag,
+	// the empty tag is used if the action passes a single message
+	messages map[string]string
+}
+
+// RejectFunc provides api.v1.reject, which accepts no argument,
+// a message or an object of localized messages, e.g. {en: "not allowed", de: "nicht erlaubt"}
+func (r *Rejection) RejectFunc(c *actions.FieldConfig) interface{} {
+	return func(call goja.FunctionCall) goja.Value {
+		if r.rejected {
+			return nil
+		}
+		r.rejected = true
+		if len(call.Arguments) == 0 || goja.IsUndefined(call.Arguments[0]) || goja.IsNull(call.Arguments[0]) {
+			return nil
+		}
+		switch message := call.Arguments[0].Export().(type) {
+		case string:
+			r.messages = map[string]string{"": message}
+		case map[string]interface{}:
+			r.messages = make(map[string]string, len(message))
+			for lang, text := range message {
+				if s, ok := text.(string); ok {
+					r.messages[lang] = s
+				}
+			}
+		default:
+			panic("message must be a string or an object of localized strings")
+		}
+		return nil
+	}
+}
+
+// Rejected returns if an action called reject
+func (r *Rejection) Rejected() bool {
+	return r.rejected
+}
+
+// Err returns nil if no action rejected the request.
+// Otherwise a precondition failed error is returned,
+// its message is the one matching the preferred languages best
+// or the default message if the action didn't provide one.
+func (r *Rejection) Err(preferredLanguages ...language.Tag) error {
+	if !r.rejected {
+		return nil
+	}
+	message := r.message(preferredLanguages...)
+	if message == "" {
+		message = "Errors.Action.Rejected"
+	}
+	return errors.ThrowPreconditionFailed(nil, "ACTIO-Sf3g2", message)
+}
+
+func (r *Rejection) message(preferredLanguages ...language.Tag) string {
+	if len(r.messages) == 0 {
+		return ""
+	}
+	if message, ok := r.messages[""]; ok {
+		return message
+	}
+	langs := make([]string, 0, len(r.messages))
+	for lang := range r.messages {
+		langs = append(langs, lang)
+	}
+	// the first language is used if none of the preferred languages matches,
+	// english is preferred over the others
+	sort.Slice(langs, func(i, j int) bool {
+		if langs[i] == language.English.String() || langs[j] == language.English.String() {
+			return langs[i] == language.English.String()
+		}
+		return langs[i] < langs[j]
+	})
+	tags := make([]language.Tag, 0, len(langs))
+	texts := make([]string, 0, len(langs))
+	for _, lang := range langs {
+		tag, err := language.Parse(lang)
+		if err != nil {
+			continue
+		}
+		tags = append(tags, tag)
+		texts = append(texts, r.messages[lang])
+	}
+	if len(tags) == 0 {
+		return ""
+	}
+	_, index, _ := language.NewMatcher(tags).Match(preferredLanguages...)
+	return texts[index]
+}
@@ -0,0 +1,139 @@
+package actions
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/dop251/goja"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// Queries are the read-only queries of the zitadel/query module
+type Queries interface {
+	SearchUserMetadata(ctx context.Context, shouldTriggerBulk bool, userID string, queries *query.UserMetadataSearchQueries, withOwnerRemoved bool) (*query.UserMetadataList, error)
+	SearchOrgMetadata(ctx context.Context, shouldTriggerBulk bool, orgID string, queries *query.OrgMetadataSearchQueries, withOwnerRemoved bool) (*query.OrgMetadataList, error)
+}
+
+// WithQuery provides read-only queries scoped to the organization of the action,
+// data of other organizations is not returned
+func WithQuery(ctx context.Context, queries Queries, orgID string) Option {
+	return func(c *runConfig) {
+		c.modules["zitadel/query"] = func(runtime *goja.Runtime, module *goja.Object) {
+			requireQuery(ctx, c, queries, orgID, module)
+		}
+	}
+}
+
+type queryModule struct {
+	ctx     context.Context
+	config  *runConfig
+	queries Queries
+	orgID   string
+}
+
+type metadataList struct {
+	Count    uint64
+	Metadata []*metadata
+}
+
+type metadata struct {
+	CreationDate time.Time
+	ChangeDate   time.Time
+	Sequence     uint64
+	Key          string
+	Value        interface{}
+}
+
+func requireQuery(ctx context.Context, c *runConfig, queries Queries, orgID string, module *goja.Object) {
+	m := &queryModule{
+		ctx:     ctx,
+		config:  c,
+		queries: queries,
+		orgID:   orgID,
+	}
+	o := module.Get("exports").(*goja.Object)
+	logging.OnError(o.Set("getUserMetadata", m.getUserMetadata)).Warn("unable to set module")
+	logging.OnError(o.Set("getOrgMetadata", m.getOrgMetadata)).Warn("unable to set module")
+}
+
+// getUserMetadata returns the metadata of a user of the organization,
+// the optional key filters the metadata by its key
+func (m *queryModule) getUserMetadata(userID string, key ...string) (*metadataList, error) {
+	ctx, cancel := m.config.moduleContext(m.ctx)
+	defer cancel()
+	resourceOwnerQuery, err := query.NewUserMetadataResourceOwnerSearchQuery(m.orgID)
+	if err != nil {
+		return nil, err
+	}
+	queries := &query.UserMetadataSearchQueries{Queries: []query.SearchQuery{resourceOwnerQuery}}
+	if k := optionalArg(key); k != "" {
+		keyQuery, err := query.NewUserMetadataKeySearchQuery(k, query.TextEquals)
+		if err != nil {
+			return nil, err
+		}
+		queries.Queries = append(queries.Queries, keyQuery)
+	}
+	list, err := m.queries.SearchUserMetadata(ctx, true, userID, queries, false)
+	if err != nil {
+		return nil, err
+	}
+	result := &metadataList{
+		Count:    list.Count,
+		Metadata: make([]*metadata, len(list.Metadata)),
+	}
+	for i, md := range list.Metadata {
+		result.Metadata[i] = &metadata{
+			CreationDate: md.CreationDate,
+			ChangeDate:   md.ChangeDate,
+			Sequence:     md.Sequence,
+			Key:          md.Key,
+			Value:        metadataValue(md.Value),
+		}
+	}
+	return result, nil
+}
+
+// getOrgMetadata returns the metadata of the organization,
+// the optional key filters the metadata by its key
+func (m *queryModule) getOrgMetadata(key ...string) (*metadataList, error) {
+	ctx, cancel := m.config.moduleContext(m.ctx)
+	defer cancel()
+	queries := new(query.OrgMetadataSearchQueries)
+	if k := optionalArg(key); k != "" {
+		keyQuery, err := query.NewOrgMetadataKeySearchQuery(k, query.TextEquals)
+		if err != nil {
+			return nil, err
+		}
+		queries.Queries = append(queries.Queries, keyQuery)
+	}
+	list, err := m.queries.SearchOrgMetadata(ctx, true, m.orgID, queries, false)
+	if err != nil {
+		return nil, err
+	}
+	result := &metadataList{
+		Count:    list.Count,
+		Metadata: make([]*metadata, len(list.Metadata)),
+	}
+	for i, md := range list.Metadata {
+		result.Metadata[i] = &metadata{
+			CreationDate: md.CreationDate,
+			ChangeDate:   md.ChangeDate,
+			Sequence:     md.Sequence,
+			Key:          md.Key,
+			Value:        metadataValue(md.Value),
+		}
+	}
+	return result, nil
+}
+
+// metadataValue returns the json value of the metadata or the value as string if it's not json
+func metadataValue(value []byte) interface{} {
+	var v interface{}
+	if err := json.Unmarshal(value, &v); err != nil {
+		return string(value)
+	}
+	return v
+}
@@ -0,0 +1,86 @@
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+type mockQueries struct {
+	userID       string
+	orgID        string
+	queriesCount int
+}
+
+func (m *mockQueries) SearchUserMetadata(_ context.Context, _ bool, userID string, queries *query.UserMetadataSearchQueries, _ bool) (*query.UserMetadataList, error) {
+	m.userID = userID
+	m.queriesCount = len(queries.Queries)
+	return &query.UserMetadataList{
+		SearchResponse: query.SearchResponse{Count: 2, State: new(query.State)},
+		Metadata: []*query.UserMetadata{
+			{Key: "json", Value: []byte(`{"key":"value"}`)},
+			{Key: "text", Value: []byte("value")},
+		},
+	}, nil
+}
+
+func (m *mockQueries) SearchOrgMetadata(_ context.Context, _ bool, orgID string, queries *query.OrgMetadataSearchQueries, _ bool) (*query.OrgMetadataList, error) {
+	m.orgID = orgID
+	m.queriesCount = len(queries.Queries)
+	return &query.OrgMetadataList{
+		SearchResponse: query.SearchResponse{Count: 1, State: new(query.State)},
+		Metadata: []*query.OrgMetadata{
+			{Key: "key", Value: []byte(`"value"`)},
+		},
+	}, nil
+}
+
+func TestQueryModule(t *testing.T) {
+	tests := []struct {
+		name             string
+		body             string
+		want             interface{}
+		wantUserID       string
+		wantOrgID        string
+		wantQueriesCount int
+	}{
+		{
+			name: "user metadata",
+			body: `let md = require("zitadel/query").getUserMetadata("user1").metadata;
+api.setResult(md[0].value.key + " " + md[1].value);`,
+			want:       "value value",
+			wantUserID: "user1",
+			// the resource owner of the metadata is always queried
+			wantQueriesCount: 1,
+		},
+		{
+			name:             "user metadata by key",
+			body:             `api.setResult(require("zitadel/query").getUserMetadata("user1", "json").count);`,
+			want:             int64(2),
+			wantUserID:       "user1",
+			wantQueriesCount: 2,
+		},
+		{
+			name:             "org metadata",
+			body:             `api.setResult(require("zitadel/query").getOrgMetadata("key").metadata[0].value);`,
+			want:             "value",
+			wantOrgID:        "org1",
+			wantQueriesCount: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries := new(mockQueries)
+			got, err := runModuleScript(t, tt.body, WithQuery(context.Background(), queries, "org1"))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantUserID, queries.userID)
+			assert.Equal(t, tt.wantOrgID, queries.orgID)
+			assert.Equal(t, tt.wantQueriesCount, queries.queriesCount)
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package actions
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Recorder records the logs and http calls of an action run,
+// the logs are not written to the logstore.
+// It's used to test actions without side effects (dry run).
+type Recorder struct {
+	mu        sync.Mutex
+	liveHTTP  bool
+	logs      []*RecordedLog
+	httpCalls []*RecordedHTTPCall
+}
+
+type RecordedLog struct {
+	Timestamp time.Time
+	Level     logrus.Level
+	Message   string
+}
+
+type RecordedHTTPCall struct {
+	Method         string
+	URL            string
+	RequestHeaders http.Header
+	RequestBody    string
+	// Stubbed is true if the call was not sent,
+	// the action got an empty json object with status 200 as response
+	Stubbed      bool
+	StatusCode   int
+	ResponseBody string
+	Error        string
+	Took         time.Duration
+}
+
+// NewRecorder creates a recorder for a single action run,
+// the http calls are only sent if liveHTTP is true
+func NewRecorder(liveHTTP bool) *Recorder {
+	return &Recorder{liveHTTP: liveHTTP}
+}
+
+// WithRecorder records the logs and http calls of the run in the recorder
+func WithRecorder(recorder *Recorder) Option {
+	return func(c *runConfig) {
+		c.recorder = recorder
+	}
+}
+
+func (r *Recorder) Logs() []*RecordedLog {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.logs
+}
+
+func (r *Recorder) HTTPCalls() []*RecordedHTTPCall {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.httpCalls
+}
+
+func (r *Recorder) log(timestamp time.Time, level logrus.Level, msg string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.logs = append(r.logs, &RecordedLog{
+		Timestamp: timestamp,
+		Level:     level,
+		Message:   msg,
+	})
+}
+
+func (r *Recorder) httpCall(call *RecordedHTTPCall) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.httpCalls = append(r.httpCalls, call)
+}
+
+// recordingTransport records the calls of the http module,
+// the calls are passed to the next transport only for live calls
+type recordingTransport struct {
+	recorder *Recorder
+	next     http.RoundTripper
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (_ *http.Response, err error) {
+	call := &RecordedHTTPCall{
+		Method:         req.Method,
+		URL:            req.URL.String(),
+		RequestHeaders: req.Header.Clone(),
+		Stubbed:        !t.recorder.liveHTTP,
+	}
+	defer func() {
+		if err != nil {
+			call.Error = err.Error()
+		}
+		t.recorder.httpCall(call)
+	}()
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		call.RequestBody = string(body)
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	if call.Stubbed {
+		call.StatusCode = http.StatusOK
+		call.ResponseBody = "{}"
+		return &http.Response{
+			StatusCode: call.StatusCode,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(bytes.NewBufferString(call.ResponseBody)),
+			Request:    req,
+		}, nil
+	}
+	start := time.Now()
+	res, err := t.next.RoundTrip(req)
+	call.Took = time.Since(start)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	call.StatusCode = res.StatusCode
+	call.ResponseBody = string(body)
+	res.Body = io.NopCloser(bytes.NewReader(body))
+	return res, nil
+}
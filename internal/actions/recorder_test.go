@@ -0,0 +1,97 @@
+package actions
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/logstore"
+	"github.com/zitadel/zitadel/internal/logstore/record"
+)
+
+func TestRun_WithRecorder(t *testing.T) {
+	SetLogstoreService(logstore.New[*record.ExecutionLog](nil, nil))
+	var called int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called++
+		body, _ := io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+
+	script := `function testFunc() {
+	let logger = require("zitadel/log");
+	let http = require("zitadel/http");
+	let res = http.fetch("` + server.URL + `", {method: "POST", body: {key: "value"}});
+	logger.log("status " + res.status);
+	logger.warn(res.text());
+}`
+	tests := []struct {
+		name       string
+		liveHTTP   bool
+		wantCalled int
+		wantCall   *RecordedHTTPCall
+		wantLogs   []string
+	}{
+		{
+			name:       "stubbed",
+			liveHTTP:   false,
+			wantCalled: 0,
+			wantCall: &RecordedHTTPCall{
+				Method:       http.MethodPost,
+				URL:          server.URL,
+				RequestBody:  `{"key":"value"}`,
+				Stubbed:      true,
+				StatusCode:   http.StatusOK,
+				ResponseBody: "{}",
+			},
+			wantLogs: []string{actionStartedMessage, "status 200", "{}", actionSucceededMessage},
+		},
+		{
+			name:       "live",
+			liveHTTP:   true,
+			wantCalled: 1,
+			wantCall: &RecordedHTTPCall{
+				Method:       http.MethodPost,
+				URL:          server.URL,
+				RequestBody:  `{"key":"value"}`,
+				StatusCode:   http.StatusCreated,
+				ResponseBody: `{"key":"value"}`,
+			},
+			wantLogs: []string{actionStartedMessage, "status 201", `{"key":"value"}`, actionSucceededMessage},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called = 0
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			recorder := NewRecorder(tt.liveHTTP)
+			err := Run(ctx, nil, nil, script, "testFunc", WithHTTP(ctx), WithRecorder(recorder))
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCalled, called)
+
+			calls := recorder.HTTPCalls()
+			require.Len(t, calls, 1)
+			calls[0].RequestHeaders = nil
+			calls[0].Took = 0
+			assert.Equal(t, tt.wantCall, calls[0])
+
+			logs := recorder.Logs()
+			messages := make([]string, len(logs))
+			for i, log := range logs {
+				messages[i] = log.Message
+			}
+			assert.Equal(t, tt.wantLogs, messages)
+			assert.Equal(t, logrus.WarnLevel, logs[2].Level)
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package actions
+
+import (
+	"math/rand"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/dop251/goja"
+	"github.com/dop251/goja/ast"
+	"github.com/dop251/goja/token"
+	"github.com/dop251/goja/unistring"
+)
+
+const (
+	// safePointPrefix prefixes the name of the function called at the safe points of a script.
+	// The name gets a random suffix per run, so a script can't shadow the function.
+	safePointPrefix = "__zitadel_safe_point_"
+	// measureSuffix is appended to the name of the safe point for the function measuring the values
+	measureSuffix = "_measure"
+)
+
+var astPackage = reflect.TypeOf(ast.Program{}).PkgPath()
+
+// compileWithSafePoints compiles the script with a call of the safe point
+// at the beginning of each loop iteration and each function body.
+// The safe point is called on the goroutine of the vm, so it can meter the run.
+// Its argument is true for loop iterations.
+// If it returns true, the measure function is called with a function
+// returning the values of the variables referenced by the loop or function
+// and a function returning a function per variable.
+func compileWithSafePoints(script, safePoint string) (*goja.Program, error) {
+	program, err := goja.Parse("", script)
+	if err != nil {
+		return nil, err
+	}
+	insertSafePoints(reflect.ValueOf(program), safePoint)
+	return goja.CompileAST(program, false)
+}
+
+func newSafePointName() string {
+	return safePointPrefix + strconv.FormatUint(rand.Uint64(), 36)
+}
+
+// insertSafePoints walks the syntax tree and inserts the safe points into the inner nodes first,
+// so the variables referenced by the inner nodes are also passed to the safe points of the outer nodes
+func insertSafePoints(v reflect.Value, safePoint string) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return
+		}
+		insertSafePoints(v.Elem(), safePoint)
+		if v.Kind() == reflect.Pointer {
+			insertSafePoint(v.Interface(), safePoint)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			insertSafePoints(v.Index(i), safePoint)
+		}
+	case reflect.Struct:
+		if v.Type().PkgPath() != astPackage {
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				insertSafePoints(v.Field(i), safePoint)
+			}
+		}
+	}
+}
+
+func insertSafePoint(node any, safePoint string) {
+	switch n := node.(type) {
+	case *ast.ForStatement:
+		n.Body = prependStatement(n.Body, safePointCall(safePoint, true, n.Test, n.Update, n.Body))
+	case *ast.ForInStatement:
+		n.Body = prependStatement(n.Body, safePointCall(safePoint, true, n.Source, n.Body))
+	case *ast.ForOfStatement:
+		n.Body = prependStatement(n.Body, safePointCall(safePoint, true, n.Source, n.Body))
+	case *ast.WhileStatement:
+		n.Body = prependStatement(n.Body, safePointCall(safePoint, true, n.Test, n.Body))
+	case *ast.DoWhileStatement:
+		n.Body = prependStatement(n.Body, safePointCall(safePoint, true, n.Test, n.Body))
+	case *ast.FunctionLiteral:
+		prependToFunction(n.Body, safePointCall(safePoint, false, n.Body))
+	case *ast.ArrowFunctionLiteral:
+		switch body := n.Body.(type) {
+		case *ast.BlockStatement:
+			prependToFunction(body, safePointCall(safePoint, false, body))
+		case *ast.ExpressionBody:
+			n.Body = &ast.BlockStatement{List: []ast.Statement{
+				safePointCall(safePoint, false, body.Expression),
+				&ast.ReturnStatement{Argument: body.Expression},
+			}}
+		}
+	}
+}
+
+func prependStatement(body ast.Statement, statement ast.Statement) ast.Statement {
+	if block, ok := body.(*ast.BlockStatement); ok {
+		block.List = append([]ast.Statement{statement}, block.List...)
+		return block
+	}
+	return &ast.BlockStatement{List: []ast.Statement{statement, body}}
+}
+
+// prependToFunction inserts the statement after the directives (e.g. "use strict") of the function body
+func prependToFunction(body *ast.BlockStatement, statement ast.Statement) {
+	if body == nil {
+		return
+	}
+	i := 0
+	for ; i < len(body.List); i++ {
+		expression, ok := body.List[i].(*ast.ExpressionStatement)
+		if !ok {
+			break
+		}
+		if _, ok = expression.Expression.(*ast.StringLiteral); !ok {
+			break
+		}
+	}
+	body.List = append(body.List[:i], append([]ast.Statement{statement}, body.List[i:]...)...)
+}
+
+// safePointCall returns the call of the safe point,
+// the measure function gets the values of the variables referenced by the nodes
+func safePointCall(safePoint string, loop bool, nodes ...any) ast.Statement {
+	names := make(map[unistring.String]bool)
+	for _, node := range nodes {
+		referencedNames(reflect.ValueOf(node), names)
+	}
+	sorted := make([]unistring.String, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	slices.Sort(sorted)
+	values := make([]ast.Expression, len(sorted))
+	variables := make([]ast.Expression, len(sorted))
+	for i, name := range sorted {
+		values[i] = &ast.Identifier{Name: name}
+		variables[i] = arrowFunction(&ast.Identifier{Name: name})
+	}
+	// the values are read by a single function,
+	// the function per variable is only used if a variable can't be read
+	arguments := []ast.Expression{
+		arrowFunction(&ast.ArrayLiteral{Value: values}),
+		arrowFunction(&ast.ArrayLiteral{Value: variables}),
+	}
+	return &ast.ExpressionStatement{
+		Expression: &ast.BinaryExpression{
+			Operator: token.LOGICAL_AND,
+			Left: &ast.CallExpression{
+				Callee:       &ast.Identifier{Name: unistring.NewFromString(safePoint)},
+				ArgumentList: []ast.Expression{&ast.BooleanLiteral{Value: loop, Literal: strconv.FormatBool(loop)}},
+			},
+			Right: &ast.CallExpression{
+				Callee:       &ast.Identifier{Name: unistring.NewFromString(safePoint + measureSuffix)},
+				ArgumentList: arguments,
+			},
+		},
+	}
+}
+
+func arrowFunction(body ast.Expression) *ast.ArrowFunctionLiteral {
+	return &ast.ArrowFunctionLiteral{
+		ParameterList: new(ast.ParameterList),
+		Body:          &ast.ExpressionBody{Expression: body},
+	}
+}
+
+// referencedNames collects the names of the identifiers of the node.
+// Property names aren't collected, as they aren't pointers to identifiers in the syntax tree.
+// Names which aren't variables in the scope of the safe point (e.g. labels)
+// fail when the safe point reads them and are skipped.
+func referencedNames(v reflect.Value, names map[unistring.String]bool) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return
+		}
+		if identifier, ok := v.Interface().(*ast.Identifier); ok {
+			if !strings.HasPrefix(identifier.Name.String(), safePointPrefix) {
+				names[identifier.Name] = true
+			}
+			return
+		}
+		referencedNames(v.Elem(), names)
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			referencedNames(v.Index(i), names)
+		}
+	case reflect.Struct:
+		if v.Type().PkgPath() != astPackage {
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				referencedNames(v.Field(i), names)
+			}
+		}
+	}
+}
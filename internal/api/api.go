@@ -20,6 +20,7 @@ import (
 	http_mw "github.com/zitadel/zitadel/internal/api/http/middleware"
 	"github.com/zitadel/zitadel/internal/api/ui/login"
 	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/execution"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/telemetry/metrics"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
@@ -40,6 +41,7 @@ type API struct {
 
 type healthCheck interface {
 	Health(ctx context.Context) error
+	ProjectionsReady(ctx context.Context) error
 }
 
 func New(
@@ -51,6 +53,7 @@ func New(
 	authZ internal_authz.Config,
 	tlsConfig *tls.Config, http2HostName, http1HostName string,
 	accessInterceptor *http_mw.AccessInterceptor,
+	executions *execution.Executions,
 ) (_ *API, err error) {
 	api := &API{
 		port:              port,
@@ -62,7 +65,7 @@ func New(
 		accessInterceptor: accessInterceptor,
 	}
 
-	api.grpcServer = server.CreateServer(api.verifier, authZ, queries, http2HostName, tlsConfig, accessInterceptor.AccessService())
+	api.grpcServer = server.CreateServer(api.verifier, authZ, queries, http2HostName, tlsConfig, accessInterceptor.AccessService(), executions)
 	api.grpcGateway, err = server.CreateGateway(ctx, port, http1HostName, accessInterceptor, tlsConfig)
 	if err != nil {
 		return nil, err
@@ -200,6 +203,9 @@ func (a *API) healthHandler() http.Handler {
 			}
 			return nil
 		},
+		func(ctx context.Context) error {
+			return a.health.ProjectionsReady(ctx)
+		},
 	}
 	handler := http.NewServeMux()
 	handler.HandleFunc("/healthz", handleHealth)
@@ -0,0 +1,187 @@
+// Package eventstream serves the event stream of the admin API as server-sent events,
+// for clients which can't consume server-streaming gRPC.
+package eventstream
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/zitadel/logging"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	http_util "github.com/zitadel/zitadel/internal/api/http"
+	http_mw "github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/query"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+const (
+	HandlerPrefix = "/events"
+	streamPath    = "/stream"
+
+	paramPosition      = "position"
+	paramOffset        = "offset"
+	paramEventType     = "event_type"
+	paramAggregateType = "aggregate_type"
+	// headerLastEventID is sent by EventSource clients on reconnect
+	headerLastEventID = "Last-Event-ID"
+
+	streamPermission  = "events.read"
+	maxEventTypes     = 30
+	maxAggregateTypes = 10
+)
+
+type Handler struct {
+	queries    *query.Queries
+	verifier   authz.APITokenVerifier
+	authConfig authz.Config
+}
+
+func NewHandler(
+	queries *query.Queries,
+	verifier authz.APITokenVerifier,
+	authConfig authz.Config,
+	instanceInterceptor,
+	accessInterceptor func(next http.Handler) http.Handler,
+) http.Handler {
+	h := &Handler{
+		queries:    queries,
+		verifier:   verifier,
+		authConfig: authConfig,
+	}
+	router := mux.NewRouter()
+	router.Use(instanceInterceptor, accessInterceptor)
+	router.HandleFunc(streamPath, h.handleStream).Methods(http.MethodGet)
+	return http_util.CopyHeadersToContext(http_mw.CORSInterceptor(router))
+}
+
+// handleStream writes the events of the instance as server-sent events until the client disconnects.
+// The id of each event is the cursor to resume the stream with.
+func (h *Handler) handleStream(w http.ResponseWriter, r *http.Request) {
+	ctx, err := h.authorize(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	streamQuery, err := streamQueryFromRequest(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, errors.ThrowInternal(nil, "STREAM-aiS4e", "Errors.Internal"))
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	// disable response buffering of nginx
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	err = h.queries.StreamEvents(ctx, streamQuery, func(event *query.StreamedEvent) error {
+		res, err := admin_pb.StreamedEventToPb(event)
+		if err != nil {
+			return err
+		}
+		data, err := protojson.Marshal(res)
+		if err != nil {
+			return err
+		}
+		if _, err = fmt.Fprintf(w, "id: %s\nevent: event\ndata: %s\n\n", formatCursor(event.Cursor), data); err != nil {
+			return err
+		}
+		flusher.Flush()
+		return nil
+	})
+	if err == nil || ctx.Err() != nil {
+		return
+	}
+	logging.WithError(err).Warn("event stream stopped")
+	// the status is already sent, the client is informed by an error event
+	_, err = fmt.Fprintf(w, "event: error\ndata: %s\n\n", strings.ReplaceAll(err.Error(), "\n", " "))
+	logging.OnError(err).Debug("unable to write error event")
+	flusher.Flush()
+}
+
+func (h *Handler) authorize(r *http.Request) (_ context.Context, err error) {
+	token := http_util.GetAuthorization(r)
+	if token == "" {
+		return nil, errors.ThrowUnauthenticated(nil, "STREAM-Eek1i", "auth header missing")
+	}
+	ctxSetter, err := authz.CheckUserAuthorization(r.Context(), nil, token, http_util.GetOrgID(r), "", h.verifier, h.authConfig, authz.Option{Permission: streamPermission}, r.Method+":"+HandlerPrefix+streamPath)
+	if err != nil {
+		return nil, err
+	}
+	return ctxSetter(r.Context()), nil
+}
+
+func streamQueryFromRequest(r *http.Request) (*query.EventStreamQuery, error) {
+	params := r.URL.Query()
+	cursor, err := parseCursor(r.Header.Get(headerLastEventID), params.Get(paramPosition), params.Get(paramOffset))
+	if err != nil {
+		return nil, err
+	}
+	if len(params[paramEventType]) > maxEventTypes || len(params[paramAggregateType]) > maxAggregateTypes {
+		return nil, errors.ThrowInvalidArgument(nil, "STREAM-Ma0sh", "Errors.Query.InvalidRequest")
+	}
+	eventTypes := make([]eventstore.EventType, len(params[paramEventType]))
+	for i, eventType := range params[paramEventType] {
+		eventTypes[i] = eventstore.EventType(eventType)
+	}
+	aggregateTypes := make([]eventstore.AggregateType, len(params[paramAggregateType]))
+	for i, aggregateType := range params[paramAggregateType] {
+		aggregateTypes[i] = eventstore.AggregateType(aggregateType)
+	}
+	return &query.EventStreamQuery{
+		Cursor:         cursor,
+		EventTypes:     eventTypes,
+		AggregateTypes: aggregateTypes,
+	}, nil
+}
+
+// parseCursor returns the cursor of the last received event,
+// the Last-Event-ID of a reconnecting client takes precedence over the query params
+func parseCursor(lastEventID, position, offset string) (cursor query.EventStreamCursor, err error) {
+	if lastEventID != "" {
+		var found bool
+		position, offset, found = strings.Cut(lastEventID, ":")
+		if !found {
+			return cursor, errors.ThrowInvalidArgument(nil, "STREAM-Oog2u", "Errors.Query.InvalidRequest")
+		}
+	}
+	if position != "" {
+		if cursor.Position, err = strconv.ParseFloat(position, 64); err != nil {
+			return cursor, errors.ThrowInvalidArgument(err, "STREAM-ieX2o", "Errors.Query.InvalidRequest")
+		}
+	}
+	if offset != "" {
+		parsed, err := strconv.ParseUint(offset, 10, 16)
+		if err != nil {
+			return cursor, errors.ThrowInvalidArgument(err, "STREAM-Ohx3a", "Errors.Query.InvalidRequest")
+		}
+		cursor.Offset = uint16(parsed)
+	}
+	return cursor, nil
+}
+
+func formatCursor(cursor query.EventStreamCursor) string {
+	return strconv.FormatFloat(cursor.Position, 'f', -1, 64) + ":" + strconv.FormatUint(uint64(cursor.Offset), 10)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	code, ok := http_util.ZitadelErrorToHTTPStatusCode(err)
+	if !ok {
+		code = http.StatusInternalServerError
+	}
+	http.Error(w, err.Error(), code)
+}
@@ -0,0 +1,90 @@
+package eventstream
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func Test_parseCursor(t *testing.T) {
+	tests := []struct {
+		name        string
+		lastEventID string
+		position    string
+		offset      string
+		want        query.EventStreamCursor
+		wantErr     bool
+	}{
+		{
+			name: "start",
+			want: query.EventStreamCursor{},
+		},
+		{
+			name:     "params",
+			position: "1694434535.2957969",
+			offset:   "2",
+			want:     query.EventStreamCursor{Position: 1694434535.2957969, Offset: 2},
+		},
+		{
+			name:        "last event id before params",
+			lastEventID: "1694434536.5:1",
+			position:    "1694434535.2957969",
+			offset:      "2",
+			want:        query.EventStreamCursor{Position: 1694434536.5, Offset: 1},
+		},
+		{
+			name:        "invalid last event id",
+			lastEventID: "1694434536.5",
+			wantErr:     true,
+		},
+		{
+			name:     "invalid position",
+			position: "position",
+			wantErr:  true,
+		},
+		{
+			name:     "offset too big",
+			position: "1694434535.2957969",
+			offset:   "65536",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseCursor(tt.lastEventID, tt.position, tt.offset)
+			if tt.wantErr {
+				assert.True(t, errors.IsErrorInvalidArgument(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_formatCursor(t *testing.T) {
+	cursor := query.EventStreamCursor{Position: 1694434535.2957969, Offset: 3}
+	formatted := formatCursor(cursor)
+	assert.Equal(t, "1694434535.2957969:3", formatted)
+
+	parsed, err := parseCursor(formatted, "", "")
+	require.NoError(t, err)
+	assert.Equal(t, cursor, parsed)
+}
+
+func Test_streamQueryFromRequest(t *testing.T) {
+	r := httptest.NewRequest("GET", "/stream?position=1.5&offset=1&event_type=user.human.added&event_type=user.machine.added&aggregate_type=user", nil)
+	got, err := streamQueryFromRequest(r)
+	require.NoError(t, err)
+	assert.Equal(t, &query.EventStreamQuery{
+		Cursor:         query.EventStreamCursor{Position: 1.5, Offset: 1},
+		EventTypes:     []eventstore.EventType{"user.human.added", "user.machine.added"},
+		AggregateTypes: []eventstore.AggregateType{"user"},
+	}, got)
+}
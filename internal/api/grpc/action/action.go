@@ -2,6 +2,7 @@ package action
 
 import (
 	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	object_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
 	"github.com/zitadel/zitadel/internal/domain"
@@ -21,6 +22,10 @@ func FlowTypeToDomain(flowType string) domain.FlowType {
 		return domain.FlowTypeInternalAuthentication
 	case domain.FlowTypeCustomizeSAMLResponse.ID():
 		return domain.FlowTypeCustomizeSAMLResponse
+	case domain.FlowTypePasswordReset.ID():
+		return domain.FlowTypePasswordReset
+	case domain.FlowTypeLogout.ID():
+		return domain.FlowTypeLogout
 	default:
 		return domain.FlowTypeUnspecified
 	}
@@ -51,6 +56,16 @@ func TriggerTypeToDomain(triggerType string) domain.TriggerType {
 		return domain.TriggerTypePreUserinfoCreation
 	case domain.TriggerTypePreSAMLResponseCreation.ID():
 		return domain.TriggerTypePreSAMLResponseCreation
+	case domain.TriggerTypePreRegistrationValidation.ID():
+		return domain.TriggerTypePreRegistrationValidation
+	case domain.TriggerTypePrePasswordReset.ID():
+		return domain.TriggerTypePrePasswordReset
+	case domain.TriggerTypePostPasswordReset.ID():
+		return domain.TriggerTypePostPasswordReset
+	case domain.TriggerTypePostLogout.ID():
+		return domain.TriggerTypePostLogout
+	case domain.TriggerTypePreRefreshTokenCreation.ID():
+		return domain.TriggerTypePreRefreshTokenCreation
 	default:
 		return domain.TriggerTypeUnspecified
 	}
@@ -107,13 +122,34 @@ func ActionsToPb(actions []*query.Action) []*action_pb.Action {
 
 func ActionToPb(action *query.Action) *action_pb.Action {
 	return &action_pb.Action{
-		Id:            action.ID,
-		Details:       object_grpc.ChangeToDetailsPb(action.Sequence, action.ChangeDate, action.ResourceOwner),
-		State:         ActionStateToPb(action.State),
-		Name:          action.Name,
-		Script:        action.Script,
-		Timeout:       durationpb.New(action.Timeout()),
-		AllowedToFail: action.AllowedToFail,
+		Id:               action.ID,
+		Details:          object_grpc.ChangeToDetailsPb(action.Sequence, action.ChangeDate, action.ResourceOwner),
+		State:            ActionStateToPb(action.State),
+		Name:             action.Name,
+		Script:           action.Script,
+		Timeout:          durationpb.New(action.Timeout()),
+		AllowedToFail:    action.AllowedToFail,
+		Version:          action.Version,
+		CanaryVersion:    action.CanaryVersion,
+		CanaryPercentage: action.CanaryPercentage,
+	}
+}
+
+func ActionVersionsToPb(versions []*query.ActionVersion) []*action_pb.ActionVersion {
+	list := make([]*action_pb.ActionVersion, len(versions))
+	for i, version := range versions {
+		list[i] = ActionVersionToPb(version)
+	}
+	return list
+}
+
+func ActionVersionToPb(version *query.ActionVersion) *action_pb.ActionVersion {
+	return &action_pb.ActionVersion{
+		Version:       version.Version,
+		CreationDate:  timestamppb.New(version.CreationDate),
+		Script:        version.Script,
+		Timeout:       durationpb.New(version.Timeout),
+		AllowedToFail: version.AllowedToFail,
 	}
 }
 
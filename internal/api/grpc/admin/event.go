@@ -6,6 +6,7 @@ import (
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/query"
 	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
 )
 
@@ -26,6 +27,16 @@ func (s *Server) ListEvents(ctx context.Context, in *admin_pb.ListEventsRequest)
 	return admin_pb.EventsToPb(ctx, events)
 }
 
+func (s *Server) StreamEvents(in *admin_pb.StreamEventsRequest, stream admin_pb.AdminService_StreamEventsServer) error {
+	return s.query.StreamEvents(stream.Context(), streamEventsRequestToQuery(in), func(event *query.StreamedEvent) error {
+		res, err := admin_pb.StreamedEventToPb(event)
+		if err != nil {
+			return err
+		}
+		return stream.Send(res)
+	})
+}
+
 func (s *Server) ListEventTypes(ctx context.Context, in *admin_pb.ListEventTypesRequest) (*admin_pb.ListEventTypesResponse, error) {
 	eventTypes := s.query.SearchEventTypes(ctx)
 	return admin_pb.EventTypesToPb(eventTypes), nil
@@ -100,3 +111,22 @@ func eventRequestToFilter(ctx context.Context, req *admin_pb.ListEventsRequest)
 	}
 	return builder, nil
 }
+
+func streamEventsRequestToQuery(req *admin_pb.StreamEventsRequest) *query.EventStreamQuery {
+	eventTypes := make([]eventstore.EventType, len(req.EventTypes))
+	for i, eventType := range req.EventTypes {
+		eventTypes[i] = eventstore.EventType(eventType)
+	}
+	aggregateTypes := make([]eventstore.AggregateType, len(req.AggregateTypes))
+	for i, aggregateType := range req.AggregateTypes {
+		aggregateTypes[i] = eventstore.AggregateType(aggregateType)
+	}
+	return &query.EventStreamQuery{
+		Cursor: query.EventStreamCursor{
+			Position: req.Position,
+			Offset:   uint16(req.Offset),
+		},
+		AggregateTypes: aggregateTypes,
+		EventTypes:     eventTypes,
+	}
+}
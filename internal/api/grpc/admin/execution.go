@@ -0,0 +1,72 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func (s *Server) SetTarget(ctx context.Context, req *admin.SetTargetRequest) (*admin.SetTargetResponse, error) {
+	target := targetPbToCommand(req)
+	details, err := s.command.SetTarget(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	return &admin.SetTargetResponse{
+		Id:      target.ID,
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RemoveTarget(ctx context.Context, req *admin.RemoveTargetRequest) (*admin.RemoveTargetResponse, error) {
+	details, err := s.command.RemoveTarget(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &admin.RemoveTargetResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) ListTargets(ctx context.Context, _ *admin.ListTargetsRequest) (*admin.ListTargetsResponse, error) {
+	targets, err := s.query.Targets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &admin.ListTargetsResponse{
+		Details: object.ToListDetails(targets.Count, targets.Sequence, targets.LastRun),
+		Result:  targetsToPb(targets.Targets),
+	}, nil
+}
+
+func (s *Server) SetExecution(ctx context.Context, req *admin.SetExecutionRequest) (*admin.SetExecutionResponse, error) {
+	details, err := s.command.SetExecution(ctx, executionTypePbToDomain(req.GetType()), req.GetCondition(), req.GetTargets())
+	if err != nil {
+		return nil, err
+	}
+	return &admin.SetExecutionResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RemoveExecution(ctx context.Context, req *admin.RemoveExecutionRequest) (*admin.RemoveExecutionResponse, error) {
+	details, err := s.command.RemoveExecution(ctx, executionTypePbToDomain(req.GetType()), req.GetCondition())
+	if err != nil {
+		return nil, err
+	}
+	return &admin.RemoveExecutionResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) ListExecutions(ctx context.Context, _ *admin.ListExecutionsRequest) (*admin.ListExecutionsResponse, error) {
+	executions, err := s.query.Executions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &admin.ListExecutionsResponse{
+		Details: object.ToListDetails(executions.Count, executions.Sequence, executions.LastRun),
+		Result:  executionsToPb(executions.Executions),
+	}, nil
+}
@@ -0,0 +1,77 @@
+package admin
+
+import (
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func targetPbToCommand(req *admin.SetTargetRequest) *command.Target {
+	return &command.Target{
+		ID:               req.GetId(),
+		Name:             req.GetName(),
+		Endpoint:         req.GetEndpoint(),
+		Timeout:          req.GetTimeout().AsDuration(),
+		InterruptOnError: req.GetInterruptOnError(),
+		SigningKey:       req.GetSigningKey(),
+	}
+}
+
+func targetsToPb(targets []*query.Target) []*admin.Target {
+	result := make([]*admin.Target, len(targets))
+	for i, target := range targets {
+		result[i] = &admin.Target{
+			Id:               target.ID,
+			Details:          object.ToViewDetailsPb(target.Sequence, target.CreationDate, target.ChangeDate, target.InstanceID),
+			Name:             target.Name,
+			Endpoint:         target.Endpoint,
+			Timeout:          durationpb.New(target.Timeout),
+			InterruptOnError: target.InterruptOnError,
+		}
+	}
+	return result
+}
+
+func executionTypePbToDomain(executionType admin.ExecutionType) domain.ExecutionType {
+	switch executionType {
+	case admin.ExecutionType_EXECUTION_TYPE_REQUEST:
+		return domain.ExecutionTypeRequest
+	case admin.ExecutionType_EXECUTION_TYPE_RESPONSE:
+		return domain.ExecutionTypeResponse
+	case admin.ExecutionType_EXECUTION_TYPE_EVENT:
+		return domain.ExecutionTypeEvent
+	default:
+		return domain.ExecutionTypeUnspecified
+	}
+}
+
+func executionTypeToPb(executionType domain.ExecutionType) admin.ExecutionType {
+	switch executionType {
+	case domain.ExecutionTypeRequest:
+		return admin.ExecutionType_EXECUTION_TYPE_REQUEST
+	case domain.ExecutionTypeResponse:
+		return admin.ExecutionType_EXECUTION_TYPE_RESPONSE
+	case domain.ExecutionTypeEvent:
+		return admin.ExecutionType_EXECUTION_TYPE_EVENT
+	default:
+		return admin.ExecutionType_EXECUTION_TYPE_UNSPECIFIED
+	}
+}
+
+func executionsToPb(executions []*query.Execution) []*admin.Execution {
+	result := make([]*admin.Execution, len(executions))
+	for i, execution := range executions {
+		executionType, condition := domain.ExecutionTypeAndCondition(execution.ID)
+		result[i] = &admin.Execution{
+			Details:   object.ToViewDetailsPb(execution.Sequence, execution.CreationDate, execution.ChangeDate, execution.InstanceID),
+			Type:      executionTypeToPb(executionType),
+			Condition: condition,
+			Targets:   execution.Targets,
+		}
+	}
+	return result
+}
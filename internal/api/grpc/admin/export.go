@@ -497,6 +497,9 @@ func (s *Server) getLockoutPolicy(ctx context.Context, orgID string) (_ *managem
 	if !queriedLockout.IsDefault {
 		return &management_pb.AddCustomLockoutPolicyRequest{
 			MaxPasswordAttempts: uint32(queriedLockout.MaxPasswordAttempts),
+			MaxOtpAttempts:      uint32(queriedLockout.MaxOTPAttempts),
+			AutoUnlockAfter:     durationpb.New(queriedLockout.AutoUnlockAfter),
+			FailedAttemptDelay:  durationpb.New(queriedLockout.FailedAttemptDelay),
 		}, nil
 	}
 	return nil, nil
@@ -512,11 +515,13 @@ func (s *Server) getPasswordComplexityPolicy(ctx context.Context, orgID string)
 	}
 	if !queriedPasswordComplexity.IsDefault {
 		return &management_pb.AddCustomPasswordComplexityPolicyRequest{
-			MinLength:    queriedPasswordComplexity.MinLength,
-			HasUppercase: queriedPasswordComplexity.HasUppercase,
-			HasLowercase: queriedPasswordComplexity.HasLowercase,
-			HasNumber:    queriedPasswordComplexity.HasNumber,
-			HasSymbol:    queriedPasswordComplexity.HasSymbol,
+			MinLength:     queriedPasswordComplexity.MinLength,
+			HasUppercase:  queriedPasswordComplexity.HasUppercase,
+			HasLowercase:  queriedPasswordComplexity.HasLowercase,
+			HasNumber:     queriedPasswordComplexity.HasNumber,
+			HasSymbol:     queriedPasswordComplexity.HasSymbol,
+			HistoryDepth:  queriedPasswordComplexity.HistoryDepth,
+			CheckBreached: queriedPasswordComplexity.CheckBreached,
 		}, nil
 	}
 	return nil, nil
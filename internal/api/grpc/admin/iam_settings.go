@@ -5,6 +5,7 @@ import (
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/api/grpc/settings"
 	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
 )
 
@@ -47,7 +48,11 @@ func (s *Server) UpdateSecretGenerator(ctx context.Context, req *admin_pb.Update
 }
 
 func (s *Server) GetSMTPConfig(ctx context.Context, req *admin_pb.GetSMTPConfigRequest) (*admin_pb.GetSMTPConfigResponse, error) {
-	smtp, err := s.query.SMTPConfigByAggregateID(ctx, authz.GetInstance(ctx).InstanceID())
+	id := req.Id
+	if id == "" {
+		id = authz.GetInstance(ctx).InstanceID()
+	}
+	smtp, err := s.query.SMTPConfigByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -56,8 +61,23 @@ func (s *Server) GetSMTPConfig(ctx context.Context, req *admin_pb.GetSMTPConfigR
 	}, nil
 }
 
+func (s *Server) ListSMTPConfigs(ctx context.Context, req *admin_pb.ListSMTPConfigsRequest) (*admin_pb.ListSMTPConfigsResponse, error) {
+	queries, err := listSMTPConfigsToModel(req)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.query.SearchSMTPConfigs(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ListSMTPConfigsResponse{
+		Details: object.ToListDetails(result.Count, result.Sequence, result.LastRun),
+		Result:  SMTPConfigsToPb(result.SMTPConfigs),
+	}, nil
+}
+
 func (s *Server) AddSMTPConfig(ctx context.Context, req *admin_pb.AddSMTPConfigRequest) (*admin_pb.AddSMTPConfigResponse, error) {
-	details, err := s.command.AddSMTPConfig(ctx, AddSMTPToConfig(req))
+	id, details, err := s.command.AddSMTPConfig(ctx, AddSMTPToConfig(req))
 	if err != nil {
 		return nil, err
 	}
@@ -66,11 +86,12 @@ func (s *Server) AddSMTPConfig(ctx context.Context, req *admin_pb.AddSMTPConfigR
 			details.Sequence,
 			details.EventDate,
 			details.ResourceOwner),
+		Id: id,
 	}, nil
 }
 
 func (s *Server) UpdateSMTPConfig(ctx context.Context, req *admin_pb.UpdateSMTPConfigRequest) (*admin_pb.UpdateSMTPConfigResponse, error) {
-	details, err := s.command.ChangeSMTPConfig(ctx, UpdateSMTPToConfig(req))
+	details, err := s.command.ChangeSMTPConfig(ctx, req.Id, UpdateSMTPToConfig(req))
 	if err != nil {
 		return nil, err
 	}
@@ -82,8 +103,8 @@ func (s *Server) UpdateSMTPConfig(ctx context.Context, req *admin_pb.UpdateSMTPC
 	}, nil
 }
 
-func (s *Server) RemoveSMTPConfig(ctx context.Context, _ *admin_pb.RemoveSMTPConfigRequest) (*admin_pb.RemoveSMTPConfigResponse, error) {
-	details, err := s.command.RemoveSMTPConfig(ctx)
+func (s *Server) RemoveSMTPConfig(ctx context.Context, req *admin_pb.RemoveSMTPConfigRequest) (*admin_pb.RemoveSMTPConfigResponse, error) {
+	details, err := s.command.RemoveSMTPConfig(ctx, req.Id)
 	if err != nil {
 		return nil, err
 	}
@@ -96,7 +117,7 @@ func (s *Server) RemoveSMTPConfig(ctx context.Context, _ *admin_pb.RemoveSMTPCon
 }
 
 func (s *Server) UpdateSMTPConfigPassword(ctx context.Context, req *admin_pb.UpdateSMTPConfigPasswordRequest) (*admin_pb.UpdateSMTPConfigPasswordResponse, error) {
-	details, err := s.command.ChangeSMTPConfigPassword(ctx, req.Password)
+	details, err := s.command.ChangeSMTPConfigPassword(ctx, req.Id, req.Password)
 	if err != nil {
 		return nil, err
 	}
@@ -108,6 +129,41 @@ func (s *Server) UpdateSMTPConfigPassword(ctx context.Context, req *admin_pb.Upd
 	}, nil
 }
 
+func (s *Server) GetNotificationProviderOrder(ctx context.Context, req *admin_pb.GetNotificationProviderOrderRequest) (*admin_pb.GetNotificationProviderOrderResponse, error) {
+	order, err := s.query.DefaultNotificationProviderOrder(ctx, settings.NotificationChannelToDomain(req.Channel))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetNotificationProviderOrderResponse{
+		Order: settings.NotificationProviderOrderToPb(order),
+	}, nil
+}
+
+func (s *Server) SetNotificationProviderOrder(ctx context.Context, req *admin_pb.SetNotificationProviderOrderRequest) (*admin_pb.SetNotificationProviderOrderResponse, error) {
+	details, err := s.command.SetDefaultNotificationProviderOrder(ctx, settings.NotificationChannelToDomain(req.Channel), req.ProviderIds)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetNotificationProviderOrderResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) ListNotificationDeliveries(ctx context.Context, req *admin_pb.ListNotificationDeliveriesRequest) (*admin_pb.ListNotificationDeliveriesResponse, error) {
+	queries, err := listNotificationDeliveriesToModel(req)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.query.SearchNotificationMessages(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ListNotificationDeliveriesResponse{
+		Details: object.ToListDetails(result.Count, result.Sequence, result.LastRun),
+		Result:  settings.NotificationDeliveriesToPb(result.NotificationMessages),
+	}, nil
+}
+
 func (s *Server) GetSecurityPolicy(ctx context.Context, req *admin_pb.GetSecurityPolicyRequest) (*admin_pb.GetSecurityPolicyResponse, error) {
 	policy, err := s.query.SecurityPolicy(ctx)
 	if err != nil {
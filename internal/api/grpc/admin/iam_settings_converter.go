@@ -5,6 +5,7 @@ import (
 
 	"github.com/zitadel/zitadel/internal/api/grpc/object"
 	obj_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/api/grpc/settings"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
@@ -157,6 +158,42 @@ func UpdateSMTPToConfig(req *admin_pb.UpdateSMTPConfigRequest) *smtp.Config {
 	}
 }
 
+func listSMTPConfigsToModel(req *admin_pb.ListSMTPConfigsRequest) (*query.SMTPConfigsSearchQueries, error) {
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	return &query.SMTPConfigsSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+	}, nil
+}
+
+func listNotificationDeliveriesToModel(req *admin_pb.ListNotificationDeliveriesRequest) (*query.NotificationMessageSearchQueries, error) {
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	queries, err := settings.NotificationDeliveryQueriesToQuery(req.UserId, req.State)
+	if err != nil {
+		return nil, err
+	}
+	return &query.NotificationMessageSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset:        offset,
+			Limit:         limit,
+			Asc:           asc,
+			SortingColumn: query.NotificationMessageColumnCreationDate,
+		},
+		Queries: queries,
+	}, nil
+}
+
+func SMTPConfigsToPb(configs []*query.SMTPConfig) []*settings_pb.SMTPConfig {
+	c := make([]*settings_pb.SMTPConfig, len(configs))
+	for i, config := range configs {
+		c[i] = SMTPConfigToPb(config)
+	}
+	return c
+}
+
 func SMTPConfigToPb(smtp *query.SMTPConfig) *settings_pb.SMTPConfig {
 	mapped := &settings_pb.SMTPConfig{
 		Tls:            smtp.TLS,
@@ -166,6 +203,7 @@ func SMTPConfigToPb(smtp *query.SMTPConfig) *settings_pb.SMTPConfig {
 		Host:           smtp.Host,
 		User:           smtp.User,
 		Details:        obj_grpc.ToViewDetailsPb(smtp.Sequence, smtp.CreationDate, smtp.ChangeDate, smtp.AggregateID),
+		Id:             smtp.ID,
 	}
 	return mapped
 }
@@ -8,5 +8,8 @@ import (
 func UpdateLockoutPolicyToDomain(p *admin.UpdateLockoutPolicyRequest) *domain.LockoutPolicy {
 	return &domain.LockoutPolicy{
 		MaxPasswordAttempts: uint64(p.MaxPasswordAttempts),
+		MaxOTPAttempts:      uint64(p.MaxOtpAttempts),
+		AutoUnlockAfter:     p.AutoUnlockAfter.AsDuration(),
+		FailedAttemptDelay:  p.FailedAttemptDelay.AsDuration(),
 	}
 }
@@ -1,8 +1,14 @@
 package admin
 
 import (
+	"net/http"
+	"slices"
+	"strings"
+
 	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/notification/channels/httpsms"
 	"github.com/zitadel/zitadel/internal/notification/channels/twilio"
 	"github.com/zitadel/zitadel/internal/query"
 	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
@@ -41,6 +47,9 @@ func SMSConfigToPb(config *query.SMSConfig) settings_pb.SMSConfig {
 	if config.TwilioConfig != nil {
 		return TwilioConfigToPb(config.TwilioConfig)
 	}
+	if config.HTTPConfig != nil {
+		return HTTPConfigToPb(config.HTTPConfig)
+	}
 	return nil
 }
 
@@ -53,6 +62,48 @@ func TwilioConfigToPb(twilio *query.Twilio) *settings_pb.SMSProvider_Twilio {
 	}
 }
 
+func HTTPConfigToPb(config *query.HTTP) *settings_pb.SMSProvider_Http {
+	successStatusCodes := make([]int32, len(config.SuccessStatusCodes))
+	for i, code := range config.SuccessStatusCodes {
+		successStatusCodes[i] = int32(code)
+	}
+	return &settings_pb.SMSProvider_Http{
+		Http: &settings_pb.HTTPConfig{
+			Url:                config.URL,
+			Method:             config.Method,
+			Headers:            smsHTTPHeadersToPb(config.Headers),
+			BodyTemplate:       config.BodyTemplate,
+			AuthType:           smsHTTPAuthTypeToPb(config.AuthType),
+			Username:           config.Username,
+			SuccessStatusCodes: successStatusCodes,
+			SuccessBodyPattern: config.SuccessBodyPattern,
+		},
+	}
+}
+
+// smsHTTPHeadersToPb only returns the names of the headers, as the values are stored encrypted
+func smsHTTPHeadersToPb(headers map[string]*crypto.CryptoValue) []*settings_pb.SMSHTTPHeader {
+	h := make([]*settings_pb.SMSHTTPHeader, 0, len(headers))
+	for key := range headers {
+		h = append(h, &settings_pb.SMSHTTPHeader{Key: key})
+	}
+	slices.SortFunc(h, func(a, b *settings_pb.SMSHTTPHeader) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+	return h
+}
+
+func smsHTTPAuthTypeToPb(authType domain.SMSHTTPAuthType) settings_pb.SMSHTTPAuthType {
+	switch authType {
+	case domain.SMSHTTPAuthTypeBasic:
+		return settings_pb.SMSHTTPAuthType_SMS_HTTP_AUTH_TYPE_BASIC
+	case domain.SMSHTTPAuthTypeBearer:
+		return settings_pb.SMSHTTPAuthType_SMS_HTTP_AUTH_TYPE_BEARER
+	default:
+		return settings_pb.SMSHTTPAuthType_SMS_HTTP_AUTH_TYPE_NONE
+	}
+}
+
 func smsStateToPb(state domain.SMSConfigState) settings_pb.SMSProviderConfigState {
 	switch state {
 	case domain.SMSConfigStateInactive:
@@ -78,3 +129,63 @@ func UpdateSMSConfigTwilioToConfig(req *admin_pb.UpdateSMSProviderTwilioRequest)
 		SenderNumber: req.SenderNumber,
 	}
 }
+
+func AddSMSConfigHTTPToConfig(req *admin_pb.AddSMSProviderHTTPRequest) *httpsms.Config {
+	return &httpsms.Config{
+		URL:                req.Url,
+		Method:             req.Method,
+		Headers:            smsHTTPHeadersToConfig(req.Headers),
+		BodyTemplate:       req.BodyTemplate,
+		AuthType:           smsHTTPAuthTypeToDomain(req.AuthType),
+		Username:           req.Username,
+		Secret:             req.Secret,
+		SuccessStatusCodes: smsHTTPStatusCodesToConfig(req.SuccessStatusCodes),
+		SuccessBodyPattern: req.SuccessBodyPattern,
+	}
+}
+
+func UpdateSMSConfigHTTPToConfig(req *admin_pb.UpdateSMSProviderHTTPRequest) *httpsms.Config {
+	return &httpsms.Config{
+		URL:                req.Url,
+		Method:             req.Method,
+		Headers:            smsHTTPHeadersToConfig(req.Headers),
+		BodyTemplate:       req.BodyTemplate,
+		AuthType:           smsHTTPAuthTypeToDomain(req.AuthType),
+		Username:           req.Username,
+		SuccessStatusCodes: smsHTTPStatusCodesToConfig(req.SuccessStatusCodes),
+		SuccessBodyPattern: req.SuccessBodyPattern,
+	}
+}
+
+func smsHTTPHeadersToConfig(headers []*settings_pb.SMSHTTPHeader) http.Header {
+	if len(headers) == 0 {
+		return nil
+	}
+	h := make(http.Header, len(headers))
+	for _, header := range headers {
+		h.Add(header.GetKey(), header.GetValue())
+	}
+	return h
+}
+
+func smsHTTPStatusCodesToConfig(codes []int32) []int {
+	if len(codes) == 0 {
+		return nil
+	}
+	c := make([]int, len(codes))
+	for i, code := range codes {
+		c[i] = int(code)
+	}
+	return c
+}
+
+func smsHTTPAuthTypeToDomain(authType settings_pb.SMSHTTPAuthType) domain.SMSHTTPAuthType {
+	switch authType {
+	case settings_pb.SMSHTTPAuthType_SMS_HTTP_AUTH_TYPE_BASIC:
+		return domain.SMSHTTPAuthTypeBasic
+	case settings_pb.SMSHTTPAuthType_SMS_HTTP_AUTH_TYPE_BEARER:
+		return domain.SMSHTTPAuthTypeBearer
+	default:
+		return domain.SMSHTTPAuthTypeNone
+	}
+}
@@ -3,6 +3,7 @@ package management
 import (
 	"context"
 
+	"github.com/zitadel/zitadel/internal/actions/dryrun"
 	"github.com/zitadel/zitadel/internal/api/authz"
 	action_grpc "github.com/zitadel/zitadel/internal/api/grpc/action"
 	obj_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
@@ -88,6 +89,44 @@ func (s *Server) ReactivateAction(ctx context.Context, req *mgmt_pb.ReactivateAc
 	}, nil
 }
 
+func (s *Server) ListActionVersions(ctx context.Context, req *mgmt_pb.ListActionVersionsRequest) (*mgmt_pb.ListActionVersionsResponse, error) {
+	versions, err := s.query.ListActionVersions(ctx, req.Id, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListActionVersionsResponse{
+		Result: action_grpc.ActionVersionsToPb(versions),
+	}, nil
+}
+
+func (s *Server) ActivateActionVersion(ctx context.Context, req *mgmt_pb.ActivateActionVersionRequest) (*mgmt_pb.ActivateActionVersionResponse, error) {
+	details, err := s.command.ActivateActionVersion(ctx, req.Id, req.Version, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ActivateActionVersionResponse{
+		Details: obj_grpc.ChangeToDetailsPb(
+			details.Sequence,
+			details.EventDate,
+			details.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) RemoveActionCanary(ctx context.Context, req *mgmt_pb.RemoveActionCanaryRequest) (*mgmt_pb.RemoveActionCanaryResponse, error) {
+	details, err := s.command.RemoveActionCanary(ctx, req.Id, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RemoveActionCanaryResponse{
+		Details: obj_grpc.ChangeToDetailsPb(
+			details.Sequence,
+			details.EventDate,
+			details.ResourceOwner,
+		),
+	}, nil
+}
+
 func (s *Server) DeleteAction(ctx context.Context, req *mgmt_pb.DeleteActionRequest) (*mgmt_pb.DeleteActionResponse, error) {
 	flowTypes, err := s.query.GetFlowTypesOfActionID(ctx, req.Id)
 	if err != nil {
@@ -96,3 +135,11 @@ func (s *Server) DeleteAction(ctx context.Context, req *mgmt_pb.DeleteActionRequ
 	_, err = s.command.DeleteAction(ctx, req.Id, authz.GetCtxData(ctx).OrgID, flowTypes...)
 	return &mgmt_pb.DeleteActionResponse{}, err
 }
+
+func (s *Server) DryRunAction(ctx context.Context, req *mgmt_pb.DryRunActionRequest) (*mgmt_pb.DryRunActionResponse, error) {
+	result, err := dryrun.Run(ctx, s.query, dryRunActionRequestToDryRun(authz.GetCtxData(ctx).OrgID, req))
+	if err != nil {
+		return nil, err
+	}
+	return dryRunResultToPb(result)
+}
@@ -1,8 +1,18 @@
 package management
 
 import (
+	"encoding/json"
+
+	"golang.org/x/text/language"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/actions/dryrun"
 	action_grpc "github.com/zitadel/zitadel/internal/api/grpc/action"
 	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	user_grpc "github.com/zitadel/zitadel/internal/api/grpc/user"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
@@ -24,10 +34,11 @@ func updateActionRequestToDomain(req *mgmt_pb.UpdateActionRequest) *domain.Actio
 		ObjectRoot: models.ObjectRoot{
 			AggregateID: req.Id,
 		},
-		Name:          req.Name,
-		Script:        req.Script,
-		Timeout:       req.Timeout.AsDuration(),
-		AllowedToFail: req.AllowedToFail,
+		Name:             req.Name,
+		Script:           req.Script,
+		Timeout:          req.Timeout.AsDuration(),
+		AllowedToFail:    req.AllowedToFail,
+		CanaryPercentage: req.CanaryPercentage,
 	}
 }
 
@@ -65,3 +76,144 @@ func ActionQueryToQuery(query interface{}) (query.SearchQuery, error) {
 	}
 	return nil, errors.ThrowInvalidArgument(nil, "MGMT-dsg3z", "Errors.Query.InvalidRequest")
 }
+
+func dryRunActionRequestToDryRun(orgID string, req *mgmt_pb.DryRunActionRequest) *dryrun.Request {
+	return &dryrun.Request{
+		Script:        req.Script,
+		FunctionName:  req.Name,
+		Timeout:       req.Timeout.AsDuration(),
+		AllowedToFail: req.AllowedToFail,
+		FlowType:      action_grpc.FlowTypeToDomain(req.FlowType),
+		TriggerType:   action_grpc.TriggerTypeToDomain(req.TriggerType),
+		LiveHTTP:      req.LiveHttp,
+		ResourceOwner: orgID,
+		Context:       dryRunActionContextToDryRun(req.Context),
+	}
+}
+
+func dryRunActionContextToDryRun(actionCtx *mgmt_pb.DryRunActionContext) *dryrun.Context {
+	if actionCtx == nil {
+		return nil
+	}
+	return &dryrun.Context{
+		UserID:       actionCtx.UserId,
+		User:         dryRunActionUserToQuery(actionCtx.User),
+		ExternalUser: dryRunActionExternalUserToDomain(actionCtx.ExternalUser),
+		ProviderInfo: actionCtx.ProviderInfo.AsMap(),
+		Claims:       actionCtx.Claims.AsMap(),
+		AuthRequest:  actionCtx.AuthRequest.AsMap(),
+		HTTPRequest:  actionCtx.HttpRequest.AsMap(),
+		AuthMethod:   actionCtx.AuthMethod,
+		AuthError:    actionCtx.AuthError,
+	}
+}
+
+func dryRunActionUserToQuery(user *mgmt_pb.DryRunActionUser) *query.User {
+	if user == nil {
+		return nil
+	}
+	return &query.User{
+		Username: user.Username,
+		Type:     domain.UserTypeHuman,
+		Human: &query.Human{
+			FirstName:         user.FirstName,
+			LastName:          user.LastName,
+			NickName:          user.NickName,
+			DisplayName:       user.DisplayName,
+			PreferredLanguage: language.Make(user.PreferredLanguage),
+			Gender:            user_grpc.GenderToDomain(user.Gender),
+			Email:             domain.EmailAddress(user.Email),
+			IsEmailVerified:   user.IsEmailVerified,
+			Phone:             domain.PhoneNumber(user.Phone),
+			IsPhoneVerified:   user.IsPhoneVerified,
+		},
+	}
+}
+
+func dryRunActionExternalUserToDomain(user *mgmt_pb.DryRunActionExternalUser) *domain.ExternalUser {
+	if user == nil {
+		return nil
+	}
+	return &domain.ExternalUser{
+		IDPConfigID:       user.IdpConfigId,
+		ExternalUserID:    user.ExternalUserId,
+		PreferredUsername: user.PreferredUsername,
+		FirstName:         user.FirstName,
+		LastName:          user.LastName,
+		NickName:          user.NickName,
+		DisplayName:       user.DisplayName,
+		PreferredLanguage: language.Make(user.PreferredLanguage),
+		Email:             domain.EmailAddress(user.Email),
+		IsEmailVerified:   user.IsEmailVerified,
+		Phone:             domain.PhoneNumber(user.Phone),
+		IsPhoneVerified:   user.IsPhoneVerified,
+	}
+}
+
+func dryRunResultToPb(result *dryrun.Result) (_ *mgmt_pb.DryRunActionResponse, err error) {
+	res := &mgmt_pb.DryRunActionResponse{
+		Mutations: make([]*mgmt_pb.DryRunActionMutation, len(result.Mutations)),
+		Logs:      make([]*mgmt_pb.DryRunActionLog, len(result.Logs)),
+		HttpCalls: make([]*mgmt_pb.DryRunActionHTTPCall, len(result.HTTPCalls)),
+		Took:      durationpb.New(result.Took),
+	}
+	for i, mutation := range result.Mutations {
+		res.Mutations[i], err = dryRunMutationToPb(mutation)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for i, log := range result.Logs {
+		res.Logs[i] = &mgmt_pb.DryRunActionLog{
+			Timestamp: timestamppb.New(log.Timestamp),
+			Level:     log.Level.String(),
+			Message:   log.Message,
+		}
+	}
+	for i, call := range result.HTTPCalls {
+		res.HttpCalls[i] = dryRunHTTPCallToPb(call)
+	}
+	if result.Err != nil {
+		res.Error = result.Err.Error()
+	}
+	return res, nil
+}
+
+// dryRunMutationToPb converts the arguments exported by the script
+// to json compatible values before they are converted to a list value
+func dryRunMutationToPb(mutation *dryrun.Mutation) (*mgmt_pb.DryRunActionMutation, error) {
+	args, err := json.Marshal(mutation.Arguments)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "MANAG-Wf2gq", "Errors.Internal")
+	}
+	var values []interface{}
+	if err = json.Unmarshal(args, &values); err != nil {
+		return nil, errors.ThrowInternal(err, "MANAG-Sd3ri", "Errors.Internal")
+	}
+	arguments, err := structpb.NewList(values)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "MANAG-Kq9fe", "Errors.Internal")
+	}
+	return &mgmt_pb.DryRunActionMutation{
+		Function:  mutation.Function,
+		Arguments: arguments,
+	}, nil
+}
+
+func dryRunHTTPCallToPb(call *actions.RecordedHTTPCall) *mgmt_pb.DryRunActionHTTPCall {
+	headers := make(map[string]string, len(call.RequestHeaders))
+	for key := range call.RequestHeaders {
+		headers[key] = call.RequestHeaders.Get(key)
+	}
+	return &mgmt_pb.DryRunActionHTTPCall{
+		Method:         call.Method,
+		Url:            call.URL,
+		RequestHeaders: headers,
+		RequestBody:    call.RequestBody,
+		Stubbed:        call.Stubbed,
+		StatusCode:     int32(call.StatusCode),
+		ResponseBody:   call.ResponseBody,
+		Error:          call.Error,
+		Took:           durationpb.New(call.Took),
+	}
+}
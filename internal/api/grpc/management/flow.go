@@ -19,6 +19,8 @@ func (s *Server) ListFlowTypes(ctx context.Context, _ *mgmt_pb.ListFlowTypesRequ
 			action_grpc.FlowTypeToPb(domain.FlowTypeCustomiseToken),
 			action_grpc.FlowTypeToPb(domain.FlowTypeInternalAuthentication),
 			action_grpc.FlowTypeToPb(domain.FlowTypeCustomizeSAMLResponse),
+			action_grpc.FlowTypeToPb(domain.FlowTypePasswordReset),
+			action_grpc.FlowTypeToPb(domain.FlowTypeLogout),
 		},
 	}, nil
 }
@@ -59,6 +61,7 @@ func (s *Server) SetTriggerActions(ctx context.Context, req *mgmt_pb.SetTriggerA
 		action_grpc.FlowTypeToDomain(req.FlowType),
 		action_grpc.TriggerTypeToDomain(req.TriggerType),
 		req.ActionIds,
+		req.ActionVersions,
 		authz.GetCtxData(ctx).OrgID,
 	)
 	if err != nil {
@@ -0,0 +1,38 @@
+package management
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/api/grpc/settings"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func (s *Server) GetNotificationProviderOrder(ctx context.Context, req *mgmt_pb.GetNotificationProviderOrderRequest) (*mgmt_pb.GetNotificationProviderOrderResponse, error) {
+	order, err := s.query.NotificationProviderOrderByOrg(ctx, authz.GetCtxData(ctx).OrgID, settings.NotificationChannelToDomain(req.Channel))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetNotificationProviderOrderResponse{Order: settings.NotificationProviderOrderToPb(order)}, nil
+}
+
+func (s *Server) SetCustomNotificationProviderOrder(ctx context.Context, req *mgmt_pb.SetCustomNotificationProviderOrderRequest) (*mgmt_pb.SetCustomNotificationProviderOrderResponse, error) {
+	objectDetails, err := s.command.SetNotificationProviderOrder(ctx, authz.GetCtxData(ctx).OrgID, settings.NotificationChannelToDomain(req.Channel), req.ProviderIds, settings.EmailSenderToCommand(req.EmailSender))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetCustomNotificationProviderOrderResponse{
+		Details: object.DomainToChangeDetailsPb(objectDetails),
+	}, nil
+}
+
+func (s *Server) ResetNotificationProviderOrderToDefault(ctx context.Context, req *mgmt_pb.ResetNotificationProviderOrderToDefaultRequest) (*mgmt_pb.ResetNotificationProviderOrderToDefaultResponse, error) {
+	objectDetails, err := s.command.RemoveNotificationProviderOrder(ctx, authz.GetCtxData(ctx).OrgID, settings.NotificationChannelToDomain(req.Channel))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ResetNotificationProviderOrderToDefaultResponse{
+		Details: object.DomainToChangeDetailsPb(objectDetails),
+	}, nil
+}
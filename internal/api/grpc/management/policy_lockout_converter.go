@@ -8,11 +8,17 @@ import (
 func AddLockoutPolicyToDomain(p *mgmt.AddCustomLockoutPolicyRequest) *domain.LockoutPolicy {
 	return &domain.LockoutPolicy{
 		MaxPasswordAttempts: uint64(p.MaxPasswordAttempts),
+		MaxOTPAttempts:      uint64(p.MaxOtpAttempts),
+		AutoUnlockAfter:     p.AutoUnlockAfter.AsDuration(),
+		FailedAttemptDelay:  p.FailedAttemptDelay.AsDuration(),
 	}
 }
 
 func UpdateLockoutPolicyToDomain(p *mgmt.UpdateCustomLockoutPolicyRequest) *domain.LockoutPolicy {
 	return &domain.LockoutPolicy{
 		MaxPasswordAttempts: uint64(p.MaxPasswordAttempts),
+		MaxOTPAttempts:      uint64(p.MaxOtpAttempts),
+		AutoUnlockAfter:     p.AutoUnlockAfter.AsDuration(),
+		FailedAttemptDelay:  p.FailedAttemptDelay.AsDuration(),
 	}
 }
@@ -14,12 +14,14 @@ import (
 	idp_grpc "github.com/zitadel/zitadel/internal/api/grpc/idp"
 	"github.com/zitadel/zitadel/internal/api/grpc/metadata"
 	obj_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/api/grpc/settings"
 	user_grpc "github.com/zitadel/zitadel/internal/api/grpc/user"
 	"github.com/zitadel/zitadel/internal/api/http"
 	z_oidc "github.com/zitadel/zitadel/internal/api/oidc"
 	"github.com/zitadel/zitadel/internal/api/ui/login"
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/repository/user"
@@ -218,6 +220,21 @@ func (s *Server) BulkRemoveUserMetadata(ctx context.Context, req *mgmt_pb.BulkRe
 	}, nil
 }
 
+func (s *Server) ListUserNotifications(ctx context.Context, req *mgmt_pb.ListUserNotificationsRequest) (*mgmt_pb.ListUserNotificationsResponse, error) {
+	queries, err := ListUserNotificationsToQuery(authz.GetCtxData(ctx).OrgID, req)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.query.SearchNotificationMessages(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListUserNotificationsResponse{
+		Result:  settings.NotificationDeliveriesToPb(res.NotificationMessages),
+		Details: obj_grpc.ToListDetails(res.Count, res.Sequence, res.LastRun),
+	}, nil
+}
+
 func (s *Server) AddHumanUser(ctx context.Context, req *mgmt_pb.AddHumanUserRequest) (*mgmt_pb.AddHumanUserResponse, error) {
 	human := AddHumanUserRequestToAddHuman(req)
 	if err := s.command.AddHuman(ctx, authz.GetCtxData(ctx).OrgID, human, true); err != nil {
@@ -366,6 +383,26 @@ func (s *Server) RemoveUser(ctx context.Context, req *mgmt_pb.RemoveUserRequest)
 	}, nil
 }
 
+func (s *Server) ForgetUser(ctx context.Context, req *mgmt_pb.ForgetUserRequest) (*mgmt_pb.ForgetUserResponse, error) {
+	_, err := s.getUserByID(ctx, req.Id)
+	if err != nil && !caos_errs.IsNotFound(err) {
+		return nil, err
+	}
+	// the user must be removed before it can be forgotten
+	if err == nil {
+		if _, err = s.RemoveUser(ctx, &mgmt_pb.RemoveUserRequest{Id: req.Id}); err != nil {
+			return nil, err
+		}
+	}
+	objectDetails, err := s.command.ForgetUser(ctx, req.Id, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ForgetUserResponse{
+		Details: obj_grpc.DomainToChangeDetailsPb(objectDetails),
+	}, nil
+}
+
 func (s *Server) removeUserDependencies(ctx context.Context, userID string) ([]*command.CascadingMembership, []string, error) {
 	userGrantUserQuery, err := query.NewUserGrantUserIDSearchQuery(userID)
 	if err != nil {
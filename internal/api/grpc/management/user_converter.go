@@ -14,6 +14,7 @@ import (
 	"github.com/zitadel/zitadel/internal/api/grpc/authn"
 	"github.com/zitadel/zitadel/internal/api/grpc/metadata"
 	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/api/grpc/settings"
 	user_grpc "github.com/zitadel/zitadel/internal/api/grpc/user"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
@@ -91,6 +92,27 @@ func ListUserMetadataToDomain(req *mgmt_pb.ListUserMetadataRequest) (*query.User
 	}, nil
 }
 
+func ListUserNotificationsToQuery(orgID string, req *mgmt_pb.ListUserNotificationsRequest) (*query.NotificationMessageSearchQueries, error) {
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	queries, err := settings.NotificationDeliveryQueriesToQuery(req.UserId, req.State)
+	if err != nil {
+		return nil, err
+	}
+	ownerQuery, err := query.NewNotificationMessageResourceOwnerSearchQuery(orgID)
+	if err != nil {
+		return nil, err
+	}
+	return &query.NotificationMessageSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset:        offset,
+			Limit:         limit,
+			Asc:           asc,
+			SortingColumn: query.NotificationMessageColumnCreationDate,
+		},
+		Queries: append(queries, ownerQuery),
+	}, nil
+}
+
 func ImportHumanUserRequestToDomain(req *mgmt_pb.ImportHumanUserRequest) (human *domain.Human, passwordless bool, links []*domain.UserIDPLink) {
 	human = &domain.Human{
 		Username: req.UserName,
@@ -1,6 +1,8 @@
 package policy
 
 import (
+	"google.golang.org/protobuf/types/known/durationpb"
+
 	"github.com/zitadel/zitadel/internal/api/grpc/object"
 	"github.com/zitadel/zitadel/internal/query"
 	policy_pb "github.com/zitadel/zitadel/pkg/grpc/policy"
@@ -10,6 +12,9 @@ func ModelLockoutPolicyToPb(policy *query.LockoutPolicy) *policy_pb.LockoutPolic
 	return &policy_pb.LockoutPolicy{
 		IsDefault:           policy.IsDefault,
 		MaxPasswordAttempts: policy.MaxPasswordAttempts,
+		MaxOtpAttempts:      policy.MaxOTPAttempts,
+		AutoUnlockAfter:     durationpb.New(policy.AutoUnlockAfter),
+		FailedAttemptDelay:  durationpb.New(policy.FailedAttemptDelay),
 		Details: object.ToViewDetailsPb(
 			policy.Sequence,
 			policy.CreationDate,
@@ -6,11 +6,13 @@ import (
 	"google.golang.org/grpc"
 
 	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/database"
 )
 
 func CallDurationHandler() grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 		ctx = call.WithTimestamp(ctx)
+		ctx = database.WithReadYourWrites(ctx)
 		return handler(ctx, req)
 	}
 }
@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"context"
+	"slices"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/execution"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// executionManagementMethods are not intercepted,
+// so failing or rejecting targets can't prevent their own removal
+var executionManagementMethods = []string{
+	"/zitadel.admin.v1.AdminService/SetTarget",
+	"/zitadel.admin.v1.AdminService/RemoveTarget",
+	"/zitadel.admin.v1.AdminService/ListTargets",
+	"/zitadel.admin.v1.AdminService/SetExecution",
+	"/zitadel.admin.v1.AdminService/RemoveExecution",
+	"/zitadel.admin.v1.AdminService/ListExecutions",
+}
+
+// ExecutionHandler calls the targets of the request executions before the method is handled
+// and the targets of the response executions after the method was handled successfully.
+// The targets can change the request and the response.
+func ExecutionHandler(executions *execution.Executions) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (_ interface{}, err error) {
+		instanceID := authz.GetInstance(ctx).InstanceID()
+		if executions == nil || instanceID == "" || slices.Contains(executionManagementMethods, info.FullMethod) {
+			return handler(ctx, req)
+		}
+		requestTargets, err := executions.Targets(ctx, domain.ExecutionIDsOfMethod(domain.ExecutionTypeRequest, info.FullMethod)...)
+		if err != nil {
+			return nil, err
+		}
+		responseTargets, err := executions.Targets(ctx, domain.ExecutionIDsOfMethod(domain.ExecutionTypeResponse, info.FullMethod)...)
+		if err != nil {
+			return nil, err
+		}
+		if len(requestTargets) == 0 && len(responseTargets) == 0 {
+			return handler(ctx, req)
+		}
+		interceptorCtx, span := tracing.NewServerInterceptorSpan(ctx)
+		defer func() { span.EndWithError(err) }()
+
+		ctxData := authz.GetCtxData(ctx)
+		requestInfo := &execution.ContextInfoRequest{
+			FullMethod: info.FullMethod,
+			InstanceID: instanceID,
+			OrgID:      ctxData.OrgID,
+			ProjectID:  ctxData.ProjectID,
+			UserID:     ctxData.UserID,
+
+			ValidateContent: validateMessage(req),
+		}
+		if requestInfo.Request, err = marshalMessage(req); err != nil {
+			return nil, err
+		}
+		if len(requestTargets) > 0 {
+			if err = executions.CallTargets(interceptorCtx, requestTargets, requestInfo); err != nil {
+				return nil, err
+			}
+			if req, err = unmarshalMessage(req, requestInfo.Request); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := handler(ctx, req)
+		if err != nil || len(responseTargets) == 0 {
+			return resp, err
+		}
+		responseInfo := &execution.ContextInfoResponse{
+			FullMethod: requestInfo.FullMethod,
+			InstanceID: requestInfo.InstanceID,
+			OrgID:      requestInfo.OrgID,
+			ProjectID:  requestInfo.ProjectID,
+			UserID:     requestInfo.UserID,
+			Request:    requestInfo.Request,
+
+			ValidateContent: validateMessage(resp),
+		}
+		if responseInfo.Response, err = marshalMessage(resp); err != nil {
+			return nil, err
+		}
+		if err = executions.CallTargets(ctx, responseTargets, responseInfo); err != nil {
+			return nil, err
+		}
+		return unmarshalMessage(resp, responseInfo.Response)
+	}
+}
+
+func marshalMessage(msg interface{}) ([]byte, error) {
+	message, ok := msg.(proto.Message)
+	if !ok {
+		return nil, errors.ThrowInternal(nil, "MIDDLE-Uu2ah", "Errors.Internal")
+	}
+	return protojson.Marshal(message)
+}
+
+// validateMessage checks the content returned by a target is a message of the type of msg
+func validateMessage(msg interface{}) func(content []byte) error {
+	return func(content []byte) error {
+		_, err := unmarshalMessage(msg, content)
+		return err
+	}
+}
+
+// unmarshalMessage returns a new message of the type of msg with the content returned by the targets
+func unmarshalMessage(msg interface{}, content []byte) (interface{}, error) {
+	message, ok := msg.(proto.Message)
+	if !ok {
+		return nil, errors.ThrowInternal(nil, "MIDDLE-aeW3o", "Errors.Internal")
+	}
+	changed := message.ProtoReflect().New().Interface()
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(content, changed); err != nil {
+		return nil, errors.ThrowPreconditionFailed(err, "MIDDLE-ohC4i", "Errors.Execution.Failed")
+	}
+	return changed, nil
+}
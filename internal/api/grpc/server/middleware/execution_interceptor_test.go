@@ -0,0 +1,171 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/execution"
+	"github.com/zitadel/zitadel/internal/logstore"
+	"github.com/zitadel/zitadel/internal/logstore/record"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+type mockExecutionQueries struct {
+	targets    []*query.Target
+	executions []*query.Execution
+}
+
+func (m *mockExecutionQueries) Targets(context.Context) (*query.Targets, error) {
+	return &query.Targets{Targets: m.targets}, nil
+}
+
+func (m *mockExecutionQueries) Executions(context.Context) (*query.Executions, error) {
+	return &query.Executions{Executions: m.executions}, nil
+}
+
+func TestExecutionHandler(t *testing.T) {
+	execution.SetLogstoreService(logstore.New[*record.ExecutionLog](nil, nil))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		switch r.URL.Path {
+		case "/request":
+			_, _ = w.Write([]byte(`{"name":"changed"}`))
+		case "/response":
+			_, _ = w.Write([]byte(`{"name":"changed", "enriched":true}`))
+		case "/reject":
+			w.WriteHeader(http.StatusForbidden)
+		case "/invalid":
+			_, _ = w.Write([]byte(`["name"]`))
+		}
+	}))
+	defer server.Close()
+	target := func(id, path string) *query.Target {
+		return &query.Target{
+			ID:               id,
+			Endpoint:         server.URL + path,
+			Timeout:          time.Second,
+			InterruptOnError: true,
+			SigningKey: &crypto.CryptoValue{
+				CryptoType: crypto.TypeEncryption,
+				Algorithm:  "enc",
+				KeyID:      "id",
+				Crypted:    []byte("key"),
+			},
+		}
+	}
+	queries := &mockExecutionQueries{
+		targets: []*query.Target{
+			target("request", "/request"),
+			target("response", "/response"),
+			target("reject", "/reject"),
+			{
+				ID:         "invalid",
+				Endpoint:   server.URL + "/invalid",
+				Timeout:    time.Second,
+				SigningKey: target("invalid", "/invalid").SigningKey,
+			},
+		},
+		executions: []*query.Execution{
+			{ID: "request/zitadel.test.v1.TestService/Change", Targets: database.TextArray[string]{"request"}},
+			{ID: "response/zitadel.test.v1.TestService", Targets: database.TextArray[string]{"response"}},
+			{ID: "request/zitadel.test.v1.TestService/Reject", Targets: database.TextArray[string]{"reject"}},
+			{ID: "request/zitadel.invalid.v1.InvalidService/Invalid", Targets: database.TextArray[string]{"invalid"}},
+			{ID: "request/zitadel.admin.v1.AdminService", Targets: database.TextArray[string]{"reject"}},
+		},
+	}
+	echoHandler := func(_ context.Context, req interface{}) (interface{}, error) {
+		return req, nil
+	}
+
+	tests := []struct {
+		name       string
+		executions *execution.Executions
+		ctx        context.Context
+		fullMethod string
+		want       map[string]interface{}
+		wantErr    func(error) bool
+	}{
+		{
+			name:       "disabled",
+			ctx:        authz.WithInstanceID(context.Background(), "instance1"),
+			fullMethod: "/zitadel.test.v1.TestService/Change",
+			want:       map[string]interface{}{"name": "request"},
+		},
+		{
+			name:       "no instance",
+			executions: execution.New(queries, crypto.CreateMockEncryptionAlg(gomock.NewController(t))),
+			ctx:        context.Background(),
+			fullMethod: "/zitadel.test.v1.TestService/Change",
+			want:       map[string]interface{}{"name": "request"},
+		},
+		{
+			name:       "no executions",
+			executions: execution.New(queries, crypto.CreateMockEncryptionAlg(gomock.NewController(t))),
+			ctx:        authz.WithInstanceID(context.Background(), "instance1"),
+			fullMethod: "/zitadel.other.v1.OtherService/Change",
+			want:       map[string]interface{}{"name": "request"},
+		},
+		{
+			name:       "request and response changed",
+			executions: execution.New(queries, crypto.CreateMockEncryptionAlg(gomock.NewController(t))),
+			ctx:        authz.WithInstanceID(context.Background(), "instance1"),
+			fullMethod: "/zitadel.test.v1.TestService/Change",
+			want:       map[string]interface{}{"name": "changed", "enriched": true},
+		},
+		{
+			name:       "request rejected",
+			executions: execution.New(queries, crypto.CreateMockEncryptionAlg(gomock.NewController(t))),
+			ctx:        authz.WithInstanceID(context.Background(), "instance1"),
+			fullMethod: "/zitadel.test.v1.TestService/Reject",
+			wantErr:    errors.IsPreconditionFailed,
+		},
+		{
+			name:       "invalid request skipped",
+			executions: execution.New(queries, crypto.CreateMockEncryptionAlg(gomock.NewController(t))),
+			ctx:        authz.WithInstanceID(context.Background(), "instance1"),
+			fullMethod: "/zitadel.invalid.v1.InvalidService/Invalid",
+			want:       map[string]interface{}{"name": "request"},
+		},
+		{
+			name:       "execution management not intercepted",
+			executions: execution.New(queries, crypto.CreateMockEncryptionAlg(gomock.NewController(t))),
+			ctx:        authz.WithInstanceID(context.Background(), "instance1"),
+			fullMethod: "/zitadel.admin.v1.AdminService/RemoveExecution",
+			want:       map[string]interface{}{"name": "request"},
+		},
+		{
+			name:       "admin methods intercepted",
+			executions: execution.New(queries, crypto.CreateMockEncryptionAlg(gomock.NewController(t))),
+			ctx:        authz.WithInstanceID(context.Background(), "instance1"),
+			fullMethod: "/zitadel.admin.v1.AdminService/GetMyInstance",
+			wantErr:    errors.IsPreconditionFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := structpb.NewStruct(map[string]interface{}{"name": "request"})
+			require.NoError(t, err)
+			got, err := ExecutionHandler(tt.executions)(tt.ctx, req, &grpc.UnaryServerInfo{FullMethod: tt.fullMethod}, echoHandler)
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err), "unexpected error: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.(*structpb.Struct).AsMap())
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// UnaryToStreamInterceptor reuses the unary interceptor for server streams.
+// The interceptor is called before the stream is handled, therefore it does not receive a request
+// and the context it returns is passed to the stream handler.
+func UnaryToStreamInterceptor(interceptor grpc.UnaryServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		_, err := interceptor(stream.Context(), nil, &grpc.UnaryServerInfo{Server: srv, FullMethod: info.FullMethod}, func(ctx context.Context, _ interface{}) (interface{}, error) {
+			return nil, handler(srv, &serverStream{ServerStream: stream, ctx: ctx})
+		})
+		return err
+	}
+}
+
+// serverStream overwrites the context of the wrapped stream
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
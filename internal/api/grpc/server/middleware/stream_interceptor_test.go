@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+type ctxKey struct{}
+
+type mockServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (m *mockServerStream) Context() context.Context {
+	return m.ctx
+}
+
+func TestUnaryToStreamInterceptor(t *testing.T) {
+	handlerErr := errors.New("handler failed")
+	interceptor := UnaryToStreamInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		assert.Nil(t, req)
+		assert.Equal(t, "/service/method", info.FullMethod)
+		return handler(context.WithValue(ctx, ctxKey{}, "value"), req)
+	})
+	err := interceptor(nil, &mockServerStream{ctx: context.Background()}, &grpc.StreamServerInfo{FullMethod: "/service/method"}, func(srv interface{}, stream grpc.ServerStream) error {
+		assert.Equal(t, "value", stream.Context().Value(ctxKey{}))
+		return handlerErr
+	})
+	assert.ErrorIs(t, err, handlerErr)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/zitadel/zitadel/internal/api/authz"
 	grpc_api "github.com/zitadel/zitadel/internal/api/grpc"
 	"github.com/zitadel/zitadel/internal/api/grpc/server/middleware"
+	"github.com/zitadel/zitadel/internal/execution"
 	"github.com/zitadel/zitadel/internal/logstore"
 	"github.com/zitadel/zitadel/internal/logstore/record"
 	"github.com/zitadel/zitadel/internal/query"
@@ -41,6 +42,7 @@ func CreateServer(
 	hostHeaderName string,
 	tlsConfig *tls.Config,
 	accessSvc *logstore.Service[*record.AccessLog],
+	executions *execution.Executions,
 ) *grpc.Server {
 	metricTypes := []metrics.MetricType{metrics.MetricTypeTotalCount, metrics.MetricTypeRequestCount, metrics.MetricTypeStatusCode}
 	serverOptions := []grpc.ServerOption{
@@ -56,11 +58,24 @@ func CreateServer(
 				middleware.AuthorizationInterceptor(verifier, authConfig),
 				middleware.QuotaExhaustedInterceptor(accessSvc, system_pb.SystemService_ServiceDesc.ServiceName),
 				middleware.TranslationHandler(),
+				middleware.ExecutionHandler(executions),
 				middleware.ValidationHandler(),
 				middleware.ServiceHandler(),
 				middleware.ActivityInterceptor(),
 			),
 		),
+		grpc.StreamInterceptor(
+			grpc_middleware.ChainStreamServer(
+				middleware.UnaryToStreamInterceptor(middleware.CallDurationHandler()),
+				middleware.UnaryToStreamInterceptor(middleware.NoCacheInterceptor()),
+				middleware.UnaryToStreamInterceptor(middleware.InstanceInterceptor(queries, hostHeaderName, system_pb.SystemService_ServiceDesc.ServiceName, healthpb.Health_ServiceDesc.ServiceName)),
+				middleware.UnaryToStreamInterceptor(middleware.AccessStorageInterceptor(accessSvc)),
+				middleware.UnaryToStreamInterceptor(middleware.ErrorHandler()),
+				middleware.UnaryToStreamInterceptor(middleware.AuthorizationInterceptor(verifier, authConfig)),
+				middleware.UnaryToStreamInterceptor(middleware.QuotaExhaustedInterceptor(accessSvc, system_pb.SystemService_ServiceDesc.ServiceName)),
+				middleware.UnaryToStreamInterceptor(middleware.ServiceHandler()),
+			),
+		),
 	}
 	if tlsConfig != nil {
 		serverOptions = append(serverOptions, grpc.Creds(credentials.NewTLS(tlsConfig)))
@@ -172,13 +172,14 @@ func factorsToPb(s *query.Session) *session.Factors {
 		return nil
 	}
 	return &session.Factors{
-		User:     user,
-		Password: passwordFactorToPb(s.PasswordFactor),
-		WebAuthN: webAuthNFactorToPb(s.WebAuthNFactor),
-		Intent:   intentFactorToPb(s.IntentFactor),
-		Totp:     totpFactorToPb(s.TOTPFactor),
-		OtpSms:   otpFactorToPb(s.OTPSMSFactor),
-		OtpEmail: otpFactorToPb(s.OTPEmailFactor),
+		User:         user,
+		Password:     passwordFactorToPb(s.PasswordFactor),
+		WebAuthN:     webAuthNFactorToPb(s.WebAuthNFactor),
+		Intent:       intentFactorToPb(s.IntentFactor),
+		Totp:         totpFactorToPb(s.TOTPFactor),
+		OtpSms:       otpFactorToPb(s.OTPSMSFactor),
+		OtpEmail:     otpFactorToPb(s.OTPEmailFactor),
+		RecoveryCode: recoveryCodeFactorToPb(s.RecoveryCodeFactor),
 	}
 }
 
@@ -188,6 +189,7 @@ func passwordFactorToPb(factor query.SessionPasswordFactor) *session.PasswordFac
 	}
 	return &session.PasswordFactor{
 		VerifiedAt: timestamppb.New(factor.PasswordCheckedAt),
+		Expired:    factor.Expired,
 	}
 }
 
@@ -228,6 +230,15 @@ func otpFactorToPb(factor query.SessionOTPFactor) *session.OTPFactor {
 	}
 }
 
+func recoveryCodeFactorToPb(factor query.SessionRecoveryCodeFactor) *session.RecoveryCodeFactor {
+	if factor.RecoveryCodeCheckedAt.IsZero() {
+		return nil
+	}
+	return &session.RecoveryCodeFactor{
+		VerifiedAt: timestamppb.New(factor.RecoveryCodeCheckedAt),
+	}
+}
+
 func userFactorToPb(factor query.SessionUserFactor) *session.UserFactor {
 	if factor.UserID == "" || factor.UserCheckedAt.IsZero() {
 		return nil
@@ -371,6 +382,9 @@ func (s *Server) checksToCommand(ctx context.Context, checks *session.Checks) ([
 	if otp := checks.GetOtpEmail(); otp != nil {
 		sessionChecks = append(sessionChecks, command.CheckOTPEmail(otp.GetCode()))
 	}
+	if recoveryCode := checks.GetRecoveryCode(); recoveryCode != nil {
+		sessionChecks = append(sessionChecks, command.CheckRecoveryCode(recoveryCode.GetCode()))
+	}
 	return sessionChecks, nil
 }
 
@@ -1,7 +1,11 @@
 package settings
 
 import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
 	obj_pb "github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/query"
 	settings_pb "github.com/zitadel/zitadel/pkg/grpc/settings"
 )
@@ -13,3 +17,139 @@ func NotificationProviderToPb(provider *query.DebugNotificationProvider) *settin
 	}
 	return mapped
 }
+
+func NotificationProviderOrderToPb(order *query.NotificationProviderOrder) *settings_pb.NotificationProviderOrder {
+	return &settings_pb.NotificationProviderOrder{
+		Details:     obj_pb.ToViewDetailsPb(order.Sequence, order.CreationDate, order.ChangeDate, order.ResourceOwner),
+		Channel:     NotificationChannelToPb(order.Channel),
+		ProviderIds: order.ProviderIDs,
+		IsDefault:   order.IsDefault,
+		EmailSender: EmailSenderToPb(order.EmailSender),
+	}
+}
+
+func EmailSenderToPb(sender *query.EmailSender) *settings_pb.EmailSender {
+	if sender == nil {
+		return nil
+	}
+	return &settings_pb.EmailSender{
+		SenderAddress:  sender.Address,
+		SenderName:     sender.Name,
+		ReplyToAddress: sender.ReplyToAddress,
+	}
+}
+
+func EmailSenderToCommand(sender *settings_pb.EmailSender) *command.EmailSender {
+	if sender == nil {
+		return nil
+	}
+	return &command.EmailSender{
+		Address:        sender.SenderAddress,
+		Name:           sender.SenderName,
+		ReplyToAddress: sender.ReplyToAddress,
+	}
+}
+
+func NotificationChannelToDomain(channel settings_pb.NotificationChannel) domain.NotificationType {
+	switch channel {
+	case settings_pb.NotificationChannel_NOTIFICATION_CHANNEL_EMAIL:
+		return domain.NotificationTypeEmail
+	case settings_pb.NotificationChannel_NOTIFICATION_CHANNEL_SMS:
+		return domain.NotificationTypeSms
+	default:
+		return -1
+	}
+}
+
+func NotificationChannelToPb(channel domain.NotificationType) settings_pb.NotificationChannel {
+	switch channel {
+	case domain.NotificationTypeEmail:
+		return settings_pb.NotificationChannel_NOTIFICATION_CHANNEL_EMAIL
+	case domain.NotificationTypeSms:
+		return settings_pb.NotificationChannel_NOTIFICATION_CHANNEL_SMS
+	default:
+		return settings_pb.NotificationChannel_NOTIFICATION_CHANNEL_UNSPECIFIED
+	}
+}
+
+func NotificationDeliveriesToPb(messages []*query.NotificationMessage) []*settings_pb.NotificationDelivery {
+	deliveries := make([]*settings_pb.NotificationDelivery, len(messages))
+	for i, message := range messages {
+		deliveries[i] = NotificationDeliveryToPb(message)
+	}
+	return deliveries
+}
+
+func NotificationDeliveryToPb(message *query.NotificationMessage) *settings_pb.NotificationDelivery {
+	delivery := &settings_pb.NotificationDelivery{
+		Details:             obj_pb.ToViewDetailsPb(message.Sequence, message.CreationDate, message.ChangeDate, message.ResourceOwner),
+		Id:                  message.ID,
+		UserId:              message.UserID,
+		Channel:             NotificationChannelToPb(message.Channel),
+		Recipient:           message.Recipient,
+		State:               NotificationDeliveryStateToPb(message.State),
+		Attempts:            uint32(message.Attempts),
+		ProviderId:          message.ProviderID,
+		ProviderMessageId:   message.ProviderMessageID,
+		Error:               message.Error,
+		TriggeringEventType: message.TriggeringEventType,
+	}
+	if !message.NextAttempt.IsZero() {
+		delivery.NextAttempt = timestamppb.New(message.NextAttempt)
+	}
+	return delivery
+}
+
+func NotificationDeliveryStateToPb(state domain.NotificationMessageState) settings_pb.NotificationDeliveryState {
+	switch state {
+	case domain.NotificationMessageStateQueued:
+		return settings_pb.NotificationDeliveryState_NOTIFICATION_DELIVERY_STATE_QUEUED
+	case domain.NotificationMessageStateSending:
+		return settings_pb.NotificationDeliveryState_NOTIFICATION_DELIVERY_STATE_SENDING
+	case domain.NotificationMessageStateSent:
+		return settings_pb.NotificationDeliveryState_NOTIFICATION_DELIVERY_STATE_SENT
+	case domain.NotificationMessageStateBounced:
+		return settings_pb.NotificationDeliveryState_NOTIFICATION_DELIVERY_STATE_BOUNCED
+	case domain.NotificationMessageStateFailed:
+		return settings_pb.NotificationDeliveryState_NOTIFICATION_DELIVERY_STATE_FAILED
+	default:
+		return settings_pb.NotificationDeliveryState_NOTIFICATION_DELIVERY_STATE_UNSPECIFIED
+	}
+}
+
+func NotificationDeliveryStateToDomain(state settings_pb.NotificationDeliveryState) domain.NotificationMessageState {
+	switch state {
+	case settings_pb.NotificationDeliveryState_NOTIFICATION_DELIVERY_STATE_QUEUED:
+		return domain.NotificationMessageStateQueued
+	case settings_pb.NotificationDeliveryState_NOTIFICATION_DELIVERY_STATE_SENDING:
+		return domain.NotificationMessageStateSending
+	case settings_pb.NotificationDeliveryState_NOTIFICATION_DELIVERY_STATE_SENT:
+		return domain.NotificationMessageStateSent
+	case settings_pb.NotificationDeliveryState_NOTIFICATION_DELIVERY_STATE_BOUNCED:
+		return domain.NotificationMessageStateBounced
+	case settings_pb.NotificationDeliveryState_NOTIFICATION_DELIVERY_STATE_FAILED:
+		return domain.NotificationMessageStateFailed
+	default:
+		return domain.NotificationMessageStateUnspecified
+	}
+}
+
+// NotificationDeliveryQueriesToQuery returns the queries filtering the notifications by user and state,
+// an empty userID or an unspecified state doesn't filter
+func NotificationDeliveryQueriesToQuery(userID string, state settings_pb.NotificationDeliveryState) (queries []query.SearchQuery, err error) {
+	if userID != "" {
+		userIDQuery, err := query.NewNotificationMessageUserIDSearchQuery(userID)
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, userIDQuery)
+	}
+	if state != settings_pb.NotificationDeliveryState_NOTIFICATION_DELIVERY_STATE_UNSPECIFIED {
+		stateQuery, err := query.NewNotificationMessageStateSearchQuery(NotificationDeliveryStateToDomain(state))
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, stateQuery)
+	}
+	return queries, nil
+}
@@ -70,6 +70,8 @@ func secondFactorTypeToPb(secondFactorType domain.SecondFactorType) settings.Sec
 		return settings.SecondFactorType_SECOND_FACTOR_TYPE_OTP_EMAIL
 	case domain.SecondFactorTypeOTPSMS:
 		return settings.SecondFactorType_SECOND_FACTOR_TYPE_OTP_SMS
+	case domain.SecondFactorTypeRecoveryCodes:
+		return settings.SecondFactorType_SECOND_FACTOR_TYPE_RECOVERY_CODES
 	case domain.SecondFactorTypeUnspecified:
 		return settings.SecondFactorType_SECOND_FACTOR_TYPE_UNSPECIFIED
 	default:
@@ -90,12 +92,14 @@ func multiFactorTypeToPb(typ domain.MultiFactorType) settings.MultiFactorType {
 
 func passwordSettingsToPb(current *query.PasswordComplexityPolicy) *settings.PasswordComplexitySettings {
 	return &settings.PasswordComplexitySettings{
-		MinLength:         current.MinLength,
-		RequiresUppercase: current.HasUppercase,
-		RequiresLowercase: current.HasLowercase,
-		RequiresNumber:    current.HasNumber,
-		RequiresSymbol:    current.HasSymbol,
-		ResourceOwnerType: isDefaultToResourceOwnerTypePb(current.IsDefault),
+		MinLength:          current.MinLength,
+		RequiresUppercase:  current.HasUppercase,
+		RequiresLowercase:  current.HasLowercase,
+		RequiresNumber:     current.HasNumber,
+		RequiresSymbol:     current.HasSymbol,
+		ResourceOwnerType:  isDefaultToResourceOwnerTypePb(current.IsDefault),
+		HistoryDepth:       current.HistoryDepth,
+		RequiresUnbreached: current.CheckBreached,
 	}
 }
 
@@ -157,6 +161,9 @@ func legalAndSupportSettingsToPb(current *query.PrivacyPolicy) *settings.LegalAn
 func lockoutSettingsToPb(current *query.LockoutPolicy) *settings.LockoutSettings {
 	return &settings.LockoutSettings{
 		MaxPasswordAttempts: current.MaxPasswordAttempts,
+		MaxOtpAttempts:      current.MaxOTPAttempts,
+		AutoUnlockAfter:     durationpb.New(current.AutoUnlockAfter),
+		FailedAttemptDelay:  durationpb.New(current.FailedAttemptDelay),
 		ResourceOwnerType:   isDefaultToResourceOwnerTypePb(current.IsDefault),
 	}
 }
@@ -213,20 +213,24 @@ func Test_multiFactorTypeToPb(t *testing.T) {
 
 func Test_passwordSettingsToPb(t *testing.T) {
 	arg := &query.PasswordComplexityPolicy{
-		MinLength:    12,
-		HasUppercase: true,
-		HasLowercase: true,
-		HasNumber:    true,
-		HasSymbol:    true,
-		IsDefault:    true,
+		MinLength:     12,
+		HasUppercase:  true,
+		HasLowercase:  true,
+		HasNumber:     true,
+		HasSymbol:     true,
+		HistoryDepth:  5,
+		CheckBreached: true,
+		IsDefault:     true,
 	}
 	want := &settings.PasswordComplexitySettings{
-		MinLength:         12,
-		RequiresUppercase: true,
-		RequiresLowercase: true,
-		RequiresNumber:    true,
-		RequiresSymbol:    true,
-		ResourceOwnerType: settings.ResourceOwnerType_RESOURCE_OWNER_TYPE_INSTANCE,
+		MinLength:          12,
+		RequiresUppercase:  true,
+		RequiresLowercase:  true,
+		RequiresNumber:     true,
+		RequiresSymbol:     true,
+		ResourceOwnerType:  settings.ResourceOwnerType_RESOURCE_OWNER_TYPE_INSTANCE,
+		HistoryDepth:       5,
+		RequiresUnbreached: true,
 	}
 
 	got := passwordSettingsToPb(arg)
@@ -337,10 +341,16 @@ func Test_legalSettingsToPb(t *testing.T) {
 func Test_lockoutSettingsToPb(t *testing.T) {
 	arg := &query.LockoutPolicy{
 		MaxPasswordAttempts: 22,
+		MaxOTPAttempts:      5,
+		AutoUnlockAfter:     time.Hour,
+		FailedAttemptDelay:  time.Second,
 		IsDefault:           true,
 	}
 	want := &settings.LockoutSettings{
 		MaxPasswordAttempts: 22,
+		MaxOtpAttempts:      5,
+		AutoUnlockAfter:     durationpb.New(time.Hour),
+		FailedAttemptDelay:  durationpb.New(time.Second),
 		ResourceOwnerType:   settings.ResourceOwnerType_RESOURCE_OWNER_TYPE_INSTANCE,
 	}
 	got := lockoutSettingsToPb(arg)
@@ -0,0 +1,41 @@
+package system
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/pkg/grpc/system"
+)
+
+func (s *Server) SetEventSink(ctx context.Context, req *system.SetEventSinkRequest) (*system.SetEventSinkResponse, error) {
+	sink := eventSinkPbToCommand(req)
+	details, err := s.command.SetEventSink(ctx, sink)
+	if err != nil {
+		return nil, err
+	}
+	return &system.SetEventSinkResponse{
+		Id:      sink.ID,
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RemoveEventSink(ctx context.Context, req *system.RemoveEventSinkRequest) (*system.RemoveEventSinkResponse, error) {
+	details, err := s.command.RemoveEventSink(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &system.RemoveEventSinkResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) ListEventSinks(ctx context.Context, _ *system.ListEventSinksRequest) (*system.ListEventSinksResponse, error) {
+	sinks, err := s.query.EventSinks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &system.ListEventSinksResponse{
+		Details: object.ToListDetails(sinks.Count, sinks.Sequence, sinks.LastRun),
+		Result:  eventSinksToPb(sinks.EventSinks),
+	}, nil
+}
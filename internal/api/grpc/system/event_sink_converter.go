@@ -0,0 +1,74 @@
+package system
+
+import (
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/pkg/grpc/system"
+)
+
+func eventSinkPbToCommand(req *system.SetEventSinkRequest) *command.EventSink {
+	aggregateTypes := make([]eventstore.AggregateType, len(req.GetAggregateTypes()))
+	for i, aggregateType := range req.GetAggregateTypes() {
+		aggregateTypes[i] = eventstore.AggregateType(aggregateType)
+	}
+	eventTypes := make([]eventstore.EventType, len(req.GetEventTypes()))
+	for i, eventType := range req.GetEventTypes() {
+		eventTypes[i] = eventstore.EventType(eventType)
+	}
+	return &command.EventSink{
+		ID:             req.GetId(),
+		Name:           req.GetName(),
+		SinkType:       eventSinkTypePbToDomain(req.GetType()),
+		Endpoint:       req.GetEndpoint(),
+		Target:         req.GetTarget(),
+		AggregateTypes: aggregateTypes,
+		EventTypes:     eventTypes,
+		SigningKey:     req.GetSigningKey(),
+	}
+}
+
+func eventSinkTypePbToDomain(sinkType system.EventSinkType) domain.EventSinkType {
+	switch sinkType {
+	case system.EventSinkType_EVENT_SINK_TYPE_HTTP:
+		return domain.EventSinkTypeHTTP
+	case system.EventSinkType_EVENT_SINK_TYPE_NATS:
+		return domain.EventSinkTypeNATS
+	case system.EventSinkType_EVENT_SINK_TYPE_KAFKA:
+		return domain.EventSinkTypeKafka
+	default:
+		return domain.EventSinkTypeUnspecified
+	}
+}
+
+func eventSinkTypeToPb(sinkType domain.EventSinkType) system.EventSinkType {
+	switch sinkType {
+	case domain.EventSinkTypeHTTP:
+		return system.EventSinkType_EVENT_SINK_TYPE_HTTP
+	case domain.EventSinkTypeNATS:
+		return system.EventSinkType_EVENT_SINK_TYPE_NATS
+	case domain.EventSinkTypeKafka:
+		return system.EventSinkType_EVENT_SINK_TYPE_KAFKA
+	default:
+		return system.EventSinkType_EVENT_SINK_TYPE_UNSPECIFIED
+	}
+}
+
+func eventSinksToPb(sinks []*query.EventSink) []*system.EventSink {
+	result := make([]*system.EventSink, len(sinks))
+	for i, sink := range sinks {
+		result[i] = &system.EventSink{
+			Id:             sink.ID,
+			Details:        object.ToViewDetailsPb(sink.Sequence, sink.CreationDate, sink.ChangeDate, sink.InstanceID),
+			Name:           sink.Name,
+			Type:           eventSinkTypeToPb(sink.SinkType),
+			Endpoint:       sink.Endpoint,
+			Target:         sink.Target,
+			AggregateTypes: sink.AggregateTypes,
+			EventTypes:     sink.EventTypes,
+		}
+	}
+	return result
+}
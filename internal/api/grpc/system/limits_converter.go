@@ -12,5 +12,18 @@ func instanceLimitsPbToCommand(req *system.SetLimitsRequest) *command.SetLimits
 	if req.AuditLogRetention != nil {
 		setLimits.AuditLogRetention = gu.Ptr(req.AuditLogRetention.AsDuration())
 	}
+	setLimits.ActionMaxAllocations = req.ActionMaxAllocations
+	setLimits.ActionMaxLoopIterations = req.ActionMaxLoopIterations
+	setLimits.ActionMaxOutputSize = req.ActionMaxOutputSize
+	setLimits.ActionMaxHTTPCalls = req.ActionMaxHttpCalls
+	setLimits.ActionMaxHTTPResponseSize = req.ActionMaxHttpResponseSize
+	setLimits.ActionMaxConcurrentRuns = req.ActionMaxConcurrentRuns
+	if req.ActionAllowedDomains != nil {
+		// an empty list resets the allowed domains to the system default
+		setLimits.ActionAllowedDomains = req.ActionAllowedDomains.GetList()
+		if setLimits.ActionAllowedDomains == nil {
+			setLimits.ActionAllowedDomains = make([]string, 0)
+		}
+	}
 	return setLimits
 }
@@ -0,0 +1,44 @@
+package system
+
+import (
+	"context"
+
+	system_pb "github.com/zitadel/zitadel/pkg/grpc/system"
+)
+
+func (s *Server) ListProjections(ctx context.Context, req *system_pb.ListProjectionsRequest) (*system_pb.ListProjectionsResponse, error) {
+	statuses, err := s.query.ProjectionStatuses(ctx, req.GetProjectionName(), req.GetInstanceId())
+	if err != nil {
+		return nil, err
+	}
+	return &system_pb.ListProjectionsResponse{Result: projectionStatusesToPb(statuses)}, nil
+}
+
+func (s *Server) RebuildProjection(ctx context.Context, req *system_pb.RebuildProjectionRequest) (*system_pb.RebuildProjectionResponse, error) {
+	if err := s.query.RebuildProjection(ctx, req.GetProjectionName(), req.GetInstanceIds()...); err != nil {
+		return nil, err
+	}
+	return &system_pb.RebuildProjectionResponse{}, nil
+}
+
+func (s *Server) PauseProjection(ctx context.Context, req *system_pb.PauseProjectionRequest) (*system_pb.PauseProjectionResponse, error) {
+	if err := s.query.PauseProjection(ctx, req.GetProjectionName(), req.GetInstanceId()); err != nil {
+		return nil, err
+	}
+	return &system_pb.PauseProjectionResponse{}, nil
+}
+
+func (s *Server) ResumeProjection(ctx context.Context, req *system_pb.ResumeProjectionRequest) (*system_pb.ResumeProjectionResponse, error) {
+	if err := s.query.ResumeProjection(ctx, req.GetProjectionName(), req.GetInstanceId()); err != nil {
+		return nil, err
+	}
+	return &system_pb.ResumeProjectionResponse{}, nil
+}
+
+func (s *Server) SkipFailedEvent(ctx context.Context, req *system_pb.SkipFailedEventRequest) (*system_pb.SkipFailedEventResponse, error) {
+	err := s.query.SkipFailedEvent(ctx, req.GetProjectionName(), req.GetInstanceId(), req.GetAggregateType(), req.GetAggregateId(), req.GetFailedSequence())
+	if err != nil {
+		return nil, err
+	}
+	return &system_pb.SkipFailedEventResponse{}, nil
+}
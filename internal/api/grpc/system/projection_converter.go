@@ -0,0 +1,60 @@
+package system
+
+import (
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	system_pb "github.com/zitadel/zitadel/pkg/grpc/system"
+)
+
+func projectionStatusesToPb(statuses []*handler.Status) []*system_pb.ProjectionStatus {
+	result := make([]*system_pb.ProjectionStatus, len(statuses))
+	for i, status := range statuses {
+		result[i] = projectionStatusToPb(status)
+	}
+	return result
+}
+
+func projectionStatusToPb(status *handler.Status) *system_pb.ProjectionStatus {
+	return &system_pb.ProjectionStatus{
+		ProjectionName: status.ProjectionName,
+		InstanceId:     status.InstanceID,
+		Position:       status.Position,
+		EventTimestamp: timestamppb.New(status.EventDate),
+		LastUpdated:    timestamppb.New(status.LastUpdated),
+		Lag:            durationpb.New(status.Lag),
+		FailedEvents:   status.FailedEvents,
+		SkippedEvents:  status.SkippedEvents,
+		Paused:         status.Paused,
+		Rebuild:        projectionRebuildToPb(status.Rebuild),
+	}
+}
+
+func projectionRebuildToPb(rebuild *handler.RebuildStatus) *system_pb.ProjectionRebuild {
+	if rebuild == nil {
+		return nil
+	}
+	pb := &system_pb.ProjectionRebuild{
+		State:     projectionRebuildStateToPb(rebuild.State),
+		Error:     rebuild.Error,
+		StartedAt: timestamppb.New(rebuild.StartedAt),
+	}
+	if !rebuild.FinishedAt.IsZero() {
+		pb.FinishedAt = timestamppb.New(rebuild.FinishedAt)
+	}
+	return pb
+}
+
+func projectionRebuildStateToPb(state handler.RebuildState) system_pb.ProjectionRebuildState {
+	switch state {
+	case handler.RebuildStateRunning:
+		return system_pb.ProjectionRebuildState_PROJECTION_REBUILD_STATE_RUNNING
+	case handler.RebuildStateDone:
+		return system_pb.ProjectionRebuildState_PROJECTION_REBUILD_STATE_DONE
+	case handler.RebuildStateFailed:
+		return system_pb.ProjectionRebuildState_PROJECTION_REBUILD_STATE_FAILED
+	default:
+		return system_pb.ProjectionRebuildState_PROJECTION_REBUILD_STATE_UNSPECIFIED
+	}
+}
@@ -1,6 +1,10 @@
 package user
 
 import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
 	"github.com/zitadel/zitadel/internal/api/grpc/object"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
@@ -65,7 +69,15 @@ func HumanToPb(view *query.Human, assetPrefix, owner string) *user_pb.Human {
 			Phone:           string(view.Phone),
 			IsPhoneVerified: view.IsPhoneVerified,
 		},
+		PasswordChanged: passwordChangedToPb(view.PasswordChanged),
+	}
+}
+
+func passwordChangedToPb(changed time.Time) *timestamppb.Timestamp {
+	if changed.IsZero() {
+		return nil
 	}
+	return timestamppb.New(changed)
 }
 
 func MachineToPb(view *query.Machine) *user_pb.Machine {
@@ -0,0 +1,28 @@
+package user
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/grpc/object/v2"
+	user "github.com/zitadel/zitadel/pkg/grpc/user/v2beta"
+)
+
+func (s *Server) GenerateRecoveryCodes(ctx context.Context, req *user.GenerateRecoveryCodesRequest) (*user.GenerateRecoveryCodesResponse, error) {
+	codes, err := s.command.GenerateHumanRecoveryCodes(ctx, req.GetUserId(), authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &user.GenerateRecoveryCodesResponse{
+		Details:       object.DomainToDetailsPb(codes.ObjectDetails),
+		RecoveryCodes: codes.Codes,
+	}, nil
+}
+
+func (s *Server) RemoveRecoveryCodes(ctx context.Context, req *user.RemoveRecoveryCodesRequest) (*user.RemoveRecoveryCodesResponse, error) {
+	objectDetails, err := s.command.RemoveHumanRecoveryCodes(ctx, req.GetUserId(), authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &user.RemoveRecoveryCodesResponse{Details: object.DomainToDetailsPb(objectDetails)}, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	errs "errors"
 	"io"
+	"slices"
 
 	"golang.org/x/text/language"
 	"google.golang.org/protobuf/types/known/structpb"
@@ -28,7 +29,7 @@ func (s *Server) AddHumanUser(ctx context.Context, req *user.AddHumanUserRequest
 		return nil, err
 	}
 	orgID := authz.GetCtxData(ctx).OrgID
-	if err = s.command.AddHuman(ctx, orgID, human, false); err != nil {
+	if err = s.command.AddHumanWithActions(ctx, orgID, human, false); err != nil {
 		return nil, err
 	}
 	return &user.AddHumanUserResponse{
@@ -253,6 +254,9 @@ func (s *Server) RetrieveIdentityProviderIntent(ctx context.Context, req *user.R
 	if intent.State != domain.IDPIntentStateSucceeded {
 		return nil, errors.ThrowPreconditionFailed(nil, "IDP-Hk38e", "Errors.Intent.NotSucceeded")
 	}
+	if err = s.command.RunIntentPostAuthenticationActions(ctx, intent); err != nil {
+		return nil, err
+	}
 	return idpIntentToIDPIntentPb(intent, s.idpAlg)
 }
 
@@ -363,9 +367,17 @@ func (s *Server) ListAuthenticationMethodTypes(ctx context.Context, req *user.Li
 	if err != nil {
 		return nil, err
 	}
+	var recoveryCodesRemaining int
+	if slices.Contains(authMethods.AuthMethodTypes, domain.UserAuthMethodTypeRecoveryCode) {
+		recoveryCodesRemaining, err = s.query.GetHumanRecoveryCodesRemaining(ctx, req.GetUserId(), "")
+		if err != nil {
+			return nil, err
+		}
+	}
 	return &user.ListAuthenticationMethodTypesResponse{
-		Details:         object.ToListDetails(authMethods.SearchResponse),
-		AuthMethodTypes: authMethodTypesToPb(authMethods.AuthMethodTypes),
+		Details:                object.ToListDetails(authMethods.SearchResponse),
+		AuthMethodTypes:        authMethodTypesToPb(authMethods.AuthMethodTypes),
+		RecoveryCodesRemaining: uint32(recoveryCodesRemaining),
 	}, nil
 }
 
@@ -393,6 +405,8 @@ func authMethodTypeToPb(methodType domain.UserAuthMethodType) user.Authenticatio
 		return user.AuthenticationMethodType_AUTHENTICATION_METHOD_TYPE_OTP_SMS
 	case domain.UserAuthMethodTypeOTPEmail:
 		return user.AuthenticationMethodType_AUTHENTICATION_METHOD_TYPE_OTP_EMAIL
+	case domain.UserAuthMethodTypeRecoveryCode:
+		return user.AuthenticationMethodType_AUTHENTICATION_METHOD_TYPE_RECOVERY_CODE
 	case domain.UserAuthMethodTypeUnspecified:
 		return user.AuthenticationMethodType_AUTHENTICATION_METHOD_TYPE_UNSPECIFIED
 	default:
@@ -4,10 +4,12 @@ import (
 	"net/http"
 
 	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/database"
 )
 
 func CallDurationHandler(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		next.ServeHTTP(w, r.WithContext(call.WithTimestamp(r.Context())))
+		ctx := call.WithTimestamp(r.Context())
+		next.ServeHTTP(w, r.WithContext(database.WithReadYourWrites(ctx)))
 	})
 }
@@ -40,7 +40,8 @@ func AuthMethodTypesToAMR(methodTypes []domain.UserAuthMethodType) []string {
 			// a user could use multiple (t)otp, which is a factor, but still will be returned as a single `otp` entry
 			otp++
 			factors++
-		case domain.UserAuthMethodTypeIDP:
+		case domain.UserAuthMethodTypeIDP,
+			domain.UserAuthMethodTypeRecoveryCode:
 			// no AMR value according to specification
 			factors++
 		case domain.UserAuthMethodTypeUnspecified:
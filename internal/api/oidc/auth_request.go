@@ -3,6 +3,7 @@ package oidc
 import (
 	"context"
 	"encoding/base64"
+	errs "errors"
 	"strings"
 	"time"
 
@@ -228,6 +229,9 @@ func (o *OPStorage) CreateAccessAndRefreshTokens(ctx context.Context, req op.Tok
 		activity.Trigger(ctx, "", tokenReq.GetSubject(), activity.OIDCRefreshToken)
 		return o.command.AddOIDCSessionRefreshAndAccessToken(setContextUserSystem(ctx), tokenReq.GetID())
 	case *RefreshTokenRequestV2:
+		if err = o.refreshTokenFlows(ctx, tokenReq); err != nil {
+			return "", "", time.Time{}, refreshTokenFlowsErr(err)
+		}
 		// trigger activity log for authentication for user
 		activity.Trigger(ctx, "", tokenReq.GetSubject(), activity.OIDCRefreshToken)
 		return o.command.ExchangeOIDCSessionRefreshAndAccessToken(setContextUserSystem(ctx), tokenReq.OIDCSessionWriteModel.AggregateID, refreshToken, tokenReq.RequestedScopes)
@@ -240,6 +244,9 @@ func (o *OPStorage) CreateAccessAndRefreshTokens(ctx context.Context, req op.Tok
 	}
 	if request, ok := req.(op.RefreshTokenRequest); ok {
 		request.SetCurrentScopes(scopes)
+		if err = o.refreshTokenFlows(ctx, request); err != nil {
+			return "", "", time.Time{}, refreshTokenFlowsErr(err)
+		}
 	}
 
 	accessTokenLifetime, _, refreshTokenIdleExpiration, refreshTokenExpiration, err := o.getOIDCSettings(ctx)
@@ -262,6 +269,15 @@ func (o *OPStorage) CreateAccessAndRefreshTokens(ctx context.Context, req op.Tok
 	return resp.TokenID, token, resp.Expiration, nil
 }
 
+// refreshTokenFlowsErr returns an invalid grant error with the message of the action if it rejected the refresh
+func refreshTokenFlowsErr(err error) error {
+	caosErr := new(errors.CaosError)
+	if errors.IsPreconditionFailed(err) && errs.As(err, &caosErr) {
+		return oidc.ErrInvalidGrant().WithParent(err).WithDescription(caosErr.GetMessage())
+	}
+	return err
+}
+
 func getInfoFromRequest(req op.TokenRequest) (string, string, string, time.Time, []string) {
 	authReq, ok := req.(*AuthRequest)
 	if ok {
@@ -13,6 +13,7 @@ import (
 	"github.com/zitadel/logging"
 	"github.com/zitadel/oidc/v3/pkg/oidc"
 	"github.com/zitadel/oidc/v3/pkg/op"
+	"golang.org/x/text/language"
 
 	"github.com/zitadel/zitadel/internal/actions"
 	"github.com/zitadel/zitadel/internal/actions/object"
@@ -534,7 +535,10 @@ func (o *OPStorage) userinfoFlows(ctx context.Context, user *query.User, userGra
 			apiFields,
 			action.Script,
 			action.Name,
-			append(actions.ActionToOptions(action), actions.WithHTTP(actionCtx), actions.WithUUID(actionCtx))...,
+			append(
+				actions.ActionToOptions(action),
+				actions.DefaultModules(actionCtx, o.query, action.ResourceOwner)...,
+			)...,
 		)
 		cancel()
 		if err != nil {
@@ -715,7 +719,10 @@ func (o *OPStorage) privateClaimsFlows(ctx context.Context, userID string, userG
 			apiFields,
 			action.Script,
 			action.Name,
-			append(actions.ActionToOptions(action), actions.WithHTTP(actionCtx), actions.WithUUID(actionCtx))...,
+			append(
+				actions.ActionToOptions(action),
+				actions.DefaultModules(actionCtx, o.query, action.ResourceOwner)...,
+			)...,
 		)
 		cancel()
 		if err != nil {
@@ -730,6 +737,63 @@ func (o *OPStorage) privateClaimsFlows(ctx context.Context, userID string, userG
 	return claims, nil
 }
 
+// refreshTokenFlows runs the pre refresh token creation actions,
+// any action is able to reject the refresh by calling api.v1.reject with an optional (localized) message
+func (o *OPStorage) refreshTokenFlows(ctx context.Context, req op.RefreshTokenRequest) error {
+	user, err := o.query.GetUserByID(ctx, true, req.GetSubject())
+	if err != nil {
+		return err
+	}
+	queriedActions, err := o.query.GetActiveActionsByFlowAndTriggerType(ctx, domain.FlowTypeCustomiseToken, domain.TriggerTypePreRefreshTokenCreation, user.ResourceOwner)
+	if err != nil || len(queriedActions) == 0 {
+		return err
+	}
+
+	ctxFields := actions.SetContextFields(
+		actions.SetFields("v1",
+			actions.SetFields("getUser", func(c *actions.FieldConfig) interface{} {
+				return func(call goja.FunctionCall) goja.Value {
+					return object.UserFromQuery(c, user)
+				}
+			}),
+			actions.SetFields("refreshToken", object.RefreshTokenField(req.GetClientID(), req.GetScopes(), req.GetAudience(), req.GetAMR(), req.GetAuthTime())),
+		),
+	)
+	rejection := new(object.Rejection)
+	apiFields := actions.WithAPIFields(
+		actions.SetFields("v1",
+			actions.SetFields("reject", rejection.RejectFunc),
+		),
+	)
+
+	for _, action := range queriedActions {
+		actionCtx, cancel := context.WithTimeout(ctx, action.Timeout())
+		err = actions.Run(
+			actionCtx,
+			ctxFields,
+			apiFields,
+			action.Script,
+			action.Name,
+			append(
+				actions.ActionToOptions(action),
+				actions.DefaultModules(actionCtx, o.query, action.ResourceOwner)...,
+			)...,
+		)
+		cancel()
+		if err != nil {
+			return err
+		}
+		if rejection.Rejected() {
+			break
+		}
+	}
+	var preferredLanguage language.Tag
+	if user.Human != nil {
+		preferredLanguage = user.Human.PreferredLanguage
+	}
+	return rejection.Err(preferredLanguage, authz.GetInstance(ctx).DefaultLanguage())
+}
+
 func (o *OPStorage) assertRoles(ctx context.Context, userID, applicationID string, requestedRoles, roleAudience []string) (*query.UserGrants, *projectsRoles, error) {
 	if (applicationID == "" || len(requestedRoles) == 0) && len(roleAudience) == 0 {
 		return nil, nil, nil
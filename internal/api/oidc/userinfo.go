@@ -261,7 +261,10 @@ func (s *Server) userinfoFlows(ctx context.Context, qu *query.OIDCUserInfo, user
 			apiFields,
 			action.Script,
 			action.Name,
-			append(actions.ActionToOptions(action), actions.WithHTTP(actionCtx), actions.WithUUID(actionCtx))...,
+			append(
+				actions.ActionToOptions(action),
+				actions.DefaultModules(actionCtx, s.query, action.ResourceOwner)...,
+			)...,
 		)
 		cancel()
 		if err != nil {
@@ -303,7 +303,10 @@ func (p *Storage) getCustomAttributes(ctx context.Context, user *query.User, use
 			apiFields,
 			action.Script,
 			action.Name,
-			append(actions.ActionToOptions(action), actions.WithHTTP(actionCtx))...,
+			append(
+				actions.ActionToOptions(action),
+				actions.DefaultModules(actionCtx, p.query, action.ResourceOwner)...,
+			)...,
 		)
 		cancel()
 		if err != nil {
@@ -133,7 +133,10 @@ func (l *Login) runPostExternalAuthenticationActions(
 			apiFields,
 			a.Script,
 			a.Name,
-			append(actions.ActionToOptions(a), actions.WithHTTP(actionCtx), actions.WithUUID(actionCtx))...,
+			append(
+				actions.ActionToOptions(a),
+				actions.DefaultModules(actionCtx, l.query, a.ResourceOwner)...,
+			)...,
 		)
 		cancel()
 		if err != nil {
@@ -151,6 +154,7 @@ const (
 	authMethodOTP          authMethod = "OTP"
 	authMethodOTPSMS       authMethod = "OTP SMS"
 	authMethodOTPEmail     authMethod = "OTP Email"
+	authMethodRecoveryCode authMethod = "recovery code"
 	authMethodU2F          authMethod = "U2F"
 	authMethodPasswordless authMethod = "passwordless"
 )
@@ -206,7 +210,10 @@ func (l *Login) runPostInternalAuthenticationActions(
 			apiFields,
 			a.Script,
 			a.Name,
-			append(actions.ActionToOptions(a), actions.WithHTTP(actionCtx), actions.WithUUID(actionCtx))...,
+			append(
+				actions.ActionToOptions(a),
+				actions.DefaultModules(actionCtx, l.query, a.ResourceOwner)...,
+			)...,
 		)
 		cancel()
 		if err != nil {
@@ -307,7 +314,10 @@ func (l *Login) runPreCreationActions(
 			apiFields,
 			a.Script,
 			a.Name,
-			append(actions.ActionToOptions(a), actions.WithHTTP(actionCtx), actions.WithUUID(actionCtx))...,
+			append(
+				actions.ActionToOptions(a),
+				actions.DefaultModules(actionCtx, l.query, a.ResourceOwner)...,
+			)...,
 		)
 		cancel()
 		if err != nil {
@@ -317,6 +327,68 @@ func (l *Login) runPreCreationActions(
 	return user, object.MetadataListToDomain(metadataList), err
 }
 
+// runPreRegistrationValidationActions runs the pre registration validation actions of the flow,
+// any action is able to reject the registration by calling api.v1.reject with an optional (localized) message
+func (l *Login) runPreRegistrationValidationActions(
+	authRequest *domain.AuthRequest,
+	httpRequest *http.Request,
+	user *domain.Human,
+	resourceOwner string,
+	flowType domain.FlowType,
+) error {
+	ctx := httpRequest.Context()
+
+	triggerActions, err := l.query.GetActiveActionsByFlowAndTriggerType(ctx, flowType, domain.TriggerTypePreRegistrationValidation, resourceOwner)
+	if err != nil {
+		return err
+	}
+
+	rejection := new(object.Rejection)
+	apiFields := actions.WithAPIFields(
+		actions.SetFields("v1",
+			actions.SetFields("reject", rejection.RejectFunc),
+		),
+	)
+
+	for _, a := range triggerActions {
+		actionCtx, cancel := context.WithTimeout(ctx, a.Timeout())
+
+		ctxFields := actions.SetContextFields(
+			actions.SetFields("v1",
+				actions.SetFields("user", func(c *actions.FieldConfig) interface{} {
+					return object.UserFromHuman(c, user)
+				}),
+				actions.SetFields("authRequest", object.AuthRequestField(authRequest)),
+				actions.SetFields("httpRequest", object.HTTPRequestField(httpRequest)),
+			),
+		)
+
+		err = actions.Run(
+			actionCtx,
+			ctxFields,
+			apiFields,
+			a.Script,
+			a.Name,
+			append(
+				actions.ActionToOptions(a),
+				actions.DefaultModules(actionCtx, l.query, a.ResourceOwner)...,
+			)...,
+		)
+		cancel()
+		if err != nil {
+			return err
+		}
+		if rejection.Rejected() {
+			break
+		}
+	}
+	if !rejection.Rejected() {
+		return nil
+	}
+	// the message is shown in the language of the login UI
+	return rejection.Err(l.renderer.ReqLang(l.getTranslator(ctx, authRequest), httpRequest), user.PreferredLanguage)
+}
+
 func (l *Login) runPostCreationActions(
 	userID string,
 	authRequest *domain.AuthRequest,
@@ -365,7 +437,10 @@ func (l *Login) runPostCreationActions(
 			apiFields,
 			a.Script,
 			a.Name,
-			append(actions.ActionToOptions(a), actions.WithHTTP(actionCtx), actions.WithUUID(actionCtx))...,
+			append(
+				actions.ActionToOptions(a),
+				actions.DefaultModules(actionCtx, l.query, a.ResourceOwner)...,
+			)...,
 		)
 		cancel()
 		if err != nil {
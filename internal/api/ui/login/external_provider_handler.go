@@ -651,6 +651,11 @@ func (l *Login) registerExternalUser(w http.ResponseWriter, r *http.Request, aut
 		l.renderExternalNotFoundOption(w, r, authReq, orgIamPolicy, nil, nil, err)
 		return
 	}
+	err = l.runPreRegistrationValidationActions(authReq, r, user, resourceOwner, domain.FlowTypeExternalAuthentication)
+	if err != nil {
+		l.renderExternalNotFoundOption(w, r, authReq, orgIamPolicy, nil, nil, err)
+		return
+	}
 	err = l.authRepo.AutoRegisterExternalUser(setContext(r.Context(), resourceOwner), user, externalIDP, nil, authReq.ID, authReq.AgentID, resourceOwner, metadata, domain.BrowserInfoFromRequest(r))
 	if err != nil {
 		l.renderExternalNotFoundOption(w, r, authReq, orgIamPolicy, user, externalIDP, err)
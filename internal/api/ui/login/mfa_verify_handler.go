@@ -8,7 +8,8 @@ import (
 )
 
 const (
-	tmplMFAVerify = "mfaverify"
+	tmplMFAVerify                = "mfaverify"
+	tmplRecoveryCodeVerification = "recoverycodeverification"
 )
 
 type mfaVerifyFormData struct {
@@ -49,6 +50,22 @@ func (l *Login) handleMFAVerify(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	}
+	if data.MFAType == domain.MFATypeRecoveryCode {
+		userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
+		err = l.authRepo.VerifyMFARecoveryCode(setContext(r.Context(), authReq.UserOrgID), authReq.UserID, authReq.UserOrgID, data.Code, authReq.ID, userAgentID, domain.BrowserInfoFromRequest(r))
+
+		metadata, actionErr := l.runPostInternalAuthenticationActions(authReq, r, authMethodRecoveryCode, err)
+		if err == nil && actionErr == nil && len(metadata) > 0 {
+			_, err = l.command.BulkSetUserMetadata(r.Context(), authReq.UserID, authReq.UserOrgID, metadata...)
+		} else if actionErr != nil && err == nil {
+			err = actionErr
+		}
+
+		if err != nil {
+			l.renderMFAVerifySelected(w, r, authReq, step, domain.MFATypeRecoveryCode, err)
+			return
+		}
+	}
 	l.renderNextStep(w, r, authReq)
 }
 
@@ -57,7 +74,7 @@ func (l *Login) renderMFAVerify(w http.ResponseWriter, r *http.Request, authReq
 		l.renderError(w, r, authReq, err)
 		return
 	}
-	provider := verificationStep.MFAProviders[len(verificationStep.MFAProviders)-1]
+	provider := defaultMFAProvider(verificationStep.MFAProviders)
 	l.renderMFAVerifySelected(w, r, authReq, verificationStep, provider, err)
 }
 
@@ -90,6 +107,13 @@ func (l *Login) renderMFAVerifySelected(w http.ResponseWriter, r *http.Request,
 	case domain.MFATypeOTPEmail:
 		l.handleOTPVerification(w, r, authReq, verificationStep.MFAProviders, domain.MFATypeOTPEmail, nil)
 		return
+	case domain.MFATypeRecoveryCode:
+		data.MFAProviders = removeSelectedProviderFromList(verificationStep.MFAProviders, domain.MFATypeRecoveryCode)
+		data.SelectedMFAProvider = domain.MFATypeRecoveryCode
+		data.Title = translator.LocalizeWithoutArgs("VerifyMFARecoveryCode.Title")
+		data.Description = translator.LocalizeWithoutArgs("VerifyMFARecoveryCode.Description")
+		l.renderer.RenderTemplate(w, r, translator, l.renderer.Templates[tmplRecoveryCodeVerification], data, nil)
+		return
 	default:
 		l.renderError(w, r, authReq, err)
 		return
@@ -97,6 +121,17 @@ func (l *Login) renderMFAVerifySelected(w http.ResponseWriter, r *http.Request,
 	l.renderer.RenderTemplate(w, r, translator, l.renderer.Templates[tmplMFAVerify], data, nil)
 }
 
+// defaultMFAProvider returns the last provider of the list,
+// recovery codes are only preselected if there's no other provider available
+func defaultMFAProvider(providers []domain.MFAType) domain.MFAType {
+	for i := len(providers) - 1; i >= 0; i-- {
+		if providers[i] != domain.MFATypeRecoveryCode {
+			return providers[i]
+		}
+	}
+	return providers[len(providers)-1]
+}
+
 func removeSelectedProviderFromList(providers []domain.MFAType, selected domain.MFAType) []domain.MFAType {
 	for i := len(providers) - 1; i >= 0; i-- {
 		if providers[i] == selected {
@@ -59,6 +59,7 @@ func CreateRenderer(pathPrefix string, staticStorage static.Storage, cookieName
 		tmplMFAInitVerify:                "mfa_init_otp.html",
 		tmplMFASMSInit:                   "mfa_init_otp_sms.html",
 		tmplOTPVerification:              "mfa_verify_otp.html",
+		tmplRecoveryCodeVerification:     "mfa_verify_recovery_code.html",
 		tmplMFAU2FInit:                   "mfa_init_u2f.html",
 		tmplU2FVerification:              "mfa_verification_u2f.html",
 		tmplMFAInitDone:                  "mfa_init_done.html",
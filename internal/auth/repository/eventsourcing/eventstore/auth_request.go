@@ -42,6 +42,7 @@ type AuthRequestRepo struct {
 	OrgViewProvider           orgViewProvider
 	LoginPolicyViewProvider   loginPolicyViewProvider
 	LockoutPolicyViewProvider lockoutPolicyViewProvider
+	PasswordAgePolicyProvider passwordAgePolicyProvider
 	PrivacyPolicyProvider     privacyPolicyProvider
 	IDPProviderViewProvider   idpProviderViewProvider
 	IDPUserLinksProvider      idpUserLinksProvider
@@ -81,6 +82,10 @@ type lockoutPolicyViewProvider interface {
 	LockoutPolicyByOrg(context.Context, bool, string, bool) (*query.LockoutPolicy, error)
 }
 
+type passwordAgePolicyProvider interface {
+	PasswordAgePolicyByOrg(context.Context, bool, string, bool) (*query.PasswordAgePolicy, error)
+}
+
 type idpProviderViewProvider interface {
 	IDPLoginPolicyLinks(context.Context, string, *query.IDPLoginPolicyLinksSearchQuery, bool) (*query.IDPLoginPolicyLinks, error)
 }
@@ -378,7 +383,10 @@ func lockoutPolicyToDomain(policy *query.LockoutPolicy) *domain.LockoutPolicy {
 		},
 		Default:             policy.IsDefault,
 		MaxPasswordAttempts: policy.MaxPasswordAttempts,
+		MaxOTPAttempts:      policy.MaxOTPAttempts,
 		ShowLockOutFailures: policy.ShowFailures,
+		AutoUnlockAfter:     policy.AutoUnlockAfter,
+		FailedAttemptDelay:  policy.FailedAttemptDelay,
 	}
 }
 
@@ -389,7 +397,11 @@ func (repo *AuthRequestRepo) VerifyMFAOTP(ctx context.Context, authRequestID, us
 	if err != nil {
 		return err
 	}
-	return repo.Command.HumanCheckMFATOTP(ctx, userID, code, resourceOwner, request.WithCurrentInfo(info))
+	policy, err := repo.getLockoutPolicy(ctx, resourceOwner)
+	if err != nil {
+		return err
+	}
+	return repo.Command.HumanCheckMFATOTP(ctx, userID, code, resourceOwner, request.WithCurrentInfo(info), lockoutPolicyToDomain(policy))
 }
 
 func (repo *AuthRequestRepo) SendMFAOTPSMS(ctx context.Context, userID, resourceOwner, authRequestID, userAgentID string) (err error) {
@@ -410,7 +422,11 @@ func (repo *AuthRequestRepo) VerifyMFAOTPSMS(ctx context.Context, userID, resour
 	if err != nil {
 		return err
 	}
-	return repo.Command.HumanCheckOTPSMS(ctx, userID, code, resourceOwner, request.WithCurrentInfo(info))
+	policy, err := repo.getLockoutPolicy(ctx, resourceOwner)
+	if err != nil {
+		return err
+	}
+	return repo.Command.HumanCheckOTPSMS(ctx, userID, code, resourceOwner, request.WithCurrentInfo(info), lockoutPolicyToDomain(policy))
 }
 
 func (repo *AuthRequestRepo) SendMFAOTPEmail(ctx context.Context, userID, resourceOwner, authRequestID, userAgentID string) (err error) {
@@ -431,7 +447,21 @@ func (repo *AuthRequestRepo) VerifyMFAOTPEmail(ctx context.Context, userID, reso
 	if err != nil {
 		return err
 	}
-	return repo.Command.HumanCheckOTPEmail(ctx, userID, code, resourceOwner, request.WithCurrentInfo(info))
+	policy, err := repo.getLockoutPolicy(ctx, resourceOwner)
+	if err != nil {
+		return err
+	}
+	return repo.Command.HumanCheckOTPEmail(ctx, userID, code, resourceOwner, request.WithCurrentInfo(info), lockoutPolicyToDomain(policy))
+}
+
+func (repo *AuthRequestRepo) VerifyMFARecoveryCode(ctx context.Context, userID, resourceOwner, code, authRequestID, userAgentID string, info *domain.BrowserInfo) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.getAuthRequestEnsureUser(ctx, authRequestID, userAgentID, userID)
+	if err != nil {
+		return err
+	}
+	return repo.Command.HumanCheckRecoveryCode(ctx, userID, code, resourceOwner, request.WithCurrentInfo(info))
 }
 
 func (repo *AuthRequestRepo) BeginMFAU2FLogin(ctx context.Context, userID, resourceOwner, authRequestID, userAgentID string) (login *domain.WebAuthNLogin, err error) {
@@ -452,7 +482,11 @@ func (repo *AuthRequestRepo) VerifyMFAU2F(ctx context.Context, userID, resourceO
 	if err != nil {
 		return err
 	}
-	return repo.Command.HumanFinishU2FLogin(ctx, userID, resourceOwner, credentialData, request)
+	policy, err := repo.getLockoutPolicy(ctx, resourceOwner)
+	if err != nil {
+		return err
+	}
+	return repo.Command.HumanFinishU2FLogin(ctx, userID, resourceOwner, credentialData, request, lockoutPolicyToDomain(policy))
 }
 
 func (repo *AuthRequestRepo) BeginPasswordlessSetup(ctx context.Context, userID, resourceOwner string, authenticatorPlatform domain.AuthenticatorAttachment) (login *domain.WebAuthNToken, err error) {
@@ -747,7 +781,8 @@ func (repo *AuthRequestRepo) checkLoginName(ctx context.Context, request *domain
 		return err
 	}
 	// if there's an active (human) user, let's use it
-	if user != nil && !user.HumanView.IsZero() && domain.UserState(user.State).NotDisabled() {
+	if user != nil && !user.HumanView.IsZero() && (domain.UserState(user.State).NotDisabled() ||
+		user.State == int32(domain.UserStateLocked) && lockExpired(ctx, repo.LockoutPolicyViewProvider, repo.UserEventProvider, user.ID, user.ResourceOwner)) {
 		request.SetUserInfo(user.ID, loginName, user.PreferredLoginName, "", "", user.ResourceOwner)
 		return nil
 	}
@@ -1044,7 +1079,14 @@ func (repo *AuthRequestRepo) nextSteps(ctx context.Context, request *domain.Auth
 		return append(steps, step), nil
 	}
 
-	if user.PasswordChangeRequired {
+	passwordChangeRequired := user.PasswordChangeRequired
+	if !passwordChangeRequired {
+		passwordChangeRequired, err = repo.passwordExpired(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if passwordChangeRequired {
 		steps = append(steps, &domain.ChangePasswordStep{})
 	}
 	if !user.IsEmailVerified {
@@ -1054,7 +1096,7 @@ func (repo *AuthRequestRepo) nextSteps(ctx context.Context, request *domain.Auth
 		steps = append(steps, &domain.ChangeUsernameStep{})
 	}
 
-	if user.PasswordChangeRequired || !user.IsEmailVerified || user.UsernameChangeRequired {
+	if passwordChangeRequired || !user.IsEmailVerified || user.UsernameChangeRequired {
 		return steps, nil
 	}
 
@@ -1300,6 +1342,33 @@ func (repo *AuthRequestRepo) getLockoutPolicy(ctx context.Context, orgID string)
 	return policy, err
 }
 
+// passwordExpired checks the password of the user against the password age policy of its organisation.
+// Users without a password or without a known password change date are never considered expired.
+func (repo *AuthRequestRepo) passwordExpired(ctx context.Context, user *user_model.UserView) (bool, error) {
+	if !user.PasswordSet || user.PasswordChanged.IsZero() {
+		return false, nil
+	}
+	policy, err := repo.PasswordAgePolicyProvider.PasswordAgePolicyByOrg(ctx, false, user.ResourceOwner, false)
+	if err != nil {
+		return false, err
+	}
+	return passwordAgePolicyToDomain(policy).IsPasswordExpired(user.PasswordChanged, time.Now()), nil
+}
+
+func passwordAgePolicyToDomain(policy *query.PasswordAgePolicy) *domain.PasswordAgePolicy {
+	return &domain.PasswordAgePolicy{
+		ObjectRoot: es_models.ObjectRoot{
+			AggregateID:   policy.ID,
+			Sequence:      policy.Sequence,
+			ResourceOwner: policy.ResourceOwner,
+			CreationDate:  policy.CreationDate,
+			ChangeDate:    policy.ChangeDate,
+		},
+		MaxAgeDays:     policy.MaxAgeDays,
+		ExpireWarnDays: policy.ExpireWarnDays,
+	}
+}
+
 func (repo *AuthRequestRepo) getLabelPolicy(ctx context.Context, orgID string) (*domain.LabelPolicy, error) {
 	policy, err := repo.LabelPolicyProvider.ActiveLabelPolicyByOrg(ctx, orgID, false)
 	if err != nil {
@@ -1515,7 +1584,6 @@ func userSessionByIDs(ctx context.Context, provider userSessionViewProvider, eve
 }
 
 func activeUserByID(ctx context.Context, userViewProvider userViewProvider, userEventProvider userEventProvider, queries orgViewProvider, lockoutPolicyProvider lockoutPolicyViewProvider, userID string, ignoreUnknownUsernames bool) (user *user_model.UserView, err error) {
-	// PLANNED: Check LockoutPolicy
 	user, err = userByID(ctx, userViewProvider, userEventProvider, userID)
 	if err != nil {
 		if ignoreUnknownUsernames && errors.IsNotFound(err) {
@@ -1530,10 +1598,11 @@ func activeUserByID(ctx context.Context, userViewProvider userViewProvider, user
 	if user.HumanView == nil {
 		return nil, errors.ThrowPreconditionFailed(nil, "EVENT-Lm69x", "Errors.User.NotHuman")
 	}
-	if user.State == user_model.UserStateLocked || user.State == user_model.UserStateSuspend {
+	if user.State == user_model.UserStateLocked && !lockExpired(ctx, lockoutPolicyProvider, userEventProvider, user.ID, user.ResourceOwner) ||
+		user.State == user_model.UserStateSuspend {
 		return nil, errors.ThrowPreconditionFailed(nil, "EVENT-FJ262", "Errors.User.Locked")
 	}
-	if !(user.State == user_model.UserStateActive || user.State == user_model.UserStateInitial) {
+	if !(user.State == user_model.UserStateActive || user.State == user_model.UserStateInitial || user.State == user_model.UserStateLocked) {
 		return nil, errors.ThrowPreconditionFailed(nil, "EVENT-FJ262", "Errors.User.NotActive")
 	}
 	org, err := queries.OrgByID(ctx, false, user.ResourceOwner)
@@ -1546,6 +1615,20 @@ func activeUserByID(ctx context.Context, userViewProvider userViewProvider, user
 	return user, nil
 }
 
+// lockExpired checks if the lock of the user expired according to the lockout policy of its organisation.
+// The user itself will be unlocked with its next check of an authentication factor.
+func lockExpired(ctx context.Context, lockoutPolicyProvider lockoutPolicyViewProvider, userEventProvider userEventProvider, userID, resourceOwner string) bool {
+	policy, err := lockoutPolicyProvider.LockoutPolicyByOrg(ctx, false, resourceOwner, false)
+	if err != nil || policy.AutoUnlockAfter <= 0 {
+		return false
+	}
+	events, err := userEventProvider.UserEventsByID(ctx, userID, 0, []eventstore.EventType{user_repo.UserLockedType})
+	if err != nil || len(events) == 0 {
+		return false
+	}
+	return lockoutPolicyToDomain(policy).IsAutoUnlocked(events[len(events)-1].CreatedAt(), time.Now())
+}
+
 func userByID(ctx context.Context, viewProvider userViewProvider, eventProvider userEventProvider, userID string) (_ *user_model.UserView, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
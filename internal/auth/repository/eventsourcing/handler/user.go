@@ -73,6 +73,18 @@ func (u *User) Reducers() []handler.AggregateReducer {
 					Event:  user_repo.HumanOTPEmailRemovedType,
 					Reduce: u.ProcessUser,
 				},
+				{
+					Event:  user_repo.HumanRecoveryCodesAddedType,
+					Reduce: u.ProcessUser,
+				},
+				{
+					Event:  user_repo.HumanRecoveryCodeCheckSucceededType,
+					Reduce: u.ProcessUser,
+				},
+				{
+					Event:  user_repo.HumanRecoveryCodesRemovedType,
+					Reduce: u.ProcessUser,
+				},
 				{
 					Event:  user_repo.MachineAddedEventType,
 					Reduce: u.ProcessUser,
@@ -241,6 +253,10 @@ func (u *User) Reducers() []handler.AggregateReducer {
 					Event:  user_repo.HumanPasswordChangedType,
 					Reduce: u.ProcessUser,
 				},
+				{
+					Event:  user_repo.HumanPasswordBreachedType,
+					Reduce: u.ProcessUser,
+				},
 				{
 					Event:  user_repo.HumanInitialCodeAddedType,
 					Reduce: u.ProcessUser,
@@ -371,6 +387,9 @@ func (u *User) ProcessUser(event eventstore.Event) (_ *handler.Statement, err er
 			user_repo.HumanOTPSMSRemovedType,
 			user_repo.HumanOTPEmailAddedType,
 			user_repo.HumanOTPEmailRemovedType,
+			user_repo.HumanRecoveryCodesAddedType,
+			user_repo.HumanRecoveryCodeCheckSucceededType,
+			user_repo.HumanRecoveryCodesRemovedType,
 			user_repo.HumanU2FTokenAddedType,
 			user_repo.HumanU2FTokenVerifiedType,
 			user_repo.HumanU2FTokenRemovedType,
@@ -380,6 +399,7 @@ func (u *User) ProcessUser(event eventstore.Event) (_ *handler.Statement, err er
 			user_repo.HumanMFAInitSkippedType,
 			user_repo.MachineChangedEventType,
 			user_repo.HumanPasswordChangedType,
+			user_repo.HumanPasswordBreachedType,
 			user_repo.HumanInitialCodeAddedType,
 			user_repo.UserV1InitialCodeAddedType,
 			user_repo.UserV1InitializedCheckSucceededType,
@@ -108,6 +108,14 @@ func (s *UserSession) Reducers() []handler.AggregateReducer {
 					Event:  user.HumanU2FTokenCheckFailedType,
 					Reduce: s.Reduce,
 				},
+				{
+					Event:  user.HumanRecoveryCodeCheckSucceededType,
+					Reduce: s.Reduce,
+				},
+				{
+					Event:  user.HumanRecoveryCodeCheckFailedType,
+					Reduce: s.Reduce,
+				},
 				{
 					Event:  user.HumanPasswordlessTokenCheckSucceededType,
 					Reduce: s.Reduce,
@@ -231,6 +239,8 @@ func (u *UserSession) Reduce(event eventstore.Event) (_ *handler.Statement, err
 			user.HumanMFAOTPCheckFailedType,
 			user.HumanU2FTokenCheckSucceededType,
 			user.HumanU2FTokenCheckFailedType,
+			user.HumanRecoveryCodeCheckSucceededType,
+			user.HumanRecoveryCodeCheckFailedType,
 			user.HumanPasswordlessTokenCheckSucceededType,
 			user.HumanPasswordlessTokenCheckFailedType,
 			user.HumanSignedOutType:
@@ -239,6 +249,11 @@ func (u *UserSession) Reduce(event eventstore.Event) (_ *handler.Statement, err
 			if err != nil {
 				return err
 			}
+			// recovery codes checked through the session API are not bound to a user agent
+			if eventData.UserAgentID == "" &&
+				(event.Type() == user.HumanRecoveryCodeCheckSucceededType || event.Type() == user.HumanRecoveryCodeCheckFailedType) {
+				return nil
+			}
 			session, err = u.view.UserSessionByIDs(eventData.UserAgentID, event.Aggregate().ID, event.Aggregate().InstanceID)
 			if err != nil {
 				if !errors.IsNotFound(err) {
@@ -358,7 +358,7 @@ func TestCommands_LinkSessionToAuthRequest(t *testing.T) {
 						),
 						eventFromEventPusher(
 							session.NewPasswordCheckedEvent(mockCtx, &session.NewAggregate("sessionID", "instance1").Aggregate,
-								testNow.Add(-5*time.Minute)),
+								"userID", "org1", testNow.Add(-5*time.Minute), false),
 						),
 						eventFromEventPusher(
 							session.NewLifetimeSetEvent(mockCtx, &session.NewAggregate("sessionID", "instance1").Aggregate,
@@ -467,7 +467,7 @@ func TestCommands_LinkSessionToAuthRequest(t *testing.T) {
 						),
 						eventFromEventPusher(
 							session.NewPasswordCheckedEvent(mockCtx, &session.NewAggregate("sessionID", "instance1").Aggregate,
-								testNow),
+								"userID", "org1", testNow, false),
 						),
 						eventFromEventPusherWithCreationDateNow(
 							session.NewLifetimeSetEvent(mockCtx, &session.NewAggregate("sessionID", "instance1").Aggregate,
@@ -552,7 +552,7 @@ func TestCommands_LinkSessionToAuthRequest(t *testing.T) {
 						),
 						eventFromEventPusher(
 							session.NewPasswordCheckedEvent(mockCtx, &session.NewAggregate("sessionID", "instance1").Aggregate,
-								testNow),
+								"userID", "org1", testNow, false),
 						),
 						eventFromEventPusherWithCreationDateNow(
 							session.NewLifetimeSetEvent(mockCtx, &session.NewAggregate("sessionID", "instance1").Aggregate,
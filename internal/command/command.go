@@ -25,18 +25,22 @@ import (
 	"github.com/zitadel/zitadel/internal/id"
 	"github.com/zitadel/zitadel/internal/repository/action"
 	"github.com/zitadel/zitadel/internal/repository/authrequest"
+	"github.com/zitadel/zitadel/internal/repository/eventsink"
+	"github.com/zitadel/zitadel/internal/repository/execution"
 	"github.com/zitadel/zitadel/internal/repository/feature"
 	"github.com/zitadel/zitadel/internal/repository/idpintent"
 	instance_repo "github.com/zitadel/zitadel/internal/repository/instance"
 	"github.com/zitadel/zitadel/internal/repository/keypair"
 	"github.com/zitadel/zitadel/internal/repository/limits"
 	"github.com/zitadel/zitadel/internal/repository/milestone"
+	"github.com/zitadel/zitadel/internal/repository/notification"
 	"github.com/zitadel/zitadel/internal/repository/oidcsession"
 	"github.com/zitadel/zitadel/internal/repository/org"
 	proj_repo "github.com/zitadel/zitadel/internal/repository/project"
 	"github.com/zitadel/zitadel/internal/repository/quota"
 	"github.com/zitadel/zitadel/internal/repository/restrictions"
 	"github.com/zitadel/zitadel/internal/repository/session"
+	"github.com/zitadel/zitadel/internal/repository/target"
 	usr_repo "github.com/zitadel/zitadel/internal/repository/user"
 	usr_grant_repo "github.com/zitadel/zitadel/internal/repository/usergrant"
 	"github.com/zitadel/zitadel/internal/static"
@@ -66,6 +70,8 @@ type Commands struct {
 	smsEncryption                   crypto.EncryptionAlgorithm
 	userEncryption                  crypto.EncryptionAlgorithm
 	userPasswordHasher              *crypto.PasswordHasher
+	breachedPasswordChecker         crypto.BreachedPasswordChecker
+	checkBreachedPasswordOnLogin    bool
 	codeAlg                         crypto.HashAlgorithm
 	machineKeySize                  int
 	applicationKeySize              int
@@ -90,6 +96,8 @@ type Commands struct {
 	defaultSecretGenerators *SecretGenerators
 
 	samlCertificateAndKeyGenerator func(id string) ([]byte, []byte, error)
+	// actionQueries are used to run the actions of the authentication flows, actions are not run if nil
+	actionQueries ActionQueries
 }
 
 func StartCommands(
@@ -109,6 +117,7 @@ func StartCommands(
 	defaultRefreshTokenLifetime,
 	defaultRefreshTokenIdleLifetime time.Duration,
 	defaultSecretGenerators *SecretGenerators,
+	actionQueries ActionQueries,
 ) (repo *Commands, err error) {
 	if externalDomain == "" {
 		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Df21s", "no external domain specified")
@@ -148,6 +157,7 @@ func StartCommands(
 		defaultRefreshTokenIdleLifetime: defaultRefreshTokenIdleLifetime,
 		defaultSecretGenerators:         defaultSecretGenerators,
 		samlCertificateAndKeyGenerator:  samlCertificateAndKeyGenerator(defaults.KeyConfig.Size),
+		actionQueries:                   actionQueries,
 	}
 
 	instance_repo.RegisterEventMappers(repo.eventstore)
@@ -165,6 +175,10 @@ func StartCommands(
 	authrequest.RegisterEventMappers(repo.eventstore)
 	oidcsession.RegisterEventMappers(repo.eventstore)
 	milestone.RegisterEventMappers(repo.eventstore)
+	eventsink.RegisterEventMappers(repo.eventstore)
+	notification.RegisterEventMappers(repo.eventstore)
+	target.RegisterEventMappers(repo.eventstore)
+	execution.RegisterEventMappers(repo.eventstore)
 	feature.RegisterEventMappers(repo.eventstore)
 
 	repo.codeAlg = crypto.NewBCrypt(defaults.SecretGenerators.PasswordSaltCost)
@@ -172,6 +186,8 @@ func StartCommands(
 	if err != nil {
 		return nil, err
 	}
+	repo.breachedPasswordChecker = defaults.BreachedPasswords.Checker(httpClient)
+	repo.checkBreachedPasswordOnLogin = defaults.BreachedPasswords.CheckOnLogin
 	repo.machineKeySize = int(defaults.SecretGenerators.MachineKeySize)
 	repo.applicationKeySize = int(defaults.SecretGenerators.ApplicationKeySize)
 
@@ -180,6 +196,10 @@ func StartCommands(
 			CryptoMFA: otpEncryption,
 			Issuer:    defaults.Multifactors.OTP.Issuer,
 		},
+		RecoveryCodes: domain.RecoveryCodesConfig{
+			Count:  defaults.Multifactors.RecoveryCodes.Count,
+			Length: defaults.Multifactors.RecoveryCodes.Length,
+		},
 	}
 
 	repo.domainVerificationGenerator = crypto.NewEncryptionGenerator(defaults.DomainVerification.VerificationGenerator, repo.domainVerificationAlg)
@@ -0,0 +1,778 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/dop251/goja"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/actions/object"
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/idpintent"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+// ActionQueries are the queries needed to run the actions of the authentication flows
+// for the calls of the session and user API.
+type ActionQueries interface {
+	GetActiveActionsByFlowAndTriggerType(ctx context.Context, flowType domain.FlowType, triggerType domain.TriggerType, orgID string) ([]*query.Action, error)
+	GetUserByID(ctx context.Context, shouldTriggerBulk bool, userID string, queries ...query.SearchQuery) (*query.User, error)
+	// Queries are provided to the actions by the zitadel/query module
+	actions.Queries
+}
+
+type authMethod string
+
+// the auth methods are equal to the ones of the login UI,
+// so the same actions can be used for both
+const (
+	authMethodPassword     authMethod = "password"
+	authMethodOTP          authMethod = "OTP"
+	authMethodOTPSMS       authMethod = "OTP SMS"
+	authMethodOTPEmail     authMethod = "OTP Email"
+	authMethodRecoveryCode authMethod = "recovery code"
+	authMethodU2F          authMethod = "U2F"
+	authMethodPasswordless authMethod = "passwordless"
+	// authMethodIntent is not passed to the actions,
+	// the check of an intent runs the actions of the external authentication flow
+	authMethodIntent authMethod = "intent"
+)
+
+// triggerActions returns the active actions of the flow and trigger,
+// no actions are returned if the commands were started without queries (e.g. during setup)
+func (c *Commands) triggerActions(ctx context.Context, flowType domain.FlowType, triggerType domain.TriggerType, resourceOwner string) ([]*query.Action, error) {
+	if c.actionQueries == nil {
+		return nil, nil
+	}
+	return c.actionQueries.GetActiveActionsByFlowAndTriggerType(ctx, flowType, triggerType, resourceOwner)
+}
+
+func (c *Commands) runPostInternalAuthenticationActions(
+	ctx context.Context,
+	resourceOwner string,
+	authMethod authMethod,
+	authenticationError error,
+) ([]*domain.Metadata, error) {
+	triggerActions, err := c.triggerActions(ctx, domain.FlowTypeInternalAuthentication, domain.TriggerTypePostAuthentication, resourceOwner)
+	if err != nil || len(triggerActions) == 0 {
+		return nil, err
+	}
+
+	metadataList := object.MetadataListFromDomain(nil)
+	apiFields := actions.WithAPIFields(
+		actions.SetFields("metadata", func(c *actions.FieldConfig) interface{} {
+			return metadataList.MetadataListFromDomain(c.Runtime)
+		}),
+		actions.SetFields("v1",
+			actions.SetFields("user",
+				actions.SetFields("appendMetadata", metadataList.AppendMetadataFunc),
+			),
+		),
+	)
+	for _, a := range triggerActions {
+		actionCtx, cancel := context.WithTimeout(ctx, a.Timeout())
+
+		ctxFields := actions.SetContextFields(
+			actions.SetFields("v1",
+				actions.SetFields("authMethod", string(authMethod)),
+				actions.SetFields("authError", authErrorField(authenticationError)),
+				// there's no auth request and http request of the login UI on calls of the API
+				actions.SetFields("authRequest", nil),
+				actions.SetFields("httpRequest", nil),
+			),
+		)
+
+		err = actions.Run(
+			actionCtx,
+			ctxFields,
+			apiFields,
+			a.Script,
+			a.Name,
+			append(
+				actions.ActionToOptions(a),
+				actions.DefaultModules(actionCtx, c.actionQueries, a.ResourceOwner)...,
+			)...,
+		)
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return object.MetadataListToDomain(metadataList), nil
+}
+
+func (c *Commands) runPostExternalAuthenticationActions(
+	ctx context.Context,
+	resourceOwner string,
+	intent *IDPIntentWriteModel,
+	authenticationError error,
+) (_ *domain.ExternalUser, run bool, err error) {
+	externalUser := externalUserFromIntent(intent)
+	triggerActions, err := c.triggerActions(ctx, domain.FlowTypeExternalAuthentication, domain.TriggerTypePostAuthentication, resourceOwner)
+	if err != nil || len(triggerActions) == 0 {
+		return externalUser, false, err
+	}
+	tokenFields, err := c.intentTokenCtxFields(intent)
+	if err != nil {
+		return nil, false, err
+	}
+	providerInfo := make(map[string]interface{})
+	if len(intent.IDPUser) > 0 {
+		if err = json.Unmarshal(intent.IDPUser, &providerInfo); err != nil {
+			return nil, false, err
+		}
+	}
+
+	metadataList := object.MetadataListFromDomain(externalUser.Metadatas)
+	apiFields := actions.WithAPIFields(
+		// the external user isn't created by the intent, so only the user name returned with it can be set
+		actions.SetFields("setPreferredUsername", func(username string) {
+			externalUser.PreferredUsername = username
+		}),
+		actions.SetFields("metadata", func(c *actions.FieldConfig) interface{} {
+			return metadataList.MetadataListFromDomain(c.Runtime)
+		}),
+		actions.SetFields("v1",
+			actions.SetFields("user",
+				actions.SetFields("appendMetadata", metadataList.AppendMetadataFunc),
+			),
+		),
+	)
+
+	for _, a := range triggerActions {
+		actionCtx, cancel := context.WithTimeout(ctx, a.Timeout())
+
+		ctxFieldOptions := append(tokenFields,
+			actions.SetFields("v1",
+				actions.SetFields("externalUser", func(c *actions.FieldConfig) interface{} {
+					return object.UserFromExternalUser(c, externalUser)
+				}),
+				actions.SetFields("providerInfo", func(c *actions.FieldConfig) interface{} {
+					return c.Runtime.ToValue(providerInfo)
+				}),
+				actions.SetFields("authRequest", nil),
+				actions.SetFields("httpRequest", nil),
+				actions.SetFields("authError", authErrorField(authenticationError)),
+			),
+		)
+
+		ctxFields := actions.SetContextFields(ctxFieldOptions...)
+
+		err = actions.Run(
+			actionCtx,
+			ctxFields,
+			apiFields,
+			a.Script,
+			a.Name,
+			append(
+				actions.ActionToOptions(a),
+				actions.DefaultModules(actionCtx, c.actionQueries, a.ResourceOwner)...,
+			)...,
+		)
+		cancel()
+		if err != nil {
+			return nil, false, err
+		}
+	}
+	externalUser.Metadatas = object.MetadataListToDomain(metadataList)
+	return externalUser, true, nil
+}
+
+func (c *Commands) runPreCreationActions(
+	ctx context.Context,
+	resourceOwner string,
+	human *AddHuman,
+	flowType domain.FlowType,
+) error {
+	triggerActions, err := c.triggerActions(ctx, flowType, domain.TriggerTypePreCreation, resourceOwner)
+	if err != nil || len(triggerActions) == 0 {
+		return err
+	}
+
+	metadataList := object.MetadataListFromDomain(addMetadataEntriesToDomain(human.Metadata))
+	apiFields := actions.WithAPIFields(
+		actions.SetFields("setFirstName", func(firstName string) {
+			human.FirstName = firstName
+		}),
+		actions.SetFields("setLastName", func(lastName string) {
+			human.LastName = lastName
+		}),
+		actions.SetFields("setNickName", func(nickName string) {
+			human.NickName = nickName
+		}),
+		actions.SetFields("setDisplayName", func(displayName string) {
+			human.DisplayName = displayName
+		}),
+		actions.SetFields("setPreferredLanguage", func(preferredLanguage string) {
+			human.PreferredLanguage = language.Make(preferredLanguage)
+		}),
+		actions.SetFields("setGender", func(gender domain.Gender) {
+			human.Gender = gender
+		}),
+		actions.SetFields("setUsername", func(username string) {
+			human.Username = username
+		}),
+		actions.SetFields("setEmail", func(email domain.EmailAddress) {
+			human.Email.Address = email
+		}),
+		actions.SetFields("setEmailVerified", func(verified bool) {
+			human.Email.Verified = verified
+		}),
+		actions.SetFields("setPhone", func(phone domain.PhoneNumber) {
+			human.Phone.Number = phone
+		}),
+		actions.SetFields("setPhoneVerified", func(verified bool) {
+			human.Phone.Verified = verified
+		}),
+		actions.SetFields("metadata", func(c *actions.FieldConfig) interface{} {
+			return metadataList.MetadataListFromDomain(c.Runtime)
+		}),
+		actions.SetFields("v1",
+			actions.SetFields("user",
+				actions.SetFields("appendMetadata", metadataList.AppendMetadataFunc),
+			),
+		),
+	)
+
+	for _, a := range triggerActions {
+		actionCtx, cancel := context.WithTimeout(ctx, a.Timeout())
+
+		ctxOpts := actions.SetContextFields(
+			actions.SetFields("v1",
+				actions.SetFields("user", func(c *actions.FieldConfig) interface{} {
+					return object.UserFromHuman(c, addHumanToDomain(human, resourceOwner))
+				}),
+				actions.SetFields("authRequest", nil),
+				actions.SetFields("httpRequest", nil),
+			),
+		)
+
+		err = actions.Run(
+			actionCtx,
+			ctxOpts,
+			apiFields,
+			a.Script,
+			a.Name,
+			append(
+				actions.ActionToOptions(a),
+				actions.DefaultModules(actionCtx, c.actionQueries, a.ResourceOwner)...,
+			)...,
+		)
+		cancel()
+		if err != nil {
+			return err
+		}
+	}
+	human.Metadata = addMetadataEntriesFromDomain(object.MetadataListToDomain(metadataList))
+	return nil
+}
+
+func (c *Commands) runPostCreationActions(
+	ctx context.Context,
+	userID string,
+	resourceOwner string,
+	flowType domain.FlowType,
+) ([]*domain.UserGrant, error) {
+	triggerActions, err := c.triggerActions(ctx, flowType, domain.TriggerTypePostCreation, resourceOwner)
+	if err != nil || len(triggerActions) == 0 {
+		return nil, err
+	}
+
+	queries := c.actionQueries
+	mutableUserGrants := &object.UserGrants{UserGrants: make([]object.UserGrant, 0)}
+
+	apiFields := actions.WithAPIFields(
+		actions.SetFields("userGrants", &mutableUserGrants.UserGrants),
+		actions.SetFields("v1",
+			actions.SetFields("appendUserGrant", object.AppendGrantFunc(mutableUserGrants)),
+		),
+	)
+
+	for _, a := range triggerActions {
+		actionCtx, cancel := context.WithTimeout(ctx, a.Timeout())
+
+		ctxFields := actions.SetContextFields(
+			actions.SetFields("v1",
+				actions.SetFields("getUser", getUserField(actionCtx, queries, userID)),
+				actions.SetFields("authRequest", nil),
+				actions.SetFields("httpRequest", nil),
+			),
+		)
+
+		err = actions.Run(
+			actionCtx,
+			ctxFields,
+			apiFields,
+			a.Script,
+			a.Name,
+			append(
+				actions.ActionToOptions(a),
+				actions.DefaultModules(actionCtx, c.actionQueries, a.ResourceOwner)...,
+			)...,
+		)
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return object.UserGrantsToDomain(userID, mutableUserGrants.UserGrants), nil
+}
+
+// runPreRegistrationValidationActions runs the pre registration validation actions of the flow,
+// any action is able to reject the registration by calling api.v1.reject with an optional (localized) message
+func (c *Commands) runPreRegistrationValidationActions(
+	ctx context.Context,
+	resourceOwner string,
+	human *AddHuman,
+	flowType domain.FlowType,
+) error {
+	triggerActions, err := c.triggerActions(ctx, flowType, domain.TriggerTypePreRegistrationValidation, resourceOwner)
+	if err != nil || len(triggerActions) == 0 {
+		return err
+	}
+
+	rejection := new(object.Rejection)
+	apiFields := actions.WithAPIFields(
+		actions.SetFields("v1",
+			actions.SetFields("reject", rejection.RejectFunc),
+		),
+	)
+
+	for _, a := range triggerActions {
+		actionCtx, cancel := context.WithTimeout(ctx, a.Timeout())
+
+		ctxFields := actions.SetContextFields(
+			actions.SetFields("v1",
+				actions.SetFields("user", func(c *actions.FieldConfig) interface{} {
+					return object.UserFromHuman(c, addHumanToDomain(human, resourceOwner))
+				}),
+				actions.SetFields("authRequest", nil),
+				actions.SetFields("httpRequest", nil),
+			),
+		)
+
+		err = actions.Run(
+			actionCtx,
+			ctxFields,
+			apiFields,
+			a.Script,
+			a.Name,
+			append(
+				actions.ActionToOptions(a),
+				actions.DefaultModules(actionCtx, c.actionQueries, a.ResourceOwner)...,
+			)...,
+		)
+		cancel()
+		if err != nil {
+			return err
+		}
+		if rejection.Rejected() {
+			break
+		}
+	}
+	return rejection.Err(human.PreferredLanguage, authz.GetInstance(ctx).DefaultLanguage())
+}
+
+// runPrePasswordResetActions runs the pre password reset actions before a password code is created,
+// any action is able to reject the reset by calling api.v1.reject with an optional (localized) message
+func (c *Commands) runPrePasswordResetActions(
+	ctx context.Context,
+	userID string,
+	resourceOwner string,
+	notificationType domain.NotificationType,
+	returnCode bool,
+) error {
+	triggerActions, err := c.triggerActions(ctx, domain.FlowTypePasswordReset, domain.TriggerTypePrePasswordReset, resourceOwner)
+	if err != nil || len(triggerActions) == 0 {
+		return err
+	}
+
+	queries := c.actionQueries
+	rejection := new(object.Rejection)
+	apiFields := actions.WithAPIFields(
+		actions.SetFields("v1",
+			actions.SetFields("reject", rejection.RejectFunc),
+		),
+	)
+
+	for _, a := range triggerActions {
+		actionCtx, cancel := context.WithTimeout(ctx, a.Timeout())
+
+		ctxFields := actions.SetContextFields(
+			actions.SetFields("v1",
+				actions.SetFields("getUser", getUserField(actionCtx, queries, userID)),
+				actions.SetFields("passwordReset", object.PasswordResetField(notificationType, returnCode)),
+				actions.SetFields("authRequest", nil),
+				actions.SetFields("httpRequest", nil),
+			),
+		)
+
+		err = actions.Run(
+			actionCtx,
+			ctxFields,
+			apiFields,
+			a.Script,
+			a.Name,
+			append(
+				actions.ActionToOptions(a),
+				actions.DefaultModules(actionCtx, c.actionQueries, a.ResourceOwner)...,
+			)...,
+		)
+		cancel()
+		if err != nil {
+			return err
+		}
+		if rejection.Rejected() {
+			break
+		}
+	}
+	return rejection.Err(authz.GetInstance(ctx).DefaultLanguage())
+}
+
+// runPostPasswordResetActions runs the post password reset actions after the password was set with a code.
+// All actions are run, the errors of the failed ones are returned to be logged, as the password is already set.
+func (c *Commands) runPostPasswordResetActions(
+	ctx context.Context,
+	userID string,
+	resourceOwner string,
+) error {
+	triggerActions, err := c.triggerActions(ctx, domain.FlowTypePasswordReset, domain.TriggerTypePostPasswordReset, resourceOwner)
+	if err != nil || len(triggerActions) == 0 {
+		return err
+	}
+
+	queries := c.actionQueries
+	var errs []error
+	for _, a := range triggerActions {
+		actionCtx, cancel := context.WithTimeout(ctx, a.Timeout())
+
+		ctxFields := actions.SetContextFields(
+			actions.SetFields("v1",
+				actions.SetFields("getUser", getUserField(actionCtx, queries, userID)),
+				actions.SetFields("authRequest", nil),
+				actions.SetFields("httpRequest", nil),
+			),
+		)
+
+		err := actions.Run(
+			actionCtx,
+			ctxFields,
+			actions.WithAPIFields(),
+			a.Script,
+			a.Name,
+			append(
+				actions.ActionToOptions(a),
+				actions.DefaultModules(actionCtx, c.actionQueries, a.ResourceOwner)...,
+			)...,
+		)
+		cancel()
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// runPostLogoutActions runs the post logout actions of the organization of the user
+// after its session was terminated, either by the login UI (userAgentID) or the session API (sessionID).
+// All actions are run, the errors of the failed ones are returned to be logged, as the session is already terminated.
+func (c *Commands) runPostLogoutActions(
+	ctx context.Context,
+	userID string,
+	resourceOwner string,
+	userAgentID string,
+	sessionID string,
+	userIDs []string,
+) error {
+	triggerActions, err := c.triggerActions(ctx, domain.FlowTypeLogout, domain.TriggerTypePostLogout, resourceOwner)
+	if err != nil || len(triggerActions) == 0 {
+		return err
+	}
+
+	queries := c.actionQueries
+	var errs []error
+	for _, a := range triggerActions {
+		actionCtx, cancel := context.WithTimeout(ctx, a.Timeout())
+
+		ctxFields := actions.SetContextFields(
+			actions.SetFields("v1",
+				actions.SetFields("getUser", getUserField(actionCtx, queries, userID)),
+				actions.SetFields("logout", object.LogoutField(userAgentID, sessionID, userIDs)),
+				actions.SetFields("authRequest", nil),
+				actions.SetFields("httpRequest", nil),
+			),
+		)
+
+		err := actions.Run(
+			actionCtx,
+			ctxFields,
+			actions.WithAPIFields(),
+			a.Script,
+			a.Name,
+			append(
+				actions.ActionToOptions(a),
+				actions.DefaultModules(actionCtx, c.actionQueries, a.ResourceOwner)...,
+			)...,
+		)
+		cancel()
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// AddHumanWithActions adds the human like [Commands.AddHuman]
+// and runs the pre creation, pre registration validation and post creation actions of the internal authentication flow,
+// respectively of the external authentication flow if the user is linked to an identity provider.
+func (c *Commands) AddHumanWithActions(ctx context.Context, resourceOwner string, human *AddHuman, allowInitMail bool) (err error) {
+	flowType := domain.FlowTypeInternalAuthentication
+	if len(human.Links) > 0 {
+		flowType = domain.FlowTypeExternalAuthentication
+	}
+	if err = c.runPreCreationActions(ctx, resourceOwner, human, flowType); err != nil {
+		return err
+	}
+	if err = c.runPreRegistrationValidationActions(ctx, resourceOwner, human, flowType); err != nil {
+		return err
+	}
+	if err = c.AddHuman(ctx, resourceOwner, human, allowInitMail); err != nil {
+		return err
+	}
+	userGrants, err := c.runPostCreationActions(ctx, human.ID, resourceOwner, flowType)
+	if err != nil {
+		return err
+	}
+	for _, grant := range userGrants {
+		if _, err = c.AddUserGrant(ctx, grant, resourceOwner); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RunIntentPostAuthenticationActions runs the post authentication actions of the external authentication flow
+// for the retrieval of a succeeded intent, if they didn't run for the intent yet.
+// The preferred username set by the actions is returned as user name of the intent,
+// the metadata is set on the user when the intent is checked by a session.
+func (c *Commands) RunIntentPostAuthenticationActions(ctx context.Context, intent *IDPIntentWriteModel) (err error) {
+	if c.actionQueries == nil || intent.ActionsRun {
+		return nil
+	}
+	resourceOwner := authz.GetInstance(ctx).DefaultOrganisationID()
+	if intent.UserID != "" {
+		userWriteModel, err := c.userWriteModelByID(ctx, intent.UserID, "")
+		if err != nil {
+			return err
+		}
+		resourceOwner = userWriteModel.ResourceOwner
+	}
+	cmd, err := c.runIntentActions(ctx, resourceOwner, intent)
+	if err != nil || cmd == nil {
+		return err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	return AppendAndReduce(intent, pushedEvents...)
+}
+
+// runIntentActions runs the post authentication actions of the intent.
+// The returned event stores their result, so they are run once per intent.
+func (c *Commands) runIntentActions(ctx context.Context, resourceOwner string, intent *IDPIntentWriteModel) (*idpintent.ActionsRunEvent, error) {
+	externalUser, run, err := c.runPostExternalAuthenticationActions(ctx, resourceOwner, intent, nil)
+	if err != nil || !run {
+		return nil, err
+	}
+	metadata := make([]*idpintent.ActionMetadata, len(externalUser.Metadatas))
+	for i, md := range externalUser.Metadatas {
+		metadata[i] = &idpintent.ActionMetadata{Key: md.Key, Value: md.Value}
+	}
+	return idpintent.NewActionsRunEvent(
+		ctx,
+		&idpintent.NewAggregate(intent.AggregateID, intent.ResourceOwner).Aggregate,
+		externalUser.PreferredUsername,
+		metadata,
+	), nil
+}
+
+// runSessionActions runs the post authentication actions for the checks of the session update.
+// If a check failed, only the actions of its auth method are run with the error.
+// The returned metadata set events of the session user are pushed with the session update.
+func (c *Commands) runSessionActions(ctx context.Context, checks *SessionCommands, checkErr error) ([]eventstore.Command, error) {
+	if c.actionQueries == nil || checks.sessionWriteModel.UserID == "" {
+		return nil, nil
+	}
+	resourceOwner := checks.sessionWriteModel.UserResourceOwner
+	var (
+		metadata   []*domain.Metadata
+		intentCmds []eventstore.Command
+	)
+	for _, method := range checks.authMethods {
+		if method == authMethodIntent {
+			intentCmd, md, err := c.runSessionIntentActions(ctx, resourceOwner, checks.intentWriteModel, checkErr)
+			if err != nil {
+				return nil, err
+			}
+			if intentCmd != nil {
+				intentCmds = append(intentCmds, intentCmd)
+			}
+			metadata = append(metadata, md...)
+			continue
+		}
+		md, err := c.runPostInternalAuthenticationActions(ctx, resourceOwner, method, checkErr)
+		if err != nil {
+			return nil, err
+		}
+		metadata = append(metadata, md...)
+	}
+	if checkErr != nil {
+		return nil, nil
+	}
+	userAgg := &user.NewAggregate(checks.sessionWriteModel.UserID, resourceOwner).Aggregate
+	cmds := make([]eventstore.Command, 0, len(intentCmds)+len(metadata))
+	cmds = append(cmds, intentCmds...)
+	for _, md := range metadata {
+		cmd, err := c.setUserMetadata(ctx, userAgg, md)
+		if err != nil {
+			return nil, err
+		}
+		cmds = append(cmds, cmd)
+	}
+	if len(cmds) == 0 {
+		return nil, nil
+	}
+	return cmds, nil
+}
+
+// runSessionIntentActions runs the post authentication actions of the checked intent, if they didn't run for it yet,
+// otherwise the metadata of the previous run is returned.
+// The returned event of a succeeded check stores the result on the intent.
+func (c *Commands) runSessionIntentActions(ctx context.Context, resourceOwner string, intent *IDPIntentWriteModel, checkErr error) (eventstore.Command, []*domain.Metadata, error) {
+	if intent == nil {
+		return nil, nil, nil
+	}
+	if intent.ActionsRun {
+		return nil, intent.ActionsMetadata, nil
+	}
+	if checkErr != nil {
+		_, _, err := c.runPostExternalAuthenticationActions(ctx, resourceOwner, intent, checkErr)
+		return nil, nil, err
+	}
+	cmd, err := c.runIntentActions(ctx, resourceOwner, intent)
+	if err != nil || cmd == nil {
+		return nil, nil, err
+	}
+	metadata := make([]*domain.Metadata, len(cmd.Metadata))
+	for i, md := range cmd.Metadata {
+		metadata[i] = &domain.Metadata{Key: md.Key, Value: md.Value}
+	}
+	return cmd, metadata, nil
+}
+
+func (c *Commands) intentTokenCtxFields(intent *IDPIntentWriteModel) ([]actions.FieldOption, error) {
+	var accessToken string
+	if intent.IDPAccessToken != nil {
+		var err error
+		accessToken, err = crypto.DecryptString(intent.IDPAccessToken, c.idpConfigEncryption)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// the claims of the id token are only provided to the actions of the login UI
+	return []actions.FieldOption{
+		actions.SetFields("accessToken", accessToken),
+		actions.SetFields("idToken", intent.IDPIDToken),
+		actions.SetFields("getClaim", func(claim string) interface{} {
+			return nil
+		}),
+		actions.SetFields("claimsJSON", func() (string, error) {
+			return "", nil
+		}),
+	}, nil
+}
+
+// getUserField provides v1.getUser, the user is queried when the action calls it
+func getUserField(ctx context.Context, queries ActionQueries, userID string) func(c *actions.FieldConfig) interface{} {
+	return func(c *actions.FieldConfig) interface{} {
+		return func(call goja.FunctionCall) goja.Value {
+			user, err := queries.GetUserByID(ctx, true, userID)
+			if err != nil {
+				panic(err)
+			}
+			return object.UserFromQuery(c, user)
+		}
+	}
+}
+
+func authErrorField(err error) string {
+	if err == nil {
+		return "none"
+	}
+	return err.Error()
+}
+
+func externalUserFromIntent(intent *IDPIntentWriteModel) *domain.ExternalUser {
+	return &domain.ExternalUser{
+		IDPConfigID:       intent.IDPID,
+		ExternalUserID:    intent.IDPUserID,
+		PreferredUsername: intent.IDPUserName,
+	}
+}
+
+func addHumanToDomain(human *AddHuman, resourceOwner string) *domain.Human {
+	return &domain.Human{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID:   human.ID,
+			ResourceOwner: resourceOwner,
+		},
+		Username: human.Username,
+		Profile: &domain.Profile{
+			FirstName:         human.FirstName,
+			LastName:          human.LastName,
+			NickName:          human.NickName,
+			DisplayName:       human.DisplayName,
+			PreferredLanguage: human.PreferredLanguage,
+			Gender:            human.Gender,
+		},
+		Email: &domain.Email{
+			EmailAddress:    human.Email.Address,
+			IsEmailVerified: human.Email.Verified,
+		},
+		Phone: &domain.Phone{
+			PhoneNumber:     human.Phone.Number,
+			IsPhoneVerified: human.Phone.Verified,
+		},
+	}
+}
+
+func addMetadataEntriesToDomain(entries []*AddMetadataEntry) []*domain.Metadata {
+	metadata := make([]*domain.Metadata, len(entries))
+	for i, entry := range entries {
+		metadata[i] = &domain.Metadata{
+			Key:   entry.Key,
+			Value: entry.Value,
+		}
+	}
+	return metadata
+}
+
+func addMetadataEntriesFromDomain(metadata []*domain.Metadata) []*AddMetadataEntry {
+	entries := make([]*AddMetadataEntry, len(metadata))
+	for i, md := range metadata {
+		entries[i] = &AddMetadataEntry{
+			Key:   md.Key,
+			Value: md.Value,
+		}
+	}
+	return entries
+}
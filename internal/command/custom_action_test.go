@@ -0,0 +1,334 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/logstore"
+	"github.com/zitadel/zitadel/internal/logstore/record"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/idpintent"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+type mockActionQueries struct {
+	actions map[domain.TriggerType][]*query.Action
+}
+
+func (m *mockActionQueries) GetActiveActionsByFlowAndTriggerType(_ context.Context, _ domain.FlowType, triggerType domain.TriggerType, _ string) ([]*query.Action, error) {
+	return m.actions[triggerType], nil
+}
+
+func (m *mockActionQueries) GetUserByID(context.Context, bool, string, ...query.SearchQuery) (*query.User, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockActionQueries) SearchUserMetadata(context.Context, bool, string, *query.UserMetadataSearchQueries, bool) (*query.UserMetadataList, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockActionQueries) SearchOrgMetadata(context.Context, bool, string, *query.OrgMetadataSearchQueries, bool) (*query.OrgMetadataList, error) {
+	return nil, errors.New("not implemented")
+}
+
+func actionQueriesExpect(triggerType domain.TriggerType, script string) *mockActionQueries {
+	return &mockActionQueries{
+		actions: map[domain.TriggerType][]*query.Action{
+			triggerType: {{Name: "action", Script: script}},
+		},
+	}
+}
+
+func TestCommands_runPostInternalAuthenticationActions(t *testing.T) {
+	actions.SetLogstoreService(logstore.New[*record.ExecutionLog](nil, nil))
+	script := `function action(ctx, api) {
+	api.v1.user.appendMetadata("method", ctx.v1.authMethod);
+	api.v1.user.appendMetadata("error", ctx.v1.authError);
+	api.v1.user.appendMetadata("request", ctx.v1.authRequest);
+}`
+	tests := []struct {
+		name          string
+		actionQueries ActionQueries
+		authErr       error
+		want          []*domain.Metadata
+	}{
+		{
+			name: "no queries",
+		},
+		{
+			name:          "succeeded",
+			actionQueries: actionQueriesExpect(domain.TriggerTypePostAuthentication, script),
+			want: []*domain.Metadata{
+				{Key: "method", Value: []byte(`"password"`)},
+				{Key: "error", Value: []byte(`"none"`)},
+				{Key: "request", Value: []byte(`null`)},
+			},
+		},
+		{
+			name:          "failed",
+			actionQueries: actionQueriesExpect(domain.TriggerTypePostAuthentication, script),
+			authErr:       errors.New("wrong password"),
+			want: []*domain.Metadata{
+				{Key: "method", Value: []byte(`"password"`)},
+				{Key: "error", Value: []byte(`"wrong password"`)},
+				{Key: "request", Value: []byte(`null`)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				actionQueries: tt.actionQueries,
+			}
+			got, err := c.runPostInternalAuthenticationActions(context.Background(), "org1", authMethodPassword, tt.authErr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCommands_runPreCreationActions(t *testing.T) {
+	actions.SetLogstoreService(logstore.New[*record.ExecutionLog](nil, nil))
+	c := &Commands{
+		actionQueries: actionQueriesExpect(domain.TriggerTypePreCreation, `function action(ctx, api) {
+	api.setFirstName(ctx.v1.user.human.firstName + "!");
+	api.setEmailVerified(true);
+	api.v1.user.appendMetadata("key", "value");
+}`),
+	}
+	human := &AddHuman{
+		Username:  "username",
+		FirstName: "firstname",
+		Email:     Email{Address: "email@test.ch"},
+		Metadata:  []*AddMetadataEntry{{Key: "existing", Value: []byte("value")}},
+	}
+	err := c.runPreCreationActions(context.Background(), "org1", human, domain.FlowTypeInternalAuthentication)
+	require.NoError(t, err)
+	assert.Equal(t, "firstname!", human.FirstName)
+	assert.True(t, human.Email.Verified)
+	assert.Equal(t, []*AddMetadataEntry{
+		{Key: "existing", Value: []byte("value")},
+		{Key: "key", Value: []byte(`"value"`)},
+	}, human.Metadata)
+}
+
+func TestCommands_runSessionActions(t *testing.T) {
+	actions.SetLogstoreService(logstore.New[*record.ExecutionLog](nil, nil))
+	userAgg := &user.NewAggregate("user1", "org1").Aggregate
+	script := `function action(ctx, api) {
+	api.v1.user.appendMetadata(ctx.v1.authMethod, ctx.v1.authError);
+}`
+	tests := []struct {
+		name        string
+		authMethods []authMethod
+		checkErr    error
+		want        []eventstore.Command
+	}{
+		{
+			name:        "failed check",
+			authMethods: []authMethod{authMethodOTP},
+			checkErr:    errors.New("invalid code"),
+		},
+		{
+			name:        "succeeded checks",
+			authMethods: []authMethod{authMethodPassword, authMethodOTP},
+			want: []eventstore.Command{
+				user.NewMetadataSetEvent(context.Background(), userAgg, "password", []byte(`"none"`)),
+				user.NewMetadataSetEvent(context.Background(), userAgg, "OTP", []byte(`"none"`)),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				actionQueries: actionQueriesExpect(domain.TriggerTypePostAuthentication, script),
+			}
+			checks := &SessionCommands{
+				sessionWriteModel: &SessionWriteModel{UserID: "user1", UserResourceOwner: "org1"},
+				authMethods:       tt.authMethods,
+			}
+			got, err := c.runSessionActions(context.Background(), checks, tt.checkErr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCommands_runSessionActions_intent(t *testing.T) {
+	actions.SetLogstoreService(logstore.New[*record.ExecutionLog](nil, nil))
+	userAgg := &user.NewAggregate("user1", "org1").Aggregate
+	intentAgg := &idpintent.NewAggregate("intent1", "instance1").Aggregate
+	script := `function action(ctx, api) {
+	api.setPreferredUsername("changed");
+	api.v1.user.appendMetadata("error", ctx.v1.authError);
+}`
+	tests := []struct {
+		name     string
+		intent   *IDPIntentWriteModel
+		checkErr error
+		want     []eventstore.Command
+	}{
+		{
+			name:   "first run",
+			intent: &IDPIntentWriteModel{WriteModel: eventstore.WriteModel{AggregateID: "intent1", ResourceOwner: "instance1"}, IDPUserName: "username"},
+			want: []eventstore.Command{
+				idpintent.NewActionsRunEvent(context.Background(), intentAgg, "changed", []*idpintent.ActionMetadata{{Key: "error", Value: []byte(`"none"`)}}),
+				user.NewMetadataSetEvent(context.Background(), userAgg, "error", []byte(`"none"`)),
+			},
+		},
+		{
+			name: "already run",
+			intent: &IDPIntentWriteModel{
+				WriteModel:      eventstore.WriteModel{AggregateID: "intent1", ResourceOwner: "instance1"},
+				IDPUserName:     "changed",
+				ActionsRun:      true,
+				ActionsMetadata: []*domain.Metadata{{Key: "previous", Value: []byte(`"run"`)}},
+			},
+			want: []eventstore.Command{
+				user.NewMetadataSetEvent(context.Background(), userAgg, "previous", []byte(`"run"`)),
+			},
+		},
+		{
+			name:     "failed check",
+			intent:   &IDPIntentWriteModel{WriteModel: eventstore.WriteModel{AggregateID: "intent1", ResourceOwner: "instance1"}},
+			checkErr: errors.New("intent invalid"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				actionQueries: actionQueriesExpect(domain.TriggerTypePostAuthentication, script),
+			}
+			checks := &SessionCommands{
+				sessionWriteModel: &SessionWriteModel{UserID: "user1", UserResourceOwner: "org1"},
+				intentWriteModel:  tt.intent,
+				authMethods:       []authMethod{authMethodIntent},
+			}
+			got, err := c.runSessionActions(context.Background(), checks, tt.checkErr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCommands_runPreRegistrationValidationActions(t *testing.T) {
+	actions.SetLogstoreService(logstore.New[*record.ExecutionLog](nil, nil))
+	tests := []struct {
+		name    string
+		script  string
+		wantErr error
+	}{
+		{
+			name: "not rejected",
+			script: `function action(ctx, api) {
+	if (ctx.v1.user.human.email == "blocked@test.ch") {
+		api.v1.reject();
+	}
+}`,
+		},
+		{
+			name: "rejected without message",
+			script: `function action(ctx, api) {
+	api.v1.reject();
+}`,
+			wantErr: caos_errs.ThrowPreconditionFailed(nil, "ACTIO-Sf3g2", "Errors.Action.Rejected"),
+		},
+		{
+			name: "rejected with message",
+			script: `function action(ctx, api) {
+	api.v1.reject("registration of " + ctx.v1.user.username + " is not allowed");
+}`,
+			wantErr: caos_errs.ThrowPreconditionFailed(nil, "ACTIO-Sf3g2", "registration of username is not allowed"),
+		},
+		{
+			name: "rejected with localized message",
+			script: `function action(ctx, api) {
+	api.v1.reject({en: "not allowed", de: "nicht erlaubt"});
+}`,
+			wantErr: caos_errs.ThrowPreconditionFailed(nil, "ACTIO-Sf3g2", "nicht erlaubt"),
+		},
+		{
+			name: "rejected with message of other language",
+			script: `function action(ctx, api) {
+	api.v1.reject({fr: "non autorisé", en: "not allowed"});
+}`,
+			wantErr: caos_errs.ThrowPreconditionFailed(nil, "ACTIO-Sf3g2", "not allowed"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				actionQueries: actionQueriesExpect(domain.TriggerTypePreRegistrationValidation, tt.script),
+			}
+			human := &AddHuman{
+				Username:          "username",
+				FirstName:         "firstname",
+				Email:             Email{Address: "email@test.ch"},
+				PreferredLanguage: language.German,
+			}
+			err := c.runPreRegistrationValidationActions(context.Background(), "org1", human, domain.FlowTypeInternalAuthentication)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestCommands_runPrePasswordResetActions(t *testing.T) {
+	actions.SetLogstoreService(logstore.New[*record.ExecutionLog](nil, nil))
+	script := `function action(ctx, api) {
+	if (ctx.v1.passwordReset.notificationType != "email") {
+		api.v1.reject("reset over " + ctx.v1.passwordReset.notificationType + " is not allowed");
+	}
+}`
+	tests := []struct {
+		name             string
+		notificationType domain.NotificationType
+		returnCode       bool
+		wantErr          error
+	}{
+		{
+			name:             "email",
+			notificationType: domain.NotificationTypeEmail,
+		},
+		{
+			name:             "sms",
+			notificationType: domain.NotificationTypeSms,
+			wantErr:          caos_errs.ThrowPreconditionFailed(nil, "ACTIO-Sf3g2", "reset over sms is not allowed"),
+		},
+		{
+			name:       "return code",
+			returnCode: true,
+			wantErr:    caos_errs.ThrowPreconditionFailed(nil, "ACTIO-Sf3g2", "reset over none is not allowed"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				actionQueries: actionQueriesExpect(domain.TriggerTypePrePasswordReset, script),
+			}
+			err := c.runPrePasswordResetActions(context.Background(), "user1", "org1", tt.notificationType, tt.returnCode)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestCommands_runPostLogoutActions(t *testing.T) {
+	actions.SetLogstoreService(logstore.New[*record.ExecutionLog](nil, nil))
+	c := &Commands{
+		actionQueries: actionQueriesExpect(domain.TriggerTypePostLogout, `function action(ctx, api) {
+	if (ctx.v1.logout.sessionId != "session1" || ctx.v1.logout.userIds[0] != "user1") {
+		throw "unexpected logout";
+	}
+}`),
+	}
+	err := c.runPostLogoutActions(context.Background(), "user1", "org1", "", "session1", []string{"user1"})
+	require.NoError(t, err)
+}
@@ -0,0 +1,145 @@
+package command
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/eventsink"
+)
+
+type EventSink struct {
+	// ID is generated if empty, the existing sink is replaced otherwise
+	ID       string
+	Name     string
+	SinkType domain.EventSinkType
+	Endpoint string
+	// Target is the NATS subject or the Kafka topic
+	Target         string
+	AggregateTypes []eventstore.AggregateType
+	EventTypes     []eventstore.EventType
+	// SigningKey is required for new HTTP sinks, the existing key is kept if it's empty
+	SigningKey string
+}
+
+func (s *EventSink) IsValid() error {
+	if s.Name == "" {
+		return errors.ThrowInvalidArgument(nil, "COMMAND-Ohph5", "Errors.EventSink.Invalid.Name")
+	}
+	if !s.SinkType.Valid() {
+		return errors.ThrowInvalidArgument(nil, "COMMAND-eiL3a", "Errors.EventSink.Invalid.Type")
+	}
+	endpoint, err := url.Parse(s.Endpoint)
+	if err != nil || endpoint.Host == "" {
+		return errors.ThrowInvalidArgument(err, "COMMAND-Quo1e", "Errors.EventSink.Invalid.Endpoint")
+	}
+	switch s.SinkType {
+	case domain.EventSinkTypeHTTP:
+		if endpoint.Scheme != "http" && endpoint.Scheme != "https" {
+			return errors.ThrowInvalidArgument(nil, "COMMAND-eeX8u", "Errors.EventSink.Invalid.Endpoint")
+		}
+	case domain.EventSinkTypeKafka:
+		if endpoint.Scheme != "http" && endpoint.Scheme != "https" {
+			return errors.ThrowInvalidArgument(nil, "COMMAND-ieH7a", "Errors.EventSink.Invalid.Endpoint")
+		}
+		if !domain.ValidKafkaTopic(s.Target) {
+			return errors.ThrowInvalidArgument(nil, "COMMAND-Aiw5o", "Errors.EventSink.Invalid.Target")
+		}
+	case domain.EventSinkTypeNATS:
+		// the tls scheme requires a TLS connection even if the server doesn't announce it
+		if endpoint.Scheme != "nats" && endpoint.Scheme != "tls" {
+			return errors.ThrowInvalidArgument(nil, "COMMAND-ahg4E", "Errors.EventSink.Invalid.Endpoint")
+		}
+		// the subject is written to the NATS protocol as is
+		if !domain.ValidNATSSubject(s.Target) {
+			return errors.ThrowInvalidArgument(nil, "COMMAND-Gei3o", "Errors.EventSink.Invalid.Target")
+		}
+	}
+	return nil
+}
+
+// SetEventSink creates a new sink of the instance if no ID is provided or replaces the existing sink
+func (c *Commands) SetEventSink(ctx context.Context, sink *EventSink) (_ *domain.ObjectDetails, err error) {
+	if err = sink.IsValid(); err != nil {
+		return nil, err
+	}
+	exists := sink.ID != ""
+	if !exists {
+		sink.ID, err = c.idGenerator.Next()
+		if err != nil {
+			return nil, err
+		}
+	}
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	wm, err := c.getEventSinkWriteModel(ctx, instanceID, sink.ID)
+	if err != nil {
+		return nil, err
+	}
+	if exists && !wm.State.Exists() {
+		return nil, errors.ThrowNotFound(nil, "COMMAND-ohNg3", "Errors.EventSink.NotFound")
+	}
+	signingKey := wm.SigningKey
+	if sink.SigningKey != "" {
+		signingKey, err = crypto.Encrypt([]byte(sink.SigningKey), c.keyAlgorithm)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if sink.SinkType != domain.EventSinkTypeHTTP {
+		signingKey = nil
+	}
+	if sink.SinkType == domain.EventSinkTypeHTTP && signingKey == nil {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-ooP4o", "Errors.EventSink.Invalid.SigningKey")
+	}
+	aggregate := eventsink.NewAggregate(sink.ID, instanceID)
+	pushedEvents, err := c.eventstore.Push(ctx, eventsink.NewSetEvent(
+		ctx,
+		&aggregate.Aggregate,
+		sink.Name,
+		sink.SinkType,
+		sink.Endpoint,
+		sink.Target,
+		sink.AggregateTypes,
+		sink.EventTypes,
+		signingKey,
+	))
+	if err != nil {
+		return nil, err
+	}
+	if err = AppendAndReduce(wm, pushedEvents...); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&wm.WriteModel), nil
+}
+
+func (c *Commands) RemoveEventSink(ctx context.Context, sinkID string) (*domain.ObjectDetails, error) {
+	if sinkID == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Ieth1", "Errors.IDMissing")
+	}
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	wm, err := c.getEventSinkWriteModel(ctx, instanceID, sinkID)
+	if err != nil {
+		return nil, err
+	}
+	if !wm.State.Exists() {
+		return nil, errors.ThrowNotFound(nil, "COMMAND-Ahv7i", "Errors.EventSink.NotFound")
+	}
+	aggregate := eventsink.NewAggregate(sinkID, instanceID)
+	pushedEvents, err := c.eventstore.Push(ctx, eventsink.NewRemovedEvent(ctx, &aggregate.Aggregate))
+	if err != nil {
+		return nil, err
+	}
+	if err = AppendAndReduce(wm, pushedEvents...); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&wm.WriteModel), nil
+}
+
+func (c *Commands) getEventSinkWriteModel(ctx context.Context, instanceID, sinkID string) (*eventSinkWriteModel, error) {
+	wm := newEventSinkWriteModel(instanceID, sinkID)
+	return wm, c.eventstore.FilterToQueryReducer(ctx, wm)
+}
@@ -0,0 +1,65 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/eventsink"
+)
+
+type eventSinkWriteModel struct {
+	eventstore.WriteModel
+
+	Name           string
+	SinkType       domain.EventSinkType
+	Endpoint       string
+	Target         string
+	AggregateTypes []eventstore.AggregateType
+	EventTypes     []eventstore.EventType
+	SigningKey     *crypto.CryptoValue
+	State          domain.EventSinkState
+}
+
+func newEventSinkWriteModel(instanceID, sinkID string) *eventSinkWriteModel {
+	return &eventSinkWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   sinkID,
+			InstanceID:    instanceID,
+			ResourceOwner: instanceID,
+		},
+	}
+}
+
+func (wm *eventSinkWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		InstanceID(wm.InstanceID).
+		AddQuery().
+		AggregateTypes(eventsink.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			eventsink.SetEventType,
+			eventsink.RemovedEventType,
+		).
+		Builder()
+}
+
+func (wm *eventSinkWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *eventsink.SetEvent:
+			wm.Name = e.Name
+			wm.SinkType = e.SinkType
+			wm.Endpoint = e.Endpoint
+			wm.Target = e.Target
+			wm.AggregateTypes = e.AggregateTypes
+			wm.EventTypes = e.EventTypes
+			wm.SigningKey = e.SigningKey
+			wm.State = domain.EventSinkStateActive
+		case *eventsink.RemovedEvent:
+			wm.SigningKey = nil
+			wm.State = domain.EventSinkStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
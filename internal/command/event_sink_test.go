@@ -0,0 +1,415 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errors "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/eventsink"
+)
+
+func TestCommands_SetEventSink(t *testing.T) {
+	signingKey := &crypto.CryptoValue{
+		CryptoType: crypto.TypeEncryption,
+		Algorithm:  "enc",
+		KeyID:      "id",
+		Crypted:    []byte("key"),
+	}
+	type fields struct {
+		eventstore  func(*testing.T) *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		ctx  context.Context
+		sink *EventSink
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "invalid name, error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx:  authz.WithInstanceID(context.Background(), "instance1"),
+				sink: &EventSink{SinkType: domain.EventSinkTypeHTTP, Endpoint: "https://example.com"},
+			},
+			res: res{
+				err: caos_errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "invalid nats endpoint, error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				sink: &EventSink{
+					Name:     "nats",
+					SinkType: domain.EventSinkTypeNATS,
+					Endpoint: "https://example.com",
+					Target:   "zitadel.events",
+				},
+			},
+			res: res{
+				err: caos_errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "invalid nats subject, error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				sink: &EventSink{
+					Name:     "nats",
+					SinkType: domain.EventSinkTypeNATS,
+					Endpoint: "nats://localhost:4222",
+					Target:   "zitadel.events 0\r\nPUB other",
+				},
+			},
+			res: res{
+				err: caos_errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "invalid kafka topic, error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				sink: &EventSink{
+					Name:     "kafka",
+					SinkType: domain.EventSinkTypeKafka,
+					Endpoint: "https://kafka-rest.example.com",
+					Target:   "zitadel/events",
+				},
+			},
+			res: res{
+				err: caos_errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "missing kafka topic, error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				sink: &EventSink{
+					Name:     "kafka",
+					SinkType: domain.EventSinkTypeKafka,
+					Endpoint: "https://kafka-rest.example.com",
+				},
+			},
+			res: res{
+				err: caos_errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "missing signing key, error",
+			fields: fields{
+				eventstore:  expectEventstore(expectFilter()),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "sink1"),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				sink: &EventSink{
+					Name:     "webhook",
+					SinkType: domain.EventSinkTypeHTTP,
+					Endpoint: "https://example.com/events",
+				},
+			},
+			res: res{
+				err: caos_errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "not existing, not found error",
+			fields: fields{
+				eventstore: expectEventstore(expectFilter()),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				sink: &EventSink{
+					ID:       "sink1",
+					Name:     "nats",
+					SinkType: domain.EventSinkTypeNATS,
+					Endpoint: "nats://localhost:4222",
+					Target:   "zitadel.events",
+				},
+			},
+			res: res{
+				err: caos_errors.IsNotFound,
+			},
+		},
+		{
+			name: "add http sink, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectPush(
+						eventsink.NewSetEvent(context.Background(),
+							&eventsink.NewAggregate("sink1", "instance1").Aggregate,
+							"webhook",
+							domain.EventSinkTypeHTTP,
+							"https://example.com/events",
+							"",
+							[]eventstore.AggregateType{"user"},
+							[]eventstore.EventType{"user.human.added"},
+							signingKey,
+						),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "sink1"),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				sink: &EventSink{
+					Name:           "webhook",
+					SinkType:       domain.EventSinkTypeHTTP,
+					Endpoint:       "https://example.com/events",
+					AggregateTypes: []eventstore.AggregateType{"user"},
+					EventTypes:     []eventstore.EventType{"user.human.added"},
+					SigningKey:     "key",
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "instance1",
+				},
+			},
+		},
+		{
+			name: "change http sink, signing key kept, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							eventsink.NewSetEvent(context.Background(),
+								&eventsink.NewAggregate("sink1", "instance1").Aggregate,
+								"webhook",
+								domain.EventSinkTypeHTTP,
+								"https://example.com/events",
+								"",
+								nil,
+								nil,
+								signingKey,
+							),
+						),
+					),
+					expectPush(
+						eventsink.NewSetEvent(context.Background(),
+							&eventsink.NewAggregate("sink1", "instance1").Aggregate,
+							"webhook",
+							domain.EventSinkTypeHTTP,
+							"https://example.com/v2/events",
+							"",
+							nil,
+							nil,
+							signingKey,
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				sink: &EventSink{
+					ID:       "sink1",
+					Name:     "webhook",
+					SinkType: domain.EventSinkTypeHTTP,
+					Endpoint: "https://example.com/v2/events",
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "instance1",
+				},
+			},
+		},
+		{
+			name: "add kafka sink, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectPush(
+						eventsink.NewSetEvent(context.Background(),
+							&eventsink.NewAggregate("sink1", "instance1").Aggregate,
+							"kafka",
+							domain.EventSinkTypeKafka,
+							"https://kafka-rest.example.com",
+							"zitadel-events",
+							nil,
+							nil,
+							nil,
+						),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "sink1"),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				sink: &EventSink{
+					Name:       "kafka",
+					SinkType:   domain.EventSinkTypeKafka,
+					Endpoint:   "https://kafka-rest.example.com",
+					Target:     "zitadel-events",
+					SigningKey: "ignored",
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "instance1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:   tt.fields.eventstore(t),
+				idGenerator:  tt.fields.idGenerator,
+				keyAlgorithm: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			}
+			got, err := c.SetEventSink(tt.args.ctx, tt.args.sink)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommands_RemoveEventSink(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		sinkID string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name       string
+		eventstore func(*testing.T) *eventstore.Eventstore
+		args       args
+		res        res
+	}{
+		{
+			name:       "missing id, error",
+			eventstore: expectEventstore(),
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+			},
+			res: res{
+				err: caos_errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "already removed, not found error",
+			eventstore: expectEventstore(
+				expectFilter(
+					eventFromEventPusher(
+						eventsink.NewSetEvent(context.Background(),
+							&eventsink.NewAggregate("sink1", "instance1").Aggregate,
+							"nats",
+							domain.EventSinkTypeNATS,
+							"nats://localhost:4222",
+							"zitadel.events",
+							nil,
+							nil,
+							nil,
+						),
+					),
+					eventFromEventPusher(
+						eventsink.NewRemovedEvent(context.Background(),
+							&eventsink.NewAggregate("sink1", "instance1").Aggregate,
+						),
+					),
+				),
+			),
+			args: args{
+				ctx:    authz.WithInstanceID(context.Background(), "instance1"),
+				sinkID: "sink1",
+			},
+			res: res{
+				err: caos_errors.IsNotFound,
+			},
+		},
+		{
+			name: "remove, ok",
+			eventstore: expectEventstore(
+				expectFilter(
+					eventFromEventPusher(
+						eventsink.NewSetEvent(context.Background(),
+							&eventsink.NewAggregate("sink1", "instance1").Aggregate,
+							"nats",
+							domain.EventSinkTypeNATS,
+							"nats://localhost:4222",
+							"zitadel.events",
+							nil,
+							nil,
+							nil,
+						),
+					),
+				),
+				expectPush(
+					eventsink.NewRemovedEvent(context.Background(),
+						&eventsink.NewAggregate("sink1", "instance1").Aggregate,
+					),
+				),
+			),
+			args: args{
+				ctx:    authz.WithInstanceID(context.Background(), "instance1"),
+				sinkID: "sink1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "instance1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.eventstore(t),
+			}
+			got, err := c.RemoveEventSink(tt.args.ctx, tt.args.sinkID)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
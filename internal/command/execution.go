@@ -0,0 +1,74 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/execution"
+)
+
+// SetExecution sets the targets called in order for the condition of the execution type.
+// The condition is a full method name, a service name or an event type, all methods or events are matched if it's empty.
+func (c *Commands) SetExecution(ctx context.Context, executionType domain.ExecutionType, condition string, targetIDs []string) (*domain.ObjectDetails, error) {
+	if !domain.ValidExecutionCondition(executionType, condition) {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-ohW6i", "Errors.Execution.Invalid.Condition")
+	}
+	if len(targetIDs) == 0 {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Ue5fa", "Errors.Execution.Invalid.Targets")
+	}
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	for _, targetID := range targetIDs {
+		target, err := c.getTargetWriteModel(ctx, instanceID, targetID)
+		if err != nil {
+			return nil, err
+		}
+		if !target.State.Exists() {
+			return nil, errors.ThrowNotFound(nil, "COMMAND-aeT4e", "Errors.Target.NotFound")
+		}
+	}
+	executionID := domain.ExecutionID(executionType, condition)
+	wm, err := c.getExecutionWriteModel(ctx, instanceID, executionID)
+	if err != nil {
+		return nil, err
+	}
+	aggregate := execution.NewAggregate(executionID, instanceID)
+	pushedEvents, err := c.eventstore.Push(ctx, execution.NewSetEvent(ctx, &aggregate.Aggregate, targetIDs))
+	if err != nil {
+		return nil, err
+	}
+	if err = AppendAndReduce(wm, pushedEvents...); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&wm.WriteModel), nil
+}
+
+func (c *Commands) RemoveExecution(ctx context.Context, executionType domain.ExecutionType, condition string) (*domain.ObjectDetails, error) {
+	if !domain.ValidExecutionCondition(executionType, condition) {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Eix3u", "Errors.Execution.Invalid.Condition")
+	}
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	executionID := domain.ExecutionID(executionType, condition)
+	wm, err := c.getExecutionWriteModel(ctx, instanceID, executionID)
+	if err != nil {
+		return nil, err
+	}
+	if !wm.State.Exists() {
+		return nil, errors.ThrowNotFound(nil, "COMMAND-ieG8o", "Errors.Execution.NotFound")
+	}
+	aggregate := execution.NewAggregate(executionID, instanceID)
+	pushedEvents, err := c.eventstore.Push(ctx, execution.NewRemovedEvent(ctx, &aggregate.Aggregate))
+	if err != nil {
+		return nil, err
+	}
+	if err = AppendAndReduce(wm, pushedEvents...); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&wm.WriteModel), nil
+}
+
+func (c *Commands) getExecutionWriteModel(ctx context.Context, instanceID, executionID string) (*executionWriteModel, error) {
+	wm := newExecutionWriteModel(instanceID, executionID)
+	return wm, c.eventstore.FilterToQueryReducer(ctx, wm)
+}
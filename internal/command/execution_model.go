@@ -0,0 +1,52 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/execution"
+)
+
+type executionWriteModel struct {
+	eventstore.WriteModel
+
+	Targets []string
+	State   domain.ExecutionState
+}
+
+func newExecutionWriteModel(instanceID, executionID string) *executionWriteModel {
+	return &executionWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   executionID,
+			InstanceID:    instanceID,
+			ResourceOwner: instanceID,
+		},
+	}
+}
+
+func (wm *executionWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		InstanceID(wm.InstanceID).
+		AddQuery().
+		AggregateTypes(execution.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			execution.SetEventType,
+			execution.RemovedEventType,
+		).
+		Builder()
+}
+
+func (wm *executionWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *execution.SetEvent:
+			wm.Targets = e.Targets
+			wm.State = domain.ExecutionStateActive
+		case *execution.RemovedEvent:
+			wm.Targets = nil
+			wm.State = domain.ExecutionStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
@@ -0,0 +1,214 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errors "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/execution"
+	"github.com/zitadel/zitadel/internal/repository/target"
+)
+
+func TestCommands_SetExecution(t *testing.T) {
+	const method = "/zitadel.user.v2beta.UserService/AddHumanUser"
+	type args struct {
+		ctx           context.Context
+		executionType domain.ExecutionType
+		condition     string
+		targets       []string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name       string
+		eventstore func(*testing.T) *eventstore.Eventstore
+		args       args
+		res        res
+	}{
+		{
+			name:       "invalid type, error",
+			eventstore: expectEventstore(),
+			args: args{
+				ctx:     authz.WithInstanceID(context.Background(), "instance1"),
+				targets: []string{"target1"},
+			},
+			res: res{
+				err: caos_errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name:       "invalid method, error",
+			eventstore: expectEventstore(),
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				executionType: domain.ExecutionTypeRequest,
+				condition:     "zitadel.user.v2beta.UserService/AddHumanUser",
+				targets:       []string{"target1"},
+			},
+			res: res{
+				err: caos_errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name:       "no targets, error",
+			eventstore: expectEventstore(),
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				executionType: domain.ExecutionTypeRequest,
+				condition:     method,
+			},
+			res: res{
+				err: caos_errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name:       "target not existing, not found error",
+			eventstore: expectEventstore(expectFilter()),
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				executionType: domain.ExecutionTypeRequest,
+				condition:     method,
+				targets:       []string{"target1"},
+			},
+			res: res{
+				err: caos_errors.IsNotFound,
+			},
+		},
+		{
+			name: "set, ok",
+			eventstore: expectEventstore(
+				expectFilter(
+					eventFromEventPusher(
+						target.NewSetEvent(context.Background(),
+							&target.NewAggregate("target1", "instance1").Aggregate,
+							"target",
+							"https://example.com",
+							time.Second,
+							false,
+							nil,
+						),
+					),
+				),
+				expectFilter(),
+				expectPush(
+					execution.NewSetEvent(context.Background(),
+						&execution.NewAggregate("request"+method, "instance1").Aggregate,
+						[]string{"target1"},
+					),
+				),
+			),
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				executionType: domain.ExecutionTypeRequest,
+				condition:     method,
+				targets:       []string{"target1"},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "instance1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.eventstore(t),
+			}
+			got, err := c.SetExecution(tt.args.ctx, tt.args.executionType, tt.args.condition, tt.args.targets)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommands_RemoveExecution(t *testing.T) {
+	type args struct {
+		ctx           context.Context
+		executionType domain.ExecutionType
+		condition     string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name       string
+		eventstore func(*testing.T) *eventstore.Eventstore
+		args       args
+		res        res
+	}{
+		{
+			name:       "not existing, not found error",
+			eventstore: expectEventstore(expectFilter()),
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				executionType: domain.ExecutionTypeEvent,
+				condition:     "user.human.added",
+			},
+			res: res{
+				err: caos_errors.IsNotFound,
+			},
+		},
+		{
+			name: "remove, ok",
+			eventstore: expectEventstore(
+				expectFilter(
+					eventFromEventPusher(
+						execution.NewSetEvent(context.Background(),
+							&execution.NewAggregate("event/user.human.added", "instance1").Aggregate,
+							[]string{"target1"},
+						),
+					),
+				),
+				expectPush(
+					execution.NewRemovedEvent(context.Background(),
+						&execution.NewAggregate("event/user.human.added", "instance1").Aggregate,
+					),
+				),
+			),
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				executionType: domain.ExecutionTypeEvent,
+				condition:     "user.human.added",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "instance1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.eventstore(t),
+			}
+			got, err := c.RemoveExecution(tt.args.ctx, tt.args.executionType, tt.args.condition)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
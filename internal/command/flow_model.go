@@ -12,6 +12,8 @@ type FlowWriteModel struct {
 	FlowType domain.FlowType
 	State    domain.FlowState
 	Triggers map[domain.TriggerType][]string
+	// TriggerVersions contains the pinned action versions per trigger
+	TriggerVersions map[domain.TriggerType]map[string]uint64
 }
 
 func NewFlowWriteModel(flowType domain.FlowType, resourceOwner string) *FlowWriteModel {
@@ -20,8 +22,9 @@ func NewFlowWriteModel(flowType domain.FlowType, resourceOwner string) *FlowWrit
 			AggregateID:   resourceOwner,
 			ResourceOwner: resourceOwner,
 		},
-		FlowType: flowType,
-		Triggers: make(map[domain.TriggerType][]string),
+		FlowType:        flowType,
+		Triggers:        make(map[domain.TriggerType][]string),
+		TriggerVersions: make(map[domain.TriggerType]map[string]uint64),
 	}
 }
 
@@ -33,10 +36,16 @@ func (wm *FlowWriteModel) Reduce() error {
 				wm.Triggers = make(map[domain.TriggerType][]string)
 			}
 			wm.Triggers[e.TriggerType] = e.ActionIDs
+			if wm.TriggerVersions == nil {
+				wm.TriggerVersions = make(map[domain.TriggerType]map[string]uint64)
+			}
+			wm.TriggerVersions[e.TriggerType] = e.ActionVersions
 		case *flow.TriggerActionsCascadeRemovedEvent:
 			remove(wm.Triggers[e.TriggerType], e.ActionID)
+			delete(wm.TriggerVersions[e.TriggerType], e.ActionID)
 		case *flow.FlowClearedEvent:
 			wm.Triggers = nil
+			wm.TriggerVersions = nil
 		}
 	}
 	return wm.WriteModel.Reduce()
@@ -28,6 +28,11 @@ type IDPIntentWriteModel struct {
 	RequestID string
 	Assertion *crypto.CryptoValue
 
+	// ActionsRun is true after the post authentication actions ran for the intent,
+	// ActionsMetadata is their metadata for the user of the intent
+	ActionsRun      bool
+	ActionsMetadata []*domain.Metadata
+
 	State     domain.IDPIntentState
 	aggregate *eventstore.Aggregate
 }
@@ -57,6 +62,8 @@ func (wm *IDPIntentWriteModel) Reduce() error {
 			wm.reduceLDAPSucceededEvent(e)
 		case *idpintent.FailedEvent:
 			wm.reduceFailedEvent(e)
+		case *idpintent.ActionsRunEvent:
+			wm.reduceActionsRunEvent(e)
 		}
 	}
 	return wm.WriteModel.Reduce()
@@ -75,6 +82,7 @@ func (wm *IDPIntentWriteModel) Query() *eventstore.SearchQueryBuilder {
 			idpintent.SAMLRequestEventType,
 			idpintent.LDAPSucceededEventType,
 			idpintent.FailedEventType,
+			idpintent.ActionsRunEventType,
 		).
 		Builder()
 }
@@ -114,6 +122,15 @@ func (wm *IDPIntentWriteModel) reduceOAuthSucceededEvent(e *idpintent.SucceededE
 	wm.State = domain.IDPIntentStateSucceeded
 }
 
+func (wm *IDPIntentWriteModel) reduceActionsRunEvent(e *idpintent.ActionsRunEvent) {
+	wm.ActionsRun = true
+	wm.IDPUserName = e.IDPUserName
+	wm.ActionsMetadata = make([]*domain.Metadata, len(e.Metadata))
+	for i, md := range e.Metadata {
+		wm.ActionsMetadata[i] = &domain.Metadata{Key: md.Key, Value: md.Value}
+	}
+}
+
 func (wm *IDPIntentWriteModel) reduceSAMLRequestEvent(e *idpintent.SAMLRequestEvent) {
 	wm.RequestID = e.RequestID
 }
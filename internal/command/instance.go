@@ -44,11 +44,13 @@ type InstanceSetup struct {
 	Org                      InstanceOrgSetup
 	SecretGenerators         *SecretGenerators
 	PasswordComplexityPolicy struct {
-		MinLength    uint64
-		HasLowercase bool
-		HasUppercase bool
-		HasNumber    bool
-		HasSymbol    bool
+		MinLength     uint64
+		HasLowercase  bool
+		HasUppercase  bool
+		HasNumber     bool
+		HasSymbol     bool
+		HistoryDepth  uint64
+		CheckBreached bool
 	}
 	PasswordAgePolicy struct {
 		ExpireWarnDays uint64
@@ -104,6 +106,9 @@ type InstanceSetup struct {
 	LockoutPolicy struct {
 		MaxAttempts              uint64
 		ShouldShowLockoutFailure bool
+		MaxOTPAttempts           uint64
+		AutoUnlockAfter          time.Duration
+		FailedAttemptDelay       time.Duration
 	}
 	EmailTemplate     []byte
 	MessageTexts      []*domain.CustomMessageText
@@ -231,6 +236,8 @@ func (c *Commands) SetUpInstance(ctx context.Context, setup *InstanceSetup) (str
 			setup.PasswordComplexityPolicy.HasUppercase,
 			setup.PasswordComplexityPolicy.HasNumber,
 			setup.PasswordComplexityPolicy.HasSymbol,
+			setup.PasswordComplexityPolicy.HistoryDepth,
+			setup.PasswordComplexityPolicy.CheckBreached,
 		),
 		prepareAddDefaultPasswordAgePolicy(
 			instanceAgg,
@@ -273,7 +280,7 @@ func (c *Commands) SetUpInstance(ctx context.Context, setup *InstanceSetup) (str
 
 		prepareAddDefaultPrivacyPolicy(instanceAgg, setup.PrivacyPolicy.TOSLink, setup.PrivacyPolicy.PrivacyLink, setup.PrivacyPolicy.HelpLink, setup.PrivacyPolicy.SupportEmail),
 		prepareAddDefaultNotificationPolicy(instanceAgg, setup.NotificationPolicy.PasswordChange),
-		prepareAddDefaultLockoutPolicy(instanceAgg, setup.LockoutPolicy.MaxAttempts, setup.LockoutPolicy.ShouldShowLockoutFailure),
+		prepareAddDefaultLockoutPolicy(instanceAgg, setup.LockoutPolicy.MaxAttempts, setup.LockoutPolicy.ShouldShowLockoutFailure, setup.LockoutPolicy.MaxOTPAttempts, setup.LockoutPolicy.AutoUnlockAfter, setup.LockoutPolicy.FailedAttemptDelay),
 
 		prepareAddDefaultLabelPolicy(
 			instanceAgg,
@@ -406,6 +413,7 @@ func setupSMTPSettings(commands *Commands, validations *[]preparation.Validation
 	*validations = append(*validations,
 		commands.prepareAddSMTPConfig(
 			instanceAgg,
+			instanceAgg.ID,
 			smtpConfig.From,
 			smtpConfig.FromName,
 			smtpConfig.ReplyToAddress,
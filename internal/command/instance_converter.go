@@ -96,12 +96,14 @@ func writeModelToPasswordAgePolicy(wm *PasswordAgePolicyWriteModel) *domain.Pass
 
 func writeModelToPasswordComplexityPolicy(wm *PasswordComplexityPolicyWriteModel) *domain.PasswordComplexityPolicy {
 	return &domain.PasswordComplexityPolicy{
-		ObjectRoot:   writeModelToObjectRoot(wm.WriteModel),
-		MinLength:    wm.MinLength,
-		HasLowercase: wm.HasLowercase,
-		HasUppercase: wm.HasUppercase,
-		HasNumber:    wm.HasNumber,
-		HasSymbol:    wm.HasSymbol,
+		ObjectRoot:    writeModelToObjectRoot(wm.WriteModel),
+		MinLength:     wm.MinLength,
+		HasLowercase:  wm.HasLowercase,
+		HasUppercase:  wm.HasUppercase,
+		HasNumber:     wm.HasNumber,
+		HasSymbol:     wm.HasSymbol,
+		HistoryDepth:  wm.HistoryDepth,
+		CheckBreached: wm.CheckBreached,
 	}
 }
 
@@ -110,6 +112,9 @@ func writeModelToLockoutPolicy(wm *LockoutPolicyWriteModel) *domain.LockoutPolic
 		ObjectRoot:          writeModelToObjectRoot(wm.WriteModel),
 		MaxPasswordAttempts: wm.MaxPasswordAttempts,
 		ShowLockOutFailures: wm.ShowLockOutFailures,
+		MaxOTPAttempts:      wm.MaxOTPAttempts,
+		AutoUnlockAfter:     wm.AutoUnlockAfter,
+		FailedAttemptDelay:  wm.FailedAttemptDelay,
 	}
 }
 
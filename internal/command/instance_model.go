@@ -63,6 +63,12 @@ func (wm *InstanceWriteModel) Reduce() error {
 	return wm.WriteModel.Reduce()
 }
 
+// SnapshotVersion implements [eventstore.SnapshotQueryReducer],
+// it must be increased if the fields or the reduce of the write model change
+func (wm *InstanceWriteModel) SnapshotVersion() uint16 {
+	return 1
+}
+
 func (wm *InstanceWriteModel) Query() *eventstore.SearchQueryBuilder {
 	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
 		ResourceOwner(wm.ResourceOwner).
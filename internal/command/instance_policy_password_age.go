@@ -52,6 +52,17 @@ func (c *Commands) ChangeDefaultPasswordAgePolicy(ctx context.Context, policy *d
 	return writeModelToPasswordAgePolicy(&existingPolicy.PasswordAgePolicyWriteModel), nil
 }
 
+func (c *Commands) getDefaultPasswordAgePolicy(ctx context.Context) (*domain.PasswordAgePolicy, error) {
+	policyWriteModel, err := c.defaultPasswordAgePolicyWriteModelByID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !policyWriteModel.State.Exists() {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "INSTANCE-Ed3Fw", "Errors.IAM.PasswordAgePolicy.NotFound")
+	}
+	return writeModelToPasswordAgePolicy(&policyWriteModel.PasswordAgePolicyWriteModel), nil
+}
+
 func (c *Commands) defaultPasswordAgePolicyWriteModelByID(ctx context.Context) (policy *InstancePasswordAgePolicyWriteModel, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -12,9 +12,9 @@ import (
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
 )
 
-func (c *Commands) AddDefaultPasswordComplexityPolicy(ctx context.Context, minLength uint64, hasLowercase, hasUppercase, hasNumber, hasSymbol bool) (*domain.ObjectDetails, error) {
+func (c *Commands) AddDefaultPasswordComplexityPolicy(ctx context.Context, minLength uint64, hasLowercase, hasUppercase, hasNumber, hasSymbol bool, historyDepth uint64, checkBreached bool) (*domain.ObjectDetails, error) {
 	instanceAgg := instance.NewAggregate(authz.GetInstance(ctx).InstanceID())
-	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, prepareAddDefaultPasswordComplexityPolicy(instanceAgg, minLength, hasLowercase, hasUppercase, hasNumber, hasSymbol))
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, prepareAddDefaultPasswordComplexityPolicy(instanceAgg, minLength, hasLowercase, hasUppercase, hasNumber, hasSymbol, historyDepth, checkBreached))
 	if err != nil {
 		return nil, err
 	}
@@ -39,7 +39,7 @@ func (c *Commands) ChangeDefaultPasswordComplexityPolicy(ctx context.Context, po
 	}
 
 	instanceAgg := InstanceAggregateFromWriteModel(&existingPolicy.PasswordComplexityPolicyWriteModel.WriteModel)
-	changedEvent, hasChanged := existingPolicy.NewChangedEvent(ctx, instanceAgg, policy.MinLength, policy.HasLowercase, policy.HasUppercase, policy.HasNumber, policy.HasSymbol)
+	changedEvent, hasChanged := existingPolicy.NewChangedEvent(ctx, instanceAgg, policy.MinLength, policy.HasLowercase, policy.HasUppercase, policy.HasNumber, policy.HasSymbol, policy.HistoryDepth, policy.CheckBreached)
 	if !hasChanged {
 		return nil, caos_errs.ThrowPreconditionFailed(nil, "INSTANCE-9jlsf", "Errors.IAM.PasswordComplexityPolicy.NotChanged")
 	}
@@ -61,11 +61,16 @@ func prepareAddDefaultPasswordComplexityPolicy(
 	hasUppercase,
 	hasNumber,
 	hasSymbol bool,
+	historyDepth uint64,
+	checkBreached bool,
 ) preparation.Validation {
 	return func() (preparation.CreateCommands, error) {
 		if minLength == 0 || minLength > 72 {
 			return nil, caos_errs.ThrowInvalidArgument(nil, "INSTANCE-Lsp0e", "Errors.Instance.PasswordComplexityPolicy.MinLengthNotAllowed")
 		}
+		if historyDepth > domain.MaxPasswordHistoryDepth {
+			return nil, caos_errs.ThrowInvalidArgument(nil, "INSTANCE-Aij4e", "Errors.User.PasswordComplexityPolicy.HistoryDepthNotAllowed")
+		}
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
 			writeModel := NewInstancePasswordComplexityPolicyWriteModel(ctx)
 			events, err := filter(ctx, writeModel.Query())
@@ -86,6 +91,8 @@ func prepareAddDefaultPasswordComplexityPolicy(
 					hasUppercase,
 					hasNumber,
 					hasSymbol,
+					historyDepth,
+					checkBreached,
 				),
 			}, nil
 		}, nil
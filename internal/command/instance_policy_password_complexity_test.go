@@ -26,6 +26,7 @@ func TestCommandSide_AddDefaultPasswordComplexityPolicy(t *testing.T) {
 		hasUppercase bool
 		hasNumber    bool
 		hasSymbol    bool
+		historyDepth uint64
 	}
 	type res struct {
 		want *domain.ObjectDetails
@@ -56,6 +57,26 @@ func TestCommandSide_AddDefaultPasswordComplexityPolicy(t *testing.T) {
 				err: caos_errs.IsErrorInvalidArgument,
 			},
 		},
+		{
+			name: "history depth too big, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:          context.Background(),
+				minLength:    8,
+				hasUppercase: true,
+				hasLowercase: true,
+				hasNumber:    true,
+				hasSymbol:    true,
+				historyDepth: domain.MaxPasswordHistoryDepth + 1,
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
 		{
 			name: "password complexity policy already existing, already exists error",
 			fields: fields{
@@ -67,6 +88,8 @@ func TestCommandSide_AddDefaultPasswordComplexityPolicy(t *testing.T) {
 								&instance.NewAggregate("INSTANCE").Aggregate,
 								8,
 								true, true, true, true,
+								0,
+								false,
 							),
 						),
 					),
@@ -95,6 +118,8 @@ func TestCommandSide_AddDefaultPasswordComplexityPolicy(t *testing.T) {
 							&instance.NewAggregate("INSTANCE").Aggregate,
 							8,
 							true, true, true, true,
+							0,
+							false,
 						),
 					),
 				),
@@ -119,7 +144,7 @@ func TestCommandSide_AddDefaultPasswordComplexityPolicy(t *testing.T) {
 			r := &Commands{
 				eventstore: tt.fields.eventstore,
 			}
-			got, err := r.AddDefaultPasswordComplexityPolicy(tt.args.ctx, tt.args.minLength, tt.args.hasLowercase, tt.args.hasUppercase, tt.args.hasNumber, tt.args.hasSymbol)
+			got, err := r.AddDefaultPasswordComplexityPolicy(tt.args.ctx, tt.args.minLength, tt.args.hasLowercase, tt.args.hasUppercase, tt.args.hasNumber, tt.args.hasSymbol, tt.args.historyDepth, false)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -172,6 +197,28 @@ func TestCommandSide_ChangeDefaultPasswordComplexityPolicy(t *testing.T) {
 				err: caos_errs.IsErrorInvalidArgument,
 			},
 		},
+		{
+			name: "history depth too big, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				policy: &domain.PasswordComplexityPolicy{
+					MinLength:    8,
+					HasUppercase: true,
+					HasLowercase: true,
+					HasNumber:    true,
+					HasSymbol:    true,
+					HistoryDepth: domain.MaxPasswordHistoryDepth + 1,
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
 		{
 			name: "password complexity policy not existing, not found error",
 			fields: fields{
@@ -205,6 +252,8 @@ func TestCommandSide_ChangeDefaultPasswordComplexityPolicy(t *testing.T) {
 								&instance.NewAggregate("INSTANCE").Aggregate,
 								8,
 								true, true, true, true,
+								0,
+								false,
 							),
 						),
 					),
@@ -235,6 +284,8 @@ func TestCommandSide_ChangeDefaultPasswordComplexityPolicy(t *testing.T) {
 								&instance.NewAggregate("INSTANCE").Aggregate,
 								8,
 								true, true, true, true,
+								0,
+								false,
 							),
 						),
 					),
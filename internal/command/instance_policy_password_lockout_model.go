@@ -2,6 +2,7 @@ package command
 
 import (
 	"context"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/eventstore"
@@ -56,7 +57,10 @@ func (wm *InstanceLockoutPolicyWriteModel) NewChangedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
 	maxAttempts uint64,
-	showLockoutFailure bool) (*instance.LockoutPolicyChangedEvent, bool) {
+	showLockoutFailure bool,
+	maxOTPAttempts uint64,
+	autoUnlockAfter time.Duration,
+	failedAttemptDelay time.Duration) (*instance.LockoutPolicyChangedEvent, bool) {
 	changes := make([]policy.LockoutPolicyChanges, 0)
 	if wm.MaxPasswordAttempts != maxAttempts {
 		changes = append(changes, policy.ChangeMaxAttempts(maxAttempts))
@@ -64,6 +68,15 @@ func (wm *InstanceLockoutPolicyWriteModel) NewChangedEvent(
 	if wm.ShowLockOutFailures != showLockoutFailure {
 		changes = append(changes, policy.ChangeShowLockOutFailures(showLockoutFailure))
 	}
+	if wm.MaxOTPAttempts != maxOTPAttempts {
+		changes = append(changes, policy.ChangeMaxOTPAttempts(maxOTPAttempts))
+	}
+	if wm.AutoUnlockAfter != autoUnlockAfter {
+		changes = append(changes, policy.ChangeAutoUnlockAfter(autoUnlockAfter))
+	}
+	if wm.FailedAttemptDelay != failedAttemptDelay {
+		changes = append(changes, policy.ChangeFailedAttemptDelay(failedAttemptDelay))
+	}
 	if len(changes) == 0 {
 		return nil, false
 	}
@@ -3,6 +3,7 @@ package command
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -23,6 +24,9 @@ func TestCommandSide_AddDefaultLockoutPolicy(t *testing.T) {
 		ctx                 context.Context
 		maxPasswordAttempts uint64
 		showLockOutFailures bool
+		maxOTPAttempts      uint64
+		autoUnlockAfter     time.Duration
+		failedAttemptDelay  time.Duration
 	}
 	type res struct {
 		want *domain.ObjectDetails
@@ -45,6 +49,9 @@ func TestCommandSide_AddDefaultLockoutPolicy(t *testing.T) {
 								&instance.NewAggregate("INSTANCE").Aggregate,
 								10,
 								true,
+								0,
+								0,
+								0,
 							),
 						),
 					),
@@ -70,6 +77,9 @@ func TestCommandSide_AddDefaultLockoutPolicy(t *testing.T) {
 							&instance.NewAggregate("INSTANCE").Aggregate,
 							10,
 							true,
+							5,
+							time.Hour,
+							time.Second,
 						),
 					),
 				),
@@ -78,6 +88,9 @@ func TestCommandSide_AddDefaultLockoutPolicy(t *testing.T) {
 				ctx:                 authz.WithInstanceID(context.Background(), "INSTANCE"),
 				maxPasswordAttempts: 10,
 				showLockOutFailures: true,
+				maxOTPAttempts:      5,
+				autoUnlockAfter:     time.Hour,
+				failedAttemptDelay:  time.Second,
 			},
 			res: res{
 				want: &domain.ObjectDetails{
@@ -91,7 +104,7 @@ func TestCommandSide_AddDefaultLockoutPolicy(t *testing.T) {
 			r := &Commands{
 				eventstore: tt.fields.eventstore,
 			}
-			got, err := r.AddDefaultLockoutPolicy(tt.args.ctx, tt.args.maxPasswordAttempts, tt.args.showLockOutFailures)
+			got, err := r.AddDefaultLockoutPolicy(tt.args.ctx, tt.args.maxPasswordAttempts, tt.args.showLockOutFailures, tt.args.maxOTPAttempts, tt.args.autoUnlockAfter, tt.args.failedAttemptDelay)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -153,6 +166,9 @@ func TestCommandSide_ChangeDefaultLockoutPolicy(t *testing.T) {
 								&instance.NewAggregate("INSTANCE").Aggregate,
 								10,
 								true,
+								0,
+								0,
+								0,
 							),
 						),
 					),
@@ -180,6 +196,9 @@ func TestCommandSide_ChangeDefaultLockoutPolicy(t *testing.T) {
 								&instance.NewAggregate("INSTANCE").Aggregate,
 								10,
 								true,
+								0,
+								0,
+								0,
 							),
 						),
 					),
@@ -207,6 +226,53 @@ func TestCommandSide_ChangeDefaultLockoutPolicy(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "change auto unlock and delays, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewLockoutPolicyAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								10,
+								true,
+								0,
+								0,
+								0,
+							),
+						),
+					),
+					expectPush(
+						newDefaultLockoutPolicyDelaysChangedEvent(context.Background(), 5, time.Hour, time.Second),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				policy: &domain.LockoutPolicy{
+					MaxPasswordAttempts: 10,
+					ShowLockOutFailures: true,
+					MaxOTPAttempts:      5,
+					AutoUnlockAfter:     time.Hour,
+					FailedAttemptDelay:  time.Second,
+				},
+			},
+			res: res{
+				want: &domain.LockoutPolicy{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "INSTANCE",
+						ResourceOwner: "INSTANCE",
+						InstanceID:    "INSTANCE",
+					},
+					MaxPasswordAttempts: 10,
+					ShowLockOutFailures: true,
+					MaxOTPAttempts:      5,
+					AutoUnlockAfter:     time.Hour,
+					FailedAttemptDelay:  time.Second,
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -237,3 +303,15 @@ func newDefaultLockoutPolicyChangedEvent(ctx context.Context, maxAttempts uint64
 	)
 	return event
 }
+
+func newDefaultLockoutPolicyDelaysChangedEvent(ctx context.Context, maxOTPAttempts uint64, autoUnlockAfter, failedAttemptDelay time.Duration) *instance.LockoutPolicyChangedEvent {
+	event, _ := instance.NewLockoutPolicyChangedEvent(ctx,
+		&instance.NewAggregate("INSTANCE").Aggregate,
+		[]policy.LockoutPolicyChanges{
+			policy.ChangeMaxOTPAttempts(maxOTPAttempts),
+			policy.ChangeAutoUnlockAfter(autoUnlockAfter),
+			policy.ChangeFailedAttemptDelay(failedAttemptDelay),
+		},
+	)
+	return event
+}
@@ -12,6 +12,7 @@ import (
 type InstanceSMTPConfigWriteModel struct {
 	eventstore.WriteModel
 
+	ID             string
 	SenderAddress  string
 	SenderName     string
 	ReplyToAddress string
@@ -26,12 +27,13 @@ type InstanceSMTPConfigWriteModel struct {
 	smtpSenderAddressMatchesInstanceDomain bool
 }
 
-func NewInstanceSMTPConfigWriteModel(instanceID, domain string) *InstanceSMTPConfigWriteModel {
+func NewInstanceSMTPConfigWriteModel(instanceID, id, domain string) *InstanceSMTPConfigWriteModel {
 	return &InstanceSMTPConfigWriteModel{
 		WriteModel: eventstore.WriteModel{
 			AggregateID:   instanceID,
 			ResourceOwner: instanceID,
 		},
+		ID:     id,
 		domain: domain,
 	}
 }
@@ -49,6 +51,26 @@ func (wm *InstanceSMTPConfigWriteModel) AppendEvents(events ...eventstore.Event)
 				continue
 			}
 			wm.WriteModel.AppendEvents(e)
+		case *instance.SMTPConfigAddedEvent:
+			if e.ID != wm.ID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *instance.SMTPConfigChangedEvent:
+			if e.ID != wm.ID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *instance.SMTPConfigPasswordChangedEvent:
+			if e.ID != wm.ID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *instance.SMTPConfigRemovedEvent:
+			if e.ID != wm.ID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
 		default:
 			wm.WriteModel.AppendEvents(e)
 		}
@@ -68,6 +90,8 @@ func (wm *InstanceSMTPConfigWriteModel) Reduce() error {
 			wm.User = e.User
 			wm.Password = e.Password
 			wm.State = domain.SMTPConfigStateActive
+		case *instance.SMTPConfigPasswordChangedEvent:
+			wm.Password = e.Password
 		case *instance.SMTPConfigChangedEvent:
 			if e.TLS != nil {
 				wm.TLS = *e.TLS
@@ -155,7 +179,7 @@ func (wm *InstanceSMTPConfigWriteModel) NewChangedEvent(ctx context.Context, agg
 	if len(changes) == 0 {
 		return nil, false, nil
 	}
-	changeEvent, err := instance.NewSMTPConfigChangeEvent(ctx, aggregate, changes)
+	changeEvent, err := instance.NewSMTPConfigChangeEvent(ctx, aggregate, wm.ID, changes)
 	if err != nil {
 		return nil, false, err
 	}
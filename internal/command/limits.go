@@ -2,6 +2,7 @@ package command
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
@@ -14,6 +15,44 @@ import (
 
 type SetLimits struct {
 	AuditLogRetention *time.Duration
+	// ActionMaxAllocations is the maximum of bytes of the values held by an action run
+	ActionMaxAllocations *uint64
+	// ActionMaxLoopIterations is the maximum of loop iterations of an action run
+	ActionMaxLoopIterations *uint64
+	// ActionMaxOutputSize is the maximum of bytes logged by an action run
+	ActionMaxOutputSize *uint64
+	// ActionMaxHTTPCalls is the maximum of HTTP calls of an action run
+	ActionMaxHTTPCalls *uint32
+	// ActionMaxHTTPResponseSize is the maximum size in bytes of a response body read by an action
+	ActionMaxHTTPResponseSize *uint64
+	// ActionMaxConcurrentRuns is the maximum of actions running at the same time in the instance
+	ActionMaxConcurrentRuns *uint32
+	// ActionAllowedDomains restricts the hosts called by actions, an empty list falls back to the allow-list of the system
+	ActionAllowedDomains []string
+}
+
+func (s *SetLimits) isEmpty() bool {
+	return s == nil ||
+		(s.AuditLogRetention == nil &&
+			s.ActionMaxAllocations == nil &&
+			s.ActionMaxLoopIterations == nil &&
+			s.ActionMaxOutputSize == nil &&
+			s.ActionMaxHTTPCalls == nil &&
+			s.ActionMaxHTTPResponseSize == nil &&
+			s.ActionMaxConcurrentRuns == nil &&
+			s.ActionAllowedDomains == nil)
+}
+
+func (s *SetLimits) validate() error {
+	if s.isEmpty() {
+		return errors.ThrowInvalidArgument(nil, "COMMAND-4M9vs", "Errors.Limits.NoneSpecified")
+	}
+	for _, domain := range s.ActionAllowedDomains {
+		if strings.TrimSpace(domain) == "" {
+			return errors.ThrowInvalidArgument(nil, "COMMAND-Bq3nd", "Errors.Limits.ActionAllowedDomainInvalid")
+		}
+	}
+	return nil
 }
 
 // SetLimits creates new limits or updates existing limits.
@@ -84,8 +123,8 @@ func (c *Commands) getLimitsWriteModel(ctx context.Context, instanceId, resource
 
 func (c *Commands) SetLimitsCommand(a *limits.Aggregate, wm *limitsWriteModel, setLimits *SetLimits) preparation.Validation {
 	return func() (preparation.CreateCommands, error) {
-		if setLimits == nil || setLimits.AuditLogRetention == nil {
-			return nil, errors.ThrowInvalidArgument(nil, "COMMAND-4M9vs", "Errors.Limits.NoneSpecified")
+		if err := setLimits.validate(); err != nil {
+			return nil, err
 		}
 		return func(ctx context.Context, _ preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
 			changes := wm.NewChanges(setLimits)
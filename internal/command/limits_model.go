@@ -1,6 +1,7 @@
 package command
 
 import (
+	"slices"
 	"time"
 
 	"github.com/zitadel/zitadel/internal/eventstore"
@@ -11,6 +12,14 @@ type limitsWriteModel struct {
 	eventstore.WriteModel
 	rollingAggregateID string
 	auditLogRetention  *time.Duration
+
+	actionMaxAllocations      *uint64
+	actionMaxLoopIterations   *uint64
+	actionMaxOutputSize       *uint64
+	actionMaxHTTPCalls        *uint32
+	actionMaxHTTPResponseSize *uint64
+	actionMaxConcurrentRuns   *uint32
+	actionAllowedDomains      []string
 }
 
 // newLimitsWriteModel aggregateId is filled by reducing unit matching events
@@ -46,9 +55,37 @@ func (wm *limitsWriteModel) Reduce() error {
 			if e.AuditLogRetention != nil {
 				wm.auditLogRetention = e.AuditLogRetention
 			}
+			if e.ActionMaxAllocations != nil {
+				wm.actionMaxAllocations = e.ActionMaxAllocations
+			}
+			if e.ActionMaxLoopIterations != nil {
+				wm.actionMaxLoopIterations = e.ActionMaxLoopIterations
+			}
+			if e.ActionMaxOutputSize != nil {
+				wm.actionMaxOutputSize = e.ActionMaxOutputSize
+			}
+			if e.ActionMaxHTTPCalls != nil {
+				wm.actionMaxHTTPCalls = e.ActionMaxHTTPCalls
+			}
+			if e.ActionMaxHTTPResponseSize != nil {
+				wm.actionMaxHTTPResponseSize = e.ActionMaxHTTPResponseSize
+			}
+			if e.ActionMaxConcurrentRuns != nil {
+				wm.actionMaxConcurrentRuns = e.ActionMaxConcurrentRuns
+			}
+			if e.ActionAllowedDomains != nil {
+				wm.actionAllowedDomains = *e.ActionAllowedDomains
+			}
 		case *limits.ResetEvent:
 			wm.rollingAggregateID = ""
 			wm.auditLogRetention = nil
+			wm.actionMaxAllocations = nil
+			wm.actionMaxLoopIterations = nil
+			wm.actionMaxOutputSize = nil
+			wm.actionMaxHTTPCalls = nil
+			wm.actionMaxHTTPResponseSize = nil
+			wm.actionMaxConcurrentRuns = nil
+			wm.actionAllowedDomains = nil
 		}
 	}
 	if err := wm.WriteModel.Reduce(); err != nil {
@@ -65,9 +102,35 @@ func (wm *limitsWriteModel) NewChanges(setLimits *SetLimits) (changes []limits.L
 	if setLimits == nil {
 		return nil
 	}
-	changes = make([]limits.LimitsChange, 0, 1)
+	changes = make([]limits.LimitsChange, 0, 8)
 	if setLimits.AuditLogRetention != nil && (wm.auditLogRetention == nil || *wm.auditLogRetention != *setLimits.AuditLogRetention) {
 		changes = append(changes, limits.ChangeAuditLogRetention(setLimits.AuditLogRetention))
 	}
+	if valueChanged(wm.actionMaxAllocations, setLimits.ActionMaxAllocations) {
+		changes = append(changes, limits.ChangeActionMaxAllocations(setLimits.ActionMaxAllocations))
+	}
+	if valueChanged(wm.actionMaxLoopIterations, setLimits.ActionMaxLoopIterations) {
+		changes = append(changes, limits.ChangeActionMaxLoopIterations(setLimits.ActionMaxLoopIterations))
+	}
+	if valueChanged(wm.actionMaxOutputSize, setLimits.ActionMaxOutputSize) {
+		changes = append(changes, limits.ChangeActionMaxOutputSize(setLimits.ActionMaxOutputSize))
+	}
+	if valueChanged(wm.actionMaxHTTPCalls, setLimits.ActionMaxHTTPCalls) {
+		changes = append(changes, limits.ChangeActionMaxHTTPCalls(setLimits.ActionMaxHTTPCalls))
+	}
+	if valueChanged(wm.actionMaxHTTPResponseSize, setLimits.ActionMaxHTTPResponseSize) {
+		changes = append(changes, limits.ChangeActionMaxHTTPResponseSize(setLimits.ActionMaxHTTPResponseSize))
+	}
+	if valueChanged(wm.actionMaxConcurrentRuns, setLimits.ActionMaxConcurrentRuns) {
+		changes = append(changes, limits.ChangeActionMaxConcurrentRuns(setLimits.ActionMaxConcurrentRuns))
+	}
+	if setLimits.ActionAllowedDomains != nil && !slices.Equal(wm.actionAllowedDomains, setLimits.ActionAllowedDomains) {
+		changes = append(changes, limits.ChangeActionAllowedDomains(setLimits.ActionAllowedDomains))
+	}
 	return changes
 }
+
+// valueChanged returns true if the value is set and differs from the current value
+func valueChanged[T comparable](current, value *T) bool {
+	return value != nil && (current == nil || *current != *value)
+}
@@ -168,6 +168,99 @@ func TestLimits_SetLimits(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "update action limits, ok",
+			fields: func(*testing.T) (*eventstore.Eventstore, id.Generator) {
+				return eventstoreExpect(
+						t,
+						expectFilter(
+							eventFromEventPusher(
+								limits.NewSetEvent(
+									eventstore.NewBaseEventForPush(
+										context.Background(),
+										&limits.NewAggregate("limits1", "instance1", "instance1").Aggregate,
+										limits.SetEventType,
+									),
+									limits.ChangeAuditLogRetention(gu.Ptr(time.Hour)),
+									limits.ChangeActionMaxHTTPCalls(gu.Ptr(uint32(5))),
+								),
+							),
+						),
+						expectPush(
+							eventFromEventPusherWithInstanceID(
+								"instance1",
+								limits.NewSetEvent(
+									eventstore.NewBaseEventForPush(
+										context.Background(),
+										&limits.NewAggregate("limits1", "instance1", "instance1").Aggregate,
+										limits.SetEventType,
+									),
+									limits.ChangeActionMaxAllocations(gu.Ptr(uint64(1024))),
+									limits.ChangeActionMaxLoopIterations(gu.Ptr(uint64(1000))),
+									limits.ChangeActionMaxConcurrentRuns(gu.Ptr(uint32(2))),
+									limits.ChangeActionAllowedDomains([]string{"zitadel.com"}),
+								),
+							),
+						),
+					),
+					nil
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				resourceOwner: "instance1",
+				setLimits: &SetLimits{
+					AuditLogRetention:       gu.Ptr(time.Hour),
+					ActionMaxAllocations:    gu.Ptr(uint64(1024)),
+					ActionMaxLoopIterations: gu.Ptr(uint64(1000)),
+					ActionMaxHTTPCalls:      gu.Ptr(uint32(5)),
+					ActionMaxConcurrentRuns: gu.Ptr(uint32(2)),
+					ActionAllowedDomains:    []string{"zitadel.com"},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "instance1",
+				},
+			},
+		},
+		{
+			name: "no limits, error",
+			fields: func(*testing.T) (*eventstore.Eventstore, id.Generator) {
+				return eventstoreExpect(
+						t,
+						expectFilter(),
+					),
+					id_mock.NewIDGeneratorExpectIDs(t, "limits1")
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				resourceOwner: "instance1",
+				setLimits:     &SetLimits{},
+			},
+			res: res{
+				err: caos_errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "empty allowed domain, error",
+			fields: func(*testing.T) (*eventstore.Eventstore, id.Generator) {
+				return eventstoreExpect(
+						t,
+						expectFilter(),
+					),
+					id_mock.NewIDGeneratorExpectIDs(t, "limits1")
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				resourceOwner: "instance1",
+				setLimits: &SetLimits{
+					ActionAllowedDomains: []string{" "},
+				},
+			},
+			res: res{
+				err: caos_errors.IsErrorInvalidArgument,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -20,17 +20,21 @@ import (
 	"github.com/zitadel/zitadel/internal/eventstore/repository/mock"
 	action_repo "github.com/zitadel/zitadel/internal/repository/action"
 	"github.com/zitadel/zitadel/internal/repository/authrequest"
+	"github.com/zitadel/zitadel/internal/repository/eventsink"
+	"github.com/zitadel/zitadel/internal/repository/execution"
 	"github.com/zitadel/zitadel/internal/repository/feature"
 	"github.com/zitadel/zitadel/internal/repository/idpintent"
 	iam_repo "github.com/zitadel/zitadel/internal/repository/instance"
 	key_repo "github.com/zitadel/zitadel/internal/repository/keypair"
 	"github.com/zitadel/zitadel/internal/repository/limits"
+	"github.com/zitadel/zitadel/internal/repository/notification"
 	"github.com/zitadel/zitadel/internal/repository/oidcsession"
 	"github.com/zitadel/zitadel/internal/repository/org"
 	proj_repo "github.com/zitadel/zitadel/internal/repository/project"
 	quota_repo "github.com/zitadel/zitadel/internal/repository/quota"
 	"github.com/zitadel/zitadel/internal/repository/restrictions"
 	"github.com/zitadel/zitadel/internal/repository/session"
+	"github.com/zitadel/zitadel/internal/repository/target"
 	usr_repo "github.com/zitadel/zitadel/internal/repository/user"
 	"github.com/zitadel/zitadel/internal/repository/usergrant"
 )
@@ -38,14 +42,19 @@ import (
 type expect func(mockRepository *mock.MockRepository)
 
 func eventstoreExpect(t *testing.T, expects ...expect) *eventstore.Eventstore {
+	return eventstoreExpectWithPersonalDataKeys(t, nil, expects...)
+}
+
+func eventstoreExpectWithPersonalDataKeys(t *testing.T, keys eventstore.PersonalDataKeys, expects ...expect) *eventstore.Eventstore {
 	m := mock.NewRepo(t)
 	for _, e := range expects {
 		e(m)
 	}
 	es := eventstore.NewEventstore(
 		&eventstore.Config{
-			Querier: m.MockQuerier,
-			Pusher:  m.MockPusher,
+			Querier:          m.MockQuerier,
+			Pusher:           m.MockPusher,
+			PersonalDataKeys: keys,
 		},
 	)
 	iam_repo.RegisterEventMappers(es)
@@ -63,6 +72,10 @@ func eventstoreExpect(t *testing.T, expects ...expect) *eventstore.Eventstore {
 	limits.RegisterEventMappers(es)
 	restrictions.RegisterEventMappers(es)
 	feature.RegisterEventMappers(es)
+	eventsink.RegisterEventMappers(es)
+	notification.RegisterEventMappers(es)
+	target.RegisterEventMappers(es)
+	execution.RegisterEventMappers(es)
 	return es
 }
 
@@ -187,6 +200,13 @@ func eventFromEventPusherWithCreationDateNow(event eventstore.Command) *reposito
 	return e
 }
 
+// eventWithSequence sets the aggregate sequence of the event,
+// which is used as version of versioned aggregates like actions
+func eventWithSequence(sequence uint64, event *repository.Event) *repository.Event {
+	event.Seq = sequence
+	return event
+}
+
 func GetMockSecretGenerator(t *testing.T) crypto.Generator {
 	ctrl := gomock.NewController(t)
 	alg := crypto.CreateMockEncryptionAlg(ctrl)
@@ -294,3 +314,15 @@ func mockPasswordHasher(x string) *crypto.PasswordHasher {
 		Prefixes: []string{"$plain$"},
 	}
 }
+
+// mockBreachedPasswordChecker reports the contained passwords as breached.
+type mockBreachedPasswordChecker []string
+
+func (m mockBreachedPasswordChecker) IsBreached(_ context.Context, password string) (bool, error) {
+	for _, breached := range m {
+		if breached == password {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,204 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/notification"
+)
+
+// NotificationMessage is a message to a user which is queued in the notification outbox
+type NotificationMessage struct {
+	// ID is the idempotency key of the message, a message is only queued once per ID
+	ID            string
+	ResourceOwner string
+	UserID        string
+	Channel       domain.NotificationType
+	// TriggeringEventType is the type of the event the message was created for
+	TriggeringEventType eventstore.EventType
+	Recipient           string
+	Subject             string
+	Content             string
+}
+
+func (m *NotificationMessage) IsValid() error {
+	if m.ID == "" || m.ResourceOwner == "" {
+		return errors.ThrowInvalidArgument(nil, "COMMAND-Ie5ph", "Errors.IDMissing")
+	}
+	if !m.Channel.Valid() {
+		return errors.ThrowInvalidArgument(nil, "COMMAND-ooD4a", "Errors.Notification.Message.Invalid")
+	}
+	if m.Recipient == "" || m.Content == "" {
+		return errors.ThrowInvalidArgument(nil, "COMMAND-Ahj3u", "Errors.Notification.Message.Invalid")
+	}
+	return nil
+}
+
+// NotificationDelivery is a started attempt to send a queued message
+type NotificationDelivery struct {
+	Attempt             uint16
+	UserID              string
+	Channel             domain.NotificationType
+	TriggeringEventType eventstore.EventType
+	Recipient           string
+	Subject             string
+	Content             *crypto.CryptoValue
+	// Event is the pushed event which started the delivery
+	Event eventstore.Event
+}
+
+// EnqueueNotification adds the message to the outbox.
+// Messages which were already queued are ignored, so a triggering event can be handled multiple times.
+func (c *Commands) EnqueueNotification(ctx context.Context, message *NotificationMessage) error {
+	if err := message.IsValid(); err != nil {
+		return err
+	}
+	wm, err := c.getNotificationMessageWriteModel(ctx, message.ID, message.ResourceOwner)
+	if err != nil {
+		return err
+	}
+	if wm.State.Exists() {
+		return nil
+	}
+	content, err := crypto.Encrypt([]byte(message.Content), c.userEncryption)
+	if err != nil {
+		return err
+	}
+	aggregate := notification.NewAggregate(message.ID, message.ResourceOwner, authz.GetInstance(ctx).InstanceID())
+	_, err = c.eventstore.Push(ctx, notification.NewQueuedEvent(
+		ctx,
+		&aggregate.Aggregate,
+		message.UserID,
+		message.Channel,
+		message.TriggeringEventType,
+		message.Recipient,
+		message.Subject,
+		content,
+	))
+	return err
+}
+
+// StartNotificationDelivery claims the next attempt to send a due message.
+// A delivery which was started but never finished (e.g. because the worker stopped) is only claimed again after the deliveryTimeout.
+func (c *Commands) StartNotificationDelivery(ctx context.Context, id, resourceOwner string, deliveryTimeout time.Duration) (*NotificationDelivery, error) {
+	if id == "" || resourceOwner == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Thae6", "Errors.IDMissing")
+	}
+	wm, err := c.getNotificationMessageWriteModel(ctx, id, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	switch {
+	case !wm.State.Exists():
+		return nil, errors.ThrowNotFound(nil, "COMMAND-ea7Ai", "Errors.Notification.Message.NotFound")
+	case wm.State.Done():
+		return nil, errors.ThrowPreconditionFailed(nil, "COMMAND-Ohx2e", "Errors.Notification.Message.AlreadyDone")
+	case wm.State == domain.NotificationMessageStateQueued && wm.NextAttempt.After(now),
+		wm.State == domain.NotificationMessageStateSending && wm.ChangeDate.Add(deliveryTimeout).After(now):
+		return nil, errors.ThrowPreconditionFailed(nil, "COMMAND-iu0Ee", "Errors.Notification.Message.NotDue")
+	}
+	aggregate := notification.NewAggregate(id, resourceOwner, authz.GetInstance(ctx).InstanceID())
+	pushedEvents, err := c.eventstore.Push(ctx, notification.NewDeliveryStartedEvent(ctx, &aggregate.Aggregate, wm.Attempts+1))
+	if err != nil {
+		return nil, err
+	}
+	if err = AppendAndReduce(wm, pushedEvents...); err != nil {
+		return nil, err
+	}
+	return &NotificationDelivery{
+		Attempt:             wm.Attempts,
+		UserID:              wm.UserID,
+		Channel:             wm.Channel,
+		TriggeringEventType: wm.TriggeringEventType,
+		Recipient:           wm.Recipient,
+		Subject:             wm.Subject,
+		Content:             wm.Content,
+		Event:               pushedEvents[0],
+	}, nil
+}
+
+// NotificationSent marks the message as accepted by the provider
+func (c *Commands) NotificationSent(ctx context.Context, id, resourceOwner string, attempt uint16, providerID, providerMessageID string) error {
+	wm, err := c.getSendingNotificationMessageWriteModel(ctx, id, resourceOwner, attempt)
+	if err != nil {
+		return err
+	}
+	aggregate := notification.NewAggregate(id, resourceOwner, authz.GetInstance(ctx).InstanceID())
+	_, err = c.eventstore.Push(ctx, notification.NewSentEvent(ctx, &aggregate.Aggregate, wm.Attempts, providerID, providerMessageID))
+	return err
+}
+
+// RetryNotification schedules the next attempt of a message after a failed one
+func (c *Commands) RetryNotification(ctx context.Context, id, resourceOwner string, attempt uint16, providerID, reason string, nextAttempt time.Time) error {
+	_, err := c.getSendingNotificationMessageWriteModel(ctx, id, resourceOwner, attempt)
+	if err != nil {
+		return err
+	}
+	aggregate := notification.NewAggregate(id, resourceOwner, authz.GetInstance(ctx).InstanceID())
+	_, err = c.eventstore.Push(ctx, notification.NewRetryScheduledEvent(ctx, &aggregate.Aggregate, providerID, reason, nextAttempt))
+	return err
+}
+
+// PostponeNotification schedules the next attempt of a message which was not passed to a provider
+// because the rate limits were exceeded, the attempt is not counted
+func (c *Commands) PostponeNotification(ctx context.Context, id, resourceOwner string, attempt uint16, providerID, reason string, nextAttempt time.Time) error {
+	wm, err := c.getSendingNotificationMessageWriteModel(ctx, id, resourceOwner, attempt)
+	if err != nil {
+		return err
+	}
+	aggregate := notification.NewAggregate(id, resourceOwner, authz.GetInstance(ctx).InstanceID())
+	_, err = c.eventstore.Push(ctx, notification.NewPostponedEvent(ctx, &aggregate.Aggregate, wm.Attempts, providerID, reason, nextAttempt))
+	return err
+}
+
+// NotificationBounced marks the message as permanently rejected by the provider, it is not retried
+func (c *Commands) NotificationBounced(ctx context.Context, id, resourceOwner string, attempt uint16, providerID, reason string) error {
+	wm, err := c.getSendingNotificationMessageWriteModel(ctx, id, resourceOwner, attempt)
+	if err != nil {
+		return err
+	}
+	aggregate := notification.NewAggregate(id, resourceOwner, authz.GetInstance(ctx).InstanceID())
+	_, err = c.eventstore.Push(ctx, notification.NewBouncedEvent(ctx, &aggregate.Aggregate, wm.Attempts, providerID, reason))
+	return err
+}
+
+// NotificationFailed marks the message as undeliverable, it is not retried
+func (c *Commands) NotificationFailed(ctx context.Context, id, resourceOwner string, attempt uint16, providerID, reason string) error {
+	wm, err := c.getSendingNotificationMessageWriteModel(ctx, id, resourceOwner, attempt)
+	if err != nil {
+		return err
+	}
+	aggregate := notification.NewAggregate(id, resourceOwner, authz.GetInstance(ctx).InstanceID())
+	_, err = c.eventstore.Push(ctx, notification.NewFailedEvent(ctx, &aggregate.Aggregate, wm.Attempts, providerID, reason))
+	return err
+}
+
+// getSendingNotificationMessageWriteModel ensures that the result of an attempt is only stored
+// by the worker which started it and as long as no other worker took the message over
+func (c *Commands) getSendingNotificationMessageWriteModel(ctx context.Context, id, resourceOwner string, attempt uint16) (*notificationMessageWriteModel, error) {
+	if id == "" || resourceOwner == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Yoh7i", "Errors.IDMissing")
+	}
+	wm, err := c.getNotificationMessageWriteModel(ctx, id, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !wm.State.Exists() {
+		return nil, errors.ThrowNotFound(nil, "COMMAND-Eeb4s", "Errors.Notification.Message.NotFound")
+	}
+	if wm.State != domain.NotificationMessageStateSending || wm.Attempts != attempt {
+		return nil, errors.ThrowPreconditionFailed(nil, "COMMAND-ke3Ai", "Errors.Notification.Message.NotSending")
+	}
+	return wm, nil
+}
+
+func (c *Commands) getNotificationMessageWriteModel(ctx context.Context, id, resourceOwner string) (*notificationMessageWriteModel, error) {
+	wm := newNotificationMessageWriteModel(id, resourceOwner)
+	return wm, c.eventstore.FilterToQueryReducer(ctx, wm)
+}
@@ -0,0 +1,83 @@
+package command
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/notification"
+)
+
+type notificationMessageWriteModel struct {
+	eventstore.WriteModel
+
+	UserID              string
+	Channel             domain.NotificationType
+	TriggeringEventType eventstore.EventType
+	Recipient           string
+	Subject             string
+	Content             *crypto.CryptoValue
+	State               domain.NotificationMessageState
+	Attempts            uint16
+	NextAttempt         time.Time
+}
+
+func newNotificationMessageWriteModel(id, resourceOwner string) *notificationMessageWriteModel {
+	return &notificationMessageWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   id,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *notificationMessageWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(notification.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			notification.QueuedEventType,
+			notification.DeliveryStartedEventType,
+			notification.RetryScheduledEventType,
+			notification.PostponedEventType,
+			notification.SentEventType,
+			notification.BouncedEventType,
+			notification.FailedEventType,
+		).
+		Builder()
+}
+
+func (wm *notificationMessageWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *notification.QueuedEvent:
+			wm.UserID = e.UserID
+			wm.Channel = e.Channel
+			wm.TriggeringEventType = e.TriggeringEventType
+			wm.Recipient = e.Recipient
+			wm.Subject = e.Subject
+			wm.Content = e.Content
+			wm.State = domain.NotificationMessageStateQueued
+		case *notification.DeliveryStartedEvent:
+			wm.Attempts = e.Attempt
+			wm.State = domain.NotificationMessageStateSending
+		case *notification.RetryScheduledEvent:
+			wm.NextAttempt = e.NextAttempt
+			wm.State = domain.NotificationMessageStateQueued
+		case *notification.PostponedEvent:
+			wm.Attempts = e.Attempt - 1
+			wm.NextAttempt = e.NextAttempt
+			wm.State = domain.NotificationMessageStateQueued
+		case *notification.SentEvent:
+			wm.State = domain.NotificationMessageStateSent
+		case *notification.BouncedEvent:
+			wm.State = domain.NotificationMessageStateBounced
+		case *notification.FailedEvent:
+			wm.State = domain.NotificationMessageStateFailed
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
@@ -0,0 +1,443 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errors "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/notification"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func notificationQueuedEvent() *notification.QueuedEvent {
+	return notification.NewQueuedEvent(context.Background(),
+		&notification.NewAggregate("user:user1:15", "org1", "instance1").Aggregate,
+		"user1",
+		domain.NotificationTypeEmail,
+		user.HumanInitialCodeAddedType,
+		"user@example.com",
+		"Initialize User",
+		&crypto.CryptoValue{
+			CryptoType: crypto.TypeEncryption,
+			Algorithm:  "enc",
+			KeyID:      "id",
+			Crypted:    []byte("content"),
+		},
+	)
+}
+
+func TestCommands_EnqueueNotification(t *testing.T) {
+	type fields struct {
+		eventstore     func(*testing.T) *eventstore.Eventstore
+		userEncryption crypto.EncryptionAlgorithm
+	}
+	type args struct {
+		ctx     context.Context
+		message *NotificationMessage
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		err    func(error) bool
+	}{
+		{
+			name: "missing recipient, error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				message: &NotificationMessage{
+					ID:            "user:user1:15",
+					ResourceOwner: "org1",
+					Channel:       domain.NotificationTypeEmail,
+					Content:       "content",
+				},
+			},
+			err: caos_errors.IsErrorInvalidArgument,
+		},
+		{
+			name: "already queued, ignored",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(notificationQueuedEvent()),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				message: &NotificationMessage{
+					ID:                  "user:user1:15",
+					ResourceOwner:       "org1",
+					UserID:              "user1",
+					Channel:             domain.NotificationTypeEmail,
+					TriggeringEventType: user.HumanInitialCodeAddedType,
+					Recipient:           "user@example.com",
+					Subject:             "Initialize User",
+					Content:             "content",
+				},
+			},
+		},
+		{
+			name: "queue, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectPush(
+						notificationQueuedEvent(),
+					),
+				),
+				userEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				message: &NotificationMessage{
+					ID:                  "user:user1:15",
+					ResourceOwner:       "org1",
+					UserID:              "user1",
+					Channel:             domain.NotificationTypeEmail,
+					TriggeringEventType: user.HumanInitialCodeAddedType,
+					Recipient:           "user@example.com",
+					Subject:             "Initialize User",
+					Content:             "content",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:     tt.fields.eventstore(t),
+				userEncryption: tt.fields.userEncryption,
+			}
+			err := c.EnqueueNotification(tt.args.ctx, tt.args.message)
+			if tt.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.err != nil && !tt.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
+
+func TestCommands_StartNotificationDelivery(t *testing.T) {
+	aggregate := &notification.NewAggregate("user:user1:15", "org1", "instance1").Aggregate
+	type args struct {
+		ctx             context.Context
+		id              string
+		resourceOwner   string
+		deliveryTimeout time.Duration
+	}
+	type res struct {
+		attempt uint16
+		err     func(error) bool
+	}
+	tests := []struct {
+		name       string
+		eventstore func(*testing.T) *eventstore.Eventstore
+		args       args
+		res        res
+	}{
+		{
+			name:       "missing id, error",
+			eventstore: expectEventstore(),
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "not queued, not found error",
+			eventstore: expectEventstore(
+				expectFilter(),
+			),
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				id:            "user:user1:15",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errors.IsNotFound,
+			},
+		},
+		{
+			name: "already sent, precondition error",
+			eventstore: expectEventstore(
+				expectFilter(
+					eventFromEventPusher(notificationQueuedEvent()),
+					eventFromEventPusher(notification.NewDeliveryStartedEvent(context.Background(), aggregate, 1)),
+					eventFromEventPusher(notification.NewSentEvent(context.Background(), aggregate, 1, "smtp1", "<user:user1:15@zitadel>")),
+				),
+			),
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				id:            "user:user1:15",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "retry not due, precondition error",
+			eventstore: expectEventstore(
+				expectFilter(
+					eventFromEventPusher(notificationQueuedEvent()),
+					eventFromEventPusher(notification.NewDeliveryStartedEvent(context.Background(), aggregate, 1)),
+					eventFromEventPusher(notification.NewRetryScheduledEvent(context.Background(), aggregate, "smtp1", "timeout", time.Now().Add(time.Hour))),
+				),
+			),
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				id:            "user:user1:15",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "delivery running, precondition error",
+			eventstore: expectEventstore(
+				expectFilter(
+					eventFromEventPusher(notificationQueuedEvent()),
+					eventFromEventPusherWithCreationDateNow(notification.NewDeliveryStartedEvent(context.Background(), aggregate, 1)),
+				),
+			),
+			args: args{
+				ctx:             authz.WithInstanceID(context.Background(), "instance1"),
+				id:              "user:user1:15",
+				resourceOwner:   "org1",
+				deliveryTimeout: time.Minute,
+			},
+			res: res{
+				err: caos_errors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "first attempt, ok",
+			eventstore: expectEventstore(
+				expectFilter(
+					eventFromEventPusher(notificationQueuedEvent()),
+				),
+				expectPush(
+					notification.NewDeliveryStartedEvent(context.Background(), aggregate, 1),
+				),
+			),
+			args: args{
+				ctx:             authz.WithInstanceID(context.Background(), "instance1"),
+				id:              "user:user1:15",
+				resourceOwner:   "org1",
+				deliveryTimeout: time.Minute,
+			},
+			res: res{
+				attempt: 1,
+			},
+		},
+		{
+			name: "postponed attempt not counted, ok",
+			eventstore: expectEventstore(
+				expectFilter(
+					eventFromEventPusher(notificationQueuedEvent()),
+					eventFromEventPusher(notification.NewDeliveryStartedEvent(context.Background(), aggregate, 1)),
+					eventFromEventPusher(notification.NewPostponedEvent(context.Background(), aggregate, 1, "smtp1", "rate limit exceeded", time.Time{})),
+				),
+				expectPush(
+					notification.NewDeliveryStartedEvent(context.Background(), aggregate, 1),
+				),
+			),
+			args: args{
+				ctx:             authz.WithInstanceID(context.Background(), "instance1"),
+				id:              "user:user1:15",
+				resourceOwner:   "org1",
+				deliveryTimeout: time.Minute,
+			},
+			res: res{
+				attempt: 1,
+			},
+		},
+		{
+			name: "delivery timed out, ok",
+			eventstore: expectEventstore(
+				expectFilter(
+					eventFromEventPusher(notificationQueuedEvent()),
+					eventFromEventPusher(notification.NewDeliveryStartedEvent(context.Background(), aggregate, 1)),
+				),
+				expectPush(
+					notification.NewDeliveryStartedEvent(context.Background(), aggregate, 2),
+				),
+			),
+			args: args{
+				ctx:             authz.WithInstanceID(context.Background(), "instance1"),
+				id:              "user:user1:15",
+				resourceOwner:   "org1",
+				deliveryTimeout: time.Minute,
+			},
+			res: res{
+				attempt: 2,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.eventstore(t),
+			}
+			got, err := c.StartNotificationDelivery(tt.args.ctx, tt.args.id, tt.args.resourceOwner, tt.args.deliveryTimeout)
+			if tt.res.err == nil {
+				if !assert.NoError(t, err) {
+					return
+				}
+				assert.Equal(t, tt.res.attempt, got.Attempt)
+				assert.Equal(t, "user1", got.UserID)
+				assert.Equal(t, domain.NotificationTypeEmail, got.Channel)
+				assert.Equal(t, user.HumanInitialCodeAddedType, got.TriggeringEventType)
+				assert.Equal(t, "user@example.com", got.Recipient)
+				assert.Equal(t, "Initialize User", got.Subject)
+				assert.Equal(t, []byte("content"), got.Content.Crypted)
+				assert.Equal(t, notification.DeliveryStartedEventType, got.Event.Type())
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
+
+func TestCommands_NotificationSent(t *testing.T) {
+	aggregate := &notification.NewAggregate("user:user1:15", "org1", "instance1").Aggregate
+	type args struct {
+		ctx     context.Context
+		attempt uint16
+	}
+	tests := []struct {
+		name       string
+		eventstore func(*testing.T) *eventstore.Eventstore
+		args       args
+		err        func(error) bool
+	}{
+		{
+			name: "not queued, not found error",
+			eventstore: expectEventstore(
+				expectFilter(),
+			),
+			args: args{
+				ctx:     authz.WithInstanceID(context.Background(), "instance1"),
+				attempt: 1,
+			},
+			err: caos_errors.IsNotFound,
+		},
+		{
+			name: "not sending, precondition error",
+			eventstore: expectEventstore(
+				expectFilter(
+					eventFromEventPusher(notificationQueuedEvent()),
+				),
+			),
+			args: args{
+				ctx:     authz.WithInstanceID(context.Background(), "instance1"),
+				attempt: 1,
+			},
+			err: caos_errors.IsPreconditionFailed,
+		},
+		{
+			name: "attempt taken over, precondition error",
+			eventstore: expectEventstore(
+				expectFilter(
+					eventFromEventPusher(notificationQueuedEvent()),
+					eventFromEventPusher(notification.NewDeliveryStartedEvent(context.Background(), aggregate, 1)),
+					eventFromEventPusher(notification.NewDeliveryStartedEvent(context.Background(), aggregate, 2)),
+				),
+			),
+			args: args{
+				ctx:     authz.WithInstanceID(context.Background(), "instance1"),
+				attempt: 1,
+			},
+			err: caos_errors.IsPreconditionFailed,
+		},
+		{
+			name: "sent, ok",
+			eventstore: expectEventstore(
+				expectFilter(
+					eventFromEventPusher(notificationQueuedEvent()),
+					eventFromEventPusher(notification.NewDeliveryStartedEvent(context.Background(), aggregate, 1)),
+					eventFromEventPusher(notification.NewRetryScheduledEvent(context.Background(), aggregate, "smtp1", "timeout", time.Time{})),
+					eventFromEventPusher(notification.NewDeliveryStartedEvent(context.Background(), aggregate, 2)),
+				),
+				expectPush(
+					notification.NewSentEvent(context.Background(), aggregate, 2, "smtp2", "<user:user1:15@zitadel>"),
+				),
+			),
+			args: args{
+				ctx:     authz.WithInstanceID(context.Background(), "instance1"),
+				attempt: 2,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.eventstore(t),
+			}
+			err := c.NotificationSent(tt.args.ctx, "user:user1:15", "org1", tt.args.attempt, "smtp2", "<user:user1:15@zitadel>")
+			if tt.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.err != nil && !tt.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
+
+func TestCommands_RetryNotification(t *testing.T) {
+	aggregate := &notification.NewAggregate("user:user1:15", "org1", "instance1").Aggregate
+	nextAttempt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := &Commands{
+		eventstore: expectEventstore(
+			expectFilter(
+				eventFromEventPusher(notificationQueuedEvent()),
+				eventFromEventPusher(notification.NewDeliveryStartedEvent(context.Background(), aggregate, 1)),
+			),
+			expectPush(
+				notification.NewRetryScheduledEvent(context.Background(), aggregate, "smtp1", "connection refused", nextAttempt),
+			),
+		)(t),
+	}
+	err := c.RetryNotification(authz.WithInstanceID(context.Background(), "instance1"), "user:user1:15", "org1", 1, "smtp1", "connection refused", nextAttempt)
+	assert.NoError(t, err)
+}
+
+func TestCommands_PostponeNotification(t *testing.T) {
+	aggregate := &notification.NewAggregate("user:user1:15", "org1", "instance1").Aggregate
+	nextAttempt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := &Commands{
+		eventstore: expectEventstore(
+			expectFilter(
+				eventFromEventPusher(notificationQueuedEvent()),
+				eventFromEventPusher(notification.NewDeliveryStartedEvent(context.Background(), aggregate, 1)),
+				eventFromEventPusher(notification.NewRetryScheduledEvent(context.Background(), aggregate, "smtp1", "connection refused", time.Time{})),
+				eventFromEventPusher(notification.NewDeliveryStartedEvent(context.Background(), aggregate, 2)),
+			),
+			expectPush(
+				notification.NewPostponedEvent(context.Background(), aggregate, 2, "smtp1", "rate limit exceeded", nextAttempt),
+			),
+		)(t),
+	}
+	err := c.PostponeNotification(authz.WithInstanceID(context.Background(), "instance1"), "user:user1:15", "org1", 2, "smtp1", "rate limit exceeded", nextAttempt)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,163 @@
+package command
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/settings"
+)
+
+// SetDefaultNotificationProviderOrder sets the providers used to send notifications of the channel
+// for all organizations without an own order.
+// Passing no providers resets the order, so all active providers are used in the order they were added.
+func (c *Commands) SetDefaultNotificationProviderOrder(ctx context.Context, channel domain.NotificationType, providerIDs []string) (*domain.ObjectDetails, error) {
+	instanceAgg := instance.NewAggregate(authz.GetInstance(ctx).InstanceID())
+	writeModel, err := c.getNotificationProviderOrderWriteModel(ctx, "", channel, providerIDs)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Equal(writeModel.ProviderIDs, providerIDs) {
+		return writeModelToObjectDetails(&writeModel.WriteModel), nil
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, instance.NewNotificationProviderOrderSetEvent(ctx, &instanceAgg.Aggregate, channel, providerIDs))
+	if err != nil {
+		return nil, err
+	}
+	return pushedEventsToObjectDetails(pushedEvents), nil
+}
+
+// EmailSender is used instead of the sender of the SMTP providers to send emails to the users of an organization
+type EmailSender struct {
+	Address        string
+	Name           string
+	ReplyToAddress string
+}
+
+// SetNotificationProviderOrder overrides the providers of the instance used to send notifications of the channel
+// to users of the organization.
+// The email sender overrides the sender of the providers, so the organization can send from its own domain.
+func (c *Commands) SetNotificationProviderOrder(ctx context.Context, orgID string, channel domain.NotificationType, providerIDs []string, emailSender *EmailSender) (*domain.ObjectDetails, error) {
+	if orgID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ux7dq", "Errors.ResourceOwnerMissing")
+	}
+	if len(providerIDs) == 0 {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Kr2vb", "Errors.NotificationProviderOrder.ProvidersMissing")
+	}
+	orgAgg := org.NewAggregate(orgID)
+	writeModel, err := c.getNotificationProviderOrderWriteModel(ctx, orgID, channel, providerIDs)
+	if err != nil {
+		return nil, err
+	}
+	sender, err := c.checkEmailSender(ctx, orgID, channel, emailSender)
+	if err != nil {
+		return nil, err
+	}
+	if writeModel.State == domain.NotificationProviderStateActive &&
+		slices.Equal(writeModel.ProviderIDs, providerIDs) &&
+		equalEmailSender(writeModel.EmailSender, sender) {
+		return writeModelToObjectDetails(&writeModel.WriteModel), nil
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewNotificationProviderOrderSetEvent(ctx, &orgAgg.Aggregate, channel, providerIDs, sender))
+	if err != nil {
+		return nil, err
+	}
+	return pushedEventsToObjectDetails(pushedEvents), nil
+}
+
+// RemoveNotificationProviderOrder removes the override of the organization,
+// so the order of the instance is used again.
+func (c *Commands) RemoveNotificationProviderOrder(ctx context.Context, orgID string, channel domain.NotificationType) (*domain.ObjectDetails, error) {
+	if orgID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Zp3wm", "Errors.ResourceOwnerMissing")
+	}
+	if !channel.Valid() {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Hd8ex", "Errors.NotificationProviderOrder.InvalidChannel")
+	}
+	orgAgg := org.NewAggregate(orgID)
+	writeModel := NewNotificationProviderOrderWriteModel(authz.GetInstance(ctx).InstanceID(), orgID, channel)
+	if err := c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	if writeModel.State != domain.NotificationProviderStateActive {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Tn5qa", "Errors.NotificationProviderOrder.NotFound")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewNotificationProviderOrderRemovedEvent(ctx, &orgAgg.Aggregate, channel))
+	if err != nil {
+		return nil, err
+	}
+	return pushedEventsToObjectDetails(pushedEvents), nil
+}
+
+// getNotificationProviderOrderWriteModel validates the providers against the providers of the instance
+// and returns the current order of the instance (orgID is empty) or organization
+func (c *Commands) getNotificationProviderOrderWriteModel(ctx context.Context, orgID string, channel domain.NotificationType, providerIDs []string) (*NotificationProviderOrderWriteModel, error) {
+	if !channel.Valid() {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Wb6ko", "Errors.NotificationProviderOrder.InvalidChannel")
+	}
+	for i, id := range providerIDs {
+		if id == "" {
+			return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Qs4nf", "Errors.IDMissing")
+		}
+		if slices.Contains(providerIDs[:i], id) {
+			return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ej7rc", "Errors.NotificationProviderOrder.DuplicateProvider")
+		}
+	}
+	writeModel := NewNotificationProviderOrderWriteModel(authz.GetInstance(ctx).InstanceID(), orgID, channel)
+	if err := c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	for _, id := range providerIDs {
+		if !writeModel.ProviderExists(id) {
+			return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Mv9ug", "Errors.NotificationProviderOrder.ProviderNotFound")
+		}
+	}
+	return writeModel, nil
+}
+
+// checkEmailSender validates the email sender of the organization.
+// The domain of the address must be a verified domain of the organization,
+// so the organization can't send emails on behalf of other domains.
+func (c *Commands) checkEmailSender(ctx context.Context, orgID string, channel domain.NotificationType, emailSender *EmailSender) (*settings.EmailSender, error) {
+	if emailSender == nil {
+		return nil, nil
+	}
+	if channel != domain.NotificationTypeEmail {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Fe6ob", "Errors.NotificationProviderOrder.EmailSenderInvalidChannel")
+	}
+	address := domain.EmailAddress(emailSender.Address).Normalize()
+	if err := address.Validate(); err != nil {
+		return nil, err
+	}
+	replyTo := domain.EmailAddress(emailSender.ReplyToAddress).Normalize()
+	if replyTo != "" {
+		if err := replyTo.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	_, senderDomain, _ := strings.Cut(string(address), "@")
+	domainWriteModel := NewOrgDomainWriteModel(orgID, strings.ToLower(senderDomain))
+	if err := c.eventstore.FilterToQueryReducer(ctx, domainWriteModel); err != nil {
+		return nil, err
+	}
+	if domainWriteModel.State != domain.OrgDomainStateActive || !domainWriteModel.Verified {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ri3vu", "Errors.NotificationProviderOrder.EmailSenderDomainNotVerified")
+	}
+	return &settings.EmailSender{
+		Address:        string(address),
+		Name:           emailSender.Name,
+		ReplyToAddress: string(replyTo),
+	}, nil
+}
+
+func equalEmailSender(a, b *settings.EmailSender) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,138 @@
+package command
+
+import (
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/settings"
+)
+
+// NotificationProviderOrderWriteModel reduces the provider order of a channel
+// set on the instance (orgID is empty) or on an organization.
+// It also keeps track of the providers of the instance, so the order can be validated.
+type NotificationProviderOrderWriteModel struct {
+	eventstore.WriteModel
+
+	Channel     domain.NotificationType
+	ProviderIDs []string
+	EmailSender *settings.EmailSender
+	State       domain.NotificationProviderState
+
+	instanceID string
+	orgID      string
+	providers  map[domain.NotificationType][]string
+}
+
+func NewNotificationProviderOrderWriteModel(instanceID, orgID string, channel domain.NotificationType) *NotificationProviderOrderWriteModel {
+	aggregateID := instanceID
+	if orgID != "" {
+		aggregateID = orgID
+	}
+	return &NotificationProviderOrderWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   aggregateID,
+			ResourceOwner: aggregateID,
+			InstanceID:    instanceID,
+		},
+		Channel:    channel,
+		instanceID: instanceID,
+		orgID:      orgID,
+		providers:  make(map[domain.NotificationType][]string),
+	}
+}
+
+func (wm *NotificationProviderOrderWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *instance.SMTPConfigAddedEvent:
+			wm.addProvider(domain.NotificationTypeEmail, e.ID)
+		case *instance.SMTPConfigRemovedEvent:
+			wm.removeProvider(domain.NotificationTypeEmail, e.ID)
+		case *instance.SMSConfigTwilioAddedEvent:
+			wm.addProvider(domain.NotificationTypeSms, e.ID)
+		case *instance.SMSConfigHTTPAddedEvent:
+			wm.addProvider(domain.NotificationTypeSms, e.ID)
+		case *instance.SMSConfigRemovedEvent:
+			wm.removeProvider(domain.NotificationTypeSms, e.ID)
+		case *instance.NotificationProviderOrderSetEvent:
+			if wm.orgID != "" || e.Channel != wm.Channel {
+				continue
+			}
+			wm.WriteModel.AppendEvents(&e.NotificationProviderOrderSetEvent)
+		case *org.NotificationProviderOrderSetEvent:
+			if e.Channel != wm.Channel {
+				continue
+			}
+			wm.WriteModel.AppendEvents(&e.NotificationProviderOrderSetEvent)
+		case *org.NotificationProviderOrderRemovedEvent:
+			if e.Channel != wm.Channel {
+				continue
+			}
+			wm.WriteModel.AppendEvents(&e.NotificationProviderOrderRemovedEvent)
+		}
+	}
+}
+
+func (wm *NotificationProviderOrderWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *settings.NotificationProviderOrderSetEvent:
+			wm.ProviderIDs = e.ProviderIDs
+			wm.EmailSender = e.EmailSender
+			wm.State = domain.NotificationProviderStateActive
+		case *settings.NotificationProviderOrderRemovedEvent:
+			wm.ProviderIDs = nil
+			wm.EmailSender = nil
+			wm.State = domain.NotificationProviderStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *NotificationProviderOrderWriteModel) Query() *eventstore.SearchQueryBuilder {
+	providerEventTypes := []eventstore.EventType{
+		instance.SMTPConfigAddedEventType,
+		instance.SMTPConfigRemovedEventType,
+		instance.SMSConfigTwilioAddedEventType,
+		instance.SMSConfigHTTPAddedEventType,
+		instance.SMSConfigRemovedEventType,
+	}
+	if wm.orgID == "" {
+		return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+			AddQuery().
+			AggregateTypes(instance.AggregateType).
+			AggregateIDs(wm.instanceID).
+			EventTypes(append(providerEventTypes, instance.NotificationProviderOrderSetEventType)...).
+			Builder()
+	}
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(wm.instanceID).
+		EventTypes(providerEventTypes...).
+		Or().
+		AggregateTypes(org.AggregateType).
+		AggregateIDs(wm.orgID).
+		EventTypes(
+			org.NotificationProviderOrderSetEventType,
+			org.NotificationProviderOrderRemovedEventType).
+		Builder()
+}
+
+// ProviderExists checks if the instance has a provider with the id for the channel of the write model
+func (wm *NotificationProviderOrderWriteModel) ProviderExists(id string) bool {
+	return slices.Contains(wm.providers[wm.Channel], id)
+}
+
+func (wm *NotificationProviderOrderWriteModel) addProvider(channel domain.NotificationType, id string) {
+	wm.providers[channel] = append(wm.providers[channel], id)
+}
+
+func (wm *NotificationProviderOrderWriteModel) removeProvider(channel domain.NotificationType, id string) {
+	wm.providers[channel] = slices.DeleteFunc(wm.providers[channel], func(providerID string) bool {
+		return providerID == id
+	})
+}
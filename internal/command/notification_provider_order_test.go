@@ -0,0 +1,580 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/settings"
+)
+
+func TestCommandSide_SetDefaultNotificationProviderOrder(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx         context.Context
+		channel     domain.NotificationType
+		providerIDs []string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "invalid channel, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:         authz.WithInstanceID(context.Background(), "INSTANCE"),
+				channel:     domain.NotificationType(5),
+				providerIDs: []string{"smtp1"},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "duplicate provider, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:         authz.WithInstanceID(context.Background(), "INSTANCE"),
+				channel:     domain.NotificationTypeEmail,
+				providerIDs: []string{"smtp1", "smtp1"},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "provider of other channel, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSMSConfigTwilioAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"sms1",
+								"sid",
+								"senderName",
+								&crypto.CryptoValue{},
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:         authz.WithInstanceID(context.Background(), "INSTANCE"),
+				channel:     domain.NotificationTypeEmail,
+				providerIDs: []string{"sms1"},
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "removed provider, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							newSMTPConfigAddedEvent("smtp1"),
+						),
+						eventFromEventPusher(
+							instance.NewSMTPConfigRemovedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"smtp1",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:         authz.WithInstanceID(context.Background(), "INSTANCE"),
+				channel:     domain.NotificationTypeEmail,
+				providerIDs: []string{"smtp1"},
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "set order, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							newSMTPConfigAddedEvent("smtp1"),
+						),
+						eventFromEventPusher(
+							newSMTPConfigAddedEvent("smtp2"),
+						),
+					),
+					expectPush(
+						instance.NewNotificationProviderOrderSetEvent(context.Background(),
+							&instance.NewAggregate("INSTANCE").Aggregate,
+							domain.NotificationTypeEmail,
+							[]string{"smtp2", "smtp1"},
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:         authz.WithInstanceID(context.Background(), "INSTANCE"),
+				channel:     domain.NotificationTypeEmail,
+				providerIDs: []string{"smtp2", "smtp1"},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+		{
+			name: "order unchanged, no event",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							newSMTPConfigAddedEvent("smtp1"),
+						),
+						eventFromEventPusher(
+							instance.NewNotificationProviderOrderSetEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								domain.NotificationTypeEmail,
+								[]string{"smtp1"},
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:         authz.WithInstanceID(context.Background(), "INSTANCE"),
+				channel:     domain.NotificationTypeEmail,
+				providerIDs: []string{"smtp1"},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.SetDefaultNotificationProviderOrder(tt.args.ctx, tt.args.channel, tt.args.providerIDs)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_SetNotificationProviderOrder(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx         context.Context
+		orgID       string
+		channel     domain.NotificationType
+		providerIDs []string
+		emailSender *EmailSender
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "org id missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:         authz.WithInstanceID(context.Background(), "INSTANCE"),
+				channel:     domain.NotificationTypeSms,
+				providerIDs: []string{"sms1"},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "providers missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:     authz.WithInstanceID(context.Background(), "INSTANCE"),
+				orgID:   "org1",
+				channel: domain.NotificationTypeSms,
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "provider not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:         authz.WithInstanceID(context.Background(), "INSTANCE"),
+				orgID:       "org1",
+				channel:     domain.NotificationTypeSms,
+				providerIDs: []string{"sms1"},
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "set order, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSMSConfigTwilioAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"sms1",
+								"sid",
+								"senderName",
+								&crypto.CryptoValue{},
+							),
+						),
+						eventFromEventPusher(
+							instance.NewNotificationProviderOrderSetEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								domain.NotificationTypeSms,
+								[]string{"sms1"},
+							),
+						),
+					),
+					expectPush(
+						org.NewNotificationProviderOrderSetEvent(context.Background(),
+							&org.NewAggregate("org1").Aggregate,
+							domain.NotificationTypeSms,
+							[]string{"sms1"},
+							nil,
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:         authz.WithInstanceID(context.Background(), "INSTANCE"),
+				orgID:       "org1",
+				channel:     domain.NotificationTypeSms,
+				providerIDs: []string{"sms1"},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			name: "email sender for sms, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSMSConfigTwilioAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"sms1",
+								"sid",
+								"senderName",
+								&crypto.CryptoValue{},
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:         authz.WithInstanceID(context.Background(), "INSTANCE"),
+				orgID:       "org1",
+				channel:     domain.NotificationTypeSms,
+				providerIDs: []string{"sms1"},
+				emailSender: &EmailSender{Address: "noreply@org.ch"},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "email sender domain not verified, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							newSMTPConfigAddedEvent("smtp1"),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewDomainAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"org.ch",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:         authz.WithInstanceID(context.Background(), "INSTANCE"),
+				orgID:       "org1",
+				channel:     domain.NotificationTypeEmail,
+				providerIDs: []string{"smtp1"},
+				emailSender: &EmailSender{Address: "noreply@org.ch"},
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "set order with email sender, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							newSMTPConfigAddedEvent("smtp1"),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewDomainAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"org.ch",
+							),
+						),
+						eventFromEventPusher(
+							org.NewDomainVerifiedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"org.ch",
+							),
+						),
+					),
+					expectPush(
+						org.NewNotificationProviderOrderSetEvent(context.Background(),
+							&org.NewAggregate("org1").Aggregate,
+							domain.NotificationTypeEmail,
+							[]string{"smtp1"},
+							&settings.EmailSender{
+								Address:        "noreply@Org.ch",
+								Name:           "Org",
+								ReplyToAddress: "support@org.ch",
+							},
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:         authz.WithInstanceID(context.Background(), "INSTANCE"),
+				orgID:       "org1",
+				channel:     domain.NotificationTypeEmail,
+				providerIDs: []string{"smtp1"},
+				emailSender: &EmailSender{
+					Address:        "noreply@Org.ch",
+					Name:           "Org",
+					ReplyToAddress: "support@org.ch",
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.SetNotificationProviderOrder(tt.args.ctx, tt.args.orgID, tt.args.channel, tt.args.providerIDs, tt.args.emailSender)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_RemoveNotificationProviderOrder(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx     context.Context
+		orgID   string
+		channel domain.NotificationType
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "org id missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:     authz.WithInstanceID(context.Background(), "INSTANCE"),
+				channel: domain.NotificationTypeEmail,
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "order not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							newSMTPConfigAddedEvent("smtp1"),
+						),
+						eventFromEventPusher(
+							org.NewNotificationProviderOrderSetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								domain.NotificationTypeSms,
+								[]string{"sms1"},
+								nil,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:     authz.WithInstanceID(context.Background(), "INSTANCE"),
+				orgID:   "org1",
+				channel: domain.NotificationTypeEmail,
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "remove order, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							newSMTPConfigAddedEvent("smtp1"),
+						),
+						eventFromEventPusher(
+							org.NewNotificationProviderOrderSetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								domain.NotificationTypeEmail,
+								[]string{"smtp1"},
+								nil,
+							),
+						),
+					),
+					expectPush(
+						org.NewNotificationProviderOrderRemovedEvent(context.Background(),
+							&org.NewAggregate("org1").Aggregate,
+							domain.NotificationTypeEmail,
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:     authz.WithInstanceID(context.Background(), "INSTANCE"),
+				orgID:   "org1",
+				channel: domain.NotificationTypeEmail,
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.RemoveNotificationProviderOrder(tt.args.ctx, tt.args.orgID, tt.args.channel)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func newSMTPConfigAddedEvent(id string) *instance.SMTPConfigAddedEvent {
+	return instance.NewSMTPConfigAddedEvent(context.Background(),
+		&instance.NewAggregate("INSTANCE").Aggregate,
+		id,
+		true,
+		"from@domain.ch",
+		"name",
+		"",
+		"host:587",
+		"user",
+		&crypto.CryptoValue{},
+	)
+}
@@ -181,7 +181,7 @@ func TestCommands_AddOIDCSessionAccessToken(t *testing.T) {
 						),
 						eventFromEventPusher(
 							session.NewPasswordCheckedEvent(context.Background(), &session.NewAggregate("sessionID", "instanceID").Aggregate,
-								testNow),
+								"userID", "org1", testNow, false),
 						),
 					),
 					expectFilter(
@@ -382,7 +382,7 @@ func TestCommands_AddOIDCSessionRefreshAndAccessToken(t *testing.T) {
 						),
 						eventFromEventPusher(
 							session.NewPasswordCheckedEvent(context.Background(), &session.NewAggregate("sessionID", "instanceID").Aggregate,
-								testNow),
+								"userID", "org1", testNow, false),
 						),
 					),
 					expectFilter(
@@ -79,7 +79,8 @@ func (c *Commands) ChangeAction(ctx context.Context, actionChange *domain.Action
 		actionChange.Name,
 		actionChange.Script,
 		actionChange.Timeout,
-		actionChange.AllowedToFail)
+		actionChange.AllowedToFail,
+		actionChange.CanaryPercentage)
 	if err != nil {
 		return nil, err
 	}
@@ -94,6 +95,76 @@ func (c *Commands) ChangeAction(ctx context.Context, actionChange *domain.Action
 	return writeModelToObjectDetails(&existingAction.WriteModel), nil
 }
 
+// ActivateActionVersion sets the passed version as current version of the action.
+// It's used to roll back to a previous version or to promote the running canary.
+func (c *Commands) ActivateActionVersion(ctx context.Context, actionID string, version uint64, resourceOwner string) (*domain.ObjectDetails, error) {
+	if actionID == "" || resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Gk3ws", "Errors.IDMissing")
+	}
+
+	existingAction, err := c.getActionWriteModelByID(ctx, actionID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !existingAction.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Vb3ua", "Errors.Action.NotFound")
+	}
+	activate := existingAction.version(version)
+	if activate == nil {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Ps9wq", "Errors.Action.Version.NotFound")
+	}
+	if existingAction.Version == version && existingAction.Canary == nil {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Kd82m", "Errors.Action.Version.AlreadyActive")
+	}
+
+	actionAgg := ActionAggregateFromWriteModel(&existingAction.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, action.NewVersionActivatedEvent(
+		ctx,
+		actionAgg,
+		activate.Version,
+		activate.Script,
+		activate.Timeout,
+		activate.AllowedToFail,
+	))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingAction, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingAction.WriteModel), nil
+}
+
+// RemoveActionCanary stops the running canary of the action, the current version stays active
+func (c *Commands) RemoveActionCanary(ctx context.Context, actionID string, resourceOwner string) (*domain.ObjectDetails, error) {
+	if actionID == "" || resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ha2nc", "Errors.IDMissing")
+	}
+
+	existingAction, err := c.getActionWriteModelByID(ctx, actionID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !existingAction.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Lw7xe", "Errors.Action.NotFound")
+	}
+	if existingAction.Canary == nil {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ue8cs", "Errors.Action.Canary.NotExisting")
+	}
+
+	actionAgg := ActionAggregateFromWriteModel(&existingAction.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, action.NewCanaryRemovedEvent(ctx, actionAgg))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingAction, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingAction.WriteModel), nil
+}
+
 func (c *Commands) DeactivateAction(ctx context.Context, actionID string, resourceOwner string) (*domain.ObjectDetails, error) {
 	if actionID == "" || resourceOwner == "" {
 		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-DAhk5", "Errors.IDMissing")
@@ -17,6 +17,10 @@ type ActionWriteModel struct {
 	Timeout       time.Duration
 	AllowedToFail bool
 	State         domain.ActionState
+	// Version is the current version
+	Version  uint64
+	Versions []*domain.ActionVersion
+	Canary   *domain.ActionCanary
 }
 
 func NewActionWriteModel(actionID string, resourceOwner string) *ActionWriteModel {
@@ -37,10 +41,25 @@ func (wm *ActionWriteModel) Reduce() error {
 			wm.Timeout = e.Timeout
 			wm.AllowedToFail = e.AllowedToFail
 			wm.State = domain.ActionStateActive
+			wm.addVersion(e.Sequence(), e.CreatedAt())
 		case *action.ChangedEvent:
 			if e.Name != nil {
 				wm.Name = *e.Name
 			}
+			if e.CanaryPercentage != nil {
+				wm.Versions = append(wm.Versions, &domain.ActionVersion{
+					Version:       e.Sequence(),
+					CreationDate:  e.CreatedAt(),
+					Script:        *e.Script,
+					Timeout:       *e.Timeout,
+					AllowedToFail: *e.AllowedToFail,
+				})
+				wm.Canary = &domain.ActionCanary{
+					Version:    e.Sequence(),
+					Percentage: *e.CanaryPercentage,
+				}
+				continue
+			}
 			if e.Script != nil {
 				wm.Script = *e.Script
 			}
@@ -50,6 +69,17 @@ func (wm *ActionWriteModel) Reduce() error {
 			if e.AllowedToFail != nil {
 				wm.AllowedToFail = *e.AllowedToFail
 			}
+			if e.CreatesVersion() {
+				wm.addVersion(e.Sequence(), e.CreatedAt())
+			}
+		case *action.VersionActivatedEvent:
+			wm.Script = e.Script
+			wm.Timeout = e.Timeout
+			wm.AllowedToFail = e.AllowedToFail
+			wm.Version = e.Version
+			wm.Canary = nil
+		case *action.CanaryRemovedEvent:
+			wm.Canary = nil
 		case *action.DeactivatedEvent:
 			wm.State = domain.ActionStateInactive
 		case *action.ReactivatedEvent:
@@ -71,10 +101,33 @@ func (wm *ActionWriteModel) Query() *eventstore.SearchQueryBuilder {
 			action.ChangedEventType,
 			action.DeactivatedEventType,
 			action.ReactivatedEventType,
-			action.RemovedEventType).
+			action.RemovedEventType,
+			action.VersionActivatedEventType,
+			action.CanaryRemovedEventType).
 		Builder()
 }
 
+// addVersion adds the current content as version and sets it as current version
+func (wm *ActionWriteModel) addVersion(version uint64, creationDate time.Time) {
+	wm.Versions = append(wm.Versions, &domain.ActionVersion{
+		Version:       version,
+		CreationDate:  creationDate,
+		Script:        wm.Script,
+		Timeout:       wm.Timeout,
+		AllowedToFail: wm.AllowedToFail,
+	})
+	wm.Version = version
+}
+
+func (wm *ActionWriteModel) version(version uint64) *domain.ActionVersion {
+	for _, v := range wm.Versions {
+		if v.Version == version {
+			return v
+		}
+	}
+	return nil
+}
+
 func (wm *ActionWriteModel) NewChangedEvent(
 	ctx context.Context,
 	agg *eventstore.Aggregate,
@@ -82,11 +135,18 @@ func (wm *ActionWriteModel) NewChangedEvent(
 	script string,
 	timeout time.Duration,
 	allowedToFail bool,
+	canaryPercentage uint32,
 ) (*action.ChangedEvent, error) {
 	changes := make([]action.ActionChanges, 0)
 	if wm.Name != name {
 		changes = append(changes, action.ChangeName(name, wm.Name))
 	}
+	if canaryPercentage > 0 {
+		if wm.Script != script || wm.Timeout != timeout || wm.AllowedToFail != allowedToFail {
+			changes = append(changes, action.ChangeCanary(canaryPercentage, script, timeout, allowedToFail))
+		}
+		return action.NewChangedEvent(ctx, agg, changes)
+	}
 	if wm.Script != script {
 		changes = append(changes, action.ChangeScript(script))
 	}
@@ -178,7 +238,7 @@ func (wm *ActionsListByOrgModel) Reduce() error {
 			wm.Actions[e.Aggregate().ID] = &ActionWriteModel{
 				WriteModel: eventstore.WriteModel{
 					AggregateID: e.Aggregate().ID,
-					ChangeDate:  e.CreationDate(),
+					ChangeDate:  e.CreatedAt(),
 				},
 				Name:  e.Name,
 				State: domain.ActionStateActive,
@@ -316,6 +316,52 @@ func TestCommands_ChangeAction(t *testing.T) {
 				},
 			},
 		},
+		{
+			"stage canary ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							action.NewAddedEvent(context.Background(),
+								&action.NewAggregate("id1", "org1").Aggregate,
+								"name",
+								"name() {};",
+								0,
+								false,
+							),
+						),
+					),
+					expectPush(
+						func() *action.ChangedEvent {
+							event, _ := action.NewChangedEvent(context.Background(),
+								&action.NewAggregate("id1", "org1").Aggregate,
+								[]action.ActionChanges{
+									action.ChangeCanary(10, "name2() {};", 0, false),
+								},
+							)
+							return event
+						}(),
+					),
+				),
+			},
+			args{
+				ctx: context.Background(),
+				changeAction: &domain.Action{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "id1",
+					},
+					Name:             "name",
+					Script:           "name2() {};",
+					CanaryPercentage: 10,
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -336,6 +382,375 @@ func TestCommands_ChangeAction(t *testing.T) {
 	}
 }
 
+func TestCommands_ActivateActionVersion(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		actionID      string
+		version       uint64
+		resourceOwner string
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"id missing, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx:           context.Background(),
+				actionID:      "",
+				version:       1,
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"not found, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				actionID:      "id1",
+				version:       1,
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsNotFound,
+			},
+		},
+		{
+			"version not found, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventWithSequence(1, eventFromEventPusher(
+							action.NewAddedEvent(context.Background(),
+								&action.NewAggregate("id1", "org1").Aggregate,
+								"name",
+								"name() {};",
+								0,
+								false,
+							),
+						)),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				actionID:      "id1",
+				version:       3,
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsNotFound,
+			},
+		},
+		{
+			"version already active, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventWithSequence(1, eventFromEventPusher(
+							action.NewAddedEvent(context.Background(),
+								&action.NewAggregate("id1", "org1").Aggregate,
+								"name",
+								"name() {};",
+								0,
+								false,
+							),
+						)),
+						eventWithSequence(2, eventFromEventPusher(
+							func() *action.ChangedEvent {
+								event, _ := action.NewChangedEvent(context.Background(),
+									&action.NewAggregate("id1", "org1").Aggregate,
+									[]action.ActionChanges{
+										action.ChangeScript("name2() {};"),
+									},
+								)
+								return event
+							}(),
+						)),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				actionID:      "id1",
+				version:       2,
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsPreconditionFailed,
+			},
+		},
+		{
+			"rollback ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventWithSequence(1, eventFromEventPusher(
+							action.NewAddedEvent(context.Background(),
+								&action.NewAggregate("id1", "org1").Aggregate,
+								"name",
+								"name() {};",
+								0,
+								false,
+							),
+						)),
+						eventWithSequence(2, eventFromEventPusher(
+							func() *action.ChangedEvent {
+								event, _ := action.NewChangedEvent(context.Background(),
+									&action.NewAggregate("id1", "org1").Aggregate,
+									[]action.ActionChanges{
+										action.ChangeScript("name2() {};"),
+									},
+								)
+								return event
+							}(),
+						)),
+					),
+					expectPush(
+						action.NewVersionActivatedEvent(context.Background(),
+							&action.NewAggregate("id1", "org1").Aggregate,
+							1,
+							"name() {};",
+							0,
+							false,
+						),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				actionID:      "id1",
+				version:       1,
+				resourceOwner: "org1",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			"promote canary ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventWithSequence(1, eventFromEventPusher(
+							action.NewAddedEvent(context.Background(),
+								&action.NewAggregate("id1", "org1").Aggregate,
+								"name",
+								"name() {};",
+								0,
+								false,
+							),
+						)),
+						eventWithSequence(2, eventFromEventPusher(
+							func() *action.ChangedEvent {
+								event, _ := action.NewChangedEvent(context.Background(),
+									&action.NewAggregate("id1", "org1").Aggregate,
+									[]action.ActionChanges{
+										action.ChangeCanary(10, "name2() {};", 0, false),
+									},
+								)
+								return event
+							}(),
+						)),
+					),
+					expectPush(
+						action.NewVersionActivatedEvent(context.Background(),
+							&action.NewAggregate("id1", "org1").Aggregate,
+							2,
+							"name2() {};",
+							0,
+							false,
+						),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				actionID:      "id1",
+				version:       2,
+				resourceOwner: "org1",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			details, err := c.ActivateActionVersion(tt.args.ctx, tt.args.actionID, tt.args.version, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_RemoveActionCanary(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		actionID      string
+		resourceOwner string
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"id missing, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx:           context.Background(),
+				actionID:      "",
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"no canary, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventWithSequence(1, eventFromEventPusher(
+							action.NewAddedEvent(context.Background(),
+								&action.NewAggregate("id1", "org1").Aggregate,
+								"name",
+								"name() {};",
+								0,
+								false,
+							),
+						)),
+						eventWithSequence(2, eventFromEventPusher(
+							func() *action.ChangedEvent {
+								event, _ := action.NewChangedEvent(context.Background(),
+									&action.NewAggregate("id1", "org1").Aggregate,
+									[]action.ActionChanges{
+										action.ChangeScript("name2() {};"),
+									},
+								)
+								return event
+							}(),
+						)),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				actionID:      "id1",
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsPreconditionFailed,
+			},
+		},
+		{
+			"remove ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventWithSequence(1, eventFromEventPusher(
+							action.NewAddedEvent(context.Background(),
+								&action.NewAggregate("id1", "org1").Aggregate,
+								"name",
+								"name() {};",
+								0,
+								false,
+							),
+						)),
+						eventWithSequence(2, eventFromEventPusher(
+							func() *action.ChangedEvent {
+								event, _ := action.NewChangedEvent(context.Background(),
+									&action.NewAggregate("id1", "org1").Aggregate,
+									[]action.ActionChanges{
+										action.ChangeCanary(10, "name2() {};", 0, false),
+									},
+								)
+								return event
+							}(),
+						)),
+					),
+					expectPush(
+						action.NewCanaryRemovedEvent(context.Background(),
+							&action.NewAggregate("id1", "org1").Aggregate,
+						),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				actionID:      "id1",
+				resourceOwner: "org1",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			details, err := c.RemoveActionCanary(tt.args.ctx, tt.args.actionID, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
 func TestCommands_DeactivateAction(t *testing.T) {
 	type fields struct {
 		eventstore *eventstore.Eventstore
@@ -3,6 +3,7 @@ package command
 import (
 	"context"
 	"reflect"
+	"slices"
 
 	"github.com/zitadel/zitadel/internal/domain"
 	caos_errs "github.com/zitadel/zitadel/internal/errors"
@@ -32,7 +33,7 @@ func (c *Commands) ClearFlow(ctx context.Context, flowType domain.FlowType, reso
 	return writeModelToObjectDetails(&existingFlow.WriteModel), nil
 }
 
-func (c *Commands) SetTriggerActions(ctx context.Context, flowType domain.FlowType, triggerType domain.TriggerType, actionIDs []string, resourceOwner string) (*domain.ObjectDetails, error) {
+func (c *Commands) SetTriggerActions(ctx context.Context, flowType domain.FlowType, triggerType domain.TriggerType, actionIDs []string, actionVersions map[string]uint64, resourceOwner string) (*domain.ObjectDetails, error) {
 	if !flowType.Valid() || !triggerType.Valid() || resourceOwner == "" {
 		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Dfhj5", "Errors.Flow.FlowTypeMissing")
 	}
@@ -43,7 +44,7 @@ func (c *Commands) SetTriggerActions(ctx context.Context, flowType domain.FlowTy
 	if err != nil {
 		return nil, err
 	}
-	if reflect.DeepEqual(existingFlow.Triggers[triggerType], actionIDs) {
+	if reflect.DeepEqual(existingFlow.Triggers[triggerType], actionIDs) && versionsEqual(existingFlow.TriggerVersions[triggerType], actionVersions) {
 		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Nfh52", "Errors.Flow.NoChanges")
 	}
 	if len(actionIDs) > 0 {
@@ -55,8 +56,11 @@ func (c *Commands) SetTriggerActions(ctx context.Context, flowType domain.FlowTy
 			return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-dg422", "Errors.Flow.ActionIDsNotExist")
 		}
 	}
+	if err = c.checkActionVersionsExist(ctx, actionIDs, actionVersions, resourceOwner); err != nil {
+		return nil, err
+	}
 	orgAgg := OrgAggregateFromWriteModel(&existingFlow.WriteModel)
-	pushedEvents, err := c.eventstore.Push(ctx, org.NewTriggerActionsSetEvent(ctx, orgAgg, flowType, triggerType, actionIDs))
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewTriggerActionsSetEvent(ctx, orgAgg, flowType, triggerType, actionIDs, actionVersions))
 	if err != nil {
 		return nil, err
 	}
@@ -76,6 +80,35 @@ func (c *Commands) getOrgFlowWriteModelByType(ctx context.Context, flowType doma
 	return flowWriteModel, nil
 }
 
+// checkActionVersionsExist ensures the pinned versions belong to actions of the trigger
+func (c *Commands) checkActionVersionsExist(ctx context.Context, actionIDs []string, actionVersions map[string]uint64, resourceOwner string) error {
+	for actionID, version := range actionVersions {
+		if !slices.Contains(actionIDs, actionID) {
+			return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Wq3nb", "Errors.Flow.ActionVersionWithoutAction")
+		}
+		existingAction, err := c.getActionWriteModelByID(ctx, actionID, resourceOwner)
+		if err != nil {
+			return err
+		}
+		if existingAction.version(version) == nil {
+			return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Xo2cd", "Errors.Action.Version.NotFound")
+		}
+	}
+	return nil
+}
+
+func versionsEqual(existing, versions map[string]uint64) bool {
+	if len(existing) != len(versions) {
+		return false
+	}
+	for actionID, version := range versions {
+		if v, ok := existing[actionID]; !ok || v != version {
+			return false
+		}
+	}
+	return true
+}
+
 func (c *Commands) actionsIDsExist(ctx context.Context, ids []string, resourceOwner string) (bool, error) {
 	actionIDsModel := NewActionsExistModel(ids, resourceOwner)
 	err := c.eventstore.FilterToQueryReducer(ctx, actionIDsModel)
@@ -75,6 +75,7 @@ func TestCommands_ClearFlow(t *testing.T) {
 								domain.FlowTypeExternalAuthentication,
 								domain.TriggerTypePostAuthentication,
 								[]string{"actionID1"},
+								nil,
 							),
 						),
 					),
@@ -123,11 +124,12 @@ func TestCommands_SetTriggerActions(t *testing.T) {
 		eventstore *eventstore.Eventstore
 	}
 	type args struct {
-		ctx           context.Context
-		flowType      domain.FlowType
-		resourceOwner string
-		triggerType   domain.TriggerType
-		actionIDs     []string
+		ctx            context.Context
+		flowType       domain.FlowType
+		resourceOwner  string
+		triggerType    domain.TriggerType
+		actionIDs      []string
+		actionVersions map[string]uint64
 	}
 	type res struct {
 		details *domain.ObjectDetails
@@ -185,6 +187,7 @@ func TestCommands_SetTriggerActions(t *testing.T) {
 								domain.FlowTypeExternalAuthentication,
 								domain.TriggerTypePostAuthentication,
 								[]string{"actionID1"},
+								nil,
 							),
 						),
 					),
@@ -244,6 +247,7 @@ func TestCommands_SetTriggerActions(t *testing.T) {
 							domain.FlowTypeExternalAuthentication,
 							domain.TriggerTypePostAuthentication,
 							[]string{"actionID1"},
+							nil,
 						),
 					),
 				),
@@ -262,13 +266,148 @@ func TestCommands_SetTriggerActions(t *testing.T) {
 				err: nil,
 			},
 		},
+		{
+			"version of other action, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+					expectFilter(
+						eventFromEventPusher(
+							action.NewAddedEvent(context.Background(),
+								&action.NewAggregate("action1", "org1").Aggregate,
+								"actionID1",
+								"function(ctx, api) action {};",
+								0,
+								false,
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:            context.Background(),
+				flowType:       domain.FlowTypeExternalAuthentication,
+				triggerType:    domain.TriggerTypePostAuthentication,
+				actionIDs:      []string{"actionID1"},
+				actionVersions: map[string]uint64{"actionID2": 1},
+				resourceOwner:  "org1",
+			},
+			res{
+				details: nil,
+				err:     errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"version not exists, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+					expectFilter(
+						eventFromEventPusher(
+							action.NewAddedEvent(context.Background(),
+								&action.NewAggregate("actionID1", "org1").Aggregate,
+								"action1",
+								"function(ctx, api) action {};",
+								0,
+								false,
+							),
+						),
+					),
+					expectFilter(
+						eventWithSequence(1, eventFromEventPusher(
+							action.NewAddedEvent(context.Background(),
+								&action.NewAggregate("actionID1", "org1").Aggregate,
+								"action1",
+								"function(ctx, api) action {};",
+								0,
+								false,
+							),
+						)),
+					),
+				),
+			},
+			args{
+				ctx:            context.Background(),
+				flowType:       domain.FlowTypeExternalAuthentication,
+				triggerType:    domain.TriggerTypePostAuthentication,
+				actionIDs:      []string{"actionID1"},
+				actionVersions: map[string]uint64{"actionID1": 2},
+				resourceOwner:  "org1",
+			},
+			res{
+				details: nil,
+				err:     errors.IsPreconditionFailed,
+			},
+		},
+		{
+			"set with version ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+					expectFilter(
+						eventFromEventPusher(
+							action.NewAddedEvent(context.Background(),
+								&action.NewAggregate("actionID1", "org1").Aggregate,
+								"action1",
+								"function(ctx, api) action {};",
+								0,
+								false,
+							),
+						),
+					),
+					expectFilter(
+						eventWithSequence(1, eventFromEventPusher(
+							action.NewAddedEvent(context.Background(),
+								&action.NewAggregate("actionID1", "org1").Aggregate,
+								"action1",
+								"function(ctx, api) action {};",
+								0,
+								false,
+							),
+						)),
+						eventWithSequence(2, eventFromEventPusher(
+							func() *action.ChangedEvent {
+								event, _ := action.NewChangedEvent(context.Background(),
+									&action.NewAggregate("actionID1", "org1").Aggregate,
+									[]action.ActionChanges{action.ChangeScript("function(ctx, api) action2 {};")},
+								)
+								return event
+							}(),
+						)),
+					),
+					expectPush(
+						org.NewTriggerActionsSetEvent(context.Background(),
+							&org.NewAggregate("org1").Aggregate,
+							domain.FlowTypeExternalAuthentication,
+							domain.TriggerTypePostAuthentication,
+							[]string{"actionID1"},
+							map[string]uint64{"actionID1": 1},
+						),
+					),
+				),
+			},
+			args{
+				ctx:            context.Background(),
+				flowType:       domain.FlowTypeExternalAuthentication,
+				triggerType:    domain.TriggerTypePostAuthentication,
+				actionIDs:      []string{"actionID1"},
+				actionVersions: map[string]uint64{"actionID1": 1},
+				resourceOwner:  "org1",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+				err: nil,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			c := &Commands{
 				eventstore: tt.fields.eventstore,
 			}
-			details, err := c.SetTriggerActions(tt.args.ctx, tt.args.flowType, tt.args.triggerType, tt.args.actionIDs, tt.args.resourceOwner)
+			details, err := c.SetTriggerActions(tt.args.ctx, tt.args.flowType, tt.args.triggerType, tt.args.actionIDs, tt.args.actionVersions, tt.args.resourceOwner)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
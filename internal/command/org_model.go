@@ -44,6 +44,12 @@ func (wm *OrgWriteModel) Reduce() error {
 	return wm.WriteModel.Reduce()
 }
 
+// SnapshotVersion implements [eventstore.SnapshotQueryReducer],
+// it must be increased if the fields or the reduce of the write model change
+func (wm *OrgWriteModel) SnapshotVersion() uint16 {
+	return 1
+}
+
 func (wm *OrgWriteModel) Query() *eventstore.SearchQueryBuilder {
 	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
 		ResourceOwner(wm.ResourceOwner).
@@ -2,6 +2,7 @@ package command
 
 import (
 	"context"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/eventstore"
 
@@ -57,7 +58,10 @@ func (wm *OrgLockoutPolicyWriteModel) NewChangedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
 	maxAttempts uint64,
-	showLockoutFailure bool) (*org.LockoutPolicyChangedEvent, bool) {
+	showLockoutFailure bool,
+	maxOTPAttempts uint64,
+	autoUnlockAfter time.Duration,
+	failedAttemptDelay time.Duration) (*org.LockoutPolicyChangedEvent, bool) {
 	changes := make([]policy.LockoutPolicyChanges, 0)
 	if wm.MaxPasswordAttempts != maxAttempts {
 		changes = append(changes, policy.ChangeMaxAttempts(maxAttempts))
@@ -65,6 +69,15 @@ func (wm *OrgLockoutPolicyWriteModel) NewChangedEvent(
 	if wm.ShowLockOutFailures != showLockoutFailure {
 		changes = append(changes, policy.ChangeShowLockOutFailures(showLockoutFailure))
 	}
+	if wm.MaxOTPAttempts != maxOTPAttempts {
+		changes = append(changes, policy.ChangeMaxOTPAttempts(maxOTPAttempts))
+	}
+	if wm.AutoUnlockAfter != autoUnlockAfter {
+		changes = append(changes, policy.ChangeAutoUnlockAfter(autoUnlockAfter))
+	}
+	if wm.FailedAttemptDelay != failedAttemptDelay {
+		changes = append(changes, policy.ChangeFailedAttemptDelay(failedAttemptDelay))
+	}
 	if len(changes) == 0 {
 		return nil, false
 	}
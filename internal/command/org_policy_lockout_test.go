@@ -62,6 +62,9 @@ func TestCommandSide_AddPasswordLockoutPolicy(t *testing.T) {
 								&org.NewAggregate("org1").Aggregate,
 								10,
 								true,
+								0,
+								0,
+								0,
 							),
 						),
 					),
@@ -90,6 +93,9 @@ func TestCommandSide_AddPasswordLockoutPolicy(t *testing.T) {
 							&org.NewAggregate("org1").Aggregate,
 							10,
 							true,
+							0,
+							0,
+							0,
 						),
 					),
 				),
@@ -201,6 +207,9 @@ func TestCommandSide_ChangePasswordLockoutPolicy(t *testing.T) {
 								&org.NewAggregate("org1").Aggregate,
 								10,
 								true,
+								0,
+								0,
+								0,
 							),
 						),
 					),
@@ -229,6 +238,9 @@ func TestCommandSide_ChangePasswordLockoutPolicy(t *testing.T) {
 								&org.NewAggregate("org1").Aggregate,
 								10,
 								true,
+								0,
+								0,
+								0,
 							),
 						),
 					),
@@ -335,6 +347,9 @@ func TestCommandSide_RemovePasswordLockoutPolicy(t *testing.T) {
 								&org.NewAggregate("org1").Aggregate,
 								10,
 								true,
+								0,
+								0,
+								0,
 							),
 						),
 					),
@@ -47,6 +47,12 @@ func (wm *OrgLoginPolicyWriteModel) Reduce() error {
 	return wm.LoginPolicyWriteModel.Reduce()
 }
 
+// SnapshotVersion implements [eventstore.SnapshotQueryReducer],
+// it must be increased if the fields or the reduce of the write model change
+func (wm *OrgLoginPolicyWriteModel) SnapshotVersion() uint16 {
+	return 1
+}
+
 func (wm *OrgLoginPolicyWriteModel) Query() *eventstore.SearchQueryBuilder {
 	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
 		ResourceOwner(wm.ResourceOwner).
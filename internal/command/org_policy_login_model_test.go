@@ -0,0 +1,70 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository/mock"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+type memorySnapshotStorage struct {
+	snapshot *eventstore.Snapshot
+}
+
+func (s *memorySnapshotStorage) Snapshot(context.Context, string, string, string) (*eventstore.Snapshot, error) {
+	return s.snapshot, nil
+}
+
+func (s *memorySnapshotStorage) StoreSnapshot(_ context.Context, snapshot *eventstore.Snapshot) error {
+	s.snapshot = snapshot
+	return nil
+}
+
+func TestOrgLoginPolicyWriteModel_snapshot(t *testing.T) {
+	ctx := authz.WithInstanceID(context.Background(), "instance1")
+	storage := new(memorySnapshotStorage)
+	events := []eventstore.Event{
+		eventFromEventPusherWithInstanceID("instance1",
+			org.NewLoginPolicyAddedEvent(context.Background(),
+				&org.NewAggregate("org1").Aggregate,
+				true, true, true, false, false, false, false, false, false, false,
+				domain.PasswordlessTypeAllowed,
+				"https://example.com/redirect",
+				time.Hour*1, time.Hour*2, time.Hour*3, time.Hour*4, time.Hour*5,
+			),
+		),
+	}
+	newEventstore := func() *eventstore.Eventstore {
+		es := eventstore.NewEventstore(&eventstore.Config{
+			Querier: mock.NewRepo(t).ExpectFilterEvents(events...).MockQuerier,
+			Snapshots: eventstore.SnapshotConfig{
+				MinEvents: 1,
+				Models: map[string]eventstore.SnapshotModelConfig{
+					"orgloginpolicywritemodel": {Enabled: true},
+				},
+			},
+			SnapshotStorage: storage,
+		})
+		org.RegisterEventMappers(es)
+		return es
+	}
+
+	reduced := NewOrgLoginPolicyWriteModel("org1")
+	require.NoError(t, newEventstore().FilterToQueryReducer(ctx, reduced))
+	require.NotNil(t, storage.snapshot)
+	assert.Equal(t, "*command.OrgLoginPolicyWriteModel", storage.snapshot.Model)
+
+	restored := NewOrgLoginPolicyWriteModel("org1")
+	require.NoError(t, newEventstore().FilterToQueryReducer(ctx, restored))
+	assert.Empty(t, restored.Events, "events of the snapshot must not be reduced again")
+	restored.Events = reduced.Events
+	assert.Equal(t, reduced, restored)
+}
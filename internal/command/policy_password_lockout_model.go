@@ -1,6 +1,8 @@
 package command
 
 import (
+	"time"
+
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/repository/policy"
@@ -11,6 +13,9 @@ type LockoutPolicyWriteModel struct {
 
 	MaxPasswordAttempts uint64
 	ShowLockOutFailures bool
+	MaxOTPAttempts      uint64
+	AutoUnlockAfter     time.Duration
+	FailedAttemptDelay  time.Duration
 	State               domain.PolicyState
 }
 
@@ -20,6 +25,9 @@ func (wm *LockoutPolicyWriteModel) Reduce() error {
 		case *policy.LockoutPolicyAddedEvent:
 			wm.MaxPasswordAttempts = e.MaxPasswordAttempts
 			wm.ShowLockOutFailures = e.ShowLockOutFailures
+			wm.MaxOTPAttempts = e.MaxOTPAttempts
+			wm.AutoUnlockAfter = e.AutoUnlockAfter
+			wm.FailedAttemptDelay = e.FailedAttemptDelay
 			wm.State = domain.PolicyStateActive
 		case *policy.LockoutPolicyChangedEvent:
 			if e.MaxPasswordAttempts != nil {
@@ -28,6 +36,15 @@ func (wm *LockoutPolicyWriteModel) Reduce() error {
 			if e.ShowLockOutFailures != nil {
 				wm.ShowLockOutFailures = *e.ShowLockOutFailures
 			}
+			if e.MaxOTPAttempts != nil {
+				wm.MaxOTPAttempts = *e.MaxOTPAttempts
+			}
+			if e.AutoUnlockAfter != nil {
+				wm.AutoUnlockAfter = *e.AutoUnlockAfter
+			}
+			if e.FailedAttemptDelay != nil {
+				wm.FailedAttemptDelay = *e.FailedAttemptDelay
+			}
 		case *policy.LockoutPolicyRemovedEvent:
 			wm.State = domain.PolicyStateRemoved
 		}
@@ -16,7 +16,8 @@ func (c *Commands) ReportQuotaUsage(ctx context.Context, dueNotifications []*quo
 	cmds := make([]eventstore.Command, 0, len(dueNotifications))
 	for _, notification := range dueNotifications {
 		ctxFilter, spanFilter := tracing.NewNamedSpan(ctx, "filterNotificationDueEvents")
-		events, errFilter := c.eventstore.Filter(
+		var alreadyDue bool
+		errFilter := c.eventstore.FilterStream(
 			ctxFilter,
 			eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
 				InstanceID(notification.Aggregate().InstanceID).
@@ -29,12 +30,16 @@ func (c *Commands) ReportQuotaUsage(ctx context.Context, dueNotifications []*quo
 					"periodStart": notification.PeriodStart,
 					"threshold":   notification.Threshold,
 				}).Builder(),
+			func(eventstore.Event) error {
+				alreadyDue = true
+				return eventstore.ErrStopFilter
+			},
 		)
 		spanFilter.EndWithError(errFilter)
 		if errFilter != nil {
 			return errFilter
 		}
-		if len(events) > 0 {
+		if alreadyDue {
 			continue
 		}
 		cmds = append(cmds, notification)
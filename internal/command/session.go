@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/zitadel/logging"
+
 	"github.com/zitadel/zitadel/internal/activity"
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/crypto"
@@ -30,14 +32,19 @@ type SessionCommands struct {
 	totpWriteModel     *HumanTOTPWriteModel
 	eventstore         *eventstore.Eventstore
 	eventCommands      []eventstore.Command
+	// authMethods are the authentication methods of the executed checks, used to run the actions
+	authMethods []authMethod
 
-	hasher      *crypto.PasswordHasher
-	intentAlg   crypto.EncryptionAlgorithm
-	totpAlg     crypto.EncryptionAlgorithm
-	otpAlg      crypto.EncryptionAlgorithm
-	createCode  cryptoCodeWithDefaultFunc
-	createToken func(sessionID string) (id string, token string, err error)
-	now         func() time.Time
+	hasher            *crypto.PasswordHasher
+	intentAlg         crypto.EncryptionAlgorithm
+	totpAlg           crypto.EncryptionAlgorithm
+	otpAlg            crypto.EncryptionAlgorithm
+	createCode        cryptoCodeWithDefaultFunc
+	createToken       func(sessionID string) (id string, token string, err error)
+	passwordAgePolicy func(ctx context.Context, orgID string) (*domain.PasswordAgePolicy, error)
+	passwordBreached  func(ctx context.Context, orgID, password string) (bool, error)
+	lockoutPolicy     func(ctx context.Context, orgID string) (*domain.LockoutPolicy, error)
+	now               func() time.Time
 }
 
 func (c *Commands) NewSessionCommands(cmds []SessionCommand, session *SessionWriteModel) *SessionCommands {
@@ -51,6 +58,9 @@ func (c *Commands) NewSessionCommands(cmds []SessionCommand, session *SessionWri
 		otpAlg:            c.userEncryption,
 		createCode:        c.newCodeWithDefault,
 		createToken:       c.sessionTokenCreator,
+		passwordAgePolicy: c.getOrgPasswordAgePolicy,
+		passwordBreached:  c.passwordBreachedOnLogin,
+		lockoutPolicy:     c.getOrgLockoutPolicy,
 		now:               time.Now,
 	}
 }
@@ -71,6 +81,7 @@ func CheckPassword(password string) SessionCommand {
 		if cmd.sessionWriteModel.UserID == "" {
 			return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Sfw3f", "Errors.User.UserIDMissing")
 		}
+		cmd.authMethods = append(cmd.authMethods, authMethodPassword)
 		cmd.passwordWriteModel = NewHumanPasswordWriteModel(cmd.sessionWriteModel.UserID, "")
 		err := cmd.eventstore.FilterToQueryReducer(ctx, cmd.passwordWriteModel)
 		if err != nil {
@@ -83,28 +94,88 @@ func CheckPassword(password string) SessionCommand {
 		if cmd.passwordWriteModel.EncodedHash == "" {
 			return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-WEf3t", "Errors.User.Password.NotSet")
 		}
+		lockoutPolicy, err := cmd.lockoutPolicy(ctx, cmd.passwordWriteModel.ResourceOwner)
+		if err != nil {
+			return err
+		}
+		lockout, err := newLockoutCheck(
+			lockoutPolicy,
+			cmd.passwordWriteModel.UserState == domain.UserStateLocked,
+			cmd.passwordWriteModel.LockedAt,
+			cmd.passwordWriteModel.passwordCheckAttempts(),
+			(*domain.LockoutPolicy).PasswordAttemptsExceeded,
+			cmd.now(),
+		)
+		if err != nil {
+			return err
+		}
+		userAgg := UserAggregateFromWriteModel(&cmd.passwordWriteModel.WriteModel)
 		ctx, spanPasswordComparison := tracing.NewNamedSpan(ctx, "passwap.Verify")
 		updated, err := cmd.hasher.Verify(cmd.passwordWriteModel.EncodedHash, password)
 		spanPasswordComparison.EndWithError(err)
 		if err != nil {
+			cmd.failedCheck(ctx, lockout, userAgg, user.NewHumanPasswordCheckFailedEvent(ctx, userAgg, nil))
 			//TODO: maybe we want to reset the session in the future https://github.com/zitadel/zitadel/issues/5807
 			return caos_errs.ThrowInvalidArgument(err, "COMMAND-SAF3g", "Errors.User.Password.Invalid")
 		}
+		cmd.succeededCheck(ctx, lockout, userAgg, user.NewHumanPasswordCheckSucceededEvent(ctx, userAgg, nil))
 		if updated != "" {
-			cmd.eventCommands = append(cmd.eventCommands, user.NewHumanPasswordHashUpdatedEvent(ctx, UserAggregateFromWriteModel(&cmd.passwordWriteModel.WriteModel), updated))
+			cmd.eventCommands = append(cmd.eventCommands, user.NewHumanPasswordHashUpdatedEvent(ctx, userAgg, updated))
+		}
+		if !cmd.passwordWriteModel.SecretChangeRequired {
+			breached, err := cmd.passwordBreached(ctx, cmd.passwordWriteModel.ResourceOwner, password)
+			if err != nil {
+				return err
+			}
+			if breached {
+				cmd.eventCommands = append(cmd.eventCommands, user.NewHumanPasswordBreachedEvent(ctx, UserAggregateFromWriteModel(&cmd.passwordWriteModel.WriteModel)))
+			}
 		}
 
-		cmd.PasswordChecked(ctx, cmd.now())
+		policy, err := cmd.passwordAgePolicy(ctx, cmd.passwordWriteModel.ResourceOwner)
+		if err != nil {
+			return err
+		}
+		checkedAt := cmd.now()
+		cmd.PasswordChecked(ctx, cmd.passwordWriteModel.AggregateID, cmd.passwordWriteModel.ResourceOwner, checkedAt, policy.IsPasswordExpired(cmd.passwordWriteModel.PasswordChanged, checkedAt))
 		return nil
 	}
 }
 
+// mfaLockoutCheck loads the lockout state of the session user for a check of the given second factor
+func (s *SessionCommands) mfaLockoutCheck(ctx context.Context, factor domain.MFAType) (*lockoutCheck, error) {
+	policy, err := s.lockoutPolicy(ctx, s.sessionWriteModel.UserResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	return mfaLockoutCheck(ctx, s.eventstore, s.sessionWriteModel.UserID, s.sessionWriteModel.UserResourceOwner, policy, factor, s.now())
+}
+
+// failedCheck pushes the failed check of an authentication factor directly,
+// as the session itself will not be updated
+func (s *SessionCommands) failedCheck(ctx context.Context, lockout *lockoutCheck, userAgg *eventstore.Aggregate, failedEvent eventstore.Command) {
+	if lockout == nil {
+		return
+	}
+	_, err := s.eventstore.Push(ctx, lockout.checkedEvents(ctx, userAgg, failedEvent, true)...)
+	logging.WithFields("userID", userAgg.ID).OnError(err).Error("unable to push failed check")
+}
+
+// succeededCheck resets previous failed attempts of an authentication factor with the session update
+func (s *SessionCommands) succeededCheck(ctx context.Context, lockout *lockoutCheck, userAgg *eventstore.Aggregate, succeededEvent eventstore.Command) {
+	s.eventCommands = append(s.eventCommands, lockout.unlockEvents(ctx, userAgg)...)
+	if lockout.hasFailedAttempts() {
+		s.eventCommands = append(s.eventCommands, succeededEvent)
+	}
+}
+
 // CheckIntent defines a check for a succeeded intent to be executed for a session update
 func CheckIntent(intentID, token string) SessionCommand {
 	return func(ctx context.Context, cmd *SessionCommands) error {
 		if cmd.sessionWriteModel.UserID == "" {
 			return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Sfw3r", "Errors.User.UserIDMissing")
 		}
+		cmd.authMethods = append(cmd.authMethods, authMethodIntent)
 		if err := crypto.CheckToken(cmd.intentAlg, token, intentID); err != nil {
 			return err
 		}
@@ -140,6 +211,7 @@ func CheckTOTP(code string) SessionCommand {
 		if cmd.sessionWriteModel.UserID == "" {
 			return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Neil7", "Errors.User.UserIDMissing")
 		}
+		cmd.authMethods = append(cmd.authMethods, authMethodOTP)
 		cmd.totpWriteModel = NewHumanTOTPWriteModel(cmd.sessionWriteModel.UserID, "")
 		err = cmd.eventstore.FilterToQueryReducer(ctx, cmd.totpWriteModel)
 		if err != nil {
@@ -148,19 +220,59 @@ func CheckTOTP(code string) SessionCommand {
 		if cmd.totpWriteModel.State != domain.MFAStateReady {
 			return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-eej1U", "Errors.User.MFA.OTP.NotReady")
 		}
+		lockout, err := cmd.mfaLockoutCheck(ctx, domain.MFATypeTOTP)
+		if err != nil {
+			return err
+		}
+		userAgg := UserAggregateFromWriteModel(&cmd.totpWriteModel.WriteModel)
 		err = domain.VerifyTOTP(code, cmd.totpWriteModel.Secret, cmd.totpAlg)
 		if err != nil {
+			cmd.failedCheck(ctx, lockout, userAgg, user.NewHumanOTPCheckFailedEvent(ctx, userAgg, nil))
 			return err
 		}
+		cmd.succeededCheck(ctx, lockout, userAgg, user.NewHumanOTPCheckSucceededEvent(ctx, userAgg, nil))
 		cmd.TOTPChecked(ctx, cmd.now())
 		return nil
 	}
 }
 
+// CheckRecoveryCode defines a check of a recovery code to be executed for a session update.
+// The code will be consumed on success.
+func CheckRecoveryCode(code string) SessionCommand {
+	return func(ctx context.Context, cmd *SessionCommands) (err error) {
+		if cmd.sessionWriteModel.UserID == "" {
+			return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Qw2fs", "Errors.User.UserIDMissing")
+		}
+		cmd.authMethods = append(cmd.authMethods, authMethodRecoveryCode)
+		writeModel := NewHumanRecoveryCodesWriteModel(cmd.sessionWriteModel.UserID, "")
+		if err = cmd.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+			return err
+		}
+		if writeModel.State != domain.MFAStateReady {
+			return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Jd82s", "Errors.User.MFA.RecoveryCodes.NotExisting")
+		}
+		if writeModel.Remaining() == 0 {
+			return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Vn3ls", "Errors.User.MFA.RecoveryCodes.NoneLeft")
+		}
+		ctx, spanPasswordComparison := tracing.NewNamedSpan(ctx, "passwap.Verify")
+		index, err := domain.VerifyRecoveryCode(code, writeModel.EncodedHashes, cmd.hasher)
+		spanPasswordComparison.EndWithError(err)
+		if err != nil {
+			return err
+		}
+		cmd.eventCommands = append(cmd.eventCommands, user.NewHumanRecoveryCodeCheckSucceededEvent(ctx, UserAggregateFromWriteModel(&writeModel.WriteModel), index, nil))
+		cmd.RecoveryCodeChecked(ctx, cmd.now())
+		return nil
+	}
+}
+
 // Exec will execute the commands specified and returns an error on the first occurrence
 func (s *SessionCommands) Exec(ctx context.Context) error {
 	for _, cmd := range s.sessionCommands {
+		executed := len(s.authMethods)
 		if err := cmd(ctx, s); err != nil {
+			// the session is not updated, so only the method of the failed check (if any) was used
+			s.authMethods = s.authMethods[executed:]
 			return err
 		}
 	}
@@ -179,8 +291,8 @@ func (s *SessionCommands) UserChecked(ctx context.Context, userID, resourceOwner
 	return nil
 }
 
-func (s *SessionCommands) PasswordChecked(ctx context.Context, checkedAt time.Time) {
-	s.eventCommands = append(s.eventCommands, session.NewPasswordCheckedEvent(ctx, s.sessionWriteModel.aggregate, checkedAt))
+func (s *SessionCommands) PasswordChecked(ctx context.Context, userID, userResourceOwner string, checkedAt time.Time, expired bool) {
+	s.eventCommands = append(s.eventCommands, session.NewPasswordCheckedEvent(ctx, s.sessionWriteModel.aggregate, userID, userResourceOwner, checkedAt, expired))
 }
 
 func (s *SessionCommands) IntentChecked(ctx context.Context, checkedAt time.Time) {
@@ -226,6 +338,10 @@ func (s *SessionCommands) OTPEmailChecked(ctx context.Context, checkedAt time.Ti
 	s.eventCommands = append(s.eventCommands, session.NewOTPEmailCheckedEvent(ctx, s.sessionWriteModel.aggregate, checkedAt))
 }
 
+func (s *SessionCommands) RecoveryCodeChecked(ctx context.Context, checkedAt time.Time) {
+	s.eventCommands = append(s.eventCommands, session.NewRecoveryCodeCheckedEvent(ctx, s.sessionWriteModel.aggregate, checkedAt))
+}
+
 func (s *SessionCommands) SetToken(ctx context.Context, tokenID string) {
 	// trigger activity log for session for user
 	activity.Trigger(ctx, s.sessionWriteModel.UserResourceOwner, s.sessionWriteModel.UserID, activity.SessionAPI)
@@ -353,6 +469,10 @@ func (c *Commands) terminateSession(ctx context.Context, sessionID, sessionToken
 	if err != nil {
 		return nil, err
 	}
+	if sessionWriteModel.UserID != "" {
+		err = c.runPostLogoutActions(ctx, sessionWriteModel.UserID, sessionWriteModel.UserResourceOwner, "", sessionWriteModel.AggregateID, []string{sessionWriteModel.UserID})
+		logging.WithFields("sessionID", sessionWriteModel.AggregateID).OnError(err).Warn("post logout actions failed")
+	}
 	return writeModelToObjectDetails(&sessionWriteModel.WriteModel), nil
 }
 
@@ -362,9 +482,17 @@ func (c *Commands) updateSession(ctx context.Context, checks *SessionCommands, m
 		return nil, err
 	}
 	if err := checks.Exec(ctx); err != nil {
+		// the actions get the error of the failed check, but it's returned anyway
+		_, actionErr := c.runSessionActions(ctx, checks, err)
+		logging.OnError(actionErr).Warn("post authentication actions of failed session check failed")
 		// TODO: how to handle failed checks (e.g. pw wrong) https://github.com/zitadel/zitadel/issues/5807
 		return nil, err
 	}
+	metadataCmds, err := c.runSessionActions(ctx, checks, nil)
+	if err != nil {
+		return nil, err
+	}
+	checks.eventCommands = append(checks.eventCommands, metadataCmds...)
 	checks.ChangeMetadata(ctx, metadata)
 	err = checks.SetLifetime(ctx, lifetime)
 	if err != nil {
@@ -37,20 +37,21 @@ func (p *WebAuthNChallengeModel) WebAuthNLogin(human *domain.Human, credentialAs
 type SessionWriteModel struct {
 	eventstore.WriteModel
 
-	TokenID              string
-	UserID               string
-	UserResourceOwner    string
-	UserCheckedAt        time.Time
-	PasswordCheckedAt    time.Time
-	IntentCheckedAt      time.Time
-	WebAuthNCheckedAt    time.Time
-	TOTPCheckedAt        time.Time
-	OTPSMSCheckedAt      time.Time
-	OTPEmailCheckedAt    time.Time
-	WebAuthNUserVerified bool
-	Metadata             map[string][]byte
-	State                domain.SessionState
-	Expiration           time.Time
+	TokenID               string
+	UserID                string
+	UserResourceOwner     string
+	UserCheckedAt         time.Time
+	PasswordCheckedAt     time.Time
+	IntentCheckedAt       time.Time
+	WebAuthNCheckedAt     time.Time
+	TOTPCheckedAt         time.Time
+	OTPSMSCheckedAt       time.Time
+	OTPEmailCheckedAt     time.Time
+	RecoveryCodeCheckedAt time.Time
+	WebAuthNUserVerified  bool
+	Metadata              map[string][]byte
+	State                 domain.SessionState
+	Expiration            time.Time
 
 	WebAuthNChallenge     *WebAuthNChallengeModel
 	OTPSMSCodeChallenge   *OTPCode
@@ -95,6 +96,8 @@ func (wm *SessionWriteModel) Reduce() error {
 			wm.reduceOTPEmailChallenged(e)
 		case *session.OTPEmailCheckedEvent:
 			wm.reduceOTPEmailChecked(e)
+		case *session.RecoveryCodeCheckedEvent:
+			wm.reduceRecoveryCodeChecked(e)
 		case *session.TokenSetEvent:
 			wm.reduceTokenSet(e)
 		case *session.LifetimeSetEvent:
@@ -123,6 +126,7 @@ func (wm *SessionWriteModel) Query() *eventstore.SearchQueryBuilder {
 			session.OTPSMSCheckedType,
 			session.OTPEmailChallengedType,
 			session.OTPEmailCheckedType,
+			session.RecoveryCodeCheckedType,
 			session.TokenSetType,
 			session.MetadataSetType,
 			session.LifetimeSetType,
@@ -199,6 +203,10 @@ func (wm *SessionWriteModel) reduceOTPEmailChecked(e *session.OTPEmailCheckedEve
 	wm.OTPEmailCheckedAt = e.CheckedAt
 }
 
+func (wm *SessionWriteModel) reduceRecoveryCodeChecked(e *session.RecoveryCodeCheckedEvent) {
+	wm.RecoveryCodeCheckedAt = e.CheckedAt
+}
+
 func (wm *SessionWriteModel) reduceTokenSet(e *session.TokenSetEvent) {
 	wm.TokenID = e.TokenID
 }
@@ -221,6 +229,7 @@ func (wm *SessionWriteModel) AuthenticationTime() time.Time {
 		wm.IntentCheckedAt,
 		wm.OTPSMSCheckedAt,
 		wm.OTPEmailCheckedAt,
+		wm.RecoveryCodeCheckedAt,
 	} {
 		if check.After(authTime) {
 			authTime = check
@@ -254,6 +263,9 @@ func (wm *SessionWriteModel) AuthMethodTypes() []domain.UserAuthMethodType {
 	if !wm.OTPEmailCheckedAt.IsZero() {
 		types = append(types, domain.UserAuthMethodTypeOTPEmail)
 	}
+	if !wm.RecoveryCodeCheckedAt.IsZero() {
+		types = append(types, domain.UserAuthMethodTypeRecoveryCode)
+	}
 	return types
 }
 
@@ -10,6 +10,7 @@ import (
 	"github.com/zitadel/zitadel/internal/domain"
 	caos_errs "github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/repository/session"
+	"github.com/zitadel/zitadel/internal/repository/user"
 )
 
 func (c *Commands) CreateOTPSMSChallengeReturnCode(dst *string) SessionCommand {
@@ -116,14 +117,22 @@ func CheckOTPSMS(code string) SessionCommand {
 		if cmd.sessionWriteModel.UserID == "" {
 			return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-VDrh3", "Errors.User.UserIDMissing")
 		}
+		cmd.authMethods = append(cmd.authMethods, authMethodOTPSMS)
 		challenge := cmd.sessionWriteModel.OTPSMSCodeChallenge
 		if challenge == nil {
 			return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-SF3tv", "Errors.User.Code.NotFound")
 		}
+		lockout, err := cmd.mfaLockoutCheck(ctx, domain.MFATypeOTPSMS)
+		if err != nil {
+			return err
+		}
+		userAgg := &user.NewAggregate(cmd.sessionWriteModel.UserID, cmd.sessionWriteModel.UserResourceOwner).Aggregate
 		err = crypto.VerifyCodeWithAlgorithm(challenge.CreationDate, challenge.Expiry, challenge.Code, code, cmd.otpAlg)
 		if err != nil {
+			cmd.failedCheck(ctx, lockout, userAgg, user.NewHumanOTPSMSCheckFailedEvent(ctx, userAgg, nil))
 			return err
 		}
+		cmd.succeededCheck(ctx, lockout, userAgg, user.NewHumanOTPSMSCheckSucceededEvent(ctx, userAgg, nil))
 		cmd.OTPSMSChecked(ctx, cmd.now())
 		return nil
 	}
@@ -134,14 +143,22 @@ func CheckOTPEmail(code string) SessionCommand {
 		if cmd.sessionWriteModel.UserID == "" {
 			return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-ejo2w", "Errors.User.UserIDMissing")
 		}
+		cmd.authMethods = append(cmd.authMethods, authMethodOTPEmail)
 		challenge := cmd.sessionWriteModel.OTPEmailCodeChallenge
 		if challenge == nil {
 			return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-zF3g3", "Errors.User.Code.NotFound")
 		}
+		lockout, err := cmd.mfaLockoutCheck(ctx, domain.MFATypeOTPEmail)
+		if err != nil {
+			return err
+		}
+		userAgg := &user.NewAggregate(cmd.sessionWriteModel.UserID, cmd.sessionWriteModel.UserResourceOwner).Aggregate
 		err = crypto.VerifyCodeWithAlgorithm(challenge.CreationDate, challenge.Expiry, challenge.Code, code, cmd.otpAlg)
 		if err != nil {
+			cmd.failedCheck(ctx, lockout, userAgg, user.NewHumanOTPEmailCheckFailedEvent(ctx, userAgg, nil))
 			return err
 		}
+		cmd.succeededCheck(ctx, lockout, userAgg, user.NewHumanOTPEmailCheckSucceededEvent(ctx, userAgg, nil))
 		cmd.OTPEmailChecked(ctx, cmd.now())
 		return nil
 	}
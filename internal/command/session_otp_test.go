@@ -806,6 +806,9 @@ func TestCheckOTPSMS(t *testing.T) {
 				sessionWriteModel: sessionModel,
 				eventstore:        tt.fields.eventstore(t),
 				otpAlg:            tt.fields.otpAlg,
+				lockoutPolicy: func(ctx context.Context, orgID string) (*domain.LockoutPolicy, error) {
+					return nil, nil
+				},
 				now: func() time.Time {
 					return testNow
 				},
@@ -934,6 +937,9 @@ func TestCheckOTPEmail(t *testing.T) {
 				sessionWriteModel: sessionModel,
 				eventstore:        tt.fields.eventstore(t),
 				otpAlg:            tt.fields.otpAlg,
+				lockoutPolicy: func(ctx context.Context, orgID string) (*domain.LockoutPolicy, error) {
+					return nil, nil
+				},
 				now: func() time.Time {
 					return testNow
 				},
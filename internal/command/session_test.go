@@ -571,7 +571,7 @@ func TestCommands_updateSession(t *testing.T) {
 							"userID", "org1", testNow,
 						),
 						session.NewPasswordCheckedEvent(context.Background(), &session.NewAggregate("sessionID", "instance1").Aggregate,
-							testNow,
+							"userID", "org1", testNow, false,
 						),
 						session.NewMetadataSetEvent(context.Background(), &session.NewAggregate("sessionID", "instance1").Aggregate,
 							map[string][]byte{"key": []byte("value")},
@@ -608,6 +608,162 @@ func TestCommands_updateSession(t *testing.T) {
 							nil
 					},
 					hasher: mockPasswordHasher("x"),
+					passwordAgePolicy: func(ctx context.Context, orgID string) (*domain.PasswordAgePolicy, error) {
+						return &domain.PasswordAgePolicy{}, nil
+					},
+					passwordBreached: func(ctx context.Context, orgID, password string) (bool, error) {
+						return false, nil
+					},
+					lockoutPolicy: func(ctx context.Context, orgID string) (*domain.LockoutPolicy, error) {
+						return &domain.LockoutPolicy{}, nil
+					},
+					now: func() time.Time {
+						return testNow
+					},
+				},
+				metadata: map[string][]byte{
+					"key": []byte("value"),
+				},
+			},
+			res{
+				want: &SessionChanged{
+					ObjectDetails: &domain.ObjectDetails{
+						ResourceOwner: "instance1",
+					},
+					ID:       "sessionID",
+					NewToken: "token",
+				},
+			},
+		},
+		{
+			"set user, password expired, metadata and token",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectPush(
+						session.NewUserCheckedEvent(context.Background(), &session.NewAggregate("sessionID", "instance1").Aggregate,
+							"userID", "org1", testNow.Add(31*24*time.Hour),
+						),
+						session.NewPasswordCheckedEvent(context.Background(), &session.NewAggregate("sessionID", "instance1").Aggregate,
+							"userID", "org1", testNow.Add(31*24*time.Hour), true,
+						),
+						session.NewMetadataSetEvent(context.Background(), &session.NewAggregate("sessionID", "instance1").Aggregate,
+							map[string][]byte{"key": []byte("value")},
+						),
+						session.NewTokenSetEvent(context.Background(), &session.NewAggregate("sessionID", "instance1").Aggregate,
+							"tokenID",
+						),
+					),
+				),
+			},
+			args{
+				ctx: authz.NewMockContext("instance1", "", ""),
+				checks: &SessionCommands{
+					sessionWriteModel: NewSessionWriteModel("sessionID", "instance1"),
+					sessionCommands: []SessionCommand{
+						CheckUser("userID", "org1"),
+						CheckPassword("password"),
+					},
+					eventstore: eventstoreExpect(t,
+						expectFilter(
+							eventFromEventPusher(
+								user.NewHumanAddedEvent(context.Background(), &user.NewAggregate("userID", "org1").Aggregate,
+									"username", "", "", "", "", language.English, domain.GenderUnspecified, "", false),
+							),
+							eventFromEventPusherWithCreationDateNow(
+								user.NewHumanPasswordChangedEvent(context.Background(), &user.NewAggregate("userID", "org1").Aggregate,
+									"$plain$x$password", false, ""),
+							),
+						),
+					),
+					createToken: func(sessionID string) (string, string, error) {
+						return "tokenID",
+							"token",
+							nil
+					},
+					hasher: mockPasswordHasher("x"),
+					passwordAgePolicy: func(ctx context.Context, orgID string) (*domain.PasswordAgePolicy, error) {
+						return &domain.PasswordAgePolicy{MaxAgeDays: 30}, nil
+					},
+					passwordBreached: func(ctx context.Context, orgID, password string) (bool, error) {
+						return false, nil
+					},
+					lockoutPolicy: func(ctx context.Context, orgID string) (*domain.LockoutPolicy, error) {
+						return &domain.LockoutPolicy{}, nil
+					},
+					now: func() time.Time {
+						return testNow.Add(31 * 24 * time.Hour)
+					},
+				},
+				metadata: map[string][]byte{
+					"key": []byte("value"),
+				},
+			},
+			res{
+				want: &SessionChanged{
+					ObjectDetails: &domain.ObjectDetails{
+						ResourceOwner: "instance1",
+					},
+					ID:       "sessionID",
+					NewToken: "token",
+				},
+			},
+		},
+		{
+			"set user, password breached, metadata and token",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectPush(
+						session.NewUserCheckedEvent(context.Background(), &session.NewAggregate("sessionID", "instance1").Aggregate,
+							"userID", "org1", testNow,
+						),
+						user.NewHumanPasswordBreachedEvent(context.Background(), &user.NewAggregate("userID", "org1").Aggregate),
+						session.NewPasswordCheckedEvent(context.Background(), &session.NewAggregate("sessionID", "instance1").Aggregate,
+							"userID", "org1", testNow, false,
+						),
+						session.NewMetadataSetEvent(context.Background(), &session.NewAggregate("sessionID", "instance1").Aggregate,
+							map[string][]byte{"key": []byte("value")},
+						),
+						session.NewTokenSetEvent(context.Background(), &session.NewAggregate("sessionID", "instance1").Aggregate,
+							"tokenID",
+						),
+					),
+				),
+			},
+			args{
+				ctx: authz.NewMockContext("instance1", "", ""),
+				checks: &SessionCommands{
+					sessionWriteModel: NewSessionWriteModel("sessionID", "instance1"),
+					sessionCommands: []SessionCommand{
+						CheckUser("userID", "org1"),
+						CheckPassword("password"),
+					},
+					eventstore: eventstoreExpect(t,
+						expectFilter(
+							eventFromEventPusher(
+								user.NewHumanAddedEvent(context.Background(), &user.NewAggregate("userID", "org1").Aggregate,
+									"username", "", "", "", "", language.English, domain.GenderUnspecified, "", false),
+							),
+							eventFromEventPusher(
+								user.NewHumanPasswordChangedEvent(context.Background(), &user.NewAggregate("userID", "org1").Aggregate,
+									"$plain$x$password", false, ""),
+							),
+						),
+					),
+					createToken: func(sessionID string) (string, string, error) {
+						return "tokenID",
+							"token",
+							nil
+					},
+					hasher: mockPasswordHasher("x"),
+					passwordAgePolicy: func(ctx context.Context, orgID string) (*domain.PasswordAgePolicy, error) {
+						return &domain.PasswordAgePolicy{}, nil
+					},
+					passwordBreached: func(ctx context.Context, orgID, password string) (bool, error) {
+						return true, nil
+					},
+					lockoutPolicy: func(ctx context.Context, orgID string) (*domain.LockoutPolicy, error) {
+						return &domain.LockoutPolicy{}, nil
+					},
 					now: func() time.Time {
 						return testNow
 					},
@@ -841,6 +997,7 @@ func TestCheckTOTP(t *testing.T) {
 	type fields struct {
 		sessionWriteModel *SessionWriteModel
 		eventstore        func(*testing.T) *eventstore.Eventstore
+		lockoutPolicy     *domain.LockoutPolicy
 	}
 
 	tests := []struct {
@@ -917,6 +1074,72 @@ func TestCheckTOTP(t *testing.T) {
 			},
 			wantErr: caos_errs.ThrowInvalidArgument(nil, "EVENT-8isk2", "Errors.User.MFA.OTP.InvalidCode"),
 		},
+		{
+			name: "otp verify error, max otp attempts reached - user locked",
+			code: "foobar",
+			fields: fields{
+				sessionWriteModel: &SessionWriteModel{
+					UserID:            "user1",
+					UserResourceOwner: "org1",
+					UserCheckedAt:     testNow,
+					aggregate:         sessAgg,
+				},
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanOTPAddedEvent(ctx, userAgg, secret),
+						),
+						eventFromEventPusher(
+							user.NewHumanOTPVerifiedEvent(ctx, userAgg, "agent1"),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanOTPCheckFailedEvent(ctx, userAgg, nil),
+						),
+					),
+					expectPush(
+						user.NewHumanOTPCheckFailedEvent(ctx, userAgg, nil),
+						user.NewUserLockedEvent(ctx, userAgg),
+					),
+				),
+				lockoutPolicy: &domain.LockoutPolicy{
+					MaxOTPAttempts: 2,
+				},
+			},
+			wantErr: caos_errs.ThrowInvalidArgument(nil, "EVENT-8isk2", "Errors.User.MFA.OTP.InvalidCode"),
+		},
+		{
+			name: "user locked, precondition error",
+			code: code,
+			fields: fields{
+				sessionWriteModel: &SessionWriteModel{
+					UserID:            "user1",
+					UserResourceOwner: "org1",
+					UserCheckedAt:     testNow,
+					aggregate:         sessAgg,
+				},
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanOTPAddedEvent(ctx, userAgg, secret),
+						),
+						eventFromEventPusher(
+							user.NewHumanOTPVerifiedEvent(ctx, userAgg, "agent1"),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewUserLockedEvent(ctx, userAgg),
+						),
+					),
+				),
+				lockoutPolicy: &domain.LockoutPolicy{
+					MaxOTPAttempts: 2,
+				},
+			},
+			wantErr: caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ohx4a", "Errors.User.Locked"),
+		},
 		{
 			name: "ok",
 			code: code,
@@ -948,7 +1171,10 @@ func TestCheckTOTP(t *testing.T) {
 				sessionWriteModel: tt.fields.sessionWriteModel,
 				eventstore:        tt.fields.eventstore(t),
 				totpAlg:           cryptoAlg,
-				now:               func() time.Time { return testNow },
+				lockoutPolicy: func(ctx context.Context, orgID string) (*domain.LockoutPolicy, error) {
+					return tt.fields.lockoutPolicy, nil
+				},
+				now: func() time.Time { return testNow },
 			}
 			err := CheckTOTP(tt.code)(ctx, cmd)
 			require.ErrorIs(t, err, tt.wantErr)
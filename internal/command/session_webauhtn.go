@@ -69,6 +69,11 @@ func (c *Commands) CheckWebAuthN(credentialAssertionData json.Marshaler) Session
 		if challenge == nil {
 			return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ioqu5", "Errors.Session.WebAuthN.NoChallenge")
 		}
+		if challenge.UserVerification == domain.UserVerificationRequirementRequired {
+			cmd.authMethods = append(cmd.authMethods, authMethodPasswordless)
+		} else {
+			cmd.authMethods = append(cmd.authMethods, authMethodU2F)
+		}
 		webAuthNTokens, err := cmd.getHumanWebAuthNTokens(ctx, challenge.UserVerification)
 		if err != nil {
 			return err
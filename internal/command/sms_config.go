@@ -6,6 +6,7 @@ import (
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/notification/channels/httpsms"
 	"github.com/zitadel/zitadel/internal/notification/channels/twilio"
 	"github.com/zitadel/zitadel/internal/repository/instance"
 )
@@ -110,6 +111,130 @@ func (c *Commands) ChangeSMSConfigTwilioToken(ctx context.Context, instanceID, i
 	return writeModelToObjectDetails(&smsConfigWriteModel.WriteModel), nil
 }
 
+func (c *Commands) AddSMSConfigHTTP(ctx context.Context, instanceID string, config *httpsms.Config) (string, *domain.ObjectDetails, error) {
+	if err := config.Validate(); err != nil {
+		return "", nil, err
+	}
+	if config.AuthType != domain.SMSHTTPAuthTypeNone && config.Secret == "" {
+		return "", nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Zt4ob", "Errors.SMSConfig.HTTP.SecretMissing")
+	}
+	id, err := c.idGenerator.Next()
+	if err != nil {
+		return "", nil, err
+	}
+	smsConfigWriteModel, err := c.getSMSConfig(ctx, instanceID, id)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var secret *crypto.CryptoValue
+	if config.Secret != "" {
+		secret, err = crypto.Encrypt([]byte(config.Secret), c.smsEncryption)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	headers, err := httpsms.EncryptHeaders(config.Headers, c.smsEncryption)
+	if err != nil {
+		return "", nil, err
+	}
+
+	iamAgg := InstanceAggregateFromWriteModel(&smsConfigWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, instance.NewSMSConfigHTTPAddedEvent(
+		ctx,
+		iamAgg,
+		id,
+		config.URL,
+		config.Method,
+		headers,
+		config.BodyTemplate,
+		config.AuthType,
+		config.Username,
+		secret,
+		config.SuccessStatusCodes,
+		config.SuccessBodyPattern,
+	))
+	if err != nil {
+		return "", nil, err
+	}
+	err = AppendAndReduce(smsConfigWriteModel, pushedEvents...)
+	if err != nil {
+		return "", nil, err
+	}
+	return id, writeModelToObjectDetails(&smsConfigWriteModel.WriteModel), nil
+}
+
+// ChangeSMSConfigHTTP changes the configuration of the HTTP SMS provider,
+// the secret is changed using [Commands.ChangeSMSConfigHTTPSecret]
+func (c *Commands) ChangeSMSConfigHTTP(ctx context.Context, instanceID, id string, config *httpsms.Config) (*domain.ObjectDetails, error) {
+	if id == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "SMS-Bn4ug", "Errors.IDMissing")
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	smsConfigWriteModel, err := c.getSMSConfig(ctx, instanceID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !smsConfigWriteModel.State.Exists() || smsConfigWriteModel.HTTP == nil {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Qe6lm", "Errors.SMSConfig.NotFound")
+	}
+	if config.AuthType != domain.SMSHTTPAuthTypeNone && smsConfigWriteModel.HTTP.Secret == nil {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Pr2hx", "Errors.SMSConfig.HTTP.SecretMissing")
+	}
+	iamAgg := InstanceAggregateFromWriteModel(&smsConfigWriteModel.WriteModel)
+
+	changedEvent, hasChanged, err := smsConfigWriteModel.NewHTTPChangedEvent(ctx, iamAgg, id, config, c.smsEncryption)
+	if err != nil {
+		return nil, err
+	}
+	if !hasChanged {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Xc9ja", "Errors.NoChangesFound")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, changedEvent)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(smsConfigWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&smsConfigWriteModel.WriteModel), nil
+}
+
+func (c *Commands) ChangeSMSConfigHTTPSecret(ctx context.Context, instanceID, id, secret string) (*domain.ObjectDetails, error) {
+	if id == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "SMS-Ko5sd", "Errors.IDMissing")
+	}
+	smsConfigWriteModel, err := c.getSMSConfig(ctx, instanceID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !smsConfigWriteModel.State.Exists() || smsConfigWriteModel.HTTP == nil {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Hw3fe", "Errors.SMSConfig.NotFound")
+	}
+	iamAgg := InstanceAggregateFromWriteModel(&smsConfigWriteModel.WriteModel)
+	newSecret, err := crypto.Encrypt([]byte(secret), c.smsEncryption)
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, instance.NewSMSConfigHTTPSecretChangedEvent(
+		ctx,
+		iamAgg,
+		id,
+		newSecret))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(smsConfigWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&smsConfigWriteModel.WriteModel), nil
+}
+
 func (c *Commands) ActivateSMSConfig(ctx context.Context, instanceID, id string) (*domain.ObjectDetails, error) {
 	if id == "" {
 		return nil, caos_errs.ThrowInvalidArgument(nil, "SMS-dn93n", "Errors.IDMissing")
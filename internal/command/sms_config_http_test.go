@@ -0,0 +1,550 @@
+package command
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/notification/channels/httpsms"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+func TestCommandSide_AddSMSConfigHTTP(t *testing.T) {
+	type fields struct {
+		eventstore  *eventstore.Eventstore
+		idGenerator id.Generator
+		alg         crypto.EncryptionAlgorithm
+	}
+	type args struct {
+		ctx        context.Context
+		instanceID string
+		sms        *httpsms.Config
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "invalid url, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:        context.Background(),
+				instanceID: "INSTANCE",
+				sms: &httpsms.Config{
+					URL:    "no-url",
+					Method: http.MethodPost,
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "invalid body template, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:        context.Background(),
+				instanceID: "INSTANCE",
+				sms: &httpsms.Config{
+					URL:          "https://sms.local/send",
+					Method:       http.MethodPost,
+					BodyTemplate: "{{.Phone",
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "secret missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:        context.Background(),
+				instanceID: "INSTANCE",
+				sms: &httpsms.Config{
+					URL:      "https://sms.local/send",
+					Method:   http.MethodPost,
+					AuthType: domain.SMSHTTPAuthTypeBearer,
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "add sms config http, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+					expectPush(
+						instance.NewSMSConfigHTTPAddedEvent(
+							context.Background(),
+							&instance.NewAggregate("INSTANCE").Aggregate,
+							"providerid",
+							"https://sms.local/send",
+							http.MethodPost,
+							map[string]*crypto.CryptoValue{
+								"X-Api-Version": {
+									CryptoType: crypto.TypeEncryption,
+									Algorithm:  "enc",
+									KeyID:      "id",
+									Crypted:    []byte(`["2"]`),
+								},
+							},
+							`{"to":{{json .Phone}},"text":{{json .Content}}}`,
+							domain.SMSHTTPAuthTypeBearer,
+							"",
+							&crypto.CryptoValue{
+								CryptoType: crypto.TypeEncryption,
+								Algorithm:  "enc",
+								KeyID:      "id",
+								Crypted:    []byte("token"),
+							},
+							[]int{http.StatusAccepted},
+							`"queued"`,
+						),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "providerid"),
+				alg:         crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:        context.Background(),
+				instanceID: "INSTANCE",
+				sms: &httpsms.Config{
+					URL:                "https://sms.local/send",
+					Method:             http.MethodPost,
+					Headers:            http.Header{"X-Api-Version": []string{"2"}},
+					BodyTemplate:       `{"to":{{json .Phone}},"text":{{json .Content}}}`,
+					AuthType:           domain.SMSHTTPAuthTypeBearer,
+					Secret:             "token",
+					SuccessStatusCodes: []int{http.StatusAccepted},
+					SuccessBodyPattern: `"queued"`,
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:    tt.fields.eventstore,
+				idGenerator:   tt.fields.idGenerator,
+				smsEncryption: tt.fields.alg,
+			}
+			_, got, err := r.AddSMSConfigHTTP(tt.args.ctx, tt.args.instanceID, tt.args.sms)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_ChangeSMSConfigHTTP(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+		alg        crypto.EncryptionAlgorithm
+	}
+	type args struct {
+		ctx        context.Context
+		instanceID string
+		id         string
+		sms        *httpsms.Config
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "id empty, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx: context.Background(),
+				sms: &httpsms.Config{},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "sms not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				sms: &httpsms.Config{
+					URL:    "https://sms.local/send",
+					Method: http.MethodPost,
+				},
+				instanceID: "INSTANCE",
+				id:         "id",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "twilio config, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSMSConfigTwilioAddedEvent(
+								context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"providerid",
+								"sid",
+								"senderName",
+								nil,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				sms: &httpsms.Config{
+					URL:    "https://sms.local/send",
+					Method: http.MethodPost,
+				},
+				instanceID: "INSTANCE",
+				id:         "providerid",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "auth without secret, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							newSMSConfigHTTPAddedEvent("providerid", nil),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				sms: &httpsms.Config{
+					URL:      "https://sms.local/send",
+					Method:   http.MethodPost,
+					AuthType: domain.SMSHTTPAuthTypeBasic,
+					Username: "user",
+				},
+				instanceID: "INSTANCE",
+				id:         "providerid",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "no changes, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							newSMSConfigHTTPAddedEvent("providerid", nil),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				sms: &httpsms.Config{
+					URL:          "https://sms.local/send",
+					Method:       http.MethodPost,
+					BodyTemplate: "{{.Content}}",
+				},
+				instanceID: "INSTANCE",
+				id:         "providerid",
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "headers not changed, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSMSConfigHTTPAddedEvent(
+								context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"providerid",
+								"https://sms.local/send",
+								http.MethodPost,
+								map[string]*crypto.CryptoValue{
+									"X-Api-Version": {
+										CryptoType: crypto.TypeEncryption,
+										Algorithm:  "enc",
+										KeyID:      "id",
+										Crypted:    []byte(`["2"]`),
+									},
+								},
+								"{{.Content}}",
+								domain.SMSHTTPAuthTypeNone,
+								"",
+								nil,
+								nil,
+								"",
+							),
+						),
+					),
+				),
+				alg: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				sms: &httpsms.Config{
+					URL:          "https://sms.local/send",
+					Method:       http.MethodPost,
+					Headers:      http.Header{"x-api-version": []string{"2"}},
+					BodyTemplate: "{{.Content}}",
+				},
+				instanceID: "INSTANCE",
+				id:         "providerid",
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "sms config http change, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							newSMSConfigHTTPAddedEvent("providerid", nil),
+						),
+					),
+					expectPush(
+						func() *instance.SMSConfigHTTPChangedEvent {
+							event, _ := instance.NewSMSConfigHTTPChangedEvent(
+								context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"providerid",
+								[]instance.SMSConfigHTTPChanges{
+									instance.ChangeSMSConfigHTTPURL("https://sms.local/v2/send"),
+									instance.ChangeSMSConfigHTTPHeaders(map[string]*crypto.CryptoValue{
+										"X-Api-Version": {
+											CryptoType: crypto.TypeEncryption,
+											Algorithm:  "enc",
+											KeyID:      "id",
+											Crypted:    []byte(`["2"]`),
+										},
+									}),
+									instance.ChangeSMSConfigHTTPSuccessStatusCodes([]int{http.StatusAccepted}),
+								},
+							)
+							return event
+						}(),
+					),
+				),
+				alg: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				sms: &httpsms.Config{
+					URL:                "https://sms.local/v2/send",
+					Method:             http.MethodPost,
+					Headers:            http.Header{"X-Api-Version": []string{"2"}},
+					BodyTemplate:       "{{.Content}}",
+					SuccessStatusCodes: []int{http.StatusAccepted},
+				},
+				instanceID: "INSTANCE",
+				id:         "providerid",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:    tt.fields.eventstore,
+				smsEncryption: tt.fields.alg,
+			}
+			got, err := r.ChangeSMSConfigHTTP(tt.args.ctx, tt.args.instanceID, tt.args.id, tt.args.sms)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_ChangeSMSConfigHTTPSecret(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+		alg        crypto.EncryptionAlgorithm
+	}
+	type args struct {
+		ctx        context.Context
+		instanceID string
+		id         string
+		secret     string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "sms not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:        context.Background(),
+				instanceID: "INSTANCE",
+				id:         "id",
+				secret:     "secret",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "sms config http secret change, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							newSMSConfigHTTPAddedEvent("providerid", nil),
+						),
+					),
+					expectPush(
+						instance.NewSMSConfigHTTPSecretChangedEvent(
+							context.Background(),
+							&instance.NewAggregate("INSTANCE").Aggregate,
+							"providerid",
+							&crypto.CryptoValue{
+								CryptoType: crypto.TypeEncryption,
+								Algorithm:  "enc",
+								KeyID:      "id",
+								Crypted:    []byte("secret"),
+							},
+						),
+					),
+				),
+				alg: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:        context.Background(),
+				instanceID: "INSTANCE",
+				id:         "providerid",
+				secret:     "secret",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:    tt.fields.eventstore,
+				smsEncryption: tt.fields.alg,
+			}
+			got, err := r.ChangeSMSConfigHTTPSecret(tt.args.ctx, tt.args.instanceID, tt.args.id, tt.args.secret)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func newSMSConfigHTTPAddedEvent(id string, secret *crypto.CryptoValue) *instance.SMSConfigHTTPAddedEvent {
+	return instance.NewSMSConfigHTTPAddedEvent(
+		context.Background(),
+		&instance.NewAggregate("INSTANCE").Aggregate,
+		id,
+		"https://sms.local/send",
+		http.MethodPost,
+		nil,
+		"{{.Content}}",
+		domain.SMSHTTPAuthTypeNone,
+		"",
+		secret,
+		nil,
+		"",
+	)
+}
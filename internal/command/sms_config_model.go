@@ -2,10 +2,14 @@ package command
 
 import (
 	"context"
+	"maps"
+	"net/http"
+	"slices"
 
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/notification/channels/httpsms"
 	"github.com/zitadel/zitadel/internal/repository/instance"
 )
 
@@ -14,6 +18,7 @@ type IAMSMSConfigWriteModel struct {
 
 	ID     string
 	Twilio *TwilioConfig
+	HTTP   *HTTPSMSConfig
 	State  domain.SMSConfigState
 }
 
@@ -23,6 +28,18 @@ type TwilioConfig struct {
 	SenderNumber string
 }
 
+type HTTPSMSConfig struct {
+	URL                string
+	Method             string
+	Headers            map[string]*crypto.CryptoValue
+	BodyTemplate       string
+	AuthType           domain.SMSHTTPAuthType
+	Username           string
+	Secret             *crypto.CryptoValue
+	SuccessStatusCodes []int
+	SuccessBodyPattern string
+}
+
 func NewIAMSMSConfigWriteModel(instanceID, id string) *IAMSMSConfigWriteModel {
 	return &IAMSMSConfigWriteModel{
 		WriteModel: eventstore.WriteModel{
@@ -62,6 +79,32 @@ func (wm *IAMSMSConfigWriteModel) Reduce() error {
 				continue
 			}
 			wm.Twilio.Token = e.Token
+		case *instance.SMSConfigHTTPAddedEvent:
+			if wm.ID != e.ID {
+				continue
+			}
+			wm.HTTP = &HTTPSMSConfig{
+				URL:                e.URL,
+				Method:             e.Method,
+				Headers:            e.Headers,
+				BodyTemplate:       e.BodyTemplate,
+				AuthType:           e.AuthType,
+				Username:           e.Username,
+				Secret:             e.Secret,
+				SuccessStatusCodes: e.SuccessStatusCodes,
+				SuccessBodyPattern: e.SuccessBodyPattern,
+			}
+			wm.State = domain.SMSConfigStateInactive
+		case *instance.SMSConfigHTTPChangedEvent:
+			if wm.ID != e.ID {
+				continue
+			}
+			wm.reduceHTTPChanged(e)
+		case *instance.SMSConfigHTTPSecretChangedEvent:
+			if wm.ID != e.ID {
+				continue
+			}
+			wm.HTTP.Secret = e.Secret
 		case *instance.SMSConfigActivatedEvent:
 			if wm.ID != e.ID {
 				continue
@@ -77,11 +120,40 @@ func (wm *IAMSMSConfigWriteModel) Reduce() error {
 				continue
 			}
 			wm.Twilio = nil
+			wm.HTTP = nil
 			wm.State = domain.SMSConfigStateRemoved
 		}
 	}
 	return wm.WriteModel.Reduce()
 }
+
+func (wm *IAMSMSConfigWriteModel) reduceHTTPChanged(e *instance.SMSConfigHTTPChangedEvent) {
+	if e.URL != nil {
+		wm.HTTP.URL = *e.URL
+	}
+	if e.Method != nil {
+		wm.HTTP.Method = *e.Method
+	}
+	if e.Headers != nil {
+		wm.HTTP.Headers = *e.Headers
+	}
+	if e.BodyTemplate != nil {
+		wm.HTTP.BodyTemplate = *e.BodyTemplate
+	}
+	if e.AuthType != nil {
+		wm.HTTP.AuthType = *e.AuthType
+	}
+	if e.Username != nil {
+		wm.HTTP.Username = *e.Username
+	}
+	if e.SuccessStatusCodes != nil {
+		wm.HTTP.SuccessStatusCodes = *e.SuccessStatusCodes
+	}
+	if e.SuccessBodyPattern != nil {
+		wm.HTTP.SuccessBodyPattern = *e.SuccessBodyPattern
+	}
+}
+
 func (wm *IAMSMSConfigWriteModel) Query() *eventstore.SearchQueryBuilder {
 	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
 		ResourceOwner(wm.ResourceOwner).
@@ -92,6 +164,9 @@ func (wm *IAMSMSConfigWriteModel) Query() *eventstore.SearchQueryBuilder {
 			instance.SMSConfigTwilioAddedEventType,
 			instance.SMSConfigTwilioChangedEventType,
 			instance.SMSConfigTwilioTokenChangedEventType,
+			instance.SMSConfigHTTPAddedEventType,
+			instance.SMSConfigHTTPChangedEventType,
+			instance.SMSConfigHTTPSecretChangedEventType,
 			instance.SMSConfigActivatedEventType,
 			instance.SMSConfigDeactivatedEventType,
 			instance.SMSConfigRemovedEventType).
@@ -118,3 +193,55 @@ func (wm *IAMSMSConfigWriteModel) NewChangedEvent(ctx context.Context, aggregate
 	}
 	return changeEvent, true, nil
 }
+
+func (wm *IAMSMSConfigWriteModel) NewHTTPChangedEvent(ctx context.Context, aggregate *eventstore.Aggregate, id string, config *httpsms.Config, alg crypto.EncryptionAlgorithm) (*instance.SMSConfigHTTPChangedEvent, bool, error) {
+	changes := make([]instance.SMSConfigHTTPChanges, 0)
+
+	if wm.HTTP.URL != config.URL {
+		changes = append(changes, instance.ChangeSMSConfigHTTPURL(config.URL))
+	}
+	if wm.HTTP.Method != config.Method {
+		changes = append(changes, instance.ChangeSMSConfigHTTPMethod(config.Method))
+	}
+	// the values of the headers are encrypted, so the decrypted headers are compared
+	headers, err := httpsms.DecryptHeaders(wm.HTTP.Headers, alg)
+	if err != nil || !maps.EqualFunc(headers, canonicalHeaders(config.Headers), slices.Equal[[]string]) {
+		encrypted, err := httpsms.EncryptHeaders(config.Headers, alg)
+		if err != nil {
+			return nil, false, err
+		}
+		changes = append(changes, instance.ChangeSMSConfigHTTPHeaders(encrypted))
+	}
+	if wm.HTTP.BodyTemplate != config.BodyTemplate {
+		changes = append(changes, instance.ChangeSMSConfigHTTPBodyTemplate(config.BodyTemplate))
+	}
+	if wm.HTTP.AuthType != config.AuthType {
+		changes = append(changes, instance.ChangeSMSConfigHTTPAuthType(config.AuthType))
+	}
+	if wm.HTTP.Username != config.Username {
+		changes = append(changes, instance.ChangeSMSConfigHTTPUsername(config.Username))
+	}
+	if !slices.Equal(wm.HTTP.SuccessStatusCodes, config.SuccessStatusCodes) {
+		changes = append(changes, instance.ChangeSMSConfigHTTPSuccessStatusCodes(config.SuccessStatusCodes))
+	}
+	if wm.HTTP.SuccessBodyPattern != config.SuccessBodyPattern {
+		changes = append(changes, instance.ChangeSMSConfigHTTPSuccessBodyPattern(config.SuccessBodyPattern))
+	}
+
+	if len(changes) == 0 {
+		return nil, false, nil
+	}
+	changeEvent, err := instance.NewSMSConfigHTTPChangedEvent(ctx, aggregate, id, changes)
+	if err != nil {
+		return nil, false, err
+	}
+	return changeEvent, true, nil
+}
+
+func canonicalHeaders(headers http.Header) http.Header {
+	canonical := make(http.Header, len(headers))
+	for key, values := range headers {
+		canonical[http.CanonicalHeaderKey(key)] = values
+	}
+	return canonical
+}
@@ -15,27 +15,44 @@ import (
 	"github.com/zitadel/zitadel/internal/repository/instance"
 )
 
-func (c *Commands) AddSMTPConfig(ctx context.Context, config *smtp.Config) (*domain.ObjectDetails, error) {
-	instanceAgg := instance.NewAggregate(authz.GetInstance(ctx).InstanceID())
-	validation := c.prepareAddSMTPConfig(instanceAgg, config.From, config.FromName, config.ReplyToAddress, config.SMTP.Host, config.SMTP.User, []byte(config.SMTP.Password), config.Tls)
+// AddSMTPConfig adds an SMTP config to the instance.
+// The first config of an instance gets the id of the instance,
+// so it can be managed without passing its id like before an instance could have multiple configs.
+func (c *Commands) AddSMTPConfig(ctx context.Context, config *smtp.Config) (string, *domain.ObjectDetails, error) {
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	instanceAgg := instance.NewAggregate(instanceID)
+	defaultConfig, err := getSMTPConfigWriteModel(ctx, c.eventstore.Filter, instanceID, "")
+	if err != nil {
+		return "", nil, err
+	}
+	id := instanceID
+	if defaultConfig.State == domain.SMTPConfigStateActive {
+		id, err = c.idGenerator.Next()
+		if err != nil {
+			return "", nil, err
+		}
+	}
+	validation := c.prepareAddSMTPConfig(instanceAgg, id, config.From, config.FromName, config.ReplyToAddress, config.SMTP.Host, config.SMTP.User, []byte(config.SMTP.Password), config.Tls)
 	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, validation)
 	if err != nil {
-		return nil, err
+		return "", nil, err
 	}
 	events, err := c.eventstore.Push(ctx, cmds...)
 	if err != nil {
-		return nil, err
+		return "", nil, err
 	}
-	return &domain.ObjectDetails{
+	return id, &domain.ObjectDetails{
 		Sequence:      events[len(events)-1].Sequence(),
 		EventDate:     events[len(events)-1].CreatedAt(),
 		ResourceOwner: events[len(events)-1].Aggregate().InstanceID,
 	}, nil
 }
 
-func (c *Commands) ChangeSMTPConfig(ctx context.Context, config *smtp.Config) (*domain.ObjectDetails, error) {
+// ChangeSMTPConfig changes the SMTP config with the id,
+// the config with the id of the instance is changed if the id is empty
+func (c *Commands) ChangeSMTPConfig(ctx context.Context, id string, config *smtp.Config) (*domain.ObjectDetails, error) {
 	instanceAgg := instance.NewAggregate(authz.GetInstance(ctx).InstanceID())
-	validation := c.prepareChangeSMTPConfig(instanceAgg, config.From, config.FromName, config.ReplyToAddress, config.SMTP.Host, config.SMTP.User, config.Tls)
+	validation := c.prepareChangeSMTPConfig(instanceAgg, smtpConfigIDOrDefault(ctx, id), config.From, config.FromName, config.ReplyToAddress, config.SMTP.Host, config.SMTP.User, config.Tls)
 	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, validation)
 	if err != nil {
 		return nil, err
@@ -51,9 +68,10 @@ func (c *Commands) ChangeSMTPConfig(ctx context.Context, config *smtp.Config) (*
 	}, nil
 }
 
-func (c *Commands) ChangeSMTPConfigPassword(ctx context.Context, password string) (*domain.ObjectDetails, error) {
+func (c *Commands) ChangeSMTPConfigPassword(ctx context.Context, id, password string) (*domain.ObjectDetails, error) {
 	instanceAgg := instance.NewAggregate(authz.GetInstance(ctx).InstanceID())
-	smtpConfigWriteModel, err := getSMTPConfigWriteModel(ctx, c.eventstore.Filter, "")
+	id = smtpConfigIDOrDefault(ctx, id)
+	smtpConfigWriteModel, err := getSMTPConfigWriteModel(ctx, c.eventstore.Filter, id, "")
 	if err != nil {
 		return nil, err
 	}
@@ -70,6 +88,7 @@ func (c *Commands) ChangeSMTPConfigPassword(ctx context.Context, password string
 	events, err := c.eventstore.Push(ctx, instance.NewSMTPConfigPasswordChangedEvent(
 		ctx,
 		&instanceAgg.Aggregate,
+		id,
 		smtpPassword))
 	if err != nil {
 		return nil, err
@@ -81,9 +100,9 @@ func (c *Commands) ChangeSMTPConfigPassword(ctx context.Context, password string
 	}, nil
 }
 
-func (c *Commands) RemoveSMTPConfig(ctx context.Context) (*domain.ObjectDetails, error) {
+func (c *Commands) RemoveSMTPConfig(ctx context.Context, id string) (*domain.ObjectDetails, error) {
 	instanceAgg := instance.NewAggregate(authz.GetInstance(ctx).InstanceID())
-	validation := c.prepareRemoveSMTPConfig(instanceAgg)
+	validation := c.prepareRemoveSMTPConfig(instanceAgg, smtpConfigIDOrDefault(ctx, id))
 	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, validation)
 	if err != nil {
 		return nil, err
@@ -99,7 +118,7 @@ func (c *Commands) RemoveSMTPConfig(ctx context.Context) (*domain.ObjectDetails,
 	}, nil
 }
 
-func (c *Commands) prepareAddSMTPConfig(a *instance.Aggregate, from, name, replyTo, hostAndPort, user string, password []byte, tls bool) preparation.Validation {
+func (c *Commands) prepareAddSMTPConfig(a *instance.Aggregate, id, from, name, replyTo, hostAndPort, user string, password []byte, tls bool) preparation.Validation {
 	return func() (preparation.CreateCommands, error) {
 		if from = strings.TrimSpace(from); from == "" {
 			return nil, errors.ThrowInvalidArgument(nil, "INST-mruNY", "Errors.Invalid.Argument")
@@ -114,7 +133,7 @@ func (c *Commands) prepareAddSMTPConfig(a *instance.Aggregate, from, name, reply
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
 			fromSplitted := strings.Split(from, "@")
 			senderDomain := fromSplitted[len(fromSplitted)-1]
-			writeModel, err := getSMTPConfigWriteModel(ctx, filter, senderDomain)
+			writeModel, err := getSMTPConfigWriteModel(ctx, filter, id, senderDomain)
 			if err != nil {
 				return nil, err
 			}
@@ -136,6 +155,7 @@ func (c *Commands) prepareAddSMTPConfig(a *instance.Aggregate, from, name, reply
 				instance.NewSMTPConfigAddedEvent(
 					ctx,
 					&a.Aggregate,
+					id,
 					tls,
 					from,
 					name,
@@ -149,7 +169,7 @@ func (c *Commands) prepareAddSMTPConfig(a *instance.Aggregate, from, name, reply
 	}
 }
 
-func (c *Commands) prepareChangeSMTPConfig(a *instance.Aggregate, from, name, replyTo, hostAndPort, user string, tls bool) preparation.Validation {
+func (c *Commands) prepareChangeSMTPConfig(a *instance.Aggregate, id, from, name, replyTo, hostAndPort, user string, tls bool) preparation.Validation {
 	return func() (preparation.CreateCommands, error) {
 		if from = strings.TrimSpace(from); from == "" {
 			return nil, errors.ThrowInvalidArgument(nil, "INST-ASv2d", "Errors.Invalid.Argument")
@@ -163,7 +183,7 @@ func (c *Commands) prepareChangeSMTPConfig(a *instance.Aggregate, from, name, re
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
 			fromSplitted := strings.Split(from, "@")
 			senderDomain := fromSplitted[len(fromSplitted)-1]
-			writeModel, err := getSMTPConfigWriteModel(ctx, filter, senderDomain)
+			writeModel, err := getSMTPConfigWriteModel(ctx, filter, id, senderDomain)
 			if err != nil {
 				return nil, err
 			}
@@ -197,10 +217,10 @@ func (c *Commands) prepareChangeSMTPConfig(a *instance.Aggregate, from, name, re
 	}
 }
 
-func (c *Commands) prepareRemoveSMTPConfig(a *instance.Aggregate) preparation.Validation {
+func (c *Commands) prepareRemoveSMTPConfig(a *instance.Aggregate, id string) preparation.Validation {
 	return func() (preparation.CreateCommands, error) {
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
-			writeModel, err := getSMTPConfigWriteModel(ctx, filter, "")
+			writeModel, err := getSMTPConfigWriteModel(ctx, filter, id, "")
 			if err != nil {
 				return nil, err
 			}
@@ -208,7 +228,7 @@ func (c *Commands) prepareRemoveSMTPConfig(a *instance.Aggregate) preparation.Va
 				return nil, errors.ThrowNotFound(nil, "INST-Sfefg", "Errors.SMTPConfig.NotFound")
 			}
 			return []eventstore.Command{
-				instance.NewSMTPConfigRemovedEvent(ctx, &a.Aggregate),
+				instance.NewSMTPConfigRemovedEvent(ctx, &a.Aggregate, id),
 			}, nil
 		}, nil
 	}
@@ -224,8 +244,20 @@ func checkSenderAddress(writeModel *InstanceSMTPConfigWriteModel) error {
 	return nil
 }
 
-func getSMTPConfigWriteModel(ctx context.Context, filter preparation.FilterToQueryReducer, domain string) (_ *InstanceSMTPConfigWriteModel, err error) {
-	writeModel := NewInstanceSMTPConfigWriteModel(authz.GetInstance(ctx).InstanceID(), domain)
+// smtpConfigIDOrDefault returns the id of the instance if no id is passed
+func smtpConfigIDOrDefault(ctx context.Context, id string) string {
+	if id != "" {
+		return id
+	}
+	return authz.GetInstance(ctx).InstanceID()
+}
+
+func getSMTPConfigWriteModel(ctx context.Context, filter preparation.FilterToQueryReducer, id, domain string) (_ *InstanceSMTPConfigWriteModel, err error) {
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	if id == "" {
+		id = instanceID
+	}
+	writeModel := NewInstanceSMTPConfigWriteModel(instanceID, id, domain)
 	events, err := filter(ctx, writeModel.Query())
 	if err != nil {
 		return nil, err
@@ -12,22 +12,26 @@ import (
 	"github.com/zitadel/zitadel/internal/domain"
 	caos_errs "github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
 	"github.com/zitadel/zitadel/internal/notification/channels/smtp"
 	"github.com/zitadel/zitadel/internal/repository/instance"
 )
 
 func TestCommandSide_AddSMTPConfig(t *testing.T) {
 	type fields struct {
-		eventstore *eventstore.Eventstore
-		alg        crypto.EncryptionAlgorithm
+		eventstore  *eventstore.Eventstore
+		idGenerator id.Generator
+		alg         crypto.EncryptionAlgorithm
 	}
 	type args struct {
 		ctx  context.Context
 		smtp *smtp.Config
 	}
 	type res struct {
-		want *domain.ObjectDetails
-		err  func(error) bool
+		wantID string
+		want   *domain.ObjectDetails
+		err    func(error) bool
 	}
 	tests := []struct {
 		name   string
@@ -40,6 +44,7 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 			fields: fields{
 				eventstore: eventstoreExpect(
 					t,
+					expectFilter(),
 					expectFilter(
 						eventFromEventPusher(
 							instance.NewDomainPolicyAddedEvent(context.Background(),
@@ -70,10 +75,25 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 			},
 		},
 		{
-			name: "smtp config, error already exists",
+			name: "add second smtp config, ok",
 			fields: fields{
 				eventstore: eventstoreExpect(
 					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSMTPConfigAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"INSTANCE",
+								true,
+								"from@domain.ch",
+								"name",
+								"",
+								"host:587",
+								"user",
+								&crypto.CryptoValue{},
+							),
+						),
+					),
 					expectFilter(
 						eventFromEventPusher(
 							instance.NewDomainAddedEvent(context.Background(),
@@ -91,6 +111,7 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 						eventFromEventPusher(
 							instance.NewSMTPConfigAddedEvent(context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
+								"INSTANCE",
 								true,
 								"from@domain.ch",
 								"name",
@@ -101,24 +122,47 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 							),
 						),
 					),
+					expectPush(
+						instance.NewSMTPConfigAddedEvent(
+							context.Background(),
+							&instance.NewAggregate("INSTANCE").Aggregate,
+							"configID",
+							true,
+							"from2@domain.ch",
+							"name2",
+							"",
+							"host2:587",
+							"user2",
+							&crypto.CryptoValue{
+								CryptoType: crypto.TypeEncryption,
+								Algorithm:  "enc",
+								KeyID:      "id",
+								Crypted:    []byte("password"),
+							},
+						),
+					),
 				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "configID"),
+				alg:         crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
 			},
 			args: args{
 				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
 				smtp: &smtp.Config{
-					Tls:            true,
-					From:           "from@domain.ch",
-					FromName:       "name",
-					ReplyToAddress: "",
+					Tls:      true,
+					From:     "from2@domain.ch",
+					FromName: "name2",
 					SMTP: smtp.SMTP{
-						Host:     "host:587",
-						User:     "user",
+						Host:     "host2:587",
+						User:     "user2",
 						Password: "password",
 					},
 				},
 			},
 			res: res{
-				err: caos_errs.IsErrorAlreadyExists,
+				wantID: "configID",
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
 			},
 		},
 		{
@@ -126,6 +170,7 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 			fields: fields{
 				eventstore: eventstoreExpect(
 					t,
+					expectFilter(),
 					expectFilter(
 						eventFromEventPusher(
 							instance.NewDomainAddedEvent(context.Background(),
@@ -145,6 +190,7 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 						instance.NewSMTPConfigAddedEvent(
 							context.Background(),
 							&instance.NewAggregate("INSTANCE").Aggregate,
+							"INSTANCE",
 							true,
 							"from@domain.ch",
 							"name",
@@ -176,6 +222,7 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 				},
 			},
 			res: res{
+				wantID: "INSTANCE",
 				want: &domain.ObjectDetails{
 					ResourceOwner: "INSTANCE",
 				},
@@ -186,6 +233,7 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 			fields: fields{
 				eventstore: eventstoreExpect(
 					t,
+					expectFilter(),
 					expectFilter(
 						eventFromEventPusher(
 							instance.NewDomainAddedEvent(context.Background(),
@@ -205,6 +253,7 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 						instance.NewSMTPConfigAddedEvent(
 							context.Background(),
 							&instance.NewAggregate("INSTANCE").Aggregate,
+							"INSTANCE",
 							true,
 							"from@domain.ch",
 							"name",
@@ -237,6 +286,7 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 				},
 			},
 			res: res{
+				wantID: "INSTANCE",
 				want: &domain.ObjectDetails{
 					ResourceOwner: "INSTANCE",
 				},
@@ -245,7 +295,7 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 		{
 			name: "smtp config, port is missing",
 			fields: fields{
-				eventstore: eventstoreExpect(t),
+				eventstore: eventstoreExpect(t, expectFilter()),
 			},
 			args: args{
 				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
@@ -267,7 +317,7 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 		{
 			name: "smtp config, host is empty",
 			fields: fields{
-				eventstore: eventstoreExpect(t),
+				eventstore: eventstoreExpect(t, expectFilter()),
 			},
 			args: args{
 				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
@@ -291,6 +341,7 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 			fields: fields{
 				eventstore: eventstoreExpect(
 					t,
+					expectFilter(),
 					expectFilter(
 						eventFromEventPusher(
 							instance.NewDomainAddedEvent(context.Background(),
@@ -310,6 +361,7 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 						instance.NewSMTPConfigAddedEvent(
 							context.Background(),
 							&instance.NewAggregate("INSTANCE").Aggregate,
+							"INSTANCE",
 							true,
 							"from@domain.ch",
 							"name",
@@ -341,6 +393,7 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 				},
 			},
 			res: res{
+				wantID: "INSTANCE",
 				want: &domain.ObjectDetails{
 					ResourceOwner: "INSTANCE",
 				},
@@ -351,9 +404,10 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			r := &Commands{
 				eventstore:     tt.fields.eventstore,
+				idGenerator:    tt.fields.idGenerator,
 				smtpEncryption: tt.fields.alg,
 			}
-			got, err := r.AddSMTPConfig(tt.args.ctx, tt.args.smtp)
+			id, got, err := r.AddSMTPConfig(tt.args.ctx, tt.args.smtp)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -361,6 +415,7 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 				t.Errorf("got wrong err: %v ", err)
 			}
 			if tt.res.err == nil {
+				assert.Equal(t, tt.res.wantID, id)
 				assert.Equal(t, tt.res.want, got)
 			}
 		})
@@ -447,6 +502,7 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 							instance.NewSMTPConfigAddedEvent(
 								context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
+								"INSTANCE",
 								true,
 								"from@domain.ch",
 								"name",
@@ -498,6 +554,7 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 							instance.NewSMTPConfigAddedEvent(
 								context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
+								"INSTANCE",
 								true,
 								"from@domain.ch",
 								"name",
@@ -549,6 +606,7 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 							instance.NewSMTPConfigAddedEvent(
 								context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
+								"INSTANCE",
 								true,
 								"from@domain.ch",
 								"name",
@@ -658,6 +716,7 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 							instance.NewSMTPConfigAddedEvent(
 								context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
+								"INSTANCE",
 								true,
 								"from@domain.ch",
 								"name",
@@ -706,7 +765,7 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 			r := &Commands{
 				eventstore: tt.fields.eventstore,
 			}
-			got, err := r.ChangeSMTPConfig(tt.args.ctx, tt.args.smtp)
+			got, err := r.ChangeSMTPConfig(tt.args.ctx, "", tt.args.smtp)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -765,6 +824,7 @@ func TestCommandSide_ChangeSMTPConfigPassword(t *testing.T) {
 							instance.NewSMTPConfigAddedEvent(
 								context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
+								"INSTANCE",
 								true,
 								"from",
 								"name",
@@ -779,6 +839,7 @@ func TestCommandSide_ChangeSMTPConfigPassword(t *testing.T) {
 						instance.NewSMTPConfigPasswordChangedEvent(
 							context.Background(),
 							&instance.NewAggregate("INSTANCE").Aggregate,
+							"INSTANCE",
 							&crypto.CryptoValue{
 								CryptoType: crypto.TypeEncryption,
 								Algorithm:  "enc",
@@ -807,7 +868,7 @@ func TestCommandSide_ChangeSMTPConfigPassword(t *testing.T) {
 				eventstore:     tt.fields.eventstore,
 				smtpEncryption: tt.fields.alg,
 			}
-			got, err := r.ChangeSMTPConfigPassword(tt.args.ctx, tt.args.password)
+			got, err := r.ChangeSMTPConfigPassword(tt.args.ctx, "", tt.args.password)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -864,6 +925,7 @@ func TestCommandSide_RemoveSMTPConfig(t *testing.T) {
 							instance.NewSMTPConfigAddedEvent(
 								context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
+								"INSTANCE",
 								true,
 								"from",
 								"name",
@@ -878,6 +940,7 @@ func TestCommandSide_RemoveSMTPConfig(t *testing.T) {
 						instance.NewSMTPConfigRemovedEvent(
 							context.Background(),
 							&instance.NewAggregate("INSTANCE").Aggregate,
+							"INSTANCE",
 						),
 					),
 				),
@@ -898,7 +961,7 @@ func TestCommandSide_RemoveSMTPConfig(t *testing.T) {
 				eventstore:     tt.fields.eventstore,
 				smtpEncryption: tt.fields.alg,
 			}
-			got, err := r.RemoveSMTPConfig(tt.args.ctx)
+			got, err := r.RemoveSMTPConfig(tt.args.ctx, "")
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -923,6 +986,7 @@ func newSMTPConfigChangedEvent(ctx context.Context, tls bool, fromAddress, fromN
 	}
 	event, _ := instance.NewSMTPConfigChangeEvent(ctx,
 		&instance.NewAggregate("INSTANCE").Aggregate,
+		"INSTANCE",
 		changes,
 	)
 	return event
@@ -0,0 +1,118 @@
+package command
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/target"
+)
+
+type Target struct {
+	// ID is generated if empty, the existing target is replaced otherwise
+	ID       string
+	Name     string
+	Endpoint string
+	Timeout  time.Duration
+	// InterruptOnError stops the call or event if the target fails or is not reachable
+	InterruptOnError bool
+	// SigningKey is required for new targets, the existing key is kept if it's empty
+	SigningKey string
+}
+
+func (t *Target) IsValid() error {
+	if t.Name == "" {
+		return errors.ThrowInvalidArgument(nil, "COMMAND-iePh8", "Errors.Target.Invalid.Name")
+	}
+	endpoint, err := url.Parse(t.Endpoint)
+	if err != nil || endpoint.Host == "" || (endpoint.Scheme != "http" && endpoint.Scheme != "https") {
+		return errors.ThrowInvalidArgument(err, "COMMAND-Ahp8o", "Errors.Target.Invalid.Endpoint")
+	}
+	if t.Timeout <= 0 {
+		return errors.ThrowInvalidArgument(nil, "COMMAND-aiW9e", "Errors.Target.Invalid.Timeout")
+	}
+	return nil
+}
+
+// SetTarget creates a new target of the instance if no ID is provided or replaces the existing target
+func (c *Commands) SetTarget(ctx context.Context, t *Target) (_ *domain.ObjectDetails, err error) {
+	if err = t.IsValid(); err != nil {
+		return nil, err
+	}
+	exists := t.ID != ""
+	if !exists {
+		t.ID, err = c.idGenerator.Next()
+		if err != nil {
+			return nil, err
+		}
+	}
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	wm, err := c.getTargetWriteModel(ctx, instanceID, t.ID)
+	if err != nil {
+		return nil, err
+	}
+	if exists && !wm.State.Exists() {
+		return nil, errors.ThrowNotFound(nil, "COMMAND-Oom4a", "Errors.Target.NotFound")
+	}
+	signingKey := wm.SigningKey
+	if t.SigningKey != "" {
+		signingKey, err = crypto.Encrypt([]byte(t.SigningKey), c.keyAlgorithm)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if signingKey == nil {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Xoo5i", "Errors.Target.Invalid.SigningKey")
+	}
+	aggregate := target.NewAggregate(t.ID, instanceID)
+	pushedEvents, err := c.eventstore.Push(ctx, target.NewSetEvent(
+		ctx,
+		&aggregate.Aggregate,
+		t.Name,
+		t.Endpoint,
+		t.Timeout,
+		t.InterruptOnError,
+		signingKey,
+	))
+	if err != nil {
+		return nil, err
+	}
+	if err = AppendAndReduce(wm, pushedEvents...); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&wm.WriteModel), nil
+}
+
+// RemoveTarget removes the target of the instance,
+// executions still referencing the target skip it
+func (c *Commands) RemoveTarget(ctx context.Context, targetID string) (*domain.ObjectDetails, error) {
+	if targetID == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-eeTh2", "Errors.IDMissing")
+	}
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	wm, err := c.getTargetWriteModel(ctx, instanceID, targetID)
+	if err != nil {
+		return nil, err
+	}
+	if !wm.State.Exists() {
+		return nil, errors.ThrowNotFound(nil, "COMMAND-Ri7ae", "Errors.Target.NotFound")
+	}
+	aggregate := target.NewAggregate(targetID, instanceID)
+	pushedEvents, err := c.eventstore.Push(ctx, target.NewRemovedEvent(ctx, &aggregate.Aggregate))
+	if err != nil {
+		return nil, err
+	}
+	if err = AppendAndReduce(wm, pushedEvents...); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&wm.WriteModel), nil
+}
+
+func (c *Commands) getTargetWriteModel(ctx context.Context, instanceID, targetID string) (*targetWriteModel, error) {
+	wm := newTargetWriteModel(instanceID, targetID)
+	return wm, c.eventstore.FilterToQueryReducer(ctx, wm)
+}
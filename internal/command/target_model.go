@@ -0,0 +1,63 @@
+package command
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/target"
+)
+
+type targetWriteModel struct {
+	eventstore.WriteModel
+
+	Name             string
+	Endpoint         string
+	Timeout          time.Duration
+	InterruptOnError bool
+	SigningKey       *crypto.CryptoValue
+	State            domain.TargetState
+}
+
+func newTargetWriteModel(instanceID, targetID string) *targetWriteModel {
+	return &targetWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   targetID,
+			InstanceID:    instanceID,
+			ResourceOwner: instanceID,
+		},
+	}
+}
+
+func (wm *targetWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		InstanceID(wm.InstanceID).
+		AddQuery().
+		AggregateTypes(target.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			target.SetEventType,
+			target.RemovedEventType,
+		).
+		Builder()
+}
+
+func (wm *targetWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *target.SetEvent:
+			wm.Name = e.Name
+			wm.Endpoint = e.Endpoint
+			wm.Timeout = e.Timeout
+			wm.InterruptOnError = e.InterruptOnError
+			wm.SigningKey = e.SigningKey
+			wm.State = domain.TargetStateActive
+		case *target.RemovedEvent:
+			wm.SigningKey = nil
+			wm.State = domain.TargetStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
@@ -0,0 +1,302 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errors "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/target"
+)
+
+func TestCommands_SetTarget(t *testing.T) {
+	signingKey := &crypto.CryptoValue{
+		CryptoType: crypto.TypeEncryption,
+		Algorithm:  "enc",
+		KeyID:      "id",
+		Crypted:    []byte("key"),
+	}
+	type fields struct {
+		eventstore  func(*testing.T) *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		ctx    context.Context
+		target *Target
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "invalid name, error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx:    authz.WithInstanceID(context.Background(), "instance1"),
+				target: &Target{Endpoint: "https://example.com", Timeout: time.Second},
+			},
+			res: res{
+				err: caos_errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "invalid endpoint, error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx:    authz.WithInstanceID(context.Background(), "instance1"),
+				target: &Target{Name: "target", Endpoint: "nats://localhost:4222", Timeout: time.Second},
+			},
+			res: res{
+				err: caos_errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "missing timeout, error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx:    authz.WithInstanceID(context.Background(), "instance1"),
+				target: &Target{Name: "target", Endpoint: "https://example.com"},
+			},
+			res: res{
+				err: caos_errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "missing signing key, error",
+			fields: fields{
+				eventstore:  expectEventstore(expectFilter()),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "target1"),
+			},
+			args: args{
+				ctx:    authz.WithInstanceID(context.Background(), "instance1"),
+				target: &Target{Name: "target", Endpoint: "https://example.com", Timeout: time.Second},
+			},
+			res: res{
+				err: caos_errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "not existing, not found error",
+			fields: fields{
+				eventstore: expectEventstore(expectFilter()),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				target: &Target{
+					ID:         "target1",
+					Name:       "target",
+					Endpoint:   "https://example.com",
+					Timeout:    time.Second,
+					SigningKey: "key",
+				},
+			},
+			res: res{
+				err: caos_errors.IsNotFound,
+			},
+		},
+		{
+			name: "add, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectPush(
+						target.NewSetEvent(context.Background(),
+							&target.NewAggregate("target1", "instance1").Aggregate,
+							"target",
+							"https://example.com",
+							time.Second,
+							true,
+							signingKey,
+						),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "target1"),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				target: &Target{
+					Name:             "target",
+					Endpoint:         "https://example.com",
+					Timeout:          time.Second,
+					InterruptOnError: true,
+					SigningKey:       "key",
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "instance1",
+				},
+			},
+		},
+		{
+			name: "change, signing key kept, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							target.NewSetEvent(context.Background(),
+								&target.NewAggregate("target1", "instance1").Aggregate,
+								"target",
+								"https://example.com",
+								time.Second,
+								false,
+								signingKey,
+							),
+						),
+					),
+					expectPush(
+						target.NewSetEvent(context.Background(),
+							&target.NewAggregate("target1", "instance1").Aggregate,
+							"target",
+							"https://example.com/v2",
+							5*time.Second,
+							false,
+							signingKey,
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				target: &Target{
+					ID:       "target1",
+					Name:     "target",
+					Endpoint: "https://example.com/v2",
+					Timeout:  5 * time.Second,
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "instance1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:   tt.fields.eventstore(t),
+				idGenerator:  tt.fields.idGenerator,
+				keyAlgorithm: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			}
+			got, err := c.SetTarget(tt.args.ctx, tt.args.target)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommands_RemoveTarget(t *testing.T) {
+	type args struct {
+		ctx      context.Context
+		targetID string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name       string
+		eventstore func(*testing.T) *eventstore.Eventstore
+		args       args
+		res        res
+	}{
+		{
+			name:       "missing id, error",
+			eventstore: expectEventstore(),
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+			},
+			res: res{
+				err: caos_errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name:       "not existing, not found error",
+			eventstore: expectEventstore(expectFilter()),
+			args: args{
+				ctx:      authz.WithInstanceID(context.Background(), "instance1"),
+				targetID: "target1",
+			},
+			res: res{
+				err: caos_errors.IsNotFound,
+			},
+		},
+		{
+			name: "remove, ok",
+			eventstore: expectEventstore(
+				expectFilter(
+					eventFromEventPusher(
+						target.NewSetEvent(context.Background(),
+							&target.NewAggregate("target1", "instance1").Aggregate,
+							"target",
+							"https://example.com",
+							time.Second,
+							false,
+							nil,
+						),
+					),
+				),
+				expectPush(
+					target.NewRemovedEvent(context.Background(),
+						&target.NewAggregate("target1", "instance1").Aggregate,
+					),
+				),
+			),
+			args: args{
+				ctx:      authz.WithInstanceID(context.Background(), "instance1"),
+				targetID: "target1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "instance1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.eventstore(t),
+			}
+			got, err := c.RemoveTarget(tt.args.ctx, tt.args.targetID)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
@@ -232,6 +232,40 @@ func (c *Commands) RemoveUser(ctx context.Context, userID, resourceOwner string,
 	return writeModelToObjectDetails(&existingUser.WriteModel), nil
 }
 
+// ForgetUser destroys the data key of the removed user (crypto-shredding),
+// afterwards the personal data in the events of the user is redacted
+func (c *Commands) ForgetUser(ctx context.Context, userID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if userID == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Eoth4", "Errors.User.UserIDMissing")
+	}
+	existingUser, err := c.userWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if existingUser.UserState == domain.UserStateUnspecified {
+		return nil, errors.ThrowNotFound(nil, "COMMAND-ooG8i", "Errors.User.NotFound")
+	}
+	if isUserStateExists(existingUser.UserState) {
+		return nil, errors.ThrowPreconditionFailed(nil, "COMMAND-Thoh2", "Errors.User.NotRemoved")
+	}
+	// the key is destroyed first, so the user is never marked as forgotten while its data can still be read.
+	// Destroying is idempotent, so a failed push can be retried.
+	if err = c.eventstore.DestroyPersonalData(ctx, authz.GetInstance(ctx).InstanceID(), userID); err != nil {
+		return nil, err
+	}
+	if existingUser.Forgotten {
+		return writeModelToObjectDetails(&existingUser.WriteModel), nil
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, user.NewUserForgottenEvent(ctx, UserAggregateFromWriteModel(&existingUser.WriteModel)))
+	if err != nil {
+		return nil, err
+	}
+	if err = AppendAndReduce(existingUser, pushedEvents...); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingUser.WriteModel), nil
+}
+
 func (c *Commands) AddUserToken(ctx context.Context, orgID, agentID, clientID, userID string, audience, scopes []string, lifetime time.Duration) (*domain.Token, error) {
 	if userID == "" { //do not check for empty orgID (JWT Profile requests won't provide it, so service user requests fail)
 		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Dbge4", "Errors.IDMissing")
@@ -4,6 +4,7 @@ import (
 	"context"
 	"strings"
 
+	"github.com/zitadel/logging"
 	"golang.org/x/text/language"
 
 	"github.com/zitadel/zitadel/internal/command/preparation"
@@ -219,7 +220,7 @@ func (c *Commands) AddHumanCommand(human *AddHuman, orgID string, hasher *crypto
 				createCmd.AddPhoneData(human.Phone.Number)
 			}
 
-			if err := addHumanCommandPassword(ctx, filter, createCmd, human, hasher); err != nil {
+			if err := c.addHumanCommandPassword(ctx, filter, createCmd, human, hasher); err != nil {
 				return nil, err
 			}
 
@@ -327,9 +328,9 @@ func (c *Commands) addHumanCommandCheckID(ctx context.Context, filter preparatio
 	return nil
 }
 
-func addHumanCommandPassword(ctx context.Context, filter preparation.FilterToQueryReducer, createCmd humanCreationCommand, human *AddHuman, hasher *crypto.PasswordHasher) (err error) {
+func (c *Commands) addHumanCommandPassword(ctx context.Context, filter preparation.FilterToQueryReducer, createCmd humanCreationCommand, human *AddHuman, hasher *crypto.PasswordHasher) (err error) {
 	if human.Password != "" {
-		if err = humanValidatePassword(ctx, filter, human.Password); err != nil {
+		if err = c.humanValidatePassword(ctx, filter, human.Password); err != nil {
 			return err
 		}
 
@@ -374,13 +375,16 @@ func userValidateDomain(ctx context.Context, a *user.Aggregate, username string,
 	return nil
 }
 
-func humanValidatePassword(ctx context.Context, filter preparation.FilterToQueryReducer, password string) error {
+func (c *Commands) humanValidatePassword(ctx context.Context, filter preparation.FilterToQueryReducer, password string) error {
 	passwordComplexity, err := passwordComplexityPolicyWriteModel(ctx, filter)
 	if err != nil {
 		return err
 	}
 
-	return passwordComplexity.Validate(password)
+	if err = passwordComplexity.Validate(password); err != nil {
+		return err
+	}
+	return c.checkPasswordBreached(ctx, passwordComplexity.CheckBreached, password)
 }
 
 func (h *AddHuman) ensureDisplayName() {
@@ -593,6 +597,11 @@ func (c *Commands) createHuman(ctx context.Context, orgID string, human *domain.
 		if err := human.HashPasswordIfExisting(pwPolicy, c.userPasswordHasher, human.Password.ChangeRequired); err != nil {
 			return nil, nil, err
 		}
+		if human.Password.SecretString != "" {
+			if err := c.checkPasswordBreached(ctx, pwPolicy.CheckBreached, human.Password.SecretString); err != nil {
+				return nil, nil, err
+			}
+		}
 	}
 
 	addedHuman = NewHumanWriteModel(human.AggregateID, orgID)
@@ -739,6 +748,7 @@ func (c *Commands) HumansSignOut(ctx context.Context, agentID string, userIDs []
 		return errors.ThrowInvalidArgument(nil, "COMMAND-M0od3", "Errors.User.UserIDMissing")
 	}
 	events := make([]eventstore.Command, 0)
+	signedOutUsers := make([]*HumanWriteModel, 0, len(userIDs))
 	for _, userID := range userIDs {
 		existingUser, err := c.getHumanWriteModelByID(ctx, userID, "")
 		if err != nil {
@@ -751,12 +761,19 @@ func (c *Commands) HumansSignOut(ctx context.Context, agentID string, userIDs []
 			ctx,
 			UserAggregateFromWriteModel(&existingUser.WriteModel),
 			agentID))
+		signedOutUsers = append(signedOutUsers, existingUser)
 	}
 	if len(events) == 0 {
 		return nil
 	}
-	_, err := c.eventstore.Push(ctx, events...)
-	return err
+	if _, err := c.eventstore.Push(ctx, events...); err != nil {
+		return err
+	}
+	for _, signedOutUser := range signedOutUsers {
+		err := c.runPostLogoutActions(ctx, signedOutUser.AggregateID, signedOutUser.ResourceOwner, agentID, "", userIDs)
+		logging.WithFields("userID", signedOutUser.AggregateID).OnError(err).Warn("post logout actions failed")
+	}
+	return nil
 }
 
 func (c *Commands) getHumanWriteModelByID(ctx context.Context, userID, resourceowner string) (*HumanWriteModel, error) {
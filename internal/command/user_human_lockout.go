@@ -0,0 +1,97 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// lockoutCheck is the lockout state of a user for the check of a single authentication factor
+type lockoutCheck struct {
+	policy   *domain.LockoutPolicy
+	attempts lockoutAttempts
+	unlock   bool
+	exceeded func(*domain.LockoutPolicy, uint64) bool
+}
+
+// newLockoutCheck returns an error if the user is not allowed to check an authentication factor,
+// because it is locked or has to wait after previous failed attempts.
+// A user whose lock expired according to the policy will be unlocked with the events of the check.
+func newLockoutCheck(
+	policy *domain.LockoutPolicy,
+	locked bool,
+	lockedAt time.Time,
+	attempts lockoutAttempts,
+	exceeded func(*domain.LockoutPolicy, uint64) bool,
+	now time.Time,
+) (*lockoutCheck, error) {
+	check := &lockoutCheck{
+		policy:   policy,
+		attempts: attempts,
+		exceeded: exceeded,
+	}
+	if locked {
+		if !policy.IsAutoUnlocked(lockedAt, now) {
+			return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ohx4a", "Errors.User.Locked")
+		}
+		check.unlock = true
+		check.attempts = lockoutAttempts{}
+		return check, nil
+	}
+	if policy.IsAttemptDelayed(attempts.Failed, attempts.LastFailedAt, now) {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ieb0a", "Errors.User.TooManyFailedAttempts")
+	}
+	return check, nil
+}
+
+// unlockEvents returns the event lifting an expired lock, which has to be pushed before the result of the check
+func (l *lockoutCheck) unlockEvents(ctx context.Context, userAgg *eventstore.Aggregate) []eventstore.Command {
+	if l == nil || !l.unlock {
+		return nil
+	}
+	return []eventstore.Command{user.NewUserUnlockedEvent(ctx, userAgg)}
+}
+
+// lockEvents returns the event locking the user, if the maximum attempts of the policy are reached by a failed check
+func (l *lockoutCheck) lockEvents(ctx context.Context, userAgg *eventstore.Aggregate) []eventstore.Command {
+	if l == nil || !l.exceeded(l.policy, l.attempts.Failed+1) {
+		return nil
+	}
+	return []eventstore.Command{user.NewUserLockedEvent(ctx, userAgg)}
+}
+
+// hasFailedAttempts checks if a succeeded check has to reset previous failed attempts
+func (l *lockoutCheck) hasFailedAttempts() bool {
+	return l != nil && l.attempts.Failed > 0
+}
+
+// checkedEvents returns the events to push for a succeeded or failed check of an authentication factor,
+// including the (un)lock of the user.
+func (l *lockoutCheck) checkedEvents(ctx context.Context, userAgg *eventstore.Aggregate, checkEvent eventstore.Command, failed bool) []eventstore.Command {
+	events := append(l.unlockEvents(ctx, userAgg), checkEvent)
+	if failed {
+		events = append(events, l.lockEvents(ctx, userAgg)...)
+	}
+	return events
+}
+
+// mfaLockoutCheck loads the lockout state of the user for a check of the given second factor.
+// Without a policy, nil is returned and the check is not limited.
+func mfaLockoutCheck(ctx context.Context, es *eventstore.Eventstore, userID, resourceOwner string, policy *domain.LockoutPolicy, factor domain.MFAType, now time.Time) (_ *lockoutCheck, err error) {
+	if policy == nil {
+		return nil, nil
+	}
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel := NewHumanLockoutWriteModel(userID, resourceOwner)
+	if err = es.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	return newLockoutCheck(policy, writeModel.Locked, writeModel.LockedAt, writeModel.attempts(factor), (*domain.LockoutPolicy).OTPAttemptsExceeded, now)
+}
@@ -0,0 +1,120 @@
+package command
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+// lockoutAttempts counts the consecutive failed checks of an authentication factor
+type lockoutAttempts struct {
+	Failed       uint64
+	LastFailedAt time.Time
+}
+
+func (a *lockoutAttempts) failed(at time.Time) {
+	a.Failed++
+	a.LastFailedAt = at
+}
+
+func (a *lockoutAttempts) reset() {
+	*a = lockoutAttempts{}
+}
+
+// HumanLockoutWriteModel keeps track of the lock of a user
+// and the consecutive failed checks of its second factors.
+// OTP SMS and OTP Email share the OTP counter.
+type HumanLockoutWriteModel struct {
+	eventstore.WriteModel
+
+	Locked   bool
+	LockedAt time.Time
+
+	OTP  lockoutAttempts
+	TOTP lockoutAttempts
+	U2F  lockoutAttempts
+}
+
+func NewHumanLockoutWriteModel(userID, resourceOwner string) *HumanLockoutWriteModel {
+	return &HumanLockoutWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *HumanLockoutWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.HumanOTPCheckFailedEvent:
+			wm.TOTP.failed(e.CreationDate())
+		case *user.HumanOTPCheckSucceededEvent:
+			wm.TOTP.reset()
+		case *user.HumanOTPSMSCheckFailedEvent:
+			wm.OTP.failed(e.CreationDate())
+		case *user.HumanOTPEmailCheckFailedEvent:
+			wm.OTP.failed(e.CreationDate())
+		case *user.HumanOTPSMSCheckSucceededEvent,
+			*user.HumanOTPEmailCheckSucceededEvent:
+			wm.OTP.reset()
+		case *user.HumanU2FCheckFailedEvent:
+			wm.U2F.failed(e.CreationDate())
+		case *user.HumanU2FCheckSucceededEvent:
+			wm.U2F.reset()
+		case *user.UserLockedEvent:
+			wm.Locked = true
+			wm.LockedAt = e.CreationDate()
+		case *user.UserUnlockedEvent:
+			wm.Locked = false
+			wm.LockedAt = time.Time{}
+			wm.OTP.reset()
+			wm.TOTP.reset()
+			wm.U2F.reset()
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *HumanLockoutWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			user.HumanMFAOTPCheckFailedType,
+			user.HumanMFAOTPCheckSucceededType,
+			user.HumanOTPSMSCheckFailedType,
+			user.HumanOTPSMSCheckSucceededType,
+			user.HumanOTPEmailCheckFailedType,
+			user.HumanOTPEmailCheckSucceededType,
+			user.HumanU2FTokenCheckFailedType,
+			user.HumanU2FTokenCheckSucceededType,
+			user.UserLockedType,
+			user.UserUnlockedType,
+			user.UserV1MFAOTPCheckFailedType,
+			user.UserV1MFAOTPCheckSucceededType,
+		).
+		Builder()
+
+	if wm.ResourceOwner != "" {
+		query.ResourceOwner(wm.ResourceOwner)
+	}
+	return query
+}
+
+// attempts returns the counter of the given second factor
+func (wm *HumanLockoutWriteModel) attempts(factor domain.MFAType) lockoutAttempts {
+	switch factor {
+	case domain.MFATypeTOTP:
+		return wm.TOTP
+	case domain.MFATypeOTPSMS, domain.MFATypeOTPEmail:
+		return wm.OTP
+	case domain.MFATypeU2F:
+		return wm.U2F
+	default:
+		return lockoutAttempts{}
+	}
+}
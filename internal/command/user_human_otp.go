@@ -146,7 +146,7 @@ func (c *Commands) HumanCheckMFATOTPSetup(ctx context.Context, userID, code, use
 	return writeModelToObjectDetails(&existingOTP.WriteModel), nil
 }
 
-func (c *Commands) HumanCheckMFATOTP(ctx context.Context, userID, code, resourceOwner string, authRequest *domain.AuthRequest) error {
+func (c *Commands) HumanCheckMFATOTP(ctx context.Context, userID, code, resourceOwner string, authRequest *domain.AuthRequest, lockoutPolicy *domain.LockoutPolicy) error {
 	if userID == "" {
 		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-8N9ds", "Errors.User.UserIDMissing")
 	}
@@ -157,13 +157,17 @@ func (c *Commands) HumanCheckMFATOTP(ctx context.Context, userID, code, resource
 	if existingOTP.State != domain.MFAStateReady {
 		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-3Mif9s", "Errors.User.MFA.OTP.NotReady")
 	}
+	lockout, err := mfaLockoutCheck(ctx, c.eventstore, userID, resourceOwner, lockoutPolicy, domain.MFATypeTOTP, time.Now())
+	if err != nil {
+		return err
+	}
 	userAgg := UserAggregateFromWriteModel(&existingOTP.WriteModel)
 	err = domain.VerifyTOTP(code, existingOTP.Secret, c.multifactors.OTP.CryptoMFA)
 	if err == nil {
-		_, err = c.eventstore.Push(ctx, user.NewHumanOTPCheckSucceededEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
+		_, err = c.eventstore.Push(ctx, lockout.checkedEvents(ctx, userAgg, user.NewHumanOTPCheckSucceededEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)), false)...)
 		return err
 	}
-	_, pushErr := c.eventstore.Push(ctx, user.NewHumanOTPCheckFailedEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
+	_, pushErr := c.eventstore.Push(ctx, lockout.checkedEvents(ctx, userAgg, user.NewHumanOTPCheckFailedEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)), true)...)
 	logging.OnError(pushErr).Error("error create password check failed event")
 	return err
 }
@@ -293,7 +297,7 @@ func (c *Commands) HumanOTPSMSCodeSent(ctx context.Context, userID, resourceOwne
 	return c.humanOTPSent(ctx, userID, resourceOwner, smsWriteModel, codeSentEvent)
 }
 
-func (c *Commands) HumanCheckOTPSMS(ctx context.Context, userID, code, resourceOwner string, authRequest *domain.AuthRequest) error {
+func (c *Commands) HumanCheckOTPSMS(ctx context.Context, userID, code, resourceOwner string, authRequest *domain.AuthRequest, lockoutPolicy *domain.LockoutPolicy) error {
 	writeModel := func(ctx context.Context, userID string, resourceOwner string) (OTPCodeWriteModel, error) {
 		return c.otpSMSCodeWriteModelByID(ctx, userID, resourceOwner)
 	}
@@ -309,6 +313,8 @@ func (c *Commands) HumanCheckOTPSMS(ctx context.Context, userID, code, resourceO
 		code,
 		resourceOwner,
 		authRequest,
+		lockoutPolicy,
+		domain.MFATypeOTPSMS,
 		writeModel,
 		succeededEvent,
 		failedEvent,
@@ -413,7 +419,7 @@ func (c *Commands) HumanOTPEmailCodeSent(ctx context.Context, userID, resourceOw
 	return c.humanOTPSent(ctx, userID, resourceOwner, smsWriteModel, codeSentEvent)
 }
 
-func (c *Commands) HumanCheckOTPEmail(ctx context.Context, userID, code, resourceOwner string, authRequest *domain.AuthRequest) error {
+func (c *Commands) HumanCheckOTPEmail(ctx context.Context, userID, code, resourceOwner string, authRequest *domain.AuthRequest, lockoutPolicy *domain.LockoutPolicy) error {
 	writeModel := func(ctx context.Context, userID string, resourceOwner string) (OTPCodeWriteModel, error) {
 		return c.otpEmailCodeWriteModelByID(ctx, userID, resourceOwner)
 	}
@@ -429,6 +435,8 @@ func (c *Commands) HumanCheckOTPEmail(ctx context.Context, userID, code, resourc
 		code,
 		resourceOwner,
 		authRequest,
+		lockoutPolicy,
+		domain.MFATypeOTPEmail,
 		writeModel,
 		succeededEvent,
 		failedEvent,
@@ -494,6 +502,8 @@ func (c *Commands) humanCheckOTP(
 	ctx context.Context,
 	userID, code, resourceOwner string,
 	authRequest *domain.AuthRequest,
+	lockoutPolicy *domain.LockoutPolicy,
+	factor domain.MFAType,
 	writeModelByID func(ctx context.Context, userID string, resourceOwner string) (OTPCodeWriteModel, error),
 	checkSucceededEvent func(ctx context.Context, aggregate *eventstore.Aggregate, info *user.AuthRequestInfo) eventstore.Command,
 	checkFailedEvent func(ctx context.Context, aggregate *eventstore.Aggregate, info *user.AuthRequestInfo) eventstore.Command,
@@ -514,13 +524,17 @@ func (c *Commands) humanCheckOTP(
 	if existingOTP.Code() == nil {
 		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-S34gh", "Errors.User.Code.NotFound")
 	}
+	lockout, err := mfaLockoutCheck(ctx, c.eventstore, userID, resourceOwner, lockoutPolicy, factor, time.Now())
+	if err != nil {
+		return err
+	}
 	userAgg := &user.NewAggregate(userID, existingOTP.ResourceOwner()).Aggregate
 	err = crypto.VerifyCodeWithAlgorithm(existingOTP.CodeCreationDate(), existingOTP.CodeExpiry(), existingOTP.Code(), code, c.userEncryption)
 	if err == nil {
-		_, err = c.eventstore.Push(ctx, checkSucceededEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
+		_, err = c.eventstore.Push(ctx, lockout.checkedEvents(ctx, userAgg, checkSucceededEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)), false)...)
 		return err
 	}
-	_, pushErr := c.eventstore.Push(ctx, checkFailedEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
+	_, pushErr := c.eventstore.Push(ctx, lockout.checkedEvents(ctx, userAgg, checkFailedEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)), true)...)
 	logging.WithFields("userID", userID).OnError(pushErr).Error("otp failure check push failed")
 	return err
 }
@@ -1784,7 +1784,7 @@ func TestCommandSide_HumanCheckOTPSMS(t *testing.T) {
 				eventstore:     tt.fields.eventstore(t),
 				userEncryption: tt.fields.userEncryption,
 			}
-			err := r.HumanCheckOTPSMS(tt.args.ctx, tt.args.userID, tt.args.code, tt.args.resourceOwner, tt.args.authRequest)
+			err := r.HumanCheckOTPSMS(tt.args.ctx, tt.args.userID, tt.args.code, tt.args.resourceOwner, tt.args.authRequest, nil)
 			assert.ErrorIs(t, err, tt.res.err)
 		})
 	}
@@ -2729,7 +2729,7 @@ func TestCommandSide_HumanCheckOTPEmail(t *testing.T) {
 				eventstore:     tt.fields.eventstore(t),
 				userEncryption: tt.fields.userEncryption,
 			}
-			err := r.HumanCheckOTPEmail(tt.args.ctx, tt.args.userID, tt.args.code, tt.args.resourceOwner, tt.args.authRequest)
+			err := r.HumanCheckOTPEmail(tt.args.ctx, tt.args.userID, tt.args.code, tt.args.resourceOwner, tt.args.authRequest, nil)
 			assert.ErrorIs(t, err, tt.res.err)
 		})
 	}
@@ -3,6 +3,7 @@ package command
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/zitadel/logging"
 	"github.com/zitadel/passwap"
@@ -58,7 +59,13 @@ func (c *Commands) SetPasswordWithVerifyCode(ctx context.Context, orgID, userID,
 		return nil, err
 	}
 
-	return c.setPassword(ctx, wm, password, false)
+	objectDetails, err = c.setPassword(ctx, wm, password, false)
+	if err != nil {
+		return nil, err
+	}
+	err = c.runPostPasswordResetActions(ctx, userID, wm.ResourceOwner)
+	logging.WithFields("userID", userID).OnError(err).Warn("post password reset actions failed")
+	return objectDetails, nil
 }
 
 func (c *Commands) setPassword(ctx context.Context, wm *HumanPasswordWriteModel, password string, changeRequired bool) (objectDetails *domain.ObjectDetails, err error) {
@@ -139,9 +146,65 @@ func (c *Commands) canUpdatePassword(ctx context.Context, newPassword string, wm
 	if err := policy.Check(newPassword); err != nil {
 		return err
 	}
+	if err := c.checkPasswordBreached(ctx, policy.CheckBreached, newPassword); err != nil {
+		return err
+	}
+	return c.checkPasswordHistory(ctx, newPassword, wm.RecentPasswordHashes(policy.HistoryDepth))
+}
+
+// checkPasswordHistory returns an error if the password matches any of the passed encoded hashes.
+// Hashes which can't be verified (e.g. unsupported encodings of imported users) are skipped.
+func (c *Commands) checkPasswordHistory(ctx context.Context, password string, encodedHashes []string) (err error) {
+	_, span := tracing.NewNamedSpan(ctx, "passwap.Verify")
+	defer func() { span.EndWithError(err) }()
+
+	for _, encoded := range encodedHashes {
+		if _, verifyErr := c.userPasswordHasher.Verify(encoded, password); verifyErr == nil {
+			return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Quo8o", "Errors.User.Password.PreviouslyUsed")
+		}
+	}
 	return nil
 }
 
+// checkPasswordBreached returns an error if checkBreached is set and the password is part of a known data breach.
+func (c *Commands) checkPasswordBreached(ctx context.Context, checkBreached bool, password string) error {
+	if !checkBreached || !c.isPasswordBreached(ctx, password) {
+		return nil
+	}
+	return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Vei4a", "Errors.User.PasswordComplexityPolicy.Breached")
+}
+
+// passwordBreachedOnLogin checks the password of a successful login,
+// if enabled in the system defaults and required by the password complexity policy of the organization.
+func (c *Commands) passwordBreachedOnLogin(ctx context.Context, orgID, password string) (bool, error) {
+	if !c.checkBreachedPasswordOnLogin || c.breachedPasswordChecker == nil {
+		return false, nil
+	}
+	policy, err := c.getOrgPasswordComplexityPolicy(ctx, orgID)
+	if err != nil {
+		return false, err
+	}
+	return policy.CheckBreached && c.isPasswordBreached(ctx, password), nil
+}
+
+// isPasswordBreached queries the configured breached password source.
+// If the source is not available, the password is treated as not breached
+// so an outage of the source doesn't prevent users from setting passwords.
+func (c *Commands) isPasswordBreached(ctx context.Context, password string) bool {
+	if c.breachedPasswordChecker == nil {
+		return false
+	}
+	ctx, span := tracing.NewSpan(ctx)
+	defer span.End()
+
+	breached, err := c.breachedPasswordChecker.IsBreached(ctx, password)
+	if err != nil {
+		logging.WithError(err).Warn("breached password check failed")
+		return false
+	}
+	return breached
+}
+
 func (c *Commands) RequestSetPassword(ctx context.Context, userID, resourceOwner string, notifyType domain.NotificationType, passwordVerificationCode crypto.Generator) (objectDetails *domain.ObjectDetails, err error) {
 	if userID == "" {
 		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-M00oL", "Errors.User.UserIDMissing")
@@ -157,6 +220,9 @@ func (c *Commands) RequestSetPassword(ctx context.Context, userID, resourceOwner
 	if existingHuman.UserState == domain.UserStateInitial {
 		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-2M9sd", "Errors.User.NotInitialised")
 	}
+	if err = c.runPrePasswordResetActions(ctx, userID, existingHuman.ResourceOwner, notifyType, false); err != nil {
+		return nil, err
+	}
 	userAgg := UserAggregateFromWriteModel(&existingHuman.WriteModel)
 	passwordCode, err := domain.NewPasswordCode(passwordVerificationCode)
 	if err != nil {
@@ -207,6 +273,41 @@ func (c *Commands) PasswordChangeSent(ctx context.Context, orgID, userID string)
 	return err
 }
 
+func (c *Commands) PasswordExpiryWarningSent(ctx context.Context, orgID, userID string) (err error) {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Wq3vS", "Errors.User.UserIDMissing")
+	}
+
+	existingPassword, err := c.passwordWriteModel(ctx, userID, orgID)
+	if err != nil {
+		return err
+	}
+	if existingPassword.UserState == domain.UserStateUnspecified || existingPassword.UserState == domain.UserStateDeleted {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Pn8xe", "Errors.User.NotFound")
+	}
+	userAgg := UserAggregateFromWriteModel(&existingPassword.WriteModel)
+	_, err = c.eventstore.Push(ctx, user.NewHumanPasswordExpiryWarningSentEvent(ctx, userAgg))
+	return err
+}
+
+// PasswordExpiryWarningDue marks the user to be warned about the password expiring at expiryDate.
+func (c *Commands) PasswordExpiryWarningDue(ctx context.Context, orgID, userID string, expiryDate time.Time) (err error) {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-ohJ4a", "Errors.User.UserIDMissing")
+	}
+
+	existingPassword, err := c.passwordWriteModel(ctx, userID, orgID)
+	if err != nil {
+		return err
+	}
+	if existingPassword.UserState == domain.UserStateUnspecified || existingPassword.UserState == domain.UserStateDeleted {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ahr0e", "Errors.User.NotFound")
+	}
+	userAgg := UserAggregateFromWriteModel(&existingPassword.WriteModel)
+	_, err = c.eventstore.Push(ctx, user.NewHumanPasswordExpiryWarningDueEvent(ctx, userAgg, expiryDate))
+	return err
+}
+
 func (c *Commands) HumanCheckPassword(ctx context.Context, orgID, userID, password string, authRequest *domain.AuthRequest, lockoutPolicy *domain.LockoutPolicy) (err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -233,8 +334,10 @@ func (c *Commands) HumanCheckPassword(ctx context.Context, orgID, userID, passwo
 	if wm.UserState == domain.UserStateUnspecified || wm.UserState == domain.UserStateDeleted {
 		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-3n77z", "Errors.User.NotFound")
 	}
-	if wm.UserState == domain.UserStateLocked {
-		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-JLK35", "Errors.User.Locked")
+	now := time.Now()
+	lockout, err := newLockoutCheck(lockoutPolicy, wm.UserState == domain.UserStateLocked, wm.LockedAt, wm.passwordCheckAttempts(), (*domain.LockoutPolicy).PasswordAttemptsExceeded, now)
+	if err != nil {
+		return err
 	}
 
 	if wm.EncodedHash == "" {
@@ -246,32 +349,37 @@ func (c *Commands) HumanCheckPassword(ctx context.Context, orgID, userID, passwo
 	updated, err := c.userPasswordHasher.Verify(wm.EncodedHash, password)
 	spanPasswordComparison.EndWithError(err)
 	err = convertPasswapErr(err)
-	commands := make([]eventstore.Command, 0, 2)
 
 	// recheck for additional events (failed password checks or locks)
 	recheckErr := c.eventstore.FilterToQueryReducer(ctx, wm)
 	if recheckErr != nil {
 		return recheckErr
 	}
-	if wm.UserState == domain.UserStateLocked {
+	if wm.UserState == domain.UserStateLocked && !lockoutPolicy.IsAutoUnlocked(wm.LockedAt, now) {
 		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-SFA3t", "Errors.User.Locked")
 	}
+	if !lockout.unlock {
+		lockout.attempts = wm.passwordCheckAttempts()
+	}
+	commands := make([]eventstore.Command, 0, 3)
 
 	if err == nil {
-		commands = append(commands, user.NewHumanPasswordCheckSucceededEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
+		commands = append(commands, lockout.checkedEvents(ctx, userAgg, user.NewHumanPasswordCheckSucceededEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)), false)...)
 		if updated != "" {
 			commands = append(commands, user.NewHumanPasswordHashUpdatedEvent(ctx, userAgg, updated))
 		}
+		if !wm.SecretChangeRequired {
+			breached, breachedErr := c.passwordBreachedOnLogin(ctx, wm.ResourceOwner, password)
+			logging.OnError(breachedErr).Warn("unable to check password for breaches")
+			if breached {
+				commands = append(commands, user.NewHumanPasswordBreachedEvent(ctx, userAgg))
+			}
+		}
 		_, err = c.eventstore.Push(ctx, commands...)
 		return err
 	}
 
-	commands = append(commands, user.NewHumanPasswordCheckFailedEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
-	if lockoutPolicy != nil && lockoutPolicy.MaxPasswordAttempts > 0 {
-		if wm.PasswordCheckFailedCount+1 >= lockoutPolicy.MaxPasswordAttempts {
-			commands = append(commands, user.NewUserLockedEvent(ctx, userAgg))
-		}
-	}
+	commands = append(commands, lockout.checkedEvents(ctx, userAgg, user.NewHumanPasswordCheckFailedEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)), true)...)
 	_, pushErr := c.eventstore.Push(ctx, commands...)
 	logging.OnError(pushErr).Error("error create password check failed event")
 	return err
@@ -15,13 +15,18 @@ type HumanPasswordWriteModel struct {
 
 	EncodedHash          string
 	SecretChangeRequired bool
+	PasswordChanged      time.Time
+	// PasswordHistory contains the previously used encoded hashes, oldest first
+	PasswordHistory []string
 
 	Code                     *crypto.CryptoValue
 	CodeCreationDate         time.Time
 	CodeExpiry               time.Duration
 	PasswordCheckFailedCount uint64
+	PasswordCheckFailedAt    time.Time
 
 	UserState domain.UserState
+	LockedAt  time.Time
 }
 
 func NewHumanPasswordWriteModel(userID, resourceOwner string) *HumanPasswordWriteModel {
@@ -39,18 +44,28 @@ func (wm *HumanPasswordWriteModel) Reduce() error {
 		case *user.HumanAddedEvent:
 			wm.EncodedHash = user.SecretOrEncodedHash(e.Secret, e.EncodedHash)
 			wm.SecretChangeRequired = e.ChangeRequired
+			if wm.EncodedHash != "" {
+				wm.PasswordChanged = e.CreationDate()
+			}
 			wm.UserState = domain.UserStateActive
 		case *user.HumanRegisteredEvent:
 			wm.EncodedHash = user.SecretOrEncodedHash(e.Secret, e.EncodedHash)
 			wm.SecretChangeRequired = e.ChangeRequired
+			if wm.EncodedHash != "" {
+				wm.PasswordChanged = e.CreationDate()
+			}
 			wm.UserState = domain.UserStateActive
 		case *user.HumanInitialCodeAddedEvent:
 			wm.UserState = domain.UserStateInitial
 		case *user.HumanInitializedCheckSucceededEvent:
 			wm.UserState = domain.UserStateActive
 		case *user.HumanPasswordChangedEvent:
+			if wm.EncodedHash != "" {
+				wm.PasswordHistory = append(wm.PasswordHistory, wm.EncodedHash)
+			}
 			wm.EncodedHash = user.SecretOrEncodedHash(e.Secret, e.EncodedHash)
 			wm.SecretChangeRequired = e.ChangeRequired
+			wm.PasswordChanged = e.CreationDate()
 			wm.Code = nil
 			wm.PasswordCheckFailedCount = 0
 		case *user.HumanPasswordCodeAddedEvent:
@@ -63,10 +78,12 @@ func (wm *HumanPasswordWriteModel) Reduce() error {
 			}
 		case *user.HumanPasswordCheckFailedEvent:
 			wm.PasswordCheckFailedCount += 1
+			wm.PasswordCheckFailedAt = e.CreationDate()
 		case *user.HumanPasswordCheckSucceededEvent:
 			wm.PasswordCheckFailedCount = 0
 		case *user.UserLockedEvent:
 			wm.UserState = domain.UserStateLocked
+			wm.LockedAt = e.CreationDate()
 		case *user.UserUnlockedEvent:
 			wm.PasswordCheckFailedCount = 0
 			if wm.UserState != domain.UserStateDeleted {
@@ -76,11 +93,38 @@ func (wm *HumanPasswordWriteModel) Reduce() error {
 			wm.UserState = domain.UserStateDeleted
 		case *user.HumanPasswordHashUpdatedEvent:
 			wm.EncodedHash = e.EncodedHash
+		case *user.HumanPasswordBreachedEvent:
+			wm.SecretChangeRequired = true
 		}
 	}
 	return wm.WriteModel.Reduce()
 }
 
+// passwordCheckAttempts returns the consecutive failed password checks
+func (wm *HumanPasswordWriteModel) passwordCheckAttempts() lockoutAttempts {
+	return lockoutAttempts{
+		Failed:       wm.PasswordCheckFailedCount,
+		LastFailedAt: wm.PasswordCheckFailedAt,
+	}
+}
+
+// RecentPasswordHashes returns the encoded hashes of the current and the previously used passwords,
+// newest first, limited to depth entries.
+func (wm *HumanPasswordWriteModel) RecentPasswordHashes(depth uint64) []string {
+	if depth == 0 {
+		return nil
+	}
+	// the depth comes from the policy, so don't allocate more than the history can fill
+	hashes := make([]string, 0, min(depth, uint64(len(wm.PasswordHistory)+1)))
+	if wm.EncodedHash != "" {
+		hashes = append(hashes, wm.EncodedHash)
+	}
+	for i := len(wm.PasswordHistory) - 1; i >= 0 && uint64(len(hashes)) < depth; i-- {
+		hashes = append(hashes, wm.PasswordHistory[i])
+	}
+	return hashes
+}
+
 func (wm *HumanPasswordWriteModel) Query() *eventstore.SearchQueryBuilder {
 	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
 		AddQuery().
@@ -96,6 +140,7 @@ func (wm *HumanPasswordWriteModel) Query() *eventstore.SearchQueryBuilder {
 			user.HumanPasswordCheckFailedType,
 			user.HumanPasswordCheckSucceededType,
 			user.HumanPasswordHashUpdatedType,
+			user.HumanPasswordBreachedType,
 			user.UserRemovedType,
 			user.UserLockedType,
 			user.UserUnlockedType,
@@ -0,0 +1,121 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// GenerateHumanRecoveryCodes creates a new batch of recovery codes for the user.
+// Possibly existing codes will be invalidated.
+// The plain codes are only returned once and cannot be retrieved afterwards.
+func (c *Commands) GenerateHumanRecoveryCodes(ctx context.Context, userID, resourceOwner string) (*domain.RecoveryCodes, error) {
+	if userID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Bw2nx", "Errors.User.UserIDMissing")
+	}
+	if err := c.checkUserExists(ctx, userID, resourceOwner); err != nil {
+		return nil, err
+	}
+	writeModel, err := c.recoveryCodesWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if userID != authz.GetCtxData(ctx).UserID {
+		if err := c.checkPermission(ctx, domain.PermissionUserWrite, writeModel.ResourceOwner, userID); err != nil {
+			return nil, err
+		}
+	}
+	plain, hashed, err := domain.GenerateRecoveryCodes(c.multifactors.RecoveryCodes, c.userPasswordHasher)
+	if err != nil {
+		return nil, err
+	}
+	userAgg := UserAggregateFromWriteModel(&writeModel.WriteModel)
+	if err = c.pushAppendAndReduce(ctx, writeModel, user.NewHumanRecoveryCodesAddedEvent(ctx, userAgg, hashed)); err != nil {
+		return nil, err
+	}
+	return &domain.RecoveryCodes{
+		ObjectDetails: writeModelToObjectDetails(&writeModel.WriteModel),
+		Codes:         plain,
+	}, nil
+}
+
+func (c *Commands) RemoveHumanRecoveryCodes(ctx context.Context, userID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if userID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Fw3g2", "Errors.User.UserIDMissing")
+	}
+	writeModel, err := c.recoveryCodesWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if userID != authz.GetCtxData(ctx).UserID {
+		if err := c.checkPermission(ctx, domain.PermissionUserWrite, writeModel.ResourceOwner, userID); err != nil {
+			return nil, err
+		}
+	}
+	if writeModel.State != domain.MFAStateReady {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Sk3l1", "Errors.User.MFA.RecoveryCodes.NotExisting")
+	}
+	userAgg := UserAggregateFromWriteModel(&writeModel.WriteModel)
+	if err = c.pushAppendAndReduce(ctx, writeModel, user.NewHumanRecoveryCodesRemovedEvent(ctx, userAgg)); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// HumanCheckRecoveryCode verifies the code against the unused recovery codes of the user (during login).
+// The code is consumed on success and cannot be used again.
+func (c *Commands) HumanCheckRecoveryCode(ctx context.Context, userID, code, resourceOwner string, authRequest *domain.AuthRequest) error {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ml2fw", "Errors.User.UserIDMissing")
+	}
+	writeModel, err := c.recoveryCodesWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if writeModel.State != domain.MFAStateReady {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Nw3la", "Errors.User.MFA.RecoveryCodes.NotExisting")
+	}
+	if writeModel.Remaining() == 0 {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Pe9ds", "Errors.User.MFA.RecoveryCodes.NoneLeft")
+	}
+	userAgg := UserAggregateFromWriteModel(&writeModel.WriteModel)
+	ctx, spanPasswordComparison := tracing.NewNamedSpan(ctx, "passwap.Verify")
+	index, err := domain.VerifyRecoveryCode(code, writeModel.EncodedHashes, c.userPasswordHasher)
+	spanPasswordComparison.EndWithError(err)
+	if err == nil {
+		// concurrent checks of the same code must not both succeed
+		userAgg.ExpectSequence(writeModel.ProcessedSequence, recoveryCodesEventTypes...)
+		_, err = c.eventstore.Push(ctx, user.NewHumanRecoveryCodeCheckSucceededEvent(ctx, userAgg, index, authRequestDomainToAuthRequestInfo(authRequest)))
+		return err
+	}
+	_, pushErr := c.eventstore.Push(ctx, user.NewHumanRecoveryCodeCheckFailedEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
+	logging.WithFields("userID", userID).OnError(pushErr).Error("recovery code failure check push failed")
+	return err
+}
+
+func (c *Commands) HumanRecoveryCodeUsedNotificationSent(ctx context.Context, userID, resourceOwner string) error {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Vd3s1", "Errors.User.UserIDMissing")
+	}
+	userAgg := &user.NewAggregate(userID, resourceOwner).Aggregate
+	_, err := c.eventstore.Push(ctx, user.NewHumanRecoveryCodeUsedNotificationSentEvent(ctx, userAgg))
+	return err
+}
+
+func (c *Commands) recoveryCodesWriteModelByID(ctx context.Context, userID, resourceOwner string) (writeModel *HumanRecoveryCodesWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewHumanRecoveryCodesWriteModel(userID, resourceOwner)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
@@ -0,0 +1,77 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+type HumanRecoveryCodesWriteModel struct {
+	eventstore.WriteModel
+
+	State domain.MFAState
+	// EncodedHashes of the current batch, used codes are replaced by an empty string
+	EncodedHashes []string
+}
+
+func NewHumanRecoveryCodesWriteModel(userID, resourceOwner string) *HumanRecoveryCodesWriteModel {
+	return &HumanRecoveryCodesWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *HumanRecoveryCodesWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.HumanRecoveryCodesAddedEvent:
+			wm.State = domain.MFAStateReady
+			wm.EncodedHashes = append([]string(nil), e.EncodedHashes...)
+		case *user.HumanRecoveryCodeCheckSucceededEvent:
+			if e.CodeIndex >= 0 && e.CodeIndex < len(wm.EncodedHashes) {
+				wm.EncodedHashes[e.CodeIndex] = ""
+			}
+		case *user.HumanRecoveryCodesRemovedEvent,
+			*user.UserRemovedEvent:
+			wm.State = domain.MFAStateRemoved
+			wm.EncodedHashes = nil
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+// recoveryCodesEventTypes change the state of the recovery codes,
+// a check must be based on the latest of them
+var recoveryCodesEventTypes = []eventstore.EventType{
+	user.HumanRecoveryCodesAddedType,
+	user.HumanRecoveryCodeCheckSucceededType,
+	user.HumanRecoveryCodesRemovedType,
+	user.UserRemovedType,
+}
+
+func (wm *HumanRecoveryCodesWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(recoveryCodesEventTypes...).
+		Builder()
+
+	if wm.ResourceOwner != "" {
+		query.ResourceOwner(wm.ResourceOwner)
+	}
+	return query
+}
+
+// Remaining returns the amount of unused codes of the current batch
+func (wm *HumanRecoveryCodesWriteModel) Remaining() int {
+	var remaining int
+	for _, hash := range wm.EncodedHashes {
+		if hash != "" {
+			remaining++
+		}
+	}
+	return remaining
+}
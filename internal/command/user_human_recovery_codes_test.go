@@ -0,0 +1,325 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func TestCommandSide_RemoveHumanRecoveryCodes(t *testing.T) {
+	ctx := authz.NewMockContext("inst1", "org1", "user1")
+	type fields struct {
+		eventstore      func(*testing.T) *eventstore.Eventstore
+		checkPermission domain.PermissionCheck
+	}
+	type (
+		args struct {
+			ctx           context.Context
+			userID        string
+			resourceOwner string
+		}
+	)
+	type res struct {
+		want *domain.ObjectDetails
+		err  error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "userid missing, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx:           ctx,
+				userID:        "",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.ThrowInvalidArgument(nil, "COMMAND-Fw3g2", "Errors.User.UserIDMissing"),
+			},
+		},
+		{
+			name: "other user not permission, permission denied error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+				checkPermission: newMockPermissionCheckNotAllowed(),
+			},
+			args: args{
+				ctx:           ctx,
+				userID:        "other",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.ThrowPermissionDenied(nil, "AUTHZ-HKJD33", "Errors.PermissionDenied"),
+			},
+		},
+		{
+			name: "recovery codes not added, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx:           ctx,
+				userID:        "user1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.ThrowNotFound(nil, "COMMAND-Sk3l1", "Errors.User.MFA.RecoveryCodes.NotExisting"),
+			},
+		},
+		{
+			name: "successful remove",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanRecoveryCodesAddedEvent(ctx,
+								&user.NewAggregate("user1", "org1").Aggregate,
+								[]string{"$plain$x$ABCDEFGHJK"},
+							),
+						),
+					),
+					expectPush(
+						user.NewHumanRecoveryCodesRemovedEvent(ctx,
+							&user.NewAggregate("user1", "org1").Aggregate,
+						),
+					),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx:           ctx,
+				userID:        "user1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:      tt.fields.eventstore(t),
+				checkPermission: tt.fields.checkPermission,
+			}
+			got, err := r.RemoveHumanRecoveryCodes(tt.args.ctx, tt.args.userID, tt.args.resourceOwner)
+			assert.ErrorIs(t, err, tt.res.err)
+			assert.Equal(t, tt.res.want, got)
+		})
+	}
+}
+
+func TestCommandSide_HumanCheckRecoveryCode(t *testing.T) {
+	ctx := authz.NewMockContext("inst1", "org1", "user1")
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	type (
+		args struct {
+			ctx           context.Context
+			userID        string
+			code          string
+			resourceOwner string
+			authRequest   *domain.AuthRequest
+		}
+	)
+	type res struct {
+		err error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "userid missing, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx:           ctx,
+				userID:        "",
+				code:          "ABCDE-FGHJK",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ml2fw", "Errors.User.UserIDMissing"),
+			},
+		},
+		{
+			name: "recovery codes not added, precondition failed error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:           ctx,
+				userID:        "user1",
+				code:          "ABCDE-FGHJK",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Nw3la", "Errors.User.MFA.RecoveryCodes.NotExisting"),
+			},
+		},
+		{
+			name: "all codes used, precondition failed error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanRecoveryCodesAddedEvent(ctx,
+								&user.NewAggregate("user1", "org1").Aggregate,
+								[]string{"$plain$x$ABCDEFGHJK"},
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanRecoveryCodeCheckSucceededEvent(ctx,
+								&user.NewAggregate("user1", "org1").Aggregate,
+								0,
+								nil,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           ctx,
+				userID:        "user1",
+				code:          "ABCDE-FGHJK",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Pe9ds", "Errors.User.MFA.RecoveryCodes.NoneLeft"),
+			},
+		},
+		{
+			name: "invalid code, check failed",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanRecoveryCodesAddedEvent(ctx,
+								&user.NewAggregate("user1", "org1").Aggregate,
+								[]string{"$plain$x$ABCDEFGHJK", "$plain$x$MNPQRSTUVW"},
+							),
+						),
+					),
+					expectPush(
+						user.NewHumanRecoveryCodeCheckFailedEvent(ctx,
+							&user.NewAggregate("user1", "org1").Aggregate,
+							nil,
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           ctx,
+				userID:        "user1",
+				code:          "XXXXX-XXXXX",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.ThrowInvalidArgument(nil, "DOMAIN-Lfe2a", "Errors.User.MFA.RecoveryCodes.InvalidCode"),
+			},
+		},
+		{
+			name: "used code, check failed",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanRecoveryCodesAddedEvent(ctx,
+								&user.NewAggregate("user1", "org1").Aggregate,
+								[]string{"$plain$x$ABCDEFGHJK", "$plain$x$MNPQRSTUVW"},
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanRecoveryCodeCheckSucceededEvent(ctx,
+								&user.NewAggregate("user1", "org1").Aggregate,
+								0,
+								nil,
+							),
+						),
+					),
+					expectPush(
+						user.NewHumanRecoveryCodeCheckFailedEvent(ctx,
+							&user.NewAggregate("user1", "org1").Aggregate,
+							nil,
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           ctx,
+				userID:        "user1",
+				code:          "ABCDE-FGHJK",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.ThrowInvalidArgument(nil, "DOMAIN-Lfe2a", "Errors.User.MFA.RecoveryCodes.InvalidCode"),
+			},
+		},
+		{
+			name: "valid code, check succeeded",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventWithSequence(5, eventFromEventPusher(
+							user.NewHumanRecoveryCodesAddedEvent(ctx,
+								&user.NewAggregate("user1", "org1").Aggregate,
+								[]string{"$plain$x$ABCDEFGHJK", "$plain$x$MNPQRSTUVW"},
+							),
+						)),
+					),
+					expectPush(
+						user.NewHumanRecoveryCodeCheckSucceededEvent(ctx,
+							user.NewAggregate("user1", "org1").Aggregate.ExpectSequence(5, recoveryCodesEventTypes...),
+							1,
+							nil,
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           ctx,
+				userID:        "user1",
+				code:          "mnpqr-stuvw",
+				resourceOwner: "org1",
+			},
+			res: res{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:         tt.fields.eventstore(t),
+				userPasswordHasher: mockPasswordHasher("x"),
+			}
+			err := r.HumanCheckRecoveryCode(tt.args.ctx, tt.args.userID, tt.args.code, tt.args.resourceOwner, tt.args.authRequest)
+			assert.ErrorIs(t, err, tt.res.err)
+		})
+	}
+}
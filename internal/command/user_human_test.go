@@ -430,6 +430,8 @@ func TestCommandSide_AddHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								0,
+								false,
 							),
 						),
 					),
@@ -499,6 +501,8 @@ func TestCommandSide_AddHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								0,
+								false,
 							),
 						),
 					),
@@ -571,6 +575,8 @@ func TestCommandSide_AddHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								0,
+								false,
 							),
 						),
 					),
@@ -644,6 +650,8 @@ func TestCommandSide_AddHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								0,
+								false,
 							),
 						),
 					),
@@ -707,6 +715,8 @@ func TestCommandSide_AddHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								0,
+								false,
 							),
 						),
 					),
@@ -770,6 +780,8 @@ func TestCommandSide_AddHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								0,
+								false,
 							),
 						),
 					),
@@ -893,6 +905,8 @@ func TestCommandSide_AddHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								0,
+								false,
 							),
 						),
 					),
@@ -972,6 +986,8 @@ func TestCommandSide_AddHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								0,
+								false,
 							),
 						),
 					),
@@ -1112,6 +1128,8 @@ func TestCommandSide_AddHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								0,
+								false,
 							),
 						),
 					),
@@ -1410,6 +1428,8 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 										false,
 										false,
 										false,
+										0,
+										false,
 									),
 								),
 							),
@@ -1455,6 +1475,8 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 										false,
 										false,
 										false,
+										0,
+										false,
 									),
 								),
 							),
@@ -1541,6 +1563,8 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 										false,
 										false,
 										false,
+										0,
+										false,
 									),
 								),
 							),
@@ -1622,6 +1646,8 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 										false,
 										false,
 										false,
+										0,
+										false,
 									),
 								),
 							),
@@ -1722,6 +1748,8 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 										false,
 										false,
 										false,
+										0,
+										false,
 									),
 								),
 							),
@@ -1826,6 +1854,8 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 										false,
 										false,
 										false,
+										0,
+										false,
 									),
 								),
 							),
@@ -1927,6 +1957,8 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 										false,
 										false,
 										false,
+										0,
+										false,
 									),
 								),
 							),
@@ -2022,6 +2054,8 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 										false,
 										false,
 										false,
+										0,
+										false,
 									),
 								),
 							),
@@ -2107,6 +2141,8 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 										false,
 										false,
 										false,
+										0,
+										false,
 									),
 								),
 							),
@@ -2193,6 +2229,8 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 										false,
 										false,
 										false,
+										0,
+										false,
 									),
 								),
 							),
@@ -2339,6 +2377,8 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 										false,
 										false,
 										false,
+										0,
+										false,
 									),
 								),
 							),
@@ -2622,6 +2662,8 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								0,
+								false,
 							),
 						),
 					),
@@ -2670,6 +2712,8 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								0,
+								false,
 							),
 						),
 					),
@@ -2740,6 +2784,8 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								0,
+								false,
 							),
 						),
 					),
@@ -2810,6 +2856,8 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								0,
+								false,
 							),
 						),
 					),
@@ -2897,6 +2945,8 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								0,
+								false,
 							),
 						),
 					),
@@ -3036,6 +3086,8 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								0,
+								false,
 							),
 						),
 					),
@@ -3143,6 +3195,8 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								0,
+								false,
 							),
 						),
 					),
@@ -3250,6 +3304,8 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								0,
+								false,
 							),
 						),
 					),
@@ -3351,6 +3407,8 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								0,
+								false,
 							),
 						),
 					),
@@ -3474,6 +3532,8 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								0,
+								false,
 							),
 						),
 					),
@@ -3591,6 +3651,8 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								0,
+								false,
 							),
 						),
 					),
@@ -3698,6 +3760,8 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								0,
+								false,
 							),
 						),
 					),
@@ -3803,6 +3867,8 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								0,
+								false,
 							),
 						),
 					),
@@ -4435,6 +4501,8 @@ func TestAddHumanCommand(t *testing.T) {
 									true,
 									true,
 									true,
+									0,
+									false,
 								),
 							}, nil
 						}).
@@ -4488,6 +4556,8 @@ func TestAddHumanCommand(t *testing.T) {
 									false,
 									false,
 									false,
+									0,
+									false,
 								),
 							}, nil
 						}).
@@ -4559,6 +4629,8 @@ func TestAddHumanCommand(t *testing.T) {
 									false,
 									false,
 									false,
+									0,
+									false,
 								),
 							}, nil
 						}).
@@ -4631,6 +4703,8 @@ func TestAddHumanCommand(t *testing.T) {
 									false,
 									false,
 									false,
+									0,
+									false,
 								),
 							}, nil
 						}).
@@ -4703,6 +4777,8 @@ func TestAddHumanCommand(t *testing.T) {
 									false,
 									false,
 									false,
+									0,
+									false,
 								),
 							}, nil
 						}).
@@ -357,7 +357,7 @@ func (c *Commands) beginWebAuthNLogin(ctx context.Context, userID, resourceOwner
 	return userAgg, webAuthNLogin, nil
 }
 
-func (c *Commands) HumanFinishU2FLogin(ctx context.Context, userID, resourceOwner string, credentialData []byte, authRequest *domain.AuthRequest) error {
+func (c *Commands) HumanFinishU2FLogin(ctx context.Context, userID, resourceOwner string, credentialData []byte, authRequest *domain.AuthRequest, lockoutPolicy *domain.LockoutPolicy) error {
 	webAuthNLogin, err := c.getHumanU2FLogin(ctx, userID, authRequest.ID, resourceOwner)
 	if err != nil {
 		return err
@@ -366,36 +366,52 @@ func (c *Commands) HumanFinishU2FLogin(ctx context.Context, userID, resourceOwne
 	if err != nil {
 		return err
 	}
+	lockout, err := mfaLockoutCheck(ctx, c.eventstore, userID, resourceOwner, lockoutPolicy, domain.MFATypeU2F, time.Now())
+	if err != nil {
+		return err
+	}
 
 	userAgg, token, signCount, err := c.finishWebAuthNLogin(ctx, userID, resourceOwner, credentialData, webAuthNLogin, u2fTokens)
 	if err != nil {
+		// failed checks have to be counted for the lockout policy
+		if userAgg == nil && lockout != nil {
+			userAgg = &usr_repo.NewAggregate(userID, resourceOwner).Aggregate
+		}
 		if userAgg == nil {
 			logging.WithFields("userID", userID, "resourceOwner", resourceOwner).WithError(err).Warn("missing userAggregate for pushing failed u2f check event")
 			return err
 		}
 		_, pushErr := c.eventstore.Push(ctx,
-			usr_repo.NewHumanU2FCheckFailedEvent(
-				ctx,
-				userAgg,
-				authRequestDomainToAuthRequestInfo(authRequest),
-			),
+			lockout.checkedEvents(ctx, userAgg,
+				usr_repo.NewHumanU2FCheckFailedEvent(
+					ctx,
+					userAgg,
+					authRequestDomainToAuthRequestInfo(authRequest),
+				),
+				true,
+			)...,
 		)
 		logging.WithFields("userID", userID, "resourceOwner", resourceOwner).OnError(pushErr).Warn("could not push failed u2f check event")
 		return err
 	}
 
 	_, err = c.eventstore.Push(ctx,
-		usr_repo.NewHumanU2FCheckSucceededEvent(
-			ctx,
-			userAgg,
-			authRequestDomainToAuthRequestInfo(authRequest),
-		),
-		usr_repo.NewHumanU2FSignCountChangedEvent(
-			ctx,
-			userAgg,
-			token.WebAuthNTokenID,
-			signCount,
-		),
+		append(
+			lockout.checkedEvents(ctx, userAgg,
+				usr_repo.NewHumanU2FCheckSucceededEvent(
+					ctx,
+					userAgg,
+					authRequestDomainToAuthRequestInfo(authRequest),
+				),
+				false,
+			),
+			usr_repo.NewHumanU2FSignCountChangedEvent(
+				ctx,
+				userAgg,
+				token.WebAuthNTokenID,
+				signCount,
+			),
+		)...,
 	)
 
 	return err
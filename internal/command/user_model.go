@@ -14,6 +14,8 @@ type UserWriteModel struct {
 	IDPLinks  []*domain.UserIDPLink
 	UserState domain.UserState
 	UserType  domain.UserType
+	// Forgotten is true if the data key of the removed user was destroyed
+	Forgotten bool
 }
 
 func NewUserWriteModel(userID, resourceOwner string) *UserWriteModel {
@@ -83,6 +85,8 @@ func (wm *UserWriteModel) Reduce() error {
 			}
 		case *user.UserRemovedEvent:
 			wm.UserState = domain.UserStateDeleted
+		case *user.UserForgottenEvent:
+			wm.Forgotten = true
 		}
 	}
 	return wm.WriteModel.Reduce()
@@ -108,6 +112,7 @@ func (wm *UserWriteModel) Query() *eventstore.SearchQueryBuilder {
 			user.UserDeactivatedType,
 			user.UserReactivatedType,
 			user.UserRemovedType,
+			user.UserForgottenType,
 			user.UserV1AddedType,
 			user.UserV1RegisteredType,
 			user.UserV1InitializedCheckSucceededType).
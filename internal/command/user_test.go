@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/text/language"
 
+	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/command/preparation"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
@@ -1433,6 +1434,242 @@ func TestCommandSide_RemoveUser(t *testing.T) {
 	}
 }
 
+type destroyPersonalDataKeys struct {
+	eventstore.PersonalDataKeys
+	destroy func(ctx context.Context, instanceID, ownerID string) error
+}
+
+func (k *destroyPersonalDataKeys) IsDestroyed(context.Context, string, string) (bool, error) {
+	return false, nil
+}
+
+func (k *destroyPersonalDataKeys) Destroy(ctx context.Context, instanceID, ownerID string) error {
+	return k.destroy(ctx, instanceID, ownerID)
+}
+
+func TestCommandSide_ForgetUser(t *testing.T) {
+	type fields struct {
+		eventstore func(*testing.T, eventstore.PersonalDataKeys) *eventstore.Eventstore
+		destroy    func(ctx context.Context, instanceID, ownerID string) error
+	}
+	type args struct {
+		ctx    context.Context
+		orgID  string
+		userID string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	humanAdded := func() eventstore.Event {
+		return eventFromEventPusher(
+			user.NewHumanAddedEvent(context.Background(),
+				&user.NewAggregate("user1", "org1").Aggregate,
+				"username",
+				"firstname",
+				"lastname",
+				"nickname",
+				"displayname",
+				language.German,
+				domain.GenderUnspecified,
+				"email@test.ch",
+				true,
+			),
+		)
+	}
+	userRemoved := func() eventstore.Event {
+		return eventFromEventPusher(
+			user.NewUserRemovedEvent(context.Background(),
+				&user.NewAggregate("user1", "org1").Aggregate,
+				"username",
+				nil,
+				true,
+			),
+		)
+	}
+	withKeys := func(expects ...expect) func(*testing.T, eventstore.PersonalDataKeys) *eventstore.Eventstore {
+		return func(t *testing.T, keys eventstore.PersonalDataKeys) *eventstore.Eventstore {
+			return eventstoreExpectWithPersonalDataKeys(t, keys, expects...)
+		}
+	}
+	withoutKeys := func(expects ...expect) func(*testing.T, eventstore.PersonalDataKeys) *eventstore.Eventstore {
+		return func(t *testing.T, _ eventstore.PersonalDataKeys) *eventstore.Eventstore {
+			return eventstoreExpect(t, expects...)
+		}
+	}
+	destroyed := func(t *testing.T) func(ctx context.Context, instanceID, ownerID string) error {
+		return func(_ context.Context, instanceID, ownerID string) error {
+			assert.Equal(t, "instance1", instanceID)
+			assert.Equal(t, "user1", ownerID)
+			return nil
+		}
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "userid missing, invalid argument error",
+			fields: fields{
+				eventstore: withKeys(),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+			},
+			res: res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "user not existing, not found error",
+			fields: fields{
+				eventstore: withKeys(
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:    context.Background(),
+				orgID:  "org1",
+				userID: "user1",
+			},
+			res: res{
+				err: errors.IsNotFound,
+			},
+		},
+		{
+			name: "user not removed, precondition error",
+			fields: fields{
+				eventstore: withKeys(
+					expectFilter(
+						humanAdded(),
+					),
+				),
+			},
+			args: args{
+				ctx:    context.Background(),
+				orgID:  "org1",
+				userID: "user1",
+			},
+			res: res{
+				err: errors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "crypto-shredding disabled, precondition error",
+			fields: fields{
+				eventstore: withoutKeys(
+					expectFilter(
+						humanAdded(),
+						userRemoved(),
+					),
+				),
+			},
+			args: args{
+				ctx:    context.Background(),
+				orgID:  "org1",
+				userID: "user1",
+			},
+			res: res{
+				err: errors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "destroy failed, no event pushed",
+			fields: fields{
+				eventstore: withKeys(
+					expectFilter(
+						humanAdded(),
+						userRemoved(),
+					),
+				),
+				destroy: func(context.Context, string, string) error {
+					return errors.ThrowInternal(nil, "TEST-ooT5a", "destroy failed")
+				},
+			},
+			args: args{
+				ctx:    authz.WithInstanceID(context.Background(), "instance1"),
+				orgID:  "org1",
+				userID: "user1",
+			},
+			res: res{
+				err: errors.IsInternal,
+			},
+		},
+		{
+			name: "forget user, ok",
+			fields: fields{
+				eventstore: withKeys(
+					expectFilter(
+						humanAdded(),
+						userRemoved(),
+					),
+					expectPush(
+						user.NewUserForgottenEvent(context.Background(),
+							&user.NewAggregate("user1", "org1").Aggregate,
+						),
+					),
+				),
+				destroy: destroyed(t),
+			},
+			args: args{
+				ctx:    authz.WithInstanceID(context.Background(), "instance1"),
+				orgID:  "org1",
+				userID: "user1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			name: "user already forgotten, key destroyed again",
+			fields: fields{
+				eventstore: withKeys(
+					expectFilter(
+						humanAdded(),
+						userRemoved(),
+						eventFromEventPusher(
+							user.NewUserForgottenEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+							),
+						),
+					),
+				),
+				destroy: destroyed(t),
+			},
+			args: args{
+				ctx:    authz.WithInstanceID(context.Background(), "instance1"),
+				orgID:  "org1",
+				userID: "user1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore(t, &destroyPersonalDataKeys{destroy: tt.fields.destroy}),
+			}
+			got, err := r.ForgetUser(tt.args.ctx, tt.args.userID, tt.args.orgID)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			} else if !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+				return
+			}
+			assert.Equal(t, tt.res.want, got)
+		})
+	}
+}
+
 func TestCommandSide_AddUserToken(t *testing.T) {
 	type fields struct {
 		eventstore  *eventstore.Eventstore
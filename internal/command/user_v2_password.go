@@ -55,6 +55,9 @@ func (c *Commands) requestPasswordReset(ctx context.Context, userID string, retu
 			return nil, nil, err
 		}
 	}
+	if err = c.runPrePasswordResetActions(ctx, userID, model.ResourceOwner, notificationType, returnCode); err != nil {
+		return nil, nil, err
+	}
 	code, err := c.newCode(ctx, c.eventstore.Filter, domain.SecretGeneratorTypePasswordResetCode, c.userEncryption)
 	if err != nil {
 		return nil, nil, err
@@ -9,6 +9,7 @@ import (
 type SystemDefaults struct {
 	SecretGenerators   SecretGenerators
 	PasswordHasher     crypto.PasswordHashConfig
+	BreachedPasswords  crypto.BreachedPasswordConfig
 	Multifactors       MultifactorConfig
 	DomainVerification DomainVerification
 	Notifications      Notifications
@@ -22,13 +23,19 @@ type SecretGenerators struct {
 }
 
 type MultifactorConfig struct {
-	OTP OTPConfig
+	OTP           OTPConfig
+	RecoveryCodes RecoveryCodesConfig
 }
 
 type OTPConfig struct {
 	Issuer string
 }
 
+type RecoveryCodesConfig struct {
+	Count  uint
+	Length uint
+}
+
 type DomainVerification struct {
 	VerificationGenerator crypto.GeneratorConfig
 }
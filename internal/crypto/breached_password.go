@@ -0,0 +1,128 @@
+package crypto
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const breachedPasswordPrefixLength = 5
+
+// BreachedPasswordChecker reports whether a password is part of a known data breach.
+type BreachedPasswordChecker interface {
+	IsBreached(ctx context.Context, password string) (bool, error)
+}
+
+type BreachedPasswordConfig struct {
+	// RangeURL is the base URL of a Have I Been Pwned compatible k-anonymity range API,
+	// e.g. https://api.pwnedpasswords.com/range/
+	RangeURL string
+	// PrefixDir is a directory containing one file per SHA-1 prefix (e.g. 21BD1.txt)
+	// in the format of the range API, as created by the haveibeenpwned-downloader.
+	// If set, it takes precedence over the RangeURL.
+	PrefixDir string
+	// Timeout limits the duration of a single request to the RangeURL
+	Timeout time.Duration
+	// CheckOnLogin checks the password on every successful password check
+	// and requires the user to change it if it was breached in the meantime
+	CheckOnLogin bool
+}
+
+// Checker returns the configured [BreachedPasswordChecker]
+// or nil if neither a RangeURL nor a PrefixDir are configured.
+func (c *BreachedPasswordConfig) Checker(client *http.Client) BreachedPasswordChecker {
+	if c.PrefixDir != "" {
+		return &breachedPasswordPrefixDir{dir: c.PrefixDir}
+	}
+	if c.RangeURL != "" {
+		if client == nil {
+			client = http.DefaultClient
+		}
+		return &breachedPasswordRangeAPI{
+			url:     strings.TrimSuffix(c.RangeURL, "/") + "/",
+			client:  client,
+			timeout: c.Timeout,
+		}
+	}
+	return nil
+}
+
+type breachedPasswordRangeAPI struct {
+	url     string
+	client  *http.Client
+	timeout time.Duration
+}
+
+func (r *breachedPasswordRangeAPI) IsBreached(ctx context.Context, password string) (bool, error) {
+	prefix, suffix := breachedPasswordHash(password)
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.url+prefix, nil)
+	if err != nil {
+		return false, errors.ThrowInternal(err, "CRYPT-Ahx3u", "unable to create breached password request")
+	}
+	req.Header.Set("Add-Padding", "true")
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return false, errors.ThrowUnavailable(err, "CRYPT-Ood6a", "breached password range api unavailable")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, errors.ThrowUnavailablef(nil, "CRYPT-eeTh0", "breached password range api responded with %d", resp.StatusCode)
+	}
+	return breachedPasswordRangeContains(resp.Body, suffix)
+}
+
+type breachedPasswordPrefixDir struct {
+	dir string
+}
+
+func (d *breachedPasswordPrefixDir) IsBreached(_ context.Context, password string) (bool, error) {
+	prefix, suffix := breachedPasswordHash(password)
+	file, err := os.Open(filepath.Join(d.dir, prefix+".txt"))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.ThrowInternal(err, "CRYPT-Xoh5e", "unable to open breached password prefix file")
+	}
+	defer file.Close()
+	return breachedPasswordRangeContains(file, suffix)
+}
+
+// breachedPasswordHash returns the upper case hex encoded SHA-1 hash of the password
+// split into the prefix sent to the range API and the remaining suffix.
+func breachedPasswordHash(password string) (prefix, suffix string) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	return hash[:breachedPasswordPrefixLength], hash[breachedPasswordPrefixLength:]
+}
+
+// breachedPasswordRangeContains reads lines in the form of `SUFFIX:COUNT`.
+// Entries with a count of 0 are padding and ignored.
+func breachedPasswordRangeContains(r io.Reader, suffix string) (bool, error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		hash, count, found := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if !found || !strings.EqualFold(hash, suffix) {
+			continue
+		}
+		return strings.TrimLeft(count, "0") != "", nil
+	}
+	if err := scanner.Err(); err != nil {
+		return false, errors.ThrowInternal(err, "CRYPT-Ien4o", "unable to read breached password range")
+	}
+	return false, nil
+}
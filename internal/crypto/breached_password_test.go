@@ -0,0 +1,107 @@
+package crypto
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sha1("password") = 5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8
+const breachedPasswordTestRange = "1E4C9B93F3F0682250B6CF8331B7EE68FD8:9545824\r\n" +
+	"1E5A9AA7E0E7A0C4E4C0C0A2A2C4D0B8B61:0\r\n" +
+	"011053FD0102E94D6AE2F8B83D76FAF94F6:1\r\n"
+
+func TestBreachedPasswordConfig_Checker(t *testing.T) {
+	assert.Nil(t, (&BreachedPasswordConfig{}).Checker(nil))
+	assert.IsType(t, &breachedPasswordRangeAPI{}, (&BreachedPasswordConfig{RangeURL: "https://localhost/range"}).Checker(nil))
+	assert.IsType(t, &breachedPasswordPrefixDir{}, (&BreachedPasswordConfig{RangeURL: "https://localhost/range", PrefixDir: "/tmp"}).Checker(nil))
+}
+
+func Test_breachedPasswordRangeAPI_IsBreached(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/range/5BAA6" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		assert.Equal(t, "true", r.Header.Get("Add-Padding"))
+		_, _ = w.Write([]byte(breachedPasswordTestRange))
+	}))
+	defer server.Close()
+
+	checker := (&BreachedPasswordConfig{RangeURL: server.URL + "/range"}).Checker(server.Client())
+	breached, err := checker.IsBreached(context.Background(), "password")
+	require.NoError(t, err)
+	assert.True(t, breached)
+
+	breached, err = checker.IsBreached(context.Background(), "Xk3#v9!qLz$8wP")
+	require.NoError(t, err)
+	assert.False(t, breached)
+}
+
+func Test_breachedPasswordRangeAPI_IsBreached_unavailable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	checker := (&BreachedPasswordConfig{RangeURL: server.URL}).Checker(server.Client())
+	_, err := checker.IsBreached(context.Background(), "password")
+	assert.Error(t, err)
+}
+
+func Test_breachedPasswordPrefixDir_IsBreached(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "5BAA6.txt"), []byte(breachedPasswordTestRange), 0600))
+
+	checker := (&BreachedPasswordConfig{PrefixDir: dir}).Checker(nil)
+	breached, err := checker.IsBreached(context.Background(), "password")
+	require.NoError(t, err)
+	assert.True(t, breached)
+
+	breached, err = checker.IsBreached(context.Background(), "Xk3#v9!qLz$8wP")
+	require.NoError(t, err)
+	assert.False(t, breached)
+}
+
+func Test_breachedPasswordRangeContains(t *testing.T) {
+	tests := []struct {
+		name   string
+		suffix string
+		want   bool
+	}{
+		{
+			name:   "found",
+			suffix: "011053FD0102E94D6AE2F8B83D76FAF94F6",
+			want:   true,
+		},
+		{
+			name:   "found lower case",
+			suffix: "1e4c9b93f3f0682250b6cf8331b7ee68fd8",
+			want:   true,
+		},
+		{
+			name:   "padding",
+			suffix: "1E5A9AA7E0E7A0C4E4C0C0A2A2C4D0B8B61",
+			want:   false,
+		},
+		{
+			name:   "not found",
+			suffix: "00000000000000000000000000000000000",
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := breachedPasswordRangeContains(strings.NewReader(breachedPasswordTestRange), tt.suffix)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package crypto
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	dataKeyLength       = 32
+	dataKeyCacheTTL     = time.Minute
+	dataKeyCacheMaxSize = 10000
+)
+
+// ShreddingConfig enables crypto-shredding of personal data
+type ShreddingConfig struct {
+	// Enabled encrypts the personal data of new events with the data key of the owner
+	Enabled bool
+}
+
+// DataKeyStorage persists the data keys of the owners (e.g. users) of personal data.
+// The keys are stored outside of the eventstore, so they can be deleted.
+type DataKeyStorage interface {
+	// CreateDataKey stores the key in the transaction if the owner has none yet and returns the stored key.
+	// It returns a PreconditionFailed error if the key of the owner was destroyed.
+	CreateDataKey(ctx context.Context, tx *sql.Tx, instanceID, ownerID string, key *CryptoValue) (*CryptoValue, error)
+	// ReadDataKey returns a NotFound error if the owner has no key (anymore)
+	ReadDataKey(ctx context.Context, instanceID, ownerID string) (*CryptoValue, error)
+	// DestroyDataKey deletes the key and remembers the owner as destroyed,
+	// it has no effect if the key was already destroyed
+	DestroyDataKey(ctx context.Context, instanceID, ownerID string) error
+	// DestroyedDataKeys returns the owners of the instance whose keys were destroyed
+	DestroyedDataKeys(ctx context.Context, instanceID string) ([]string, error)
+}
+
+// DataKeys encrypts personal data with a key per owner (crypto-shredding).
+// After the key is destroyed, the data cannot be decrypted anymore.
+// The data keys are encrypted by the EncryptionAlgorithm before they are stored.
+//
+// Keys are cached for a minute, so other nodes might still decrypt data
+// for that duration after the key was destroyed.
+type DataKeys struct {
+	storage DataKeyStorage
+	alg     EncryptionAlgorithm
+
+	mu        sync.Mutex
+	cache     map[string]*cachedDataKey
+	destroyed map[string]*destroyedDataKeys
+}
+
+type cachedDataKey struct {
+	key       []byte
+	expiresAt time.Time
+}
+
+type destroyedDataKeys struct {
+	owners    map[string]bool
+	expiresAt time.Time
+}
+
+func NewDataKeys(storage DataKeyStorage, alg EncryptionAlgorithm) *DataKeys {
+	return &DataKeys{
+		storage:   storage,
+		alg:       alg,
+		cache:     make(map[string]*cachedDataKey),
+		destroyed: make(map[string]*destroyedDataKeys),
+	}
+}
+
+// Seal encrypts the value with the data key of the owner,
+// the key is created in the transaction if it does not exist
+func (k *DataKeys) Seal(ctx context.Context, tx *sql.Tx, instanceID, ownerID string, value []byte) (string, error) {
+	key, err := k.dataKey(ctx, instanceID, ownerID)
+	if err != nil {
+		return "", err
+	}
+	if key == nil {
+		// the created key is not cached, because the transaction might be rolled back
+		if key, err = k.createDataKey(ctx, tx, instanceID, ownerID); err != nil {
+			return "", err
+		}
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, value, []byte(instanceID+ownerID))), nil
+}
+
+// Open decrypts the sealed value with the data key of the owner.
+// It returns a NotFound error if the key was destroyed.
+func (k *DataKeys) Open(ctx context.Context, instanceID, ownerID, sealed string) ([]byte, error) {
+	key, err := k.dataKey(ctx, instanceID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		return nil, errors.ThrowNotFound(nil, "CRYPT-Eez3i", "Errors.DataKey.NotFound")
+	}
+	data, err := base64.StdEncoding.DecodeString(sealed)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "CRYPT-Aeg4o", "unable to decode sealed data")
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, errors.ThrowInternal(nil, "CRYPT-Ohw6u", "sealed data too short")
+	}
+	value, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], []byte(instanceID+ownerID))
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "CRYPT-ooY5a", "unable to open sealed data")
+	}
+	return value, nil
+}
+
+// IsDestroyed returns true if the key of the owner was destroyed.
+// The destroyed keys are cached per instance, because they are checked for the unsealed personal data of all owners.
+func (k *DataKeys) IsDestroyed(ctx context.Context, instanceID, ownerID string) (bool, error) {
+	k.mu.Lock()
+	cached, ok := k.destroyed[instanceID]
+	k.mu.Unlock()
+	if ok && cached.expiresAt.After(time.Now()) {
+		return cached.owners[ownerID], nil
+	}
+
+	owners, err := k.storage.DestroyedDataKeys(ctx, instanceID)
+	if err != nil {
+		return false, err
+	}
+	cached = &destroyedDataKeys{
+		owners:    make(map[string]bool, len(owners)),
+		expiresAt: time.Now().Add(dataKeyCacheTTL),
+	}
+	for _, owner := range owners {
+		cached.owners[owner] = true
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.destroyed[instanceID] = cached
+	return cached.owners[ownerID], nil
+}
+
+// Destroy deletes the data key of the owner, all data sealed with it is lost.
+// Destroying it again has no effect.
+func (k *DataKeys) Destroy(ctx context.Context, instanceID, ownerID string) error {
+	if err := k.storage.DestroyDataKey(ctx, instanceID, ownerID); err != nil {
+		return err
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	delete(k.cache, instanceID+":"+ownerID)
+	delete(k.destroyed, instanceID)
+	return nil
+}
+
+// dataKey returns the decrypted key of the owner or nil if it does not exist.
+// Missing keys are not cached, because they might be created by a push in progress.
+func (k *DataKeys) dataKey(ctx context.Context, instanceID, ownerID string) ([]byte, error) {
+	cacheKey := instanceID + ":" + ownerID
+	k.mu.Lock()
+	cached, ok := k.cache[cacheKey]
+	k.mu.Unlock()
+	if ok && cached.expiresAt.After(time.Now()) {
+		return cached.key, nil
+	}
+
+	encrypted, err := k.storage.ReadDataKey(ctx, instanceID, ownerID)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	if encrypted == nil {
+		return nil, nil
+	}
+	key, err := Decrypt(encrypted, k.alg)
+	if err != nil {
+		return nil, err
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if len(k.cache) >= dataKeyCacheMaxSize {
+		k.cache = make(map[string]*cachedDataKey)
+	}
+	k.cache[cacheKey] = &cachedDataKey{key: key, expiresAt: time.Now().Add(dataKeyCacheTTL)}
+	return key, nil
+}
+
+func (k *DataKeys) createDataKey(ctx context.Context, tx *sql.Tx, instanceID, ownerID string) ([]byte, error) {
+	key := make([]byte, dataKeyLength)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, err
+	}
+	encrypted, err := Encrypt(key, k.alg)
+	if err != nil {
+		return nil, err
+	}
+	// the key of a concurrent creation could have been stored
+	stored, err := k.storage.CreateDataKey(ctx, tx, instanceID, ownerID, encrypted)
+	if err != nil {
+		return nil, err
+	}
+	return Decrypt(stored, k.alg)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
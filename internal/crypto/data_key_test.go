@@ -0,0 +1,87 @@
+package crypto
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+type memoryDataKeyStorage struct {
+	keys      map[string]*CryptoValue
+	destroyed map[string][]string
+	reads     int
+}
+
+func (m *memoryDataKeyStorage) CreateDataKey(_ context.Context, _ *sql.Tx, instanceID, ownerID string, key *CryptoValue) (*CryptoValue, error) {
+	for _, owner := range m.destroyed[instanceID] {
+		if owner == ownerID {
+			return nil, errors.ThrowPreconditionFailed(nil, "TEST-Aig3u", "destroyed")
+		}
+	}
+	if existing, ok := m.keys[instanceID+ownerID]; ok {
+		return existing, nil
+	}
+	m.keys[instanceID+ownerID] = key
+	return key, nil
+}
+
+func (m *memoryDataKeyStorage) ReadDataKey(_ context.Context, instanceID, ownerID string) (*CryptoValue, error) {
+	m.reads++
+	key, ok := m.keys[instanceID+ownerID]
+	if !ok {
+		return nil, errors.ThrowNotFound(nil, "TEST-ooK3e", "not found")
+	}
+	return key, nil
+}
+
+func (m *memoryDataKeyStorage) DestroyDataKey(_ context.Context, instanceID, ownerID string) error {
+	delete(m.keys, instanceID+ownerID)
+	m.destroyed[instanceID] = append(m.destroyed[instanceID], ownerID)
+	return nil
+}
+
+func (m *memoryDataKeyStorage) DestroyedDataKeys(_ context.Context, instanceID string) ([]string, error) {
+	return m.destroyed[instanceID], nil
+}
+
+func TestDataKeys(t *testing.T) {
+	ctx := context.Background()
+	storage := &memoryDataKeyStorage{keys: make(map[string]*CryptoValue), destroyed: make(map[string][]string)}
+	keys := NewDataKeys(storage, &mockEncCrypto{})
+
+	sealed, err := keys.Seal(ctx, nil, "instance", "user1", []byte("personal data"))
+	require.NoError(t, err)
+	assert.NotContains(t, sealed, "personal data")
+	assert.Len(t, storage.keys, 1)
+
+	opened, err := keys.Open(ctx, "instance", "user1", sealed)
+	require.NoError(t, err)
+	assert.Equal(t, "personal data", string(opened))
+	opened, err = keys.Open(ctx, "instance", "user1", sealed)
+	require.NoError(t, err)
+	assert.Equal(t, "personal data", string(opened))
+	assert.Equal(t, 2, storage.reads, "key must be cached after it was read")
+
+	_, err = keys.Open(ctx, "instance", "user2", sealed)
+	assert.True(t, errors.IsNotFound(err), "other owner has no key")
+
+	destroyed, err := keys.IsDestroyed(ctx, "instance", "user1")
+	require.NoError(t, err)
+	assert.False(t, destroyed)
+
+	require.NoError(t, keys.Destroy(ctx, "instance", "user1"))
+	require.NoError(t, keys.Destroy(ctx, "instance", "user1"), "destroy must be idempotent")
+	_, err = keys.Open(ctx, "instance", "user1", sealed)
+	assert.True(t, errors.IsNotFound(err), "key destroyed")
+	destroyed, err = keys.IsDestroyed(ctx, "instance", "user1")
+	require.NoError(t, err)
+	assert.True(t, destroyed)
+
+	_, err = keys.Seal(ctx, nil, "instance", "user1", []byte("personal data"))
+	assert.True(t, errors.IsPreconditionFailed(err), "no new key for destroyed owner")
+}
@@ -0,0 +1,164 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	z_db "github.com/zitadel/zitadel/internal/database"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	DataKeysTable           = "system.data_keys"
+	dataKeysInstanceIDCol   = "instance_id"
+	dataKeysOwnerIDCol      = "owner_id"
+	dataKeysKeyCol          = "key"
+	dataKeysCreationDateCol = "creation_date"
+
+	DestroyedDataKeysTable              = "system.destroyed_data_keys"
+	destroyedDataKeysInstanceIDCol      = "instance_id"
+	destroyedDataKeysOwnerIDCol         = "owner_id"
+	destroyedDataKeysDestructionDateCol = "destruction_date"
+)
+
+// createDataKeyStmt stores the key unless the owner has one or its key was destroyed
+const createDataKeyStmt = "INSERT INTO " + DataKeysTable +
+	" (" + dataKeysInstanceIDCol + ", " + dataKeysOwnerIDCol + ", " + dataKeysKeyCol + ", " + dataKeysCreationDateCol + ")" +
+	" SELECT $1::TEXT, $2::TEXT, $3::JSONB, now()" +
+	" WHERE NOT EXISTS (SELECT 1 FROM " + DestroyedDataKeysTable +
+	" WHERE " + destroyedDataKeysInstanceIDCol + " = $1 AND " + destroyedDataKeysOwnerIDCol + " = $2)" +
+	" ON CONFLICT (" + dataKeysInstanceIDCol + ", " + dataKeysOwnerIDCol + ") DO NOTHING"
+
+type dataKeyStorage struct {
+	client *z_db.DB
+}
+
+// NewDataKeyStorage stores the data keys used for crypto-shredding of personal data
+func NewDataKeyStorage(client *z_db.DB) crypto.DataKeyStorage {
+	return &dataKeyStorage{client: client}
+}
+
+func (d *dataKeyStorage) CreateDataKey(ctx context.Context, tx *sql.Tx, instanceID, ownerID string, key *crypto.CryptoValue) (*crypto.CryptoValue, error) {
+	if _, err := tx.ExecContext(ctx, createDataKeyStmt, instanceID, ownerID, key); err != nil {
+		return nil, caos_errs.ThrowInternal(err, "CRYPT-ahL9e", "unable to create data key")
+	}
+
+	// the key of a concurrent creation could have been stored
+	stmt, args, err := readDataKeyStmt(instanceID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	stored := new(crypto.CryptoValue)
+	err = tx.QueryRowContext(ctx, stmt, args...).Scan(stored)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, caos_errs.ThrowPreconditionFailed(err, "CRYPT-Zoh5e", "Errors.DataKey.Destroyed")
+	}
+	if err != nil {
+		return nil, caos_errs.ThrowInternal(err, "CRYPT-Ez4ai", "unable to read data key")
+	}
+	return stored, nil
+}
+
+func (d *dataKeyStorage) ReadDataKey(ctx context.Context, instanceID, ownerID string) (*crypto.CryptoValue, error) {
+	stmt, args, err := readDataKeyStmt(instanceID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	key := new(crypto.CryptoValue)
+	err = d.client.QueryRowContext(ctx, func(row *sql.Row) error {
+		return row.Scan(key)
+	}, stmt, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, caos_errs.ThrowNotFound(err, "CRYPT-Ush3e", "Errors.DataKey.NotFound")
+	}
+	if err != nil {
+		return nil, caos_errs.ThrowInternal(err, "CRYPT-ieG2u", "unable to read data key")
+	}
+	return key, nil
+}
+
+func readDataKeyStmt(instanceID, ownerID string) (string, []any, error) {
+	stmt, args, err := sq.Select(dataKeysKeyCol).
+		From(DataKeysTable).
+		Where(sq.Eq{
+			dataKeysInstanceIDCol: instanceID,
+			dataKeysOwnerIDCol:    ownerID,
+		}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return "", nil, caos_errs.ThrowInternal(err, "CRYPT-Wai5u", "unable to read data key")
+	}
+	return stmt, args, nil
+}
+
+func (d *dataKeyStorage) DestroyDataKey(ctx context.Context, instanceID, ownerID string) (err error) {
+	deleteStmt, deleteArgs, err := sq.Delete(DataKeysTable).
+		Where(sq.Eq{
+			dataKeysInstanceIDCol: instanceID,
+			dataKeysOwnerIDCol:    ownerID,
+		}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "CRYPT-Eev1a", "unable to destroy data key")
+	}
+	destroyStmt, destroyArgs, err := sq.Insert(DestroyedDataKeysTable).
+		Columns(destroyedDataKeysInstanceIDCol, destroyedDataKeysOwnerIDCol, destroyedDataKeysDestructionDateCol).
+		Values(instanceID, ownerID, sq.Expr("now()")).
+		Suffix("ON CONFLICT (" + destroyedDataKeysInstanceIDCol + ", " + destroyedDataKeysOwnerIDCol + ") DO NOTHING").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "CRYPT-ohD5i", "unable to destroy data key")
+	}
+
+	tx, err := d.client.BeginTx(ctx, nil)
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "CRYPT-Eiz8e", "unable to destroy data key")
+	}
+	defer func() {
+		if err != nil {
+			logging.OnError(tx.Rollback()).Debug("unable to rollback")
+			return
+		}
+		err = tx.Commit()
+	}()
+	if _, err = tx.ExecContext(ctx, deleteStmt, deleteArgs...); err != nil {
+		return caos_errs.ThrowInternal(err, "CRYPT-ooz9E", "unable to destroy data key")
+	}
+	if _, err = tx.ExecContext(ctx, destroyStmt, destroyArgs...); err != nil {
+		return caos_errs.ThrowInternal(err, "CRYPT-Ahz1o", "unable to destroy data key")
+	}
+	return nil
+}
+
+func (d *dataKeyStorage) DestroyedDataKeys(ctx context.Context, instanceID string) (owners []string, err error) {
+	stmt, args, err := sq.Select(destroyedDataKeysOwnerIDCol).
+		From(DestroyedDataKeysTable).
+		Where(sq.Eq{destroyedDataKeysInstanceIDCol: instanceID}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, caos_errs.ThrowInternal(err, "CRYPT-Voh1a", "unable to query destroyed data keys")
+	}
+	err = d.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		for rows.Next() {
+			var owner string
+			if err := rows.Scan(&owner); err != nil {
+				return err
+			}
+			owners = append(owners, owner)
+		}
+		return rows.Err()
+	}, stmt, args...)
+	if err != nil {
+		return nil, caos_errs.ThrowInternal(err, "CRYPT-Thi4a", "unable to query destroyed data keys")
+	}
+	return owners, nil
+}
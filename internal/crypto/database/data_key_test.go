@@ -0,0 +1,77 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	z_db "github.com/zitadel/zitadel/internal/database"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+func Test_dataKeyStorage(t *testing.T) {
+	client, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	storage := NewDataKeyStorage(&z_db.DB{DB: client})
+	key := &crypto.CryptoValue{CryptoType: crypto.TypeEncryption, Algorithm: "enc", KeyID: "id", Crypted: []byte("key")}
+	keyJSON, err := key.Value()
+	require.NoError(t, err)
+
+	mock.ExpectBegin()
+	tx, err := client.Begin()
+	require.NoError(t, err)
+	mock.ExpectExec(regexp.QuoteMeta(createDataKeyStmt)).
+		WithArgs("instance", "user1", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT key FROM system.data_keys WHERE instance_id = $1 AND owner_id = $2")).
+		WithArgs("instance", "user1").
+		WillReturnRows(sqlmock.NewRows([]string{"key"}).AddRow(keyJSON))
+	created, err := storage.CreateDataKey(context.Background(), tx, "instance", "user1", key)
+	require.NoError(t, err)
+	assert.Equal(t, key, created)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM system.data_keys WHERE instance_id = $1 AND owner_id = $2")).
+		WithArgs("instance", "user1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO system.destroyed_data_keys (instance_id,owner_id,destruction_date) VALUES ($1,$2,now()) ON CONFLICT (instance_id, owner_id) DO NOTHING")).
+		WithArgs("instance", "user1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	require.NoError(t, storage.DestroyDataKey(context.Background(), "instance", "user1"))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT key FROM system.data_keys WHERE instance_id = $1 AND owner_id = $2")).
+		WithArgs("instance", "user1").
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+	_, err = storage.ReadDataKey(context.Background(), "instance", "user1")
+	assert.True(t, caos_errs.IsNotFound(err))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT owner_id FROM system.destroyed_data_keys WHERE instance_id = $1")).
+		WithArgs("instance").
+		WillReturnRows(sqlmock.NewRows([]string{"owner_id"}).AddRow("user1"))
+	mock.ExpectCommit()
+	destroyed, err := storage.DestroyedDataKeys(context.Background(), "instance")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user1"}, destroyed)
+
+	// no key is created after it was destroyed
+	mock.ExpectExec(regexp.QuoteMeta(createDataKeyStmt)).
+		WithArgs("instance", "user1", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT key FROM system.data_keys WHERE instance_id = $1 AND owner_id = $2")).
+		WithArgs("instance", "user1").
+		WillReturnError(sql.ErrNoRows)
+	_, err = storage.CreateDataKey(context.Background(), tx, "instance", "user1", key)
+	assert.True(t, caos_errs.IsPreconditionFailed(err))
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
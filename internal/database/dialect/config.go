@@ -25,6 +25,8 @@ type Matcher interface {
 
 type Connector interface {
 	Connect(useAdmin, isEventPusher bool, pusherRatio float32, appName string) (*sql.DB, error)
+	// ConnectReplicas opens a connection pool for each configured read replica
+	ConnectReplicas(appName string) ([]*sql.DB, error)
 	Password() string
 	Database
 }
@@ -36,6 +38,25 @@ type Database interface {
 	Timetravel(time.Duration) string
 }
 
+// Replica is a read only copy of the database.
+// The database name, users and options of the primary are used to connect.
+type Replica struct {
+	Host            string
+	Port            uint16
+	MaxOpenConns    uint32
+	MaxIdleConns    uint32
+	MaxConnLifetime time.Duration
+	MaxConnIdleTime time.Duration
+}
+
+// Configure applies the connection limits of the replica to the pool
+func (r *Replica) Configure(db *sql.DB) {
+	db.SetMaxOpenConns(int(r.MaxOpenConns))
+	db.SetMaxIdleConns(int(r.MaxIdleConns))
+	db.SetConnMaxLifetime(r.MaxConnLifetime)
+	db.SetConnMaxIdleTime(r.MaxConnIdleTime)
+}
+
 func Register(matcher Matcher, config Connector, isDefault bool) {
 	dialectsMu.Lock()
 	defer dialectsMu.Unlock()
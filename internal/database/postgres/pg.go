@@ -39,6 +39,8 @@ type Config struct {
 	// Additional options to be appended as options=<Options>
 	// The value will be taken as is. Multiple options are space separated.
 	Options string
+	// Replicas are read only copies of the database used by the queries
+	Replicas []dialect.Replica
 }
 
 func (c *Config) MatchName(name string) bool {
@@ -96,6 +98,22 @@ func (c *Config) Connect(useAdmin, isEventPusher bool, pusherRatio float32, appN
 	return db, nil
 }
 
+func (c *Config) ConnectReplicas(appName string) ([]*sql.DB, error) {
+	clients := make([]*sql.DB, 0, len(c.Replicas))
+	for _, replica := range c.Replicas {
+		config := *c
+		config.Host = replica.Host
+		config.Port = int32(replica.Port)
+		client, err := sql.Open("pgx", config.String(false, appName))
+		if err != nil {
+			return nil, err
+		}
+		replica.Configure(client)
+		clients = append(clients, client)
+	}
+	return clients, nil
+}
+
 func (c *Config) DatabaseName() string {
 	return c.Database
 }
@@ -0,0 +1,187 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"sync/atomic"
+
+	"github.com/zitadel/logging"
+
+	zerrors "github.com/zitadel/zitadel/internal/errors"
+)
+
+// replicaPositionsStmt returns the positions of the latest events processed by the projections of an instance on the replica.
+// The projections are read from the replicas, so the replicated state of the projections is relevant
+// and not the replicated events.
+const replicaPositionsStmt = `SELECT "position" FROM projections.current_states WHERE instance_id = $1`
+
+type replica struct {
+	*sql.DB
+
+	mu sync.RWMutex
+	// positions are the positions per instance up to which all projections are known to be replicated
+	positions map[string]float64
+}
+
+// caughtUp returns true if all projections of the instance on the replica processed the events up to position.
+// The slowest projection is relevant because the projections read by the call are unknown.
+func (r *replica) caughtUp(ctx context.Context, instanceID string, position float64) bool {
+	if r.knownPosition(instanceID) >= position {
+		return true
+	}
+	// the lock is not held during the query, so concurrent calls don't wait for each other
+	latest, err := r.queryPosition(ctx, instanceID)
+	if err != nil {
+		logging.WithError(err).WithField("instance", instanceID).Warn("unable to query position of replica")
+		return false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.positions == nil {
+		r.positions = make(map[string]float64)
+	}
+	if latest > r.positions[instanceID] {
+		r.positions[instanceID] = latest
+	}
+	return r.positions[instanceID] >= position
+}
+
+// queryPosition returns the lowest position of the projections of the instance
+func (r *replica) queryPosition(ctx context.Context, instanceID string) (float64, error) {
+	rows, err := r.QueryContext(ctx, replicaPositionsStmt, instanceID)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+	var (
+		lowest float64
+		found  bool
+	)
+	for rows.Next() {
+		var position float64
+		if err = rows.Scan(&position); err != nil {
+			return 0, err
+		}
+		if !found || position < lowest {
+			lowest, found = position, true
+		}
+	}
+	return lowest, rows.Err()
+}
+
+func (r *replica) knownPosition(instanceID string) float64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.positions[instanceID]
+}
+
+type replicas struct {
+	clients []*replica
+	next    atomic.Uint32
+}
+
+// ConnectReplicas returns a client which routes the read only queries of [DB.QueryContext] and [DB.QueryRowContext]
+// to the replicas of the config.
+// The writes and transactions of the client are executed on primary.
+// If no replicas are configured primary is returned.
+func ConnectReplicas(config Config, primary *DB) (*DB, error) {
+	clients, err := config.connector.ConnectReplicas(zitadelAppName)
+	if err != nil {
+		return nil, err
+	}
+	if len(clients) == 0 {
+		return primary, nil
+	}
+	db := &DB{
+		DB:       primary.DB,
+		Database: primary.Database,
+		replicas: &replicas{clients: make([]*replica, len(clients))},
+	}
+	for i, client := range clients {
+		if err = client.Ping(); err != nil {
+			return nil, zerrors.ThrowPreconditionFailed(err, "DATAB-ieR4i", "Errors.Database.Connection.Failed")
+		}
+		db.replicas.clients[i] = &replica{DB: client}
+	}
+	return db, nil
+}
+
+// reader returns the connection pool for read only queries.
+// A replica is only used if it contains the events written in the context of the call.
+func (db *DB) reader(ctx context.Context) *sql.DB {
+	if db.replicas == nil {
+		return db.DB
+	}
+	instanceID, position, primaryRequired := consistency(ctx)
+	if primaryRequired {
+		return db.DB
+	}
+	replica := db.replicas.clients[int(db.replicas.next.Add(1))%len(db.replicas.clients)]
+	if position > 0 && !replica.caughtUp(ctx, instanceID, position) {
+		return db.DB
+	}
+	return replica.DB
+}
+
+type consistencyKey struct{}
+
+// consistencyState holds the writes of a call, its pointer is stored in the context
+// so the writes of the callees are visible to the caller
+type consistencyState struct {
+	mu              sync.Mutex
+	instanceID      string
+	position        float64
+	primaryRequired bool
+}
+
+// WithReadYourWrites prepares the context to carry the writes of the call,
+// so that subsequent queries of the call never read from a replica which is behind the writes.
+// Calls without prepared context read from any replica.
+func WithReadYourWrites(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(consistencyKey{}).(*consistencyState); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, consistencyKey{}, new(consistencyState))
+}
+
+// SetWrittenPosition remembers the position of events pushed to the instance,
+// subsequent queries of the call are only routed to replicas whose projections of the instance contain the position.
+// Calls writing to multiple instances are routed to primary.
+func SetWrittenPosition(ctx context.Context, instanceID string, position float64) {
+	state, ok := ctx.Value(consistencyKey{}).(*consistencyState)
+	if !ok {
+		return
+	}
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	if state.instanceID != "" && state.instanceID != instanceID {
+		state.primaryRequired = true
+	}
+	state.instanceID = instanceID
+	if position > state.position {
+		state.position = position
+	}
+}
+
+// RequirePrimary routes the subsequent queries of the call to primary.
+// It's used if data is written without an event position, e.g. by triggered projections.
+func RequirePrimary(ctx context.Context) {
+	state, ok := ctx.Value(consistencyKey{}).(*consistencyState)
+	if !ok {
+		return
+	}
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	state.primaryRequired = true
+}
+
+func consistency(ctx context.Context) (instanceID string, position float64, primaryRequired bool) {
+	state, ok := ctx.Value(consistencyKey{}).(*consistencyState)
+	if !ok {
+		return "", 0, false
+	}
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	return state.instanceID, state.position, state.primaryRequired
+}
@@ -0,0 +1,174 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/database/mock"
+)
+
+func TestSetWrittenPosition(t *testing.T) {
+	t.Run("not prepared", func(t *testing.T) {
+		ctx := context.Background()
+		SetWrittenPosition(ctx, "instance", 42)
+		RequirePrimary(ctx)
+
+		instanceID, position, primaryRequired := consistency(ctx)
+		assert.Empty(t, instanceID)
+		assert.Zero(t, position)
+		assert.False(t, primaryRequired)
+	})
+	t.Run("highest position", func(t *testing.T) {
+		ctx := WithReadYourWrites(context.Background())
+		SetWrittenPosition(ctx, "instance", 42)
+		SetWrittenPosition(WithReadYourWrites(ctx), "instance", 41)
+
+		instanceID, position, primaryRequired := consistency(ctx)
+		assert.Equal(t, "instance", instanceID)
+		assert.Equal(t, float64(42), position)
+		assert.False(t, primaryRequired)
+	})
+	t.Run("multiple instances", func(t *testing.T) {
+		ctx := WithReadYourWrites(context.Background())
+		SetWrittenPosition(ctx, "instance", 42)
+		SetWrittenPosition(ctx, "instance2", 43)
+
+		_, _, primaryRequired := consistency(ctx)
+		assert.True(t, primaryRequired)
+	})
+	t.Run("primary required", func(t *testing.T) {
+		ctx := WithReadYourWrites(context.Background())
+		RequirePrimary(ctx)
+
+		_, _, primaryRequired := consistency(ctx)
+		assert.True(t, primaryRequired)
+	})
+}
+
+func TestDB_reader(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctx      func() context.Context
+		replica  *mock.SQLMock
+		position float64
+		// otherInstance sets the known position for another instance
+		otherInstance bool
+		wantReplica   bool
+	}{
+		{
+			name:        "no writes",
+			ctx:         context.Background,
+			replica:     mock.NewSQLMock(t),
+			wantReplica: true,
+		},
+		{
+			name: "primary required",
+			ctx: func() context.Context {
+				ctx := WithReadYourWrites(context.Background())
+				RequirePrimary(ctx)
+				return ctx
+			},
+			replica: mock.NewSQLMock(t),
+		},
+		{
+			name: "replica knows position",
+			ctx: func() context.Context {
+				ctx := WithReadYourWrites(context.Background())
+				SetWrittenPosition(ctx, "instance", 42)
+				return ctx
+			},
+			replica:     mock.NewSQLMock(t),
+			position:    42,
+			wantReplica: true,
+		},
+		{
+			name: "replica caught up",
+			ctx: func() context.Context {
+				ctx := WithReadYourWrites(context.Background())
+				SetWrittenPosition(ctx, "instance", 42)
+				return ctx
+			},
+			replica: mock.NewSQLMock(t,
+				mock.ExpectQuery(replicaPositionsStmt,
+					mock.WithQueryArgs("instance"),
+					mock.WithQueryResult([]string{"position"}, [][]driver.Value{{float64(43)}, {float64(42)}}),
+				),
+			),
+			position:    41,
+			wantReplica: true,
+		},
+		{
+			name: "replica behind",
+			ctx: func() context.Context {
+				ctx := WithReadYourWrites(context.Background())
+				SetWrittenPosition(ctx, "instance", 42)
+				return ctx
+			},
+			replica: mock.NewSQLMock(t,
+				mock.ExpectQuery(replicaPositionsStmt,
+					mock.WithQueryArgs("instance"),
+					mock.WithQueryResult([]string{"position"}, [][]driver.Value{{float64(41)}}),
+				),
+			),
+			position: 40,
+		},
+		{
+			name: "projection of replica behind",
+			ctx: func() context.Context {
+				ctx := WithReadYourWrites(context.Background())
+				SetWrittenPosition(ctx, "instance", 42)
+				return ctx
+			},
+			replica: mock.NewSQLMock(t,
+				mock.ExpectQuery(replicaPositionsStmt,
+					mock.WithQueryArgs("instance"),
+					mock.WithQueryResult([]string{"position"}, [][]driver.Value{{float64(50)}, {float64(41)}}),
+				),
+			),
+		},
+		{
+			name: "other instance known",
+			ctx: func() context.Context {
+				ctx := WithReadYourWrites(context.Background())
+				SetWrittenPosition(ctx, "instance", 42)
+				return ctx
+			},
+			replica: mock.NewSQLMock(t,
+				mock.ExpectQuery(replicaPositionsStmt,
+					mock.WithQueryArgs("instance"),
+					mock.WithQueryResult([]string{"position"}, [][]driver.Value{}),
+				),
+			),
+			position:      50,
+			otherInstance: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			primary := new(sql.DB)
+			instanceID := "instance"
+			if tt.otherInstance {
+				instanceID = "instance2"
+			}
+			db := &DB{
+				DB: primary,
+				replicas: &replicas{
+					clients: []*replica{{DB: tt.replica.DB, positions: map[string]float64{instanceID: tt.position}}},
+				},
+			}
+
+			got := db.reader(tt.ctx())
+			if tt.wantReplica {
+				assert.Same(t, tt.replica.DB, got)
+			} else {
+				assert.Same(t, primary, got)
+			}
+
+			tt.replica.Assert(t)
+		})
+	}
+}
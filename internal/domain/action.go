@@ -14,10 +14,30 @@ type Action struct {
 	Timeout       time.Duration
 	AllowedToFail bool
 	State         ActionState
+	// CanaryPercentage stages the changed script as canary,
+	// it's executed for the percentage of the executions until it's activated or removed
+	CanaryPercentage uint32
 }
 
 func (a *Action) IsValid() bool {
-	return a.Name != ""
+	return a.Name != "" && a.CanaryPercentage <= 100
+}
+
+// ActionVersion is an immutable version of the script of an action.
+// The version is the sequence of the event which created it.
+type ActionVersion struct {
+	Version       uint64
+	CreationDate  time.Time
+	Script        string
+	Timeout       time.Duration
+	AllowedToFail bool
+}
+
+// ActionCanary is a version of an action which is executed
+// for a percentage of the executions instead of the current version
+type ActionCanary struct {
+	Version    uint64
+	Percentage uint32
 }
 
 type ActionState int32
@@ -108,6 +108,7 @@ const (
 	MFATypeU2FUserVerification
 	MFATypeOTPSMS
 	MFATypeOTPEmail
+	MFATypeRecoveryCode
 )
 
 type MFALevel int
@@ -17,6 +17,8 @@ const (
 	DomainClaimedMessageType            = "DomainClaimed"
 	PasswordlessRegistrationMessageType = "PasswordlessRegistration"
 	PasswordChangeMessageType           = "PasswordChange"
+	RecoveryCodeUsedMessageType         = "RecoveryCodeUsed"
+	PasswordExpiryWarningMessageType    = "PasswordExpiryWarning"
 	MessageTitle                        = "Title"
 	MessagePreHeader                    = "PreHeader"
 	MessageSubject                      = "Subject"
@@ -71,5 +73,7 @@ func IsMessageTextType(textType string) bool {
 		textType == VerifyEmailOTPMessageType ||
 		textType == DomainClaimedMessageType ||
 		textType == PasswordlessRegistrationMessageType ||
-		textType == PasswordChangeMessageType
+		textType == PasswordChangeMessageType ||
+		textType == RecoveryCodeUsedMessageType ||
+		textType == PasswordExpiryWarningMessageType
 }
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+type EventSinkType int32
+
+const (
+	EventSinkTypeUnspecified EventSinkType = iota
+	// EventSinkTypeHTTP posts the events to a webhook signed by HMAC
+	EventSinkTypeHTTP
+	// EventSinkTypeNATS publishes the events on a NATS subject
+	EventSinkTypeNATS
+	// EventSinkTypeKafka produces the events to a topic of a Kafka REST proxy
+	EventSinkTypeKafka
+
+	eventSinkTypeCount
+)
+
+func (t EventSinkType) Valid() bool {
+	return t > EventSinkTypeUnspecified && t < eventSinkTypeCount
+}
+
+type EventSinkState int32
+
+const (
+	EventSinkStateUnspecified EventSinkState = iota
+	EventSinkStateActive
+	EventSinkStateRemoved
+)
+
+func (s EventSinkState) Exists() bool {
+	return s == EventSinkStateActive
+}
+
+var kafkaTopicRegex = regexp.MustCompile(`^[a-zA-Z0-9._-]{1,249}$`)
+
+// ValidNATSSubject checks if the subject can be published to,
+// it consists of non-empty tokens separated by dots
+// and must not contain wildcards, whitespace or control characters
+func ValidNATSSubject(subject string) bool {
+	if subject == "" {
+		return false
+	}
+	for _, token := range strings.Split(subject, ".") {
+		if token == "" || token == "*" || token == ">" {
+			return false
+		}
+		for _, r := range token {
+			if unicode.IsSpace(r) || unicode.IsControl(r) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// ValidKafkaTopic checks if the name is a legal Kafka topic
+func ValidKafkaTopic(topic string) bool {
+	return topic != "." && topic != ".." && kafkaTopicRegex.MatchString(topic)
+}
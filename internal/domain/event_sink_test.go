@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidNATSSubject(t *testing.T) {
+	tests := []struct {
+		subject string
+		want    bool
+	}{
+		{"zitadel.events", true},
+		{"zitadel", true},
+		{"", false},
+		{"zitadel..events", false},
+		{"zitadel.", false},
+		{"zitadel.*", false},
+		{"zitadel.>", false},
+		{"zitadel events", false},
+		{"zitadel.events\r\nPUB other 0", false},
+		{"zitadel\tevents", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.subject, func(t *testing.T) {
+			assert.Equal(t, tt.want, ValidNATSSubject(tt.subject))
+		})
+	}
+}
+
+func TestValidKafkaTopic(t *testing.T) {
+	tests := []struct {
+		topic string
+		want  bool
+	}{
+		{"zitadel-events", true},
+		{"zitadel.events_1", true},
+		{"", false},
+		{".", false},
+		{"..", false},
+		{"zitadel/events", false},
+		{"zitadel events", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.topic, func(t *testing.T) {
+			assert.Equal(t, tt.want, ValidKafkaTopic(tt.topic))
+		})
+	}
+}
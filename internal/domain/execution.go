@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"strings"
+)
+
+type ExecutionType int32
+
+const (
+	ExecutionTypeUnspecified ExecutionType = iota
+	// ExecutionTypeRequest calls the targets before the API method is handled
+	ExecutionTypeRequest
+	// ExecutionTypeResponse calls the targets after the API method was handled successfully
+	ExecutionTypeResponse
+	// ExecutionTypeEvent calls the targets after the event was pushed
+	ExecutionTypeEvent
+
+	executionTypeCount
+)
+
+func (t ExecutionType) Valid() bool {
+	return t > ExecutionTypeUnspecified && t < executionTypeCount
+}
+
+func (t ExecutionType) String() string {
+	switch t {
+	case ExecutionTypeRequest:
+		return "request"
+	case ExecutionTypeResponse:
+		return "response"
+	case ExecutionTypeEvent:
+		return "event"
+	default:
+		return ""
+	}
+}
+
+// ExecutionID returns the id of the execution of the condition.
+// The condition of request and response executions is either a full method name (e.g. /zitadel.user.v2beta.UserService/AddHumanUser),
+// a service name (e.g. zitadel.user.v2beta.UserService) or empty for all methods.
+// The condition of event executions is either an event type or empty for all events.
+func ExecutionID(executionType ExecutionType, condition string) string {
+	condition = strings.TrimPrefix(condition, "/")
+	if condition == "" {
+		return executionType.String()
+	}
+	return executionType.String() + "/" + condition
+}
+
+// ExecutionTypeAndCondition returns the execution type and the condition of the id of an execution
+func ExecutionTypeAndCondition(executionID string) (ExecutionType, string) {
+	typ, condition, _ := strings.Cut(executionID, "/")
+	for executionType := ExecutionTypeRequest; executionType < executionTypeCount; executionType++ {
+		if executionType.String() != typ {
+			continue
+		}
+		if executionType != ExecutionTypeEvent && strings.Contains(condition, "/") {
+			// full method names start with a slash
+			condition = "/" + condition
+		}
+		return executionType, condition
+	}
+	return ExecutionTypeUnspecified, ""
+}
+
+// ExecutionIDsOfMethod returns the ids of all executions matching the full method name,
+// ordered from all methods to the method itself
+func ExecutionIDsOfMethod(executionType ExecutionType, fullMethod string) []string {
+	ids := []string{ExecutionID(executionType, "")}
+	service, _, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if !ok {
+		return ids
+	}
+	return append(ids,
+		ExecutionID(executionType, service),
+		ExecutionID(executionType, fullMethod),
+	)
+}
+
+// ExecutionIDsOfEvent returns the ids of all executions matching the event type,
+// ordered from all events to the event type itself
+func ExecutionIDsOfEvent(eventType string) []string {
+	return []string{
+		ExecutionID(ExecutionTypeEvent, ""),
+		ExecutionID(ExecutionTypeEvent, eventType),
+	}
+}
+
+// ValidExecutionCondition checks the format of the condition of the execution type
+func ValidExecutionCondition(executionType ExecutionType, condition string) bool {
+	if !executionType.Valid() {
+		return false
+	}
+	if executionType == ExecutionTypeEvent || condition == "" {
+		return true
+	}
+	parts := strings.Split(condition, "/")
+	switch len(parts) {
+	case 1:
+		// service
+		return true
+	case 3:
+		// full method
+		return parts[0] == "" && parts[1] != "" && parts[2] != ""
+	default:
+		return false
+	}
+}
+
+type ExecutionState int32
+
+const (
+	ExecutionStateUnspecified ExecutionState = iota
+	ExecutionStateActive
+	ExecutionStateRemoved
+)
+
+func (s ExecutionState) Exists() bool {
+	return s == ExecutionStateActive
+}
+
+type TargetState int32
+
+const (
+	TargetStateUnspecified TargetState = iota
+	TargetStateActive
+	TargetStateRemoved
+)
+
+func (s TargetState) Exists() bool {
+	return s == TargetStateActive
+}
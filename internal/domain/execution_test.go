@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecutionIDsOfMethod(t *testing.T) {
+	assert.Equal(t, []string{
+		"request",
+		"request/zitadel.user.v2beta.UserService",
+		"request/zitadel.user.v2beta.UserService/AddHumanUser",
+	}, ExecutionIDsOfMethod(ExecutionTypeRequest, "/zitadel.user.v2beta.UserService/AddHumanUser"))
+	assert.Equal(t, []string{"response"}, ExecutionIDsOfMethod(ExecutionTypeResponse, "invalid"))
+}
+
+func TestExecutionTypeAndCondition(t *testing.T) {
+	tests := []struct {
+		executionType ExecutionType
+		condition     string
+	}{
+		{ExecutionTypeRequest, ""},
+		{ExecutionTypeRequest, "zitadel.user.v2beta.UserService"},
+		{ExecutionTypeResponse, "/zitadel.user.v2beta.UserService/AddHumanUser"},
+		{ExecutionTypeEvent, "user.human.added"},
+	}
+	for _, tt := range tests {
+		t.Run(ExecutionID(tt.executionType, tt.condition), func(t *testing.T) {
+			executionType, condition := ExecutionTypeAndCondition(ExecutionID(tt.executionType, tt.condition))
+			assert.Equal(t, tt.executionType, executionType)
+			assert.Equal(t, tt.condition, condition)
+		})
+	}
+}
+
+func TestValidExecutionCondition(t *testing.T) {
+	tests := []struct {
+		name          string
+		executionType ExecutionType
+		condition     string
+		want          bool
+	}{
+		{"unspecified type", ExecutionTypeUnspecified, "", false},
+		{"all methods", ExecutionTypeRequest, "", true},
+		{"service", ExecutionTypeRequest, "zitadel.user.v2beta.UserService", true},
+		{"method", ExecutionTypeResponse, "/zitadel.user.v2beta.UserService/AddHumanUser", true},
+		{"method without slash", ExecutionTypeResponse, "zitadel.user.v2beta.UserService/AddHumanUser", false},
+		{"method without name", ExecutionTypeResponse, "/zitadel.user.v2beta.UserService/", false},
+		{"event", ExecutionTypeEvent, "user.human.added", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ValidExecutionCondition(tt.executionType, tt.condition))
+		})
+	}
+}
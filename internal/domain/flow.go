@@ -22,6 +22,8 @@ const (
 	FlowTypeCustomiseToken
 	FlowTypeInternalAuthentication
 	FlowTypeCustomizeSAMLResponse
+	FlowTypePasswordReset
+	FlowTypeLogout
 	flowTypeCount
 )
 
@@ -45,22 +47,34 @@ func (s FlowType) TriggerTypes() []TriggerType {
 			TriggerTypePostAuthentication,
 			TriggerTypePreCreation,
 			TriggerTypePostCreation,
+			TriggerTypePreRegistrationValidation,
 		}
 	case FlowTypeCustomiseToken:
 		return []TriggerType{
 			TriggerTypePreUserinfoCreation,
 			TriggerTypePreAccessTokenCreation,
+			TriggerTypePreRefreshTokenCreation,
 		}
 	case FlowTypeInternalAuthentication:
 		return []TriggerType{
 			TriggerTypePostAuthentication,
 			TriggerTypePreCreation,
 			TriggerTypePostCreation,
+			TriggerTypePreRegistrationValidation,
 		}
 	case FlowTypeCustomizeSAMLResponse:
 		return []TriggerType{
 			TriggerTypePreSAMLResponseCreation,
 		}
+	case FlowTypePasswordReset:
+		return []TriggerType{
+			TriggerTypePrePasswordReset,
+			TriggerTypePostPasswordReset,
+		}
+	case FlowTypeLogout:
+		return []TriggerType{
+			TriggerTypePostLogout,
+		}
 	default:
 		return nil
 	}
@@ -87,6 +101,10 @@ func (s FlowType) LocalizationKey() string {
 		return "Action.Flow.Type.InternalAuthentication"
 	case FlowTypeCustomizeSAMLResponse:
 		return "Action.Flow.Type.CustomizeSAMLResponse"
+	case FlowTypePasswordReset:
+		return "Action.Flow.Type.PasswordReset"
+	case FlowTypeLogout:
+		return "Action.Flow.Type.Logout"
 	default:
 		return "Action.Flow.Type.Unspecified"
 	}
@@ -102,6 +120,11 @@ const (
 	TriggerTypePreUserinfoCreation
 	TriggerTypePreAccessTokenCreation
 	TriggerTypePreSAMLResponseCreation
+	TriggerTypePreRegistrationValidation
+	TriggerTypePrePasswordReset
+	TriggerTypePostPasswordReset
+	TriggerTypePostLogout
+	TriggerTypePreRefreshTokenCreation
 	triggerTypeCount
 )
 
@@ -134,6 +157,16 @@ func (s TriggerType) LocalizationKey() string {
 		return "Action.TriggerType.PreAccessTokenCreation"
 	case TriggerTypePreSAMLResponseCreation:
 		return "Action.TriggerType.PreSAMLResponseCreation"
+	case TriggerTypePreRegistrationValidation:
+		return "Action.TriggerType.PreRegistrationValidation"
+	case TriggerTypePrePasswordReset:
+		return "Action.TriggerType.PrePasswordReset"
+	case TriggerTypePostPasswordReset:
+		return "Action.TriggerType.PostPasswordReset"
+	case TriggerTypePostLogout:
+		return "Action.TriggerType.PostLogout"
+	case TriggerTypePreRefreshTokenCreation:
+		return "Action.TriggerType.PreRefreshTokenCreation"
 	default:
 		return "Action.TriggerType.Unspecified"
 	}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/zitadel/passwap"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+// recoveryCodeRunes omits characters which are easily confused when read from a print out (0/O, 1/I/L)
+var recoveryCodeRunes = []rune("ABCDEFGHJKMNPQRSTUVWXYZ23456789")
+
+const recoveryCodeSeparator = "-"
+
+type RecoveryCodesConfig struct {
+	// Count is the amount of codes generated per batch
+	Count uint
+	// Length is the amount of characters of a single code (without separator)
+	Length uint
+}
+
+type RecoveryCodes struct {
+	*ObjectDetails
+
+	Codes []string
+}
+
+// GenerateRecoveryCodes creates a batch of plain recovery codes and their hashes.
+// The plain codes are formatted in two groups (e.g. ABCDE-FGHJK) for better readability,
+// the hashes are calculated over the normalized code (see [NormalizeRecoveryCode]).
+func GenerateRecoveryCodes(config RecoveryCodesConfig, hasher *crypto.PasswordHasher) (plain, hashed []string, err error) {
+	if config.Count == 0 || config.Length == 0 {
+		return nil, nil, caos_errs.ThrowInternal(nil, "DOMAIN-Rk3fa", "Errors.User.MFA.RecoveryCodes.InvalidConfig")
+	}
+	plain = make([]string, config.Count)
+	hashed = make([]string, config.Count)
+	for i := range plain {
+		code, err := crypto.GenerateRandomString(config.Length, recoveryCodeRunes)
+		if err != nil {
+			return nil, nil, caos_errs.ThrowInternal(err, "DOMAIN-Hs92k", "Errors.Internal")
+		}
+		hashed[i], err = hasher.Hash(code)
+		if err != nil {
+			return nil, nil, caos_errs.ThrowInternal(err, "DOMAIN-Pq8sd", "Errors.Internal")
+		}
+		plain[i] = formatRecoveryCode(code)
+	}
+	return plain, hashed, nil
+}
+
+func formatRecoveryCode(code string) string {
+	half := len(code) / 2
+	if half == 0 {
+		return code
+	}
+	return code[:half] + recoveryCodeSeparator + code[half:]
+}
+
+// NormalizeRecoveryCode removes separators and whitespace and converts the code to upper case,
+// so users can enter it the way they like.
+func NormalizeRecoveryCode(code string) string {
+	code = strings.ToUpper(code)
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '\t' || r == rune(recoveryCodeSeparator[0]) {
+			return -1
+		}
+		return r
+	}, code)
+}
+
+// VerifyRecoveryCode checks the code against the passed hashes and returns the index of the matching one.
+// Hashes which are already used must be passed as empty strings, so that the indexes stay stable.
+func VerifyRecoveryCode(code string, hashes []string, hasher *crypto.PasswordHasher) (int, error) {
+	code = NormalizeRecoveryCode(code)
+	if code == "" {
+		return -1, caos_errs.ThrowInvalidArgument(nil, "DOMAIN-Gd31s", "Errors.User.Code.Empty")
+	}
+	for i, hash := range hashes {
+		if hash == "" {
+			continue
+		}
+		_, err := hasher.Verify(hash, code)
+		if err == nil {
+			return i, nil
+		}
+		if !errors.Is(err, passwap.ErrPasswordMismatch) {
+			return -1, caos_errs.ThrowInternal(err, "DOMAIN-Jw3ns", "Errors.Internal")
+		}
+	}
+	return -1, caos_errs.ThrowInvalidArgument(nil, "DOMAIN-Lfe2a", "Errors.User.MFA.RecoveryCodes.InvalidCode")
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeRecoveryCode(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		want string
+	}{
+		{
+			name: "empty",
+			code: "",
+			want: "",
+		},
+		{
+			name: "formatted",
+			code: "ABCDE-FGHJK",
+			want: "ABCDEFGHJK",
+		},
+		{
+			name: "lower case with whitespace",
+			code: " abcde fghjk\t",
+			want: "ABCDEFGHJK",
+		},
+		{
+			name: "multiple separators",
+			code: "ab-cd-ef-gh-jk",
+			want: "ABCDEFGHJK",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NormalizeRecoveryCode(tt.code))
+		})
+	}
+}
+
+func TestFormatRecoveryCode(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		want string
+	}{
+		{
+			name: "single character",
+			code: "A",
+			want: "A",
+		},
+		{
+			name: "even length",
+			code: "ABCDEFGHJK",
+			want: "ABCDE-FGHJK",
+		},
+		{
+			name: "odd length",
+			code: "ABCDEFGHJ",
+			want: "ABCD-EFGHJ",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := formatRecoveryCode(tt.code)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.code, NormalizeRecoveryCode(got))
+		})
+	}
+}
@@ -18,7 +18,8 @@ func (f MFAState) Valid() bool {
 }
 
 type MultifactorConfigs struct {
-	OTP OTPConfig
+	OTP           OTPConfig
+	RecoveryCodes RecoveryCodesConfig
 }
 
 type OTPConfig struct {
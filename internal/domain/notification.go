@@ -35,3 +35,31 @@ const (
 
 	notificationProviderTypeCount
 )
+
+// NotificationMessageState is the delivery state of a message queued in the notification outbox
+type NotificationMessageState int32
+
+const (
+	NotificationMessageStateUnspecified NotificationMessageState = iota
+	// NotificationMessageStateQueued messages wait for their (next) delivery attempt
+	NotificationMessageStateQueued
+	// NotificationMessageStateSending messages are currently handed over to a provider
+	NotificationMessageStateSending
+	NotificationMessageStateSent
+	// NotificationMessageStateBounced messages were rejected by the provider, e.g. because of an unknown recipient
+	NotificationMessageStateBounced
+	// NotificationMessageStateFailed messages could not be delivered within the maximum attempts
+	NotificationMessageStateFailed
+)
+
+// Exists returns true if the message was queued
+func (s NotificationMessageState) Exists() bool {
+	return s > NotificationMessageStateUnspecified
+}
+
+// Done returns true if no further delivery is attempted
+func (s NotificationMessageState) Done() bool {
+	return s == NotificationMessageStateSent ||
+		s == NotificationMessageStateBounced ||
+		s == NotificationMessageStateFailed
+}
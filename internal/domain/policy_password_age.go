@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"time"
+
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
 )
 
@@ -10,3 +12,32 @@ type PasswordAgePolicy struct {
 	MaxAgeDays     uint64
 	ExpireWarnDays uint64
 }
+
+// PasswordExpiryDate returns the point in time when a password set at changed expires.
+// A zero time is returned if the policy does not limit the age of passwords
+// or the change date of the password is unknown.
+func (p *PasswordAgePolicy) PasswordExpiryDate(changed time.Time) time.Time {
+	if p == nil || p.MaxAgeDays == 0 || changed.IsZero() {
+		return time.Time{}
+	}
+	return changed.Add(time.Duration(p.MaxAgeDays) * 24 * time.Hour)
+}
+
+// IsPasswordExpired checks if a password set at changed is expired at now.
+func (p *PasswordAgePolicy) IsPasswordExpired(changed, now time.Time) bool {
+	expiry := p.PasswordExpiryDate(changed)
+	return !expiry.IsZero() && !now.Before(expiry)
+}
+
+// IsPasswordExpiryWarning checks if a password set at changed is not yet expired at now,
+// but will expire within the warning period of the policy.
+func (p *PasswordAgePolicy) IsPasswordExpiryWarning(changed, now time.Time) bool {
+	if p == nil || p.ExpireWarnDays == 0 {
+		return false
+	}
+	expiry := p.PasswordExpiryDate(changed)
+	if expiry.IsZero() || !now.Before(expiry) {
+		return false
+	}
+	return !now.Before(expiry.Add(-time.Duration(p.ExpireWarnDays) * 24 * time.Hour))
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordAgePolicy_IsPasswordExpired(t *testing.T) {
+	changed := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	type args struct {
+		policy  *PasswordAgePolicy
+		changed time.Time
+		now     time.Time
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantExpired bool
+		wantWarning bool
+	}{
+		{
+			"no policy, false",
+			args{
+				policy:  nil,
+				changed: changed,
+				now:     changed.AddDate(1, 0, 0),
+			},
+			false,
+			false,
+		},
+		{
+			"no max age, false",
+			args{
+				policy:  &PasswordAgePolicy{ExpireWarnDays: 10},
+				changed: changed,
+				now:     changed.AddDate(1, 0, 0),
+			},
+			false,
+			false,
+		},
+		{
+			"unknown change date, false",
+			args{
+				policy: &PasswordAgePolicy{MaxAgeDays: 30, ExpireWarnDays: 10},
+				now:    changed,
+			},
+			false,
+			false,
+		},
+		{
+			"before warning period, false",
+			args{
+				policy:  &PasswordAgePolicy{MaxAgeDays: 30, ExpireWarnDays: 10},
+				changed: changed,
+				now:     changed.AddDate(0, 0, 19),
+			},
+			false,
+			false,
+		},
+		{
+			"within warning period, warning",
+			args{
+				policy:  &PasswordAgePolicy{MaxAgeDays: 30, ExpireWarnDays: 10},
+				changed: changed,
+				now:     changed.AddDate(0, 0, 20),
+			},
+			false,
+			true,
+		},
+		{
+			"max age reached, expired",
+			args{
+				policy:  &PasswordAgePolicy{MaxAgeDays: 30, ExpireWarnDays: 10},
+				changed: changed,
+				now:     changed.AddDate(0, 0, 30),
+			},
+			true,
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantExpired, tt.args.policy.IsPasswordExpired(tt.args.changed, tt.args.now))
+			assert.Equal(t, tt.wantWarning, tt.args.policy.IsPasswordExpiryWarning(tt.args.changed, tt.args.now))
+		})
+	}
+}
@@ -14,14 +14,20 @@ var (
 	hasSymbol          = regexp.MustCompile(`[^A-Za-z0-9]`).MatchString
 )
 
+// MaxPasswordHistoryDepth limits the amount of previously used passwords checked on a password change,
+// as every one of them has to be compared with the new password.
+const MaxPasswordHistoryDepth = 20
+
 type PasswordComplexityPolicy struct {
 	models.ObjectRoot
 
-	MinLength    uint64
-	HasLowercase bool
-	HasUppercase bool
-	HasNumber    bool
-	HasSymbol    bool
+	MinLength     uint64
+	HasLowercase  bool
+	HasUppercase  bool
+	HasNumber     bool
+	HasSymbol     bool
+	HistoryDepth  uint64
+	CheckBreached bool
 
 	Default bool
 }
@@ -30,6 +36,9 @@ func (p *PasswordComplexityPolicy) IsValid() error {
 	if p.MinLength == 0 || p.MinLength > 72 {
 		return caos_errs.ThrowInvalidArgument(nil, "MODEL-Lsp0e", "Errors.User.PasswordComplexityPolicy.MinLengthNotAllowed")
 	}
+	if p.HistoryDepth > MaxPasswordHistoryDepth {
+		return caos_errs.ThrowInvalidArgument(nil, "MODEL-Ohs8e", "Errors.User.PasswordComplexityPolicy.HistoryDepthNotAllowed")
+	}
 	return nil
 }
 
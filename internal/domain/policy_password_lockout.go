@@ -1,13 +1,60 @@
 package domain
 
 import (
+	"time"
+
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
 )
 
+// maxFailedAttemptDelay limits the exponential back-off between failed attempts
+const maxFailedAttemptDelay = time.Hour
+
 type LockoutPolicy struct {
 	models.ObjectRoot
 
 	Default             bool
 	MaxPasswordAttempts uint64
+	MaxOTPAttempts      uint64
 	ShowLockOutFailures bool
+	AutoUnlockAfter     time.Duration
+	FailedAttemptDelay  time.Duration
+}
+
+// PasswordAttemptsExceeded checks if a user has to be locked after failedAttempts consecutive failed password checks.
+func (p *LockoutPolicy) PasswordAttemptsExceeded(failedAttempts uint64) bool {
+	return p != nil && p.MaxPasswordAttempts > 0 && failedAttempts >= p.MaxPasswordAttempts
+}
+
+// OTPAttemptsExceeded checks if a user has to be locked after failedAttempts consecutive failed checks
+// of a single second factor (OTP, TOTP or U2F).
+func (p *LockoutPolicy) OTPAttemptsExceeded(failedAttempts uint64) bool {
+	return p != nil && p.MaxOTPAttempts > 0 && failedAttempts >= p.MaxOTPAttempts
+}
+
+// IsAutoUnlocked checks if the lock of a user locked at lockedAt is expired at now.
+func (p *LockoutPolicy) IsAutoUnlocked(lockedAt, now time.Time) bool {
+	return p != nil && p.AutoUnlockAfter > 0 && !now.Before(lockedAt.Add(p.AutoUnlockAfter))
+}
+
+// NextAttemptAllowedAt returns the earliest point in time a check is allowed
+// after failedAttempts consecutive failed checks, the last one at lastFailedAt.
+// The delay starts with FailedAttemptDelay and doubles with every further failed attempt, up to one hour.
+// A zero time is returned if the policy does not delay attempts.
+func (p *LockoutPolicy) NextAttemptAllowedAt(failedAttempts uint64, lastFailedAt time.Time) time.Time {
+	if p == nil || p.FailedAttemptDelay <= 0 || failedAttempts == 0 || lastFailedAt.IsZero() {
+		return time.Time{}
+	}
+	delay := p.FailedAttemptDelay
+	for i := uint64(1); i < failedAttempts && delay < maxFailedAttemptDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxFailedAttemptDelay {
+		delay = maxFailedAttemptDelay
+	}
+	return lastFailedAt.Add(delay)
+}
+
+// IsAttemptDelayed checks if a check at now has to be rejected because of the back-off after failed attempts.
+func (p *LockoutPolicy) IsAttemptDelayed(failedAttempts uint64, lastFailedAt, now time.Time) bool {
+	return now.Before(p.NextAttemptAllowedAt(failedAttempts, lastFailedAt))
 }
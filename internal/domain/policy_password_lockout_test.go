@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLockoutPolicy_IsAutoUnlocked(t *testing.T) {
+	lockedAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		policy *LockoutPolicy
+		now    time.Time
+		want   bool
+	}{
+		{
+			"no policy, false",
+			nil,
+			lockedAt.Add(24 * time.Hour),
+			false,
+		},
+		{
+			"no auto unlock, false",
+			&LockoutPolicy{MaxPasswordAttempts: 3},
+			lockedAt.Add(24 * time.Hour),
+			false,
+		},
+		{
+			"before unlock, false",
+			&LockoutPolicy{AutoUnlockAfter: time.Hour},
+			lockedAt.Add(59 * time.Minute),
+			false,
+		},
+		{
+			"unlocked, true",
+			&LockoutPolicy{AutoUnlockAfter: time.Hour},
+			lockedAt.Add(time.Hour),
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.policy.IsAutoUnlocked(lockedAt, tt.now))
+		})
+	}
+}
+
+func TestLockoutPolicy_NextAttemptAllowedAt(t *testing.T) {
+	lastFailedAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name           string
+		policy         *LockoutPolicy
+		failedAttempts uint64
+		want           time.Time
+	}{
+		{
+			"no policy, zero",
+			nil,
+			3,
+			time.Time{},
+		},
+		{
+			"no delay, zero",
+			&LockoutPolicy{MaxPasswordAttempts: 3},
+			3,
+			time.Time{},
+		},
+		{
+			"no failed attempts, zero",
+			&LockoutPolicy{FailedAttemptDelay: time.Second},
+			0,
+			time.Time{},
+		},
+		{
+			"first failed attempt, base delay",
+			&LockoutPolicy{FailedAttemptDelay: time.Second},
+			1,
+			lastFailedAt.Add(time.Second),
+		},
+		{
+			"fourth failed attempt, doubled delay",
+			&LockoutPolicy{FailedAttemptDelay: time.Second},
+			4,
+			lastFailedAt.Add(8 * time.Second),
+		},
+		{
+			"many failed attempts, limited delay",
+			&LockoutPolicy{FailedAttemptDelay: time.Second},
+			100,
+			lastFailedAt.Add(time.Hour),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.policy.NextAttemptAllowedAt(tt.failedAttempts, lastFailedAt))
+		})
+	}
+}
+
+func TestLockoutPolicy_AttemptsExceeded(t *testing.T) {
+	policy := &LockoutPolicy{MaxPasswordAttempts: 3, MaxOTPAttempts: 5}
+	assert.False(t, policy.PasswordAttemptsExceeded(2))
+	assert.True(t, policy.PasswordAttemptsExceeded(3))
+	assert.False(t, policy.OTPAttemptsExceeded(4))
+	assert.True(t, policy.OTPAttemptsExceeded(5))
+	assert.False(t, (&LockoutPolicy{}).OTPAttemptsExceeded(100))
+	assert.False(t, (*LockoutPolicy)(nil).PasswordAttemptsExceeded(100))
+}
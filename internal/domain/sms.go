@@ -12,3 +12,17 @@ const (
 func (s SMSConfigState) Exists() bool {
 	return s != SMSConfigStateUnspecified && s != SMSConfigStateRemoved
 }
+
+// SMSHTTPAuthType defines how the HTTP SMS provider authenticates against the endpoint
+type SMSHTTPAuthType int32
+
+const (
+	SMSHTTPAuthTypeNone SMSHTTPAuthType = iota
+	SMSHTTPAuthTypeBasic
+	SMSHTTPAuthTypeBearer
+	smsHTTPAuthTypeCount
+)
+
+func (t SMSHTTPAuthType) Valid() bool {
+	return t >= SMSHTTPAuthTypeNone && t < smsHTTPAuthTypeCount
+}
@@ -55,6 +55,7 @@ const (
 	UserAuthMethodTypeIDP
 	UserAuthMethodTypeOTPSMS
 	UserAuthMethodTypeOTPEmail
+	UserAuthMethodTypeRecoveryCode
 	userAuthMethodTypeCount
 )
 
@@ -76,6 +77,7 @@ func HasMFA(methods []UserAuthMethodType) bool {
 			UserAuthMethodTypeTOTP,
 			UserAuthMethodTypeOTPSMS,
 			UserAuthMethodTypeOTPEmail,
+			UserAuthMethodTypeRecoveryCode,
 			UserAuthMethodTypeIDP:
 			factors++
 		case UserAuthMethodTypeUnspecified,
@@ -0,0 +1,65 @@
+package eventsink
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+const (
+	cloudEventsSpecVersion = "1.0"
+	cloudEventsContentType = "application/cloudevents+json"
+)
+
+// CloudEvent is the structured representation of an event according to the CloudEvents specification 1.0.
+// The attributes of ZITADEL are added as extensions.
+type CloudEvent struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject"`
+	Time            time.Time       `json:"time"`
+	DataContentType string          `json:"datacontenttype,omitempty"`
+	Data            json.RawMessage `json:"data,omitempty"`
+
+	InstanceID    string  `json:"zitadelinstanceid"`
+	ResourceOwner string  `json:"zitadelresourceowner"`
+	AggregateType string  `json:"zitadelaggregatetype"`
+	AggregateID   string  `json:"zitadelaggregateid"`
+	Sequence      uint64  `json:"zitadelsequence"`
+	Position      float64 `json:"zitadelposition"`
+	Creator       string  `json:"zitadelcreator,omitempty"`
+}
+
+// newCloudEvent maps the event, the secrets of the payload are removed.
+// The id is unique per source, so consumers can deduplicate redelivered events.
+func newCloudEvent(event eventstore.Event) (*CloudEvent, error) {
+	data, err := query.StripPayloadSecrets(event.DataAsBytes())
+	if err != nil {
+		return nil, err
+	}
+	cloudEvent := &CloudEvent{
+		SpecVersion:   cloudEventsSpecVersion,
+		ID:            fmt.Sprintf("%s:%s:%d", event.Aggregate().Type, event.Aggregate().ID, event.Sequence()),
+		Source:        "/instances/" + event.Aggregate().InstanceID,
+		Type:          string(event.Type()),
+		Subject:       string(event.Aggregate().Type) + "/" + event.Aggregate().ID,
+		Time:          event.CreatedAt(),
+		InstanceID:    event.Aggregate().InstanceID,
+		ResourceOwner: event.Aggregate().ResourceOwner,
+		AggregateType: string(event.Aggregate().Type),
+		AggregateID:   event.Aggregate().ID,
+		Sequence:      event.Sequence(),
+		Position:      event.Position(),
+		Creator:       event.Creator(),
+	}
+	if len(data) > 0 {
+		cloudEvent.DataContentType = "application/json"
+		cloudEvent.Data = data
+	}
+	return cloudEvent, nil
+}
@@ -0,0 +1,12 @@
+package eventsink
+
+import (
+	"time"
+)
+
+type Config struct {
+	// Enabled starts the delivery of the events to the event sinks configured on the instances
+	Enabled bool
+	// Timeout of a single delivery to a sink
+	Timeout time.Duration
+}
@@ -0,0 +1,277 @@
+package eventsink
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/query/projection"
+)
+
+const (
+	DeliveryProjectionTable = "projections.event_sink_deliveries"
+	RetryProjectionTable    = "projections.event_sink_retries"
+
+	sinksCacheDuration = 5 * time.Second
+
+	BacklogInstanceIDCol    = "instance_id"
+	BacklogSinkIDCol        = "sink_id"
+	BacklogPositionCol      = "position"
+	BacklogAggregateTypeCol = "aggregate_type"
+	BacklogAggregateIDCol   = "aggregate_id"
+	BacklogSequenceCol      = "sequence"
+	BacklogPayloadCol       = "payload"
+	BacklogAttemptsCol      = "attempts"
+)
+
+type Queries interface {
+	EventSinks(ctx context.Context) (*query.EventSinks, error)
+}
+
+// Start delivers the events of all instances to their event sinks.
+// The handler stores its own position, deliveries failed on a sink are stored in the backlog of the sink
+// so the other sinks are not blocked.
+// The backlog is retried by a second handler in order,
+// the entries are skipped after the max failure count is reached.
+func Start(
+	ctx context.Context,
+	customConfig projection.CustomConfig,
+	config *Config,
+	es *eventstore.Eventstore,
+	queries Queries,
+	keyEncryption crypto.EncryptionAlgorithm,
+) {
+	if config == nil || !config.Enabled {
+		return
+	}
+	handlerCfg := projection.ApplyCustomConfig(customConfig)
+	delivery := newDelivery(config, es, queries, keyEncryption)
+	deliveryHandler := handler.NewHandler(ctx, &handlerCfg, delivery)
+	if err := deliveryHandler.Init(ctx); err != nil {
+		logging.WithError(err).Error("unable to initialize event sink backlog, events are not delivered")
+		return
+	}
+	deliveryHandler.Start(ctx)
+	newRetrier(ctx, handlerCfg, delivery).Start(ctx)
+}
+
+type delivery struct {
+	aggregateTypes []eventstore.AggregateType
+	queries        Queries
+	keyEncryption  crypto.EncryptionAlgorithm
+
+	http  *httpPublisher
+	nats  *natsPublisher
+	kafka *kafkaPublisher
+
+	now     func() time.Time
+	sinksMu sync.Mutex
+	sinks   map[string]*cachedSinks
+}
+
+type cachedSinks struct {
+	sinks     []*query.EventSink
+	expiresAt time.Time
+}
+
+func newDelivery(config *Config, es *eventstore.Eventstore, queries Queries, keyEncryption crypto.EncryptionAlgorithm) *delivery {
+	registered := es.AggregateTypes()
+	aggregateTypes := make([]eventstore.AggregateType, len(registered))
+	for i, typ := range registered {
+		aggregateTypes[i] = eventstore.AggregateType(typ)
+	}
+	client := &http.Client{
+		Timeout:   config.Timeout,
+		Transport: actions.DenyListTransport(),
+	}
+	return &delivery{
+		aggregateTypes: aggregateTypes,
+		queries:        queries,
+		keyEncryption:  keyEncryption,
+		http:           &httpPublisher{client: client, now: time.Now},
+		nats:           &natsPublisher{timeout: config.Timeout},
+		kafka:          &kafkaPublisher{client: client},
+		now:            time.Now,
+		sinks:          make(map[string]*cachedSinks),
+	}
+}
+
+func (*delivery) Name() string {
+	return DeliveryProjectionTable
+}
+
+func (d *delivery) Reducers() []handler.AggregateReducer {
+	reducers := make([]handler.AggregateReducer, len(d.aggregateTypes))
+	for i, aggregateType := range d.aggregateTypes {
+		reducers[i] = handler.AggregateReducer{
+			Aggregate: aggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  handler.AllEvents,
+					Reduce: d.reduce,
+				},
+			},
+		}
+	}
+	return reducers
+}
+
+func (*delivery) Init() *old_handler.Check {
+	return handler.NewTableCheck(
+		handler.NewTable([]*handler.InitColumn{
+			handler.NewColumn(BacklogInstanceIDCol, handler.ColumnTypeText),
+			handler.NewColumn(BacklogSinkIDCol, handler.ColumnTypeText),
+			handler.NewColumn(BacklogPositionCol, handler.ColumnTypeDecimal),
+			handler.NewColumn(BacklogAggregateTypeCol, handler.ColumnTypeText),
+			handler.NewColumn(BacklogAggregateIDCol, handler.ColumnTypeText),
+			handler.NewColumn(BacklogSequenceCol, handler.ColumnTypeInt64),
+			handler.NewColumn(BacklogPayloadCol, handler.ColumnTypeBytes),
+			handler.NewColumn(BacklogAttemptsCol, handler.ColumnTypeInt64),
+		},
+			handler.NewPrimaryKey(BacklogInstanceIDCol, BacklogSinkIDCol, BacklogAggregateTypeCol, BacklogAggregateIDCol, BacklogSequenceCol),
+		),
+	)
+}
+
+func (d *delivery) reduce(event eventstore.Event) (*handler.Statement, error) {
+	return handler.NewStatement(event, func(ex handler.Executer, _ string) error {
+		ctx := authz.WithInstanceID(context.Background(), event.Aggregate().InstanceID)
+		return d.deliver(ctx, ex, event)
+	}), nil
+}
+
+// deliver publishes the event to all subscribed sinks of the instance.
+// If a sink fails or already has a backlog, the event is appended to the backlog of the sink,
+// so the event is still delivered to the other sinks and the order of the events of a sink is kept.
+// Only errors of the backlog itself are returned, so the event is handled again.
+func (d *delivery) deliver(ctx context.Context, ex handler.Executer, event eventstore.Event) error {
+	sinks, err := d.instanceSinks(ctx, event.Aggregate().InstanceID)
+	if err != nil {
+		return err
+	}
+	var payload []byte
+	for _, sink := range sinks {
+		if !sink.Subscribed(event) {
+			continue
+		}
+		if payload == nil {
+			cloudEvent, err := newCloudEvent(event)
+			if err != nil {
+				return err
+			}
+			if payload, err = json.Marshal(cloudEvent); err != nil {
+				return err
+			}
+		}
+		backlogged, err := appendToBacklog(ex, sink.ID, event, payload)
+		if err != nil {
+			return err
+		}
+		if backlogged {
+			continue
+		}
+		if err = d.publish(ctx, sink, event.Aggregate().ID, payload); err != nil {
+			logging.WithFields("instance", event.Aggregate().InstanceID, "sink", sink.ID).WithError(err).Warn("delivery to event sink failed, retried from the backlog")
+			if err = addToBacklog(ex, sink.ID, event, payload); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+const (
+	backlogColumns = "(" + BacklogInstanceIDCol + ", " + BacklogSinkIDCol + ", " + BacklogPositionCol + ", " +
+		BacklogAggregateTypeCol + ", " + BacklogAggregateIDCol + ", " + BacklogSequenceCol + ", " +
+		BacklogPayloadCol + ", " + BacklogAttemptsCol + ")"
+	appendToBacklogStmt = "INSERT INTO " + DeliveryProjectionTable + " " + backlogColumns +
+		" SELECT $1::TEXT, $2::TEXT, $3::DECIMAL, $4::TEXT, $5::TEXT, $6::BIGINT, $7::BYTEA, 0" +
+		" WHERE EXISTS (SELECT 1 FROM " + DeliveryProjectionTable + " WHERE " + BacklogInstanceIDCol + " = $1 AND " + BacklogSinkIDCol + " = $2)" +
+		" ON CONFLICT DO NOTHING"
+	addToBacklogStmt = "INSERT INTO " + DeliveryProjectionTable + " " + backlogColumns +
+		" VALUES ($1, $2, $3, $4, $5, $6, $7, 1)" +
+		" ON CONFLICT DO NOTHING"
+)
+
+// appendToBacklog adds the event to the backlog of the sink if the backlog isn't empty
+func appendToBacklog(ex handler.Executer, sinkID string, event eventstore.Event, payload []byte) (bool, error) {
+	result, err := ex.Exec(appendToBacklogStmt, backlogArgs(sinkID, event, payload)...)
+	if err != nil {
+		return false, caos_errs.ThrowInternal(err, "SINK-ahX4e", "unable to append to backlog")
+	}
+	appended, err := result.RowsAffected()
+	if err != nil {
+		return false, caos_errs.ThrowInternal(err, "SINK-Ohc1a", "unable to append to backlog")
+	}
+	return appended > 0, nil
+}
+
+// addToBacklog adds the event which failed on its first attempt to the backlog of the sink
+func addToBacklog(ex handler.Executer, sinkID string, event eventstore.Event, payload []byte) error {
+	if _, err := ex.Exec(addToBacklogStmt, backlogArgs(sinkID, event, payload)...); err != nil {
+		return caos_errs.ThrowInternal(err, "SINK-Aeb6o", "unable to add to backlog")
+	}
+	return nil
+}
+
+func backlogArgs(sinkID string, event eventstore.Event, payload []byte) []any {
+	return []any{
+		event.Aggregate().InstanceID,
+		sinkID,
+		event.Position(),
+		string(event.Aggregate().Type),
+		event.Aggregate().ID,
+		event.Sequence(),
+		payload,
+	}
+}
+
+func (d *delivery) publish(ctx context.Context, sink *query.EventSink, aggregateID string, payload []byte) error {
+	switch sink.SinkType {
+	case domain.EventSinkTypeHTTP:
+		signingKey, err := crypto.Decrypt(sink.SigningKey, d.keyEncryption)
+		if err != nil {
+			return err
+		}
+		return d.http.publish(ctx, sink.Endpoint, signingKey, payload)
+	case domain.EventSinkTypeNATS:
+		return d.nats.publish(ctx, sink.Endpoint, sink.Target, payload)
+	case domain.EventSinkTypeKafka:
+		return d.kafka.publish(ctx, sink.Endpoint, sink.Target, aggregateID, payload)
+	default:
+		return caos_errs.ThrowInternalf(nil, "SINK-Xai0e", "unknown sink type %d", sink.SinkType)
+	}
+}
+
+// instanceSinks caches the sinks per instance for a short duration,
+// because they are needed for every event of the instance
+func (d *delivery) instanceSinks(ctx context.Context, instanceID string) ([]*query.EventSink, error) {
+	d.sinksMu.Lock()
+	defer d.sinksMu.Unlock()
+	cached, ok := d.sinks[instanceID]
+	if ok && cached.expiresAt.After(d.now()) {
+		return cached.sinks, nil
+	}
+	sinks, err := d.queries.EventSinks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	d.sinks[instanceID] = &cachedSinks{
+		sinks:     sinks.EventSinks,
+		expiresAt: d.now().Add(sinksCacheDuration),
+	}
+	return sinks.EventSinks, nil
+}
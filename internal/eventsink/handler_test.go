@@ -0,0 +1,192 @@
+package eventsink
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+type mockQueries struct {
+	sinks []*query.EventSink
+	calls int
+}
+
+func (m *mockQueries) EventSinks(context.Context) (*query.EventSinks, error) {
+	m.calls++
+	return &query.EventSinks{EventSinks: m.sinks}, nil
+}
+
+func testEvent(aggregateType eventstore.AggregateType, eventType eventstore.EventType, data string) eventstore.Event {
+	return &eventstore.BaseEvent{
+		Agg: &eventstore.Aggregate{
+			ID:            "agg1",
+			Type:          aggregateType,
+			ResourceOwner: "org1",
+			InstanceID:    "instance1",
+		},
+		EventType: eventType,
+		Seq:       3,
+		Creation:  time.Unix(1700000000, 0).UTC(),
+		Pos:       42.5,
+		Data:      []byte(data),
+	}
+}
+
+func Test_delivery_deliver(t *testing.T) {
+	var received []*CloudEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		cloudEvent := new(CloudEvent)
+		assert.NoError(t, json.Unmarshal(body, cloudEvent))
+		received = append(received, cloudEvent)
+	}))
+	defer server.Close()
+
+	queries := &mockQueries{
+		sinks: []*query.EventSink{
+			{
+				ID:             "sink1",
+				SinkType:       domain.EventSinkTypeHTTP,
+				Endpoint:       server.URL,
+				AggregateTypes: database.TextArray[string]{"user"},
+				SigningKey: &crypto.CryptoValue{
+					CryptoType: crypto.TypeEncryption,
+					Algorithm:  "enc",
+					KeyID:      "id",
+					Crypted:    []byte("key"),
+				},
+			},
+			{
+				ID:         "sink2",
+				SinkType:   domain.EventSinkTypeHTTP,
+				Endpoint:   server.URL,
+				EventTypes: database.TextArray[string]{"user.removed"},
+			},
+		},
+	}
+	now := time.Now()
+	d := &delivery{
+		queries:       queries,
+		keyEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+		http:          &httpPublisher{client: server.Client(), now: time.Now},
+		now:           func() time.Time { return now },
+		sinks:         make(map[string]*cachedSinks),
+	}
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	mock.ExpectExec(regexp.QuoteMeta(appendToBacklogStmt)).
+		WithArgs("instance1", "sink1", 42.5, "user", "agg1", uint64(3), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = d.deliver(context.Background(), db, testEvent("user", "user.human.added", `{"userName":"user","encodedHash":"$2a$hash"}`))
+	require.NoError(t, err)
+	err = d.deliver(context.Background(), db, testEvent("org", "org.added", `{"name":"org"}`))
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	assert.Equal(t, 1, queries.calls)
+	require.Len(t, received, 1)
+	assert.Equal(t, &CloudEvent{
+		SpecVersion:     "1.0",
+		ID:              "user:agg1:3",
+		Source:          "/instances/instance1",
+		Type:            "user.human.added",
+		Subject:         "user/agg1",
+		Time:            time.Unix(1700000000, 0).UTC(),
+		DataContentType: "application/json",
+		Data:            json.RawMessage(`{"userName":"user"}`),
+		InstanceID:      "instance1",
+		ResourceOwner:   "org1",
+		AggregateType:   "user",
+		AggregateID:     "agg1",
+		Sequence:        3,
+		Position:        42.5,
+	}, received[0])
+}
+
+func Test_delivery_deliver_isolatesSinks(t *testing.T) {
+	var received int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/failing" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		received++
+	}))
+	defer server.Close()
+
+	signingKey := &crypto.CryptoValue{
+		CryptoType: crypto.TypeEncryption,
+		Algorithm:  "enc",
+		KeyID:      "id",
+		Crypted:    []byte("key"),
+	}
+	queries := &mockQueries{
+		sinks: []*query.EventSink{
+			{
+				ID:         "failing",
+				SinkType:   domain.EventSinkTypeHTTP,
+				Endpoint:   server.URL + "/failing",
+				SigningKey: signingKey,
+			},
+			{
+				ID:         "backlogged",
+				SinkType:   domain.EventSinkTypeHTTP,
+				Endpoint:   server.URL + "/backlogged",
+				SigningKey: signingKey,
+			},
+			{
+				ID:         "healthy",
+				SinkType:   domain.EventSinkTypeHTTP,
+				Endpoint:   server.URL + "/healthy",
+				SigningKey: signingKey,
+			},
+		},
+	}
+	d := &delivery{
+		queries:       queries,
+		keyEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+		http:          &httpPublisher{client: server.Client(), now: time.Now},
+		now:           time.Now,
+		sinks:         make(map[string]*cachedSinks),
+	}
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	mock.ExpectExec(regexp.QuoteMeta(appendToBacklogStmt)).
+		WithArgs("instance1", "failing", 42.5, "user", "agg1", uint64(3), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(addToBacklogStmt)).
+		WithArgs("instance1", "failing", 42.5, "user", "agg1", uint64(3), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(appendToBacklogStmt)).
+		WithArgs("instance1", "backlogged", 42.5, "user", "agg1", uint64(3), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(appendToBacklogStmt)).
+		WithArgs("instance1", "healthy", 42.5, "user", "agg1", uint64(3), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = d.deliver(context.Background(), db, testEvent("user", "user.human.added", `{"userName":"user"}`))
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+	assert.Equal(t, 1, received)
+}
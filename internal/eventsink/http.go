@@ -0,0 +1,59 @@
+package eventsink
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+// SignatureHeader contains the timestamp and the HMAC-SHA256 of the payload of HTTP sinks
+// in the format "t=<unix timestamp>,v1=<hex encoded signature>".
+// The signed content is "<unix timestamp>.<payload>".
+const SignatureHeader = "Zitadel-Signature"
+
+type httpPublisher struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+func (p *httpPublisher) publish(ctx context.Context, endpoint string, signingKey []byte, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", cloudEventsContentType)
+	req.Header.Set(SignatureHeader, SignPayload(signingKey, p.now(), payload))
+	return doRequest(p.client, req)
+}
+
+// SignPayload returns the value of the [SignatureHeader] of the payload signed at the timestamp
+func SignPayload(key []byte, timestamp time.Time, payload []byte) string {
+	unix := strconv.FormatInt(timestamp.Unix(), 10)
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(unix))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "t=" + unix + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func doRequest(client *http.Client, req *http.Request) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// read the body to allow reusing the connection
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.ThrowUnavailablef(nil, "SINK-ohK4e", "unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package eventsink
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignPayload(t *testing.T) {
+	got := SignPayload([]byte("key"), time.Unix(1700000000, 0), []byte(`{"id":"1"}`))
+	// echo -n '1700000000.{"id":"1"}' | openssl dgst -sha256 -hmac key
+	assert.Equal(t, "t=1700000000,v1=9e040cb90cefc5a04ab9a9848a74e2ff0489b4d8837b38d54555cb67e4f8e76e", got)
+}
+
+func Test_httpPublisher_publish(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{
+		{
+			name:   "accepted",
+			status: http.StatusAccepted,
+		},
+		{
+			name:    "server error",
+			status:  http.StatusInternalServerError,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				body      []byte
+				signature string
+			)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, cloudEventsContentType, r.Header.Get("Content-Type"))
+				signature = r.Header.Get(SignatureHeader)
+				body, _ = io.ReadAll(r.Body)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			now := time.Unix(1700000000, 0)
+			p := &httpPublisher{client: server.Client(), now: func() time.Time { return now }}
+			err := p.publish(context.Background(), server.URL, []byte("key"), []byte(`{"id":"1"}`))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, `{"id":"1"}`, string(body))
+			assert.Equal(t, SignPayload([]byte("key"), now, body), signature)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package eventsink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const kafkaRESTContentType = "application/vnd.kafka.json.v2+json"
+
+// kafkaPublisher produces the messages through a Kafka REST proxy (API v2),
+// the endpoint is the base URL of the proxy.
+// The aggregate id is used as key so the events of an aggregate are written to the same partition in order.
+type kafkaPublisher struct {
+	client *http.Client
+}
+
+type kafkaRecords struct {
+	Records []kafkaRecord `json:"records"`
+}
+
+type kafkaRecord struct {
+	Key   string          `json:"key"`
+	Value json.RawMessage `json:"value"`
+}
+
+type kafkaResponse struct {
+	Offsets []struct {
+		Partition *int32 `json:"partition"`
+		ErrorCode *int   `json:"error_code"`
+		Error     string `json:"error"`
+	} `json:"offsets"`
+}
+
+func (p *kafkaPublisher) publish(ctx context.Context, endpoint, topic, key string, payload []byte) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return err
+	}
+	user := u.User
+	u.User = nil
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/topics/" + url.PathEscape(topic)
+
+	body, err := json.Marshal(&kafkaRecords{Records: []kafkaRecord{{Key: key, Value: payload}}})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", kafkaRESTContentType)
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json")
+	if user != nil {
+		pass, _ := user.Password()
+		req.SetBasicAuth(user.Username(), pass)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+		return errors.ThrowUnavailablef(nil, "SINK-Iej3a", "unexpected status code %d", resp.StatusCode)
+	}
+	produced := new(kafkaResponse)
+	if err = json.NewDecoder(io.LimitReader(resp.Body, 1<<16)).Decode(produced); err != nil {
+		return err
+	}
+	for _, offset := range produced.Offsets {
+		if offset.ErrorCode != nil || offset.Error != "" {
+			return errors.ThrowUnavailablef(nil, "SINK-Zoo6e", "kafka error: %s", offset.Error)
+		}
+	}
+	return nil
+}
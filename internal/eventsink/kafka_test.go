@@ -0,0 +1,67 @@
+package eventsink
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_kafkaPublisher_publish(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		response string
+		wantErr  bool
+	}{
+		{
+			name:     "produced",
+			status:   http.StatusOK,
+			response: `{"offsets":[{"partition":0,"offset":1}]}`,
+		},
+		{
+			name:     "record error",
+			status:   http.StatusOK,
+			response: `{"offsets":[{"partition":null,"offset":null,"error_code":50002,"error":"retriable"}]}`,
+			wantErr:  true,
+		},
+		{
+			name:    "unknown topic",
+			status:  http.StatusNotFound,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var records kafkaRecords
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/kafka/topics/zitadel", r.URL.Path)
+				assert.Equal(t, kafkaRESTContentType, r.Header.Get("Content-Type"))
+				user, pass, ok := r.BasicAuth()
+				assert.True(t, ok)
+				assert.Equal(t, "user", user)
+				assert.Equal(t, "pass", pass)
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&records))
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			endpoint := "http://user:pass@" + server.Listener.Addr().String() + "/kafka/"
+			p := &kafkaPublisher{client: server.Client()}
+			err := p.publish(context.Background(), endpoint, "zitadel", "agg1", []byte(`{"id":"1"}`))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, records.Records, 1)
+			assert.Equal(t, "agg1", records.Records[0].Key)
+			assert.JSONEq(t, `{"id":"1"}`, string(records.Records[0].Value))
+		})
+	}
+}
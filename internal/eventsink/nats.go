@@ -0,0 +1,163 @@
+package eventsink
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+// natsPublisher implements the subset of the NATS client protocol required to publish a message.
+// A connection is opened per message, which is sufficient for the throughput of event sinks
+// and does not require a client library.
+type natsPublisher struct {
+	timeout time.Duration
+}
+
+type natsInfo struct {
+	TLSRequired  bool `json:"tls_required"`
+	AuthRequired bool `json:"auth_required"`
+}
+
+type natsConnect struct {
+	Verbose  bool   `json:"verbose"`
+	Pedantic bool   `json:"pedantic"`
+	Name     string `json:"name"`
+	Lang     string `json:"lang"`
+	Version  string `json:"version"`
+	User     string `json:"user,omitempty"`
+	Pass     string `json:"pass,omitempty"`
+	Token    string `json:"auth_token,omitempty"`
+}
+
+func (p *natsPublisher) publish(ctx context.Context, endpoint, subject string, payload []byte) (err error) {
+	// the subject is validated when the sink is set,
+	// it's checked again because it's written to the protocol as is
+	if !domain.ValidNATSSubject(subject) {
+		return errors.ThrowInvalidArgumentf(nil, "SINK-Phee0", "invalid nats subject %q", subject)
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return err
+	}
+	if actions.IsHostDenied(u.Hostname()) {
+		return errors.ThrowInvalidArgument(nil, "SINK-ue2Ai", "host is denied")
+	}
+	host := u.Host
+	if u.Port() == "" {
+		host = net.JoinHostPort(u.Hostname(), "4222")
+	}
+	dialer := actions.DenyListDialer(&net.Dialer{Timeout: p.timeout})
+	conn, err := dialer.DialContext(ctx, "tcp", host)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		closeErr := conn.Close()
+		if err == nil {
+			err = closeErr
+		}
+	}()
+	deadline := time.Now().Add(p.timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	if err = conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+
+	reader := bufio.NewReader(conn)
+	info, err := readNATSInfo(reader)
+	if err != nil {
+		return err
+	}
+	if info.TLSRequired || u.Scheme == "tls" {
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: u.Hostname(), MinVersion: tls.VersionTLS12})
+		if err = tlsConn.HandshakeContext(ctx); err != nil {
+			return err
+		}
+		conn = tlsConn
+		reader = bufio.NewReader(conn)
+	}
+
+	connect, err := json.Marshal(natsConnectOf(u))
+	if err != nil {
+		return err
+	}
+	var message strings.Builder
+	message.WriteString("CONNECT ")
+	message.Write(connect)
+	message.WriteString("\r\nPUB ")
+	message.WriteString(subject)
+	message.WriteString(" ")
+	message.WriteString(strconv.Itoa(len(payload)))
+	message.WriteString("\r\n")
+	message.Write(payload)
+	message.WriteString("\r\nPING\r\n")
+	if _, err = conn.Write([]byte(message.String())); err != nil {
+		return err
+	}
+	return awaitNATSPong(reader)
+}
+
+func natsConnectOf(u *url.URL) *natsConnect {
+	connect := &natsConnect{
+		Name:    "zitadel-event-sink",
+		Lang:    "go",
+		Version: "1.0.0",
+	}
+	if u.User == nil {
+		return connect
+	}
+	if pass, ok := u.User.Password(); ok {
+		connect.User = u.User.Username()
+		connect.Pass = pass
+		return connect
+	}
+	connect.Token = u.User.Username()
+	return connect
+}
+
+func readNATSInfo(reader *bufio.Reader) (*natsInfo, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, "INFO ") {
+		return nil, errors.ThrowUnavailablef(nil, "SINK-Ahx9i", "unexpected nats protocol message %q", line)
+	}
+	info := new(natsInfo)
+	if err = json.Unmarshal([]byte(strings.TrimPrefix(line, "INFO ")), info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// awaitNATSPong waits for the response of the PING sent after the message.
+// The server processes the messages of a connection in order,
+// so the PONG confirms that the message was accepted.
+func awaitNATSPong(reader *bufio.Reader) error {
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "PONG":
+			return nil
+		case strings.HasPrefix(line, "-ERR"):
+			return errors.ThrowUnavailablef(nil, "SINK-ua8Ie", "nats error: %s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		}
+	}
+}
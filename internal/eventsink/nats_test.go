@@ -0,0 +1,104 @@
+package eventsink
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type natsMessage struct {
+	connect string
+	subject string
+	payload string
+}
+
+// startNATSServer accepts a single connection and answers the PING with the response
+func startNATSServer(t *testing.T, response string) (string, <-chan *natsMessage) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+	messages := make(chan *natsMessage, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_, _ = conn.Write([]byte("INFO {\"server_id\":\"test\",\"max_payload\":1048576}\r\n"))
+		reader := bufio.NewReader(conn)
+		message := new(natsMessage)
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimSpace(line)
+			switch {
+			case strings.HasPrefix(line, "CONNECT "):
+				message.connect = strings.TrimPrefix(line, "CONNECT ")
+			case strings.HasPrefix(line, "PUB "):
+				fields := strings.Fields(line)
+				size, _ := strconv.Atoi(fields[2])
+				payload := make([]byte, size+2)
+				if _, err = io.ReadFull(reader, payload); err != nil {
+					return
+				}
+				message.subject = fields[1]
+				message.payload = string(payload[:size])
+			case line == "PING":
+				messages <- message
+				_, _ = conn.Write([]byte(response))
+				return
+			}
+		}
+	}()
+	return "nats://user:pass@" + listener.Addr().String(), messages
+}
+
+func Test_natsPublisher_publish(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		wantErr  bool
+	}{
+		{
+			name:     "published",
+			response: "PONG\r\n",
+		},
+		{
+			name:     "error",
+			response: "-ERR 'Permissions Violation for Publish to zitadel.events'\r\n",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoint, messages := startNATSServer(t, tt.response)
+			p := &natsPublisher{timeout: 5 * time.Second}
+			err := p.publish(context.Background(), endpoint, "zitadel.events", []byte(`{"id":"1"}`))
+			message := <-messages
+			assert.Equal(t, "zitadel.events", message.subject)
+			assert.Equal(t, `{"id":"1"}`, message.payload)
+			assert.Contains(t, message.connect, `"user":"user","pass":"pass"`)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_natsPublisher_publish_invalidSubject(t *testing.T) {
+	p := &natsPublisher{timeout: 5 * time.Second}
+	err := p.publish(context.Background(), "nats://127.0.0.1:4222", "zitadel.events 0\r\nPUB other", []byte(`{"id":"1"}`))
+	require.Error(t, err)
+}
@@ -0,0 +1,202 @@
+package eventsink
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/repository/pseudo"
+)
+
+// retrier delivers the backlogs of the sinks of an instance in order.
+// It's triggered for every instance after RequeueEvery.
+type retrier struct {
+	delivery    *delivery
+	client      *database.DB
+	limit       uint16
+	maxAttempts uint8
+}
+
+func newRetrier(ctx context.Context, handlerCfg handler.Config, delivery *delivery) *handler.Handler {
+	r := &retrier{
+		delivery:    delivery,
+		client:      handlerCfg.Client,
+		limit:       handlerCfg.BulkLimit,
+		maxAttempts: handlerCfg.MaxFailureCount,
+	}
+	handlerCfg.TriggerWithoutEvents = r.retry
+	return handler.NewHandler(ctx, &handlerCfg, r)
+}
+
+func (*retrier) Name() string {
+	return RetryProjectionTable
+}
+
+func (r *retrier) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{{
+		Aggregate: pseudo.AggregateType,
+		EventReducers: []handler.EventReducer{{
+			Event:  pseudo.ScheduledEventType,
+			Reduce: r.retry,
+		}},
+	}}
+}
+
+func (r *retrier) retry(event eventstore.Event) (*handler.Statement, error) {
+	scheduledEvent, ok := event.(*pseudo.ScheduledEvent)
+	if !ok {
+		return nil, caos_errs.ThrowInvalidArgumentf(nil, "SINK-Eiph4", "reduce.wrong.event.type %s", event.Type())
+	}
+	return handler.NewStatement(event, func(ex handler.Executer, _ string) error {
+		for _, instanceID := range scheduledEvent.InstanceIDs {
+			if err := r.retryInstance(authz.WithInstanceID(context.Background(), instanceID), ex, instanceID); err != nil {
+				return err
+			}
+		}
+		return nil
+	}), nil
+}
+
+type backlogEntry struct {
+	sinkID        string
+	aggregateType string
+	aggregateID   string
+	sequence      uint64
+	payload       []byte
+	attempts      uint8
+}
+
+const (
+	backlogStmt = "SELECT " + BacklogSinkIDCol + ", " + BacklogAggregateTypeCol + ", " + BacklogAggregateIDCol + ", " +
+		BacklogSequenceCol + ", " + BacklogPayloadCol + ", " + BacklogAttemptsCol +
+		" FROM " + DeliveryProjectionTable +
+		" WHERE " + BacklogInstanceIDCol + " = $1" +
+		" ORDER BY " + BacklogSinkIDCol + ", " + BacklogPositionCol + ", " + BacklogAggregateTypeCol + ", " + BacklogAggregateIDCol + ", " + BacklogSequenceCol +
+		" LIMIT $2"
+	backlogEntryCondition = " WHERE " + BacklogInstanceIDCol + " = $1 AND " + BacklogSinkIDCol + " = $2 AND " +
+		BacklogAggregateTypeCol + " = $3 AND " + BacklogAggregateIDCol + " = $4 AND " + BacklogSequenceCol + " = $5"
+	removeBacklogEntryStmt = "DELETE FROM " + DeliveryProjectionTable + backlogEntryCondition
+	failBacklogEntryStmt   = "UPDATE " + DeliveryProjectionTable + " SET " + BacklogAttemptsCol + " = " + BacklogAttemptsCol + " + 1" + backlogEntryCondition
+	removeSinkBacklogStmt  = "DELETE FROM " + DeliveryProjectionTable + " WHERE " + BacklogInstanceIDCol + " = $1 AND " + BacklogSinkIDCol + " = $2"
+	deadLetterStmt         = "INSERT INTO " + projection.FailedEventsTable +
+		" (projection_name, instance_id, aggregate_type, aggregate_id, event_creation_date, failed_sequence, failure_count, error, last_failed)" +
+		" VALUES ($1, $2, $3, $4, $5, $6, $7, $8, now())" +
+		" ON CONFLICT (projection_name, instance_id, aggregate_type, aggregate_id, failed_sequence) DO UPDATE SET" +
+		" failure_count = EXCLUDED.failure_count, error = EXCLUDED.error, last_failed = EXCLUDED.last_failed"
+)
+
+// DeadLetterProjectionName is the projection name of the failed events of a sink,
+// the entries of the backlog which failed too often are moved to the failed events.
+func DeadLetterProjectionName(sinkID string) string {
+	return DeliveryProjectionTable + "/" + sinkID
+}
+
+// retryInstance delivers the backlog entries of each sink in order until the first failure of the sink
+func (r *retrier) retryInstance(ctx context.Context, ex handler.Executer, instanceID string) error {
+	backlog, err := r.backlog(ctx, instanceID)
+	if err != nil || len(backlog) == 0 {
+		return err
+	}
+	sinks, err := r.delivery.instanceSinks(ctx, instanceID)
+	if err != nil {
+		return err
+	}
+	sinksByID := make(map[string]*query.EventSink, len(sinks))
+	for _, sink := range sinks {
+		sinksByID[sink.ID] = sink
+	}
+	failedSinks := make(map[string]bool)
+	for _, entry := range backlog {
+		if failedSinks[entry.sinkID] {
+			continue
+		}
+		sink, ok := sinksByID[entry.sinkID]
+		if !ok {
+			// the sink was removed
+			failedSinks[entry.sinkID] = true
+			if _, err = ex.Exec(removeSinkBacklogStmt, instanceID, entry.sinkID); err != nil {
+				return caos_errs.ThrowInternal(err, "SINK-oa9Wo", "unable to remove backlog")
+			}
+			continue
+		}
+		publishErr := r.delivery.publish(ctx, sink, entry.aggregateID, entry.payload)
+		if publishErr == nil || entry.attempts+1 >= r.maxAttempts {
+			if publishErr != nil {
+				logging.WithFields("instance", instanceID, "sink", entry.sinkID, "aggregate", entry.aggregateID, "sequence", entry.sequence).
+					WithError(publishErr).Error("delivery to event sink failed too often, event is moved to the failed events")
+				if err = r.deadLetter(ex, instanceID, entry, publishErr); err != nil {
+					return err
+				}
+			}
+			if _, err = ex.Exec(removeBacklogEntryStmt, entry.args(instanceID)...); err != nil {
+				return caos_errs.ThrowInternal(err, "SINK-Cie2a", "unable to remove backlog entry")
+			}
+			continue
+		}
+		failedSinks[entry.sinkID] = true
+		logging.WithFields("instance", instanceID, "sink", entry.sinkID).WithError(publishErr).Warn("retry of event sink failed")
+		if _, err = ex.Exec(failBacklogEntryStmt, entry.args(instanceID)...); err != nil {
+			return caos_errs.ThrowInternal(err, "SINK-ohG3u", "unable to update backlog entry")
+		}
+	}
+	return nil
+}
+
+func (r *retrier) backlog(ctx context.Context, instanceID string) (backlog []*backlogEntry, err error) {
+	err = r.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		for rows.Next() {
+			entry := new(backlogEntry)
+			if err := rows.Scan(&entry.sinkID, &entry.aggregateType, &entry.aggregateID, &entry.sequence, &entry.payload, &entry.attempts); err != nil {
+				return err
+			}
+			backlog = append(backlog, entry)
+		}
+		return rows.Err()
+	}, backlogStmt, instanceID, r.limit)
+	if err != nil {
+		return nil, caos_errs.ThrowInternal(err, "SINK-Yae1o", "unable to query backlog")
+	}
+	return backlog, nil
+}
+
+// deadLetter writes the entry to the failed events in the same transaction as it's removed from the backlog
+func (r *retrier) deadLetter(ex handler.Executer, instanceID string, entry *backlogEntry, publishErr error) error {
+	_, err := ex.Exec(deadLetterStmt,
+		DeadLetterProjectionName(entry.sinkID),
+		instanceID,
+		entry.aggregateType,
+		entry.aggregateID,
+		r.eventCreationDate(entry),
+		entry.sequence,
+		entry.attempts+1,
+		publishErr.Error(),
+	)
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "SINK-Uu4ae", "unable to move backlog entry to failed events")
+	}
+	return nil
+}
+
+// eventCreationDate returns the time of the cloud event of the entry,
+// the time of the retry is used if the payload can't be parsed
+func (r *retrier) eventCreationDate(entry *backlogEntry) time.Time {
+	cloudEvent := new(CloudEvent)
+	if err := json.Unmarshal(entry.payload, cloudEvent); err != nil || cloudEvent.Time.IsZero() {
+		return r.delivery.now()
+	}
+	return cloudEvent.Time
+}
+
+func (e *backlogEntry) args(instanceID string) []any {
+	return []any{instanceID, e.sinkID, e.aggregateType, e.aggregateID, e.sequence}
+}
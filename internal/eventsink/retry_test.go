@@ -0,0 +1,118 @@
+package eventsink
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func Test_retrier_retryInstance(t *testing.T) {
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/failing" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		received = append(received, r.URL.Path)
+	}))
+	defer server.Close()
+
+	signingKey := &crypto.CryptoValue{
+		CryptoType: crypto.TypeEncryption,
+		Algorithm:  "enc",
+		KeyID:      "id",
+		Crypted:    []byte("key"),
+	}
+	queries := &mockQueries{
+		sinks: []*query.EventSink{
+			{
+				ID:         "failing",
+				SinkType:   domain.EventSinkTypeHTTP,
+				Endpoint:   server.URL + "/failing",
+				SigningKey: signingKey,
+			},
+			{
+				ID:         "healthy",
+				SinkType:   domain.EventSinkTypeHTTP,
+				Endpoint:   server.URL + "/healthy",
+				SigningKey: signingKey,
+			},
+		},
+	}
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	r := &retrier{
+		delivery: &delivery{
+			queries:       queries,
+			keyEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			http:          &httpPublisher{client: server.Client(), now: time.Now},
+			now:           time.Now,
+			sinks:         make(map[string]*cachedSinks),
+		},
+		client:      &database.DB{DB: db},
+		limit:       10,
+		maxAttempts: 3,
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(backlogStmt)).
+		WithArgs("instance1", uint16(10)).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"sink_id", "aggregate_type", "aggregate_id", "sequence", "payload", "attempts"}).
+				AddRow("failing", "user", "agg1", 1, []byte(`{"time":"2023-11-14T22:13:20Z"}`), 2).
+				AddRow("failing", "user", "agg1", 2, []byte(`{}`), 1).
+				AddRow("failing", "user", "agg1", 3, []byte(`{}`), 1).
+				AddRow("healthy", "user", "agg1", 1, []byte(`{}`), 1).
+				AddRow("healthy", "user", "agg1", 2, []byte(`{}`), 1).
+				AddRow("removed", "user", "agg1", 1, []byte(`{}`), 1).
+				AddRow("removed", "user", "agg1", 2, []byte(`{}`), 1),
+		)
+	mock.ExpectCommit()
+	// the first entry reached the max attempts and is moved to the failed events, the next one fails and blocks the sink
+	mock.ExpectExec(regexp.QuoteMeta(deadLetterStmt)).
+		WithArgs(
+			"projections.event_sink_deliveries/failing",
+			"instance1",
+			"user",
+			"agg1",
+			time.Date(2023, 11, 14, 22, 13, 20, 0, time.UTC),
+			uint64(1),
+			uint8(3),
+			sqlmock.AnyArg(),
+		).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(removeBacklogEntryStmt)).
+		WithArgs("instance1", "failing", "user", "agg1", uint64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(failBacklogEntryStmt)).
+		WithArgs("instance1", "failing", "user", "agg1", uint64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(removeBacklogEntryStmt)).
+		WithArgs("instance1", "healthy", "user", "agg1", uint64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(removeBacklogEntryStmt)).
+		WithArgs("instance1", "healthy", "user", "agg1", uint64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(removeSinkBacklogStmt)).
+		WithArgs("instance1", "removed").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	err = r.retryInstance(context.Background(), db, "instance1")
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+	assert.Equal(t, []string{"/healthy", "/healthy"}, received)
+}
@@ -77,6 +77,19 @@ type Aggregate struct {
 	InstanceID string `json:"-"`
 	// Version is the semver this aggregate represents
 	Version Version `json:"-"`
+	// ExpectedSequence and ExpectedEventTypes are set by [Aggregate.ExpectSequence]
+	ExpectedSequence   uint64      `json:"-"`
+	ExpectedEventTypes []EventType `json:"-"`
+}
+
+// ExpectSequence lets the push of the commands on the aggregate fail
+// if an event of one of the types was pushed to the aggregate after the sequence.
+// It prevents concurrent commands based on the same state, e.g. from consuming a one-time code twice,
+// without failing because of unrelated events of the aggregate.
+func (a *Aggregate) ExpectSequence(sequence uint64, types ...EventType) *Aggregate {
+	a.ExpectedSequence = sequence
+	a.ExpectedEventTypes = types
+	return a
 }
 
 // AggregateType is the object name
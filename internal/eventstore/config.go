@@ -5,8 +5,18 @@ import (
 )
 
 type Config struct {
-	PushTimeout time.Duration
+	PushTimeout  time.Duration
+	Notification NotifierConfig
 
 	Pusher  Pusher
 	Querier Querier
+	// Notifier propagates pushed events to the other nodes, it's optional
+	Notifier Notifier
+
+	Snapshots SnapshotConfig
+	// SnapshotStorage stores the snapshots of the models, it's optional
+	SnapshotStorage SnapshotStorage
+	// PersonalDataKeys open the sealed personal data of the events,
+	// they are required as soon as crypto-shredding was enabled once
+	PersonalDataKeys PersonalDataKeys
 }
@@ -2,11 +2,13 @@ package eventstore
 
 import (
 	"context"
+	"errors"
 	"sort"
 	"sync"
 	"time"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
 )
 
 // Eventstore abstracts all functions needed to store valid events
@@ -16,12 +18,20 @@ type Eventstore struct {
 	// or if we scale to >4vCPU use a sync.Map
 	interceptorMutex  sync.RWMutex
 	eventInterceptors map[EventType]eventTypeInterceptors
+	upcasters         map[upcasterKey]Upcaster
 	eventTypes        []string
 	aggregateTypes    []string
 	PushTimeout       time.Duration
 
-	pusher  Pusher
-	querier Querier
+	pusher   Pusher
+	querier  Querier
+	notifier Notifier
+	// snapshots is nil if snapshots are disabled
+	snapshots *snapshotter
+	// personalDataKeys is nil if crypto-shredding was never enabled
+	personalDataKeys PersonalDataKeys
+	// personalDataFields are the fields of the event types containing personal data
+	personalDataFields map[EventType][]string
 
 	instances         []string
 	lastInstanceQuery time.Time
@@ -37,8 +47,12 @@ func NewEventstore(config *Config) *Eventstore {
 		eventInterceptors: map[EventType]eventTypeInterceptors{},
 		PushTimeout:       config.PushTimeout,
 
-		pusher:  config.Pusher,
-		querier: config.Querier,
+		pusher:    config.Pusher,
+		querier:   config.Querier,
+		notifier:  config.Notifier,
+		snapshots: newSnapshotter(config),
+
+		personalDataKeys: config.PersonalDataKeys,
 
 		instancesMu: sync.Mutex{},
 	}
@@ -66,11 +80,17 @@ func (es *Eventstore) Push(ctx context.Context, cmds ...Command) ([]Event, error
 		return nil, err
 	}
 
-	mappedEvents, err := es.mapEvents(events)
+	mappedEvents, err := es.mapEvents(ctx, events)
 	if err != nil {
 		return mappedEvents, err
 	}
+	if len(mappedEvents) > 0 {
+		// the queries of the call must not read from replicas which are behind the pushed events
+		latest := mappedEvents[len(mappedEvents)-1]
+		database.SetWrittenPosition(ctx, latest.Aggregate().InstanceID, latest.Position())
+	}
 	es.notify(mappedEvents)
+	es.notifyNodes(ctx, mappedEvents)
 	return mappedEvents, nil
 }
 
@@ -85,15 +105,10 @@ func (es *Eventstore) AggregateTypes() []string {
 // Filter filters the stored events based on the searchQuery
 // and maps the events to the defined event structs
 //
-// Deprecated: Use [FilterToQueryReducer] instead to avoid allocations.
+// Deprecated: Use [FilterToQueryReducer] or [FilterStream] instead to avoid allocations.
 func (es *Eventstore) Filter(ctx context.Context, searchQuery *SearchQueryBuilder) ([]Event, error) {
 	events := make([]Event, 0, searchQuery.GetLimit())
-	searchQuery.ensureInstanceID(ctx)
-	err := es.querier.FilterToReducer(ctx, searchQuery, func(event Event) error {
-		event, err := es.mapEvent(event)
-		if err != nil {
-			return err
-		}
+	err := es.FilterStream(ctx, searchQuery, func(event Event) error {
 		events = append(events, event)
 		return nil
 	})
@@ -103,29 +118,60 @@ func (es *Eventstore) Filter(ctx context.Context, searchQuery *SearchQueryBuilde
 	return events, nil
 }
 
-func (es *Eventstore) mapEvents(events []Event) (mappedEvents []Event, err error) {
-	mappedEvents = make([]Event, len(events))
+// ErrStopFilter can be returned by the reduce function of [FilterStream]
+// to stop filtering without an error
+var ErrStopFilter = errors.New("stop filter")
 
-	es.interceptorMutex.RLock()
-	defer es.interceptorMutex.RUnlock()
+// FilterStream filters the stored events based on the searchQuery
+// and passes the mapped events one by one to reduce,
+// the events are not kept in memory by the eventstore.
+// The next event is only read from the storage after reduce returned.
+// Filtering stops if ctx is done or reduce returns an error.
+func (es *Eventstore) FilterStream(ctx context.Context, searchQuery *SearchQueryBuilder, reduce Reducer) error {
+	searchQuery.ensureInstanceID(ctx)
+	err := es.querier.FilterToReducer(ctx, searchQuery, func(event Event) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		event, err := es.mapEvent(ctx, event)
+		if err != nil {
+			return err
+		}
+		return reduce(event)
+	})
+	if errors.Is(err, ErrStopFilter) {
+		return nil
+	}
+	return err
+}
 
+func (es *Eventstore) mapEvents(ctx context.Context, events []Event) (mappedEvents []Event, err error) {
+	mappedEvents = make([]Event, len(events))
 	for i, event := range events {
-		mappedEvents[i], err = es.mapEventLocked(event)
+		mappedEvents[i], err = es.mapEvent(ctx, event)
 		if err != nil {
 			return nil, err
 		}
 	}
-
 	return mappedEvents, nil
 }
 
-func (es *Eventstore) mapEvent(event Event) (Event, error) {
+func (es *Eventstore) mapEvent(ctx context.Context, event Event) (Event, error) {
+	// the personal data is opened without holding the lock, because it might read the keys from the storage
+	event, err := es.openPersonalData(ctx, event)
+	if err != nil {
+		return nil, err
+	}
 	es.interceptorMutex.RLock()
 	defer es.interceptorMutex.RUnlock()
 	return es.mapEventLocked(event)
 }
 
-func (es *Eventstore) mapEventLocked(event Event) (Event, error) {
+func (es *Eventstore) mapEventLocked(event Event) (_ Event, err error) {
+	event, err = es.upcastLocked(event)
+	if err != nil {
+		return nil, err
+	}
 	interceptors, ok := es.eventInterceptors[event.Type()]
 	if !ok || interceptors.eventMapper == nil {
 		return BaseEventFromRepo(event), nil
@@ -151,12 +197,7 @@ type reducer interface {
 
 // FilterToReducer filters the events based on the search query, appends all events to the reducer and calls it's reduce function
 func (es *Eventstore) FilterToReducer(ctx context.Context, searchQuery *SearchQueryBuilder, r reducer) error {
-	searchQuery.ensureInstanceID(ctx)
-	return es.querier.FilterToReducer(ctx, searchQuery, func(event Event) error {
-		event, err := es.mapEvent(event)
-		if err != nil {
-			return err
-		}
+	return es.FilterStream(ctx, searchQuery, func(event Event) error {
 		r.AppendEvents(event)
 		return r.Reduce()
 	})
@@ -199,8 +240,13 @@ type QueryReducer interface {
 }
 
 // FilterToQueryReducer filters the events based on the search query of the query function,
-// appends all events to the reducer and calls it's reduce function
+// appends all events to the reducer and calls it's reduce function.
+// If snapshots are enabled and r implements [SnapshotQueryReducer]
+// only the events after the latest snapshot of r are filtered.
 func (es *Eventstore) FilterToQueryReducer(ctx context.Context, r QueryReducer) error {
+	if snapshotReducer, ok := r.(SnapshotQueryReducer); ok && es.snapshots != nil {
+		return es.filterToSnapshotReducer(ctx, snapshotReducer)
+	}
 	return es.FilterToReducer(ctx, r.Query(), r)
 }
 
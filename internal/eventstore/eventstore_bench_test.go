@@ -7,7 +7,9 @@ import (
 	"strconv"
 	"testing"
 
+	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	new_es "github.com/zitadel/zitadel/internal/eventstore/v3"
 )
 
 //go:embed bench_payload.txt
@@ -160,3 +162,65 @@ func Benchmark_Push_MultipleAggregate_Parallel(b *testing.B) {
 		}
 	}
 }
+
+type benchSnapshotWriteModel struct {
+	eventstore.WriteModel
+
+	Count int
+}
+
+func (wm *benchSnapshotWriteModel) Reduce() error {
+	wm.Count += len(wm.Events)
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *benchSnapshotWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes("bench.snapshot").
+		AggregateIDs(wm.AggregateID).
+		Builder()
+}
+
+func (wm *benchSnapshotWriteModel) SnapshotVersion() uint16 {
+	return 1
+}
+
+func Benchmark_FilterToQueryReducer_Snapshot(b *testing.B) {
+	ctx := authz.WithInstanceID(context.Background(), "instance")
+	client := clients["v3(inmemory)"]
+	querier := queriers["v2(inmemory)"]
+
+	for _, historyLength := range []int{10, 100, 1000} {
+		cleanupEventstore(client)()
+		aggregateID := strconv.Itoa(historyLength)
+		for i := 0; i < historyLength; i++ {
+			_, err := pushers["v3(inmemory)"].Push(ctx, generateCommand("bench.snapshot", aggregateID))
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+
+		for _, snapshots := range []bool{false, true} {
+			es := eventstore.NewEventstore(&eventstore.Config{
+				Querier: querier,
+				Snapshots: eventstore.SnapshotConfig{
+					Enabled:   snapshots,
+					MinEvents: 1,
+				},
+				SnapshotStorage: new_es.NewSnapshotStorage(client),
+			})
+			b.Run(fmt.Sprintf("Benchmark_FilterToQueryReducer_Snapshot-%d-events-snapshots-%t", historyLength, snapshots), func(b *testing.B) {
+				for n := 0; n < b.N; n++ {
+					wm := &benchSnapshotWriteModel{WriteModel: eventstore.WriteModel{AggregateID: aggregateID}}
+					if err := es.FilterToQueryReducer(ctx, wm); err != nil {
+						b.Error(err)
+					}
+					if wm.Count != historyLength {
+						b.Errorf("expected %d reduced events, got %d", historyLength, wm.Count)
+					}
+				}
+			})
+		}
+	}
+}
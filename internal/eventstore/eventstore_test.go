@@ -3,6 +3,7 @@ package eventstore
 import (
 	"context"
 	"encoding/json"
+	stderrors "errors"
 	"fmt"
 	"reflect"
 	"testing"
@@ -1149,6 +1150,79 @@ func TestEventstore_FilterToReducer(t *testing.T) {
 	}
 }
 
+func TestEventstore_FilterStream(t *testing.T) {
+	streamedEvents := []Event{
+		&BaseEvent{Agg: &Aggregate{ID: "test.aggregate"}, EventType: "test.event", Seq: 1},
+		&BaseEvent{Agg: &Aggregate{ID: "test.aggregate"}, EventType: "test.event", Seq: 2},
+		&BaseEvent{Agg: &Aggregate{ID: "test.aggregate"}, EventType: "test.event", Seq: 3},
+	}
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		reduceErr   func(reduced int) error
+		wantReduced int
+		wantErr     error
+	}{
+		{
+			name:        "all events",
+			ctx:         context.Background(),
+			reduceErr:   func(int) error { return nil },
+			wantReduced: 3,
+		},
+		{
+			name: "stopped",
+			ctx:  context.Background(),
+			reduceErr: func(reduced int) error {
+				if reduced == 2 {
+					return ErrStopFilter
+				}
+				return nil
+			},
+			wantReduced: 2,
+		},
+		{
+			name: "reduce error",
+			ctx:  context.Background(),
+			reduceErr: func(int) error {
+				return errors.ThrowInternal(nil, "V2-Oor5a", "test err")
+			},
+			wantReduced: 1,
+			wantErr:     errors.ThrowInternal(nil, "V2-Oor5a", "test err"),
+		},
+		{
+			name:        "context canceled",
+			ctx:         canceledCtx,
+			reduceErr:   func(int) error { return nil },
+			wantReduced: 0,
+			wantErr:     context.Canceled,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := &Eventstore{
+				querier:           &testQuerier{events: streamedEvents, t: t},
+				eventInterceptors: map[EventType]eventTypeInterceptors{},
+			}
+			var reduced int
+			err := es.FilterStream(tt.ctx, NewSearchQueryBuilder(ColumnsEvent), func(event Event) error {
+				reduced++
+				if event.Sequence() != uint64(reduced) {
+					t.Errorf("wrong event order, expected sequence %d got %d", reduced, event.Sequence())
+				}
+				return tt.reduceErr(reduced)
+			})
+			if !reflect.DeepEqual(tt.wantErr, err) && !stderrors.Is(err, tt.wantErr) {
+				t.Errorf("Eventstore.FilterStream() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if reduced != tt.wantReduced {
+				t.Errorf("Eventstore.FilterStream() reduced = %d, want %d", reduced, tt.wantReduced)
+			}
+		})
+	}
+}
+
 func combineEventLists(lists ...[]Event) []Event {
 	events := []Event{}
 	for _, list := range lists {
@@ -1257,7 +1331,7 @@ func TestEventstore_mapEvents(t *testing.T) {
 				t.FailNow()
 			}
 
-			gotMappedEvents, err := es.mapEvents(tt.args.events)
+			gotMappedEvents, err := es.mapEvents(context.Background(), tt.args.events)
 			if (err != nil) != tt.res.wantErr {
 				t.Errorf("Eventstore.mapEvents() error = %v, wantErr %v", err, tt.res.wantErr)
 				return
@@ -290,7 +290,7 @@ func TestUserReadModel(t *testing.T) {
 	es := eventstore.NewEventstore(
 		&eventstore.Config{
 			Querier: query_repo.NewCRDB(testCRDBClient),
-			Pusher:  v3.NewEventstore(testCRDBClient),
+			Pusher:  v3.NewEventstore(testCRDBClient, nil),
 		},
 	)
 
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"context"
 	"database/sql"
 	_ "embed"
 	"time"
@@ -47,7 +48,7 @@ func failureFromStatement(statement *Statement, err error) *failure {
 	}
 }
 
-func (h *Handler) handleFailedStmt(tx *sql.Tx, f *failure) (shouldContinue bool) {
+func (h *Handler) handleFailedStmt(ctx context.Context, tx *sql.Tx, f *failure) (shouldContinue bool) {
 	failureCount, err := h.failureCount(tx, f)
 	if err != nil {
 		h.logFailure(f).WithError(err).Warn("unable to get failure count")
@@ -57,7 +58,9 @@ func (h *Handler) handleFailedStmt(tx *sql.Tx, f *failure) (shouldContinue bool)
 	err = h.setFailureCount(tx, failureCount, f)
 	h.logFailure(f).OnError(err).Warn("unable to update failure count")
 
-	return failureCount >= h.maxFailureCount
+	shouldContinue = failureCount >= h.maxFailureCount
+	h.countFailedEvent(ctx, f.instance, shouldContinue)
+	return shouldContinue
 }
 
 func (h *Handler) failureCount(tx *sql.Tx, f *failure) (count uint8, err error) {
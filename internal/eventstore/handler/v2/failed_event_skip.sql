@@ -0,0 +1,10 @@
+UPDATE 
+    projections.failed_events2
+SET 
+    failure_count = $6
+WHERE 
+    projection_name = $1
+    AND instance_id = $2
+    AND aggregate_type = $3
+    AND aggregate_id = $4
+    AND failed_sequence = $5;
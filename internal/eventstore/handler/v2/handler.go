@@ -20,6 +20,7 @@ import (
 type EventStore interface {
 	InstanceIDs(ctx context.Context, maxAge time.Duration, forceLoad bool, query *eventstore.SearchQueryBuilder) ([]string, error)
 	Filter(ctx context.Context, queryFactory *eventstore.SearchQueryBuilder) ([]eventstore.Event, error)
+	FilterStream(ctx context.Context, queryFactory *eventstore.SearchQueryBuilder, reduce eventstore.Reducer) error
 	Push(ctx context.Context, cmds ...eventstore.Command) ([]eventstore.Event, error)
 }
 
@@ -53,6 +54,9 @@ type Handler struct {
 	now                   nowFunc
 
 	triggeredInstancesSync sync.Map
+	// progress of the instances for metrics and readiness: instanceID -> *progress
+	progress sync.Map
+	paused   pausedInstances
 
 	triggerWithoutEvents Reduce
 }
@@ -71,10 +75,15 @@ func NewHandler(
 	projection Projection,
 ) *Handler {
 	aggregates := make(map[eventstore.AggregateType][]eventstore.EventType, len(projection.Reducers()))
+	allEvents := make(map[eventstore.AggregateType]bool)
 	for _, reducer := range projection.Reducers() {
-		eventTypes := make([]eventstore.EventType, len(reducer.EventReducers))
-		for i, eventReducer := range reducer.EventReducers {
-			eventTypes[i] = eventReducer.Event
+		eventTypes := make([]eventstore.EventType, 0, len(reducer.EventReducers))
+		for _, eventReducer := range reducer.EventReducers {
+			if eventReducer.Event == AllEvents {
+				allEvents[reducer.Aggregate] = true
+				continue
+			}
+			eventTypes = append(eventTypes, eventReducer.Event)
 		}
 		if _, ok := aggregates[reducer.Aggregate]; ok {
 			aggregates[reducer.Aggregate] = append(aggregates[reducer.Aggregate], eventTypes...)
@@ -82,6 +91,10 @@ func NewHandler(
 		}
 		aggregates[reducer.Aggregate] = eventTypes
 	}
+	// no event types query and subscribe all events of the aggregate
+	for aggregate := range allEvents {
+		aggregates[aggregate] = nil
+	}
 
 	handler := &Handler{
 		projection:             projection,
@@ -98,6 +111,7 @@ func NewHandler(
 		triggerWithoutEvents:   config.TriggerWithoutEvents,
 		txDuration:             config.TransactionDuration,
 	}
+	registerMetrics(handler)
 
 	return handler
 }
@@ -160,31 +174,53 @@ func (h *Handler) schedule(ctx context.Context) {
 func (h *Handler) subscribe(ctx context.Context) {
 	queue := make(chan eventstore.Event, 100)
 	subscription := eventstore.SubscribeEventTypes(queue, h.eventTypes)
+	notifications := make(chan eventstore.Notification, 100)
+	notificationSubscription := eventstore.SubscribeNotifications(notifications, h.aggregateTypes()...)
 	for {
 		select {
 		case <-ctx.Done():
+			notificationSubscription.Unsubscribe()
 			subscription.Unsubscribe()
 			h.log().Debug("shutdown")
 			return
 		case event := <-queue:
 			events := checkAdditionalEvents(queue, event)
-			solvedInstances := make([]string, 0, len(events))
-			queueCtx := call.WithTimestamp(ctx)
-			for _, e := range events {
-				if instanceSolved(solvedInstances, e.Aggregate().InstanceID) {
-					continue
-				}
-				queueCtx = authz.WithInstanceID(queueCtx, e.Aggregate().InstanceID)
-				_, err := h.Trigger(queueCtx)
-				h.log().OnError(err).Debug("trigger of queued event failed")
-				if err == nil {
-					solvedInstances = append(solvedInstances, e.Aggregate().InstanceID)
-				}
+			instances := make([]string, len(events))
+			for i, e := range events {
+				instances[i] = e.Aggregate().InstanceID
 			}
+			h.triggerInstances(ctx, instances)
+		case notification := <-notifications:
+			h.triggerInstances(ctx, checkAdditionalNotifications(notifications, notification))
 		}
 	}
 }
 
+// triggerInstances triggers the projection once for each of the instances
+func (h *Handler) triggerInstances(ctx context.Context, instances []string) {
+	solvedInstances := make([]string, 0, len(instances))
+	queueCtx := call.WithTimestamp(ctx)
+	for _, instance := range instances {
+		if instanceSolved(solvedInstances, instance) {
+			continue
+		}
+		queueCtx = authz.WithInstanceID(queueCtx, instance)
+		_, err := h.Trigger(queueCtx)
+		h.log().OnError(err).Debug("trigger of queued event failed")
+		if err == nil {
+			solvedInstances = append(solvedInstances, instance)
+		}
+	}
+}
+
+func (h *Handler) aggregateTypes() []eventstore.AggregateType {
+	aggregateTypes := make([]eventstore.AggregateType, 0, len(h.eventTypes))
+	for aggregateType := range h.eventTypes {
+		aggregateTypes = append(aggregateTypes, aggregateType)
+	}
+	return aggregateTypes
+}
+
 func instanceSolved(solvedInstances []string, instanceID string) bool {
 	for _, solvedInstance := range solvedInstances {
 		if solvedInstance == instanceID {
@@ -208,6 +244,21 @@ func checkAdditionalEvents(eventQueue chan eventstore.Event, event eventstore.Ev
 	}
 }
 
+// checkAdditionalNotifications returns the instances of the notification
+// and of the notifications received in the meantime
+func checkAdditionalNotifications(queue chan eventstore.Notification, notification eventstore.Notification) []string {
+	instances := []string{notification.InstanceID}
+	for {
+		wait := time.NewTimer(1 * time.Millisecond)
+		select {
+		case notification := <-queue:
+			instances = append(instances, notification.InstanceID)
+		case <-wait.C:
+			return instances
+		}
+	}
+}
+
 func (h *Handler) queryInstances(ctx context.Context, didInitialize bool) ([]string, error) {
 	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsInstanceIDs).
 		AwaitOpenTransactions().
@@ -290,12 +341,17 @@ func (h *Handler) lockInstance(ctx context.Context, config *triggerConfig) func(
 }
 
 func (h *Handler) processEvents(ctx context.Context, config *triggerConfig) (additionalIteration bool, err error) {
+	// the context of the transaction is already cancelled if the state was locked
+	triggerCtx := ctx
 	defer func() {
 		pgErr := new(pgconn.PgError)
 		if errors.As(err, &pgErr) {
 			// error returned if the row is currently locked by another connection
 			if pgErr.Code == "55P03" {
 				h.log().Debug("state already locked")
+				instanceID := authz.GetInstance(triggerCtx).InstanceID()
+				h.countLockContention(triggerCtx, instanceID)
+				h.updateLockedProgress(triggerCtx, instanceID)
 				err = nil
 				additionalIteration = false
 			}
@@ -328,6 +384,20 @@ func (h *Handler) processEvents(ctx context.Context, config *triggerConfig) (add
 		}
 		return additionalIteration, err
 	}
+	var paused bool
+	defer func() {
+		if !additionalIteration && !paused && err == nil {
+			h.caughtUp(currentState)
+			return
+		}
+		// the projection is behind the oldest event it didn't process
+		h.updateProgress(ctx, currentState.instanceID, currentState.position)
+	}()
+
+	paused, err = h.isPaused(ctx, tx, currentState.instanceID)
+	if err != nil || paused {
+		return false, err
+	}
 
 	var statements []*Statement
 	statements, additionalIteration, err = h.generateStatements(ctx, tx, currentState)
@@ -343,6 +413,9 @@ func (h *Handler) processEvents(ctx context.Context, config *triggerConfig) (add
 	if lastProcessedIndex < 0 {
 		return false, err
 	}
+	h.countProcessedEvents(ctx, currentState.instanceID, lastProcessedIndex+1)
+	// the rows written by the trigger are not available on replicas yet
+	database.RequirePrimary(ctx)
 
 	currentState.position = statements[lastProcessedIndex].Position
 	currentState.offset = statements[lastProcessedIndex].offset
@@ -364,16 +437,20 @@ func (h *Handler) generateStatements(ctx context.Context, tx *sql.Tx, currentSta
 		return []*Statement{stmt}, false, nil
 	}
 
-	events, err := h.es.Filter(ctx, h.eventQuery(currentState))
+	// the events are reduced while they are read, so they are not kept in memory
+	var eventAmount int
+	toStatements := h.newStatementGenerator(tx, currentState)
+	err = h.es.FilterStream(ctx, h.eventQuery(currentState), func(event eventstore.Event) error {
+		eventAmount++
+		return toStatements.next(ctx, event)
+	})
 	if err != nil {
 		h.log().WithError(err).Debug("filter eventstore failed")
 		return nil, false, err
 	}
-	eventAmount := len(events)
-
-	statements, err := h.eventsToStatements(tx, events, currentState)
-	if err != nil || len(statements) == 0 {
-		return nil, false, err
+	statements := toStatements.statements
+	if len(statements) == 0 {
+		return nil, false, nil
 	}
 
 	idx := skipPreviouslyReduced(statements, currentState)
@@ -390,7 +467,7 @@ func (h *Handler) generateStatements(ctx context.Context, tx *sql.Tx, currentSta
 	statements = statements[idx+1:]
 
 	additionalIteration = eventAmount == int(h.bulkLimit)
-	if len(statements) < len(events) {
+	if len(statements) < eventAmount {
 		// retry immediately if statements failed
 		additionalIteration = true
 	}
@@ -443,10 +520,13 @@ func (h *Handler) executeStatement(ctx context.Context, tx *sql.Tx, currentState
 		_, err = tx.Exec("RELEASE SAVEPOINT exec")
 	}()
 
-	if err = statement.Execute(tx, h.projection.Name()); err != nil {
+	start := time.Now()
+	err = statement.Execute(tx, h.projection.Name())
+	h.recordDuration(ctx, ProjectionStatementDuration, start)
+	if err != nil {
 		h.log().WithError(err).Error("statement execution failed")
 
-		shouldContinue = h.handleFailedStmt(tx, failureFromStatement(statement, err))
+		shouldContinue = h.handleFailedStmt(ctx, tx, failureFromStatement(statement, err))
 		if shouldContinue {
 			return nil
 		}
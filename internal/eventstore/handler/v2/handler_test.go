@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+func TestNewHandler_eventTypes(t *testing.T) {
+	reduce := func(event eventstore.Event) (*Statement, error) {
+		return NewNoOpStatement(event), nil
+	}
+	h := NewHandler(context.Background(), &Config{}, &projection{
+		name: "test",
+		reducers: []AggregateReducer{
+			{
+				Aggregate: "user",
+				EventReducers: []EventReducer{
+					{Event: "user.added", Reduce: reduce},
+				},
+			},
+			{
+				Aggregate: "user",
+				EventReducers: []EventReducer{
+					{Event: "user.removed", Reduce: reduce},
+				},
+			},
+			{
+				Aggregate: "org",
+				EventReducers: []EventReducer{
+					{Event: "org.added", Reduce: reduce},
+					{Event: AllEvents, Reduce: reduce},
+				},
+			},
+		},
+	})
+	assert.Equal(t, map[eventstore.AggregateType][]eventstore.EventType{
+		"user": {"user.added", "user.removed"},
+		"org":  nil,
+	}, h.eventTypes)
+}
+
+func TestHandler_reduce_allEvents(t *testing.T) {
+	var reduced []eventstore.EventType
+	h := &Handler{
+		projection: &projection{
+			name: "test",
+			reducers: []AggregateReducer{
+				{
+					Aggregate: "org",
+					EventReducers: []EventReducer{
+						{
+							Event: AllEvents,
+							Reduce: func(event eventstore.Event) (*Statement, error) {
+								reduced = append(reduced, event.Type())
+								return NewNoOpStatement(event), nil
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, event := range []eventstore.Event{
+		&eventstore.BaseEvent{Agg: &eventstore.Aggregate{Type: "org"}, EventType: "org.added"},
+		&eventstore.BaseEvent{Agg: &eventstore.Aggregate{Type: "user"}, EventType: "user.added"},
+		&eventstore.BaseEvent{Agg: &eventstore.Aggregate{Type: "org"}, EventType: "org.changed"},
+	} {
+		_, err := h.reduce(event)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, []eventstore.EventType{"org.added", "org.changed"}, reduced)
+}
@@ -124,6 +124,7 @@ const (
 	ColumnTypeEnumArray
 	ColumnTypeInt64
 	ColumnTypeBool
+	ColumnTypeDecimal
 )
 
 func NewIndex(name string, columns []string, opts ...indexOpts) *Index {
@@ -407,6 +408,8 @@ func columnType(columnType ColumnType) string {
 		return "JSONB"
 	case ColumnTypeBytes:
 		return "BYTEA"
+	case ColumnTypeDecimal:
+		return "DECIMAL"
 	default:
 		panic("unknown column type")
 	}
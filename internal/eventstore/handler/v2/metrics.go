@@ -0,0 +1,203 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"errors"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/telemetry/metrics"
+)
+
+const (
+	ProjectionEventsProcessed              = "zitadel.projection.events_processed"
+	ProjectionEventsProcessedDescription   = "Count of events processed by the projection"
+	ProjectionReduceDuration               = "zitadel.projection.reduce_duration"
+	ProjectionReduceDurationDescription    = "Duration of reducing an event to a statement in seconds"
+	ProjectionStatementDuration            = "zitadel.projection.statement_duration"
+	ProjectionStatementDurationDescription = "Duration of executing a statement in seconds"
+	ProjectionPosition                     = "zitadel.projection.position"
+	ProjectionPositionDescription          = "Position of the last event processed by the projection"
+	ProjectionLag                          = "zitadel.projection.lag_milliseconds"
+	ProjectionLagDescription               = "Age in milliseconds of the oldest event of the instance the projection didn't process yet"
+	ProjectionLockContention               = "zitadel.projection.lock_contention"
+	ProjectionLockContentionDescription    = "Count of triggers skipped because the projection was locked by another process"
+	ProjectionFailedEvents                 = "zitadel.projection.failed_events"
+	ProjectionFailedEventsDescription      = "Count of failed events of the projection"
+	projectionMetricsLabelProjection       = "projection"
+	projectionMetricsLabelInstance         = "instance"
+	projectionMetricsLabelSkipped          = "skipped"
+	projectionMetricsDurationUnit          = "s"
+)
+
+var (
+	//go:embed state_position.sql
+	statePositionStmt string
+
+	durationBuckets = []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5}
+
+	registerMetricsOnce sync.Once
+	// observedHandlers are the handlers reported by the observers of positions and lags
+	observedHandlers sync.Map
+)
+
+// progress is the last known processing state of the projection for an instance
+type progress struct {
+	position float64
+	// pendingSince is the creation date of the oldest event of the instance the projection didn't process yet,
+	// it's zero if the projection processed all events
+	pendingSince time.Time
+}
+
+func (p *progress) lag(now time.Time) time.Duration {
+	if p.pendingSince.IsZero() {
+		return 0
+	}
+	return now.Sub(p.pendingSince)
+}
+
+func registerMetrics(h *Handler) {
+	registerMetricsOnce.Do(func() {
+		err := metrics.RegisterCounter(ProjectionEventsProcessed, ProjectionEventsProcessedDescription)
+		h.log().OnError(err).Warn("unable to register projection metric")
+		err = metrics.RegisterCounter(ProjectionLockContention, ProjectionLockContentionDescription)
+		h.log().OnError(err).Warn("unable to register projection metric")
+		err = metrics.RegisterCounter(ProjectionFailedEvents, ProjectionFailedEventsDescription)
+		h.log().OnError(err).Warn("unable to register projection metric")
+		err = metrics.RegisterHistogram(ProjectionReduceDuration, ProjectionReduceDurationDescription, projectionMetricsDurationUnit, durationBuckets)
+		h.log().OnError(err).Warn("unable to register projection metric")
+		err = metrics.RegisterHistogram(ProjectionStatementDuration, ProjectionStatementDurationDescription, projectionMetricsDurationUnit, durationBuckets)
+		h.log().OnError(err).Warn("unable to register projection metric")
+		err = metrics.RegisterValueObserver(ProjectionPosition, ProjectionPositionDescription, observeProgress(func(_ time.Time, p *progress) int64 {
+			return int64(p.position)
+		}))
+		h.log().OnError(err).Warn("unable to register projection metric")
+		err = metrics.RegisterValueObserver(ProjectionLag, ProjectionLagDescription, observeProgress(func(now time.Time, p *progress) int64 {
+			return p.lag(now).Milliseconds()
+		}))
+		h.log().OnError(err).Warn("unable to register projection metric")
+	})
+	observedHandlers.Store(h, struct{}{})
+}
+
+// observeProgress reports the value of the progress of each instance of all handlers
+func observeProgress(value func(now time.Time, p *progress) int64) metric.Int64Callback {
+	return func(_ context.Context, observer metric.Int64Observer) error {
+		now := time.Now()
+		observedHandlers.Range(func(key, _ any) bool {
+			h := key.(*Handler)
+			h.progress.Range(func(instanceID, p any) bool {
+				observer.Observe(value(now, p.(*progress)), metric.WithAttributes(
+					attribute.String(projectionMetricsLabelProjection, h.projection.Name()),
+					attribute.String(projectionMetricsLabelInstance, instanceID.(string)),
+				))
+				return true
+			})
+			return true
+		})
+		return nil
+	}
+}
+
+// Lag returns the age of the oldest event the projection didn't process of the instance lagging the most
+func (h *Handler) Lag() (lag time.Duration) {
+	now := time.Now()
+	h.progress.Range(func(_, p any) bool {
+		if instanceLag := p.(*progress).lag(now); instanceLag > lag {
+			lag = instanceLag
+		}
+		return true
+	})
+	return lag
+}
+
+// caughtUp records that the projection processed all events of the instance
+func (h *Handler) caughtUp(currentState *state) {
+	h.progress.Store(currentState.instanceID, &progress{position: currentState.position})
+}
+
+// updateProgress records the oldest event of the instance after the position,
+// the lag is computed against the events of the instance and not the outcome of the trigger.
+func (h *Handler) updateProgress(ctx context.Context, instanceID string, position float64) {
+	if h.triggerWithoutEvents != nil {
+		return
+	}
+	p := &progress{position: position}
+	err := h.es.FilterStream(ctx, h.pendingEventQuery(instanceID, position), func(event eventstore.Event) error {
+		p.pendingSince = event.CreatedAt()
+		return eventstore.ErrStopFilter
+	})
+	if err != nil {
+		h.log().WithField("instance", instanceID).WithError(err).Debug("unable to query pending events")
+		return
+	}
+	h.progress.Store(instanceID, p)
+}
+
+// updateLockedProgress records the progress of an instance whose state is locked by another process,
+// the position is read without waiting for the lock
+func (h *Handler) updateLockedProgress(ctx context.Context, instanceID string) {
+	var position sql.NullFloat64
+	err := h.client.QueryRowContext(ctx, func(row *sql.Row) error {
+		return row.Scan(&position)
+	}, statePositionStmt, instanceID, h.projection.Name())
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		h.log().WithField("instance", instanceID).WithError(err).Debug("unable to query position")
+		return
+	}
+	h.updateProgress(ctx, instanceID, position.Float64)
+}
+
+// pendingEventQuery returns the oldest event of the instance after the position the projection reduces
+func (h *Handler) pendingEventQuery(instanceID string, position float64) *eventstore.SearchQueryBuilder {
+	builder := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		Limit(1).
+		OrderAsc().
+		InstanceID(instanceID).
+		PositionAfter(position)
+	for aggregateType, eventTypes := range h.eventTypes {
+		builder = builder.
+			AddQuery().
+			AggregateTypes(aggregateType).
+			EventTypes(eventTypes...).
+			Builder()
+	}
+	return builder
+}
+
+func (h *Handler) metricLabels(instanceID string) map[string]attribute.Value {
+	return map[string]attribute.Value{
+		projectionMetricsLabelProjection: attribute.StringValue(h.projection.Name()),
+		projectionMetricsLabelInstance:   attribute.StringValue(instanceID),
+	}
+}
+
+func (h *Handler) countProcessedEvents(ctx context.Context, instanceID string, count int) {
+	err := metrics.AddCount(ctx, ProjectionEventsProcessed, int64(count), h.metricLabels(instanceID))
+	h.log().OnError(err).Debug("unable to count processed events")
+}
+
+func (h *Handler) countLockContention(ctx context.Context, instanceID string) {
+	err := metrics.AddCount(ctx, ProjectionLockContention, 1, h.metricLabels(instanceID))
+	h.log().OnError(err).Debug("unable to count lock contention")
+}
+
+func (h *Handler) countFailedEvent(ctx context.Context, instanceID string, skipped bool) {
+	labels := h.metricLabels(instanceID)
+	labels[projectionMetricsLabelSkipped] = attribute.BoolValue(skipped)
+	err := metrics.AddCount(ctx, ProjectionFailedEvents, 1, labels)
+	h.log().OnError(err).Debug("unable to count failed event")
+}
+
+func (h *Handler) recordDuration(ctx context.Context, name string, start time.Time) {
+	err := metrics.RecordHistogram(ctx, name, time.Since(start).Seconds(), map[string]attribute.Value{
+		projectionMetricsLabelProjection: attribute.StringValue(h.projection.Name()),
+	})
+	h.log().OnError(err).Debug("unable to record duration")
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/database/mock"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+func Test_progress_lag(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name     string
+		progress *progress
+		want     time.Duration
+	}{
+		{
+			name:     "caught up",
+			progress: &progress{position: 42},
+			want:     0,
+		},
+		{
+			name: "behind",
+			progress: &progress{
+				position:     42,
+				pendingSince: now.Add(-time.Minute),
+			},
+			want: time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.progress.lag(now); got != tt.want {
+				t.Errorf("lag() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// pendingEventStore returns the pending event of the instance if the position of the query is before the event
+type pendingEventStore struct {
+	EventStore
+	position  float64
+	createdAt time.Time
+}
+
+func (es *pendingEventStore) FilterStream(_ context.Context, query *eventstore.SearchQueryBuilder, reduce eventstore.Reducer) error {
+	if query.GetPositionAfter() >= es.position {
+		return nil
+	}
+	err := reduce(&eventstore.BaseEvent{Creation: es.createdAt})
+	if errors.Is(err, eventstore.ErrStopFilter) {
+		return nil
+	}
+	return err
+}
+
+func TestHandler_Lag(t *testing.T) {
+	pendingSince := time.Now().Add(-time.Hour)
+	h := &Handler{
+		projection: &projection{name: "projection"},
+		es:         &pendingEventStore{position: 43, createdAt: pendingSince},
+	}
+	if lag := h.Lag(); lag != 0 {
+		t.Errorf("unexpected lag without progress: %v", lag)
+	}
+
+	h.caughtUp(&state{instanceID: "instance1", position: 43})
+	h.updateProgress(context.Background(), "instance2", 42)
+	if lag := h.Lag(); lag < time.Hour {
+		t.Errorf("expected lag of the oldest pending event, got: %v", lag)
+	}
+
+	h.updateProgress(context.Background(), "instance2", 43)
+	if lag := h.Lag(); lag != 0 {
+		t.Errorf("expected no lag after the instance caught up, got: %v", lag)
+	}
+}
+
+func TestHandler_updateLockedProgress(t *testing.T) {
+	pendingSince := time.Now().Add(-time.Hour)
+	client := mock.NewSQLMock(t,
+		mock.ExpectBegin(nil),
+		mock.ExpectQuery(statePositionStmt,
+			mock.WithQueryArgs("instance", "projection"),
+			mock.WithQueryResult([]string{"position"}, [][]driver.Value{{float64(42)}}),
+		),
+		mock.ExpectCommit(nil),
+	)
+	h := &Handler{
+		client:     &database.DB{DB: client.DB},
+		projection: &projection{name: "projection"},
+		es:         &pendingEventStore{position: 43, createdAt: pendingSince},
+	}
+
+	// the state is locked by another process which didn't process the pending event yet
+	h.updateLockedProgress(context.Background(), "instance")
+	if lag := h.Lag(); lag < time.Hour {
+		t.Errorf("expected lag of the oldest pending event, got: %v", lag)
+	}
+	client.Assert(t)
+}
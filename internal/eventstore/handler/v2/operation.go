@@ -0,0 +1,189 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+// Operation is a manual intervention on a projection,
+// each operation is recorded in projections.projection_operations
+type Operation string
+
+const (
+	OperationPause           Operation = "pause"
+	OperationResume          Operation = "resume"
+	OperationSkipFailedEvent Operation = "skip_failed_event"
+	OperationRebuild         Operation = "rebuild"
+)
+
+// pausedInstancesCacheDuration is the duration the paused instances of the projection are cached,
+// pausing and resuming takes effect on the other nodes within this duration
+const pausedInstancesCacheDuration = 10 * time.Second
+
+var (
+	//go:embed operation_log.sql
+	logOperationStmt string
+	//go:embed pause_list.sql
+	pausedInstancesStmt string
+	//go:embed pause_set.sql
+	pauseStmt string
+	//go:embed pause_remove.sql
+	resumeStmt string
+	//go:embed failed_event_skip.sql
+	skipFailedEventStmt string
+)
+
+// Pause stops the processing of events for the instance until the projection is resumed.
+// An empty instanceID pauses the projection for all instances.
+func (h *Handler) Pause(ctx context.Context, instanceID string) error {
+	defer h.paused.invalidate()
+	return h.operate(ctx, instanceID, OperationPause, "", func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, pauseStmt, h.projection.Name(), instanceID); err != nil {
+			return errs.ThrowInternal(err, "V2-Ahp3u", "unable to pause projection")
+		}
+		return nil
+	})
+}
+
+// Resume removes the pause of the instance.
+// A projection paused for all instances must be resumed with an empty instanceID.
+func (h *Handler) Resume(ctx context.Context, instanceID string) error {
+	defer h.paused.invalidate()
+	return h.operate(ctx, instanceID, OperationResume, "", func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, resumeStmt, h.projection.Name(), instanceID); err != nil {
+			return errs.ThrowInternal(err, "V2-oo9Ra", "unable to resume projection")
+		}
+		return nil
+	})
+}
+
+// SkipFailedEvent marks the failed event as exceeded the max failure count,
+// so the event is skipped on the next processing of the projection
+func (h *Handler) SkipFailedEvent(ctx context.Context, instanceID string, aggregateType eventstore.AggregateType, aggregateID string, sequence uint64) error {
+	details := fmt.Sprintf("%s/%s/%d", aggregateType, aggregateID, sequence)
+	return h.operate(ctx, instanceID, OperationSkipFailedEvent, details, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, skipFailedEventStmt,
+			h.projection.Name(),
+			instanceID,
+			aggregateType,
+			aggregateID,
+			sequence,
+			h.maxFailureCount,
+		)
+		if err != nil {
+			return errs.ThrowInternal(err, "V2-ieG0e", "unable to skip failed event")
+		}
+		if affected, err := res.RowsAffected(); affected == 0 || err != nil {
+			return errs.ThrowNotFound(err, "V2-Ohb6u", "failed event not found")
+		}
+		return nil
+	})
+}
+
+// pausedInstances caches the paused instances of the projection,
+// so the pauses are not queried on every trigger
+type pausedInstances struct {
+	mu sync.RWMutex
+	// instances contains the empty string if the projection is paused for all instances
+	instances map[string]bool
+	expiresAt time.Time
+}
+
+func (p *pausedInstances) invalidate() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.expiresAt = time.Time{}
+}
+
+// isPaused checks if the projection is paused for the instance or for all instances.
+// The database is only queried if the cached pauses expired.
+func (h *Handler) isPaused(ctx context.Context, tx *sql.Tx, instanceID string) (bool, error) {
+	h.paused.mu.RLock()
+	instances, expiresAt := h.paused.instances, h.paused.expiresAt
+	h.paused.mu.RUnlock()
+	if h.now().Before(expiresAt) {
+		return instances[""] || instances[instanceID], nil
+	}
+
+	instances, err := h.queryPausedInstances(ctx, tx)
+	if err != nil {
+		return false, err
+	}
+	h.paused.mu.Lock()
+	h.paused.instances, h.paused.expiresAt = instances, h.now().Add(pausedInstancesCacheDuration)
+	h.paused.mu.Unlock()
+	return instances[""] || instances[instanceID], nil
+}
+
+func (h *Handler) queryPausedInstances(ctx context.Context, tx *sql.Tx) (_ map[string]bool, err error) {
+	rows, err := tx.QueryContext(ctx, pausedInstancesStmt, h.projection.Name())
+	if err != nil {
+		return nil, errs.ThrowInternal(err, "V2-Ieph7", "unable to check if projection is paused")
+	}
+	defer func() {
+		closeErr := rows.Close()
+		if err == nil && closeErr != nil {
+			err = errs.ThrowInternal(closeErr, "V2-aiT4o", "unable to check if projection is paused")
+		}
+	}()
+	instances := make(map[string]bool)
+	for rows.Next() {
+		var instanceID string
+		if err = rows.Scan(&instanceID); err != nil {
+			return nil, errs.ThrowInternal(err, "V2-ahX7e", "unable to check if projection is paused")
+		}
+		instances[instanceID] = true
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errs.ThrowInternal(err, "V2-Ohm6a", "unable to check if projection is paused")
+	}
+	return instances, nil
+}
+
+// operate executes the operation and records it in the same transaction
+func (h *Handler) operate(ctx context.Context, instanceID string, operation Operation, details string, execute func(tx *sql.Tx) error) (err error) {
+	tx, err := h.client.BeginTx(ctx, nil)
+	if err != nil {
+		return errs.ThrowInternal(err, "V2-ua4Ei", "begin failed")
+	}
+	defer func() {
+		if err != nil {
+			rollbackErr := tx.Rollback()
+			h.log().OnError(rollbackErr).Debug("unable to rollback tx")
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	if err = execute(tx); err != nil {
+		return err
+	}
+	return h.logOperation(ctx, tx, instanceID, operation, details)
+}
+
+func (h *Handler) logOperation(ctx context.Context, tx *sql.Tx, instanceID string, operation Operation, details string) error {
+	editor := authz.GetCtxData(ctx).UserID
+	_, err := tx.ExecContext(ctx, logOperationStmt,
+		h.projection.Name(),
+		instanceID,
+		operation,
+		details,
+		editor,
+	)
+	if err != nil {
+		return errs.ThrowInternal(err, "V2-Iex5a", "unable to log operation")
+	}
+	h.log().WithField("instance", instanceID).
+		WithField("operation", operation).
+		WithField("editor", editor).
+		Info("projection operation executed")
+	return nil
+}
@@ -0,0 +1,15 @@
+INSERT INTO projections.projection_operations (
+    projection_name
+    , instance_id
+    , operation
+    , details
+    , editor
+    , created_at
+) VALUES (
+    $1
+    , $2
+    , $3
+    , $4
+    , $5
+    , now()
+);
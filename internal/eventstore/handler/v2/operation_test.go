@@ -0,0 +1,253 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/database/mock"
+	errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+func TestHandler_Pause(t *testing.T) {
+	tests := []struct {
+		name       string
+		instanceID string
+		mock       *mock.SQLMock
+		isErr      func(t *testing.T, err error)
+	}{
+		{
+			name:       "pause fails",
+			instanceID: "instance",
+			mock: mock.NewSQLMock(t,
+				mock.ExpectBegin(nil),
+				mock.ExcpectExec(pauseStmt,
+					mock.WithExecArgs("projection", "instance"),
+					mock.WithExecErr(sql.ErrTxDone),
+				),
+				mock.ExpectRollback(nil),
+			),
+			isErr: func(t *testing.T, err error) {
+				if !errors.Is(err, errs.ThrowInternal(nil, "V2-Ahp3u", "")) {
+					t.Errorf("unexpected error, want internal (V2-Ahp3u), got: %v", err)
+				}
+			},
+		},
+		{
+			name:       "paused and logged",
+			instanceID: "instance",
+			mock: mock.NewSQLMock(t,
+				mock.ExpectBegin(nil),
+				mock.ExcpectExec(pauseStmt,
+					mock.WithExecArgs("projection", "instance"),
+					mock.WithExecRowsAffected(1),
+				),
+				mock.ExcpectExec(logOperationStmt,
+					mock.WithExecArgs("projection", "instance", "pause", "", "editor"),
+					mock.WithExecRowsAffected(1),
+				),
+				mock.ExpectCommit(nil),
+			),
+		},
+		{
+			name:       "all instances paused and logged",
+			instanceID: "",
+			mock: mock.NewSQLMock(t,
+				mock.ExpectBegin(nil),
+				mock.ExcpectExec(pauseStmt,
+					mock.WithExecArgs("projection", ""),
+					mock.WithExecRowsAffected(1),
+				),
+				mock.ExcpectExec(logOperationStmt,
+					mock.WithExecArgs("projection", "", "pause", "", "editor"),
+					mock.WithExecRowsAffected(1),
+				),
+				mock.ExpectCommit(nil),
+			),
+		},
+	}
+	for _, tt := range tests {
+		if tt.isErr == nil {
+			tt.isErr = func(t *testing.T, err error) {
+				if err != nil {
+					t.Error("expected no error got:", err)
+				}
+			}
+		}
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{
+				client:     &database.DB{DB: tt.mock.DB},
+				projection: &projection{name: "projection"},
+			}
+			ctx := authz.SetCtxData(context.Background(), authz.CtxData{UserID: "editor"})
+
+			err := h.Pause(ctx, tt.instanceID)
+			tt.isErr(t, err)
+
+			tt.mock.Assert(t)
+		})
+	}
+}
+
+func TestHandler_SkipFailedEvent(t *testing.T) {
+	tests := []struct {
+		name  string
+		mock  *mock.SQLMock
+		isErr func(t *testing.T, err error)
+	}{
+		{
+			name: "failed event not found",
+			mock: mock.NewSQLMock(t,
+				mock.ExpectBegin(nil),
+				mock.ExcpectExec(skipFailedEventStmt,
+					mock.WithExecArgs("projection", "instance", "aggregate", "id", uint64(42), uint64(5)),
+					mock.WithExecNoRowsAffected(),
+				),
+				mock.ExpectRollback(nil),
+			),
+			isErr: func(t *testing.T, err error) {
+				if !errs.IsNotFound(err) {
+					t.Errorf("unexpected error, want not found, got: %v", err)
+				}
+			},
+		},
+		{
+			name: "skipped and logged",
+			mock: mock.NewSQLMock(t,
+				mock.ExpectBegin(nil),
+				mock.ExcpectExec(skipFailedEventStmt,
+					mock.WithExecArgs("projection", "instance", "aggregate", "id", uint64(42), uint64(5)),
+					mock.WithExecRowsAffected(1),
+				),
+				mock.ExcpectExec(logOperationStmt,
+					mock.WithExecArgs("projection", "instance", "skip_failed_event", "aggregate/id/42", "editor"),
+					mock.WithExecRowsAffected(1),
+				),
+				mock.ExpectCommit(nil),
+			),
+		},
+	}
+	for _, tt := range tests {
+		if tt.isErr == nil {
+			tt.isErr = func(t *testing.T, err error) {
+				if err != nil {
+					t.Error("expected no error got:", err)
+				}
+			}
+		}
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{
+				client:          &database.DB{DB: tt.mock.DB},
+				projection:      &projection{name: "projection"},
+				maxFailureCount: 5,
+			}
+			ctx := authz.SetCtxData(context.Background(), authz.CtxData{UserID: "editor"})
+
+			err := h.SkipFailedEvent(ctx, "instance", "aggregate", "id", 42)
+			tt.isErr(t, err)
+
+			tt.mock.Assert(t)
+		})
+	}
+}
+
+func TestHandler_isPaused(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name   string
+		mock   *mock.SQLMock
+		cached *pausedInstances
+		paused bool
+	}{
+		{
+			name: "not paused",
+			mock: mock.NewSQLMock(t,
+				mock.ExpectBegin(nil),
+				mock.ExpectQuery(pausedInstancesStmt,
+					mock.WithQueryArgs("projection"),
+					mock.WithQueryResult([]string{"instance_id"}, [][]driver.Value{{"other"}}),
+				),
+			),
+		},
+		{
+			name: "paused",
+			mock: mock.NewSQLMock(t,
+				mock.ExpectBegin(nil),
+				mock.ExpectQuery(pausedInstancesStmt,
+					mock.WithQueryArgs("projection"),
+					mock.WithQueryResult([]string{"instance_id"}, [][]driver.Value{{"instance"}}),
+				),
+			),
+			paused: true,
+		},
+		{
+			name: "paused for all instances",
+			mock: mock.NewSQLMock(t,
+				mock.ExpectBegin(nil),
+				mock.ExpectQuery(pausedInstancesStmt,
+					mock.WithQueryArgs("projection"),
+					mock.WithQueryResult([]string{"instance_id"}, [][]driver.Value{{""}}),
+				),
+			),
+			paused: true,
+		},
+		{
+			name: "cached",
+			mock: mock.NewSQLMock(t,
+				mock.ExpectBegin(nil),
+			),
+			cached: &pausedInstances{
+				instances: map[string]bool{"instance": true},
+				expiresAt: now.Add(time.Second),
+			},
+			paused: true,
+		},
+		{
+			name: "cache expired",
+			mock: mock.NewSQLMock(t,
+				mock.ExpectBegin(nil),
+				mock.ExpectQuery(pausedInstancesStmt,
+					mock.WithQueryArgs("projection"),
+					mock.WithQueryResult([]string{"instance_id"}, nil),
+				),
+			),
+			cached: &pausedInstances{
+				instances: map[string]bool{"instance": true},
+				expiresAt: now,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{
+				projection: &projection{name: "projection"},
+				now:        func() time.Time { return now },
+			}
+			if tt.cached != nil {
+				h.paused.instances, h.paused.expiresAt = tt.cached.instances, tt.cached.expiresAt
+			}
+			tx, err := tt.mock.DB.Begin()
+			if err != nil {
+				t.Fatalf("unable to begin transaction: %v", err)
+			}
+
+			paused, err := h.isPaused(context.Background(), tx, "instance")
+			if err != nil {
+				t.Fatal("expected no error got:", err)
+			}
+			if paused != tt.paused {
+				t.Errorf("unexpected paused, want: %v got: %v", tt.paused, paused)
+			}
+			if !h.paused.expiresAt.After(now) {
+				t.Error("paused instances must be cached")
+			}
+
+			tt.mock.Assert(t)
+		})
+	}
+}
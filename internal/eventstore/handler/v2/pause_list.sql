@@ -0,0 +1,6 @@
+SELECT
+    instance_id
+FROM
+    projections.paused_projections
+WHERE
+    projection_name = $1;
@@ -0,0 +1,5 @@
+DELETE FROM 
+    projections.paused_projections
+WHERE 
+    projection_name = $1
+    AND instance_id = $2;
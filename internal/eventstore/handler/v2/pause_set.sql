@@ -0,0 +1,9 @@
+INSERT INTO projections.paused_projections (
+    projection_name
+    , instance_id
+    , paused_at
+) VALUES (
+    $1
+    , $2
+    , now()
+) ON CONFLICT DO NOTHING;
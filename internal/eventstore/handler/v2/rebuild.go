@@ -0,0 +1,380 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"strings"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+)
+
+const (
+	// rebuildPrefix is prepended to the table name of the projection for the shadow tables of a rebuild
+	rebuildPrefix = "rebuild_"
+	// rebuildHeartbeat is the interval a running rebuild is marked alive
+	rebuildHeartbeat = 30 * time.Second
+	// rebuildStaleAfter is the duration without heartbeat after which a rebuild can be started again,
+	// e.g. if the process of the running rebuild was stopped
+	rebuildStaleAfter = 4 * rebuildHeartbeat
+)
+
+// RebuildState is the state of the last rebuild of a projection
+type RebuildState string
+
+const (
+	RebuildStateRunning RebuildState = "running"
+	RebuildStateDone    RebuildState = "done"
+	RebuildStateFailed  RebuildState = "failed"
+)
+
+var (
+	//go:embed rebuild_tables.sql
+	rebuildTablesStmt string
+	//go:embed rebuild_columns.sql
+	rebuildColumnsStmt string
+	//go:embed rebuild_state.sql
+	rebuildStateStmt string
+	//go:embed rebuild_failed_events_move.sql
+	rebuildMoveFailedEventsStmt string
+	//go:embed rebuild_failed_events_remove.sql
+	rebuildRemoveFailedEventsStmt string
+	//go:embed rebuild_states_remove.sql
+	rebuildRemoveStatesStmt string
+	//go:embed rebuild_lock.sql
+	rebuildLockStmt string
+	//go:embed rebuild_heartbeat.sql
+	rebuildHeartbeatStmt string
+	//go:embed rebuild_finish.sql
+	rebuildFinishStmt string
+)
+
+// shadowProjection reduces the events of the projection into the shadow tables
+type shadowProjection struct {
+	Projection
+	name string
+}
+
+// Name implements [Projection]
+func (p *shadowProjection) Name() string {
+	return p.name
+}
+
+// Init implements [initializer]
+func (p *shadowProjection) Init() *handler.Check {
+	return p.Projection.(initializer).Init()
+}
+
+func rebuildName(name string) string {
+	idx := strings.LastIndex(name, ".")
+	return name[:idx+1] + rebuildPrefix + name[idx+1:]
+}
+
+// Rebuild reduces all events of the instances into shadow tables of the projection.
+// Afterwards the rows of each instance are replaced with the rows of the shadow tables in a single transaction,
+// so the projection stays available with its previous state during the rebuild.
+// The projection is rebuilt for all instances if no instanceIDs are passed.
+// It fails if another rebuild of the projection is running, the state of the rebuild is returned by [Handler.Status].
+func (h *Handler) Rebuild(ctx context.Context, instanceIDs ...string) (err error) {
+	finish, err := h.lockRebuild(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { finish(err) }()
+	return h.rebuild(ctx, instanceIDs...)
+}
+
+// StartRebuild locks the rebuild like [Handler.Rebuild] and rebuilds the projection in the background,
+// as the rebuild takes longer than a request.
+func (h *Handler) StartRebuild(ctx context.Context, instanceIDs ...string) error {
+	finish, err := h.lockRebuild(ctx)
+	if err != nil {
+		return err
+	}
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		err := h.rebuild(ctx, instanceIDs...)
+		h.log().OnError(err).Error("rebuild of projection failed")
+		finish(err)
+	}()
+	return nil
+}
+
+// lockRebuild marks the rebuild of the projection as running until finish is called,
+// so the shadow tables aren't dropped by a concurrent rebuild of another process.
+func (h *Handler) lockRebuild(ctx context.Context) (finish func(error), err error) {
+	if _, ok := h.projection.(initializer); !ok || h.triggerWithoutEvents != nil {
+		return nil, errs.ThrowPreconditionFailed(nil, "V2-Ceev4", "projection cannot be rebuilt")
+	}
+	res, err := h.client.ExecContext(ctx, rebuildLockStmt, h.projection.Name(), RebuildStateRunning, h.now().Add(-rebuildStaleAfter))
+	if err != nil {
+		return nil, errs.ThrowInternal(err, "V2-ooK4a", "unable to lock rebuild")
+	}
+	if affected, err := res.RowsAffected(); affected == 0 || err != nil {
+		return nil, errs.ThrowPreconditionFailed(err, "V2-eeW8o", "rebuild of projection is already running")
+	}
+
+	// the state is recorded even if the context of the caller is done
+	ctx = context.WithoutCancel(ctx)
+	stop := make(chan struct{})
+	go h.rebuildHeartbeat(ctx, stop)
+	return func(rebuildErr error) {
+		close(stop)
+		state, message := RebuildStateDone, sql.NullString{}
+		if rebuildErr != nil {
+			state, message = RebuildStateFailed, sql.NullString{String: rebuildErr.Error(), Valid: true}
+		}
+		_, err := h.client.ExecContext(ctx, rebuildFinishStmt, h.projection.Name(), state, message)
+		h.log().OnError(err).Warn("unable to set state of rebuild")
+	}, nil
+}
+
+func (h *Handler) rebuildHeartbeat(ctx context.Context, stop <-chan struct{}) {
+	ticker := time.NewTicker(rebuildHeartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			_, err := h.client.ExecContext(ctx, rebuildHeartbeatStmt, h.projection.Name(), RebuildStateRunning)
+			h.log().OnError(err).Warn("unable to update heartbeat of rebuild")
+		}
+	}
+}
+
+func (h *Handler) rebuild(ctx context.Context, instanceIDs ...string) (err error) {
+	shadow := h.shadow()
+
+	// the shadow tables of interrupted rebuilds are removed before
+	if err = shadow.dropShadow(ctx); err != nil {
+		return err
+	}
+	defer func() {
+		dropErr := shadow.dropShadow(ctx)
+		h.log().OnError(dropErr).Warn("unable to drop shadow tables of rebuild")
+	}()
+	if err = shadow.Init(ctx); err != nil {
+		return err
+	}
+
+	if len(instanceIDs) == 0 {
+		instanceIDs, err = h.queryInstances(ctx, false)
+		if err != nil {
+			return err
+		}
+	}
+	for _, instanceID := range instanceIDs {
+		instanceCtx := authz.WithInstanceID(ctx, instanceID)
+		if err = shadow.triggerRebuild(instanceCtx); err != nil {
+			return err
+		}
+		if err = h.swap(instanceCtx, shadow); err != nil {
+			return err
+		}
+		h.log().WithField("instance", instanceID).Info("projection rebuilt")
+	}
+	return nil
+}
+
+// shadow returns a handler with the same configuration reducing into the shadow tables
+func (h *Handler) shadow() *Handler {
+	return &Handler{
+		client: h.client,
+		projection: &shadowProjection{
+			Projection: h.projection,
+			name:       rebuildName(h.projection.Name()),
+		},
+		es:                    h.es,
+		bulkLimit:             h.bulkLimit,
+		eventTypes:            h.eventTypes,
+		maxFailureCount:       h.maxFailureCount,
+		retryFailedAfter:      h.retryFailedAfter,
+		requeueEvery:          h.requeueEvery,
+		handleActiveInstances: h.handleActiveInstances,
+		txDuration:            h.txDuration,
+		now:                   h.now,
+	}
+}
+
+// triggerRebuild reduces all events of the instance.
+// Failed statements are retried until they exceed the max failure count.
+func (h *Handler) triggerRebuild(ctx context.Context) (err error) {
+	for i := 0; i <= int(h.maxFailureCount); i++ {
+		if _, err = h.Trigger(ctx, WithAwaitRunning()); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// swap replaces the rows of the instance with the rows of the shadow tables
+// and continues the projection at the state of the shadow
+func (h *Handler) swap(ctx context.Context, shadow *Handler) (err error) {
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	tx, err := h.client.BeginTx(ctx, nil)
+	if err != nil {
+		return errs.ThrowInternal(err, "V2-Aet5i", "begin failed")
+	}
+	defer func() {
+		if err != nil {
+			rollbackErr := tx.Rollback()
+			h.log().OnError(rollbackErr).Debug("unable to rollback tx")
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	// the lock of the state prevents the projection from reducing events during the swap
+	if _, err = h.currentState(ctx, tx, &triggerConfig{awaitRunning: true}); err != nil {
+		return err
+	}
+
+	tables, err := shadow.shadowTables(ctx, tx, "BASE TABLE")
+	if err != nil {
+		return err
+	}
+	// suffixed tables reference the primary table, so they are cleared first
+	for i := len(tables) - 1; i >= 0; i-- {
+		if _, err = tx.ExecContext(ctx, "DELETE FROM "+h.originalTable(tables[i])+" WHERE instance_id = $1", instanceID); err != nil {
+			return errs.ThrowInternal(err, "V2-Zeu3o", "unable to clear projection")
+		}
+	}
+	for _, table := range tables {
+		if err = h.copyShadowTable(ctx, tx, table, instanceID); err != nil {
+			return err
+		}
+	}
+
+	if _, err = tx.ExecContext(ctx, rebuildStateStmt, h.projection.Name(), shadow.projection.Name(), instanceID); err != nil {
+		return errs.ThrowInternal(err, "V2-ib7Ae", "unable to set state")
+	}
+	if _, err = tx.ExecContext(ctx, rebuildRemoveFailedEventsStmt, h.projection.Name(), instanceID); err != nil {
+		return errs.ThrowInternal(err, "V2-Ohs4e", "unable to remove failed events")
+	}
+	if _, err = tx.ExecContext(ctx, rebuildMoveFailedEventsStmt, h.projection.Name(), shadow.projection.Name(), instanceID); err != nil {
+		return errs.ThrowInternal(err, "V2-Ju1ee", "unable to move failed events")
+	}
+	return h.logOperation(ctx, tx, instanceID, OperationRebuild, "")
+}
+
+// originalTable returns the table of the projection the shadow table replaces
+func (h *Handler) originalTable(shadowTable string) string {
+	schema, _ := splitTableName(h.projection.Name())
+	return schema + "." + strings.TrimPrefix(shadowTable, rebuildPrefix)
+}
+
+func (h *Handler) copyShadowTable(ctx context.Context, tx *sql.Tx, shadowTable, instanceID string) error {
+	schema, _ := splitTableName(h.projection.Name())
+	rows, err := tx.QueryContext(ctx, rebuildColumnsStmt, schema, shadowTable)
+	if err != nil {
+		return errs.ThrowInternal(err, "V2-Ohph8", "unable to query columns")
+	}
+	var (
+		columns          []string
+		hasInstanceIDCol bool
+	)
+	for rows.Next() {
+		var column string
+		if err = rows.Scan(&column); err != nil {
+			rows.Close()
+			return errs.ThrowInternal(err, "V2-ooC8i", "unable to scan columns")
+		}
+		hasInstanceIDCol = hasInstanceIDCol || column == "instance_id"
+		columns = append(columns, `"`+column+`"`)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return errs.ThrowInternal(err, "V2-ooC8i", "unable to scan columns")
+	}
+	if !hasInstanceIDCol {
+		return errs.ThrowPreconditionFailed(nil, "V2-Eiz1a", "projection cannot be rebuilt, instance_id column missing")
+	}
+
+	stmt := "INSERT INTO " + h.originalTable(shadowTable) + " (" + strings.Join(columns, ", ") + ") SELECT " +
+		strings.Join(columns, ", ") + " FROM " + schema + "." + shadowTable + " WHERE instance_id = $1"
+	if _, err = tx.ExecContext(ctx, stmt, instanceID); err != nil {
+		return errs.ThrowInternal(err, "V2-Kai8o", "unable to copy shadow table")
+	}
+	return nil
+}
+
+// dropShadow removes the tables, states and failed events of the shadow handler
+func (h *Handler) dropShadow(ctx context.Context) (err error) {
+	tx, err := h.client.BeginTx(ctx, nil)
+	if err != nil {
+		return errs.ThrowInternal(err, "V2-ew8Oh", "begin failed")
+	}
+	defer func() {
+		if err != nil {
+			rollbackErr := tx.Rollback()
+			h.log().OnError(rollbackErr).Debug("unable to rollback tx")
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	schema, _ := splitTableName(h.projection.Name())
+	views, err := h.shadowTables(ctx, tx, "VIEW")
+	if err != nil {
+		return err
+	}
+	for _, view := range views {
+		if _, err = tx.ExecContext(ctx, "DROP VIEW IF EXISTS "+schema+"."+view); err != nil {
+			return errs.ThrowInternal(err, "V2-Ahb4u", "unable to drop view")
+		}
+	}
+	tables, err := h.shadowTables(ctx, tx, "BASE TABLE")
+	if err != nil {
+		return err
+	}
+	for i := len(tables) - 1; i >= 0; i-- {
+		if _, err = tx.ExecContext(ctx, "DROP TABLE IF EXISTS "+schema+"."+tables[i]+" CASCADE"); err != nil {
+			return errs.ThrowInternal(err, "V2-ohX2e", "unable to drop table")
+		}
+	}
+
+	if _, err = tx.ExecContext(ctx, rebuildRemoveStatesStmt, h.projection.Name()); err != nil {
+		return errs.ThrowInternal(err, "V2-Mai6e", "unable to remove states")
+	}
+	if _, err = tx.ExecContext(ctx, rebuildRemoveFailedEventsStmt, h.projection.Name(), ""); err != nil {
+		return errs.ThrowInternal(err, "V2-Ohs4e", "unable to remove failed events")
+	}
+	return nil
+}
+
+// shadowTables returns the names of the primary and suffixed tables of the projection ordered by name,
+// the primary table is always the first
+func (h *Handler) shadowTables(ctx context.Context, tx *sql.Tx, tableType string) (tables []string, err error) {
+	schema, name := splitTableName(h.projection.Name())
+	suffixed := strings.ReplaceAll(name, "_", `\_`) + `\_%`
+	rows, err := tx.QueryContext(ctx, rebuildTablesStmt, schema, name, suffixed)
+	if err != nil {
+		return nil, errs.ThrowInternal(err, "V2-Eeh3o", "unable to query tables")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var table, typ string
+		if err = rows.Scan(&table, &typ); err != nil {
+			return nil, errs.ThrowInternal(err, "V2-ahB3u", "unable to scan tables")
+		}
+		if typ == tableType {
+			tables = append(tables, table)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errs.ThrowInternal(err, "V2-ahB3u", "unable to scan tables")
+	}
+	return tables, nil
+}
+
+func splitTableName(name string) (schema, table string) {
+	idx := strings.LastIndex(name, ".")
+	if idx < 0 {
+		return "public", name
+	}
+	return name[:idx], name[idx+1:]
+}
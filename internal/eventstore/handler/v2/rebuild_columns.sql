@@ -0,0 +1,9 @@
+SELECT
+    column_name
+FROM
+    information_schema.columns
+WHERE
+    table_schema = $1
+    AND table_name = $2
+ORDER BY
+    ordinal_position;
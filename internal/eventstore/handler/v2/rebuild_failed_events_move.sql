@@ -0,0 +1,7 @@
+UPDATE
+    projections.failed_events2
+SET
+    projection_name = $1
+WHERE
+    projection_name = $2
+    AND instance_id = $3;
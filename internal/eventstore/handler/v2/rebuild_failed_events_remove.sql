@@ -0,0 +1,5 @@
+DELETE FROM
+    projections.failed_events2
+WHERE
+    projection_name = $1
+    AND ($2 = '' OR instance_id = $2);
@@ -0,0 +1,9 @@
+UPDATE
+    projections.projection_rebuilds
+SET
+    state = $2
+    , error = $3
+    , updated_at = now()
+    , finished_at = now()
+WHERE
+    projection_name = $1;
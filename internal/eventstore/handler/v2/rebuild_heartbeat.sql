@@ -0,0 +1,7 @@
+UPDATE
+    projections.projection_rebuilds
+SET
+    updated_at = now()
+WHERE
+    projection_name = $1
+    AND state = $2;
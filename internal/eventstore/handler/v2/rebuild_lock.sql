@@ -0,0 +1,27 @@
+INSERT INTO projections.projection_rebuilds (
+    projection_name
+    , state
+    , error
+    , started_at
+    , updated_at
+    , finished_at
+) VALUES (
+    $1
+    , $2
+    , NULL
+    , now()
+    , now()
+    , NULL
+) ON CONFLICT (
+    projection_name
+) DO UPDATE SET
+    state = EXCLUDED.state
+    , error = NULL
+    , started_at = EXCLUDED.started_at
+    , updated_at = EXCLUDED.updated_at
+    , finished_at = NULL
+WHERE
+    projections.projection_rebuilds.state <> EXCLUDED.state
+    -- the rebuild of a stopped process doesn't update its heartbeat anymore
+    OR projections.projection_rebuilds.updated_at < $3
+;
@@ -0,0 +1,37 @@
+INSERT INTO projections.current_states (
+    projection_name
+    , instance_id
+    , aggregate_id
+    , aggregate_type
+    , "sequence"
+    , event_date
+    , "position"
+    , last_updated
+    , filter_offset
+) SELECT
+    $1
+    , instance_id
+    , aggregate_id
+    , aggregate_type
+    , "sequence"
+    , event_date
+    , "position"
+    , now()
+    , filter_offset
+FROM
+    projections.current_states
+WHERE
+    projection_name = $2
+    AND instance_id = $3
+ON CONFLICT (
+    projection_name
+    , instance_id
+) DO UPDATE SET
+    aggregate_id = EXCLUDED.aggregate_id
+    , aggregate_type = EXCLUDED.aggregate_type
+    , "sequence" = EXCLUDED."sequence"
+    , event_date = EXCLUDED.event_date
+    , "position" = EXCLUDED."position"
+    , last_updated = EXCLUDED.last_updated
+    , filter_offset = EXCLUDED.filter_offset
+;
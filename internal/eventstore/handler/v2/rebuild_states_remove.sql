@@ -0,0 +1,4 @@
+DELETE FROM
+    projections.current_states
+WHERE
+    projection_name = $1;
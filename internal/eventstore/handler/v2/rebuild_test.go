@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/database/mock"
+	errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+)
+
+func Test_rebuildName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{
+			name: "projections.orgs1",
+			want: "projections.rebuild_orgs1",
+		},
+		{
+			name: "orgs1",
+			want: "rebuild_orgs1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rebuildName(tt.name); got != tt.want {
+				t.Errorf("rebuildName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandler_originalTable(t *testing.T) {
+	h := &Handler{
+		projection: &projection{name: "projections.login_names3"},
+	}
+	tests := []struct {
+		shadowTable string
+		want        string
+	}{
+		{
+			shadowTable: "rebuild_login_names3",
+			want:        "projections.login_names3",
+		},
+		{
+			shadowTable: "rebuild_login_names3_users",
+			want:        "projections.login_names3_users",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.shadowTable, func(t *testing.T) {
+			if got := h.originalTable(tt.shadowTable); got != tt.want {
+				t.Errorf("originalTable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandler_shadow(t *testing.T) {
+	h := &Handler{
+		projection:      &projection{name: "projections.orgs1"},
+		bulkLimit:       10,
+		maxFailureCount: 3,
+	}
+	shadow := h.shadow()
+	if name := shadow.projection.Name(); name != "projections.rebuild_orgs1" {
+		t.Errorf("unexpected name of shadow projection: %v", name)
+	}
+	if shadow.bulkLimit != h.bulkLimit || shadow.maxFailureCount != h.maxFailureCount {
+		t.Error("configuration of handler not applied to shadow")
+	}
+}
+
+type initProjection struct {
+	projection
+}
+
+// Init implements [initializer]
+func (p *initProjection) Init() *handler.Check {
+	return nil
+}
+
+func TestHandler_lockRebuild(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		projection Projection
+		mock       *mock.SQLMock
+		rebuildErr error
+		isErr      func(t *testing.T, err error)
+	}{
+		{
+			name:       "projection without tables",
+			projection: &projection{name: "projection"},
+			mock:       mock.NewSQLMock(t),
+			isErr: func(t *testing.T, err error) {
+				if !errs.IsPreconditionFailed(err) {
+					t.Errorf("unexpected error, want precondition failed, got: %v", err)
+				}
+			},
+		},
+		{
+			name:       "lock fails",
+			projection: &initProjection{projection{name: "projection"}},
+			mock: mock.NewSQLMock(t,
+				mock.ExcpectExec(rebuildLockStmt,
+					mock.WithExecArgs("projection", RebuildStateRunning, now.Add(-rebuildStaleAfter)),
+					mock.WithExecErr(sql.ErrConnDone),
+				),
+			),
+			isErr: func(t *testing.T, err error) {
+				if !errors.Is(err, errs.ThrowInternal(nil, "V2-ooK4a", "")) {
+					t.Errorf("unexpected error, want internal (V2-ooK4a), got: %v", err)
+				}
+			},
+		},
+		{
+			name:       "rebuild already running",
+			projection: &initProjection{projection{name: "projection"}},
+			mock: mock.NewSQLMock(t,
+				mock.ExcpectExec(rebuildLockStmt,
+					mock.WithExecArgs("projection", RebuildStateRunning, now.Add(-rebuildStaleAfter)),
+					mock.WithExecNoRowsAffected(),
+				),
+			),
+			isErr: func(t *testing.T, err error) {
+				if !errs.IsPreconditionFailed(err) {
+					t.Errorf("unexpected error, want precondition failed, got: %v", err)
+				}
+			},
+		},
+		{
+			name:       "rebuild done",
+			projection: &initProjection{projection{name: "projection"}},
+			mock: mock.NewSQLMock(t,
+				mock.ExcpectExec(rebuildLockStmt,
+					mock.WithExecArgs("projection", RebuildStateRunning, now.Add(-rebuildStaleAfter)),
+					mock.WithExecRowsAffected(1),
+				),
+				mock.ExcpectExec(rebuildFinishStmt,
+					mock.WithExecArgs("projection", RebuildStateDone, nil),
+					mock.WithExecRowsAffected(1),
+				),
+			),
+		},
+		{
+			name:       "rebuild failed",
+			projection: &initProjection{projection{name: "projection"}},
+			mock: mock.NewSQLMock(t,
+				mock.ExcpectExec(rebuildLockStmt,
+					mock.WithExecArgs("projection", RebuildStateRunning, now.Add(-rebuildStaleAfter)),
+					mock.WithExecRowsAffected(1),
+				),
+				mock.ExcpectExec(rebuildFinishStmt,
+					mock.WithExecArgs("projection", RebuildStateFailed, "rebuild failed"),
+					mock.WithExecRowsAffected(1),
+				),
+			),
+			rebuildErr: errors.New("rebuild failed"),
+		},
+	}
+	for _, tt := range tests {
+		if tt.isErr == nil {
+			tt.isErr = func(t *testing.T, err error) {
+				if err != nil {
+					t.Error("expected no error got:", err)
+				}
+			}
+		}
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{
+				client:     &database.DB{DB: tt.mock.DB},
+				projection: tt.projection,
+				now:        func() time.Time { return now },
+			}
+
+			finish, err := h.lockRebuild(context.Background())
+			tt.isErr(t, err)
+			if err == nil {
+				finish(tt.rebuildErr)
+			}
+
+			tt.mock.Assert(t)
+		})
+	}
+}
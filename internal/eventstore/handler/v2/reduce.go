@@ -2,6 +2,10 @@ package handler
 
 import "github.com/zitadel/zitadel/internal/eventstore"
 
+// AllEvents is used as [EventReducer.Event]
+// to reduce all events of the aggregate
+const AllEvents eventstore.EventType = ""
+
 // EventReducer represents the required data
 // to work with events
 type EventReducer struct {
@@ -0,0 +1,7 @@
+SELECT
+    "position"
+FROM
+    projections.current_states
+WHERE
+    instance_id = $1
+    AND projection_name = $2;
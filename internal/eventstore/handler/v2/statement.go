@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	errs "errors"
@@ -15,31 +16,46 @@ import (
 	"github.com/zitadel/zitadel/internal/eventstore"
 )
 
-func (h *Handler) eventsToStatements(tx *sql.Tx, events []eventstore.Event, currentState *state) (statements []*Statement, err error) {
-	statements = make([]*Statement, 0, len(events))
+// statementGenerator reduces the events of an iteration to statements one by one
+type statementGenerator struct {
+	handler          *Handler
+	tx               *sql.Tx
+	previousPosition float64
+	offset           uint16
+	statements       []*Statement
+}
 
-	previousPosition := currentState.position
-	offset := currentState.offset
-	for _, event := range events {
-		statement, err := h.reduce(event)
-		if err != nil {
-			h.logEvent(event).WithError(err).Error("reduce failed")
-			if shouldContinue := h.handleFailedStmt(tx, failureFromEvent(event, err)); shouldContinue {
-				continue
-			}
-			return statements, err
-		}
-		offset++
-		if previousPosition != event.Position() {
-			// offset is 1 because we want to skip this event
-			offset = 1
+func (h *Handler) newStatementGenerator(tx *sql.Tx, currentState *state) *statementGenerator {
+	return &statementGenerator{
+		handler:          h,
+		tx:               tx,
+		previousPosition: currentState.position,
+		offset:           currentState.offset,
+		statements:       make([]*Statement, 0, h.bulkLimit),
+	}
+}
+
+func (g *statementGenerator) next(ctx context.Context, event eventstore.Event) error {
+	start := time.Now()
+	statement, err := g.handler.reduce(event)
+	g.handler.recordDuration(ctx, ProjectionReduceDuration, start)
+	if err != nil {
+		g.handler.logEvent(event).WithError(err).Error("reduce failed")
+		if shouldContinue := g.handler.handleFailedStmt(ctx, g.tx, failureFromEvent(event, err)); shouldContinue {
+			return nil
 		}
-		statement.offset = offset
-		statement.Position = event.Position()
-		previousPosition = event.Position()
-		statements = append(statements, statement)
+		return err
+	}
+	g.offset++
+	if g.previousPosition != event.Position() {
+		// offset is 1 because we want to skip this event
+		g.offset = 1
 	}
-	return statements, nil
+	statement.offset = g.offset
+	statement.Position = event.Position()
+	g.previousPosition = event.Position()
+	g.statements = append(g.statements, statement)
+	return nil
 }
 
 func (h *Handler) reduce(event eventstore.Event) (*Statement, error) {
@@ -48,7 +64,7 @@ func (h *Handler) reduce(event eventstore.Event) (*Statement, error) {
 			continue
 		}
 		for _, reduce := range reducer.EventReducers {
-			if reduce.Event != event.Type() {
+			if reduce.Event != AllEvents && reduce.Event != event.Type() {
 				continue
 			}
 			return reduce.Reduce(event)
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"time"
+
+	errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+//go:embed status_get.sql
+var statusStmt string
+
+// Status is the progress of the projection for an instance
+type Status struct {
+	ProjectionName string
+	InstanceID     string
+	Position       float64
+	// EventDate is the creation date of the last reduced event
+	EventDate   time.Time
+	LastUpdated time.Time
+	// Lag is the time between the latest event of the instance and the last reduced event
+	Lag time.Duration
+	// FailedEvents is the count of failed events which are retried
+	FailedEvents uint64
+	// SkippedEvents is the count of failed events which exceeded the max failure count
+	SkippedEvents uint64
+	Paused        bool
+	// Rebuild is the last rebuild of the projection, nil if it was never rebuilt
+	Rebuild *RebuildStatus
+}
+
+// RebuildStatus is the state of the last rebuild of the projection
+type RebuildStatus struct {
+	State RebuildState
+	// Error is the reason of a failed rebuild
+	Error      string
+	StartedAt  time.Time
+	FinishedAt time.Time
+}
+
+// Status returns the progress of the projection for the instance,
+// an empty instanceID returns the progress for all instances
+func (h *Handler) Status(ctx context.Context, instanceID string) (statuses []*Status, err error) {
+	err = h.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		for rows.Next() {
+			status, err := h.scanStatus(rows)
+			if err != nil {
+				return err
+			}
+			statuses = append(statuses, status)
+		}
+		return rows.Err()
+	}, statusStmt, h.projection.Name(), instanceID, h.maxFailureCount)
+	if err != nil {
+		return nil, errs.ThrowInternal(err, "V2-Uu7ai", "unable to query status")
+	}
+	return statuses, nil
+}
+
+func (h *Handler) scanStatus(rows *sql.Rows) (*Status, error) {
+	var (
+		status          = &Status{ProjectionName: h.projection.Name()}
+		position        sql.NullFloat64
+		eventDate       sql.NullTime
+		lastUpdated     sql.NullTime
+		latestEventDate sql.NullTime
+		rebuildState    sql.NullString
+		rebuildError    sql.NullString
+		rebuildStarted  sql.NullTime
+		rebuildFinished sql.NullTime
+	)
+	err := rows.Scan(
+		&status.InstanceID,
+		&position,
+		&eventDate,
+		&lastUpdated,
+		&latestEventDate,
+		&status.FailedEvents,
+		&status.SkippedEvents,
+		&status.Paused,
+		&rebuildState,
+		&rebuildError,
+		&rebuildStarted,
+		&rebuildFinished,
+	)
+	if err != nil {
+		return nil, err
+	}
+	status.Position = position.Float64
+	status.EventDate = eventDate.Time
+	status.LastUpdated = lastUpdated.Time
+	if latestEventDate.Time.After(status.EventDate) {
+		status.Lag = latestEventDate.Time.Sub(status.EventDate)
+	}
+	if rebuildState.Valid {
+		status.Rebuild = &RebuildStatus{
+			State:      RebuildState(rebuildState.String),
+			Error:      rebuildError.String,
+			StartedAt:  rebuildStarted.Time,
+			FinishedAt: rebuildFinished.Time,
+		}
+	}
+	return status, nil
+}
@@ -0,0 +1,56 @@
+SELECT
+    s.instance_id
+    , s."position"
+    , s.event_date
+    , s.last_updated
+    , (
+        SELECT 
+            MAX(e.created_at) 
+        FROM 
+            eventstore.events2 e 
+        WHERE 
+            e.instance_id = s.instance_id
+    ) AS latest_event_date
+    , (
+        SELECT 
+            COUNT(*) 
+        FROM 
+            projections.failed_events2 f 
+        WHERE 
+            f.projection_name = s.projection_name 
+            AND f.instance_id = s.instance_id
+            AND f.failure_count < $3
+    ) AS failed_events
+    , (
+        SELECT 
+            COUNT(*) 
+        FROM 
+            projections.failed_events2 f 
+        WHERE 
+            f.projection_name = s.projection_name 
+            AND f.instance_id = s.instance_id
+            AND f.failure_count >= $3
+    ) AS skipped_events
+    , EXISTS (
+        SELECT 
+            1 
+        FROM 
+            projections.paused_projections p 
+        WHERE 
+            p.projection_name = s.projection_name 
+            AND p.instance_id IN (s.instance_id, '')
+    ) AS paused
+    , r.state AS rebuild_state
+    , r.error AS rebuild_error
+    , r.started_at AS rebuild_started_at
+    , r.finished_at AS rebuild_finished_at
+FROM
+    projections.current_states s
+LEFT JOIN
+    projections.projection_rebuilds r
+    ON r.projection_name = s.projection_name
+WHERE
+    s.projection_name = $1
+    AND ($2 = '' OR s.instance_id = $2)
+ORDER BY
+    s.instance_id;
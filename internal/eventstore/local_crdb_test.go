@@ -47,14 +47,14 @@ func TestMain(m *testing.M) {
 	queriers["v2(inmemory)"] = v2
 	clients["v2(inmemory)"] = testCRDBClient
 
-	pushers["v3(inmemory)"] = new_es.NewEventstore(testCRDBClient)
+	pushers["v3(inmemory)"] = new_es.NewEventstore(testCRDBClient, nil)
 	clients["v3(inmemory)"] = testCRDBClient
 
 	if localDB, err := connectLocalhost(); err == nil {
 		if err = initDB(localDB); err != nil {
 			logging.WithFields("error", err).Fatal("migrations failed")
 		}
-		pushers["v3(singlenode)"] = new_es.NewEventstore(localDB)
+		pushers["v3(singlenode)"] = new_es.NewEventstore(localDB, nil)
 		clients["v3(singlenode)"] = localDB
 	}
 
@@ -94,6 +94,10 @@ func initDB(db *database.DB) error {
 	}
 	// create old events
 	_, err = db.Exec(oldEventsTable)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(snapshotsTable)
 	return err
 }
 
@@ -215,6 +219,10 @@ func cleanupEventstore(client *database.DB) func() {
 		if err != nil {
 			logging.Warnf("unable to truncate unique constraints: %v", err)
 		}
+		_, err = client.Exec("TRUNCATE eventstore.snapshots")
+		if err != nil {
+			logging.Warnf("unable to truncate snapshots: %v", err)
+		}
 	}
 }
 
@@ -239,3 +247,15 @@ const oldEventsTable = `CREATE TABLE IF NOT EXISTS eventstore.events (
 
 	, PRIMARY KEY (instance_id, aggregate_type, aggregate_id, event_sequence DESC)
 );`
+
+const snapshotsTable = `CREATE TABLE IF NOT EXISTS eventstore.snapshots (
+	instance_id TEXT NOT NULL
+	, model TEXT NOT NULL
+	, "key" TEXT NOT NULL
+	, "version" INT2 NOT NULL
+	, "position" DECIMAL NOT NULL
+	, payload JSONB NOT NULL
+	, created_at TIMESTAMPTZ NOT NULL
+
+	, PRIMARY KEY (instance_id, model, "key")
+);`
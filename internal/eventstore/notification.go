@@ -0,0 +1,150 @@
+package eventstore
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/zitadel/logging"
+)
+
+// Notification informs that events of an aggregate type were pushed on an instance
+type Notification struct {
+	InstanceID    string        `json:"instanceID"`
+	AggregateType AggregateType `json:"aggregateType"`
+}
+
+// Notifier propagates pushed events to the other nodes of the system.
+// The local subscriptions are notified by the eventstore itself.
+type Notifier interface {
+	// Notify is called after events were pushed successfully
+	Notify(ctx context.Context, notifications []Notification) error
+	// Listen blocks until ctx is done or the connection is lost
+	// and calls receive for every notification of the other nodes
+	Listen(ctx context.Context, receive func(Notification)) error
+}
+
+// NotifierConfig defines how pushed events are propagated to the other nodes
+type NotifierConfig struct {
+	// Type is either "listen" (LISTEN / NOTIFY of Postgres) or "poll".
+	// Events are only propagated inside the node if it's empty
+	Type string
+	// PollInterval is the interval the "poll" notifier queries the pushed events
+	PollInterval time.Duration
+}
+
+const (
+	NotifierTypeListen = "listen"
+	NotifierTypePoll   = "poll"
+)
+
+// notificationRetryInterval is the time waited until listening is restarted after an error
+const notificationRetryInterval = 5 * time.Second
+
+var (
+	notificationSubscriptions = map[AggregateType][]*NotificationSubscription{}
+	notificationSubsMutex     sync.Mutex
+)
+
+type NotificationSubscription struct {
+	Notifications chan Notification
+	aggregates    []AggregateType
+}
+
+// SubscribeNotifications subscribes for events pushed on other nodes on the given aggregates
+func SubscribeNotifications(queue chan Notification, aggregates ...AggregateType) *NotificationSubscription {
+	sub := &NotificationSubscription{
+		Notifications: queue,
+		aggregates:    aggregates,
+	}
+
+	notificationSubsMutex.Lock()
+	defer notificationSubsMutex.Unlock()
+
+	for _, aggregate := range aggregates {
+		notificationSubscriptions[aggregate] = append(notificationSubscriptions[aggregate], sub)
+	}
+	return sub
+}
+
+func (s *NotificationSubscription) Unsubscribe() {
+	notificationSubsMutex.Lock()
+	defer notificationSubsMutex.Unlock()
+	for _, aggregate := range s.aggregates {
+		subs := notificationSubscriptions[aggregate]
+		for i := len(subs) - 1; i >= 0; i-- {
+			if subs[i] == s {
+				subs = append(subs[:i], subs[i+1:]...)
+			}
+		}
+		notificationSubscriptions[aggregate] = subs
+	}
+}
+
+// receiveNotification passes the notification to the subscriptions of its aggregate type.
+// Subscribers which are not ready skip the notification, they are triggered by their interval anyway.
+func receiveNotification(notification Notification) {
+	notificationSubsMutex.Lock()
+	defer notificationSubsMutex.Unlock()
+	for _, sub := range notificationSubscriptions[notification.AggregateType] {
+		select {
+		case sub.Notifications <- notification:
+		default:
+			logging.WithFields("instance", notification.InstanceID, "aggregateType", notification.AggregateType).Debug("unable to pass notification")
+		}
+	}
+}
+
+// notificationsFromEvents returns a notification for each distinct instance and aggregate type of the events
+func notificationsFromEvents(events []Event) []Notification {
+	notifications := make([]Notification, 0, len(events))
+	for _, event := range events {
+		notification := Notification{
+			InstanceID:    event.Aggregate().InstanceID,
+			AggregateType: event.Aggregate().Type,
+		}
+		if !containsNotification(notifications, notification) {
+			notifications = append(notifications, notification)
+		}
+	}
+	return notifications
+}
+
+func containsNotification(notifications []Notification, notification Notification) bool {
+	for _, n := range notifications {
+		if n == notification {
+			return true
+		}
+	}
+	return false
+}
+
+// notifyNodes propagates the pushed events to the other nodes.
+// Failures are only logged, the projections of the other nodes are still triggered by their interval.
+func (es *Eventstore) notifyNodes(ctx context.Context, events []Event) {
+	if es.notifier == nil || len(events) == 0 {
+		return
+	}
+	err := es.notifier.Notify(ctx, notificationsFromEvents(events))
+	logging.OnError(err).Warn("unable to notify other nodes")
+}
+
+// ListenNotifications passes the notifications of the other nodes to the subscriptions until ctx is done.
+// Listening is restarted if the notifier fails.
+func (es *Eventstore) ListenNotifications(ctx context.Context) {
+	if es.notifier == nil {
+		return
+	}
+	for {
+		err := es.notifier.Listen(ctx, receiveNotification)
+		if ctx.Err() != nil {
+			return
+		}
+		logging.OnError(err).Warn("listening for notifications failed, restarting")
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(notificationRetryInterval):
+		}
+	}
+}
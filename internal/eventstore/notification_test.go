@@ -0,0 +1,132 @@
+package eventstore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testNotifier struct {
+	notified [][]Notification
+}
+
+func (n *testNotifier) Notify(_ context.Context, notifications []Notification) error {
+	n.notified = append(n.notified, notifications)
+	return nil
+}
+
+func (n *testNotifier) Listen(ctx context.Context, _ func(Notification)) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func Test_notificationsFromEvents(t *testing.T) {
+	newEvent := func(instanceID string, aggregateType AggregateType) Event {
+		return &BaseEvent{
+			Agg: &Aggregate{
+				ID:         "id",
+				Type:       aggregateType,
+				InstanceID: instanceID,
+			},
+		}
+	}
+	tests := []struct {
+		name   string
+		events []Event
+		want   []Notification
+	}{
+		{
+			name:   "no events",
+			events: nil,
+			want:   []Notification{},
+		},
+		{
+			name: "distinct instances and aggregate types",
+			events: []Event{
+				newEvent("instance1", "user"),
+				newEvent("instance1", "org"),
+				newEvent("instance2", "user"),
+			},
+			want: []Notification{
+				{InstanceID: "instance1", AggregateType: "user"},
+				{InstanceID: "instance1", AggregateType: "org"},
+				{InstanceID: "instance2", AggregateType: "user"},
+			},
+		},
+		{
+			name: "duplicates",
+			events: []Event{
+				newEvent("instance1", "user"),
+				newEvent("instance1", "user"),
+				newEvent("instance1", "org"),
+				newEvent("instance1", "user"),
+			},
+			want: []Notification{
+				{InstanceID: "instance1", AggregateType: "user"},
+				{InstanceID: "instance1", AggregateType: "org"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, notificationsFromEvents(tt.events))
+		})
+	}
+}
+
+func Test_receiveNotification(t *testing.T) {
+	userQueue := make(chan Notification, 1)
+	userSub := SubscribeNotifications(userQueue, "notification.user")
+	defer userSub.Unsubscribe()
+	orgQueue := make(chan Notification, 1)
+	orgSub := SubscribeNotifications(orgQueue, "notification.org")
+	defer orgSub.Unsubscribe()
+
+	notification := Notification{InstanceID: "instance1", AggregateType: "notification.user"}
+	receiveNotification(notification)
+	// the full queue must not block
+	receiveNotification(notification)
+
+	select {
+	case got := <-userQueue:
+		assert.Equal(t, notification, got)
+	case <-time.After(time.Second):
+		t.Fatal("notification not received")
+	}
+	assert.Len(t, userQueue, 0)
+	assert.Len(t, orgQueue, 0)
+
+	userSub.Unsubscribe()
+	receiveNotification(notification)
+	assert.Len(t, userQueue, 0)
+}
+
+func TestEventstore_Push_notifyNodes(t *testing.T) {
+	notifier := new(testNotifier)
+	es := &Eventstore{
+		pusher: &testPusher{
+			t: t,
+			events: []Event{
+				&BaseEvent{
+					Agg: &Aggregate{
+						ID:            "1",
+						Type:          "test.aggregate",
+						ResourceOwner: "caos",
+						InstanceID:    "zitadel",
+					},
+					Data:      []byte(nil),
+					User:      "editorUser",
+					EventType: "test.event",
+				},
+			},
+		},
+		notifier:          notifier,
+		eventInterceptors: map[EventType]eventTypeInterceptors{},
+	}
+	_, err := es.Push(context.Background(), newTestEvent("1", "", func() interface{} { return []byte(nil) }, false))
+	require.NoError(t, err)
+	assert.Equal(t, [][]Notification{{{InstanceID: "zitadel", AggregateType: "test.aggregate"}}}, notifier.notified)
+}
@@ -0,0 +1,234 @@
+package eventstore
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	// RedactedPersonalData replaces the personal data of events after the key of its owner was destroyed
+	RedactedPersonalData = "[redacted]"
+
+	personalDataKey = "personalData"
+)
+
+// PersonalDataKeys encrypt the personal data in the payloads of events with a key per owner of the data,
+// which is the aggregate of the event (e.g. the user).
+// After the key of an owner is destroyed, its personal data can't be decrypted anymore (crypto-shredding).
+type PersonalDataKeys interface {
+	// Seal encrypts the value with the key of the owner,
+	// a missing key is created in the transaction of the push
+	Seal(ctx context.Context, tx *sql.Tx, instanceID, ownerID string, value []byte) (string, error)
+	// Open decrypts the sealed value, it returns a NotFound error if the key of the owner was destroyed
+	Open(ctx context.Context, instanceID, ownerID, sealed string) ([]byte, error)
+	// IsDestroyed returns true if the key of the owner was destroyed
+	IsDestroyed(ctx context.Context, instanceID, ownerID string) (bool, error)
+	// Destroy destroys the key of the owner, destroying it again has no effect
+	Destroy(ctx context.Context, instanceID, ownerID string) error
+}
+
+// PersonalDataCommand is a command whose payload contains personal data of the owner of the aggregate.
+// The fields are sealed by the pusher if it has [PersonalDataKeys].
+type PersonalDataCommand interface {
+	Command
+	// PersonalDataFields are the json keys of the payload containing the personal data
+	PersonalDataFields() []string
+}
+
+// PersonalDataOwner is implemented by a [PersonalDataCommand] whose personal data belongs to another owner than the aggregate,
+// e.g. the user a notification is sent to. The personal data is sealed with the key of the owner,
+// so it's crypto-shredded together with the personal data of the owner.
+type PersonalDataOwner interface {
+	PersonalDataOwnerID() string
+}
+
+type sealedPersonalData struct {
+	// Fields are the keys of the payload which are sealed
+	Fields []string `json:"fields"`
+	Sealed string   `json:"sealed"`
+	// Owner is the owner of the key if it's not the aggregate
+	Owner string `json:"owner,omitempty"`
+}
+
+// SealPersonalData replaces the fields of the payload with the personal data sealed by the key of the owner,
+// which is the aggregate of the command unless the command implements [PersonalDataOwner].
+// It's called by the pusher in the transaction of the push.
+func SealPersonalData(ctx context.Context, tx *sql.Tx, keys PersonalDataKeys, command PersonalDataCommand, payload []byte) ([]byte, error) {
+	fields := command.PersonalDataFields()
+	if len(payload) == 0 || len(fields) == 0 {
+		return payload, nil
+	}
+	values := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(payload, &values); err != nil {
+		return nil, errors.ThrowInternal(err, "V2-Ohc5a", "unable to unmarshal personal data")
+	}
+	personalData := make(map[string]json.RawMessage, len(fields))
+	sealed := &sealedPersonalData{Fields: make([]string, 0, len(fields))}
+	for _, field := range fields {
+		value, ok := values[field]
+		if !ok {
+			continue
+		}
+		personalData[field] = value
+		sealed.Fields = append(sealed.Fields, field)
+		delete(values, field)
+	}
+	if len(personalData) == 0 {
+		return payload, nil
+	}
+	plain, err := json.Marshal(personalData)
+	if err != nil {
+		return nil, err
+	}
+	aggregate := command.Aggregate()
+	ownerID := aggregate.ID
+	if owner, ok := command.(PersonalDataOwner); ok && owner.PersonalDataOwnerID() != "" && owner.PersonalDataOwnerID() != aggregate.ID {
+		ownerID = owner.PersonalDataOwnerID()
+		sealed.Owner = ownerID
+	}
+	sealed.Sealed, err = keys.Seal(ctx, tx, aggregate.InstanceID, ownerID, plain)
+	if err != nil {
+		return nil, err
+	}
+	if values[personalDataKey], err = json.Marshal(sealed); err != nil {
+		return nil, err
+	}
+	return json.Marshal(values)
+}
+
+// RegisterPersonalDataFields registers the fields of the payload of the event type which contain personal data.
+// They are redacted in the events pushed before crypto-shredding was enabled, if the key of the owner was destroyed.
+func (es *Eventstore) RegisterPersonalDataFields(eventType EventType, fields ...string) *Eventstore {
+	es.interceptorMutex.Lock()
+	defer es.interceptorMutex.Unlock()
+
+	if es.personalDataFields == nil {
+		es.personalDataFields = make(map[EventType][]string)
+	}
+	es.personalDataFields[eventType] = fields
+	return es
+}
+
+// DestroyPersonalData destroys the key of the owner,
+// afterwards the personal data of its events is redacted
+func (es *Eventstore) DestroyPersonalData(ctx context.Context, instanceID, ownerID string) error {
+	if es.personalDataKeys == nil {
+		return errors.ThrowPreconditionFailed(nil, "V2-ieX8o", "Errors.User.CryptoShreddingDisabled")
+	}
+	return es.personalDataKeys.Destroy(ctx, instanceID, ownerID)
+}
+
+// openPersonalData replaces the sealed personal data of the event with the decrypted fields
+// or with [RedactedPersonalData] if the key of the owner was destroyed.
+// The personal data of events pushed before crypto-shredding was enabled is redacted as well.
+// The payload of the event is replaced, so consumers of the raw payload (e.g. the events API) get the same result.
+func (es *Eventstore) openPersonalData(ctx context.Context, event Event) (Event, error) {
+	payload := event.DataAsBytes()
+	es.interceptorMutex.RLock()
+	fields, registered := es.personalDataFields[event.Type()]
+	es.interceptorMutex.RUnlock()
+	mightBeSealed := bytes.Contains(payload, []byte(`"`+personalDataKey+`"`))
+	if !mightBeSealed && (!registered || es.personalDataKeys == nil) {
+		return event, nil
+	}
+
+	values := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(payload, &values); err != nil {
+		return nil, errors.ThrowInternal(err, "V2-Iek4u", "unable to unmarshal personal data")
+	}
+	rawSealed, sealed := values[personalDataKey]
+	if sealed && es.personalDataKeys == nil {
+		// returning the event without personal data would corrupt the projections
+		return nil, errors.ThrowInternalf(nil, "V2-Eim5a", "personal data keys are required to read event %s", event.Type())
+	}
+	if !sealed && !registered {
+		return event, nil
+	}
+
+	var err error
+	if sealed {
+		err = es.openSealedPersonalData(ctx, event.Aggregate(), rawSealed, values)
+	} else {
+		err = es.redactPlainPersonalData(ctx, event.Aggregate(), fields, values)
+	}
+	if err != nil {
+		return nil, err
+	}
+	opened := &personalDataEvent{Event: event}
+	if opened.payload, err = json.Marshal(values); err != nil {
+		return nil, errors.ThrowInternal(err, "V2-ohT3a", "unable to marshal personal data")
+	}
+	return opened, nil
+}
+
+func (es *Eventstore) openSealedPersonalData(ctx context.Context, aggregate *Aggregate, rawSealed json.RawMessage, values map[string]json.RawMessage) error {
+	sealed := new(sealedPersonalData)
+	if err := json.Unmarshal(rawSealed, sealed); err != nil {
+		return errors.ThrowInternal(err, "V2-Ooph3", "unable to unmarshal personal data")
+	}
+	delete(values, personalDataKey)
+
+	ownerID := aggregate.ID
+	if sealed.Owner != "" {
+		ownerID = sealed.Owner
+	}
+	plain, err := es.personalDataKeys.Open(ctx, aggregate.InstanceID, ownerID, sealed.Sealed)
+	if errors.IsNotFound(err) {
+		redact(values, sealed.Fields)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	personalData := make(map[string]json.RawMessage, len(sealed.Fields))
+	if err = json.Unmarshal(plain, &personalData); err != nil {
+		return errors.ThrowInternal(err, "V2-Aew6u", "unable to unmarshal personal data")
+	}
+	for field, value := range personalData {
+		values[field] = value
+	}
+	return nil
+}
+
+// redactPlainPersonalData redacts the personal data of events pushed before crypto-shredding was enabled
+func (es *Eventstore) redactPlainPersonalData(ctx context.Context, aggregate *Aggregate, fields []string, values map[string]json.RawMessage) error {
+	destroyed, err := es.personalDataKeys.IsDestroyed(ctx, aggregate.InstanceID, aggregate.ID)
+	if err != nil || !destroyed {
+		return err
+	}
+	present := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if value, ok := values[field]; ok && string(value) != `""` && string(value) != "null" {
+			present = append(present, field)
+		}
+	}
+	redact(values, present)
+	return nil
+}
+
+func redact(values map[string]json.RawMessage, fields []string) {
+	redacted, _ := json.Marshal(RedactedPersonalData)
+	for _, field := range fields {
+		values[field] = redacted
+	}
+}
+
+// personalDataEvent is a stored event with the opened or redacted personal data
+type personalDataEvent struct {
+	Event
+	payload []byte
+}
+
+// DataAsBytes implements [Event]
+func (e *personalDataEvent) DataAsBytes() []byte {
+	return e.payload
+}
+
+// Unmarshal implements [Event]
+func (e *personalDataEvent) Unmarshal(ptr any) error {
+	return json.Unmarshal(e.payload, ptr)
+}
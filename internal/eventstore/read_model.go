@@ -48,3 +48,31 @@ func (rm *ReadModel) Reduce() error {
 	rm.Events = []Event{}
 	return nil
 }
+
+func (rm *ReadModel) snapshotMetadata() *snapshotMetadata {
+	return &snapshotMetadata{
+		AggregateID:       rm.AggregateID,
+		ProcessedSequence: rm.ProcessedSequence,
+		ResourceOwner:     rm.ResourceOwner,
+		InstanceID:        rm.InstanceID,
+		CreationDate:      rm.CreationDate,
+		ChangeDate:        rm.ChangeDate,
+	}
+}
+
+func (rm *ReadModel) restoreSnapshotMetadata(metadata *snapshotMetadata) {
+	if rm.AggregateID == "" {
+		rm.AggregateID = metadata.AggregateID
+	}
+	if rm.ResourceOwner == "" {
+		rm.ResourceOwner = metadata.ResourceOwner
+	}
+	if rm.InstanceID == "" {
+		rm.InstanceID = metadata.InstanceID
+	}
+	if rm.CreationDate.IsZero() {
+		rm.CreationDate = metadata.CreationDate
+	}
+	rm.ProcessedSequence = metadata.ProcessedSequence
+	rm.ChangeDate = metadata.ChangeDate
+}
@@ -42,6 +42,9 @@ type Event struct {
 	//Version describes the definition of the aggregate at a certain point in time
 	// it's used in read models to reduce the events in the correct definition
 	Version eventstore.Version
+	//Rev is the revision of the payload
+	// it's used to upcast the payloads of earlier revisions
+	Rev uint16
 	//AggregateID id is the unique identifier of the aggregate
 	// the client must generate it by it's own
 	AggregateID string
@@ -82,7 +85,7 @@ func (e *Event) Type() eventstore.EventType {
 
 // Revision implements [eventstore.Event]
 func (e *Event) Revision() uint16 {
-	return 0
+	return e.Rev
 }
 
 // Sequence implements [eventstore.Event]
@@ -104,9 +104,22 @@ func query(ctx context.Context, criteria querier, searchQuery *eventstore.Search
 		contextQuerier = &tx{Tx: q.Tx}
 	}
 
+	// the errors of the reducer are returned unchanged
+	var reduceErr error
+	if reduce, ok := dest.(eventstore.Reducer); ok {
+		dest = eventstore.Reducer(func(event eventstore.Event) error {
+			reduceErr = reduce(event)
+			return reduceErr
+		})
+	}
+
 	err = contextQuerier.QueryContext(ctx,
 		func(rows *sql.Rows) error {
+			// the next row is only scanned after the previous was reduced
 			for rows.Next() {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
 				err := rowScanner(rows.Scan, dest)
 				if err != nil {
 					return err
@@ -114,6 +127,9 @@ func query(ctx context.Context, criteria querier, searchQuery *eventstore.Search
 			}
 			return nil
 		}, query, values...)
+	if reduceErr != nil {
+		return reduceErr
+	}
 	if err != nil {
 		logging.New().WithError(err).Info("query failed")
 		return z_errors.ThrowInternal(err, "SQL-KyeAx", "unable to filter events")
@@ -194,7 +210,6 @@ func eventsScanner(useV1 bool) func(scanner scan, dest interface{}) (err error)
 				&event.Version,
 			)
 		} else {
-			var revision uint8
 			err = scanner(
 				&event.CreationDate,
 				&event.Typ,
@@ -206,9 +221,10 @@ func eventsScanner(useV1 bool) func(scanner scan, dest interface{}) (err error)
 				&event.InstanceID,
 				&event.AggregateType,
 				&event.AggregateID,
-				&revision,
+				&event.Rev,
 			)
-			event.Version = eventstore.Version("v" + strconv.Itoa(int(revision)))
+			// the revision column holds the version of the aggregate the event was pushed with
+			event.Version = eventstore.Version("v" + strconv.Itoa(int(event.Rev)))
 		}
 
 		if err != nil {
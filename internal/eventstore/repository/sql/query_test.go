@@ -177,11 +177,30 @@ func Test_prepareColumns(t *testing.T) {
 			res: res{
 				query: `SELECT created_at, event_type, "sequence", "position", payload, creator, "owner", instance_id, aggregate_type, aggregate_id, revision FROM eventstore.events2`,
 				expected: []eventstore.Event{
-					&repository.Event{AggregateID: "hodor", AggregateType: "user", Seq: 5, Pos: 42, Data: nil, Version: "v1"},
+					&repository.Event{AggregateID: "hodor", AggregateType: "user", Seq: 5, Pos: 42, Data: nil, Version: "v1", Rev: 1},
 				},
 			},
 			fields: fields{
-				dbRow: []interface{}{time.Time{}, eventstore.EventType(""), uint64(5), sql.NullFloat64{Float64: 42, Valid: true}, sql.RawBytes(nil), "", sql.NullString{}, "", eventstore.AggregateType("user"), "hodor", uint8(1)},
+				dbRow: []interface{}{time.Time{}, eventstore.EventType(""), uint64(5), sql.NullFloat64{Float64: 42, Valid: true}, sql.RawBytes(nil), "", sql.NullString{}, "", eventstore.AggregateType("user"), "hodor", uint16(1)},
+			},
+		},
+		{
+			name: "events v2 revision as version",
+			args: args{
+				columns: eventstore.ColumnsEvent,
+				dest: eventstore.Reducer(func(event eventstore.Event) error {
+					reducedEvents = append(reducedEvents, event)
+					return nil
+				}),
+			},
+			res: res{
+				query: `SELECT created_at, event_type, "sequence", "position", payload, creator, "owner", instance_id, aggregate_type, aggregate_id, revision FROM eventstore.events2`,
+				expected: []eventstore.Event{
+					&repository.Event{AggregateID: "hodor", AggregateType: "user", Seq: 5, Pos: 42, Data: nil, Version: "v2", Rev: 2},
+				},
+			},
+			fields: fields{
+				dbRow: []interface{}{time.Time{}, eventstore.EventType(""), uint64(5), sql.NullFloat64{Float64: 42, Valid: true}, sql.RawBytes(nil), "", sql.NullString{}, "", eventstore.AggregateType("user"), "hodor", uint16(2)},
 			},
 		},
 		{
@@ -196,11 +215,11 @@ func Test_prepareColumns(t *testing.T) {
 			res: res{
 				query: `SELECT created_at, event_type, "sequence", "position", payload, creator, "owner", instance_id, aggregate_type, aggregate_id, revision FROM eventstore.events2`,
 				expected: []eventstore.Event{
-					&repository.Event{AggregateID: "hodor", AggregateType: "user", Seq: 5, Pos: 0, Data: nil, Version: "v1"},
+					&repository.Event{AggregateID: "hodor", AggregateType: "user", Seq: 5, Pos: 0, Data: nil, Version: "v1", Rev: 1},
 				},
 			},
 			fields: fields{
-				dbRow: []interface{}{time.Time{}, eventstore.EventType(""), uint64(5), sql.NullFloat64{Float64: 0, Valid: false}, sql.RawBytes(nil), "", sql.NullString{}, "", eventstore.AggregateType("user"), "hodor", uint8(1)},
+				dbRow: []interface{}{time.Time{}, eventstore.EventType(""), uint64(5), sql.NullFloat64{Float64: 0, Valid: false}, sql.RawBytes(nil), "", sql.NullString{}, "", eventstore.AggregateType("user"), "hodor", uint16(1)},
 			},
 		},
 		{
@@ -863,6 +882,60 @@ func Test_query_events_mocked(t *testing.T) {
 	}
 }
 
+func Test_query_events_reducer(t *testing.T) {
+	expectedQuery := `SELECT creation_date, event_type, event_sequence, event_data, editor_user, resource_owner, instance_id, aggregate_type, aggregate_id, aggregate_version FROM eventstore.events WHERE aggregate_type = \$1 ORDER BY event_sequence`
+	searchQuery := func() *eventstore.SearchQueryBuilder {
+		return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+			OrderAsc().
+			AddQuery().
+			AggregateTypes("user").
+			Builder()
+	}
+	expectRows := func(mock sqlmock.Sqlmock) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(expectedQuery).
+			WithArgs(eventstore.AggregateType("user")).
+			WillReturnRows(
+				sqlmock.NewRows([]string{"creation_date", "event_type", "event_sequence", "event_data", "editor_user", "resource_owner", "instance_id", "aggregate_type", "aggregate_id", "aggregate_version"}).
+					AddRow(time.Now(), "user.added", 1, nil, "editor", "ro", "instance", "user", "id", "v1").
+					AddRow(time.Now(), "user.changed", 2, nil, "editor", "ro", "instance", "user", "id", "v1"),
+			).
+			RowsWillBeClosed()
+		mock.ExpectRollback()
+	}
+
+	t.Run("reducer error returned unchanged", func(t *testing.T) {
+		client := newMockClient(t)
+		expectRows(client.mock)
+		crdb := NewCRDB(&database.DB{DB: client.client, Database: new(testDB)})
+
+		var reduced int
+		err := query(context.Background(), crdb, searchQuery(), eventstore.Reducer(func(eventstore.Event) error {
+			reduced++
+			return eventstore.ErrStopFilter
+		}), true)
+		assert.ErrorIs(t, err, eventstore.ErrStopFilter)
+		assert.Equal(t, 1, reduced)
+		assert.NoError(t, client.mock.ExpectationsWereMet())
+	})
+
+	t.Run("context canceled", func(t *testing.T) {
+		client := newMockClient(t)
+		expectRows(client.mock)
+		crdb := NewCRDB(&database.DB{DB: client.client, Database: new(testDB)})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		var reduced int
+		err := query(ctx, crdb, searchQuery(), eventstore.Reducer(func(eventstore.Event) error {
+			reduced++
+			cancel()
+			return nil
+		}), true)
+		assert.Error(t, err)
+		assert.Equal(t, 1, reduced)
+	})
+}
+
 type dbMock struct {
 	mock   sqlmock.Sqlmock
 	client *sql.DB
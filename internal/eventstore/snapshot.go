@@ -0,0 +1,287 @@
+package eventstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/zitadel/logging"
+)
+
+// SnapshotConfig defines when the state of write and read models is stored as snapshot
+type SnapshotConfig struct {
+	// Enabled enables the snapshots of the models which are not configured in Models
+	Enabled bool
+	// MinEvents is the count of events reduced after the last snapshot needed to store a new snapshot
+	MinEvents uint32
+	// MinAge is the age the last reduced event must have before a snapshot is stored.
+	// Events of transactions which were open at the time of the snapshot would be missed otherwise.
+	MinAge time.Duration
+	// Models overwrites the config per model,
+	// the key is the name of the type of the model (e.g. OrgWriteModel) and is case-insensitive
+	Models map[string]SnapshotModelConfig
+}
+
+// SnapshotModelConfig defines the snapshots of a single model
+type SnapshotModelConfig struct {
+	Enabled bool
+	// MinEvents of the [SnapshotConfig] is used if zero
+	MinEvents uint32
+	// MinAge of the [SnapshotConfig] is used if zero
+	MinAge time.Duration
+	// Version is added to the SnapshotVersion of the model,
+	// increasing it ignores the stored snapshots without changing the code
+	Version uint16
+}
+
+// Snapshot is the serialized state of a model after it reduced all events up to Position
+type Snapshot struct {
+	InstanceID string
+	// Model is the type of the model
+	Model string
+	// Key identifies the query of the model
+	Key      string
+	Version  uint16
+	Position float64
+	Payload  []byte
+}
+
+// SnapshotStorage stores the snapshots
+type SnapshotStorage interface {
+	// Snapshot returns the latest snapshot of the model or nil if none exists
+	Snapshot(ctx context.Context, instanceID, model, key string) (*Snapshot, error)
+	// StoreSnapshot creates or replaces the snapshot of the model
+	StoreSnapshot(ctx context.Context, snapshot *Snapshot) error
+}
+
+// SnapshotQueryReducer is implemented by models which enable snapshots.
+// The state of the model is serialized as JSON,
+// which means that all fields reflecting the reduced events must be exported.
+// [WriteModel] and [ReadModel] implement the unexported methods.
+type SnapshotQueryReducer interface {
+	QueryReducer
+	// SnapshotVersion must be increased if the fields or the reduce of the model change,
+	// the snapshots of the previous versions are ignored.
+	SnapshotVersion() uint16
+
+	snapshotMetadata() *snapshotMetadata
+	restoreSnapshotMetadata(*snapshotMetadata)
+}
+
+// snapshotMetadata is the state of the embedded [WriteModel] or [ReadModel]
+// which is ignored by the serialization of the model
+type snapshotMetadata struct {
+	AggregateID       string    `json:"aggregateId,omitempty"`
+	ProcessedSequence uint64    `json:"processedSequence,omitempty"`
+	ResourceOwner     string    `json:"resourceOwner,omitempty"`
+	InstanceID        string    `json:"instanceId,omitempty"`
+	CreationDate      time.Time `json:"creationDate,omitempty"`
+	ChangeDate        time.Time `json:"changeDate,omitempty"`
+}
+
+type snapshotPayload struct {
+	Metadata *snapshotMetadata `json:"metadata"`
+	Model    json.RawMessage   `json:"model"`
+}
+
+type snapshotter struct {
+	storage SnapshotStorage
+	config  SnapshotConfig
+	// models are the configs of the models by the lower case name of the model
+	models map[string]SnapshotModelConfig
+}
+
+func newSnapshotter(config *Config) *snapshotter {
+	if config.SnapshotStorage == nil {
+		return nil
+	}
+	enabled := config.Snapshots.Enabled
+	models := make(map[string]SnapshotModelConfig, len(config.Snapshots.Models))
+	for name, model := range config.Snapshots.Models {
+		models[strings.ToLower(name)] = model
+		enabled = enabled || model.Enabled
+	}
+	if !enabled {
+		return nil
+	}
+	return &snapshotter{
+		storage: config.SnapshotStorage,
+		config:  config.Snapshots,
+		models:  models,
+	}
+}
+
+// modelConfig returns the config of the model, the models which are not configured use the defaults
+func (s *snapshotter) modelConfig(r SnapshotQueryReducer) SnapshotModelConfig {
+	typ := reflect.TypeOf(r)
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	model, ok := s.models[strings.ToLower(typ.Name())]
+	if !ok {
+		return SnapshotModelConfig{
+			Enabled:   s.config.Enabled,
+			MinEvents: s.config.MinEvents,
+			MinAge:    s.config.MinAge,
+		}
+	}
+	if model.MinEvents == 0 {
+		model.MinEvents = s.config.MinEvents
+	}
+	if model.MinAge == 0 {
+		model.MinAge = s.config.MinAge
+	}
+	return model
+}
+
+// filterToSnapshotReducer restores the latest snapshot of the model
+// and filters only the events after the position of the snapshot.
+// Errors of the snapshot storage are logged, the events are filtered from the beginning in that case.
+func (es *Eventstore) filterToSnapshotReducer(ctx context.Context, r SnapshotQueryReducer) error {
+	searchQuery := r.Query()
+	config := es.snapshots.modelConfig(r)
+	if !config.Enabled {
+		return es.FilterToReducer(ctx, searchQuery, r)
+	}
+	searchQuery.ensureInstanceID(ctx)
+	key, ok := searchQuery.snapshotKey()
+	if !ok {
+		return es.FilterToReducer(ctx, searchQuery, r)
+	}
+	instanceID, model, version := *searchQuery.instanceID, snapshotModel(r), r.SnapshotVersion()+config.Version
+
+	var (
+		restored bool
+		position float64
+	)
+	snapshot, err := es.snapshots.storage.Snapshot(ctx, instanceID, model, key)
+	logging.WithFields("model", model, "instance", instanceID).OnError(err).Warn("unable to read snapshot")
+	if snapshot != nil && snapshot.Version == version {
+		err = restoreSnapshot(r, snapshot.Payload)
+		logging.WithFields("model", model, "instance", instanceID).OnError(err).Warn("unable to restore snapshot")
+		if err == nil {
+			restored, position = true, snapshot.Position
+			// the position is passed as float, the position of the snapshot is included
+			// to ignore rounding and the events of the snapshot are skipped below
+			searchQuery.PositionAfter(math.Nextafter(position, math.Inf(-1)))
+		}
+	}
+
+	var (
+		reduced   uint32
+		lastEvent Event
+	)
+	err = es.FilterStream(ctx, searchQuery, func(event Event) error {
+		if restored && event.Position() <= position {
+			return nil
+		}
+		reduced++
+		lastEvent = event
+		r.AppendEvents(event)
+		return r.Reduce()
+	})
+	if err != nil || lastEvent == nil {
+		return err
+	}
+	if reduced < config.MinEvents || time.Since(lastEvent.CreatedAt()) < config.MinAge {
+		return nil
+	}
+	payload, err := takeSnapshot(r)
+	if err != nil {
+		logging.WithFields("model", model, "instance", instanceID).WithError(err).Warn("unable to take snapshot")
+		return nil
+	}
+	err = es.snapshots.storage.StoreSnapshot(ctx, &Snapshot{
+		InstanceID: instanceID,
+		Model:      model,
+		Key:        key,
+		Version:    version,
+		Position:   lastEvent.Position(),
+		Payload:    payload,
+	})
+	logging.WithFields("model", model, "instance", instanceID).OnError(err).Warn("unable to store snapshot")
+	return nil
+}
+
+func snapshotModel(r SnapshotQueryReducer) string {
+	return reflect.TypeOf(r).String()
+}
+
+func takeSnapshot(r SnapshotQueryReducer) ([]byte, error) {
+	model, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(&snapshotPayload{
+		Metadata: r.snapshotMetadata(),
+		Model:    model,
+	})
+}
+
+// restoreSnapshot only changes the state of r if the payload can be restored completely
+func restoreSnapshot(r SnapshotQueryReducer, payload []byte) error {
+	snapshot := new(snapshotPayload)
+	if err := json.Unmarshal(payload, snapshot); err != nil {
+		return err
+	}
+	if snapshot.Metadata == nil {
+		return fmt.Errorf("metadata of snapshot missing")
+	}
+	typ := reflect.TypeOf(r)
+	if typ.Kind() != reflect.Ptr {
+		return fmt.Errorf("model must be a pointer")
+	}
+	if err := json.Unmarshal(snapshot.Model, reflect.New(typ.Elem()).Interface()); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(snapshot.Model, r); err != nil {
+		return err
+	}
+	r.restoreSnapshotMetadata(snapshot.Metadata)
+	return nil
+}
+
+// snapshotKey identifies the events the query filters.
+// Snapshots are only possible for queries of an instance filtering all events in ascending order
+func (builder *SearchQueryBuilder) snapshotKey() (string, bool) {
+	if builder.instanceID == nil || *builder.instanceID == "" ||
+		builder.columns != ColumnsEvent ||
+		builder.limit > 0 ||
+		builder.offset > 0 ||
+		builder.desc ||
+		builder.tx != nil ||
+		builder.allowTimeTravel ||
+		builder.awaitOpenTransactions ||
+		builder.positionAfter > 0 ||
+		builder.eventSequenceGreater > 0 ||
+		!builder.creationDateAfter.IsZero() ||
+		!builder.creationDateBefore.IsZero() ||
+		len(builder.excludedInstanceIDs) > 0 {
+		return "", false
+	}
+
+	key := struct {
+		ResourceOwner string
+		EditorUser    string
+		Queries       []any
+	}{
+		ResourceOwner: builder.resourceOwner,
+		EditorUser:    builder.editorUser,
+		Queries:       make([]any, len(builder.queries)),
+	}
+	for i, query := range builder.queries {
+		key.Queries[i] = []any{query.aggregateTypes, query.aggregateIDs, query.eventTypes, query.eventData}
+	}
+	data, err := json.Marshal(key)
+	if err != nil {
+		return "", false
+	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), true
+}
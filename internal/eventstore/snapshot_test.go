@@ -0,0 +1,458 @@
+package eventstore
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+)
+
+type testSnapshotWriteModel struct {
+	WriteModel
+
+	Count int
+	Types []EventType
+	// version is returned by SnapshotVersion
+	version uint16
+}
+
+func newTestSnapshotWriteModel(version uint16) *testSnapshotWriteModel {
+	return &testSnapshotWriteModel{
+		WriteModel: WriteModel{
+			AggregateID:   "id",
+			ResourceOwner: "ro",
+		},
+		version: version,
+	}
+}
+
+func (wm *testSnapshotWriteModel) Reduce() error {
+	for _, event := range wm.WriteModel.Events {
+		wm.Count++
+		wm.Types = append(wm.Types, event.Type())
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *testSnapshotWriteModel) Query() *SearchQueryBuilder {
+	return NewSearchQueryBuilder(ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes("test").
+		AggregateIDs(wm.AggregateID).
+		Builder()
+}
+
+func (wm *testSnapshotWriteModel) SnapshotVersion() uint16 {
+	return wm.version
+}
+
+// positionQuerier only returns the events after the position of the query
+type positionQuerier struct {
+	testQuerier
+	positionAfter float64
+}
+
+func (repo *positionQuerier) FilterToReducer(ctx context.Context, searchQuery *SearchQueryBuilder, reduce Reducer) error {
+	repo.positionAfter = searchQuery.GetPositionAfter()
+	for _, event := range repo.events {
+		if event.Position() <= repo.positionAfter {
+			continue
+		}
+		if err := reduce(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type testSnapshotStorage struct {
+	snapshot *Snapshot
+	err      error
+	stored   *Snapshot
+}
+
+func (s *testSnapshotStorage) Snapshot(context.Context, string, string, string) (*Snapshot, error) {
+	return s.snapshot, s.err
+}
+
+func (s *testSnapshotStorage) StoreSnapshot(_ context.Context, snapshot *Snapshot) error {
+	s.stored = snapshot
+	return s.err
+}
+
+func snapshotTestEvents(count int, creationDate time.Time) []Event {
+	events := make([]Event, count)
+	for i := range events {
+		events[i] = &BaseEvent{
+			Agg: &Aggregate{
+				ID:            "id",
+				Type:          "test",
+				ResourceOwner: "ro",
+				InstanceID:    "instance",
+			},
+			EventType: EventType("test.event" + string(rune('1'+i))),
+			Seq:       uint64(i + 1),
+			Pos:       float64(i + 1),
+			Creation:  creationDate,
+		}
+	}
+	return events
+}
+
+func mustSnapshotPayload(t *testing.T, version uint16, events ...Event) []byte {
+	wm := newTestSnapshotWriteModel(version)
+	wm.AppendEvents(events...)
+	require.NoError(t, wm.Reduce())
+	payload, err := takeSnapshot(wm)
+	require.NoError(t, err)
+	return payload
+}
+
+func TestEventstore_FilterToQueryReducer_snapshot(t *testing.T) {
+	ctx := authz.WithInstanceID(context.Background(), "instance")
+	oldEvents := snapshotTestEvents(4, time.Now().Add(-time.Hour))
+	type fields struct {
+		config  SnapshotConfig
+		storage *testSnapshotStorage
+		events  []Event
+	}
+	type want struct {
+		count         int
+		sequence      uint64
+		positionAfter float64
+		stored        bool
+		storedCount   int
+		// storedVersion is the version of the model if zero
+		storedVersion uint16
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		version uint16
+		want    want
+	}{
+		{
+			name: "no snapshot, snapshot stored",
+			fields: fields{
+				config:  SnapshotConfig{Enabled: true, MinEvents: 2},
+				storage: &testSnapshotStorage{},
+				events:  oldEvents,
+			},
+			version: 1,
+			want: want{
+				count:       4,
+				sequence:    4,
+				stored:      true,
+				storedCount: 4,
+			},
+		},
+		{
+			name: "too few events, snapshot not stored",
+			fields: fields{
+				config:  SnapshotConfig{Enabled: true, MinEvents: 5},
+				storage: &testSnapshotStorage{},
+				events:  oldEvents,
+			},
+			version: 1,
+			want: want{
+				count:    4,
+				sequence: 4,
+			},
+		},
+		{
+			name: "recent events, snapshot not stored",
+			fields: fields{
+				config:  SnapshotConfig{Enabled: true, MinAge: time.Minute},
+				storage: &testSnapshotStorage{},
+				events:  snapshotTestEvents(4, time.Now()),
+			},
+			version: 1,
+			want: want{
+				count:    4,
+				sequence: 4,
+			},
+		},
+		{
+			name: "snapshot restored",
+			fields: fields{
+				config: SnapshotConfig{Enabled: true, MinEvents: 1},
+				storage: &testSnapshotStorage{
+					snapshot: &Snapshot{
+						Version:  1,
+						Position: 2,
+						Payload:  mustSnapshotPayload(t, 1, oldEvents[:2]...),
+					},
+				},
+				events: oldEvents,
+			},
+			version: 1,
+			want: want{
+				count:         4,
+				sequence:      4,
+				positionAfter: 1.9999999999999998,
+				stored:        true,
+				storedCount:   4,
+			},
+		},
+		{
+			name: "snapshot restored, no new events",
+			fields: fields{
+				config: SnapshotConfig{Enabled: true, MinEvents: 1},
+				storage: &testSnapshotStorage{
+					snapshot: &Snapshot{
+						Version:  1,
+						Position: 4,
+						Payload:  mustSnapshotPayload(t, 1, oldEvents...),
+					},
+				},
+				events: oldEvents,
+			},
+			version: 1,
+			want: want{
+				count:         4,
+				sequence:      4,
+				positionAfter: 3.9999999999999996,
+			},
+		},
+		{
+			name: "outdated snapshot version ignored",
+			fields: fields{
+				config: SnapshotConfig{Enabled: true, MinEvents: 1},
+				storage: &testSnapshotStorage{
+					snapshot: &Snapshot{
+						Version:  1,
+						Position: 2,
+						Payload:  mustSnapshotPayload(t, 1, oldEvents[:2]...),
+					},
+				},
+				events: oldEvents,
+			},
+			version: 2,
+			want: want{
+				count:       4,
+				sequence:    4,
+				stored:      true,
+				storedCount: 4,
+			},
+		},
+		{
+			name: "invalid snapshot ignored",
+			fields: fields{
+				config: SnapshotConfig{Enabled: true, MinEvents: 1},
+				storage: &testSnapshotStorage{
+					snapshot: &Snapshot{
+						Version:  1,
+						Position: 2,
+						Payload:  []byte(`{"metadata":{},"model":{"Count":"2"}}`),
+					},
+				},
+				events: oldEvents,
+			},
+			version: 1,
+			want: want{
+				count:       4,
+				sequence:    4,
+				stored:      true,
+				storedCount: 4,
+			},
+		},
+		{
+			name: "model enabled, defaults disabled",
+			fields: fields{
+				config: SnapshotConfig{MinEvents: 2, Models: map[string]SnapshotModelConfig{
+					"testsnapshotwritemodel": {Enabled: true},
+				}},
+				storage: &testSnapshotStorage{},
+				events:  oldEvents,
+			},
+			version: 1,
+			want: want{
+				count:       4,
+				sequence:    4,
+				stored:      true,
+				storedCount: 4,
+			},
+		},
+		{
+			name: "model disabled, defaults enabled",
+			fields: fields{
+				config: SnapshotConfig{Enabled: true, MinEvents: 2, Models: map[string]SnapshotModelConfig{
+					"TestSnapshotWriteModel": {Enabled: false},
+				}},
+				storage: &testSnapshotStorage{},
+				events:  oldEvents,
+			},
+			version: 1,
+			want: want{
+				count:    4,
+				sequence: 4,
+			},
+		},
+		{
+			name: "model min events overwrites defaults",
+			fields: fields{
+				config: SnapshotConfig{Enabled: true, MinEvents: 2, Models: map[string]SnapshotModelConfig{
+					"testSnapshotWriteModel": {Enabled: true, MinEvents: 5},
+				}},
+				storage: &testSnapshotStorage{},
+				events:  oldEvents,
+			},
+			version: 1,
+			want: want{
+				count:    4,
+				sequence: 4,
+			},
+		},
+		{
+			name: "model version ignores snapshot of code version",
+			fields: fields{
+				config: SnapshotConfig{MinEvents: 1, Models: map[string]SnapshotModelConfig{
+					"testSnapshotWriteModel": {Enabled: true, Version: 1},
+				}},
+				storage: &testSnapshotStorage{
+					snapshot: &Snapshot{
+						Version:  1,
+						Position: 2,
+						Payload:  mustSnapshotPayload(t, 1, oldEvents[:2]...),
+					},
+				},
+				events: oldEvents,
+			},
+			version: 1,
+			want: want{
+				count:         4,
+				sequence:      4,
+				stored:        true,
+				storedCount:   4,
+				storedVersion: 2,
+			},
+		},
+		{
+			name: "storage error ignored",
+			fields: fields{
+				config:  SnapshotConfig{Enabled: true, MinEvents: 1},
+				storage: &testSnapshotStorage{err: errors.New("storage error")},
+				events:  oldEvents,
+			},
+			version: 1,
+			want: want{
+				count:       4,
+				sequence:    4,
+				stored:      true,
+				storedCount: 4,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			querier := &positionQuerier{testQuerier: testQuerier{events: tt.fields.events}}
+			es := NewEventstore(&Config{
+				Querier:         querier,
+				Snapshots:       tt.fields.config,
+				SnapshotStorage: tt.fields.storage,
+			})
+			wm := newTestSnapshotWriteModel(tt.version)
+			require.NoError(t, es.FilterToQueryReducer(ctx, wm))
+
+			assert.Equal(t, tt.want.count, wm.Count)
+			assert.Len(t, wm.Types, tt.want.count)
+			assert.Equal(t, tt.want.sequence, wm.ProcessedSequence)
+			assert.Equal(t, "instance", wm.InstanceID)
+			assert.Equal(t, tt.want.positionAfter, querier.positionAfter)
+			if !tt.want.stored {
+				assert.Nil(t, tt.fields.storage.stored)
+				return
+			}
+			require.NotNil(t, tt.fields.storage.stored)
+			assert.Equal(t, "instance", tt.fields.storage.stored.InstanceID)
+			assert.Equal(t, "*eventstore.testSnapshotWriteModel", tt.fields.storage.stored.Model)
+			if tt.want.storedVersion == 0 {
+				tt.want.storedVersion = tt.version
+			}
+			assert.Equal(t, tt.want.storedVersion, tt.fields.storage.stored.Version)
+			assert.Equal(t, float64(4), tt.fields.storage.stored.Position)
+
+			restored := newTestSnapshotWriteModel(tt.version)
+			require.NoError(t, restoreSnapshot(restored, tt.fields.storage.stored.Payload))
+			assert.Equal(t, tt.want.storedCount, restored.Count)
+			assert.Equal(t, wm.ProcessedSequence, restored.ProcessedSequence)
+			assert.Equal(t, wm.ChangeDate.UTC(), restored.ChangeDate.UTC())
+		})
+	}
+}
+
+func TestEventstore_FilterToQueryReducer_snapshotsDisabled(t *testing.T) {
+	ctx := authz.WithInstanceID(context.Background(), "instance")
+	storage := &testSnapshotStorage{}
+	es := NewEventstore(&Config{
+		Querier:         &positionQuerier{testQuerier: testQuerier{events: snapshotTestEvents(4, time.Now().Add(-time.Hour))}},
+		Snapshots:       SnapshotConfig{Enabled: false},
+		SnapshotStorage: storage,
+	})
+	wm := newTestSnapshotWriteModel(1)
+	require.NoError(t, es.FilterToQueryReducer(ctx, wm))
+	assert.Equal(t, 4, wm.Count)
+	assert.Nil(t, storage.stored)
+}
+
+func TestSearchQueryBuilder_snapshotKey(t *testing.T) {
+	query := func(aggregateID string) *SearchQueryBuilder {
+		return NewSearchQueryBuilder(ColumnsEvent).
+			InstanceID("instance").
+			ResourceOwner("ro").
+			AddQuery().
+			AggregateTypes("test").
+			AggregateIDs(aggregateID).
+			Builder()
+	}
+	key, ok := query("id").snapshotKey()
+	require.True(t, ok)
+	sameKey, ok := query("id").snapshotKey()
+	require.True(t, ok)
+	assert.Equal(t, key, sameKey)
+	otherKey, ok := query("other").snapshotKey()
+	require.True(t, ok)
+	assert.NotEqual(t, key, otherKey)
+
+	tests := []struct {
+		name  string
+		query *SearchQueryBuilder
+	}{
+		{
+			name:  "no instance",
+			query: NewSearchQueryBuilder(ColumnsEvent).AddQuery().AggregateTypes("test").Builder(),
+		},
+		{
+			name:  "limit",
+			query: query("id").Limit(1),
+		},
+		{
+			name:  "descending",
+			query: query("id").OrderDesc(),
+		},
+		{
+			name:  "position",
+			query: query("id").PositionAfter(1),
+		},
+		{
+			name:  "sequence",
+			query: query("id").SequenceGreater(1),
+		},
+		{
+			name:  "columns",
+			query: query("id").Columns(ColumnsMaxSequence),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, ok := tt.query.snapshotKey()
+			assert.False(t, ok)
+		})
+	}
+}
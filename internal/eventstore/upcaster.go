@@ -0,0 +1,108 @@
+package eventstore
+
+import (
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+// Upcaster transforms the payload of an event of a revision into the payload of the next revision
+type Upcaster func(payload []byte) ([]byte, error)
+
+// JSONUpcaster upcasts the payload as json object,
+// e.g. to rename, move or default fields
+func JSONUpcaster(upcast func(payload map[string]any) error) Upcaster {
+	return func(data []byte) ([]byte, error) {
+		payload := make(map[string]any)
+		if len(data) > 0 {
+			if err := json.Unmarshal(data, &payload); err != nil {
+				return nil, err
+			}
+		}
+		if err := upcast(payload); err != nil {
+			return nil, err
+		}
+		return json.Marshal(payload)
+	}
+}
+
+type upcasterKey struct {
+	aggregateType AggregateType
+	eventType     EventType
+	revision      uint16
+}
+
+// RegisterUpcaster registers the upcaster transforming the payloads of the events from revision to revision+1.
+// Stored events are upcasted revision by revision until no upcaster of the revision is registered,
+// before they are mapped by the event mapper.
+func (es *Eventstore) RegisterUpcaster(aggregateType AggregateType, eventType EventType, revision uint16, upcaster Upcaster) *Eventstore {
+	if upcaster == nil || eventType == "" {
+		return es
+	}
+	es.interceptorMutex.Lock()
+	defer es.interceptorMutex.Unlock()
+
+	if es.upcasters == nil {
+		es.upcasters = make(map[upcasterKey]Upcaster)
+	}
+	es.upcasters[upcasterKey{aggregateType: aggregateType, eventType: eventType, revision: revision}] = upcaster
+
+	return es
+}
+
+// upcastLocked transforms the payload of the event to the latest revision.
+// The caller must hold the read lock of the interceptors.
+func (es *Eventstore) upcastLocked(event Event) (Event, error) {
+	if len(es.upcasters) == 0 {
+		return event, nil
+	}
+	key := upcasterKey{
+		aggregateType: event.Aggregate().Type,
+		eventType:     event.Type(),
+		revision:      event.Revision(),
+	}
+	upcaster, ok := es.upcasters[key]
+	if !ok {
+		return event, nil
+	}
+	upcasted := &upcastedEvent{
+		Event:    event,
+		revision: key.revision,
+		payload:  event.DataAsBytes(),
+	}
+	for ; ok; upcaster, ok = es.upcasters[key] {
+		payload, err := upcaster(upcasted.payload)
+		if err != nil {
+			return nil, errors.ThrowInternalf(err, "V2-Oog4i", "unable to upcast event %s of revision %d", key.eventType, key.revision)
+		}
+		key.revision++
+		upcasted.payload = payload
+		upcasted.revision = key.revision
+	}
+	return upcasted, nil
+}
+
+// upcastedEvent is a stored event with the payload of a later revision
+type upcastedEvent struct {
+	Event
+	revision uint16
+	payload  []byte
+}
+
+// Revision implements [Event]
+func (e *upcastedEvent) Revision() uint16 {
+	return e.revision
+}
+
+// DataAsBytes implements [Event]
+func (e *upcastedEvent) DataAsBytes() []byte {
+	return e.payload
+}
+
+// Unmarshal implements [Event]
+func (e *upcastedEvent) Unmarshal(ptr any) error {
+	if len(e.payload) == 0 {
+		return nil
+	}
+	return json.Unmarshal(e.payload, ptr)
+}
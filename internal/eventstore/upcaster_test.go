@@ -0,0 +1,161 @@
+package eventstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	zerrors "github.com/zitadel/zitadel/internal/errors"
+)
+
+// storedEvent is an event as returned by the querier
+type storedEvent struct {
+	aggregate *Aggregate
+	typ       EventType
+	revision  uint16
+	payload   []byte
+}
+
+func (e *storedEvent) Aggregate() *Aggregate { return e.aggregate }
+func (e *storedEvent) Creator() string       { return "creator" }
+func (e *storedEvent) Type() EventType       { return e.typ }
+func (e *storedEvent) Revision() uint16      { return e.revision }
+func (e *storedEvent) Sequence() uint64      { return 1 }
+func (e *storedEvent) CreatedAt() time.Time  { return time.Time{} }
+func (e *storedEvent) Position() float64     { return 1 }
+func (e *storedEvent) DataAsBytes() []byte   { return e.payload }
+func (e *storedEvent) Unmarshal(ptr any) error {
+	if len(e.payload) == 0 {
+		return nil
+	}
+	return json.Unmarshal(e.payload, ptr)
+}
+
+// upcastTestEvent is the current shape (revision 2) of the event
+type upcastTestEvent struct {
+	*BaseEvent `json:"-"`
+
+	DisplayName string `json:"displayName"`
+	Active      bool   `json:"active"`
+}
+
+func (e *upcastTestEvent) SetBaseEvent(event *BaseEvent) {
+	e.BaseEvent = event
+}
+
+func upcastTestEventstore() *Eventstore {
+	es := NewEventstore(&Config{})
+	es.RegisterFilterEventMapper("test.aggregate", "test.added", GenericEventMapper[upcastTestEvent])
+	// revision 0 stored the display name as name
+	es.RegisterUpcaster("test.aggregate", "test.added", 0, JSONUpcaster(func(payload map[string]any) error {
+		payload["displayName"] = payload["name"]
+		delete(payload, "name")
+		return nil
+	}))
+	// revision 1 had no active flag, all objects were active
+	es.RegisterUpcaster("test.aggregate", "test.added", 1, JSONUpcaster(func(payload map[string]any) error {
+		payload["active"] = true
+		return nil
+	}))
+	return es
+}
+
+func TestEventstore_upcast(t *testing.T) {
+	aggregate := &Aggregate{Type: "test.aggregate", ID: "id"}
+	tests := []struct {
+		name         string
+		event        *storedEvent
+		want         *upcastTestEvent
+		wantRevision uint16
+	}{
+		{
+			name: "revision 0",
+			event: &storedEvent{
+				aggregate: aggregate,
+				typ:       "test.added",
+				revision:  0,
+				payload:   []byte(`{"name": "zitadel"}`),
+			},
+			want: &upcastTestEvent{
+				DisplayName: "zitadel",
+				Active:      true,
+			},
+			wantRevision: 2,
+		},
+		{
+			name: "revision 1",
+			event: &storedEvent{
+				aggregate: aggregate,
+				typ:       "test.added",
+				revision:  1,
+				payload:   []byte(`{"displayName": "zitadel"}`),
+			},
+			want: &upcastTestEvent{
+				DisplayName: "zitadel",
+				Active:      true,
+			},
+			wantRevision: 2,
+		},
+		{
+			name: "current revision",
+			event: &storedEvent{
+				aggregate: aggregate,
+				typ:       "test.added",
+				revision:  2,
+				payload:   []byte(`{"displayName": "zitadel", "active": false}`),
+			},
+			want: &upcastTestEvent{
+				DisplayName: "zitadel",
+				Active:      false,
+			},
+			wantRevision: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := upcastTestEventstore()
+
+			upcasted, err := es.upcastLocked(tt.event)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRevision, upcasted.Revision())
+
+			mapped, err := es.mapEvent(context.Background(), tt.event)
+			require.NoError(t, err)
+			got, ok := mapped.(*upcastTestEvent)
+			require.True(t, ok, "unexpected type of mapped event: %T", mapped)
+			assert.Equal(t, tt.want.DisplayName, got.DisplayName)
+			assert.Equal(t, tt.want.Active, got.Active)
+		})
+	}
+}
+
+func TestEventstore_upcast_otherAggregate(t *testing.T) {
+	es := upcastTestEventstore()
+	event := &storedEvent{
+		aggregate: &Aggregate{Type: "other.aggregate"},
+		typ:       "test.added",
+		payload:   []byte(`{"name": "zitadel"}`),
+	}
+
+	upcasted, err := es.upcastLocked(event)
+	require.NoError(t, err)
+	assert.Same(t, event, upcasted)
+}
+
+func TestEventstore_upcast_error(t *testing.T) {
+	es := NewEventstore(&Config{})
+	es.RegisterUpcaster("test.aggregate", "test.added", 0, func([]byte) ([]byte, error) {
+		return nil, errors.New("failed")
+	})
+
+	_, err := es.mapEvent(context.Background(), &storedEvent{
+		aggregate: &Aggregate{Type: "test.aggregate"},
+		typ:       "test.added",
+	})
+	assert.True(t, zerrors.IsInternal(err), "expected internal error, got: %v", err)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
 )
 
 var (
@@ -15,9 +16,11 @@ var (
 
 type Eventstore struct {
 	client *database.DB
+	// personalDataKeys seal the personal data of the commands, it's nil if crypto-shredding is disabled
+	personalDataKeys eventstore.PersonalDataKeys
 }
 
-func NewEventstore(client *database.DB) *Eventstore {
+func NewEventstore(client *database.DB, personalDataKeys eventstore.PersonalDataKeys) *Eventstore {
 	switch client.Type() {
 	case "cockroach":
 		pushPlaceholderFmt = "($%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, hlc_to_timestamp(cluster_logical_timestamp()), cluster_logical_timestamp(), $%d)"
@@ -27,7 +30,7 @@ func NewEventstore(client *database.DB) *Eventstore {
 		uniqueConstraintPlaceholderFmt = "(%s, %s, %s)"
 	}
 
-	return &Eventstore{client: client}
+	return &Eventstore{client: client, personalDataKeys: personalDataKeys}
 }
 
 func (es *Eventstore) Health(ctx context.Context) error {
@@ -0,0 +1,43 @@
+package eventstore
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+//go:embed expected_sequence.sql
+var expectedSequenceStmt string
+
+// checkExpectedSequences ensures no event of the expected types was pushed
+// to the aggregates of the commands after their expected sequence.
+// It must be called after [latestSequences], which locks the aggregates until the transaction ends.
+func checkExpectedSequences(ctx context.Context, tx *sql.Tx, commands []eventstore.Command) error {
+	checked := make(map[*eventstore.Aggregate]bool)
+	for _, command := range commands {
+		aggregate := command.Aggregate()
+		if len(aggregate.ExpectedEventTypes) == 0 || checked[aggregate] {
+			continue
+		}
+		checked[aggregate] = true
+
+		var sequence uint64
+		err := tx.QueryRowContext(ctx, expectedSequenceStmt,
+			aggregate.InstanceID,
+			aggregate.Type,
+			aggregate.ID,
+			database.TextArray[eventstore.EventType](aggregate.ExpectedEventTypes),
+		).Scan(&sequence)
+		if err != nil {
+			return errors.ThrowInternal(err, "V3-Quo9a", "Errors.Internal")
+		}
+		if sequence != aggregate.ExpectedSequence {
+			return errors.ThrowPreconditionFailed(nil, "V3-eiX5u", "Errors.Eventstore.ConcurrentModification")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,9 @@
+SELECT
+    COALESCE(MAX("sequence"), 0)
+FROM
+    eventstore.events2
+WHERE
+    instance_id = $1
+    AND aggregate_type = $2
+    AND aggregate_id = $3
+    AND event_type = ANY($4)
@@ -0,0 +1,71 @@
+package eventstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+func Test_checkExpectedSequences(t *testing.T) {
+	tests := []struct {
+		name     string
+		commands []eventstore.Command
+		sequence uint64
+		queried  bool
+		wantErr  func(error) bool
+	}{
+		{
+			name: "no expectation",
+			commands: []eventstore.Command{
+				&mockCommand{aggregate: mockAggregate("V3-oos1A")},
+			},
+		},
+		{
+			name: "expected sequence",
+			commands: []eventstore.Command{
+				&mockCommand{aggregate: mockAggregate("V3-oos1A").ExpectSequence(5, "type.added", "type.removed")},
+			},
+			sequence: 5,
+			queried:  true,
+		},
+		{
+			name: "concurrent modification",
+			commands: []eventstore.Command{
+				&mockCommand{aggregate: mockAggregate("V3-oos1A").ExpectSequence(5, "type.added", "type.removed")},
+			},
+			sequence: 6,
+			queried:  true,
+			wantErr:  errors.IsPreconditionFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			require.NoError(t, err)
+			defer db.Close()
+			mock.ExpectBegin()
+			if tt.queried {
+				mock.ExpectQuery(expectedSequenceStmt).
+					WithArgs("instance", eventstore.AggregateType("type"), "V3-oos1A", database.TextArray[eventstore.EventType]{"type.added", "type.removed"}).
+					WillReturnRows(sqlmock.NewRows([]string{"max"}).AddRow(tt.sequence))
+			}
+			tx, err := db.Begin()
+			require.NoError(t, err)
+
+			err = checkExpectedSequences(context.Background(), tx, tt.commands)
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err))
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
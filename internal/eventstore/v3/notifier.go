@@ -0,0 +1,220 @@
+package eventstore
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/jackc/pgx/v4/stdlib"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	// notificationChannel is the channel of LISTEN / NOTIFY the pushed events are propagated on
+	notificationChannel = "zitadel_events"
+	// maxNotificationsPerPayload keeps the payload below the limit of 8000 bytes of NOTIFY
+	maxNotificationsPerPayload = 50
+
+	defaultPollInterval = time.Second
+)
+
+// NewNotifier returns the notifier defined by the config or nil if pushed events are not propagated.
+// CockroachDB does not support LISTEN / NOTIFY, the pushed events are polled instead.
+func NewNotifier(client *database.DB, config eventstore.NotifierConfig) eventstore.Notifier {
+	switch config.Type {
+	case eventstore.NotifierTypeListen:
+		if client.Type() == "postgres" {
+			return newListenNotifier(client)
+		}
+		logging.WithFields("database", client.Type()).Info("LISTEN / NOTIFY not supported, pushed events are polled")
+		return newPollNotifier(client, config.PollInterval)
+	case eventstore.NotifierTypePoll:
+		return newPollNotifier(client, config.PollInterval)
+	case "":
+		return nil
+	default:
+		logging.WithFields("type", config.Type).Warn("unknown notifier type, pushed events are not propagated")
+		return nil
+	}
+}
+
+type listenNotifier struct {
+	client *database.DB
+	// node identifies the notifications of this node,
+	// which were already passed to the local subscriptions
+	node string
+}
+
+type notificationPayload struct {
+	Node          string                    `json:"node"`
+	Notifications []eventstore.Notification `json:"notifications"`
+}
+
+func newListenNotifier(client *database.DB) *listenNotifier {
+	node := make([]byte, 8)
+	_, err := rand.Read(node)
+	logging.OnError(err).Warn("unable to generate node id for notifications")
+	return &listenNotifier{
+		client: client,
+		node:   hex.EncodeToString(node),
+	}
+}
+
+// Notify implements [eventstore.Notifier]
+func (n *listenNotifier) Notify(ctx context.Context, notifications []eventstore.Notification) error {
+	for len(notifications) > 0 {
+		chunk := notifications
+		if len(chunk) > maxNotificationsPerPayload {
+			chunk = chunk[:maxNotificationsPerPayload]
+		}
+		notifications = notifications[len(chunk):]
+
+		payload, err := json.Marshal(&notificationPayload{Node: n.node, Notifications: chunk})
+		if err != nil {
+			return err
+		}
+		if _, err = n.client.ExecContext(ctx, "SELECT pg_notify($1, $2)", notificationChannel, string(payload)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Listen implements [eventstore.Notifier]
+// it occupies a connection of the pool as long as it listens
+func (n *listenNotifier) Listen(ctx context.Context, receive func(eventstore.Notification)) error {
+	conn, err := n.client.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return conn.Raw(func(driverConn any) error {
+		stdConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return errors.ThrowInternal(nil, "V3-oo5Ae", "Errors.Internal")
+		}
+		pgxConn := stdConn.Conn()
+		if _, err := pgxConn.Exec(ctx, "LISTEN "+notificationChannel); err != nil {
+			return err
+		}
+		defer func() {
+			unlistenCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			_, err := pgxConn.Exec(unlistenCtx, "UNLISTEN "+notificationChannel)
+			logging.OnError(err).Debug("unlisten failed")
+		}()
+		for {
+			notification, err := pgxConn.WaitForNotification(ctx)
+			if err != nil {
+				return err
+			}
+			n.receive(notification.Payload, receive)
+		}
+	})
+}
+
+func (n *listenNotifier) receive(data string, receive func(eventstore.Notification)) {
+	payload := new(notificationPayload)
+	if err := json.Unmarshal([]byte(data), payload); err != nil {
+		logging.WithError(err).Warn("unable to decode notification")
+		return
+	}
+	if payload.Node == n.node {
+		return
+	}
+	for _, notification := range payload.Notifications {
+		receive(notification)
+	}
+}
+
+// pollNotifier queries the events pushed since the last poll.
+// It does not distinguish the events of the own node
+// and events of transactions committed after a later poll are only handled by the interval of the projections.
+type pollNotifier struct {
+	client   *database.DB
+	interval time.Duration
+}
+
+func newPollNotifier(client *database.DB, interval time.Duration) *pollNotifier {
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	return &pollNotifier{
+		client:   client,
+		interval: interval,
+	}
+}
+
+// Notify implements [eventstore.Notifier]
+// the pushed events are found by polling
+func (*pollNotifier) Notify(context.Context, []eventstore.Notification) error {
+	return nil
+}
+
+// Listen implements [eventstore.Notifier]
+func (n *pollNotifier) Listen(ctx context.Context, receive func(eventstore.Notification)) (err error) {
+	// the creation dates of the events are set by the database,
+	// the clock of this node can differ
+	since, err := n.databaseTime(ctx)
+	if err != nil {
+		return err
+	}
+	ticker := time.NewTicker(n.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			since, err = n.poll(ctx, since, receive)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+//go:embed notifier_poll.sql
+var pollNotificationsStmt string
+
+// databaseTimeStmt returns the current time of the database in the same way the creation date of pushed events is set
+const databaseTimeStmt = "SELECT statement_timestamp()"
+
+func (n *pollNotifier) databaseTime(ctx context.Context) (now time.Time, err error) {
+	err = n.client.QueryRowContext(ctx, func(row *sql.Row) error {
+		return row.Scan(&now)
+	}, databaseTimeStmt)
+	return now, err
+}
+
+// poll passes a notification for each instance and aggregate type with events created after since
+// and returns the creation date of the latest event
+func (n *pollNotifier) poll(ctx context.Context, since time.Time, receive func(eventstore.Notification)) (latest time.Time, err error) {
+	latest = since
+	err = n.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		for rows.Next() {
+			var (
+				notification eventstore.Notification
+				createdAt    time.Time
+			)
+			if err := rows.Scan(&notification.InstanceID, &notification.AggregateType, &createdAt); err != nil {
+				return err
+			}
+			if createdAt.After(latest) {
+				latest = createdAt
+			}
+			receive(notification)
+		}
+		return nil
+	}, pollNotificationsStmt, since)
+	return latest, err
+}
@@ -0,0 +1,11 @@
+SELECT
+    instance_id
+    , aggregate_type
+    , MAX(created_at)
+FROM
+    eventstore.events2
+WHERE
+    created_at > $1
+GROUP BY
+    instance_id
+    , aggregate_type;
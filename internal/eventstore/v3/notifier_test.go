@@ -0,0 +1,131 @@
+package eventstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+func Test_listenNotifier_receive(t *testing.T) {
+	notifications := []eventstore.Notification{
+		{InstanceID: "instance1", AggregateType: "user"},
+		{InstanceID: "instance2", AggregateType: "org"},
+	}
+	tests := []struct {
+		name string
+		data string
+		want []eventstore.Notification
+	}{
+		{
+			name: "invalid payload",
+			data: "{",
+			want: nil,
+		},
+		{
+			name: "own node",
+			data: mustNotificationPayload(t, "node1", notifications),
+			want: nil,
+		},
+		{
+			name: "other node",
+			data: mustNotificationPayload(t, "node2", notifications),
+			want: notifications,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := &listenNotifier{node: "node1"}
+			var got []eventstore.Notification
+			n.receive(tt.data, func(notification eventstore.Notification) {
+				got = append(got, notification)
+			})
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_listenNotifier_Notify(t *testing.T) {
+	notifications := make([]eventstore.Notification, maxNotificationsPerPayload+1)
+	for i := range notifications {
+		notifications[i] = eventstore.Notification{InstanceID: fmt.Sprintf("instance%d", i), AggregateType: "user"}
+	}
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+	mock.ExpectExec("SELECT pg_notify($1, $2)").
+		WithArgs(notificationChannel, mustNotificationPayload(t, "node1", notifications[:maxNotificationsPerPayload])).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("SELECT pg_notify($1, $2)").
+		WithArgs(notificationChannel, mustNotificationPayload(t, "node1", notifications[maxNotificationsPerPayload:])).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	n := &listenNotifier{client: &database.DB{DB: db}, node: "node1"}
+	require.NoError(t, n.Notify(context.Background(), notifications))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_pollNotifier_poll(t *testing.T) {
+	since := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	latest := since.Add(time.Second)
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+	mock.ExpectBegin()
+	mock.ExpectQuery(pollNotificationsStmt).
+		WithArgs(since).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"instance_id", "aggregate_type", "max"}).
+				AddRow("instance1", "user", latest).
+				AddRow("instance2", "org", since.Add(time.Millisecond)),
+		)
+	mock.ExpectCommit()
+
+	n := newPollNotifier(&database.DB{DB: db}, 0)
+	var got []eventstore.Notification
+	gotLatest, err := n.poll(context.Background(), since, func(notification eventstore.Notification) {
+		got = append(got, notification)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, latest, gotLatest)
+	assert.Equal(t, []eventstore.Notification{
+		{InstanceID: "instance1", AggregateType: "user"},
+		{InstanceID: "instance2", AggregateType: "org"},
+	}, got)
+	assert.Equal(t, defaultPollInterval, n.interval)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_pollNotifier_databaseTime(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+	mock.ExpectBegin()
+	mock.ExpectQuery(databaseTimeStmt).
+		WillReturnRows(sqlmock.NewRows([]string{"statement_timestamp"}).AddRow(now))
+	mock.ExpectCommit()
+
+	n := newPollNotifier(&database.DB{DB: db}, 0)
+	got, err := n.databaseTime(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, now, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func mustNotificationPayload(t *testing.T, node string, notifications []eventstore.Notification) string {
+	payload, err := json.Marshal(&notificationPayload{Node: node, Notifications: notifications})
+	require.NoError(t, err)
+	return string(payload)
+}
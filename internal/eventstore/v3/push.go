@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	_ "embed"
+	"encoding/json"
 	errs "errors"
 	"fmt"
 	"strconv"
@@ -37,7 +38,16 @@ func (es *Eventstore) Push(ctx context.Context, commands ...eventstore.Command)
 			return err
 		}
 
-		events, err = insertEvents(ctx, tx, sequences, commands)
+		if err = checkExpectedSequences(ctx, tx, commands); err != nil {
+			return err
+		}
+
+		sealed, err := sealPersonalData(ctx, tx, es.personalDataKeys, commands)
+		if err != nil {
+			return err
+		}
+
+		events, err = insertEvents(ctx, tx, sequences, sealed)
 		if err != nil {
 			return err
 		}
@@ -55,6 +65,43 @@ func (es *Eventstore) Push(ctx context.Context, commands ...eventstore.Command)
 //go:embed push.sql
 var pushStmt string
 
+// sealPersonalData seals the personal data of the commands in the transaction of the push,
+// so the keys created for the commands are only stored if the events are
+func sealPersonalData(ctx context.Context, tx *sql.Tx, keys eventstore.PersonalDataKeys, commands []eventstore.Command) ([]eventstore.Command, error) {
+	if keys == nil {
+		return commands, nil
+	}
+	sealed := make([]eventstore.Command, len(commands))
+	for i, command := range commands {
+		sealed[i] = command
+		personalDataCommand, ok := command.(eventstore.PersonalDataCommand)
+		if !ok || command.Payload() == nil {
+			continue
+		}
+		payload, err := json.Marshal(command.Payload())
+		if err != nil {
+			return nil, errors.ThrowInternal(err, "V3-Wae0o", "Errors.Internal")
+		}
+		payload, err = eventstore.SealPersonalData(ctx, tx, keys, personalDataCommand, payload)
+		if err != nil {
+			return nil, err
+		}
+		sealed[i] = &sealedCommand{Command: command, payload: payload}
+	}
+	return sealed, nil
+}
+
+// sealedCommand is a command with its personal data sealed
+type sealedCommand struct {
+	eventstore.Command
+	payload json.RawMessage
+}
+
+// Payload implements [eventstore.Command]
+func (c *sealedCommand) Payload() any {
+	return c.payload
+}
+
 func insertEvents(ctx context.Context, tx *sql.Tx, sequences []*latestSequence, commands []eventstore.Command) ([]eventstore.Event, error) {
 	events, placeholders, args, err := mapCommands(commands, sequences)
 	if err != nil {
@@ -130,9 +177,14 @@ func mapCommands(commands []eventstore.Command, sequences []*latestSequence) (ev
 			i*argsPerCommand+10,
 		)
 
-		revision, err := strconv.Atoi(strings.TrimPrefix(string(events[i].(*event).aggregate.Version), "v"))
-		if err != nil {
-			return nil, nil, nil, errors.ThrowInternal(err, "V3-JoZEp", "Errors.Internal")
+		// commands define the revision of their payload,
+		// the payloads of commands without revision have not changed since the revision column was filled with the version of the aggregate
+		revision := int(events[i].(*event).revision)
+		if revision == 0 {
+			revision, err = strconv.Atoi(strings.TrimPrefix(string(events[i].(*event).aggregate.Version), "v"))
+			if err != nil {
+				return nil, nil, nil, errors.ThrowInternal(err, "V3-JoZEp", "Errors.Internal")
+			}
 		}
 		args = append(args,
 			events[i].(*event).aggregate.InstanceID,
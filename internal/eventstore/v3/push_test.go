@@ -236,7 +236,7 @@ func Test_mapCommands(t *testing.T) {
 			}
 		}
 		// is used to set the the [pushPlaceholderFmt]
-		NewEventstore(&database.DB{Database: new(cockroach.Config)})
+		NewEventstore(&database.DB{Database: new(cockroach.Config)}, nil)
 		t.Run(tt.name, func(t *testing.T) {
 			defer func() {
 				cause := recover()
@@ -0,0 +1,65 @@
+package eventstore
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	errs "errors"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed snapshot_query.sql
+	snapshotQueryStmt string
+	//go:embed snapshot_store.sql
+	snapshotStoreStmt string
+)
+
+var _ eventstore.SnapshotStorage = (*SnapshotStorage)(nil)
+
+// SnapshotStorage stores the snapshots of the models in eventstore.snapshots
+type SnapshotStorage struct {
+	client *database.DB
+}
+
+func NewSnapshotStorage(client *database.DB) *SnapshotStorage {
+	return &SnapshotStorage{client: client}
+}
+
+// Snapshot implements [eventstore.SnapshotStorage]
+func (s *SnapshotStorage) Snapshot(ctx context.Context, instanceID, model, key string) (*eventstore.Snapshot, error) {
+	snapshot := &eventstore.Snapshot{
+		InstanceID: instanceID,
+		Model:      model,
+		Key:        key,
+	}
+	err := s.client.QueryRowContext(ctx, func(row *sql.Row) error {
+		return row.Scan(&snapshot.Version, &snapshot.Position, &snapshot.Payload)
+	}, snapshotQueryStmt, instanceID, model, key)
+	if errs.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "V3-Oot8a", "Errors.Internal")
+	}
+	return snapshot, nil
+}
+
+// StoreSnapshot implements [eventstore.SnapshotStorage]
+func (s *SnapshotStorage) StoreSnapshot(ctx context.Context, snapshot *eventstore.Snapshot) error {
+	_, err := s.client.ExecContext(ctx, snapshotStoreStmt,
+		snapshot.InstanceID,
+		snapshot.Model,
+		snapshot.Key,
+		snapshot.Version,
+		snapshot.Position,
+		snapshot.Payload,
+	)
+	if err != nil {
+		return errors.ThrowInternal(err, "V3-eeK4a", "Errors.Internal")
+	}
+	return nil
+}
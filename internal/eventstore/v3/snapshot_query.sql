@@ -0,0 +1,10 @@
+SELECT
+    "version"
+    , "position"
+    , payload
+FROM
+    eventstore.snapshots
+WHERE
+    instance_id = $1
+    AND model = $2
+    AND "key" = $3
@@ -0,0 +1,19 @@
+INSERT INTO eventstore.snapshots (
+    instance_id
+    , model
+    , "key"
+    , "version"
+    , "position"
+    , payload
+    , created_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, now()
+) ON CONFLICT (instance_id, model, "key") DO UPDATE SET
+    "version" = EXCLUDED."version"
+    , "position" = EXCLUDED."position"
+    , payload = EXCLUDED.payload
+    , created_at = EXCLUDED.created_at
+-- a concurrently stored snapshot of a later position is kept
+WHERE
+    snapshots."version" <> EXCLUDED."version"
+    OR snapshots."position" < EXCLUDED."position"
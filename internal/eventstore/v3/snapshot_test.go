@@ -0,0 +1,104 @@
+package eventstore
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+func TestSnapshotStorage_Snapshot(t *testing.T) {
+	tests := []struct {
+		name    string
+		expect  func(sqlmock.Sqlmock)
+		want    *eventstore.Snapshot
+		wantErr bool
+	}{
+		{
+			name: "not found",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(snapshotQueryStmt).
+					WithArgs("instance", "model", "key").
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			want: nil,
+		},
+		{
+			name: "error",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(snapshotQueryStmt).
+					WithArgs("instance", "model", "key").
+					WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "found",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(snapshotQueryStmt).
+					WithArgs("instance", "model", "key").
+					WillReturnRows(
+						sqlmock.NewRows([]string{"version", "position", "payload"}).
+							AddRow(2, 1.5, []byte(`{"model":{}}`)),
+					)
+				mock.ExpectCommit()
+			},
+			want: &eventstore.Snapshot{
+				InstanceID: "instance",
+				Model:      "model",
+				Key:        "key",
+				Version:    2,
+				Position:   1.5,
+				Payload:    []byte(`{"model":{}}`),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			require.NoError(t, err)
+			defer db.Close()
+			tt.expect(mock)
+
+			got, err := NewSnapshotStorage(&database.DB{DB: db}).Snapshot(context.Background(), "instance", "model", "key")
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSnapshotStorage_StoreSnapshot(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+	mock.ExpectExec(snapshotStoreStmt).
+		WithArgs("instance", "model", "key", uint16(2), 1.5, []byte(`{"model":{}}`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = NewSnapshotStorage(&database.DB{DB: db}).StoreSnapshot(context.Background(), &eventstore.Snapshot{
+		InstanceID: "instance",
+		Model:      "model",
+		Key:        "key",
+		Version:    2,
+		Position:   1.5,
+		Payload:    []byte(`{"model":{}}`),
+	})
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -45,3 +45,27 @@ func (wm *WriteModel) Reduce() error {
 	wm.Events = []Event{}
 	return nil
 }
+
+func (wm *WriteModel) snapshotMetadata() *snapshotMetadata {
+	return &snapshotMetadata{
+		AggregateID:       wm.AggregateID,
+		ProcessedSequence: wm.ProcessedSequence,
+		ResourceOwner:     wm.ResourceOwner,
+		InstanceID:        wm.InstanceID,
+		ChangeDate:        wm.ChangeDate,
+	}
+}
+
+func (wm *WriteModel) restoreSnapshotMetadata(metadata *snapshotMetadata) {
+	if wm.AggregateID == "" {
+		wm.AggregateID = metadata.AggregateID
+	}
+	if wm.ResourceOwner == "" {
+		wm.ResourceOwner = metadata.ResourceOwner
+	}
+	if wm.InstanceID == "" {
+		wm.InstanceID = metadata.InstanceID
+	}
+	wm.ProcessedSequence = metadata.ProcessedSequence
+	wm.ChangeDate = metadata.ChangeDate
+}
@@ -0,0 +1,112 @@
+package execution
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventsink"
+	"github.com/zitadel/zitadel/internal/logstore"
+	"github.com/zitadel/zitadel/internal/logstore/record"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// maxResponseSize limits the size of the requests and responses returned by the targets
+const maxResponseSize = 1 << 20
+
+var logstoreService *logstore.Service[*record.ExecutionLog]
+
+func SetLogstoreService(svc *logstore.Service[*record.ExecutionLog]) {
+	logstoreService = svc
+}
+
+// CallTargets calls the targets in order.
+// The content returned by a target is passed to the following targets and can be read from the info afterwards.
+// A failing target or a target returning invalid content interrupts the execution if it is configured to interrupt on error,
+// else it is only logged.
+func (e *Executions) CallTargets(ctx context.Context, targets []*query.Target, info ContextInfo) error {
+	if len(targets) == 0 {
+		return nil
+	}
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	remaining := logstoreService.Limit(ctx, instanceID)
+	if remaining != nil && *remaining == 0 {
+		return errors.ThrowResourceExhausted(nil, "EXEC-Shoh5", "Errors.Quota.Execution.Exhausted")
+	}
+	for _, target := range targets {
+		timeout := target.Timeout
+		if remaining != nil && timeout > time.Duration(*remaining)*time.Second {
+			timeout = time.Duration(*remaining) * time.Second
+		}
+		started := e.now()
+		content, err := e.call(ctx, target, timeout, info)
+		if err == nil && len(content) > 0 {
+			err = info.SetContent(content)
+		}
+		e.log(ctx, instanceID, target, info, started, err)
+		if err != nil && target.InterruptOnError {
+			return errors.ThrowPreconditionFailed(err, "EXEC-Ohb8i", "Errors.Execution.Failed")
+		}
+	}
+	return nil
+}
+
+// call posts the info to the target and returns the response body
+func (e *Executions) call(ctx context.Context, target *query.Target, timeout time.Duration, info ContextInfo) ([]byte, error) {
+	payload, err := json.Marshal(info)
+	if err != nil {
+		return nil, err
+	}
+	signingKey, err := crypto.Decrypt(target.SigningKey, e.keyEncryption)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target.Endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(eventsink.SignatureHeader, eventsink.SignPayload(signingKey, e.now(), payload))
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, errors.ThrowUnavailablef(nil, "EXEC-Eiph3", "unexpected status code %d", resp.StatusCode)
+	}
+	return body, nil
+}
+
+func (e *Executions) log(ctx context.Context, instanceID string, target *query.Target, info ContextInfo, started time.Time, err error) {
+	now := e.now()
+	r := &record.ExecutionLog{
+		LogDate:    now,
+		Took:       now.Sub(started),
+		Message:    "target call succeeded",
+		LogLevel:   logrus.InfoLevel,
+		InstanceID: instanceID,
+		ActionID:   target.ID,
+		Metadata:   info.logMetadata(),
+	}
+	r.Metadata["targetName"] = target.Name
+	if err != nil {
+		r.Message = "target call failed: " + err.Error()
+		r.LogLevel = logrus.ErrorLevel
+	}
+	logstoreService.Handle(ctx, r)
+}
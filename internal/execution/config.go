@@ -0,0 +1,6 @@
+package execution
+
+type Config struct {
+	// Enabled calls the targets of the executions configured on the instances
+	Enabled bool
+}
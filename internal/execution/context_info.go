@@ -0,0 +1,137 @@
+package execution
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// ContextInfo is the payload sent to the targets
+type ContextInfo interface {
+	// GetContent returns the request or response as changed by the targets
+	GetContent() []byte
+	// SetContent sets the request or response returned by a target,
+	// invalid content is returned as error and not set
+	SetContent(content []byte) error
+	logMetadata() map[string]any
+}
+
+// ContextInfoRequest is sent to the targets of request executions.
+// The targets respond with the request to handle, an empty response body keeps the request unchanged.
+type ContextInfoRequest struct {
+	FullMethod string          `json:"fullMethod"`
+	InstanceID string          `json:"instanceID"`
+	OrgID      string          `json:"orgID,omitempty"`
+	ProjectID  string          `json:"projectID,omitempty"`
+	UserID     string          `json:"userID,omitempty"`
+	Request    json.RawMessage `json:"request"`
+	// ValidateContent checks the request returned by a target
+	ValidateContent func(content []byte) error `json:"-"`
+}
+
+func (c *ContextInfoRequest) GetContent() []byte {
+	return c.Request
+}
+
+func (c *ContextInfoRequest) SetContent(content []byte) error {
+	if err := validateContent(c.ValidateContent, content); err != nil {
+		return err
+	}
+	c.Request = content
+	return nil
+}
+
+func (c *ContextInfoRequest) logMetadata() map[string]any {
+	return map[string]any{"fullMethod": c.FullMethod}
+}
+
+// ContextInfoResponse is sent to the targets of response executions.
+// The targets respond with the response to return, an empty response body keeps the response unchanged.
+type ContextInfoResponse struct {
+	FullMethod string          `json:"fullMethod"`
+	InstanceID string          `json:"instanceID"`
+	OrgID      string          `json:"orgID,omitempty"`
+	ProjectID  string          `json:"projectID,omitempty"`
+	UserID     string          `json:"userID,omitempty"`
+	Request    json.RawMessage `json:"request"`
+	Response   json.RawMessage `json:"response"`
+	// ValidateContent checks the response returned by a target
+	ValidateContent func(content []byte) error `json:"-"`
+}
+
+func (c *ContextInfoResponse) GetContent() []byte {
+	return c.Response
+}
+
+func (c *ContextInfoResponse) SetContent(content []byte) error {
+	if err := validateContent(c.ValidateContent, content); err != nil {
+		return err
+	}
+	c.Response = content
+	return nil
+}
+
+func (c *ContextInfoResponse) logMetadata() map[string]any {
+	return map[string]any{"fullMethod": c.FullMethod}
+}
+
+// validateContent checks the content is valid json and passes the validation of the info
+func validateContent(validate func([]byte) error, content []byte) error {
+	if !json.Valid(content) {
+		return errors.ThrowInvalidArgument(nil, "EXEC-Uo3ie", "invalid content")
+	}
+	if validate == nil {
+		return nil
+	}
+	return validate(content)
+}
+
+// ContextInfoEvent is sent to the targets of event executions,
+// the response bodies of the targets are ignored
+type ContextInfoEvent struct {
+	AggregateID   string                   `json:"aggregateID"`
+	AggregateType eventstore.AggregateType `json:"aggregateType"`
+	ResourceOwner string                   `json:"resourceOwner"`
+	InstanceID    string                   `json:"instanceID"`
+	Version       eventstore.Version       `json:"version"`
+	Sequence      uint64                   `json:"sequence"`
+	EventType     eventstore.EventType     `json:"eventType"`
+	CreatedAt     time.Time                `json:"createdAt"`
+	UserID        string                   `json:"userID"`
+	EventPayload  json.RawMessage          `json:"eventPayload,omitempty"`
+}
+
+// newContextInfoEvent maps the event, the secrets of the payload are removed
+func newContextInfoEvent(event eventstore.Event) (*ContextInfoEvent, error) {
+	payload, err := query.StripPayloadSecrets(event.DataAsBytes())
+	if err != nil {
+		return nil, err
+	}
+	return &ContextInfoEvent{
+		AggregateID:   event.Aggregate().ID,
+		AggregateType: event.Aggregate().Type,
+		ResourceOwner: event.Aggregate().ResourceOwner,
+		InstanceID:    event.Aggregate().InstanceID,
+		Version:       event.Aggregate().Version,
+		Sequence:      event.Sequence(),
+		EventType:     event.Type(),
+		CreatedAt:     event.CreatedAt(),
+		UserID:        event.Creator(),
+		EventPayload:  payload,
+	}, nil
+}
+
+func (c *ContextInfoEvent) GetContent() []byte {
+	return c.EventPayload
+}
+
+func (*ContextInfoEvent) SetContent([]byte) error {
+	return nil
+}
+
+func (c *ContextInfoEvent) logMetadata() map[string]any {
+	return map[string]any{"eventType": c.EventType}
+}
@@ -0,0 +1,73 @@
+package execution
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+)
+
+const EventHandlerProjectionTable = "projections.execution_events"
+
+// eventHandler calls the targets of the event executions.
+// An event is retried if a target interrupting on error fails.
+type eventHandler struct {
+	aggregateTypes []eventstore.AggregateType
+	executions     *Executions
+}
+
+func newEventHandler(es *eventstore.Eventstore, executions *Executions) *eventHandler {
+	registered := es.AggregateTypes()
+	aggregateTypes := make([]eventstore.AggregateType, len(registered))
+	for i, typ := range registered {
+		aggregateTypes[i] = eventstore.AggregateType(typ)
+	}
+	return &eventHandler{
+		aggregateTypes: aggregateTypes,
+		executions:     executions,
+	}
+}
+
+func (*eventHandler) Name() string {
+	return EventHandlerProjectionTable
+}
+
+func (h *eventHandler) Reducers() []handler.AggregateReducer {
+	reducers := make([]handler.AggregateReducer, len(h.aggregateTypes))
+	for i, aggregateType := range h.aggregateTypes {
+		reducers[i] = handler.AggregateReducer{
+			Aggregate: aggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  handler.AllEvents,
+					Reduce: h.reduce,
+				},
+			},
+		}
+	}
+	return reducers
+}
+
+func (h *eventHandler) reduce(event eventstore.Event) (*handler.Statement, error) {
+	return handler.NewStatement(event, func(handler.Executer, string) error {
+		ctx := authz.WithInstanceID(context.Background(), event.Aggregate().InstanceID)
+		return h.call(ctx, event)
+	}), nil
+}
+
+func (h *eventHandler) call(ctx context.Context, event eventstore.Event) error {
+	targets, err := h.executions.Targets(ctx, domain.ExecutionIDsOfEvent(string(event.Type()))...)
+	if err != nil {
+		return err
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+	info, err := newContextInfoEvent(event)
+	if err != nil {
+		return err
+	}
+	return h.executions.CallTargets(ctx, targets, info)
+}
@@ -0,0 +1,140 @@
+package execution
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/query/projection"
+)
+
+const executionsCacheDuration = 5 * time.Second
+
+type Queries interface {
+	Targets(ctx context.Context) (*query.Targets, error)
+	Executions(ctx context.Context) (*query.Executions, error)
+}
+
+// Executions calls the targets of the executions of the instances
+type Executions struct {
+	queries       Queries
+	keyEncryption crypto.EncryptionAlgorithm
+	client        *http.Client
+
+	now         func() time.Time
+	instancesMu sync.RWMutex
+	instances   map[string]*cachedExecutions
+	// loading queries the executions of an instance once for all concurrent calls
+	loading singleflight.Group
+}
+
+type cachedExecutions struct {
+	targets    map[string]*query.Target
+	executions map[string][]string
+	expiresAt  time.Time
+}
+
+// Start returns the executions of the instances and starts calling the targets of the event executions.
+// It returns nil if the executions are disabled.
+func Start(
+	ctx context.Context,
+	customConfig projection.CustomConfig,
+	config *Config,
+	es *eventstore.Eventstore,
+	queries Queries,
+	keyEncryption crypto.EncryptionAlgorithm,
+) *Executions {
+	if config == nil || !config.Enabled {
+		return nil
+	}
+	executions := New(queries, keyEncryption)
+	handlerCfg := projection.ApplyCustomConfig(customConfig)
+	handler.NewHandler(ctx, &handlerCfg, newEventHandler(es, executions)).Start(ctx)
+	return executions
+}
+
+func New(queries Queries, keyEncryption crypto.EncryptionAlgorithm) *Executions {
+	return &Executions{
+		queries:       queries,
+		keyEncryption: keyEncryption,
+		// the timeout is set per target
+		client:    &http.Client{Transport: actions.DenyListTransport()},
+		now:       time.Now,
+		instances: make(map[string]*cachedExecutions),
+	}
+}
+
+// Targets returns the targets of the executions in the order of the ids and the targets of each execution.
+// A target is only returned once, removed targets are skipped.
+func (e *Executions) Targets(ctx context.Context, executionIDs ...string) ([]*query.Target, error) {
+	cached, err := e.instanceExecutions(ctx, authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	var targets []*query.Target
+	seen := make(map[string]bool)
+	for _, executionID := range executionIDs {
+		for _, targetID := range cached.executions[executionID] {
+			target, ok := cached.targets[targetID]
+			if !ok || seen[targetID] {
+				continue
+			}
+			seen[targetID] = true
+			targets = append(targets, target)
+		}
+	}
+	return targets, nil
+}
+
+// instanceExecutions caches the executions and targets per instance for a short duration,
+// because they are needed for every call and event of the instance
+func (e *Executions) instanceExecutions(ctx context.Context, instanceID string) (*cachedExecutions, error) {
+	e.instancesMu.RLock()
+	cached, ok := e.instances[instanceID]
+	e.instancesMu.RUnlock()
+	if ok && cached.expiresAt.After(e.now()) {
+		return cached, nil
+	}
+	loaded, err, _ := e.loading.Do(instanceID, func() (interface{}, error) {
+		return e.loadExecutions(ctx, instanceID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return loaded.(*cachedExecutions), nil
+}
+
+func (e *Executions) loadExecutions(ctx context.Context, instanceID string) (*cachedExecutions, error) {
+	targets, err := e.queries.Targets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	executions, err := e.queries.Executions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cached := &cachedExecutions{
+		targets:    make(map[string]*query.Target, len(targets.Targets)),
+		executions: make(map[string][]string, len(executions.Executions)),
+		expiresAt:  e.now().Add(executionsCacheDuration),
+	}
+	for _, target := range targets.Targets {
+		cached.targets[target.ID] = target
+	}
+	for _, execution := range executions.Executions {
+		cached.executions[execution.ID] = execution.Targets
+	}
+	e.instancesMu.Lock()
+	e.instances[instanceID] = cached
+	e.instancesMu.Unlock()
+	return cached, nil
+}
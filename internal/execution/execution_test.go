@@ -0,0 +1,182 @@
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventsink"
+	"github.com/zitadel/zitadel/internal/logstore"
+	"github.com/zitadel/zitadel/internal/logstore/record"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+const method = "/zitadel.user.v2beta.UserService/AddHumanUser"
+
+type mockQueries struct {
+	targets    []*query.Target
+	executions []*query.Execution
+	calls      int
+}
+
+func (m *mockQueries) Targets(context.Context) (*query.Targets, error) {
+	m.calls++
+	return &query.Targets{Targets: m.targets}, nil
+}
+
+func (m *mockQueries) Executions(context.Context) (*query.Executions, error) {
+	return &query.Executions{Executions: m.executions}, nil
+}
+
+func testTarget(id, endpoint string, interruptOnError bool) *query.Target {
+	return &query.Target{
+		ID:               id,
+		Name:             id,
+		Endpoint:         endpoint,
+		Timeout:          time.Second,
+		InterruptOnError: interruptOnError,
+		SigningKey: &crypto.CryptoValue{
+			CryptoType: crypto.TypeEncryption,
+			Algorithm:  "enc",
+			KeyID:      "id",
+			Crypted:    []byte("key"),
+		},
+	}
+}
+
+func testExecutions(t *testing.T, queries Queries) *Executions {
+	e := New(queries, crypto.CreateMockEncryptionAlg(gomock.NewController(t)))
+	now := time.Unix(1700000000, 0)
+	e.now = func() time.Time { return now }
+	return e
+}
+
+func TestExecutions_Targets(t *testing.T) {
+	queries := &mockQueries{
+		targets: []*query.Target{
+			testTarget("target1", "https://example.com/1", false),
+			testTarget("target2", "https://example.com/2", false),
+		},
+		executions: []*query.Execution{
+			{ID: "request", Targets: database.TextArray[string]{"target2"}},
+			{ID: "request/zitadel.user.v2beta.UserService", Targets: database.TextArray[string]{"removed"}},
+			{ID: "request" + method, Targets: database.TextArray[string]{"target1", "target2"}},
+			{ID: "event/user.human.added", Targets: database.TextArray[string]{"target1"}},
+		},
+	}
+	e := testExecutions(t, queries)
+	ctx := authz.WithInstanceID(context.Background(), "instance1")
+
+	targets, err := e.Targets(ctx, domain.ExecutionIDsOfMethod(domain.ExecutionTypeRequest, method)...)
+	require.NoError(t, err)
+	assert.Equal(t, []*query.Target{queries.targets[1], queries.targets[0]}, targets)
+
+	targets, err = e.Targets(ctx, domain.ExecutionIDsOfMethod(domain.ExecutionTypeResponse, method)...)
+	require.NoError(t, err)
+	assert.Empty(t, targets)
+
+	targets, err = e.Targets(ctx, domain.ExecutionIDsOfEvent("user.human.added")...)
+	require.NoError(t, err)
+	assert.Equal(t, []*query.Target{queries.targets[0]}, targets)
+
+	assert.Equal(t, 1, queries.calls)
+}
+
+func TestExecutions_CallTargets(t *testing.T) {
+	SetLogstoreService(logstore.New[*record.ExecutionLog](nil, nil))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, eventsink.SignPayload([]byte("key"), time.Unix(1700000000, 0), body), r.Header.Get(eventsink.SignatureHeader))
+		switch r.URL.Path {
+		case "/change":
+			info := new(ContextInfoRequest)
+			assert.NoError(t, json.Unmarshal(body, info))
+			_, _ = w.Write([]byte(`{"username":"changed"}`))
+		case "/fail":
+			w.WriteHeader(http.StatusForbidden)
+		case "/invalid":
+			_, _ = w.Write([]byte(`{"username":`))
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		targets     []*query.Target
+		wantRequest string
+		wantErr     func(error) bool
+	}{
+		{
+			name:        "no targets",
+			wantRequest: `{"username":"user"}`,
+		},
+		{
+			name: "change request",
+			targets: []*query.Target{
+				testTarget("target1", server.URL+"/change", false),
+				testTarget("target2", server.URL+"/keep", false),
+			},
+			wantRequest: `{"username":"changed"}`,
+		},
+		{
+			name: "failed target skipped",
+			targets: []*query.Target{
+				testTarget("target1", server.URL+"/fail", false),
+				testTarget("target2", server.URL+"/change", false),
+			},
+			wantRequest: `{"username":"changed"}`,
+		},
+		{
+			name: "failed target interrupts",
+			targets: []*query.Target{
+				testTarget("target1", server.URL+"/fail", true),
+				testTarget("target2", server.URL+"/change", false),
+			},
+			wantErr: errors.IsPreconditionFailed,
+		},
+		{
+			name: "invalid content skipped",
+			targets: []*query.Target{
+				testTarget("target1", server.URL+"/invalid", false),
+			},
+			wantRequest: `{"username":"user"}`,
+		},
+		{
+			name: "invalid content interrupts",
+			targets: []*query.Target{
+				testTarget("target1", server.URL+"/invalid", true),
+			},
+			wantErr: errors.IsPreconditionFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := testExecutions(t, nil)
+			info := &ContextInfoRequest{
+				FullMethod: method,
+				InstanceID: "instance1",
+				Request:    []byte(`{"username":"user"}`),
+			}
+			err := e.CallTargets(authz.WithInstanceID(context.Background(), "instance1"), tt.targets, info)
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err), "unexpected error: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.wantRequest, string(info.GetContent()))
+		})
+	}
+}
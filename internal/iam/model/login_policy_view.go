@@ -112,6 +112,8 @@ func secondFactorsToDomain(types []domain.SecondFactorType) []domain.SecondFacto
 			secondfactors[i] = domain.SecondFactorTypeOTPEmail
 		case domain.SecondFactorTypeOTPSMS:
 			secondfactors[i] = domain.SecondFactorTypeOTPSMS
+		case domain.SecondFactorTypeRecoveryCodes:
+			secondfactors[i] = domain.SecondFactorTypeRecoveryCodes
 		}
 	}
 	return secondfactors
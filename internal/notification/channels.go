@@ -6,7 +6,6 @@ import (
 	"github.com/zitadel/logging"
 
 	"github.com/zitadel/zitadel/internal/notification/channels/smtp"
-	"github.com/zitadel/zitadel/internal/notification/channels/twilio"
 	"github.com/zitadel/zitadel/internal/notification/channels/webhook"
 	"github.com/zitadel/zitadel/internal/notification/handlers"
 	"github.com/zitadel/zitadel/internal/notification/senders"
@@ -28,13 +27,17 @@ type deliveryMetrics struct {
 }
 
 type channels struct {
-	q        *handlers.NotificationQueries
-	counters counters
+	q               *handlers.NotificationQueries
+	smtpConnections *smtp.Connections
+	rateLimits      *senders.RateLimits
+	counters        counters
 }
 
-func newChannels(q *handlers.NotificationQueries) *channels {
+func newChannels(q *handlers.NotificationQueries, rateLimits *senders.RateLimits) *channels {
 	c := &channels{
-		q: q,
+		q:               q,
+		smtpConnections: smtp.NewConnections(),
+		rateLimits:      rateLimits,
 		counters: counters{
 			success: deliveryMetrics{
 				email: "successful_deliveries_email",
@@ -62,36 +65,37 @@ func registerCounter(counter, desc string) {
 	logging.WithFields("metric", counter).OnError(err).Panic("unable to register counter")
 }
 
-func (c *channels) Email(ctx context.Context) (*senders.Chain, *smtp.Config, error) {
-	smtpCfg, err := c.q.GetSMTPConfig(ctx)
+func (c *channels) Email(ctx context.Context, orgID string) (*senders.Chain, error) {
+	smtpCfgs, err := c.q.GetActiveSMTPConfigs(ctx, orgID)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
-	chain, err := senders.EmailChannels(
+	return senders.EmailChannels(
 		ctx,
-		smtpCfg,
+		smtpCfgs,
+		c.smtpConnections,
+		c.rateLimits,
 		c.q.GetFileSystemProvider,
 		c.q.GetLogProvider,
 		c.counters.success.email,
 		c.counters.failed.email,
 	)
-	return chain, smtpCfg, err
 }
 
-func (c *channels) SMS(ctx context.Context) (*senders.Chain, *twilio.Config, error) {
-	twilioCfg, err := c.q.GetTwilioConfig(ctx)
+func (c *channels) SMS(ctx context.Context, orgID string) (*senders.Chain, error) {
+	smsCfgs, err := c.q.GetActiveSMSConfigs(ctx, orgID)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
-	chain, err := senders.SMSChannels(
+	return senders.SMSChannels(
 		ctx,
-		twilioCfg,
+		smsCfgs,
+		c.rateLimits,
 		c.q.GetFileSystemProvider,
 		c.q.GetLogProvider,
 		c.counters.success.sms,
 		c.counters.failed.sms,
 	)
-	return chain, twilioCfg, err
 }
 
 func (c *channels) Webhook(ctx context.Context, cfg webhook.Config) (*senders.Chain, error) {
@@ -1,6 +1,12 @@
 package channels
 
-import "github.com/zitadel/zitadel/internal/eventstore"
+import (
+	"errors"
+	"net"
+	"net/http"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
 
 type Message interface {
 	GetTriggeringEvent() eventstore.Event
@@ -18,3 +24,76 @@ type HandleMessageFunc func(message Message) error
 func (h HandleMessageFunc) HandleMessage(message Message) error {
 	return h(message)
 }
+
+// TransportError is returned by a NotificationChannel if the provider could not be reached.
+// The message was not sent, so it's safe to send it using another provider.
+type TransportError struct {
+	err error
+}
+
+func NewTransportError(err error) *TransportError {
+	return &TransportError{err: err}
+}
+
+func (e *TransportError) Error() string {
+	return "transport error: " + e.err.Error()
+}
+
+func (e *TransportError) Unwrap() error {
+	return e.err
+}
+
+// IsTransportError checks if the message was not sent because the provider could not be reached
+func IsTransportError(err error) bool {
+	var transportErr *TransportError
+	return errors.As(err, &transportErr)
+}
+
+// IsConnectionError checks if the error occurred before a connection to the provider was established,
+// e.g. the host could not be resolved or the dial failed.
+// Errors after the connection was established are not considered,
+// because the provider might already have received the message.
+func IsConnectionError(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// IsThrottledStatus checks if a provider rejected a request with the status code without processing it,
+// e.g. because the rate limit of the account was exceeded or the provider is overloaded
+func IsThrottledStatus(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode == http.StatusServiceUnavailable
+}
+
+// BounceError is returned by a NotificationChannel if the provider rejected the recipient permanently,
+// e.g. because the mailbox or the phone number does not exist.
+// Sending the message again or using another provider won't succeed.
+type BounceError struct {
+	err error
+}
+
+func NewBounceError(err error) *BounceError {
+	return &BounceError{err: err}
+}
+
+func (e *BounceError) Error() string {
+	return "bounced: " + e.err.Error()
+}
+
+func (e *BounceError) Unwrap() error {
+	return e.err
+}
+
+// IsBounceError checks if the provider rejected the recipient of the message permanently
+func IsBounceError(err error) bool {
+	var bounceErr *BounceError
+	return errors.As(err, &bounceErr)
+}
+
+// DeliveryRecorder is implemented by messages which record the provider they were passed to
+type DeliveryRecorder interface {
+	SetProviderID(id string)
+}
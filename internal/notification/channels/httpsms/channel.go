@@ -0,0 +1,194 @@
+package httpsms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/notification/channels"
+	"github.com/zitadel/zitadel/internal/notification/messages"
+)
+
+const (
+	requestTimeout = 5 * time.Second
+	// maxResponseSize limits the body read to match the success pattern
+	maxResponseSize = 1 << 20
+	// defaultContentType is sent if no Content-Type header is configured
+	defaultContentType = "application/json"
+)
+
+// client refuses the hosts of the deny list, as the url is configured by the administrators of the instance
+var client = &http.Client{
+	Timeout:   requestTimeout,
+	Transport: actions.DenyListTransport(),
+}
+
+// templateData is passed to the body template
+type templateData struct {
+	Phone   templateString
+	Content templateString
+}
+
+// templateString is escaped for the content type of the request when it's printed by the body template,
+// so {{.Content}} can be used inside a quoted JSON string or as a form value.
+// The json function quotes the value and the raw function returns the unescaped value.
+type templateString struct {
+	value  string
+	escape func(string) string
+}
+
+func (s templateString) String() string {
+	return s.escape(s.value)
+}
+
+func (s templateString) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.value)
+}
+
+// escaper returns the escaping of the values for the content type,
+// values of unknown content types aren't escaped
+func escaper(contentType string) func(string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return noEscape
+	}
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		return escapeJSON
+	case mediaType == "application/x-www-form-urlencoded":
+		return url.QueryEscape
+	}
+	return noEscape
+}
+
+func escapeJSON(s string) string {
+	quoted, _ := json.Marshal(s)
+	return string(quoted[1 : len(quoted)-1])
+}
+
+func noEscape(s string) string {
+	return s
+}
+
+// parseBodyTemplate parses the body template,
+// the json function escapes a value so it can be used inside a JSON document
+func parseBodyTemplate(body string) (*template.Template, error) {
+	return template.New("body").
+		Funcs(template.FuncMap{
+			"json": func(v any) (string, error) {
+				b, err := json.Marshal(v)
+				return string(b), err
+			},
+			"raw": func(s templateString) string {
+				return s.value
+			},
+		}).
+		Option("missingkey=error").
+		Parse(body)
+}
+
+func InitChannel(ctx context.Context, cfg Config) (channels.NotificationChannel, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	body, err := parseBodyTemplate(cfg.BodyTemplate)
+	if err != nil {
+		return nil, err
+	}
+	successPattern, err := regexp.Compile(cfg.SuccessBodyPattern)
+	if err != nil {
+		return nil, err
+	}
+	headers := cfg.Headers.Clone()
+	if headers == nil {
+		headers = make(http.Header)
+	}
+	if headers.Get("Content-Type") == "" && cfg.BodyTemplate != "" {
+		headers.Set("Content-Type", defaultContentType)
+	}
+	escape := escaper(headers.Get("Content-Type"))
+
+	logging.Debug("successfully initialized http sms channel")
+	return channels.HandleMessageFunc(func(message channels.Message) error {
+		requestCtx, cancel := context.WithTimeout(ctx, requestTimeout)
+		defer cancel()
+		msg, ok := message.(*messages.SMS)
+		if !ok {
+			return errors.ThrowInternal(nil, "HSMS-Np4ce", "message is not SMS")
+		}
+		content, err := msg.GetContent()
+		if err != nil {
+			return err
+		}
+		payload := new(bytes.Buffer)
+		data := &templateData{
+			Phone:   templateString{value: msg.RecipientPhoneNumber, escape: escape},
+			Content: templateString{value: content, escape: escape},
+		}
+		if err = body.Execute(payload, data); err != nil {
+			return errors.ThrowInternal(err, "HSMS-Hq6xs", "could not render body")
+		}
+		req, err := http.NewRequestWithContext(requestCtx, cfg.Method, cfg.URL, payload)
+		if err != nil {
+			return err
+		}
+		req.Header = headers.Clone()
+		if msg.IdempotencyKey != "" {
+			req.Header.Set("Idempotency-Key", msg.IdempotencyKey)
+		}
+		switch cfg.AuthType {
+		case domain.SMSHTTPAuthTypeBasic:
+			req.SetBasicAuth(cfg.Username, cfg.Secret)
+		case domain.SMSHTTPAuthTypeBearer:
+			req.Header.Set("Authorization", "Bearer "+cfg.Secret)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			err = errors.ThrowUnknown(err, "HSMS-Rk2vb", "could not send message")
+			if channels.IsConnectionError(err) {
+				return channels.NewTransportError(err)
+			}
+			return err
+		}
+		defer resp.Body.Close()
+		if !isSuccessStatus(cfg.SuccessStatusCodes, resp.StatusCode) {
+			if channels.IsThrottledStatus(resp.StatusCode) {
+				return channels.NewTransportError(errors.ThrowResourceExhausted(fmt.Errorf("calling url %s returned %s", cfg.URL, resp.Status), "HSMS-Wq5fo", "sms provider throttled the request"))
+			}
+			return errors.ThrowUnknown(fmt.Errorf("calling url %s returned %s", cfg.URL, resp.Status), "HSMS-Ea7zu", "sms provider didn't return a success status")
+		}
+		if cfg.SuccessBodyPattern != "" {
+			respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+			if err != nil {
+				return errors.ThrowUnknown(err, "HSMS-Vb3ol", "could not read response")
+			}
+			if !successPattern.Match(respBody) {
+				return errors.ThrowUnknown(fmt.Errorf("response of url %s doesn't match %q", cfg.URL, cfg.SuccessBodyPattern), "HSMS-Oi8dw", "sms provider didn't return a success response")
+			}
+		}
+		logging.WithFields("calling_url", cfg.URL, "method", cfg.Method, "status", resp.StatusCode).Debug("sms sent")
+		return nil
+	}), nil
+}
+
+func isSuccessStatus(successCodes []int, status int) bool {
+	if len(successCodes) == 0 {
+		return status >= 200 && status < 300
+	}
+	return slices.Contains(successCodes, status)
+}
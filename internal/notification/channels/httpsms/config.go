@@ -0,0 +1,66 @@
+package httpsms
+
+import (
+	"net/http"
+	"net/url"
+	"regexp"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+// Config of an SMS provider which is called using a generic HTTP request
+type Config struct {
+	URL    string
+	Method string
+	// Headers are stored with encrypted values, see [EncryptHeaders]
+	Headers http.Header
+	// BodyTemplate is rendered as request body,
+	// the placeholders {{.Phone}} and {{.Content}} are replaced with the recipient and the text of the message escaped for the Content-Type header,
+	// which defaults to application/json
+	BodyTemplate string
+	AuthType     domain.SMSHTTPAuthType
+	// Username is used for basic auth
+	Username string
+	// Secret is the password for basic auth or the bearer token
+	Secret string
+	// SuccessStatusCodes of the response, all 2xx status codes are successful if empty
+	SuccessStatusCodes []int
+	// SuccessBodyPattern is a regular expression the response body must match, if set
+	SuccessBodyPattern string
+}
+
+var allowedMethods = map[string]bool{
+	http.MethodGet:   true,
+	http.MethodPost:  true,
+	http.MethodPut:   true,
+	http.MethodPatch: true,
+}
+
+func (c *Config) Validate() error {
+	u, err := url.Parse(c.URL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return errors.ThrowInvalidArgument(err, "HSMS-Ux3nq", "Errors.SMSConfig.HTTP.InvalidURL")
+	}
+	if !allowedMethods[c.Method] {
+		return errors.ThrowInvalidArgument(nil, "HSMS-Tk8wd", "Errors.SMSConfig.HTTP.InvalidMethod")
+	}
+	if _, err = parseBodyTemplate(c.BodyTemplate); err != nil {
+		return errors.ThrowInvalidArgument(err, "HSMS-Cz2pe", "Errors.SMSConfig.HTTP.InvalidBodyTemplate")
+	}
+	if !c.AuthType.Valid() {
+		return errors.ThrowInvalidArgument(nil, "HSMS-Mf5ha", "Errors.SMSConfig.HTTP.InvalidAuthType")
+	}
+	if c.AuthType == domain.SMSHTTPAuthTypeBasic && c.Username == "" {
+		return errors.ThrowInvalidArgument(nil, "HSMS-Wd9rk", "Errors.SMSConfig.HTTP.UsernameMissing")
+	}
+	for _, code := range c.SuccessStatusCodes {
+		if code < 100 || code > 599 {
+			return errors.ThrowInvalidArgument(nil, "HSMS-Gb7lo", "Errors.SMSConfig.HTTP.InvalidStatusCode")
+		}
+	}
+	if _, err = regexp.Compile(c.SuccessBodyPattern); err != nil {
+		return errors.ThrowInvalidArgument(err, "HSMS-Jy4sv", "Errors.SMSConfig.HTTP.InvalidSuccessPattern")
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package httpsms
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+// EncryptHeaders encrypts the values of the headers, as they might contain credentials (e.g. api keys).
+// The names of the headers are kept readable.
+func EncryptHeaders(headers http.Header, alg crypto.EncryptionAlgorithm) (map[string]*crypto.CryptoValue, error) {
+	if len(headers) == 0 {
+		return nil, nil
+	}
+	encrypted := make(map[string]*crypto.CryptoValue, len(headers))
+	for key, values := range headers {
+		value, err := json.Marshal(values)
+		if err != nil {
+			return nil, errors.ThrowInternal(err, "HSMS-Ke3mz", "Errors.Internal")
+		}
+		encrypted[http.CanonicalHeaderKey(key)], err = crypto.Encrypt(value, alg)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return encrypted, nil
+}
+
+// DecryptHeaders decrypts the values of the headers encrypted by [EncryptHeaders]
+func DecryptHeaders(encrypted map[string]*crypto.CryptoValue, alg crypto.EncryptionAlgorithm) (http.Header, error) {
+	if len(encrypted) == 0 {
+		return nil, nil
+	}
+	headers := make(http.Header, len(encrypted))
+	for key, value := range encrypted {
+		decrypted, err := crypto.Decrypt(value, alg)
+		if err != nil {
+			return nil, err
+		}
+		var values []string
+		if err = json.Unmarshal(decrypted, &values); err != nil {
+			return nil, errors.ThrowInternal(err, "HSMS-Pq8vn", "Errors.Internal")
+		}
+		headers[key] = values
+	}
+	return headers, nil
+}
@@ -0,0 +1,30 @@
+package httpsms
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+)
+
+func TestEncryptHeaders(t *testing.T) {
+	alg := crypto.CreateMockEncryptionAlg(gomock.NewController(t))
+	encrypted, err := EncryptHeaders(http.Header{"x-api-key": []string{"secret"}}, alg)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]*crypto.CryptoValue{
+		"X-Api-Key": {
+			CryptoType: crypto.TypeEncryption,
+			Algorithm:  "enc",
+			KeyID:      "id",
+			Crypted:    []byte(`["secret"]`),
+		},
+	}, encrypted)
+
+	headers, err := DecryptHeaders(encrypted, alg)
+	require.NoError(t, err)
+	assert.Equal(t, http.Header{"X-Api-Key": []string{"secret"}}, headers)
+}
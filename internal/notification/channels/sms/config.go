@@ -0,0 +1,14 @@
+package sms
+
+import (
+	"github.com/zitadel/zitadel/internal/notification/channels/httpsms"
+	"github.com/zitadel/zitadel/internal/notification/channels/twilio"
+)
+
+// Config of an SMS provider, only one of the providers is set
+type Config struct {
+	// ID of the provider, used to record which provider sent a message
+	ID           string
+	TwilioConfig *twilio.Config
+	HTTPConfig   *httpsms.Config
+}
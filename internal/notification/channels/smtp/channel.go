@@ -5,6 +5,7 @@ import (
 	"errors"
 	"net"
 	"net/smtp"
+	"net/textproto"
 
 	"github.com/zitadel/logging"
 
@@ -16,29 +17,31 @@ import (
 var _ channels.NotificationChannel = (*Email)(nil)
 
 type Email struct {
-	smtpClient     *smtp.Client
+	smtp           SMTP
+	tls            bool
 	senderAddress  string
 	senderName     string
 	replyToAddress string
+	connections    *Connections
 }
 
-func InitChannel(cfg *Config) (*Email, error) {
-	client, err := cfg.SMTP.connectToSMTP(cfg.Tls)
-	if err != nil {
-		logging.New().WithError(err).Error("could not connect to smtp")
-		return nil, err
-	}
+// InitChannel creates the channel, the connection to the server is established with the first message
+// so an unreachable server can be reported as transport error.
+// The connection is kept open by the connections for the following messages,
+// without connections a connection is established for every message.
+func InitChannel(cfg *Config, connections *Connections) *Email {
 	logging.New().Debug("successfully initialized smtp email channel")
 	return &Email{
-		smtpClient:     client,
+		smtp:           cfg.SMTP,
+		tls:            cfg.Tls,
 		senderName:     cfg.FromName,
 		senderAddress:  cfg.From,
 		replyToAddress: cfg.ReplyToAddress,
-	}, nil
+		connections:    connections,
+	}
 }
 
 func (email *Email) HandleMessage(message channels.Message) error {
-	defer email.smtpClient.Close()
 	emailMsg, ok := message.(*messages.Email)
 	if !ok {
 		return caos_errs.ThrowInternal(nil, "EMAIL-s8JLs", "message is not EmailMessage")
@@ -50,20 +53,58 @@ func (email *Email) HandleMessage(message channels.Message) error {
 	emailMsg.SenderEmail = email.senderAddress
 	emailMsg.SenderName = email.senderName
 	emailMsg.ReplyToAddress = email.replyToAddress
+
+	key := connectionKey(email.smtp, email.tls)
+	smtpClient, err := email.connect(key)
+	if err != nil {
+		logging.New().WithError(err).Error("could not connect to smtp")
+		return channels.NewTransportError(err)
+	}
+	if err = email.send(smtpClient, emailMsg); err != nil {
+		// the state of the connection is unknown after an error
+		smtpClient.Close()
+		return err
+	}
+	emailMsg.ProviderMessageID = emailMsg.MessageID()
+	email.connections.put(key, smtpClient)
+	return nil
+}
+
+// connect returns an idle connection to the server if it's still open or connects to the server
+func (email *Email) connect(key string) (*smtp.Client, error) {
+	if smtpClient := email.connections.get(key); smtpClient != nil {
+		if err := smtpClient.Reset(); err == nil {
+			return smtpClient, nil
+		}
+		smtpClient.Close()
+	}
+	return email.smtp.connectToSMTP(email.tls)
+}
+
+// send passes the message to the server,
+// the server didn't accept the message if a transport error is returned
+func (email *Email) send(smtpClient *smtp.Client, emailMsg *messages.Email) error {
 	// To && From
-	if err := email.smtpClient.Mail(emailMsg.SenderEmail); err != nil {
-		return caos_errs.ThrowInternalf(err, "EMAIL-s3is3", "could not set sender: %v", emailMsg.SenderEmail)
+	if err := smtpClient.Mail(emailMsg.SenderEmail); err != nil {
+		return channels.NewTransportError(caos_errs.ThrowInternalf(err, "EMAIL-s3is3", "could not set sender: %v", emailMsg.SenderEmail))
 	}
 	for _, recp := range append(append(emailMsg.Recipients, emailMsg.CC...), emailMsg.BCC...) {
-		if err := email.smtpClient.Rcpt(recp); err != nil {
-			return caos_errs.ThrowInternalf(err, "EMAIL-s4is4", "could not set recipient: %v", recp)
+		if err := smtpClient.Rcpt(recp); err != nil {
+			err = caos_errs.ThrowInternalf(err, "EMAIL-s4is4", "could not set recipient: %v", recp)
+			if isPermanentFailure(err) {
+				return channels.NewBounceError(err)
+			}
+			if isTemporaryFailure(err) {
+				return channels.NewTransportError(err)
+			}
+			return err
 		}
 	}
 
 	// Data
-	w, err := email.smtpClient.Data()
+	w, err := smtpClient.Data()
 	if err != nil {
-		return err
+		return channels.NewTransportError(err)
 	}
 
 	content, err := emailMsg.GetContent()
@@ -77,11 +118,25 @@ func (email *Email) HandleMessage(message channels.Message) error {
 	}
 
 	err = w.Close()
-	if err != nil {
-		return err
+	if isTemporaryFailure(err) {
+		// the server didn't accept the message, e.g. because the sender is throttled
+		return channels.NewTransportError(err)
 	}
+	return err
+}
+
+// isPermanentFailure checks if the server replied with a 5xx code,
+// e.g. 550 if the mailbox of the recipient does not exist
+func isPermanentFailure(err error) bool {
+	var protoErr *textproto.Error
+	return errors.As(err, &protoErr) && protoErr.Code >= 500
+}
 
-	return email.smtpClient.Quit()
+// isTemporaryFailure checks if the server replied with a 4xx code,
+// e.g. 421 or 451 if the server is busy or the sender exceeded a rate limit
+func isTemporaryFailure(err error) bool {
+	var protoErr *textproto.Error
+	return errors.As(err, &protoErr) && protoErr.Code >= 400 && protoErr.Code < 500
 }
 
 func (smtpConfig SMTP) connectToSMTP(tlsRequired bool) (client *smtp.Client, err error) {
@@ -1,6 +1,8 @@
 package smtp
 
 type Config struct {
+	// ID of the provider, used to record which provider sent a message
+	ID             string
 	SMTP           SMTP
 	Tls            bool
 	From           string
@@ -0,0 +1,97 @@
+package smtp
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/smtp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/zitadel/logging"
+)
+
+// idleTimeout closes a connection which wasn't used for sending a message,
+// it's below the timeout of most servers so the connection isn't closed by the server first
+const idleTimeout = 30 * time.Second
+
+// Connections keeps the connections to the SMTP servers open after a message was sent,
+// so the next message to the same server doesn't need to connect and authenticate again.
+// A connection is only used by one message at a time,
+// concurrent messages connect to the server if no idle connection is left.
+type Connections struct {
+	mu   sync.Mutex
+	idle map[string][]*idleConnection
+}
+
+type idleConnection struct {
+	client *smtp.Client
+	timer  *time.Timer
+}
+
+func NewConnections() *Connections {
+	return &Connections{
+		idle: make(map[string][]*idleConnection),
+	}
+}
+
+// get returns an idle connection to the server or nil if there is none
+func (c *Connections) get(key string) *smtp.Client {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	defer func() {
+		if len(c.idle[key]) == 0 {
+			delete(c.idle, key)
+		}
+	}()
+	for idle := c.idle[key]; len(idle) > 0; idle = c.idle[key] {
+		conn := idle[len(idle)-1]
+		c.idle[key] = idle[:len(idle)-1]
+		// the connection is closed by the timer if it already fired
+		if conn.timer.Stop() {
+			return conn.client
+		}
+	}
+	return nil
+}
+
+// put keeps the connection open for the next message,
+// without connections it's closed
+func (c *Connections) put(key string, client *smtp.Client) {
+	if c == nil {
+		logging.OnError(client.Quit()).Debug("unable to quit smtp connection")
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	conn := &idleConnection{client: client}
+	conn.timer = time.AfterFunc(idleTimeout, func() {
+		c.remove(key, conn)
+		logging.OnError(client.Quit()).Debug("unable to quit idle smtp connection")
+	})
+	c.idle[key] = append(c.idle[key], conn)
+}
+
+func (c *Connections) remove(key string, conn *idleConnection) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, idle := range c.idle[key] {
+		if idle == conn {
+			c.idle[key] = append(c.idle[key][:i], c.idle[key][i+1:]...)
+			break
+		}
+	}
+	if len(c.idle[key]) == 0 {
+		delete(c.idle, key)
+	}
+}
+
+// connectionKey identifies the server and the credentials of a connection,
+// so a connection isn't used anymore after the config was changed
+func connectionKey(smtpConfig SMTP, tls bool) string {
+	hash := sha256.Sum256([]byte(smtpConfig.Host + "\x00" + smtpConfig.User + "\x00" + smtpConfig.Password + "\x00" + strconv.FormatBool(tls)))
+	return hex.EncodeToString(hash[:])
+}
@@ -1,6 +1,10 @@
 package twilio
 
 import (
+	"errors"
+	"slices"
+
+	"github.com/kevinburke/rest/resterror"
 	"github.com/kevinburke/twilio-go"
 	"github.com/zitadel/logging"
 
@@ -23,11 +27,42 @@ func InitChannel(config Config) channels.NotificationChannel {
 		if err != nil {
 			return err
 		}
+		twilioMsg.SenderPhoneNumber = config.SenderNumber
 		m, err := client.Messages.SendMessage(twilioMsg.SenderPhoneNumber, twilioMsg.RecipientPhoneNumber, content, nil)
 		if err != nil {
-			return caos_errs.ThrowInternal(err, "TWILI-osk3S", "could not send message")
+			err = caos_errs.ThrowInternal(err, "TWILI-osk3S", "could not send message")
+			if channels.IsConnectionError(err) {
+				return channels.NewTransportError(err)
+			}
+			if isInvalidRecipient(err) {
+				return channels.NewBounceError(err)
+			}
+			if isThrottled(err) {
+				return channels.NewTransportError(err)
+			}
+			return err
 		}
+		twilioMsg.ProviderMessageID = m.Sid
 		logging.WithFields("message_sid", m.Sid, "status", m.Status).Debug("sms sent")
 		return nil
 	})
 }
+
+// invalidRecipientCodes are the error codes returned by twilio if the message can't be sent to the recipient at all
+// https://www.twilio.com/docs/api/errors
+var invalidRecipientCodes = []string{
+	"21211", // invalid 'To' phone number
+	"21610", // recipient unsubscribed
+	"21614", // 'To' number is not a valid mobile number
+}
+
+func isInvalidRecipient(err error) bool {
+	var restErr *resterror.Error
+	return errors.As(err, &restErr) && slices.Contains(invalidRecipientCodes, restErr.ID)
+}
+
+// isThrottled checks if twilio rejected the message because of the rate limit of the account
+func isThrottled(err error) bool {
+	var restErr *resterror.Error
+	return errors.As(err, &restErr) && (channels.IsThrottledStatus(restErr.Status) || restErr.ID == "20429")
+}
@@ -2,8 +2,10 @@ package handlers
 
 import (
 	"context"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
 )
 
 func (n *NotificationQueries) IsAlreadyHandled(ctx context.Context, event eventstore.Event, data map[string]interface{}, aggregateType eventstore.AggregateType, eventTypes ...eventstore.EventType) (bool, error) {
@@ -24,3 +26,22 @@ func (n *NotificationQueries) IsAlreadyHandled(ctx context.Context, event events
 	}
 	return len(events) > 0, nil
 }
+
+// IsPasswordExpiryWarningSent checks if an expiry warning was already sent for the password the user set at passwordChanged.
+func (n *NotificationQueries) IsPasswordExpiryWarningSent(ctx context.Context, instanceID, userID string, passwordChanged time.Time) (bool, error) {
+	events, err := n.es.Filter(
+		ctx,
+		eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+			InstanceID(instanceID).
+			CreationDateAfter(passwordChanged).
+			AddQuery().
+			AggregateTypes(user.AggregateType).
+			AggregateIDs(userID).
+			EventTypes(user.HumanPasswordExpiryWarningSentType).
+			Builder(),
+	)
+	if err != nil {
+		return false, err
+	}
+	return len(events) > 0, nil
+}
@@ -2,7 +2,9 @@ package handlers
 
 import (
 	"context"
+	"time"
 
+	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/repository/milestone"
 	"github.com/zitadel/zitadel/internal/repository/quota"
 )
@@ -18,7 +20,17 @@ type Commands interface {
 	UserDomainClaimedSent(ctx context.Context, orgID, userID string) error
 	HumanPasswordlessInitCodeSent(ctx context.Context, userID, resourceOwner, codeID string) error
 	PasswordChangeSent(ctx context.Context, orgID, userID string) error
+	PasswordExpiryWarningDue(ctx context.Context, orgID, userID string, expiryDate time.Time) error
+	PasswordExpiryWarningSent(ctx context.Context, orgID, userID string) error
+	HumanRecoveryCodeUsedNotificationSent(ctx context.Context, userID, resourceOwner string) error
 	HumanPhoneVerificationCodeSent(ctx context.Context, orgID, userID string) error
 	UsageNotificationSent(ctx context.Context, dueEvent *quota.NotificationDueEvent) error
 	MilestonePushed(ctx context.Context, msType milestone.Type, endpoints []string, primaryDomain string) error
+	EnqueueNotification(ctx context.Context, message *command.NotificationMessage) error
+	StartNotificationDelivery(ctx context.Context, id, resourceOwner string, deliveryTimeout time.Duration) (*command.NotificationDelivery, error)
+	NotificationSent(ctx context.Context, id, resourceOwner string, attempt uint16, providerID, providerMessageID string) error
+	RetryNotification(ctx context.Context, id, resourceOwner string, attempt uint16, providerID, reason string, nextAttempt time.Time) error
+	PostponeNotification(ctx context.Context, id, resourceOwner string, attempt uint16, providerID, reason string, nextAttempt time.Time) error
+	NotificationBounced(ctx context.Context, id, resourceOwner string, attempt uint16, providerID, reason string) error
+	NotificationFailed(ctx context.Context, id, resourceOwner string, attempt uint16, providerID, reason string) error
 }
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"context"
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// providersInOrder returns the providers in the order set on the organization or the instance and the order itself.
+// Only the providers of the order are returned, so a removed or inactive provider is skipped.
+// If no order is set, all providers are returned in the passed order.
+func providersInOrder[T any](ctx context.Context, n *NotificationQueries, orgID string, channel domain.NotificationType, providers []T, providerID func(T) string) ([]T, *query.NotificationProviderOrder, error) {
+	order, err := n.NotificationProviderOrderByOrg(ctx, orgID, channel)
+	if errors.IsNotFound(err) {
+		return providers, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(order.ProviderIDs) == 0 {
+		return providers, order, nil
+	}
+	ordered := make([]T, 0, len(order.ProviderIDs))
+	for _, id := range order.ProviderIDs {
+		i := slices.IndexFunc(providers, func(provider T) bool {
+			return providerID(provider) == id
+		})
+		if i < 0 {
+			continue
+		}
+		ordered = append(ordered, providers[i])
+	}
+	return ordered, order, nil
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/notification/handlers/mock"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func Test_providersInOrder(t *testing.T) {
+	type args struct {
+		providers []string
+	}
+	type want struct {
+		providers []string
+		err       func(error) bool
+	}
+	tests := []struct {
+		name    string
+		queries func(*mock.MockQueries)
+		args    args
+		want    want
+	}{
+		{
+			name: "no order, all providers",
+			queries: func(queries *mock.MockQueries) {
+				queries.EXPECT().NotificationProviderOrderByOrg(gomock.Any(), "org", domain.NotificationTypeEmail).Return(nil, errors.ThrowNotFound(nil, "QUERY-Fe5mv", "Errors.NotificationProviderOrder.NotFound"))
+			},
+			args: args{
+				providers: []string{"smtp1", "smtp2"},
+			},
+			want: want{
+				providers: []string{"smtp1", "smtp2"},
+			},
+		},
+		{
+			name: "empty order, all providers",
+			queries: func(queries *mock.MockQueries) {
+				queries.EXPECT().NotificationProviderOrderByOrg(gomock.Any(), "org", domain.NotificationTypeEmail).Return(&query.NotificationProviderOrder{}, nil)
+			},
+			args: args{
+				providers: []string{"smtp1", "smtp2"},
+			},
+			want: want{
+				providers: []string{"smtp1", "smtp2"},
+			},
+		},
+		{
+			name: "order, providers in order",
+			queries: func(queries *mock.MockQueries) {
+				queries.EXPECT().NotificationProviderOrderByOrg(gomock.Any(), "org", domain.NotificationTypeEmail).Return(&query.NotificationProviderOrder{
+					ProviderIDs: database.TextArray[string]{"smtp2", "smtp1"},
+				}, nil)
+			},
+			args: args{
+				providers: []string{"smtp1", "smtp2"},
+			},
+			want: want{
+				providers: []string{"smtp2", "smtp1"},
+			},
+		},
+		{
+			name: "order, only providers of order",
+			queries: func(queries *mock.MockQueries) {
+				queries.EXPECT().NotificationProviderOrderByOrg(gomock.Any(), "org", domain.NotificationTypeEmail).Return(&query.NotificationProviderOrder{
+					ProviderIDs: database.TextArray[string]{"smtp3", "removed", "smtp1"},
+				}, nil)
+			},
+			args: args{
+				providers: []string{"smtp1", "smtp2", "smtp3"},
+			},
+			want: want{
+				providers: []string{"smtp3", "smtp1"},
+			},
+		},
+		{
+			name: "query error",
+			queries: func(queries *mock.MockQueries) {
+				queries.EXPECT().NotificationProviderOrderByOrg(gomock.Any(), "org", domain.NotificationTypeEmail).Return(nil, errors.ThrowInternal(nil, "QUERY-Wc9ja", "Errors.Internal"))
+			},
+			args: args{
+				providers: []string{"smtp1"},
+			},
+			want: want{
+				err: errors.IsInternal,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			queries := mock.NewMockQueries(ctrl)
+			tt.queries(queries)
+			n := NewNotificationQueries(queries, nil, "", 0, false, "", nil, nil, nil)
+			got, _, err := providersInOrder(context.Background(), n, "org", domain.NotificationTypeEmail, tt.args.providers, func(id string) string {
+				return id
+			})
+			if tt.want.err != nil {
+				assert.True(t, tt.want.err(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want.providers, got)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/notification/channels/httpsms"
+	"github.com/zitadel/zitadel/internal/notification/channels/sms"
+	"github.com/zitadel/zitadel/internal/notification/channels/twilio"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// GetActiveSMSConfigs reads the active iam SMS provider configs
+// in the order they are used to send messages to users of the organization
+func (n *NotificationQueries) GetActiveSMSConfigs(ctx context.Context, orgID string) ([]*sms.Config, error) {
+	active, err := query.NewSMSProviderStateQuery(domain.SMSConfigStateActive)
+	if err != nil {
+		return nil, err
+	}
+	configs, err := n.SearchSMSConfigs(ctx, &query.SMSConfigsSearchQueries{
+		SearchRequest: query.SearchRequest{
+			SortingColumn: query.SMSConfigColumnCreationDate,
+			Asc:           true,
+		},
+		Queries: []query.SearchQuery{active},
+	})
+	if err != nil {
+		return nil, err
+	}
+	ordered, _, err := providersInOrder(ctx, n, orgID, domain.NotificationTypeSms, configs.Configs, func(config *query.SMSConfig) string {
+		return config.ID
+	})
+	if err != nil {
+		return nil, err
+	}
+	smsConfigs := make([]*sms.Config, 0, len(ordered))
+	for _, config := range ordered {
+		smsConfig, err := n.smsConfig(config)
+		if err != nil {
+			return nil, err
+		}
+		if smsConfig != nil {
+			smsConfigs = append(smsConfigs, smsConfig)
+		}
+	}
+	return smsConfigs, nil
+}
+
+func (n *NotificationQueries) smsConfig(config *query.SMSConfig) (*sms.Config, error) {
+	switch {
+	case config.TwilioConfig != nil:
+		token, err := crypto.DecryptString(config.TwilioConfig.Token, n.SMSTokenCrypto)
+		if err != nil {
+			return nil, err
+		}
+		return &sms.Config{
+			ID: config.ID,
+			TwilioConfig: &twilio.Config{
+				SID:          config.TwilioConfig.SID,
+				Token:        token,
+				SenderNumber: config.TwilioConfig.SenderNumber,
+			},
+		}, nil
+	case config.HTTPConfig != nil:
+		var (
+			secret string
+			err    error
+		)
+		if config.HTTPConfig.Secret != nil {
+			secret, err = crypto.DecryptString(config.HTTPConfig.Secret, n.SMSTokenCrypto)
+			if err != nil {
+				return nil, err
+			}
+		}
+		headers, err := httpsms.DecryptHeaders(config.HTTPConfig.Headers, n.SMSTokenCrypto)
+		if err != nil {
+			return nil, err
+		}
+		return &sms.Config{
+			ID: config.ID,
+			HTTPConfig: &httpsms.Config{
+				URL:                config.HTTPConfig.URL,
+				Method:             config.HTTPConfig.Method,
+				Headers:            headers,
+				BodyTemplate:       config.HTTPConfig.BodyTemplate,
+				AuthType:           config.HTTPConfig.AuthType,
+				Username:           config.HTTPConfig.Username,
+				Secret:             secret,
+				SuccessStatusCodes: config.HTTPConfig.SuccessStatusCodes,
+				SuccessBodyPattern: config.HTTPConfig.SuccessBodyPattern,
+			},
+		}, nil
+	default:
+		return nil, nil
+	}
+}
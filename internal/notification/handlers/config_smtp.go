@@ -3,30 +3,49 @@ package handlers
 import (
 	"context"
 
-	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/notification/channels/smtp"
+	"github.com/zitadel/zitadel/internal/query"
 )
 
-// GetSMTPConfig reads the iam SMTP provider config
-func (n *NotificationQueries) GetSMTPConfig(ctx context.Context) (*smtp.Config, error) {
-	config, err := n.SMTPConfigByAggregateID(ctx, authz.GetInstance(ctx).InstanceID())
+// GetActiveSMTPConfigs reads the iam SMTP provider configs
+// in the order they are used to send emails to users of the organization.
+// The sender of the configs is replaced by the email sender of the organization if it's set.
+func (n *NotificationQueries) GetActiveSMTPConfigs(ctx context.Context, orgID string) ([]*smtp.Config, error) {
+	configs, err := n.SearchSMTPConfigs(ctx, &query.SMTPConfigsSearchQueries{})
 	if err != nil {
 		return nil, err
 	}
-	password, err := crypto.DecryptString(config.Password, n.SMTPPasswordCrypto)
+	ordered, order, err := providersInOrder(ctx, n, orgID, domain.NotificationTypeEmail, configs.SMTPConfigs, func(config *query.SMTPConfig) string {
+		return config.ID
+	})
 	if err != nil {
 		return nil, err
 	}
-	return &smtp.Config{
-		From:           config.SenderAddress,
-		FromName:       config.SenderName,
-		ReplyToAddress: config.ReplyToAddress,
-		Tls:            config.TLS,
-		SMTP: smtp.SMTP{
-			Host:     config.Host,
-			User:     config.User,
-			Password: password,
-		},
-	}, nil
+	smtpConfigs := make([]*smtp.Config, len(ordered))
+	for i, config := range ordered {
+		password, err := crypto.DecryptString(config.Password, n.SMTPPasswordCrypto)
+		if err != nil {
+			return nil, err
+		}
+		smtpConfigs[i] = &smtp.Config{
+			ID:             config.ID,
+			From:           config.SenderAddress,
+			FromName:       config.SenderName,
+			ReplyToAddress: config.ReplyToAddress,
+			Tls:            config.TLS,
+			SMTP: smtp.SMTP{
+				Host:     config.Host,
+				User:     config.User,
+				Password: password,
+			},
+		}
+		if order != nil && order.EmailSender != nil {
+			smtpConfigs[i].From = order.EmailSender.Address
+			smtpConfigs[i].FromName = order.EmailSender.Name
+			smtpConfigs[i].ReplyToAddress = order.EmailSender.ReplyToAddress
+		}
+	}
+	return smtpConfigs, nil
 }
@@ -11,7 +11,9 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
+	command "github.com/zitadel/zitadel/internal/command"
 	milestone "github.com/zitadel/zitadel/internal/repository/milestone"
 	quota "github.com/zitadel/zitadel/internal/repository/quota"
 	gomock "go.uber.org/mock/gomock"
@@ -40,6 +42,20 @@ func (m *MockCommands) EXPECT() *MockCommandsMockRecorder {
 	return m.recorder
 }
 
+// EnqueueNotification mocks base method.
+func (m *MockCommands) EnqueueNotification(arg0 context.Context, arg1 *command.NotificationMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueueNotification", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnqueueNotification indicates an expected call of EnqueueNotification.
+func (mr *MockCommandsMockRecorder) EnqueueNotification(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueNotification", reflect.TypeOf((*MockCommands)(nil).EnqueueNotification), arg0, arg1)
+}
+
 // HumanEmailVerificationCodeSent mocks base method.
 func (m *MockCommands) HumanEmailVerificationCodeSent(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -124,6 +140,20 @@ func (mr *MockCommandsMockRecorder) HumanPhoneVerificationCodeSent(arg0, arg1, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HumanPhoneVerificationCodeSent", reflect.TypeOf((*MockCommands)(nil).HumanPhoneVerificationCodeSent), arg0, arg1, arg2)
 }
 
+// HumanRecoveryCodeUsedNotificationSent mocks base method.
+func (m *MockCommands) HumanRecoveryCodeUsedNotificationSent(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HumanRecoveryCodeUsedNotificationSent", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HumanRecoveryCodeUsedNotificationSent indicates an expected call of HumanRecoveryCodeUsedNotificationSent.
+func (mr *MockCommandsMockRecorder) HumanRecoveryCodeUsedNotificationSent(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HumanRecoveryCodeUsedNotificationSent", reflect.TypeOf((*MockCommands)(nil).HumanRecoveryCodeUsedNotificationSent), arg0, arg1, arg2)
+}
+
 // MilestonePushed mocks base method.
 func (m *MockCommands) MilestonePushed(arg0 context.Context, arg1 milestone.Type, arg2 []string, arg3 string) error {
 	m.ctrl.T.Helper()
@@ -138,6 +168,48 @@ func (mr *MockCommandsMockRecorder) MilestonePushed(arg0, arg1, arg2, arg3 any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MilestonePushed", reflect.TypeOf((*MockCommands)(nil).MilestonePushed), arg0, arg1, arg2, arg3)
 }
 
+// NotificationBounced mocks base method.
+func (m *MockCommands) NotificationBounced(arg0 context.Context, arg1, arg2 string, arg3 uint16, arg4, arg5 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotificationBounced", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotificationBounced indicates an expected call of NotificationBounced.
+func (mr *MockCommandsMockRecorder) NotificationBounced(arg0, arg1, arg2, arg3, arg4, arg5 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotificationBounced", reflect.TypeOf((*MockCommands)(nil).NotificationBounced), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// NotificationFailed mocks base method.
+func (m *MockCommands) NotificationFailed(arg0 context.Context, arg1, arg2 string, arg3 uint16, arg4, arg5 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotificationFailed", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotificationFailed indicates an expected call of NotificationFailed.
+func (mr *MockCommandsMockRecorder) NotificationFailed(arg0, arg1, arg2, arg3, arg4, arg5 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotificationFailed", reflect.TypeOf((*MockCommands)(nil).NotificationFailed), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// NotificationSent mocks base method.
+func (m *MockCommands) NotificationSent(arg0 context.Context, arg1, arg2 string, arg3 uint16, arg4, arg5 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotificationSent", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotificationSent indicates an expected call of NotificationSent.
+func (mr *MockCommandsMockRecorder) NotificationSent(arg0, arg1, arg2, arg3, arg4, arg5 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotificationSent", reflect.TypeOf((*MockCommands)(nil).NotificationSent), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
 // OTPEmailSent mocks base method.
 func (m *MockCommands) OTPEmailSent(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -194,6 +266,77 @@ func (mr *MockCommandsMockRecorder) PasswordCodeSent(arg0, arg1, arg2 any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PasswordCodeSent", reflect.TypeOf((*MockCommands)(nil).PasswordCodeSent), arg0, arg1, arg2)
 }
 
+// PasswordExpiryWarningDue mocks base method.
+func (m *MockCommands) PasswordExpiryWarningDue(arg0 context.Context, arg1, arg2 string, arg3 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PasswordExpiryWarningDue", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PasswordExpiryWarningDue indicates an expected call of PasswordExpiryWarningDue.
+func (mr *MockCommandsMockRecorder) PasswordExpiryWarningDue(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PasswordExpiryWarningDue", reflect.TypeOf((*MockCommands)(nil).PasswordExpiryWarningDue), arg0, arg1, arg2, arg3)
+}
+
+// PasswordExpiryWarningSent mocks base method.
+func (m *MockCommands) PasswordExpiryWarningSent(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PasswordExpiryWarningSent", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PasswordExpiryWarningSent indicates an expected call of PasswordExpiryWarningSent.
+func (mr *MockCommandsMockRecorder) PasswordExpiryWarningSent(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PasswordExpiryWarningSent", reflect.TypeOf((*MockCommands)(nil).PasswordExpiryWarningSent), arg0, arg1, arg2)
+}
+
+// PostponeNotification mocks base method.
+func (m *MockCommands) PostponeNotification(arg0 context.Context, arg1, arg2 string, arg3 uint16, arg4, arg5 string, arg6 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostponeNotification", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PostponeNotification indicates an expected call of PostponeNotification.
+func (mr *MockCommandsMockRecorder) PostponeNotification(arg0, arg1, arg2, arg3, arg4, arg5, arg6 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostponeNotification", reflect.TypeOf((*MockCommands)(nil).PostponeNotification), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+}
+
+// RetryNotification mocks base method.
+func (m *MockCommands) RetryNotification(arg0 context.Context, arg1, arg2 string, arg3 uint16, arg4, arg5 string, arg6 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryNotification", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RetryNotification indicates an expected call of RetryNotification.
+func (mr *MockCommandsMockRecorder) RetryNotification(arg0, arg1, arg2, arg3, arg4, arg5, arg6 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryNotification", reflect.TypeOf((*MockCommands)(nil).RetryNotification), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+}
+
+// StartNotificationDelivery mocks base method.
+func (m *MockCommands) StartNotificationDelivery(arg0 context.Context, arg1, arg2 string, arg3 time.Duration) (*command.NotificationDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartNotificationDelivery", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*command.NotificationDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartNotificationDelivery indicates an expected call of StartNotificationDelivery.
+func (mr *MockCommandsMockRecorder) StartNotificationDelivery(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartNotificationDelivery", reflect.TypeOf((*MockCommands)(nil).StartNotificationDelivery), arg0, arg1, arg2, arg3)
+}
+
 // UsageNotificationSent mocks base method.
 func (m *MockCommands) UsageNotificationSent(arg0 context.Context, arg1 *quota.NotificationDueEvent) error {
 	m.ctrl.T.Helper()
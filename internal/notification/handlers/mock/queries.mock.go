@@ -11,6 +11,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/zitadel/zitadel/internal/domain"
 	query "github.com/zitadel/zitadel/internal/query"
@@ -71,6 +72,36 @@ func (mr *MockQueriesMockRecorder) CustomTextListByTemplate(arg0, arg1, arg2, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CustomTextListByTemplate", reflect.TypeOf((*MockQueries)(nil).CustomTextListByTemplate), arg0, arg1, arg2, arg3)
 }
 
+// DueNotificationMessages mocks base method.
+func (m *MockQueries) DueNotificationMessages(arg0 context.Context, arg1 time.Time, arg2 time.Duration, arg3 uint64) (*query.NotificationMessages, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DueNotificationMessages", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*query.NotificationMessages)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DueNotificationMessages indicates an expected call of DueNotificationMessages.
+func (mr *MockQueriesMockRecorder) DueNotificationMessages(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DueNotificationMessages", reflect.TypeOf((*MockQueries)(nil).DueNotificationMessages), arg0, arg1, arg2, arg3)
+}
+
+// DuePasswordExpiryWarnings mocks base method.
+func (m *MockQueries) DuePasswordExpiryWarnings(arg0 context.Context, arg1 time.Time, arg2 uint64) (*query.PasswordExpiryWarnings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DuePasswordExpiryWarnings", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*query.PasswordExpiryWarnings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DuePasswordExpiryWarnings indicates an expected call of DuePasswordExpiryWarnings.
+func (mr *MockQueriesMockRecorder) DuePasswordExpiryWarnings(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DuePasswordExpiryWarnings", reflect.TypeOf((*MockQueries)(nil).DuePasswordExpiryWarnings), arg0, arg1, arg2)
+}
+
 // GetDefaultLanguage mocks base method.
 func (m *MockQueries) GetDefaultLanguage(arg0 context.Context) language.Tag {
 	m.ctrl.T.Helper()
@@ -120,6 +151,26 @@ func (mr *MockQueriesMockRecorder) GetNotifyUserByID(arg0, arg1, arg2 any, arg3
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotifyUserByID", reflect.TypeOf((*MockQueries)(nil).GetNotifyUserByID), varargs...)
 }
 
+// GetUserByID mocks base method.
+func (m *MockQueries) GetUserByID(arg0 context.Context, arg1 bool, arg2 string, arg3 ...query.SearchQuery) (*query.User, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetUserByID", varargs...)
+	ret0, _ := ret[0].(*query.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockQueriesMockRecorder) GetUserByID(arg0, arg1, arg2 any, arg3 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockQueries)(nil).GetUserByID), varargs...)
+}
+
 // MailTemplateByOrg mocks base method.
 func (m *MockQueries) MailTemplateByOrg(arg0 context.Context, arg1 string, arg2 bool) (*query.MailTemplate, error) {
 	m.ctrl.T.Helper()
@@ -165,39 +216,34 @@ func (mr *MockQueriesMockRecorder) NotificationProviderByIDAndType(arg0, arg1, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotificationProviderByIDAndType", reflect.TypeOf((*MockQueries)(nil).NotificationProviderByIDAndType), arg0, arg1, arg2)
 }
 
-// SMSProviderConfig mocks base method.
-func (m *MockQueries) SMSProviderConfig(arg0 context.Context, arg1 ...query.SearchQuery) (*query.SMSConfig, error) {
+// NotificationProviderOrderByOrg mocks base method.
+func (m *MockQueries) NotificationProviderOrderByOrg(arg0 context.Context, arg1 string, arg2 domain.NotificationType) (*query.NotificationProviderOrder, error) {
 	m.ctrl.T.Helper()
-	varargs := []any{arg0}
-	for _, a := range arg1 {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "SMSProviderConfig", varargs...)
-	ret0, _ := ret[0].(*query.SMSConfig)
+	ret := m.ctrl.Call(m, "NotificationProviderOrderByOrg", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*query.NotificationProviderOrder)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// SMSProviderConfig indicates an expected call of SMSProviderConfig.
-func (mr *MockQueriesMockRecorder) SMSProviderConfig(arg0 any, arg1 ...any) *gomock.Call {
+// NotificationProviderOrderByOrg indicates an expected call of NotificationProviderOrderByOrg.
+func (mr *MockQueriesMockRecorder) NotificationProviderOrderByOrg(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]any{arg0}, arg1...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SMSProviderConfig", reflect.TypeOf((*MockQueries)(nil).SMSProviderConfig), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotificationProviderOrderByOrg", reflect.TypeOf((*MockQueries)(nil).NotificationProviderOrderByOrg), arg0, arg1, arg2)
 }
 
-// SMTPConfigByAggregateID mocks base method.
-func (m *MockQueries) SMTPConfigByAggregateID(arg0 context.Context, arg1 string) (*query.SMTPConfig, error) {
+// PasswordAgePolicyByOrg mocks base method.
+func (m *MockQueries) PasswordAgePolicyByOrg(arg0 context.Context, arg1 bool, arg2 string, arg3 bool) (*query.PasswordAgePolicy, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SMTPConfigByAggregateID", arg0, arg1)
-	ret0, _ := ret[0].(*query.SMTPConfig)
+	ret := m.ctrl.Call(m, "PasswordAgePolicyByOrg", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*query.PasswordAgePolicy)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// SMTPConfigByAggregateID indicates an expected call of SMTPConfigByAggregateID.
-func (mr *MockQueriesMockRecorder) SMTPConfigByAggregateID(arg0, arg1 any) *gomock.Call {
+// PasswordAgePolicyByOrg indicates an expected call of PasswordAgePolicyByOrg.
+func (mr *MockQueriesMockRecorder) PasswordAgePolicyByOrg(arg0, arg1, arg2, arg3 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SMTPConfigByAggregateID", reflect.TypeOf((*MockQueries)(nil).SMTPConfigByAggregateID), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PasswordAgePolicyByOrg", reflect.TypeOf((*MockQueries)(nil).PasswordAgePolicyByOrg), arg0, arg1, arg2, arg3)
 }
 
 // SearchInstanceDomains mocks base method.
@@ -230,6 +276,36 @@ func (mr *MockQueriesMockRecorder) SearchMilestones(arg0, arg1, arg2 any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchMilestones", reflect.TypeOf((*MockQueries)(nil).SearchMilestones), arg0, arg1, arg2)
 }
 
+// SearchSMSConfigs mocks base method.
+func (m *MockQueries) SearchSMSConfigs(arg0 context.Context, arg1 *query.SMSConfigsSearchQueries) (*query.SMSConfigs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchSMSConfigs", arg0, arg1)
+	ret0, _ := ret[0].(*query.SMSConfigs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchSMSConfigs indicates an expected call of SearchSMSConfigs.
+func (mr *MockQueriesMockRecorder) SearchSMSConfigs(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchSMSConfigs", reflect.TypeOf((*MockQueries)(nil).SearchSMSConfigs), arg0, arg1)
+}
+
+// SearchSMTPConfigs mocks base method.
+func (m *MockQueries) SearchSMTPConfigs(arg0 context.Context, arg1 *query.SMTPConfigsSearchQueries) (*query.SMTPConfigs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchSMTPConfigs", arg0, arg1)
+	ret0, _ := ret[0].(*query.SMTPConfigs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchSMTPConfigs indicates an expected call of SearchSMTPConfigs.
+func (mr *MockQueriesMockRecorder) SearchSMTPConfigs(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchSMTPConfigs", reflect.TypeOf((*MockQueries)(nil).SearchSMTPConfigs), arg0, arg1)
+}
+
 // SessionByID mocks base method.
 func (m *MockQueries) SessionByID(arg0 context.Context, arg1 bool, arg2, arg3 string) (*query.Session, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/notification/channels"
+	"github.com/zitadel/zitadel/internal/notification/channels/webhook"
+	"github.com/zitadel/zitadel/internal/notification/messages"
+	"github.com/zitadel/zitadel/internal/notification/senders"
+	"github.com/zitadel/zitadel/internal/notification/types"
+)
+
+type NotificationOutboxConfig struct {
+	// Enabled queues the emails and SMS to users in the outbox, they are sent by the notification worker afterwards.
+	// They are sent directly by the user notifications projection otherwise.
+	Enabled bool
+	// Limit is the maximum amount of messages of an instance sent per run of the worker
+	Limit uint64
+	// MaxAttempts is the amount of attempts after which a message is marked as failed
+	MaxAttempts uint16
+	// MinRetryDelay is the delay after the first failed attempt, it's doubled after every further attempt
+	MinRetryDelay time.Duration
+	// MaxRetryDelay limits the delay between two attempts
+	MaxRetryDelay time.Duration
+	// DeliveryTimeout is the duration after which a started but unfinished delivery is attempted again,
+	// e.g. if the worker was stopped while sending
+	DeliveryTimeout time.Duration
+	// RateLimit of each provider, messages exceeding the limit are passed to the next provider or postponed to the next run
+	RateLimit senders.RateLimitConfig
+}
+
+// retryDelay returns the delay after the failed attempt,
+// starting with MinRetryDelay and doubled after every attempt up to MaxRetryDelay
+func (c *NotificationOutboxConfig) retryDelay(attempt uint16) time.Duration {
+	delay := c.MinRetryDelay
+	for i := uint16(1); i < attempt && delay < c.MaxRetryDelay; i++ {
+		delay *= 2
+	}
+	if c.MaxRetryDelay > 0 && delay > c.MaxRetryDelay {
+		return c.MaxRetryDelay
+	}
+	return delay
+}
+
+var _ types.ChannelChains = (*outboxChannels)(nil)
+
+// outboxChannels queues the emails and SMS in the notification outbox instead of sending them
+type outboxChannels struct {
+	commands Commands
+	chains   types.ChannelChains
+}
+
+// NewOutboxChannels returns channels which queue the emails and SMS,
+// the webhooks are still sent by the passed chains
+func NewOutboxChannels(commands Commands, chains types.ChannelChains) types.ChannelChains {
+	return &outboxChannels{
+		commands: commands,
+		chains:   chains,
+	}
+}
+
+func (o *outboxChannels) Email(ctx context.Context, orgID string) (*senders.Chain, error) {
+	return senders.ChainChannels(channels.HandleMessageFunc(func(message channels.Message) error {
+		email, ok := message.(*messages.Email)
+		if !ok {
+			return errors.ThrowInternal(nil, "HANDL-Quo4e", "message is not EmailMessage")
+		}
+		if len(email.Recipients) != 1 {
+			return errors.ThrowInternalf(nil, "HANDL-ahB1u", "queued emails must have exactly one recipient but got %d", len(email.Recipients))
+		}
+		return o.enqueue(ctx, orgID, domain.NotificationTypeEmail, email.UserID, email.TriggeringEvent, email.Recipients[0], email.Subject, email.Content)
+	})), nil
+}
+
+func (o *outboxChannels) SMS(ctx context.Context, orgID string) (*senders.Chain, error) {
+	return senders.ChainChannels(channels.HandleMessageFunc(func(message channels.Message) error {
+		sms, ok := message.(*messages.SMS)
+		if !ok {
+			return errors.ThrowInternal(nil, "HANDL-ooz3E", "message is not SMS")
+		}
+		return o.enqueue(ctx, orgID, domain.NotificationTypeSms, sms.UserID, sms.TriggeringEvent, sms.RecipientPhoneNumber, "", sms.Content)
+	})), nil
+}
+
+func (o *outboxChannels) Webhook(ctx context.Context, cfg webhook.Config) (*senders.Chain, error) {
+	return o.chains.Webhook(ctx, cfg)
+}
+
+func (o *outboxChannels) enqueue(
+	ctx context.Context,
+	orgID string,
+	channel domain.NotificationType,
+	userID string,
+	triggeringEvent eventstore.Event,
+	recipient,
+	subject,
+	content string,
+) error {
+	return o.commands.EnqueueNotification(ctx, &command.NotificationMessage{
+		ID:                  idempotencyKey(triggeringEvent),
+		ResourceOwner:       orgID,
+		UserID:              userID,
+		Channel:             channel,
+		TriggeringEventType: triggeringEvent.Type(),
+		Recipient:           recipient,
+		Subject:             subject,
+		Content:             content,
+	})
+}
+
+// idempotencyKey identifies the message sent for the triggering event,
+// so the message is only queued once, even if the event is handled again
+func idempotencyKey(event eventstore.Event) string {
+	return fmt.Sprintf("%s:%s:%d", event.Aggregate().Type, event.Aggregate().ID, event.Sequence())
+}
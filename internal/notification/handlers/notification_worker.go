@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/notification/channels"
+	"github.com/zitadel/zitadel/internal/notification/messages"
+	"github.com/zitadel/zitadel/internal/notification/senders"
+	"github.com/zitadel/zitadel/internal/notification/types"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/pseudo"
+)
+
+const (
+	NotificationOutboxProjectionTable = "projections.notification_outbox"
+)
+
+// notificationWorker sends the messages queued in the notification outbox.
+// It's triggered for every instance after RequeueEvery.
+type notificationWorker struct {
+	config   NotificationOutboxConfig
+	commands Commands
+	queries  *NotificationQueries
+	channels types.ChannelChains
+	now      func() time.Time
+}
+
+func NewNotificationWorker(
+	ctx context.Context,
+	outboxCfg NotificationOutboxConfig,
+	handlerCfg handler.Config,
+	commands Commands,
+	queries *NotificationQueries,
+	channels types.ChannelChains,
+) *handler.Handler {
+	worker := &notificationWorker{
+		config:   outboxCfg,
+		commands: commands,
+		queries:  queries,
+		channels: channels,
+		now:      time.Now,
+	}
+	handlerCfg.TriggerWithoutEvents = worker.sendDueMessages
+	return handler.NewHandler(
+		ctx,
+		&handlerCfg,
+		worker,
+	)
+}
+
+func (*notificationWorker) Name() string {
+	return NotificationOutboxProjectionTable
+}
+
+func (w *notificationWorker) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{{
+		Aggregate: pseudo.AggregateType,
+		EventReducers: []handler.EventReducer{{
+			Event:  pseudo.ScheduledEventType,
+			Reduce: w.sendDueMessages,
+		}},
+	}}
+}
+
+func (w *notificationWorker) sendDueMessages(event eventstore.Event) (*handler.Statement, error) {
+	scheduledEvent, ok := event.(*pseudo.ScheduledEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Aeb6k", "reduce.wrong.event.type %s", event.Type())
+	}
+
+	return handler.NewStatement(event, func(ex handler.Executer, projectionName string) error {
+		ctx := call.WithTimestamp(context.Background())
+		for _, instanceID := range scheduledEvent.InstanceIDs {
+			due, err := w.queries.DueNotificationMessages(authz.WithInstanceID(ctx, instanceID), w.now(), w.config.DeliveryTimeout, w.config.Limit)
+			if err != nil {
+				return err
+			}
+			for _, message := range due.NotificationMessages {
+				rateLimited, err := w.deliver(HandlerContext(&eventstore.Aggregate{InstanceID: message.InstanceID, ResourceOwner: message.ResourceOwner}), message)
+				logging.WithFields("instance", message.InstanceID, "notification", message.ID).OnError(err).Warn("storing notification delivery result failed")
+				if rateLimited {
+					// the following messages would exceed the rate limits as well, they are sent in the next run
+					break
+				}
+			}
+		}
+		return nil
+	}), nil
+}
+
+// deliver sends the message and stores the result of the attempt.
+// Failed attempts are retried with an exponential backoff until MaxAttempts is reached,
+// bounced messages are never retried.
+// Messages exceeding the rate limits are postponed to the next run without counting the attempt,
+// rateLimited is true in that case.
+func (w *notificationWorker) deliver(ctx context.Context, message *query.NotificationMessage) (rateLimited bool, err error) {
+	delivery, err := w.commands.StartNotificationDelivery(ctx, message.ID, message.ResourceOwner, w.config.DeliveryTimeout)
+	if errors.IsErrorAlreadyExists(err) || errors.IsPreconditionFailed(err) {
+		// the message was picked up by another worker in the meantime
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	result, err := w.send(ctx, message, delivery)
+	switch {
+	case err == nil:
+		return false, w.commands.NotificationSent(ctx, message.ID, message.ResourceOwner, delivery.Attempt, result.ProviderID, result.ProviderMessageID)
+	case channels.IsBounceError(err):
+		return false, w.commands.NotificationBounced(ctx, message.ID, message.ResourceOwner, delivery.Attempt, result.ProviderID, err.Error())
+	case senders.IsRateLimited(err):
+		return true, w.commands.PostponeNotification(ctx, message.ID, message.ResourceOwner, delivery.Attempt, result.ProviderID, err.Error(), w.now())
+	case delivery.Attempt >= w.config.MaxAttempts:
+		return false, w.commands.NotificationFailed(ctx, message.ID, message.ResourceOwner, delivery.Attempt, result.ProviderID, err.Error())
+	default:
+		return false, w.commands.RetryNotification(ctx, message.ID, message.ResourceOwner, delivery.Attempt, result.ProviderID, err.Error(), w.now().Add(w.config.retryDelay(delivery.Attempt)))
+	}
+}
+
+func (w *notificationWorker) send(ctx context.Context, message *query.NotificationMessage, delivery *command.NotificationDelivery) (messages.Delivery, error) {
+	result := messages.Delivery{IdempotencyKey: message.ID}
+	content, err := crypto.DecryptString(delivery.Content, w.queries.UserDataCrypto)
+	if err != nil {
+		return result, err
+	}
+	triggeringEvent := &queuedEvent{Event: delivery.Event, triggeringEventType: delivery.TriggeringEventType}
+	switch delivery.Channel {
+	case domain.NotificationTypeEmail:
+		chain, err := w.channels.Email(ctx, message.ResourceOwner)
+		if err != nil {
+			return result, err
+		}
+		email := &messages.Email{
+			Recipients:      []string{delivery.Recipient},
+			Subject:         delivery.Subject,
+			Content:         content,
+			UserID:          delivery.UserID,
+			TriggeringEvent: triggeringEvent,
+			Delivery:        result,
+		}
+		err = sendWithChain(chain, email)
+		return email.Delivery, err
+	case domain.NotificationTypeSms:
+		chain, err := w.channels.SMS(ctx, message.ResourceOwner)
+		if err != nil {
+			return result, err
+		}
+		sms := &messages.SMS{
+			RecipientPhoneNumber: delivery.Recipient,
+			Content:              content,
+			UserID:               delivery.UserID,
+			TriggeringEvent:      triggeringEvent,
+			Delivery:             result,
+		}
+		err = sendWithChain(chain, sms)
+		return sms.Delivery, err
+	default:
+		return result, errors.ThrowInternalf(nil, "HANDL-iech3", "unknown notification channel %d", delivery.Channel)
+	}
+}
+
+func sendWithChain(chain *senders.Chain, message channels.Message) error {
+	if chain == nil || chain.Len() == 0 {
+		return errors.ThrowPreconditionFailed(nil, "HANDL-Eil2a", "Errors.Notification.Channels.NotPresent")
+	}
+	return chain.HandleMessage(message)
+}
+
+// queuedEvent reports the type of the event the message was queued for,
+// so queued messages are logged and measured the same as messages sent directly
+type queuedEvent struct {
+	eventstore.Event
+	triggeringEventType eventstore.EventType
+}
+
+func (e *queuedEvent) Type() eventstore.EventType {
+	return e.triggeringEventType
+}
@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/notification/channels"
+	"github.com/zitadel/zitadel/internal/notification/handlers/mock"
+	"github.com/zitadel/zitadel/internal/notification/messages"
+	"github.com/zitadel/zitadel/internal/notification/senders"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func TestNotificationOutboxConfig_retryDelay(t *testing.T) {
+	config := &NotificationOutboxConfig{
+		MinRetryDelay: time.Second,
+		MaxRetryDelay: 10 * time.Second,
+	}
+	tests := []struct {
+		attempt uint16
+		want    time.Duration
+	}{
+		{attempt: 1, want: time.Second},
+		{attempt: 2, want: 2 * time.Second},
+		{attempt: 3, want: 4 * time.Second},
+		{attempt: 4, want: 8 * time.Second},
+		{attempt: 5, want: 10 * time.Second},
+		{attempt: 100, want: 10 * time.Second},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, config.retryDelay(tt.attempt), "attempt %d", tt.attempt)
+	}
+}
+
+func Test_notificationWorker_deliver(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	message := &query.NotificationMessage{
+		ID:            "user:user1:10",
+		ResourceOwner: "org1",
+		InstanceID:    "instance1",
+	}
+	bounceErr := channels.NewBounceError(errors.ThrowInvalidArgument(nil, "SMS-Twi21", "invalid number"))
+	transportErr := channels.NewTransportError(errors.ThrowUnavailable(nil, "SMS-Twi00", "not reachable"))
+	tests := []struct {
+		name        string
+		attempt     uint16
+		commands    func(*mock.MockCommands)
+		send        func(*messages.SMS) error
+		sent        bool
+		rateLimited bool
+		err         func(error) bool
+	}{
+		{
+			name: "already started, skipped",
+			commands: func(commands *mock.MockCommands) {
+				commands.EXPECT().StartNotificationDelivery(gomock.Any(), "user:user1:10", "org1", time.Minute).
+					Return(nil, errors.ThrowPreconditionFailed(nil, "COMMAND-Ga8ev", "Errors.Notification.Message.NotDue"))
+			},
+		},
+		{
+			name:    "sent",
+			attempt: 1,
+			commands: func(commands *mock.MockCommands) {
+				commands.EXPECT().NotificationSent(gomock.Any(), "user:user1:10", "org1", uint16(1), "sms1", "sid")
+			},
+			send: func(sms *messages.SMS) error {
+				sms.SetProviderID("sms1")
+				sms.ProviderMessageID = "sid"
+				return nil
+			},
+			sent: true,
+		},
+		{
+			name:    "bounced",
+			attempt: 1,
+			commands: func(commands *mock.MockCommands) {
+				commands.EXPECT().NotificationBounced(gomock.Any(), "user:user1:10", "org1", uint16(1), "sms1", bounceErr.Error())
+			},
+			send: func(sms *messages.SMS) error {
+				sms.SetProviderID("sms1")
+				return bounceErr
+			},
+			sent: true,
+		},
+		{
+			name:    "failed, retried",
+			attempt: 2,
+			commands: func(commands *mock.MockCommands) {
+				commands.EXPECT().RetryNotification(gomock.Any(), "user:user1:10", "org1", uint16(2), "sms1", transportErr.Error(), now.Add(2*time.Second))
+			},
+			send: func(sms *messages.SMS) error {
+				sms.SetProviderID("sms1")
+				return transportErr
+			},
+			sent: true,
+		},
+		{
+			name:    "rate limited, postponed",
+			attempt: 3,
+			commands: func(commands *mock.MockCommands) {
+				commands.EXPECT().PostponeNotification(gomock.Any(), "user:user1:10", "org1", uint16(3), "sms1", gomock.Any(), now)
+			},
+			send: func(sms *messages.SMS) error {
+				sms.SetProviderID("sms1")
+				return channels.NewTransportError(senders.ErrRateLimited)
+			},
+			sent:        true,
+			rateLimited: true,
+		},
+		{
+			name:    "failed, max attempts reached",
+			attempt: 3,
+			commands: func(commands *mock.MockCommands) {
+				commands.EXPECT().NotificationFailed(gomock.Any(), "user:user1:10", "org1", uint16(3), "sms1", transportErr.Error())
+			},
+			send: func(sms *messages.SMS) error {
+				sms.SetProviderID("sms1")
+				return transportErr
+			},
+			sent: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			commands := mock.NewMockCommands(ctrl)
+			codeAlg, content := cryptoValue(t, ctrl, "content")
+			if tt.attempt > 0 {
+				commands.EXPECT().StartNotificationDelivery(gomock.Any(), "user:user1:10", "org1", time.Minute).Return(&command.NotificationDelivery{
+					Attempt:             tt.attempt,
+					UserID:              "user1",
+					Channel:             domain.NotificationTypeSms,
+					TriggeringEventType: user.HumanPhoneCodeAddedType,
+					Recipient:           "+41791234567",
+					Content:             content,
+					Event:               &eventstore.BaseEvent{},
+				}, nil)
+			}
+			tt.commands(commands)
+			var sent bool
+			w := &notificationWorker{
+				config: NotificationOutboxConfig{
+					MaxAttempts:     3,
+					MinRetryDelay:   time.Second,
+					MaxRetryDelay:   time.Minute,
+					DeliveryTimeout: time.Minute,
+				},
+				commands: commands,
+				queries:  NewNotificationQueries(mock.NewMockQueries(ctrl), nil, "", 0, false, "", codeAlg, nil, nil),
+				channels: &testChannels{Chain: *senders.ChainChannels(channels.HandleMessageFunc(func(message channels.Message) error {
+					sent = true
+					sms := message.(*messages.SMS)
+					assert.Equal(t, "+41791234567", sms.RecipientPhoneNumber)
+					assert.Equal(t, "content", sms.Content)
+					assert.Equal(t, "user1", sms.UserID)
+					assert.Equal(t, "user:user1:10", sms.IdempotencyKey)
+					assert.Equal(t, user.HumanPhoneCodeAddedType, sms.TriggeringEvent.Type())
+					return tt.send(sms)
+				}))},
+				now: func() time.Time { return now },
+			}
+			rateLimited, err := w.deliver(context.Background(), message)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.sent, sent)
+			assert.Equal(t, tt.rateLimited, rateLimited)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/pseudo"
+)
+
+const (
+	PasswordExpiryWarnerProjectionTable = "projections.password_expiry_warnings"
+)
+
+// passwordExpiryWarner marks the users whose password expires soon to be warned.
+// It's triggered for every instance after RequeueEvery,
+// so users are warned ahead of the expiry and not only on their next login.
+// The warning itself is sent by the userNotifier.
+type passwordExpiryWarner struct {
+	limit    uint64
+	commands Commands
+	queries  *NotificationQueries
+	now      func() time.Time
+}
+
+func NewPasswordExpiryWarner(
+	ctx context.Context,
+	handlerCfg handler.Config,
+	commands Commands,
+	queries *NotificationQueries,
+) *handler.Handler {
+	warner := &passwordExpiryWarner{
+		limit:    uint64(handlerCfg.BulkLimit),
+		commands: commands,
+		queries:  queries,
+		now:      time.Now,
+	}
+	handlerCfg.TriggerWithoutEvents = warner.markDueWarnings
+	return handler.NewHandler(
+		ctx,
+		&handlerCfg,
+		warner,
+	)
+}
+
+func (*passwordExpiryWarner) Name() string {
+	return PasswordExpiryWarnerProjectionTable
+}
+
+func (w *passwordExpiryWarner) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{{
+		Aggregate: pseudo.AggregateType,
+		EventReducers: []handler.EventReducer{{
+			Event:  pseudo.ScheduledEventType,
+			Reduce: w.markDueWarnings,
+		}},
+	}}
+}
+
+func (w *passwordExpiryWarner) markDueWarnings(event eventstore.Event) (*handler.Statement, error) {
+	scheduledEvent, ok := event.(*pseudo.ScheduledEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-ooF3e", "reduce.wrong.event.type %s", event.Type())
+	}
+
+	return handler.NewStatement(event, func(ex handler.Executer, projectionName string) error {
+		ctx := call.WithTimestamp(context.Background())
+		for _, instanceID := range scheduledEvent.InstanceIDs {
+			due, err := w.queries.DuePasswordExpiryWarnings(authz.WithInstanceID(ctx, instanceID), w.now(), w.limit)
+			if err != nil {
+				return err
+			}
+			for _, warning := range due.PasswordExpiryWarnings {
+				err = w.commands.PasswordExpiryWarningDue(HandlerContext(&eventstore.Aggregate{InstanceID: instanceID, ResourceOwner: warning.ResourceOwner}), warning.ResourceOwner, warning.UserID, warning.ExpiryDate)
+				logging.WithFields("instance", instanceID, "user", warning.UserID).OnError(err).Warn("marking password expiry warning as due failed")
+			}
+		}
+		return nil
+	}), nil
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/notification/handlers/mock"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/pseudo"
+)
+
+func Test_passwordExpiryWarner_markDueWarnings(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	expiry := now.Add(3 * 24 * time.Hour)
+	tests := []struct {
+		name     string
+		queries  func(*mock.MockQueries)
+		commands func(*mock.MockCommands)
+		wantErr  bool
+	}{
+		{
+			name: "query error",
+			queries: func(queries *mock.MockQueries) {
+				queries.EXPECT().DuePasswordExpiryWarnings(gomock.Any(), now, uint64(10)).
+					Return(nil, errors.ThrowInternal(nil, "QUERY-Iej7a", "Errors.Internal"))
+			},
+			commands: func(*mock.MockCommands) {},
+			wantErr:  true,
+		},
+		{
+			name: "no due warnings",
+			queries: func(queries *mock.MockQueries) {
+				queries.EXPECT().DuePasswordExpiryWarnings(gomock.Any(), now, uint64(10)).
+					Return(&query.PasswordExpiryWarnings{}, nil).Times(2)
+			},
+			commands: func(*mock.MockCommands) {},
+		},
+		{
+			name: "due warnings marked, failures don't stop the run",
+			queries: func(queries *mock.MockQueries) {
+				queries.EXPECT().DuePasswordExpiryWarnings(gomock.Any(), now, uint64(10)).
+					Return(&query.PasswordExpiryWarnings{
+						PasswordExpiryWarnings: []*query.PasswordExpiryWarning{
+							{UserID: "user1", ResourceOwner: "org1", ExpiryDate: expiry},
+							{UserID: "user2", ResourceOwner: "org2", ExpiryDate: expiry},
+						},
+					}, nil).Times(2)
+			},
+			commands: func(commands *mock.MockCommands) {
+				commands.EXPECT().PasswordExpiryWarningDue(gomock.Any(), "org1", "user1", expiry).
+					Return(errors.ThrowPreconditionFailed(nil, "COMMAND-Ahr0e", "Errors.User.NotFound")).Times(2)
+				commands.EXPECT().PasswordExpiryWarningDue(gomock.Any(), "org2", "user2", expiry).Times(2)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			queries := mock.NewMockQueries(ctrl)
+			commands := mock.NewMockCommands(ctrl)
+			tt.queries(queries)
+			tt.commands(commands)
+			w := &passwordExpiryWarner{
+				limit:    10,
+				commands: commands,
+				queries:  &NotificationQueries{Queries: queries},
+				now:      func() time.Time { return now },
+			}
+			stmt, err := w.markDueWarnings(pseudo.NewScheduledEvent(context.Background(), now, "instance1", "instance2"))
+			require.NoError(t, err)
+			err = stmt.Execute(nil, "")
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
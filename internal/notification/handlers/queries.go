@@ -2,6 +2,8 @@ package handlers
 
 import (
 	"context"
+	"time"
+
 	"golang.org/x/text/language"
 
 	"github.com/zitadel/zitadel/internal/crypto"
@@ -14,14 +16,19 @@ type Queries interface {
 	ActiveLabelPolicyByOrg(ctx context.Context, orgID string, withOwnerRemoved bool) (*query.LabelPolicy, error)
 	MailTemplateByOrg(ctx context.Context, orgID string, withOwnerRemoved bool) (*query.MailTemplate, error)
 	GetNotifyUserByID(ctx context.Context, shouldTriggered bool, userID string, queries ...query.SearchQuery) (*query.NotifyUser, error)
+	GetUserByID(ctx context.Context, shouldTriggerBulk bool, userID string, queries ...query.SearchQuery) (*query.User, error)
 	CustomTextListByTemplate(ctx context.Context, aggregateID, template string, withOwnerRemoved bool) (*query.CustomTexts, error)
 	SearchInstanceDomains(ctx context.Context, queries *query.InstanceDomainSearchQueries) (*query.InstanceDomains, error)
 	SessionByID(ctx context.Context, shouldTriggerBulk bool, id, sessionToken string) (*query.Session, error)
 	NotificationPolicyByOrg(ctx context.Context, shouldTriggerBulk bool, orgID string, withOwnerRemoved bool) (*query.NotificationPolicy, error)
+	PasswordAgePolicyByOrg(ctx context.Context, shouldTriggerBulk bool, orgID string, withOwnerRemoved bool) (*query.PasswordAgePolicy, error)
 	SearchMilestones(ctx context.Context, instanceIDs []string, queries *query.MilestonesSearchQueries) (*query.Milestones, error)
 	NotificationProviderByIDAndType(ctx context.Context, aggID string, providerType domain.NotificationProviderType) (*query.DebugNotificationProvider, error)
-	SMSProviderConfig(ctx context.Context, queries ...query.SearchQuery) (*query.SMSConfig, error)
-	SMTPConfigByAggregateID(ctx context.Context, aggregateID string) (*query.SMTPConfig, error)
+	SearchSMSConfigs(ctx context.Context, queries *query.SMSConfigsSearchQueries) (*query.SMSConfigs, error)
+	SearchSMTPConfigs(ctx context.Context, queries *query.SMTPConfigsSearchQueries) (*query.SMTPConfigs, error)
+	NotificationProviderOrderByOrg(ctx context.Context, orgID string, channel domain.NotificationType) (*query.NotificationProviderOrder, error)
+	DueNotificationMessages(ctx context.Context, now time.Time, deliveryTimeout time.Duration, limit uint64) (*query.NotificationMessages, error)
+	DuePasswordExpiryWarnings(ctx context.Context, now time.Time, limit uint64) (*query.PasswordExpiryWarnings, error)
 	GetDefaultLanguage(ctx context.Context) language.Tag
 	GetInstanceRestrictions(ctx context.Context) (restrictions query.Restrictions, err error)
 }
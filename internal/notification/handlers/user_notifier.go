@@ -106,6 +106,18 @@ func (u *userNotifier) Reducers() []handler.AggregateReducer {
 					Event:  user.HumanOTPEmailCodeAddedType,
 					Reduce: u.reduceOTPEmailCodeAdded,
 				},
+				{
+					Event:  user.HumanRecoveryCodeCheckSucceededType,
+					Reduce: u.reduceRecoveryCodeUsed,
+				},
+				{
+					Event:  user.HumanPasswordCheckSucceededType,
+					Reduce: u.reducePasswordCheckSucceeded,
+				},
+				{
+					Event:  user.HumanPasswordExpiryWarningDueType,
+					Reduce: u.reducePasswordExpiryWarningDue,
+				},
 			},
 		},
 		{
@@ -119,6 +131,10 @@ func (u *userNotifier) Reducers() []handler.AggregateReducer {
 					Event:  session.OTPEmailChallengedType,
 					Reduce: u.reduceSessionOTPEmailChallenged,
 				},
+				{
+					Event:  session.PasswordCheckedType,
+					Reduce: u.reduceSessionPasswordChecked,
+				},
 			},
 		},
 	}
@@ -667,6 +683,144 @@ func (u *userNotifier) reducePasswordChanged(event eventstore.Event) (*handler.S
 	}), nil
 }
 
+func (u *userNotifier) reduceRecoveryCodeUsed(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanRecoveryCodeCheckSucceededEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Rc8sk", "reduce.wrong.event.type %s", user.HumanRecoveryCodeCheckSucceededType)
+	}
+
+	return handler.NewStatement(event, func(ex handler.Executer, projectionName string) error {
+		ctx := HandlerContext(event.Aggregate())
+		alreadyHandled, err := u.queries.IsAlreadyHandled(ctx, event, nil, user.AggregateType, user.HumanRecoveryCodeUsedNotificationSentType)
+		if err != nil {
+			return err
+		}
+		if alreadyHandled {
+			return nil
+		}
+
+		colors, err := u.queries.ActiveLabelPolicyByOrg(ctx, e.Aggregate().ResourceOwner, false)
+		if err != nil {
+			return err
+		}
+
+		template, err := u.queries.MailTemplateByOrg(ctx, e.Aggregate().ResourceOwner, false)
+		if err != nil {
+			return err
+		}
+
+		notifyUser, err := u.queries.GetNotifyUserByID(ctx, true, e.Aggregate().ID)
+		if err != nil {
+			return err
+		}
+		translator, err := u.queries.GetTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, domain.RecoveryCodeUsedMessageType)
+		if err != nil {
+			return err
+		}
+		ctx, err = u.queries.Origin(ctx, e)
+		if err != nil {
+			return err
+		}
+		err = types.SendEmail(ctx, u.channels, string(template.Template), translator, notifyUser, colors, e).
+			SendRecoveryCodeUsed(ctx, notifyUser)
+		if err != nil {
+			return err
+		}
+		return u.commands.HumanRecoveryCodeUsedNotificationSent(ctx, e.Aggregate().ID, e.Aggregate().ResourceOwner)
+	}), nil
+}
+
+func (u *userNotifier) reducePasswordCheckSucceeded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanPasswordCheckSucceededEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Pw3xq", "reduce.wrong.event.type %s", user.HumanPasswordCheckSucceededType)
+	}
+	return u.reducePasswordExpiryWarning(e, e.Aggregate().ID, e.Aggregate().ResourceOwner), nil
+}
+
+func (u *userNotifier) reducePasswordExpiryWarningDue(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanPasswordExpiryWarningDueEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-ieL4a", "reduce.wrong.event.type %s", user.HumanPasswordExpiryWarningDueType)
+	}
+	return u.reducePasswordExpiryWarning(e, e.Aggregate().ID, e.Aggregate().ResourceOwner), nil
+}
+
+func (u *userNotifier) reduceSessionPasswordChecked(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*session.PasswordCheckedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Wf2ke", "reduce.wrong.event.type %s", session.PasswordCheckedType)
+	}
+	// an expired password needs to be changed anyway, there's nothing to warn about
+	// events created before the user was stored on them can't be attributed without the session projection
+	if e.Expired || e.UserID == "" {
+		return handler.NewNoOpStatement(e), nil
+	}
+	return u.reducePasswordExpiryWarning(e, e.UserID, e.UserResourceOwner), nil
+}
+
+// reducePasswordExpiryWarning sends a warning to the user if the password will expire within the warning period
+// of the password age policy. The warning is only sent once per password.
+func (u *userNotifier) reducePasswordExpiryWarning(event eventstore.Event, userID, resourceOwner string) *handler.Statement {
+	return handler.NewStatement(event, func(ex handler.Executer, projectionName string) error {
+		ctx := HandlerContext(event.Aggregate())
+		policy, err := u.queries.PasswordAgePolicyByOrg(ctx, true, resourceOwner, false)
+		if err != nil {
+			return err
+		}
+		agePolicy := &domain.PasswordAgePolicy{
+			MaxAgeDays:     policy.MaxAgeDays,
+			ExpireWarnDays: policy.ExpireWarnDays,
+		}
+		if agePolicy.MaxAgeDays == 0 || agePolicy.ExpireWarnDays == 0 {
+			return nil
+		}
+		checkedUser, err := u.queries.GetUserByID(ctx, true, userID)
+		if err != nil {
+			return err
+		}
+		if checkedUser.Human == nil || !agePolicy.IsPasswordExpiryWarning(checkedUser.Human.PasswordChanged, event.CreatedAt()) {
+			return nil
+		}
+		alreadyHandled, err := u.queries.IsPasswordExpiryWarningSent(ctx, event.Aggregate().InstanceID, userID, checkedUser.Human.PasswordChanged)
+		if err != nil {
+			return err
+		}
+		if alreadyHandled {
+			return nil
+		}
+
+		colors, err := u.queries.ActiveLabelPolicyByOrg(ctx, resourceOwner, false)
+		if err != nil {
+			return err
+		}
+
+		template, err := u.queries.MailTemplateByOrg(ctx, resourceOwner, false)
+		if err != nil {
+			return err
+		}
+
+		notifyUser, err := u.queries.GetNotifyUserByID(ctx, true, userID)
+		if err != nil {
+			return err
+		}
+		translator, err := u.queries.GetTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, domain.PasswordExpiryWarningMessageType)
+		if err != nil {
+			return err
+		}
+		ctx, err = u.queries.Origin(ctx, event)
+		if err != nil {
+			return err
+		}
+		err = types.SendEmail(ctx, u.channels, string(template.Template), translator, notifyUser, colors, event).
+			SendPasswordExpiryWarning(ctx, notifyUser, agePolicy.PasswordExpiryDate(checkedUser.Human.PasswordChanged))
+		if err != nil {
+			return err
+		}
+		return u.commands.PasswordExpiryWarningSent(ctx, resourceOwner, userID)
+	})
+}
+
 func (u *userNotifier) reducePhoneCodeAdded(event eventstore.Event) (*handler.Statement, error) {
 	e, ok := event.(*user.HumanPhoneCodeAddedEvent)
 	if !ok {
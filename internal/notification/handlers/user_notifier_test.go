@@ -20,8 +20,6 @@ import (
 	"github.com/zitadel/zitadel/internal/eventstore/repository"
 	es_repo_mock "github.com/zitadel/zitadel/internal/eventstore/repository/mock"
 	channel_mock "github.com/zitadel/zitadel/internal/notification/channels/mock"
-	"github.com/zitadel/zitadel/internal/notification/channels/smtp"
-	"github.com/zitadel/zitadel/internal/notification/channels/twilio"
 	"github.com/zitadel/zitadel/internal/notification/channels/webhook"
 	"github.com/zitadel/zitadel/internal/notification/handlers/mock"
 	"github.com/zitadel/zitadel/internal/notification/senders"
@@ -1309,6 +1307,7 @@ func newUserNotifier(t *testing.T, ctrl *gomock.Controller, queries *mock.MockQu
 	smtpAlg, _ := cryptoValue(t, ctrl, "smtppw")
 	channel := channel_mock.NewMockNotificationChannel(ctrl)
 	if w.err == nil {
+		w.message.UserID = userID
 		w.message.TriggeringEvent = a.event
 		channel.EXPECT().HandleMessage(&w.message).Return(nil)
 	}
@@ -1326,25 +1325,25 @@ func newUserNotifier(t *testing.T, ctrl *gomock.Controller, queries *mock.MockQu
 			f.SMSTokenCrypto,
 		),
 		otpEmailTmpl: defaultOTPEmailTemplate,
-		channels:     &channels{Chain: *senders.ChainChannels(channel)},
+		channels:     &testChannels{Chain: *senders.ChainChannels(channel)},
 	}
 }
 
-var _ types.ChannelChains = (*channels)(nil)
+var _ types.ChannelChains = (*testChannels)(nil)
 
-type channels struct {
+type testChannels struct {
 	senders.Chain
 }
 
-func (c *channels) Email(context.Context) (*senders.Chain, *smtp.Config, error) {
-	return &c.Chain, nil, nil
+func (c *testChannels) Email(context.Context, string) (*senders.Chain, error) {
+	return &c.Chain, nil
 }
 
-func (c *channels) SMS(context.Context) (*senders.Chain, *twilio.Config, error) {
-	return &c.Chain, nil, nil
+func (c *testChannels) SMS(context.Context, string) (*senders.Chain, error) {
+	return &c.Chain, nil
 }
 
-func (c *channels) Webhook(context.Context, webhook.Config) (*senders.Chain, error) {
+func (c *testChannels) Webhook(context.Context, webhook.Config) (*senders.Chain, error) {
 	return &c.Chain, nil
 }
 
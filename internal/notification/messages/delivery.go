@@ -0,0 +1,22 @@
+package messages
+
+import (
+	"github.com/zitadel/zitadel/internal/notification/channels"
+)
+
+var _ channels.DeliveryRecorder = (*Delivery)(nil)
+
+// Delivery is embedded into the messages to exchange information about the delivery with the providers
+type Delivery struct {
+	// IdempotencyKey identifies the message, it's passed to the providers which support it,
+	// so a message sent again after a failed attempt can be recognized
+	IdempotencyKey string
+	// ProviderID is the id of the provider the message was last passed to
+	ProviderID string
+	// ProviderMessageID is the id the provider assigned to the accepted message
+	ProviderMessageID string
+}
+
+func (d *Delivery) SetProviderID(id string) {
+	d.ProviderID = id
+}
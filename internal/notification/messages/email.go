@@ -1,6 +1,7 @@
 package messages
 
 import (
+	"crypto/sha256"
 	"fmt"
 	"regexp"
 	"strings"
@@ -26,7 +27,9 @@ type Email struct {
 	ReplyToAddress  string
 	Subject         string
 	Content         string
+	UserID          string
 	TriggeringEvent eventstore.Event
+	Delivery
 }
 
 func (msg *Email) GetContent() (string, error) {
@@ -43,6 +46,9 @@ func (msg *Email) GetContent() (string, error) {
 	headers["To"] = strings.Join(msg.Recipients, ", ")
 	headers["Cc"] = strings.Join(msg.CC, ", ")
 	headers["Date"] = time.Now().Format(time.RFC1123Z)
+	if messageID := msg.MessageID(); messageID != "" {
+		headers["Message-ID"] = messageID
+	}
 
 	message := ""
 	for k, v := range headers {
@@ -64,6 +70,20 @@ func (msg *Email) GetTriggeringEvent() eventstore.Event {
 	return msg.TriggeringEvent
 }
 
+// MessageID returns the Message-ID header derived from the idempotency key,
+// so the receiving servers can detect a message which is sent again.
+// It's empty if the message has no idempotency key.
+func (msg *Email) MessageID() string {
+	if msg.IdempotencyKey == "" {
+		return ""
+	}
+	domain := "zitadel"
+	if at := strings.LastIndex(msg.SenderEmail, "@"); at >= 0 && at < len(msg.SenderEmail)-1 {
+		domain = msg.SenderEmail[at+1:]
+	}
+	return fmt.Sprintf("<%x@%s>", sha256.Sum256([]byte(msg.IdempotencyKey)), domain)
+}
+
 func isHTML(input string) bool {
 	return isHTMLRgx.MatchString(input)
 }
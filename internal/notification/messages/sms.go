@@ -11,7 +11,9 @@ type SMS struct {
 	SenderPhoneNumber    string
 	RecipientPhoneNumber string
 	Content              string
+	UserID               string
 	TriggeringEvent      eventstore.Event
+	Delivery
 }
 
 func (msg *SMS) GetContent() (string, error) {
@@ -7,7 +7,9 @@ import (
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/notification/handlers"
+	"github.com/zitadel/zitadel/internal/notification/senders"
 	_ "github.com/zitadel/zitadel/internal/notification/statik"
+	"github.com/zitadel/zitadel/internal/notification/types"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/query/projection"
 )
@@ -16,6 +18,9 @@ func Start(
 	ctx context.Context,
 	userHandlerCustomConfig, quotaHandlerCustomConfig, telemetryHandlerCustomConfig projection.CustomConfig,
 	telemetryCfg handlers.TelemetryPusherConfig,
+	outboxHandlerCustomConfig projection.CustomConfig,
+	outboxCfg handlers.NotificationOutboxConfig,
+	passwordExpiryWarnerCustomConfig projection.CustomConfig,
 	externalDomain string,
 	externalPort uint16,
 	externalSecure bool,
@@ -27,8 +32,18 @@ func Start(
 	userEncryption, smtpEncryption, smsEncryption crypto.EncryptionAlgorithm,
 ) {
 	q := handlers.NewNotificationQueries(queries, es, externalDomain, externalPort, externalSecure, fileSystemPath, userEncryption, smtpEncryption, smsEncryption)
-	c := newChannels(q)
-	handlers.NewUserNotifier(ctx, projection.ApplyCustomConfig(userHandlerCustomConfig), commands, q, c, otpEmailTmpl).Start(ctx)
+	var rateLimits *senders.RateLimits
+	if outboxCfg.Enabled {
+		rateLimits = senders.NewRateLimits(outboxCfg.RateLimit)
+	}
+	c := newChannels(q, rateLimits)
+	userChannels := types.ChannelChains(c)
+	if outboxCfg.Enabled {
+		userChannels = handlers.NewOutboxChannels(commands, c)
+		handlers.NewNotificationWorker(ctx, outboxCfg, projection.ApplyCustomConfig(outboxHandlerCustomConfig), commands, q, c).Start(ctx)
+	}
+	handlers.NewUserNotifier(ctx, projection.ApplyCustomConfig(userHandlerCustomConfig), commands, q, userChannels, otpEmailTmpl).Start(ctx)
+	handlers.NewPasswordExpiryWarner(ctx, projection.ApplyCustomConfig(passwordExpiryWarnerCustomConfig), commands, q).Start(ctx)
 	handlers.NewQuotaNotifier(ctx, projection.ApplyCustomConfig(quotaHandlerCustomConfig), commands, q, c).Start(ctx)
 	if telemetryCfg.Enabled {
 		handlers.NewTelemetryPusher(ctx, telemetryCfg, projection.ApplyCustomConfig(telemetryHandlerCustomConfig), commands, q, c).Start(ctx)
@@ -3,7 +3,6 @@ package senders
 import (
 	"context"
 
-	"github.com/zitadel/logging"
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/notification/channels"
 	"github.com/zitadel/zitadel/internal/notification/channels/fs"
@@ -14,31 +13,42 @@ import (
 
 const smtpSpanName = "smtp.NotificationChannel"
 
+// EmailChannels sends the message using the first reachable of the passed SMTP providers,
+// providers which exceed their rate limit are skipped.
+// The connections to the SMTP servers are reused by the channels.
 func EmailChannels(
 	ctx context.Context,
-	emailConfig *smtp.Config,
+	emailConfigs []*smtp.Config,
+	connections *smtp.Connections,
+	rateLimits *RateLimits,
 	getFileSystemProvider func(ctx context.Context) (*fs.Config, error),
 	getLogProvider func(ctx context.Context) (*log.Config, error),
 	successMetricName,
 	failureMetricName string,
 ) (chain *Chain, err error) {
-	channels := make([]channels.NotificationChannel, 0, 3)
-	p, err := smtp.InitChannel(emailConfig)
-	logging.WithFields(
-		"instance", authz.GetInstance(ctx).InstanceID(),
-	).OnError(err).Debug("initializing SMTP channel failed")
-	if err == nil {
-		channels = append(
-			channels,
-			instrumenting.Wrap(
-				ctx,
-				p,
-				smtpSpanName,
-				successMetricName,
-				failureMetricName,
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	providers := make([]channels.NotificationChannel, 0, len(emailConfigs))
+	for _, emailConfig := range emailConfigs {
+		providers = append(
+			providers,
+			newProvider(
+				instanceID,
+				emailConfig.ID,
+				rateLimits,
+				instrumenting.Wrap(
+					ctx,
+					smtp.InitChannel(emailConfig, connections),
+					smtpSpanName,
+					successMetricName,
+					failureMetricName,
+				),
 			),
 		)
 	}
+	channels := make([]channels.NotificationChannel, 0, 3)
+	if len(providers) > 0 {
+		channels = append(channels, FailoverChannels(providers...))
+	}
 	channels = append(channels, debugChannels(ctx, getFileSystemProvider, getLogProvider)...)
 	return ChainChannels(channels...), nil
 }
@@ -0,0 +1,36 @@
+package senders
+
+import (
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/notification/channels"
+)
+
+var _ channels.NotificationChannel = (*Failover)(nil)
+
+// Failover sends a message using the first of its channels which can be reached
+type Failover struct {
+	channels []channels.NotificationChannel
+}
+
+func FailoverChannels(channel ...channels.NotificationChannel) *Failover {
+	return &Failover{channels: channel}
+}
+
+// HandleMessage passes the message to the channels in the same order they were provided to FailoverChannels().
+// The next channel is only used if the previous one returned a transport error,
+// so a message is never sent by more than one channel.
+func (f *Failover) HandleMessage(message channels.Message) (err error) {
+	for i := range f.channels {
+		err = f.channels[i].HandleMessage(message)
+		if !channels.IsTransportError(err) {
+			return err
+		}
+		logging.WithFields("provider", i).WithError(err).Warn("notification provider not reachable or throttled")
+	}
+	return err
+}
+
+func (f *Failover) Len() int {
+	return len(f.channels)
+}
@@ -0,0 +1,60 @@
+package senders
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/zitadel/zitadel/internal/notification/channels"
+	"github.com/zitadel/zitadel/internal/notification/channels/mock"
+)
+
+func TestFailover_HandleMessage(t *testing.T) {
+	errTransport := channels.NewTransportError(errors.New("connection refused"))
+	errRejected := errors.New("rejected")
+	tests := []struct {
+		name    string
+		results []error
+		wantErr error
+	}{
+		{
+			name:    "first channel succeeds",
+			results: []error{nil},
+		},
+		{
+			name:    "first channel not reachable, second succeeds",
+			results: []error{errTransport, nil},
+		},
+		{
+			name:    "first channel rejects, no failover",
+			results: []error{errRejected},
+			wantErr: errRejected,
+		},
+		{
+			name:    "no channel reachable",
+			results: []error{errTransport, errTransport},
+			wantErr: errTransport,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			message := mock.NewMockMessage(ctrl)
+			notificationChannels := make([]channels.NotificationChannel, 0, len(tt.results)+1)
+			for _, result := range tt.results {
+				channel := mock.NewMockNotificationChannel(ctrl)
+				channel.EXPECT().HandleMessage(message).Return(result)
+				notificationChannels = append(notificationChannels, channel)
+			}
+			// channels after the one which handled the message must not be called
+			if !channels.IsTransportError(tt.results[len(tt.results)-1]) {
+				notificationChannels = append(notificationChannels, mock.NewMockNotificationChannel(ctrl))
+			}
+
+			err := FailoverChannels(notificationChannels...).HandleMessage(message)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package senders
+
+import (
+	"errors"
+	"sync"
+
+	"golang.org/x/time/rate"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/notification/channels"
+)
+
+// ErrRateLimited is the cause of the transport error returned if the rate limit of a provider is exceeded
+var ErrRateLimited = caos_errs.ThrowResourceExhausted(nil, "SENDE-ao5Ei", "Errors.Notification.RateLimitExceeded")
+
+// IsRateLimited checks if the message was not passed to the (last) provider because its rate limit was exceeded,
+// so the message can be sent later without counting a failed attempt
+func IsRateLimited(err error) bool {
+	return errors.Is(err, ErrRateLimited)
+}
+
+// RateLimitConfig limits the messages passed to a single provider
+type RateLimitConfig struct {
+	// PerSecond is the sustained rate of messages, the limit is disabled if it's 0
+	PerSecond float64
+	// Burst is the amount of messages which can be sent at once
+	Burst int
+}
+
+// RateLimits keeps a limiter per provider, so the limit applies to all messages sent by the provider
+type RateLimits struct {
+	limit    rate.Limit
+	burst    int
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+// NewRateLimits returns nil if the limit is disabled
+func NewRateLimits(config RateLimitConfig) *RateLimits {
+	if config.PerSecond <= 0 {
+		return nil
+	}
+	burst := config.Burst
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimits{
+		limit:    rate.Limit(config.PerSecond),
+		burst:    burst,
+		limiters: make(map[string]*rate.Limiter),
+	}
+}
+
+func (l *RateLimits) limiter(key string) *rate.Limiter {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	limiter, ok := l.limiters[key]
+	if !ok {
+		limiter = rate.NewLimiter(l.limit, l.burst)
+		l.limiters[key] = limiter
+	}
+	return limiter
+}
+
+var _ channels.NotificationChannel = (*provider)(nil)
+
+// provider records the id of the provider on the message and enforces its rate limit
+type provider struct {
+	id      string
+	limiter *rate.Limiter
+	channel channels.NotificationChannel
+}
+
+func newProvider(instanceID, id string, limits *RateLimits, channel channels.NotificationChannel) *provider {
+	return &provider{
+		id:      id,
+		limiter: limits.limiter(instanceID + "/" + id),
+		channel: channel,
+	}
+}
+
+// HandleMessage returns a transport error if the rate limit is exceeded,
+// so the message is passed to the next provider on failover
+func (p *provider) HandleMessage(message channels.Message) error {
+	if recorder, ok := message.(channels.DeliveryRecorder); ok {
+		recorder.SetProviderID(p.id)
+	}
+	if p.limiter != nil && !p.limiter.Allow() {
+		return channels.NewTransportError(ErrRateLimited)
+	}
+	return p.channel.HandleMessage(message)
+}
@@ -0,0 +1,86 @@
+package senders
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/notification/channels"
+	"github.com/zitadel/zitadel/internal/notification/channels/mock"
+	"github.com/zitadel/zitadel/internal/notification/messages"
+)
+
+func TestProvider_HandleMessage(t *testing.T) {
+	tests := []struct {
+		name           string
+		limits         RateLimitConfig
+		messages       int
+		wantDelivered  int
+		wantRateLimits int
+	}{
+		{
+			name:          "no limit",
+			messages:      3,
+			wantDelivered: 3,
+		},
+		{
+			name:          "within burst",
+			limits:        RateLimitConfig{PerSecond: 0.001, Burst: 2},
+			messages:      2,
+			wantDelivered: 2,
+		},
+		{
+			name:           "limit exceeded",
+			limits:         RateLimitConfig{PerSecond: 0.001, Burst: 2},
+			messages:       3,
+			wantDelivered:  2,
+			wantRateLimits: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			channel := mock.NewMockNotificationChannel(ctrl)
+			channel.EXPECT().HandleMessage(gomock.Any()).Times(tt.wantDelivered).Return(nil)
+			p := newProvider("instance1", "smtp1", NewRateLimits(tt.limits), channel)
+
+			var rateLimited int
+			for i := 0; i < tt.messages; i++ {
+				message := new(messages.Email)
+				err := p.HandleMessage(message)
+				if channels.IsTransportError(err) {
+					assert.True(t, IsRateLimited(err))
+					rateLimited++
+				} else {
+					assert.NoError(t, err)
+				}
+				assert.Equal(t, "smtp1", message.ProviderID)
+			}
+			assert.Equal(t, tt.wantRateLimits, rateLimited)
+		})
+	}
+}
+
+func TestRateLimits_limiter(t *testing.T) {
+	limits := NewRateLimits(RateLimitConfig{PerSecond: 1})
+	assert.Same(t, limits.limiter("instance1/smtp1"), limits.limiter("instance1/smtp1"))
+	assert.NotSame(t, limits.limiter("instance1/smtp1"), limits.limiter("instance1/smtp2"))
+	assert.Nil(t, NewRateLimits(RateLimitConfig{}).limiter("instance1/smtp1"))
+}
+
+func TestIsRateLimited(t *testing.T) {
+	limits := NewRateLimits(RateLimitConfig{PerSecond: 0.001, Burst: 1})
+	ctrl := gomock.NewController(t)
+	channel := mock.NewMockNotificationChannel(ctrl)
+	channel.EXPECT().HandleMessage(gomock.Any()).Return(channels.NewTransportError(caos_errs.ThrowResourceExhausted(nil, "TEST-ahX4o", "throttled")))
+	failover := FailoverChannels(newProvider("instance1", "smtp1", limits, channel))
+
+	err := failover.HandleMessage(new(messages.Email))
+	assert.True(t, channels.IsTransportError(err))
+	assert.False(t, IsRateLimited(err), "throttling of the provider is a failed attempt")
+
+	err = failover.HandleMessage(new(messages.Email))
+	assert.True(t, IsRateLimited(err))
+}
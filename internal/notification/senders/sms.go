@@ -3,35 +3,82 @@ package senders
 import (
 	"context"
 
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/notification/channels"
 	"github.com/zitadel/zitadel/internal/notification/channels/fs"
+	"github.com/zitadel/zitadel/internal/notification/channels/httpsms"
 	"github.com/zitadel/zitadel/internal/notification/channels/instrumenting"
 	"github.com/zitadel/zitadel/internal/notification/channels/log"
+	"github.com/zitadel/zitadel/internal/notification/channels/sms"
 	"github.com/zitadel/zitadel/internal/notification/channels/twilio"
 )
 
-const twilioSpanName = "twilio.NotificationChannel"
+const (
+	twilioSpanName  = "twilio.NotificationChannel"
+	httpSMSSpanName = "httpsms.NotificationChannel"
+)
 
+// SMSChannels sends the message using the first reachable of the passed SMS providers,
+// providers which exceed their rate limit are skipped
 func SMSChannels(
 	ctx context.Context,
-	twilioConfig *twilio.Config,
+	smsConfigs []*sms.Config,
+	rateLimits *RateLimits,
 	getFileSystemProvider func(ctx context.Context) (*fs.Config, error),
 	getLogProvider func(ctx context.Context) (*log.Config, error),
 	successMetricName,
 	failureMetricName string,
 ) (chain *Chain, err error) {
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	providers := make([]channels.NotificationChannel, 0, len(smsConfigs))
+	for _, smsConfig := range smsConfigs {
+		switch {
+		case smsConfig.TwilioConfig != nil:
+			providers = append(
+				providers,
+				newProvider(
+					instanceID,
+					smsConfig.ID,
+					rateLimits,
+					instrumenting.Wrap(
+						ctx,
+						twilio.InitChannel(*smsConfig.TwilioConfig),
+						twilioSpanName,
+						successMetricName,
+						failureMetricName,
+					),
+				),
+			)
+		case smsConfig.HTTPConfig != nil:
+			httpChannel, err := httpsms.InitChannel(ctx, *smsConfig.HTTPConfig)
+			logging.WithFields(
+				"instance", instanceID,
+			).OnError(err).Warn("initializing http sms channel failed")
+			if err != nil {
+				continue
+			}
+			providers = append(
+				providers,
+				newProvider(
+					instanceID,
+					smsConfig.ID,
+					rateLimits,
+					instrumenting.Wrap(
+						ctx,
+						httpChannel,
+						httpSMSSpanName,
+						successMetricName,
+						failureMetricName,
+					),
+				),
+			)
+		}
+	}
 	channels := make([]channels.NotificationChannel, 0, 3)
-	if twilioConfig != nil {
-		channels = append(
-			channels,
-			instrumenting.Wrap(
-				ctx,
-				twilio.InitChannel(*twilioConfig),
-				twilioSpanName,
-				successMetricName,
-				failureMetricName,
-			),
-		)
+	if len(providers) > 0 {
+		channels = append(channels, FailoverChannels(providers...))
 	}
 	channels = append(channels, debugChannels(ctx, getFileSystemProvider, getLogProvider)...)
 	return ChainChannels(channels...), nil
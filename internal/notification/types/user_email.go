@@ -24,12 +24,13 @@ func generateEmail(
 		Recipients:      []string{user.VerifiedEmail},
 		Subject:         subject,
 		Content:         content,
+		UserID:          user.ID,
 		TriggeringEvent: triggeringEvent,
 	}
 	if lastEmail {
 		message.Recipients = []string{user.LastEmail}
 	}
-	emailChannels, _, err := channels.Email(ctx)
+	emailChannels, err := channels.Email(ctx, user.ResourceOwner)
 	if err != nil {
 		return err
 	}
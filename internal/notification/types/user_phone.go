@@ -19,19 +19,15 @@ func generateSms(
 	lastPhone bool,
 	triggeringEvent eventstore.Event,
 ) error {
-	number := ""
-	smsChannels, twilioConfig, err := channels.SMS(ctx)
+	smsChannels, err := channels.SMS(ctx, user.ResourceOwner)
 	logging.OnError(err).Error("could not create sms channel")
 	if smsChannels == nil || smsChannels.Len() == 0 {
 		return errors.ThrowPreconditionFailed(nil, "PHONE-w8nfow", "Errors.Notification.Channels.NotPresent")
 	}
-	if err == nil {
-		number = twilioConfig.SenderNumber
-	}
 	message := &messages.SMS{
-		SenderPhoneNumber:    number,
 		RecipientPhoneNumber: user.VerifiedPhone,
 		Content:              content,
+		UserID:               user.ID,
 		TriggeringEvent:      triggeringEvent,
 	}
 	if lastPhone {
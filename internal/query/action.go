@@ -73,6 +73,18 @@ var (
 		name:  projection.ActionOwnerRemovedCol,
 		table: actionTable,
 	}
+	ActionColumnVersion = Column{
+		name:  projection.ActionVersionCol,
+		table: actionTable,
+	}
+	ActionColumnCanaryVersion = Column{
+		name:  projection.ActionCanaryVersionCol,
+		table: actionTable,
+	}
+	ActionColumnCanaryPercentage = Column{
+		name:  projection.ActionCanaryPercentCol,
+		table: actionTable,
+	}
 )
 
 type Actions struct {
@@ -92,6 +104,18 @@ type Action struct {
 	Script        string
 	timeout       time.Duration
 	AllowedToFail bool
+
+	// Version is the current version of the action.
+	// Actions returned for execution contain the content and number of the version to execute
+	Version          uint64
+	CanaryVersion    uint64
+	CanaryPercentage uint32
+	// Rollout is set on actions returned for execution,
+	// it's either [ActionRolloutCurrent], [ActionRolloutCanary] or [ActionRolloutPinned]
+	Rollout string
+
+	// pinnedVersion is the version set on the flow trigger
+	pinnedVersion uint64
 }
 
 func (a *Action) Timeout() time.Duration {
@@ -195,6 +219,9 @@ func prepareActionsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuil
 			ActionColumnScript.identifier(),
 			ActionColumnTimeout.identifier(),
 			ActionColumnAllowedToFail.identifier(),
+			ActionColumnVersion.identifier(),
+			ActionColumnCanaryVersion.identifier(),
+			ActionColumnCanaryPercentage.identifier(),
 			countColumn.identifier(),
 		).From(actionTable.identifier() + db.Timetravel(call.Took(ctx))).
 			PlaceholderFormat(sq.Dollar),
@@ -214,6 +241,9 @@ func prepareActionsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuil
 					&action.Script,
 					&action.timeout,
 					&action.AllowedToFail,
+					&action.Version,
+					&action.CanaryVersion,
+					&action.CanaryPercentage,
 					&count,
 				)
 				if err != nil {
@@ -247,6 +277,9 @@ func prepareActionQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuild
 			ActionColumnScript.identifier(),
 			ActionColumnTimeout.identifier(),
 			ActionColumnAllowedToFail.identifier(),
+			ActionColumnVersion.identifier(),
+			ActionColumnCanaryVersion.identifier(),
+			ActionColumnCanaryPercentage.identifier(),
 		).From(actionTable.identifier() + db.Timetravel(call.Took(ctx))).
 			PlaceholderFormat(sq.Dollar),
 		func(row *sql.Row) (*Action, error) {
@@ -262,6 +295,9 @@ func prepareActionQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuild
 				&action.Script,
 				&action.timeout,
 				&action.AllowedToFail,
+				&action.Version,
+				&action.CanaryVersion,
+				&action.CanaryPercentage,
 			)
 			if err != nil {
 				if errs.Is(err, sql.ErrNoRows) {
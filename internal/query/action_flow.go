@@ -52,6 +52,10 @@ var (
 		name:  projection.FlowActionIDCol,
 		table: flowsTriggersTable,
 	}
+	FlowsTriggersColumnActionVersion = Column{
+		name:  projection.FlowActionVersionCol,
+		table: flowsTriggersTable,
+	}
 )
 
 type Flow struct {
@@ -106,7 +110,15 @@ func (q *Queries) GetActiveActionsByFlowAndTriggerType(ctx context.Context, flow
 		actions, err = scan(rows)
 		return err
 	}, query, args...)
-	return actions, err
+	if err != nil {
+		return nil, err
+	}
+	for _, action := range actions {
+		if err = q.selectActionVersion(ctx, action); err != nil {
+			return nil, err
+		}
+	}
+	return actions, nil
 }
 
 func (q *Queries) GetFlowTypesOfActionID(ctx context.Context, actionID string) (types []domain.FlowType, err error) {
@@ -165,6 +177,10 @@ func prepareTriggerActionsQuery(ctx context.Context, db prepareDatabase) (sq.Sel
 			ActionColumnScript.identifier(),
 			ActionColumnAllowedToFail.identifier(),
 			ActionColumnTimeout.identifier(),
+			ActionColumnVersion.identifier(),
+			ActionColumnCanaryVersion.identifier(),
+			ActionColumnCanaryPercentage.identifier(),
+			FlowsTriggersColumnActionVersion.identifier(),
 		).
 			From(flowsTriggersTable.name).
 			LeftJoin(join(ActionColumnID, FlowsTriggersColumnActionID) + db.Timetravel(call.Took(ctx))).
@@ -184,6 +200,10 @@ func prepareTriggerActionsQuery(ctx context.Context, db prepareDatabase) (sq.Sel
 					&action.Script,
 					&action.AllowedToFail,
 					&action.timeout,
+					&action.Version,
+					&action.CanaryVersion,
+					&action.CanaryPercentage,
+					&action.pinnedVersion,
 				)
 				if err != nil {
 					return nil, err
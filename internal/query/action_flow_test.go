@@ -16,24 +16,24 @@ import (
 )
 
 var (
-	prepareFlowStmt = `SELECT projections.actions3.id,` +
-		` projections.actions3.creation_date,` +
-		` projections.actions3.change_date,` +
-		` projections.actions3.resource_owner,` +
-		` projections.actions3.action_state,` +
-		` projections.actions3.sequence,` +
-		` projections.actions3.name,` +
-		` projections.actions3.script,` +
-		` projections.actions3.allowed_to_fail,` +
-		` projections.actions3.timeout,` +
-		` projections.flow_triggers3.trigger_type,` +
-		` projections.flow_triggers3.trigger_sequence,` +
-		` projections.flow_triggers3.flow_type,` +
-		` projections.flow_triggers3.change_date,` +
-		` projections.flow_triggers3.sequence,` +
-		` projections.flow_triggers3.resource_owner` +
-		` FROM projections.flow_triggers3` +
-		` LEFT JOIN projections.actions3 ON projections.flow_triggers3.action_id = projections.actions3.id AND projections.flow_triggers3.instance_id = projections.actions3.instance_id`
+	prepareFlowStmt = `SELECT projections.actions4.id,` +
+		` projections.actions4.creation_date,` +
+		` projections.actions4.change_date,` +
+		` projections.actions4.resource_owner,` +
+		` projections.actions4.action_state,` +
+		` projections.actions4.sequence,` +
+		` projections.actions4.name,` +
+		` projections.actions4.script,` +
+		` projections.actions4.allowed_to_fail,` +
+		` projections.actions4.timeout,` +
+		` projections.flow_triggers4.trigger_type,` +
+		` projections.flow_triggers4.trigger_sequence,` +
+		` projections.flow_triggers4.flow_type,` +
+		` projections.flow_triggers4.change_date,` +
+		` projections.flow_triggers4.sequence,` +
+		` projections.flow_triggers4.resource_owner` +
+		` FROM projections.flow_triggers4` +
+		` LEFT JOIN projections.actions4 ON projections.flow_triggers4.action_id = projections.actions4.id AND projections.flow_triggers4.instance_id = projections.actions4.instance_id`
 	// ` AS OF SYSTEM TIME '-1 ms'`
 	prepareFlowCols = []string{
 		"id",
@@ -55,18 +55,22 @@ var (
 		"resource_owner",
 	}
 
-	prepareTriggerActionStmt = `SELECT projections.actions3.id,` +
-		` projections.actions3.creation_date,` +
-		` projections.actions3.change_date,` +
-		` projections.actions3.resource_owner,` +
-		` projections.actions3.action_state,` +
-		` projections.actions3.sequence,` +
-		` projections.actions3.name,` +
-		` projections.actions3.script,` +
-		` projections.actions3.allowed_to_fail,` +
-		` projections.actions3.timeout` +
-		` FROM projections.flow_triggers3` +
-		` LEFT JOIN projections.actions3 ON projections.flow_triggers3.action_id = projections.actions3.id AND projections.flow_triggers3.instance_id = projections.actions3.instance_id`
+	prepareTriggerActionStmt = `SELECT projections.actions4.id,` +
+		` projections.actions4.creation_date,` +
+		` projections.actions4.change_date,` +
+		` projections.actions4.resource_owner,` +
+		` projections.actions4.action_state,` +
+		` projections.actions4.sequence,` +
+		` projections.actions4.name,` +
+		` projections.actions4.script,` +
+		` projections.actions4.allowed_to_fail,` +
+		` projections.actions4.timeout,` +
+		` projections.actions4.version,` +
+		` projections.actions4.canary_version,` +
+		` projections.actions4.canary_percentage,` +
+		` projections.flow_triggers4.action_version` +
+		` FROM projections.flow_triggers4` +
+		` LEFT JOIN projections.actions4 ON projections.flow_triggers4.action_id = projections.actions4.id AND projections.flow_triggers4.instance_id = projections.actions4.instance_id`
 	// ` AS OF SYSTEM TIME '-1 ms'`
 
 	prepareTriggerActionCols = []string{
@@ -80,10 +84,14 @@ var (
 		"script",
 		"allowed_to_fail",
 		"timeout",
+		"version",
+		"canary_version",
+		"canary_percentage",
+		"action_version",
 	}
 
-	prepareFlowTypeStmt = `SELECT projections.flow_triggers3.flow_type` +
-		` FROM projections.flow_triggers3`
+	prepareFlowTypeStmt = `SELECT projections.flow_triggers4.flow_type` +
+		` FROM projections.flow_triggers4`
 	// ` AS OF SYSTEM TIME '-1 ms'`
 
 	prepareFlowTypeCols = []string{
@@ -348,6 +356,10 @@ func Test_FlowPrepares(t *testing.T) {
 							"script",
 							true,
 							10000000000,
+							uint64(20211115),
+							uint64(0),
+							uint32(0),
+							uint64(0),
 						},
 					},
 				),
@@ -364,6 +376,7 @@ func Test_FlowPrepares(t *testing.T) {
 					Script:        "script",
 					AllowedToFail: true,
 					timeout:       10 * time.Second,
+					Version:       20211115,
 				},
 			},
 		},
@@ -386,6 +399,10 @@ func Test_FlowPrepares(t *testing.T) {
 							"script",
 							true,
 							10000000000,
+							uint64(20211115),
+							uint64(20211116),
+							uint32(20),
+							uint64(0),
 						},
 						{
 							"action-id-2",
@@ -398,22 +415,29 @@ func Test_FlowPrepares(t *testing.T) {
 							"script",
 							false,
 							5000000000,
+							uint64(20211115),
+							uint64(0),
+							uint32(0),
+							uint64(20211110),
 						},
 					},
 				),
 			},
 			object: []*Action{
 				{
-					ID:            "action-id-1",
-					CreationDate:  testNow,
-					ChangeDate:    testNow,
-					ResourceOwner: "ro",
-					State:         domain.ActionStateActive,
-					Sequence:      20211115,
-					Name:          "action-name-1",
-					Script:        "script",
-					AllowedToFail: true,
-					timeout:       10 * time.Second,
+					ID:               "action-id-1",
+					CreationDate:     testNow,
+					ChangeDate:       testNow,
+					ResourceOwner:    "ro",
+					State:            domain.ActionStateActive,
+					Sequence:         20211115,
+					Name:             "action-name-1",
+					Script:           "script",
+					AllowedToFail:    true,
+					timeout:          10 * time.Second,
+					Version:          20211115,
+					CanaryVersion:    20211116,
+					CanaryPercentage: 20,
 				},
 				{
 					ID:            "action-id-2",
@@ -426,6 +450,8 @@ func Test_FlowPrepares(t *testing.T) {
 					Script:        "script",
 					AllowedToFail: false,
 					timeout:       5 * time.Second,
+					Version:       20211115,
+					pinnedVersion: 20211110,
 				},
 			},
 		},
@@ -14,18 +14,21 @@ import (
 )
 
 var (
-	prepareActionsStmt = `SELECT projections.actions3.id,` +
-		` projections.actions3.creation_date,` +
-		` projections.actions3.change_date,` +
-		` projections.actions3.resource_owner,` +
-		` projections.actions3.sequence,` +
-		` projections.actions3.action_state,` +
-		` projections.actions3.name,` +
-		` projections.actions3.script,` +
-		` projections.actions3.timeout,` +
-		` projections.actions3.allowed_to_fail,` +
+	prepareActionsStmt = `SELECT projections.actions4.id,` +
+		` projections.actions4.creation_date,` +
+		` projections.actions4.change_date,` +
+		` projections.actions4.resource_owner,` +
+		` projections.actions4.sequence,` +
+		` projections.actions4.action_state,` +
+		` projections.actions4.name,` +
+		` projections.actions4.script,` +
+		` projections.actions4.timeout,` +
+		` projections.actions4.allowed_to_fail,` +
+		` projections.actions4.version,` +
+		` projections.actions4.canary_version,` +
+		` projections.actions4.canary_percentage,` +
 		` COUNT(*) OVER ()` +
-		` FROM projections.actions3`
+		` FROM projections.actions4`
 		// ` AS OF SYSTEM TIME '-1 ms'`
 	prepareActionsCols = []string{
 		"id",
@@ -38,20 +41,26 @@ var (
 		"script",
 		"timeout",
 		"allowed_to_fail",
+		"version",
+		"canary_version",
+		"canary_percentage",
 		"count",
 	}
 
-	prepareActionStmt = `SELECT projections.actions3.id,` +
-		` projections.actions3.creation_date,` +
-		` projections.actions3.change_date,` +
-		` projections.actions3.resource_owner,` +
-		` projections.actions3.sequence,` +
-		` projections.actions3.action_state,` +
-		` projections.actions3.name,` +
-		` projections.actions3.script,` +
-		` projections.actions3.timeout,` +
-		` projections.actions3.allowed_to_fail` +
-		` FROM projections.actions3`
+	prepareActionStmt = `SELECT projections.actions4.id,` +
+		` projections.actions4.creation_date,` +
+		` projections.actions4.change_date,` +
+		` projections.actions4.resource_owner,` +
+		` projections.actions4.sequence,` +
+		` projections.actions4.action_state,` +
+		` projections.actions4.name,` +
+		` projections.actions4.script,` +
+		` projections.actions4.timeout,` +
+		` projections.actions4.allowed_to_fail,` +
+		` projections.actions4.version,` +
+		` projections.actions4.canary_version,` +
+		` projections.actions4.canary_percentage` +
+		` FROM projections.actions4`
 		// ` AS OF SYSTEM TIME '-1 ms'`
 	prepareActionCols = []string{
 		"id",
@@ -64,6 +73,9 @@ var (
 		"script",
 		"timeout",
 		"allowed_to_fail",
+		"version",
+		"canary_version",
+		"canary_percentage",
 	}
 )
 
@@ -109,6 +121,9 @@ func Test_ActionPrepares(t *testing.T) {
 							"script",
 							1 * time.Second,
 							true,
+							uint64(20211109),
+							uint64(0),
+							uint32(0),
 						},
 					},
 				),
@@ -129,6 +144,7 @@ func Test_ActionPrepares(t *testing.T) {
 						Script:        "script",
 						timeout:       1 * time.Second,
 						AllowedToFail: true,
+						Version:       20211109,
 					},
 				},
 			},
@@ -152,6 +168,9 @@ func Test_ActionPrepares(t *testing.T) {
 							"script",
 							1 * time.Second,
 							true,
+							uint64(20211109),
+							uint64(0),
+							uint32(0),
 						},
 						{
 							"id-2",
@@ -164,6 +183,9 @@ func Test_ActionPrepares(t *testing.T) {
 							"script",
 							1 * time.Second,
 							true,
+							uint64(20211109),
+							uint64(0),
+							uint32(0),
 						},
 					},
 				),
@@ -184,6 +206,7 @@ func Test_ActionPrepares(t *testing.T) {
 						Script:        "script",
 						timeout:       1 * time.Second,
 						AllowedToFail: true,
+						Version:       20211109,
 					},
 					{
 						ID:            "id-2",
@@ -196,6 +219,7 @@ func Test_ActionPrepares(t *testing.T) {
 						Script:        "script",
 						timeout:       1 * time.Second,
 						AllowedToFail: true,
+						Version:       20211109,
 					},
 				},
 			},
@@ -253,20 +277,26 @@ func Test_ActionPrepares(t *testing.T) {
 						"script",
 						1 * time.Second,
 						true,
+						uint64(20211109),
+						uint64(20211110),
+						uint32(10),
 					},
 				),
 			},
 			object: &Action{
-				ID:            "id",
-				CreationDate:  testNow,
-				ChangeDate:    testNow,
-				ResourceOwner: "ro",
-				State:         domain.ActionStateActive,
-				Sequence:      20211109,
-				Name:          "action-name",
-				Script:        "script",
-				timeout:       1 * time.Second,
-				AllowedToFail: true,
+				ID:               "id",
+				CreationDate:     testNow,
+				ChangeDate:       testNow,
+				ResourceOwner:    "ro",
+				State:            domain.ActionStateActive,
+				Sequence:         20211109,
+				Name:             "action-name",
+				Script:           "script",
+				timeout:          1 * time.Second,
+				AllowedToFail:    true,
+				Version:          20211109,
+				CanaryVersion:    20211110,
+				CanaryPercentage: 10,
 			},
 		},
 		{
@@ -0,0 +1,236 @@
+package query
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/action"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// ActionVersion is the content of an action at the time the version was created
+type ActionVersion struct {
+	Version       uint64
+	CreationDate  time.Time
+	Script        string
+	Timeout       time.Duration
+	AllowedToFail bool
+}
+
+const (
+	// ActionRolloutCurrent marks an action executing its current version
+	ActionRolloutCurrent = "current"
+	// ActionRolloutCanary marks an action executing its canary version
+	ActionRolloutCanary = "canary"
+	// ActionRolloutPinned marks an action executing the version pinned on the flow trigger
+	ActionRolloutPinned = "pinned"
+
+	// actionVersionCacheSize limits the cached versions, the cache is dropped if it's full
+	actionVersionCacheSize = 1000
+)
+
+// canaryHit decides if an execution runs the canary version,
+// it's a variable to be able to control the rollout in tests
+var canaryHit = func(percentage uint32) bool {
+	return uint32(rand.Intn(100)) < percentage
+}
+
+// ListActionVersions returns all versions of the action ordered by their creation
+func (q *Queries) ListActionVersions(ctx context.Context, actionID, resourceOwner string) (versions []*ActionVersion, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if actionID == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "QUERY-Wv3ks", "Errors.IDMissing")
+	}
+	readModel := NewActionVersionsReadModel(actionID, resourceOwner)
+	if err = q.eventstore.FilterToQueryReducer(ctx, readModel); err != nil {
+		return nil, err
+	}
+	if len(readModel.Versions) == 0 {
+		return nil, errors.ThrowNotFound(nil, "QUERY-Pq8cx", "Errors.Action.NotFound")
+	}
+	return readModel.Versions, nil
+}
+
+// selectActionVersion sets the content of the version to execute on the action.
+// A version pinned on the flow trigger takes precedence over a running canary.
+func (q *Queries) selectActionVersion(ctx context.Context, a *Action) error {
+	a.Rollout = ActionRolloutCurrent
+	version := a.Version
+	switch {
+	case a.pinnedVersion > 0:
+		a.Rollout = ActionRolloutPinned
+		version = a.pinnedVersion
+	case a.CanaryVersion > 0 && canaryHit(a.CanaryPercentage):
+		a.Rollout = ActionRolloutCanary
+		version = a.CanaryVersion
+	}
+	if version == a.Version {
+		return nil
+	}
+	v, err := q.actionVersion(ctx, a.ID, a.ResourceOwner, version)
+	if err != nil {
+		return err
+	}
+	a.Version = v.Version
+	a.Script = v.Script
+	a.timeout = v.Timeout
+	a.AllowedToFail = v.AllowedToFail
+	return nil
+}
+
+// actionVersion returns the version of the action from the cache,
+// the versions of the action are only filtered from the eventstore if the version isn't cached yet
+func (q *Queries) actionVersion(ctx context.Context, actionID, resourceOwner string, version uint64) (*ActionVersion, error) {
+	key := actionVersionKey{
+		instanceID: authz.GetInstance(ctx).InstanceID(),
+		actionID:   actionID,
+		version:    version,
+	}
+	if v, ok := q.actionVersions.get(key); ok {
+		return v, nil
+	}
+	versions, err := q.ListActionVersions(ctx, actionID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	q.actionVersions.set(key.instanceID, actionID, versions)
+	for _, v := range versions {
+		if v.Version == version {
+			return v, nil
+		}
+	}
+	return nil, errors.ThrowNotFound(nil, "QUERY-Hn2rt", "Errors.Action.Version.NotFound")
+}
+
+type actionVersionKey struct {
+	instanceID string
+	actionID   string
+	version    uint64
+}
+
+// actionVersionCache caches the versions of the actions executed instead of their current version.
+// A version never changes after its creation, so it's cached until the cache is full.
+// The zero value is ready to use.
+type actionVersionCache struct {
+	mu       sync.RWMutex
+	versions map[actionVersionKey]*ActionVersion
+}
+
+func (c *actionVersionCache) get(key actionVersionKey) (*ActionVersion, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	v, ok := c.versions[key]
+	return v, ok
+}
+
+func (c *actionVersionCache) set(instanceID, actionID string, versions []*ActionVersion) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.versions == nil || len(c.versions)+len(versions) > actionVersionCacheSize {
+		c.versions = make(map[actionVersionKey]*ActionVersion, len(versions))
+	}
+	for _, v := range versions {
+		c.versions[actionVersionKey{instanceID: instanceID, actionID: actionID, version: v.Version}] = v
+	}
+}
+
+type ActionVersionsReadModel struct {
+	*eventstore.ReadModel
+
+	Versions []*ActionVersion
+
+	script        string
+	timeout       time.Duration
+	allowedToFail bool
+}
+
+func NewActionVersionsReadModel(actionID, resourceOwner string) *ActionVersionsReadModel {
+	return &ActionVersionsReadModel{
+		ReadModel: &eventstore.ReadModel{
+			AggregateID:   actionID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (rm *ActionVersionsReadModel) AppendEvents(events ...eventstore.Event) {
+	rm.ReadModel.AppendEvents(events...)
+}
+
+func (rm *ActionVersionsReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch e := event.(type) {
+		case *action.AddedEvent:
+			rm.script = e.Script
+			rm.timeout = e.Timeout
+			rm.allowedToFail = e.AllowedToFail
+			rm.addVersion(e.Sequence(), e.CreatedAt())
+		case *action.ChangedEvent:
+			if e.CanaryPercentage != nil {
+				rm.Versions = append(rm.Versions, &ActionVersion{
+					Version:       e.Sequence(),
+					CreationDate:  e.CreatedAt(),
+					Script:        *e.Script,
+					Timeout:       *e.Timeout,
+					AllowedToFail: *e.AllowedToFail,
+				})
+				continue
+			}
+			if !e.CreatesVersion() {
+				continue
+			}
+			if e.Script != nil {
+				rm.script = *e.Script
+			}
+			if e.Timeout != nil {
+				rm.timeout = *e.Timeout
+			}
+			if e.AllowedToFail != nil {
+				rm.allowedToFail = *e.AllowedToFail
+			}
+			rm.addVersion(e.Sequence(), e.CreatedAt())
+		case *action.VersionActivatedEvent:
+			rm.script = e.Script
+			rm.timeout = e.Timeout
+			rm.allowedToFail = e.AllowedToFail
+		case *action.RemovedEvent:
+			rm.Versions = nil
+		}
+	}
+	return rm.ReadModel.Reduce()
+}
+
+func (rm *ActionVersionsReadModel) addVersion(version uint64, creationDate time.Time) {
+	rm.Versions = append(rm.Versions, &ActionVersion{
+		Version:       version,
+		CreationDate:  creationDate,
+		Script:        rm.script,
+		Timeout:       rm.timeout,
+		AllowedToFail: rm.allowedToFail,
+	})
+}
+
+func (rm *ActionVersionsReadModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AwaitOpenTransactions().
+		AddQuery().
+		AggregateTypes(action.AggregateType).
+		AggregateIDs(rm.AggregateID).
+		EventTypes(action.AddedEventType,
+			action.ChangedEventType,
+			action.VersionActivatedEventType,
+			action.RemovedEventType).
+		Builder()
+
+	if rm.ResourceOwner != "" {
+		query.ResourceOwner(rm.ResourceOwner)
+	}
+	return query
+}
@@ -0,0 +1,170 @@
+package query
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	action_repo "github.com/zitadel/zitadel/internal/repository/action"
+)
+
+func actionEventWithSequence(sequence uint64, event eventstore.Command) *repository.Event {
+	e := eventFromEventPusher(event)
+	e.Seq = sequence
+	return e
+}
+
+func actionVersionEvents() []eventstore.Event {
+	agg := &action_repo.NewAggregate("actionID", "orgID").Aggregate
+	changed, _ := action_repo.NewChangedEvent(context.Background(), agg,
+		[]action_repo.ActionChanges{action_repo.ChangeScript("function v2() {}")},
+	)
+	renamed, _ := action_repo.NewChangedEvent(context.Background(), agg,
+		[]action_repo.ActionChanges{action_repo.ChangeName("name2", "name")},
+	)
+	canary, _ := action_repo.NewChangedEvent(context.Background(), agg,
+		[]action_repo.ActionChanges{action_repo.ChangeCanary(10, "function v4() {}", time.Second, true)},
+	)
+	return []eventstore.Event{
+		actionEventWithSequence(1, action_repo.NewAddedEvent(context.Background(), agg, "name", "function v1() {}", 0, false)),
+		actionEventWithSequence(2, changed),
+		actionEventWithSequence(3, renamed),
+		actionEventWithSequence(4, canary),
+	}
+}
+
+func TestQueries_ListActionVersions(t *testing.T) {
+	tests := []struct {
+		name       string
+		eventstore func(*testing.T) *eventstore.Eventstore
+		actionID   string
+		want       []*ActionVersion
+		wantErr    error
+	}{
+		{
+			name:       "id missing",
+			eventstore: expectEventstore(),
+			wantErr:    errs.ThrowInvalidArgument(nil, "QUERY-Wv3ks", "Errors.IDMissing"),
+		},
+		{
+			name: "not found",
+			eventstore: expectEventstore(
+				expectFilter(),
+			),
+			actionID: "actionID",
+			wantErr:  errs.ThrowNotFound(nil, "QUERY-Pq8cx", "Errors.Action.NotFound"),
+		},
+		{
+			name: "versions",
+			eventstore: expectEventstore(
+				expectFilter(actionVersionEvents()...),
+			),
+			actionID: "actionID",
+			want: []*ActionVersion{
+				{Version: 1, Script: "function v1() {}"},
+				{Version: 2, Script: "function v2() {}"},
+				{Version: 4, Script: "function v4() {}", Timeout: time.Second, AllowedToFail: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := &Queries{
+				eventstore: tt.eventstore(t),
+			}
+			ctx := authz.NewMockContext("instanceID", "orgID", "loginClient")
+			got, err := q.ListActionVersions(ctx, tt.actionID, "orgID")
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestQueries_selectActionVersion(t *testing.T) {
+	tests := []struct {
+		name       string
+		eventstore func(*testing.T) *eventstore.Eventstore
+		action     *Action
+		canaryHit  bool
+		want       *Action
+		wantErr    error
+	}{
+		{
+			name:       "current version",
+			eventstore: expectEventstore(),
+			action:     &Action{ID: "actionID", Script: "function v2() {}", Version: 2, CanaryVersion: 4, CanaryPercentage: 10},
+			want:       &Action{ID: "actionID", Script: "function v2() {}", Version: 2, CanaryVersion: 4, CanaryPercentage: 10, Rollout: ActionRolloutCurrent},
+		},
+		{
+			name: "canary version",
+			eventstore: expectEventstore(
+				expectFilter(actionVersionEvents()...),
+			),
+			action:    &Action{ID: "actionID", Script: "function v2() {}", Version: 2, CanaryVersion: 4, CanaryPercentage: 10},
+			canaryHit: true,
+			want:      &Action{ID: "actionID", Script: "function v4() {}", timeout: time.Second, AllowedToFail: true, Version: 4, CanaryVersion: 4, CanaryPercentage: 10, Rollout: ActionRolloutCanary},
+		},
+		{
+			name: "pinned version before canary",
+			eventstore: expectEventstore(
+				expectFilter(actionVersionEvents()...),
+			),
+			action:    &Action{ID: "actionID", Script: "function v2() {}", Version: 2, CanaryVersion: 4, CanaryPercentage: 10, pinnedVersion: 1},
+			canaryHit: true,
+			want:      &Action{ID: "actionID", Script: "function v1() {}", Version: 1, CanaryVersion: 4, CanaryPercentage: 10, pinnedVersion: 1, Rollout: ActionRolloutPinned},
+		},
+		{
+			name: "pinned version not found",
+			eventstore: expectEventstore(
+				expectFilter(actionVersionEvents()...),
+			),
+			action:  &Action{ID: "actionID", Script: "function v2() {}", Version: 2, pinnedVersion: 3},
+			wantErr: errs.ThrowNotFound(nil, "QUERY-Hn2rt", "Errors.Action.Version.NotFound"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hit := canaryHit
+			canaryHit = func(uint32) bool { return tt.canaryHit }
+			defer func() { canaryHit = hit }()
+
+			q := &Queries{
+				eventstore: tt.eventstore(t),
+			}
+			ctx := authz.NewMockContext("instanceID", "orgID", "loginClient")
+			err := q.selectActionVersion(ctx, tt.action)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, tt.action)
+		})
+	}
+}
+
+func TestQueries_selectActionVersion_cached(t *testing.T) {
+	q := &Queries{
+		// the versions are filtered once
+		eventstore: expectEventstore(
+			expectFilter(actionVersionEvents()...),
+		)(t),
+	}
+	ctx := authz.NewMockContext("instanceID", "orgID", "loginClient")
+	for _, pinned := range []uint64{1, 4, 1} {
+		a := &Action{ID: "actionID", Script: "function v2() {}", Version: 2, pinnedVersion: pinned}
+		require.NoError(t, q.selectActionVersion(ctx, a))
+		assert.Equal(t, pinned, a.Version)
+	}
+}
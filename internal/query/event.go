@@ -43,14 +43,10 @@ func (r *eventsReducer) Reduce() error { return nil }
 func (q *Queries) SearchEvents(ctx context.Context, query *eventstore.SearchQueryBuilder) (_ []*Event, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
-	auditLogRetention := q.defaultAuditLogRetention
-	instanceLimits, err := q.Limits(ctx, authz.GetInstance(ctx).InstanceID())
-	if err != nil && !errors.IsNotFound(err) {
+	auditLogRetention, err := q.auditLogRetention(ctx, authz.GetInstance(ctx).InstanceID())
+	if err != nil {
 		return nil, err
 	}
-	if instanceLimits != nil && instanceLimits.AuditLogRetention != nil {
-		auditLogRetention = *instanceLimits.AuditLogRetention
-	}
 	if auditLogRetention != 0 {
 		query = filterAuditLogRetention(ctx, auditLogRetention, query)
 	}
@@ -61,6 +57,17 @@ func (q *Queries) SearchEvents(ctx context.Context, query *eventstore.SearchQuer
 	return reducer.events, nil
 }
 
+func (q *Queries) auditLogRetention(ctx context.Context, instanceID string) (time.Duration, error) {
+	instanceLimits, err := q.Limits(ctx, instanceID)
+	if err != nil && !errors.IsNotFound(err) {
+		return 0, err
+	}
+	if instanceLimits != nil && instanceLimits.AuditLogRetention != nil {
+		return *instanceLimits.AuditLogRetention, nil
+	}
+	return q.defaultAuditLogRetention, nil
+}
+
 func filterAuditLogRetention(ctx context.Context, auditLogRetention time.Duration, builder *eventstore.SearchQueryBuilder) *eventstore.SearchQueryBuilder {
 	callTime := call.FromContext(ctx)
 	if callTime.IsZero() {
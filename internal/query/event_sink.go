@@ -0,0 +1,186 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+var (
+	eventSinksTable = table{
+		name:          projection.EventSinkProjectionTable,
+		instanceIDCol: projection.EventSinkColumnInstanceID,
+	}
+	EventSinkColumnID = Column{
+		name:  projection.EventSinkColumnID,
+		table: eventSinksTable,
+	}
+	EventSinkColumnCreationDate = Column{
+		name:  projection.EventSinkColumnCreationDate,
+		table: eventSinksTable,
+	}
+	EventSinkColumnChangeDate = Column{
+		name:  projection.EventSinkColumnChangeDate,
+		table: eventSinksTable,
+	}
+	EventSinkColumnInstanceID = Column{
+		name:  projection.EventSinkColumnInstanceID,
+		table: eventSinksTable,
+	}
+	EventSinkColumnSequence = Column{
+		name:  projection.EventSinkColumnSequence,
+		table: eventSinksTable,
+	}
+	EventSinkColumnName = Column{
+		name:  projection.EventSinkColumnName,
+		table: eventSinksTable,
+	}
+	EventSinkColumnSinkType = Column{
+		name:  projection.EventSinkColumnSinkType,
+		table: eventSinksTable,
+	}
+	EventSinkColumnEndpoint = Column{
+		name:  projection.EventSinkColumnEndpoint,
+		table: eventSinksTable,
+	}
+	EventSinkColumnTarget = Column{
+		name:  projection.EventSinkColumnTarget,
+		table: eventSinksTable,
+	}
+	EventSinkColumnAggregateTypes = Column{
+		name:  projection.EventSinkColumnAggregateTypes,
+		table: eventSinksTable,
+	}
+	EventSinkColumnEventTypes = Column{
+		name:  projection.EventSinkColumnEventTypes,
+		table: eventSinksTable,
+	}
+	EventSinkColumnSigningKey = Column{
+		name:  projection.EventSinkColumnSigningKey,
+		table: eventSinksTable,
+	}
+)
+
+type EventSinks struct {
+	SearchResponse
+	EventSinks []*EventSink
+}
+
+type EventSink struct {
+	ID           string
+	CreationDate time.Time
+	ChangeDate   time.Time
+	InstanceID   string
+	Sequence     uint64
+
+	Name           string
+	SinkType       domain.EventSinkType
+	Endpoint       string
+	Target         string
+	AggregateTypes database.TextArray[string]
+	EventTypes     database.TextArray[string]
+	SigningKey     *crypto.CryptoValue
+}
+
+// Subscribed returns true if the sink subscribed to the event,
+// no aggregate types or event types subscribe to all of them
+func (s *EventSink) Subscribed(event eventstore.Event) bool {
+	return containsOrEmpty(s.AggregateTypes, string(event.Aggregate().Type)) &&
+		containsOrEmpty(s.EventTypes, string(event.Type()))
+}
+
+func containsOrEmpty(list []string, value string) bool {
+	if len(list) == 0 {
+		return true
+	}
+	for _, entry := range list {
+		if entry == value {
+			return true
+		}
+	}
+	return false
+}
+
+// EventSinks returns all sinks of the instance
+func (q *Queries) EventSinks(ctx context.Context) (sinks *EventSinks, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareEventSinksQuery(ctx, q.client)
+	stmt, args, err := query.Where(sq.Eq{
+		EventSinkColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).OrderBy(EventSinkColumnCreationDate.identifier()).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Aesh3", "Errors.Query.InvalidRequest")
+	}
+
+	err = q.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		sinks, err = scan(rows)
+		return err
+	}, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	sinks.State, err = q.latestState(ctx, eventSinksTable)
+	return sinks, err
+}
+
+func prepareEventSinksQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*EventSinks, error)) {
+	return sq.Select(
+			EventSinkColumnID.identifier(),
+			EventSinkColumnCreationDate.identifier(),
+			EventSinkColumnChangeDate.identifier(),
+			EventSinkColumnInstanceID.identifier(),
+			EventSinkColumnSequence.identifier(),
+			EventSinkColumnName.identifier(),
+			EventSinkColumnSinkType.identifier(),
+			EventSinkColumnEndpoint.identifier(),
+			EventSinkColumnTarget.identifier(),
+			EventSinkColumnAggregateTypes.identifier(),
+			EventSinkColumnEventTypes.identifier(),
+			EventSinkColumnSigningKey.identifier(),
+			countColumn.identifier(),
+		).From(eventSinksTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*EventSinks, error) {
+			sinks := &EventSinks{EventSinks: []*EventSink{}}
+			for rows.Next() {
+				sink := new(EventSink)
+				err := rows.Scan(
+					&sink.ID,
+					&sink.CreationDate,
+					&sink.ChangeDate,
+					&sink.InstanceID,
+					&sink.Sequence,
+					&sink.Name,
+					&sink.SinkType,
+					&sink.Endpoint,
+					&sink.Target,
+					&sink.AggregateTypes,
+					&sink.EventTypes,
+					&sink.SigningKey,
+					&sinks.Count,
+				)
+				if err != nil {
+					return nil, errors.ThrowInternal(err, "QUERY-Shoo0", "Errors.Internal")
+				}
+				sinks.EventSinks = append(sinks.EventSinks, sink)
+			}
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-ieT7o", "Errors.Query.CloseRows")
+			}
+			return sinks, nil
+		}
+}
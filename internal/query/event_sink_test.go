@@ -0,0 +1,213 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	expectedEventSinksQuery = regexp.QuoteMeta(`SELECT projections.event_sinks.id,` +
+		` projections.event_sinks.creation_date,` +
+		` projections.event_sinks.change_date,` +
+		` projections.event_sinks.instance_id,` +
+		` projections.event_sinks.sequence,` +
+		` projections.event_sinks.name,` +
+		` projections.event_sinks.sink_type,` +
+		` projections.event_sinks.endpoint,` +
+		` projections.event_sinks.target,` +
+		` projections.event_sinks.aggregate_types,` +
+		` projections.event_sinks.event_types,` +
+		` projections.event_sinks.signing_key,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.event_sinks` +
+		` AS OF SYSTEM TIME '-1 ms'`)
+
+	eventSinksCols = []string{
+		"id",
+		"creation_date",
+		"change_date",
+		"instance_id",
+		"sequence",
+		"name",
+		"sink_type",
+		"endpoint",
+		"target",
+		"aggregate_types",
+		"event_types",
+		"signing_key",
+		"count",
+	}
+)
+
+func Test_EventSinksPrepare(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareEventSinksQuery no result",
+			prepare: prepareEventSinksQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					expectedEventSinksQuery,
+					nil,
+					nil,
+				),
+			},
+			object: &EventSinks{EventSinks: []*EventSink{}},
+		},
+		{
+			name:    "prepareEventSinksQuery multiple result",
+			prepare: prepareEventSinksQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					expectedEventSinksQuery,
+					eventSinksCols,
+					[][]driver.Value{
+						{
+							"sink-1",
+							testNow,
+							testNow,
+							"instance-id",
+							uint64(20211109),
+							"webhook",
+							domain.EventSinkTypeHTTP,
+							"https://example.com/events",
+							"",
+							database.TextArray[string]{"user"},
+							database.TextArray[string]{},
+							&crypto.CryptoValue{},
+						},
+						{
+							"sink-2",
+							testNow,
+							testNow,
+							"instance-id",
+							uint64(20211110),
+							"nats",
+							domain.EventSinkTypeNATS,
+							"nats://localhost:4222",
+							"zitadel.events",
+							database.TextArray[string]{},
+							database.TextArray[string]{"user.human.added"},
+							nil,
+						},
+					},
+				),
+			},
+			object: &EventSinks{
+				SearchResponse: SearchResponse{
+					Count: 2,
+				},
+				EventSinks: []*EventSink{
+					{
+						ID:             "sink-1",
+						CreationDate:   testNow,
+						ChangeDate:     testNow,
+						InstanceID:     "instance-id",
+						Sequence:       20211109,
+						Name:           "webhook",
+						SinkType:       domain.EventSinkTypeHTTP,
+						Endpoint:       "https://example.com/events",
+						AggregateTypes: database.TextArray[string]{"user"},
+						SigningKey:     &crypto.CryptoValue{},
+					},
+					{
+						ID:           "sink-2",
+						CreationDate: testNow,
+						ChangeDate:   testNow,
+						InstanceID:   "instance-id",
+						Sequence:     20211110,
+						Name:         "nats",
+						SinkType:     domain.EventSinkTypeNATS,
+						Endpoint:     "nats://localhost:4222",
+						Target:       "zitadel.events",
+						EventTypes:   database.TextArray[string]{"user.human.added"},
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareEventSinksQuery sql err",
+			prepare: prepareEventSinksQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					expectedEventSinksQuery,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*EventSinks)(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
+
+func TestEventSink_Subscribed(t *testing.T) {
+	event := &eventstore.BaseEvent{
+		Agg:       &eventstore.Aggregate{Type: "user"},
+		EventType: "user.human.added",
+	}
+	tests := []struct {
+		name string
+		sink *EventSink
+		want bool
+	}{
+		{
+			name: "all events",
+			sink: &EventSink{},
+			want: true,
+		},
+		{
+			name: "aggregate type",
+			sink: &EventSink{AggregateTypes: database.TextArray[string]{"org", "user"}},
+			want: true,
+		},
+		{
+			name: "other aggregate type",
+			sink: &EventSink{AggregateTypes: database.TextArray[string]{"org"}},
+			want: false,
+		},
+		{
+			name: "event type",
+			sink: &EventSink{AggregateTypes: database.TextArray[string]{"user"}, EventTypes: database.TextArray[string]{"user.human.added"}},
+			want: true,
+		},
+		{
+			name: "other event type",
+			sink: &EventSink{EventTypes: database.TextArray[string]{"user.human.changed"}},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.sink.Subscribed(event))
+		})
+	}
+}
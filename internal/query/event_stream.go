@@ -0,0 +1,315 @@
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/action"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+const (
+	eventStreamBulkLimit    = 200
+	eventStreamPollInterval = time.Second
+	// eventStreamPermissionTTL defines how long the permissions of the caller are cached,
+	// so revoked permissions are applied to running streams
+	eventStreamPermissionTTL = time.Minute
+	eventStreamPermission    = "events.read"
+)
+
+// EventStreamCursor identifies the last event passed to the stream.
+// Offset is the count of events already passed with the same position,
+// because all events of a transaction share the position.
+type EventStreamCursor struct {
+	Position float64
+	Offset   uint16
+}
+
+type EventStreamQuery struct {
+	Cursor         EventStreamCursor
+	AggregateTypes []eventstore.AggregateType
+	EventTypes     []eventstore.EventType
+}
+
+type StreamedEvent struct {
+	*Event
+	// Cursor must be stored by the client to resume the stream after this event
+	Cursor EventStreamCursor
+}
+
+// StreamEvents passes the events of the instance after the cursor to send until ctx is done or send fails.
+// The payloads of the events are redacted according to the permissions of the caller.
+func (q *Queries) StreamEvents(ctx context.Context, query *EventStreamQuery, send func(*StreamedEvent) error) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	notifications := make(chan eventstore.Notification, 1)
+	subscription := eventstore.SubscribeNotifications(notifications, streamedAggregateTypes(q.eventstore, query.AggregateTypes)...)
+	defer subscription.Unsubscribe()
+
+	redactor := newEventRedactor(q.checkPermission)
+	cursor := query.Cursor
+	ticker := time.NewTicker(eventStreamPollInterval)
+	defer ticker.Stop()
+	for {
+		events, err := q.streamEventsBulk(ctx, instanceID, cursor, query)
+		if err != nil {
+			return err
+		}
+		users := make(map[string]*EventEditor)
+		for _, event := range events {
+			if err = redactor.revalidate(ctx, time.Now()); err != nil {
+				return err
+			}
+			cursor = nextEventStreamCursor(cursor, event.Position())
+			converted := q.convertEvent(ctx, event, users)
+			converted.Payload = redactor.redact(ctx, event)
+			if err = send(&StreamedEvent{Event: converted, Cursor: cursor}); err != nil {
+				return err
+			}
+			if changesPermissions(event.Type()) {
+				redactor.invalidate()
+			}
+		}
+		if len(events) == eventStreamBulkLimit {
+			continue
+		}
+		if err = awaitStreamedEvents(ctx, instanceID, ticker.C, notifications); err != nil {
+			return err
+		}
+	}
+}
+
+func (q *Queries) streamEventsBulk(ctx context.Context, instanceID string, cursor EventStreamCursor, query *EventStreamQuery) ([]eventstore.Event, error) {
+	builder := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AwaitOpenTransactions().
+		Limit(eventStreamBulkLimit).
+		OrderAsc().
+		InstanceID(instanceID)
+	if cursor.Position > 0 {
+		// decrease position by 10 because builder.PositionAfter filters for position > and we need position >=
+		builder = builder.PositionAfter(math.Float64frombits(math.Float64bits(cursor.Position) - 10))
+		if cursor.Offset > 0 {
+			builder = builder.Offset(cursor.Offset)
+		}
+	}
+	if len(query.AggregateTypes) > 0 || len(query.EventTypes) > 0 {
+		builder = builder.AddQuery().
+			AggregateTypes(query.AggregateTypes...).
+			EventTypes(query.EventTypes...).
+			Builder()
+	}
+	auditLogRetention, err := q.auditLogRetention(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	if auditLogRetention != 0 {
+		builder = filterAuditLogRetention(ctx, auditLogRetention, builder)
+	}
+	return q.eventstore.Filter(ctx, builder)
+}
+
+func nextEventStreamCursor(cursor EventStreamCursor, position float64) EventStreamCursor {
+	if cursor.Position == position {
+		cursor.Offset++
+		return cursor
+	}
+	return EventStreamCursor{Position: position, Offset: 1}
+}
+
+// awaitStreamedEvents blocks until the next poll, a notification of the instance from another node or ctx is done
+func awaitStreamedEvents(ctx context.Context, instanceID string, poll <-chan time.Time, notifications <-chan eventstore.Notification) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-poll:
+			return nil
+		case notification := <-notifications:
+			if notification.InstanceID == instanceID {
+				return nil
+			}
+		}
+	}
+}
+
+func streamedAggregateTypes(es *eventstore.Eventstore, aggregateTypes []eventstore.AggregateType) []eventstore.AggregateType {
+	if len(aggregateTypes) > 0 {
+		return aggregateTypes
+	}
+	registered := es.AggregateTypes()
+	types := make([]eventstore.AggregateType, len(registered))
+	for i, typ := range registered {
+		types[i] = eventstore.AggregateType(typ)
+	}
+	return types
+}
+
+// secretPayloadKeys are removed from every streamed payload independent of the permissions of the caller
+var secretPayloadKeys = map[string]bool{
+	"encodedHash":    true,
+	"encodedHashes":  true,
+	"hashedSecret":   true,
+	"secret":         true,
+	"refreshToken":   true,
+	"privateKey":     true,
+	"idpIdToken":     true,
+	"idpAccessToken": true,
+	"bindPassword":   true,
+	"clientSecret":   true,
+}
+
+type eventRedactor struct {
+	checkPermission domain.PermissionCheck
+	// permitted caches the result of the permission check per permission and resource owner
+	permitted map[string]bool
+	// validatedAt is the time the permission of the caller to stream the events was last checked
+	validatedAt time.Time
+}
+
+func newEventRedactor(checkPermission domain.PermissionCheck) *eventRedactor {
+	return &eventRedactor{
+		checkPermission: checkPermission,
+		permitted:       make(map[string]bool),
+	}
+}
+
+// revalidate checks the permission of the caller to stream the events again
+// and drops the cached permissions after [eventStreamPermissionTTL] or if they were invalidated
+func (r *eventRedactor) revalidate(ctx context.Context, now time.Time) error {
+	if !r.validatedAt.IsZero() && now.Sub(r.validatedAt) < eventStreamPermissionTTL {
+		return nil
+	}
+	r.permitted = make(map[string]bool)
+	if err := r.checkPermission(ctx, eventStreamPermission, authz.GetCtxData(ctx).OrgID, ""); err != nil {
+		return err
+	}
+	r.validatedAt = now
+	return nil
+}
+
+// invalidate forces the permissions to be checked again before the next event is passed
+func (r *eventRedactor) invalidate() {
+	r.validatedAt = time.Time{}
+}
+
+// permissionEventTypes change the memberships or the state of users
+// and therefore might revoke permissions of the caller
+var permissionEventTypes = map[eventstore.EventType]bool{
+	instance.MemberChangedEventType:        true,
+	instance.MemberRemovedEventType:        true,
+	instance.MemberCascadeRemovedEventType: true,
+	org.MemberChangedEventType:             true,
+	org.MemberRemovedEventType:             true,
+	org.MemberCascadeRemovedEventType:      true,
+	project.MemberChangedType:              true,
+	project.MemberRemovedType:              true,
+	project.MemberCascadeRemovedType:       true,
+	user.UserLockedType:                    true,
+	user.UserDeactivatedType:               true,
+	user.UserRemovedType:                   true,
+}
+
+func changesPermissions(eventType eventstore.EventType) bool {
+	return permissionEventTypes[eventType]
+}
+
+// redact returns the payload of the event without secrets
+// or no payload at all if the caller is not allowed to read the aggregate
+func (r *eventRedactor) redact(ctx context.Context, event eventstore.Event) []byte {
+	payload := event.DataAsBytes()
+	if len(payload) == 0 {
+		return payload
+	}
+	if !r.isPermitted(ctx, readPermissionOfAggregate(event.Aggregate().Type), event.Aggregate().ResourceOwner) {
+		return nil
+	}
+	redacted, err := StripPayloadSecrets(payload)
+	if err != nil {
+		return nil
+	}
+	return redacted
+}
+
+// StripPayloadSecrets removes the secrets like hashes and encrypted values from the payload of an event,
+// before it is passed outside of ZITADEL
+func StripPayloadSecrets(payload []byte) ([]byte, error) {
+	if len(payload) == 0 {
+		return payload, nil
+	}
+	var data any
+	if err := json.Unmarshal(payload, &data); err != nil {
+		return nil, err
+	}
+	return json.Marshal(stripSecrets(data))
+}
+
+func (r *eventRedactor) isPermitted(ctx context.Context, permission, resourceOwner string) bool {
+	key := permission + ":" + resourceOwner
+	permitted, ok := r.permitted[key]
+	if !ok {
+		permitted = r.checkPermission(ctx, permission, resourceOwner, "") == nil
+		r.permitted[key] = permitted
+	}
+	return permitted
+}
+
+func readPermissionOfAggregate(aggregateType eventstore.AggregateType) string {
+	switch aggregateType {
+	case user.AggregateType:
+		return domain.PermissionUserRead
+	case usergrant.AggregateType:
+		return "user.grant.read"
+	case org.AggregateType:
+		return "org.read"
+	case project.AggregateType:
+		return "project.read"
+	case action.AggregateType:
+		return "org.action.read"
+	default:
+		return "iam.read"
+	}
+}
+
+// stripSecrets removes the secret keys and encrypted values ([crypto.CryptoValue]) of the decoded payload
+func stripSecrets(data any) any {
+	switch value := data.(type) {
+	case map[string]any:
+		for key, field := range value {
+			if secretPayloadKeys[key] || isCryptoValue(field) {
+				delete(value, key)
+				continue
+			}
+			value[key] = stripSecrets(field)
+		}
+		return value
+	case []any:
+		for i, field := range value {
+			value[i] = stripSecrets(field)
+		}
+		return value
+	default:
+		return value
+	}
+}
+
+func isCryptoValue(data any) bool {
+	value, ok := data.(map[string]any)
+	if !ok {
+		return false
+	}
+	_, crypted := value["Crypted"]
+	_, cryptoType := value["CryptoType"]
+	return crypted && cryptoType
+}
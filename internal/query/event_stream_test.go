@@ -0,0 +1,160 @@
+package query
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func Test_eventRedactor_redact(t *testing.T) {
+	newEvent := func(aggregateType eventstore.AggregateType, resourceOwner string, data string) eventstore.Event {
+		return &eventstore.BaseEvent{
+			Agg: &eventstore.Aggregate{
+				ID:            "id",
+				Type:          aggregateType,
+				ResourceOwner: resourceOwner,
+			},
+			Data: []byte(data),
+		}
+	}
+	permitOrg := func(orgID string) func(ctx context.Context, permission, orgID, resourceID string) error {
+		return func(_ context.Context, _, resourceOwner, _ string) error {
+			if resourceOwner == orgID {
+				return nil
+			}
+			return errors.ThrowPermissionDenied(nil, "AUTHZ-HKJD33", "Errors.PermissionDenied")
+		}
+	}
+	tests := []struct {
+		name            string
+		checkPermission func(ctx context.Context, permission, orgID, resourceID string) error
+		event           eventstore.Event
+		want            string
+	}{
+		{
+			name:            "no payload",
+			checkPermission: permitOrg("org1"),
+			event:           newEvent(user.AggregateType, "org1", ""),
+			want:            "",
+		},
+		{
+			name:            "permission denied",
+			checkPermission: permitOrg("org1"),
+			event:           newEvent(user.AggregateType, "org2", `{"userName":"username"}`),
+			want:            "",
+		},
+		{
+			name:            "secrets stripped",
+			checkPermission: permitOrg("org1"),
+			event: newEvent(user.AggregateType, "org1", `{
+				"userName":"username",
+				"encodedHash":"$2a$hash",
+				"code":{"CryptoType":0,"Algorithm":"enc","KeyID":"id","Crypted":"Y29kZQ=="},
+				"nested":[{"secret":"s","name":"n"}]
+			}`),
+			want: `{"nested":[{"name":"n"}],"userName":"username"}`,
+		},
+		{
+			name:            "invalid payload",
+			checkPermission: permitOrg("org1"),
+			event:           newEvent(user.AggregateType, "org1", `{`),
+			want:            "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newEventRedactor(tt.checkPermission)
+			assert.Equal(t, tt.want, string(r.redact(context.Background(), tt.event)))
+		})
+	}
+}
+
+func Test_eventRedactor_isPermitted_cached(t *testing.T) {
+	var checks int
+	r := newEventRedactor(func(context.Context, string, string, string) error {
+		checks++
+		return nil
+	})
+	assert.True(t, r.isPermitted(context.Background(), "user.read", "org1"))
+	assert.True(t, r.isPermitted(context.Background(), "user.read", "org1"))
+	assert.True(t, r.isPermitted(context.Background(), "org.read", "org1"))
+	assert.Equal(t, 2, checks)
+}
+
+func Test_nextEventStreamCursor(t *testing.T) {
+	tests := []struct {
+		name     string
+		cursor   EventStreamCursor
+		position float64
+		want     EventStreamCursor
+	}{
+		{
+			name:     "initial",
+			cursor:   EventStreamCursor{},
+			position: 1.5,
+			want:     EventStreamCursor{Position: 1.5, Offset: 1},
+		},
+		{
+			name:     "same position",
+			cursor:   EventStreamCursor{Position: 1.5, Offset: 1},
+			position: 1.5,
+			want:     EventStreamCursor{Position: 1.5, Offset: 2},
+		},
+		{
+			name:     "next position",
+			cursor:   EventStreamCursor{Position: 1.5, Offset: 2},
+			position: 2.5,
+			want:     EventStreamCursor{Position: 2.5, Offset: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, nextEventStreamCursor(tt.cursor, tt.position))
+		})
+	}
+}
+
+func Test_eventRedactor_revalidate(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var (
+		checks  int
+		revoked bool
+	)
+	r := newEventRedactor(func(_ context.Context, permission, _, _ string) error {
+		checks++
+		if revoked {
+			return errors.ThrowPermissionDenied(nil, "AUTHZ-HKJD33", "Errors.PermissionDenied")
+		}
+		return nil
+	})
+
+	require.NoError(t, r.revalidate(context.Background(), now))
+	r.permitted["user.read:org1"] = true
+	require.NoError(t, r.revalidate(context.Background(), now.Add(eventStreamPermissionTTL/2)))
+	assert.Equal(t, 1, checks, "permission must be cached within the ttl")
+	assert.True(t, r.permitted["user.read:org1"])
+
+	require.NoError(t, r.revalidate(context.Background(), now.Add(eventStreamPermissionTTL)))
+	assert.Equal(t, 2, checks, "permission must be checked after the ttl")
+	assert.Empty(t, r.permitted)
+
+	revoked = true
+	r.invalidate()
+	err := r.revalidate(context.Background(), now.Add(eventStreamPermissionTTL))
+	assert.True(t, errors.IsPermissionDenied(err))
+	assert.Equal(t, 3, checks, "permission must be checked after invalidation")
+}
+
+func Test_changesPermissions(t *testing.T) {
+	assert.True(t, changesPermissions(instance.MemberRemovedEventType))
+	assert.True(t, changesPermissions(user.UserDeactivatedType))
+	assert.False(t, changesPermissions(user.HumanAddedType))
+}
@@ -0,0 +1,123 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+var (
+	executionsTable = table{
+		name:          projection.ExecutionProjectionTable,
+		instanceIDCol: projection.ExecutionColumnInstanceID,
+	}
+	ExecutionColumnID = Column{
+		name:  projection.ExecutionColumnID,
+		table: executionsTable,
+	}
+	ExecutionColumnCreationDate = Column{
+		name:  projection.ExecutionColumnCreationDate,
+		table: executionsTable,
+	}
+	ExecutionColumnChangeDate = Column{
+		name:  projection.ExecutionColumnChangeDate,
+		table: executionsTable,
+	}
+	ExecutionColumnInstanceID = Column{
+		name:  projection.ExecutionColumnInstanceID,
+		table: executionsTable,
+	}
+	ExecutionColumnSequence = Column{
+		name:  projection.ExecutionColumnSequence,
+		table: executionsTable,
+	}
+	ExecutionColumnTargets = Column{
+		name:  projection.ExecutionColumnTargets,
+		table: executionsTable,
+	}
+)
+
+type Executions struct {
+	SearchResponse
+	Executions []*Execution
+}
+
+type Execution struct {
+	// ID is the execution type followed by the condition, see [domain.ExecutionID]
+	ID           string
+	CreationDate time.Time
+	ChangeDate   time.Time
+	InstanceID   string
+	Sequence     uint64
+
+	Targets database.TextArray[string]
+}
+
+// Executions returns all executions of the instance
+func (q *Queries) Executions(ctx context.Context) (executions *Executions, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareExecutionsQuery(ctx, q.client)
+	stmt, args, err := query.Where(sq.Eq{
+		ExecutionColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).OrderBy(ExecutionColumnID.identifier()).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Chah6", "Errors.Query.InvalidRequest")
+	}
+
+	err = q.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		executions, err = scan(rows)
+		return err
+	}, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	executions.State, err = q.latestState(ctx, executionsTable)
+	return executions, err
+}
+
+func prepareExecutionsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*Executions, error)) {
+	return sq.Select(
+			ExecutionColumnID.identifier(),
+			ExecutionColumnCreationDate.identifier(),
+			ExecutionColumnChangeDate.identifier(),
+			ExecutionColumnInstanceID.identifier(),
+			ExecutionColumnSequence.identifier(),
+			ExecutionColumnTargets.identifier(),
+			countColumn.identifier(),
+		).From(executionsTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*Executions, error) {
+			executions := &Executions{Executions: []*Execution{}}
+			for rows.Next() {
+				execution := new(Execution)
+				err := rows.Scan(
+					&execution.ID,
+					&execution.CreationDate,
+					&execution.ChangeDate,
+					&execution.InstanceID,
+					&execution.Sequence,
+					&execution.Targets,
+					&executions.Count,
+				)
+				if err != nil {
+					return nil, errors.ThrowInternal(err, "QUERY-ahN4i", "Errors.Internal")
+				}
+				executions.Executions = append(executions.Executions, execution)
+			}
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-ohX5o", "Errors.Query.CloseRows")
+			}
+			return executions, nil
+		}
+}
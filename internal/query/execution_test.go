@@ -0,0 +1,133 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+)
+
+var (
+	expectedExecutionsQuery = regexp.QuoteMeta(`SELECT projections.executions.id,` +
+		` projections.executions.creation_date,` +
+		` projections.executions.change_date,` +
+		` projections.executions.instance_id,` +
+		` projections.executions.sequence,` +
+		` projections.executions.targets,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.executions` +
+		` AS OF SYSTEM TIME '-1 ms'`)
+
+	executionsCols = []string{
+		"id",
+		"creation_date",
+		"change_date",
+		"instance_id",
+		"sequence",
+		"targets",
+		"count",
+	}
+)
+
+func Test_ExecutionsPrepare(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareExecutionsQuery no result",
+			prepare: prepareExecutionsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					expectedExecutionsQuery,
+					nil,
+					nil,
+				),
+			},
+			object: &Executions{Executions: []*Execution{}},
+		},
+		{
+			name:    "prepareExecutionsQuery multiple result",
+			prepare: prepareExecutionsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					expectedExecutionsQuery,
+					executionsCols,
+					[][]driver.Value{
+						{
+							"event/user.human.added",
+							testNow,
+							testNow,
+							"instance-id",
+							uint64(20211109),
+							database.TextArray[string]{"target-1"},
+						},
+						{
+							"request/zitadel.user.v2beta.UserService",
+							testNow,
+							testNow,
+							"instance-id",
+							uint64(20211110),
+							database.TextArray[string]{"target-1", "target-2"},
+						},
+					},
+				),
+			},
+			object: &Executions{
+				SearchResponse: SearchResponse{
+					Count: 2,
+				},
+				Executions: []*Execution{
+					{
+						ID:           "event/user.human.added",
+						CreationDate: testNow,
+						ChangeDate:   testNow,
+						InstanceID:   "instance-id",
+						Sequence:     20211109,
+						Targets:      database.TextArray[string]{"target-1"},
+					},
+					{
+						ID:           "request/zitadel.user.v2beta.UserService",
+						CreationDate: testNow,
+						ChangeDate:   testNow,
+						InstanceID:   "instance-id",
+						Sequence:     20211110,
+						Targets:      database.TextArray[string]{"target-1", "target-2"},
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareExecutionsQuery sql err",
+			prepare: prepareExecutionsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					expectedExecutionsQuery,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*Executions)(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
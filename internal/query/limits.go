@@ -7,6 +7,7 @@ import (
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/muhlemmer/gu"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/call"
@@ -49,6 +50,34 @@ var (
 		name:  projection.LimitsColumnAuditLogRetention,
 		table: limitSettingsTable,
 	}
+	LimitsColumnActionMaxAllocations = Column{
+		name:  projection.LimitsColumnActionMaxAllocations,
+		table: limitSettingsTable,
+	}
+	LimitsColumnActionMaxLoopIterations = Column{
+		name:  projection.LimitsColumnActionMaxLoopIterations,
+		table: limitSettingsTable,
+	}
+	LimitsColumnActionMaxOutputSize = Column{
+		name:  projection.LimitsColumnActionMaxOutputSize,
+		table: limitSettingsTable,
+	}
+	LimitsColumnActionMaxHTTPCalls = Column{
+		name:  projection.LimitsColumnActionMaxHTTPCalls,
+		table: limitSettingsTable,
+	}
+	LimitsColumnActionMaxHTTPResponseSize = Column{
+		name:  projection.LimitsColumnActionMaxHTTPResponseSize,
+		table: limitSettingsTable,
+	}
+	LimitsColumnActionMaxConcurrentRuns = Column{
+		name:  projection.LimitsColumnActionMaxConcurrentRuns,
+		table: limitSettingsTable,
+	}
+	LimitsColumnActionAllowedDomains = Column{
+		name:  projection.LimitsColumnActionAllowedDomains,
+		table: limitSettingsTable,
+	}
 )
 
 type Limits struct {
@@ -59,6 +88,14 @@ type Limits struct {
 	Sequence      uint64
 
 	AuditLogRetention *time.Duration
+
+	ActionMaxAllocations      *uint64
+	ActionMaxLoopIterations   *uint64
+	ActionMaxOutputSize       *uint64
+	ActionMaxHTTPCalls        *uint32
+	ActionMaxHTTPResponseSize *uint64
+	ActionMaxConcurrentRuns   *uint32
+	ActionAllowedDomains      []string
 }
 
 func (q *Queries) Limits(ctx context.Context, resourceOwner string) (limits *Limits, err error) {
@@ -89,6 +126,13 @@ func prepareLimitsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuild
 			LimitsColumnResourceOwner.identifier(),
 			LimitsColumnSequence.identifier(),
 			LimitsColumnAuditLogRetention.identifier(),
+			LimitsColumnActionMaxAllocations.identifier(),
+			LimitsColumnActionMaxLoopIterations.identifier(),
+			LimitsColumnActionMaxOutputSize.identifier(),
+			LimitsColumnActionMaxHTTPCalls.identifier(),
+			LimitsColumnActionMaxHTTPResponseSize.identifier(),
+			LimitsColumnActionMaxConcurrentRuns.identifier(),
+			LimitsColumnActionAllowedDomains.identifier(),
 		).
 			From(limitSettingsTable.identifier() + db.Timetravel(call.Took(ctx))).
 			PlaceholderFormat(sq.Dollar),
@@ -96,6 +140,14 @@ func prepareLimitsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuild
 			var (
 				limits            = new(Limits)
 				auditLogRetention database.NullDuration
+
+				actionMaxAllocations      sql.NullInt64
+				actionMaxLoopIterations   sql.NullInt64
+				actionMaxOutputSize       sql.NullInt64
+				actionMaxHTTPCalls        sql.NullInt64
+				actionMaxHTTPResponseSize sql.NullInt64
+				actionMaxConcurrentRuns   sql.NullInt64
+				actionAllowedDomains      database.TextArray[string]
 			)
 			err := row.Scan(
 				&limits.AggregateID,
@@ -104,6 +156,13 @@ func prepareLimitsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuild
 				&limits.ResourceOwner,
 				&limits.Sequence,
 				&auditLogRetention,
+				&actionMaxAllocations,
+				&actionMaxLoopIterations,
+				&actionMaxOutputSize,
+				&actionMaxHTTPCalls,
+				&actionMaxHTTPResponseSize,
+				&actionMaxConcurrentRuns,
+				&actionAllowedDomains,
 			)
 			if err != nil {
 				if errs.Is(err, sql.ErrNoRows) {
@@ -114,6 +173,27 @@ func prepareLimitsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuild
 			if auditLogRetention.Valid {
 				limits.AuditLogRetention = &auditLogRetention.Duration
 			}
+			if actionMaxAllocations.Valid {
+				limits.ActionMaxAllocations = gu.Ptr(uint64(actionMaxAllocations.Int64))
+			}
+			if actionMaxLoopIterations.Valid {
+				limits.ActionMaxLoopIterations = gu.Ptr(uint64(actionMaxLoopIterations.Int64))
+			}
+			if actionMaxOutputSize.Valid {
+				limits.ActionMaxOutputSize = gu.Ptr(uint64(actionMaxOutputSize.Int64))
+			}
+			if actionMaxHTTPCalls.Valid {
+				limits.ActionMaxHTTPCalls = gu.Ptr(uint32(actionMaxHTTPCalls.Int64))
+			}
+			if actionMaxHTTPResponseSize.Valid {
+				limits.ActionMaxHTTPResponseSize = gu.Ptr(uint64(actionMaxHTTPResponseSize.Int64))
+			}
+			if actionMaxConcurrentRuns.Valid {
+				limits.ActionMaxConcurrentRuns = gu.Ptr(uint32(actionMaxConcurrentRuns.Int64))
+			}
+			if len(actionAllowedDomains) > 0 {
+				limits.ActionAllowedDomains = actionAllowedDomains
+			}
 			return limits, nil
 		}
 }
@@ -11,17 +11,25 @@ import (
 
 	"github.com/muhlemmer/gu"
 
+	"github.com/zitadel/zitadel/internal/database"
 	errs "github.com/zitadel/zitadel/internal/errors"
 )
 
 var (
-	expectedLimitsQuery = regexp.QuoteMeta("SELECT projections.limits.aggregate_id," +
-		" projections.limits.creation_date," +
-		" projections.limits.change_date," +
-		" projections.limits.resource_owner," +
-		" projections.limits.sequence," +
-		" projections.limits.audit_log_retention" +
-		" FROM projections.limits" +
+	expectedLimitsQuery = regexp.QuoteMeta("SELECT projections.limits2.aggregate_id," +
+		" projections.limits2.creation_date," +
+		" projections.limits2.change_date," +
+		" projections.limits2.resource_owner," +
+		" projections.limits2.sequence," +
+		" projections.limits2.audit_log_retention," +
+		" projections.limits2.action_max_allocations," +
+		" projections.limits2.action_max_loop_iterations," +
+		" projections.limits2.action_max_output_size," +
+		" projections.limits2.action_max_http_calls," +
+		" projections.limits2.action_max_http_response_size," +
+		" projections.limits2.action_max_concurrent_runs," +
+		" projections.limits2.action_allowed_domains" +
+		" FROM projections.limits2" +
 		" AS OF SYSTEM TIME '-1 ms'",
 	)
 
@@ -32,6 +40,13 @@ var (
 		"resource_owner",
 		"sequence",
 		"audit_log_retention",
+		"action_max_allocations",
+		"action_max_loop_iterations",
+		"action_max_output_size",
+		"action_max_http_calls",
+		"action_max_http_response_size",
+		"action_max_concurrent_runs",
+		"action_allowed_domains",
 	}
 )
 
@@ -78,6 +93,13 @@ func Test_LimitsPrepare(t *testing.T) {
 						"instance1",
 						0,
 						intervalDriverValue(t, time.Hour),
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -90,6 +112,45 @@ func Test_LimitsPrepare(t *testing.T) {
 				AuditLogRetention: gu.Ptr(time.Hour),
 			},
 		},
+		{
+			name:    "prepareLimitsQuery action limits",
+			prepare: prepareLimitsQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					expectedLimitsQuery,
+					limitsCols,
+					[]driver.Value{
+						"limits1",
+						testNow,
+						testNow,
+						"instance1",
+						0,
+						nil,
+						1048576,
+						100000,
+						65536,
+						5,
+						1024,
+						2,
+						database.TextArray[string]{"zitadel.com"},
+					},
+				),
+			},
+			object: &Limits{
+				AggregateID:               "limits1",
+				CreationDate:              testNow,
+				ChangeDate:                testNow,
+				ResourceOwner:             "instance1",
+				Sequence:                  0,
+				ActionMaxAllocations:      gu.Ptr(uint64(1048576)),
+				ActionMaxLoopIterations:   gu.Ptr(uint64(100000)),
+				ActionMaxOutputSize:       gu.Ptr(uint64(65536)),
+				ActionMaxHTTPCalls:        gu.Ptr(uint32(5)),
+				ActionMaxHTTPResponseSize: gu.Ptr(uint64(1024)),
+				ActionMaxConcurrentRuns:   gu.Ptr(uint32(2)),
+				ActionAllowedDomains:      []string{"zitadel.com"},
+			},
+		},
 		{
 			name:    "prepareLimitsQuery sql err",
 			prepare: prepareLimitsQuery,
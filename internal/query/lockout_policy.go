@@ -29,6 +29,9 @@ type LockoutPolicy struct {
 
 	MaxPasswordAttempts uint64
 	ShowFailures        bool
+	MaxOTPAttempts      uint64
+	AutoUnlockAfter     time.Duration
+	FailedAttemptDelay  time.Duration
 
 	IsDefault bool
 }
@@ -70,6 +73,18 @@ var (
 		name:  projection.LockoutPolicyMaxPasswordAttemptsCol,
 		table: lockoutTable,
 	}
+	LockoutColMaxOTPAttempts = Column{
+		name:  projection.LockoutPolicyMaxOTPAttemptsCol,
+		table: lockoutTable,
+	}
+	LockoutColAutoUnlockAfter = Column{
+		name:  projection.LockoutPolicyAutoUnlockAfterCol,
+		table: lockoutTable,
+	}
+	LockoutColFailedAttemptDelay = Column{
+		name:  projection.LockoutPolicyFailedAttemptDelayCol,
+		table: lockoutTable,
+	}
 	LockoutColIsDefault = Column{
 		name:  projection.LockoutPolicyIsDefaultCol,
 		table: lockoutTable,
@@ -154,6 +169,9 @@ func prepareLockoutPolicyQuery(ctx context.Context, db prepareDatabase) (sq.Sele
 			LockoutColResourceOwner.identifier(),
 			LockoutColShowFailures.identifier(),
 			LockoutColMaxPasswordAttempts.identifier(),
+			LockoutColMaxOTPAttempts.identifier(),
+			LockoutColAutoUnlockAfter.identifier(),
+			LockoutColFailedAttemptDelay.identifier(),
 			LockoutColIsDefault.identifier(),
 			LockoutColState.identifier(),
 		).
@@ -169,6 +187,9 @@ func prepareLockoutPolicyQuery(ctx context.Context, db prepareDatabase) (sq.Sele
 				&policy.ResourceOwner,
 				&policy.ShowFailures,
 				&policy.MaxPasswordAttempts,
+				&policy.MaxOTPAttempts,
+				&policy.AutoUnlockAfter,
+				&policy.FailedAttemptDelay,
 				&policy.IsDefault,
 				&policy.State,
 			)
@@ -7,22 +7,26 @@ import (
 	"fmt"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/domain"
 	errs "github.com/zitadel/zitadel/internal/errors"
 )
 
 var (
-	prepareLockoutPolicyStmt = `SELECT projections.lockout_policies2.id,` +
-		` projections.lockout_policies2.sequence,` +
-		` projections.lockout_policies2.creation_date,` +
-		` projections.lockout_policies2.change_date,` +
-		` projections.lockout_policies2.resource_owner,` +
-		` projections.lockout_policies2.show_failure,` +
-		` projections.lockout_policies2.max_password_attempts,` +
-		` projections.lockout_policies2.is_default,` +
-		` projections.lockout_policies2.state` +
-		` FROM projections.lockout_policies2` +
+	prepareLockoutPolicyStmt = `SELECT projections.lockout_policies3.id,` +
+		` projections.lockout_policies3.sequence,` +
+		` projections.lockout_policies3.creation_date,` +
+		` projections.lockout_policies3.change_date,` +
+		` projections.lockout_policies3.resource_owner,` +
+		` projections.lockout_policies3.show_failure,` +
+		` projections.lockout_policies3.max_password_attempts,` +
+		` projections.lockout_policies3.max_otp_attempts,` +
+		` projections.lockout_policies3.auto_unlock_after,` +
+		` projections.lockout_policies3.failed_attempt_delay,` +
+		` projections.lockout_policies3.is_default,` +
+		` projections.lockout_policies3.state` +
+		` FROM projections.lockout_policies3` +
 		` AS OF SYSTEM TIME '-1 ms'`
 
 	prepareLockoutPolicyCols = []string{
@@ -33,6 +37,9 @@ var (
 		"resource_owner",
 		"show_failure",
 		"max_password_attempts",
+		"max_otp_attempts",
+		"auto_unlock_after",
+		"failed_attempt_delay",
 		"is_default",
 		"state",
 	}
@@ -82,6 +89,9 @@ func Test_LockoutPolicyPrepares(t *testing.T) {
 						"ro",
 						true,
 						20,
+						5,
+						time.Hour,
+						time.Second,
 						true,
 						domain.PolicyStateActive,
 					},
@@ -96,6 +106,9 @@ func Test_LockoutPolicyPrepares(t *testing.T) {
 				State:               domain.PolicyStateActive,
 				ShowFailures:        true,
 				MaxPasswordAttempts: 20,
+				MaxOTPAttempts:      5,
+				AutoUnlockAfter:     time.Hour,
+				FailedAttemptDelay:  time.Second,
 				IsDefault:           true,
 			},
 		},
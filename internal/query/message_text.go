@@ -33,6 +33,8 @@ type MessageTexts struct {
 	DomainClaimed            MessageText
 	PasswordlessRegistration MessageText
 	PasswordChange           MessageText
+	RecoveryCodeUsed         MessageText
+	PasswordExpiryWarning    MessageText
 }
 
 type MessageText struct {
@@ -346,6 +348,10 @@ func (m *MessageTexts) GetMessageTextByType(msgType string) *MessageText {
 		return &m.PasswordlessRegistration
 	case domain.PasswordChangeMessageType:
 		return &m.PasswordChange
+	case domain.RecoveryCodeUsedMessageType:
+		return &m.RecoveryCodeUsed
+	case domain.PasswordExpiryWarningMessageType:
+		return &m.PasswordExpiryWarning
 	}
 	return nil
 }
@@ -0,0 +1,260 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+var (
+	notificationMessagesTable = table{
+		name:          projection.NotificationMessageProjectionTable,
+		instanceIDCol: projection.NotificationMessageColumnInstanceID,
+	}
+	NotificationMessageColumnID = Column{
+		name:  projection.NotificationMessageColumnID,
+		table: notificationMessagesTable,
+	}
+	NotificationMessageColumnCreationDate = Column{
+		name:  projection.NotificationMessageColumnCreationDate,
+		table: notificationMessagesTable,
+	}
+	NotificationMessageColumnChangeDate = Column{
+		name:  projection.NotificationMessageColumnChangeDate,
+		table: notificationMessagesTable,
+	}
+	NotificationMessageColumnSequence = Column{
+		name:  projection.NotificationMessageColumnSequence,
+		table: notificationMessagesTable,
+	}
+	NotificationMessageColumnResourceOwner = Column{
+		name:  projection.NotificationMessageColumnResourceOwner,
+		table: notificationMessagesTable,
+	}
+	NotificationMessageColumnInstanceID = Column{
+		name:  projection.NotificationMessageColumnInstanceID,
+		table: notificationMessagesTable,
+	}
+	NotificationMessageColumnUserID = Column{
+		name:  projection.NotificationMessageColumnUserID,
+		table: notificationMessagesTable,
+	}
+	NotificationMessageColumnChannel = Column{
+		name:  projection.NotificationMessageColumnChannel,
+		table: notificationMessagesTable,
+	}
+	NotificationMessageColumnTriggeringEventType = Column{
+		name:  projection.NotificationMessageColumnTriggeringEventType,
+		table: notificationMessagesTable,
+	}
+	NotificationMessageColumnRecipient = Column{
+		name:  projection.NotificationMessageColumnRecipient,
+		table: notificationMessagesTable,
+	}
+	NotificationMessageColumnState = Column{
+		name:  projection.NotificationMessageColumnState,
+		table: notificationMessagesTable,
+	}
+	NotificationMessageColumnAttempts = Column{
+		name:  projection.NotificationMessageColumnAttempts,
+		table: notificationMessagesTable,
+	}
+	NotificationMessageColumnNextAttempt = Column{
+		name:  projection.NotificationMessageColumnNextAttempt,
+		table: notificationMessagesTable,
+	}
+	NotificationMessageColumnProviderID = Column{
+		name:  projection.NotificationMessageColumnProviderID,
+		table: notificationMessagesTable,
+	}
+	NotificationMessageColumnProviderMessageID = Column{
+		name:  projection.NotificationMessageColumnProviderMessageID,
+		table: notificationMessagesTable,
+	}
+	NotificationMessageColumnError = Column{
+		name:  projection.NotificationMessageColumnError,
+		table: notificationMessagesTable,
+	}
+)
+
+type NotificationMessages struct {
+	SearchResponse
+	NotificationMessages []*NotificationMessage
+}
+
+// NotificationMessage is the delivery status of a message in the notification outbox
+type NotificationMessage struct {
+	ID            string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	Sequence      uint64
+	ResourceOwner string
+	InstanceID    string
+
+	UserID              string
+	Channel             domain.NotificationType
+	TriggeringEventType string
+	Recipient           string
+	State               domain.NotificationMessageState
+	Attempts            uint16
+	// NextAttempt is only set if a failed attempt is retried
+	NextAttempt       time.Time
+	ProviderID        string
+	ProviderMessageID string
+	Error             string
+}
+
+type NotificationMessageSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *NotificationMessageSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func NewNotificationMessageUserIDSearchQuery(userID string) (SearchQuery, error) {
+	return NewTextQuery(NotificationMessageColumnUserID, userID, TextEquals)
+}
+
+func NewNotificationMessageResourceOwnerSearchQuery(resourceOwner string) (SearchQuery, error) {
+	return NewTextQuery(NotificationMessageColumnResourceOwner, resourceOwner, TextEquals)
+}
+
+func NewNotificationMessageStateSearchQuery(state domain.NotificationMessageState) (SearchQuery, error) {
+	return NewNumberQuery(NotificationMessageColumnState, state, NumberEquals)
+}
+
+// SearchNotificationMessages returns the delivery status of the messages of the instance
+func (q *Queries) SearchNotificationMessages(ctx context.Context, queries *NotificationMessageSearchQueries) (messages *NotificationMessages, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareNotificationMessagesQuery(ctx, q.client)
+	stmt, args, err := queries.toQuery(query).
+		Where(sq.Eq{
+			NotificationMessageColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+		}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Wai5o", "Errors.Query.InvalidRequest")
+	}
+
+	err = q.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		messages, err = scan(rows)
+		return err
+	}, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	messages.State, err = q.latestState(ctx, notificationMessagesTable)
+	return messages, err
+}
+
+// DueNotificationMessages returns the messages of the instance which are ready for their next delivery attempt.
+// Messages with a started delivery are returned again if it didn't finish within the deliveryTimeout.
+func (q *Queries) DueNotificationMessages(ctx context.Context, now time.Time, deliveryTimeout time.Duration, limit uint64) (messages *NotificationMessages, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareNotificationMessagesQuery(ctx, q.client)
+	stmt, args, err := query.
+		Where(sq.Eq{
+			NotificationMessageColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+		}).
+		Where(sq.Or{
+			sq.And{
+				sq.Eq{NotificationMessageColumnState.identifier(): domain.NotificationMessageStateQueued},
+				sq.Or{
+					sq.Eq{NotificationMessageColumnNextAttempt.identifier(): nil},
+					sq.LtOrEq{NotificationMessageColumnNextAttempt.identifier(): now},
+				},
+			},
+			sq.And{
+				sq.Eq{NotificationMessageColumnState.identifier(): domain.NotificationMessageStateSending},
+				sq.LtOrEq{NotificationMessageColumnChangeDate.identifier(): now.Add(-deliveryTimeout)},
+			},
+		}).
+		OrderBy(NotificationMessageColumnCreationDate.identifier()).
+		Limit(limit).
+		ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-ieN4u", "Errors.Query.InvalidRequest")
+	}
+
+	err = q.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		messages, err = scan(rows)
+		return err
+	}, stmt, args...)
+	return messages, err
+}
+
+func prepareNotificationMessagesQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*NotificationMessages, error)) {
+	return sq.Select(
+			NotificationMessageColumnID.identifier(),
+			NotificationMessageColumnCreationDate.identifier(),
+			NotificationMessageColumnChangeDate.identifier(),
+			NotificationMessageColumnSequence.identifier(),
+			NotificationMessageColumnResourceOwner.identifier(),
+			NotificationMessageColumnInstanceID.identifier(),
+			NotificationMessageColumnUserID.identifier(),
+			NotificationMessageColumnChannel.identifier(),
+			NotificationMessageColumnTriggeringEventType.identifier(),
+			NotificationMessageColumnRecipient.identifier(),
+			NotificationMessageColumnState.identifier(),
+			NotificationMessageColumnAttempts.identifier(),
+			NotificationMessageColumnNextAttempt.identifier(),
+			NotificationMessageColumnProviderID.identifier(),
+			NotificationMessageColumnProviderMessageID.identifier(),
+			NotificationMessageColumnError.identifier(),
+			countColumn.identifier(),
+		).From(notificationMessagesTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*NotificationMessages, error) {
+			messages := &NotificationMessages{NotificationMessages: []*NotificationMessage{}}
+			for rows.Next() {
+				message := new(NotificationMessage)
+				var nextAttempt sql.NullTime
+				err := rows.Scan(
+					&message.ID,
+					&message.CreationDate,
+					&message.ChangeDate,
+					&message.Sequence,
+					&message.ResourceOwner,
+					&message.InstanceID,
+					&message.UserID,
+					&message.Channel,
+					&message.TriggeringEventType,
+					&message.Recipient,
+					&message.State,
+					&message.Attempts,
+					&nextAttempt,
+					&message.ProviderID,
+					&message.ProviderMessageID,
+					&message.Error,
+					&messages.Count,
+				)
+				if err != nil {
+					return nil, errors.ThrowInternal(err, "QUERY-ooN6e", "Errors.Internal")
+				}
+				message.NextAttempt = nextAttempt.Time
+				messages.NotificationMessages = append(messages.NotificationMessages, message)
+			}
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Ohg0u", "Errors.Query.CloseRows")
+			}
+			return messages, nil
+		}
+}
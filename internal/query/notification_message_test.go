@@ -0,0 +1,190 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+var (
+	expectedNotificationMessagesQuery = regexp.QuoteMeta(`SELECT projections.notification_messages.id,` +
+		` projections.notification_messages.creation_date,` +
+		` projections.notification_messages.change_date,` +
+		` projections.notification_messages.sequence,` +
+		` projections.notification_messages.resource_owner,` +
+		` projections.notification_messages.instance_id,` +
+		` projections.notification_messages.user_id,` +
+		` projections.notification_messages.channel,` +
+		` projections.notification_messages.triggering_event_type,` +
+		` projections.notification_messages.recipient,` +
+		` projections.notification_messages.state,` +
+		` projections.notification_messages.attempts,` +
+		` projections.notification_messages.next_attempt,` +
+		` projections.notification_messages.provider_id,` +
+		` projections.notification_messages.provider_message_id,` +
+		` projections.notification_messages.error,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.notification_messages` +
+		` AS OF SYSTEM TIME '-1 ms'`)
+
+	notificationMessagesCols = []string{
+		"id",
+		"creation_date",
+		"change_date",
+		"sequence",
+		"resource_owner",
+		"instance_id",
+		"user_id",
+		"channel",
+		"triggering_event_type",
+		"recipient",
+		"state",
+		"attempts",
+		"next_attempt",
+		"provider_id",
+		"provider_message_id",
+		"error",
+		"count",
+	}
+)
+
+func Test_NotificationMessagesPrepare(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareNotificationMessagesQuery no result",
+			prepare: prepareNotificationMessagesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					expectedNotificationMessagesQuery,
+					nil,
+					nil,
+				),
+			},
+			object: &NotificationMessages{NotificationMessages: []*NotificationMessage{}},
+		},
+		{
+			name:    "prepareNotificationMessagesQuery multiple result",
+			prepare: prepareNotificationMessagesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					expectedNotificationMessagesQuery,
+					notificationMessagesCols,
+					[][]driver.Value{
+						{
+							"user:user-id:15",
+							testNow,
+							testNow,
+							uint64(20211109),
+							"org-id",
+							"instance-id",
+							"user-id",
+							domain.NotificationTypeEmail,
+							"user.human.initialization.code.added",
+							"user@example.com",
+							domain.NotificationMessageStateSent,
+							uint16(1),
+							nil,
+							"smtp-id",
+							"<user:user-id:15@zitadel>",
+							"",
+						},
+						{
+							"user:user-id:16",
+							testNow,
+							testNow,
+							uint64(20211110),
+							"org-id",
+							"instance-id",
+							"user-id",
+							domain.NotificationTypeSms,
+							"user.human.phone.code.added",
+							"+41791234567",
+							domain.NotificationMessageStateQueued,
+							uint16(2),
+							testNow,
+							"sms-id",
+							"",
+							"connection refused",
+						},
+					},
+				),
+			},
+			object: &NotificationMessages{
+				SearchResponse: SearchResponse{
+					Count: 2,
+				},
+				NotificationMessages: []*NotificationMessage{
+					{
+						ID:                  "user:user-id:15",
+						CreationDate:        testNow,
+						ChangeDate:          testNow,
+						Sequence:            20211109,
+						ResourceOwner:       "org-id",
+						InstanceID:          "instance-id",
+						UserID:              "user-id",
+						Channel:             domain.NotificationTypeEmail,
+						TriggeringEventType: "user.human.initialization.code.added",
+						Recipient:           "user@example.com",
+						State:               domain.NotificationMessageStateSent,
+						Attempts:            1,
+						ProviderID:          "smtp-id",
+						ProviderMessageID:   "<user:user-id:15@zitadel>",
+					},
+					{
+						ID:                  "user:user-id:16",
+						CreationDate:        testNow,
+						ChangeDate:          testNow,
+						Sequence:            20211110,
+						ResourceOwner:       "org-id",
+						InstanceID:          "instance-id",
+						UserID:              "user-id",
+						Channel:             domain.NotificationTypeSms,
+						TriggeringEventType: "user.human.phone.code.added",
+						Recipient:           "+41791234567",
+						State:               domain.NotificationMessageStateQueued,
+						Attempts:            2,
+						NextAttempt:         testNow,
+						ProviderID:          "sms-id",
+						Error:               "connection refused",
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareNotificationMessagesQuery sql err",
+			prepare: prepareNotificationMessagesQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					expectedNotificationMessagesQuery,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*NotificationMessages)(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
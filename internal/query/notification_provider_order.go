@@ -0,0 +1,197 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+type NotificationProviderOrder struct {
+	AggregateID   string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+	Sequence      uint64
+
+	Channel     domain.NotificationType
+	ProviderIDs database.TextArray[string]
+	IsDefault   bool
+	// EmailSender overrides the sender of the SMTP providers, it's nil if not set
+	EmailSender *EmailSender
+}
+
+type EmailSender struct {
+	Address        string
+	Name           string
+	ReplyToAddress string
+}
+
+var (
+	notificationProviderOrderTable = table{
+		name:          projection.NotificationProviderOrderProjectionTable,
+		instanceIDCol: projection.NotificationProviderOrderColumnInstanceID,
+	}
+	NotificationProviderOrderColAggregateID = Column{
+		name:  projection.NotificationProviderOrderColumnAggregateID,
+		table: notificationProviderOrderTable,
+	}
+	NotificationProviderOrderColCreationDate = Column{
+		name:  projection.NotificationProviderOrderColumnCreationDate,
+		table: notificationProviderOrderTable,
+	}
+	NotificationProviderOrderColChangeDate = Column{
+		name:  projection.NotificationProviderOrderColumnChangeDate,
+		table: notificationProviderOrderTable,
+	}
+	NotificationProviderOrderColResourceOwner = Column{
+		name:  projection.NotificationProviderOrderColumnResourceOwner,
+		table: notificationProviderOrderTable,
+	}
+	NotificationProviderOrderColSequence = Column{
+		name:  projection.NotificationProviderOrderColumnSequence,
+		table: notificationProviderOrderTable,
+	}
+	NotificationProviderOrderColInstanceID = Column{
+		name:  projection.NotificationProviderOrderColumnInstanceID,
+		table: notificationProviderOrderTable,
+	}
+	NotificationProviderOrderColChannel = Column{
+		name:  projection.NotificationProviderOrderColumnChannel,
+		table: notificationProviderOrderTable,
+	}
+	NotificationProviderOrderColProviderIDs = Column{
+		name:  projection.NotificationProviderOrderColumnProviderIDs,
+		table: notificationProviderOrderTable,
+	}
+	NotificationProviderOrderColIsDefault = Column{
+		name:  projection.NotificationProviderOrderColumnIsDefault,
+		table: notificationProviderOrderTable,
+	}
+	NotificationProviderOrderColSenderAddress = Column{
+		name:  projection.NotificationProviderOrderColumnSenderAddress,
+		table: notificationProviderOrderTable,
+	}
+	NotificationProviderOrderColSenderName = Column{
+		name:  projection.NotificationProviderOrderColumnSenderName,
+		table: notificationProviderOrderTable,
+	}
+	NotificationProviderOrderColReplyTo = Column{
+		name:  projection.NotificationProviderOrderColumnReplyTo,
+		table: notificationProviderOrderTable,
+	}
+)
+
+// NotificationProviderOrderByOrg returns the provider order of the channel set on the organization,
+// or the order of the instance if the organization has none.
+func (q *Queries) NotificationProviderOrderByOrg(ctx context.Context, orgID string, channel domain.NotificationType) (order *NotificationProviderOrder, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	stmt, scan := prepareNotificationProviderOrderQuery(ctx, q.client)
+	query, args, err := stmt.Where(
+		sq.And{
+			sq.Eq{
+				NotificationProviderOrderColInstanceID.identifier(): instanceID,
+				NotificationProviderOrderColChannel.identifier():    channel,
+			},
+			sq.Or{
+				sq.Eq{NotificationProviderOrderColAggregateID.identifier(): orgID},
+				sq.Eq{NotificationProviderOrderColAggregateID.identifier(): instanceID},
+			},
+		}).
+		OrderBy(NotificationProviderOrderColIsDefault.identifier()).
+		Limit(1).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Xk3gd", "Errors.Query.SQLStatement")
+	}
+
+	err = q.client.QueryRowContext(ctx, func(row *sql.Row) error {
+		order, err = scan(row)
+		return err
+	}, query, args...)
+	return order, err
+}
+
+// DefaultNotificationProviderOrder returns the provider order of the channel set on the instance
+func (q *Queries) DefaultNotificationProviderOrder(ctx context.Context, channel domain.NotificationType) (order *NotificationProviderOrder, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	stmt, scan := prepareNotificationProviderOrderQuery(ctx, q.client)
+	query, args, err := stmt.Where(sq.Eq{
+		NotificationProviderOrderColInstanceID.identifier():  instanceID,
+		NotificationProviderOrderColAggregateID.identifier(): instanceID,
+		NotificationProviderOrderColChannel.identifier():     channel,
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Pb8sn", "Errors.Query.SQLStatement")
+	}
+
+	err = q.client.QueryRowContext(ctx, func(row *sql.Row) error {
+		order, err = scan(row)
+		return err
+	}, query, args...)
+	return order, err
+}
+
+func prepareNotificationProviderOrderQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*NotificationProviderOrder, error)) {
+	return sq.Select(
+			NotificationProviderOrderColAggregateID.identifier(),
+			NotificationProviderOrderColCreationDate.identifier(),
+			NotificationProviderOrderColChangeDate.identifier(),
+			NotificationProviderOrderColResourceOwner.identifier(),
+			NotificationProviderOrderColSequence.identifier(),
+			NotificationProviderOrderColChannel.identifier(),
+			NotificationProviderOrderColProviderIDs.identifier(),
+			NotificationProviderOrderColIsDefault.identifier(),
+			NotificationProviderOrderColSenderAddress.identifier(),
+			NotificationProviderOrderColSenderName.identifier(),
+			NotificationProviderOrderColReplyTo.identifier(),
+		).
+			From(notificationProviderOrderTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*NotificationProviderOrder, error) {
+			order := new(NotificationProviderOrder)
+			var senderAddress, senderName, replyTo sql.NullString
+			err := row.Scan(
+				&order.AggregateID,
+				&order.CreationDate,
+				&order.ChangeDate,
+				&order.ResourceOwner,
+				&order.Sequence,
+				&order.Channel,
+				&order.ProviderIDs,
+				&order.IsDefault,
+				&senderAddress,
+				&senderName,
+				&replyTo,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-Fe5mv", "Errors.NotificationProviderOrder.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-Wc9ja", "Errors.Internal")
+			}
+			if senderAddress.String != "" {
+				order.EmailSender = &EmailSender{
+					Address:        senderAddress.String,
+					Name:           senderName.String,
+					ReplyToAddress: replyTo.String,
+				}
+			}
+			return order, nil
+		}
+}
@@ -0,0 +1,167 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+var (
+	prepareNotificationProviderOrderStmt = `SELECT projections.notification_provider_orders.aggregate_id,` +
+		` projections.notification_provider_orders.creation_date,` +
+		` projections.notification_provider_orders.change_date,` +
+		` projections.notification_provider_orders.resource_owner,` +
+		` projections.notification_provider_orders.sequence,` +
+		` projections.notification_provider_orders.channel,` +
+		` projections.notification_provider_orders.provider_ids,` +
+		` projections.notification_provider_orders.is_default,` +
+		` projections.notification_provider_orders.email_sender_address,` +
+		` projections.notification_provider_orders.email_sender_name,` +
+		` projections.notification_provider_orders.email_reply_to_address` +
+		` FROM projections.notification_provider_orders` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	prepareNotificationProviderOrderCols = []string{
+		"aggregate_id",
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"sequence",
+		"channel",
+		"provider_ids",
+		"is_default",
+		"email_sender_address",
+		"email_sender_name",
+		"email_reply_to_address",
+	}
+)
+
+func Test_NotificationProviderOrderPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareNotificationProviderOrderQuery no result",
+			prepare: prepareNotificationProviderOrderQuery,
+			want: want{
+				sqlExpectations: mockQueriesScanErr(
+					regexp.QuoteMeta(prepareNotificationProviderOrderStmt),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*NotificationProviderOrder)(nil),
+		},
+		{
+			name:    "prepareNotificationProviderOrderQuery found",
+			prepare: prepareNotificationProviderOrderQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(prepareNotificationProviderOrderStmt),
+					prepareNotificationProviderOrderCols,
+					[]driver.Value{
+						"org-id",
+						testNow,
+						testNow,
+						"org-id",
+						uint64(20211108),
+						domain.NotificationTypeSms,
+						database.TextArray[string]{"sms2", "sms1"},
+						false,
+						nil,
+						nil,
+						nil,
+					},
+				),
+			},
+			object: &NotificationProviderOrder{
+				AggregateID:   "org-id",
+				CreationDate:  testNow,
+				ChangeDate:    testNow,
+				ResourceOwner: "org-id",
+				Sequence:      20211108,
+				Channel:       domain.NotificationTypeSms,
+				ProviderIDs:   database.TextArray[string]{"sms2", "sms1"},
+				IsDefault:     false,
+			},
+		},
+		{
+			name:    "prepareNotificationProviderOrderQuery found with email sender",
+			prepare: prepareNotificationProviderOrderQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(prepareNotificationProviderOrderStmt),
+					prepareNotificationProviderOrderCols,
+					[]driver.Value{
+						"org-id",
+						testNow,
+						testNow,
+						"org-id",
+						uint64(20211108),
+						domain.NotificationTypeEmail,
+						database.TextArray[string]{"smtp1"},
+						false,
+						"noreply@org.ch",
+						"Org",
+						"",
+					},
+				),
+			},
+			object: &NotificationProviderOrder{
+				AggregateID:   "org-id",
+				CreationDate:  testNow,
+				ChangeDate:    testNow,
+				ResourceOwner: "org-id",
+				Sequence:      20211108,
+				Channel:       domain.NotificationTypeEmail,
+				ProviderIDs:   database.TextArray[string]{"smtp1"},
+				IsDefault:     false,
+				EmailSender: &EmailSender{
+					Address: "noreply@org.ch",
+					Name:    "Org",
+				},
+			},
+		},
+		{
+			name:    "prepareNotificationProviderOrderQuery sql err",
+			prepare: prepareNotificationProviderOrderQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareNotificationProviderOrderStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*NotificationProviderOrder)(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
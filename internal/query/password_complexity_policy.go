@@ -27,11 +27,13 @@ type PasswordComplexityPolicy struct {
 	ResourceOwner string
 	State         domain.PolicyState
 
-	MinLength    uint64
-	HasLowercase bool
-	HasUppercase bool
-	HasNumber    bool
-	HasSymbol    bool
+	MinLength     uint64
+	HasLowercase  bool
+	HasUppercase  bool
+	HasNumber     bool
+	HasSymbol     bool
+	HistoryDepth  uint64
+	CheckBreached bool
 
 	IsDefault bool
 }
@@ -150,6 +152,14 @@ var (
 		name:  projection.ComplexityPolicyHasSymbolCol,
 		table: passwordComplexityTable,
 	}
+	PasswordComplexityColHistoryDepth = Column{
+		name:  projection.ComplexityPolicyHistoryDepthCol,
+		table: passwordComplexityTable,
+	}
+	PasswordComplexityColCheckBreached = Column{
+		name:  projection.ComplexityPolicyCheckBreachedCol,
+		table: passwordComplexityTable,
+	}
 	PasswordComplexityColIsDefault = Column{
 		name:  projection.ComplexityPolicyIsDefaultCol,
 		table: passwordComplexityTable,
@@ -176,6 +186,8 @@ func preparePasswordComplexityPolicyQuery(ctx context.Context, db prepareDatabas
 			PasswordComplexityColHasUpperCase.identifier(),
 			PasswordComplexityColHasNumber.identifier(),
 			PasswordComplexityColHasSymbol.identifier(),
+			PasswordComplexityColHistoryDepth.identifier(),
+			PasswordComplexityColCheckBreached.identifier(),
 			PasswordComplexityColIsDefault.identifier(),
 			PasswordComplexityColState.identifier(),
 		).
@@ -194,6 +206,8 @@ func preparePasswordComplexityPolicyQuery(ctx context.Context, db prepareDatabas
 				&policy.HasUppercase,
 				&policy.HasNumber,
 				&policy.HasSymbol,
+				&policy.HistoryDepth,
+				&policy.CheckBreached,
 				&policy.IsDefault,
 				&policy.State,
 			)
@@ -13,19 +13,21 @@ import (
 )
 
 var (
-	preparePasswordComplexityPolicyStmt = `SELECT projections.password_complexity_policies2.id,` +
-		` projections.password_complexity_policies2.sequence,` +
-		` projections.password_complexity_policies2.creation_date,` +
-		` projections.password_complexity_policies2.change_date,` +
-		` projections.password_complexity_policies2.resource_owner,` +
-		` projections.password_complexity_policies2.min_length,` +
-		` projections.password_complexity_policies2.has_lowercase,` +
-		` projections.password_complexity_policies2.has_uppercase,` +
-		` projections.password_complexity_policies2.has_number,` +
-		` projections.password_complexity_policies2.has_symbol,` +
-		` projections.password_complexity_policies2.is_default,` +
-		` projections.password_complexity_policies2.state` +
-		` FROM projections.password_complexity_policies2` +
+	preparePasswordComplexityPolicyStmt = `SELECT projections.password_complexity_policies4.id,` +
+		` projections.password_complexity_policies4.sequence,` +
+		` projections.password_complexity_policies4.creation_date,` +
+		` projections.password_complexity_policies4.change_date,` +
+		` projections.password_complexity_policies4.resource_owner,` +
+		` projections.password_complexity_policies4.min_length,` +
+		` projections.password_complexity_policies4.has_lowercase,` +
+		` projections.password_complexity_policies4.has_uppercase,` +
+		` projections.password_complexity_policies4.has_number,` +
+		` projections.password_complexity_policies4.has_symbol,` +
+		` projections.password_complexity_policies4.history_depth,` +
+		` projections.password_complexity_policies4.check_breached,` +
+		` projections.password_complexity_policies4.is_default,` +
+		` projections.password_complexity_policies4.state` +
+		` FROM projections.password_complexity_policies4` +
 		` AS OF SYSTEM TIME '-1 ms'`
 	preparePasswordComplexityPolicyCols = []string{
 		"id",
@@ -38,6 +40,8 @@ var (
 		"has_uppercase",
 		"has_number",
 		"has_symbol",
+		"history_depth",
+		"check_breached",
 		"is_default",
 		"state",
 	}
@@ -90,6 +94,8 @@ func Test_PasswordComplexityPolicyPrepares(t *testing.T) {
 						true,
 						true,
 						true,
+						5,
+						true,
 						true,
 						domain.PolicyStateActive,
 					},
@@ -107,6 +113,8 @@ func Test_PasswordComplexityPolicyPrepares(t *testing.T) {
 				HasUppercase:  true,
 				HasNumber:     true,
 				HasSymbol:     true,
+				HistoryDepth:  5,
+				CheckBreached: true,
 				IsDefault:     true,
 			},
 		},
@@ -21,24 +21,24 @@ var (
 		", members.user_id" +
 		", members.roles" +
 		", projections.login_names3.login_name" +
-		", projections.users10_humans.email" +
-		", projections.users10_humans.first_name" +
-		", projections.users10_humans.last_name" +
-		", projections.users10_humans.display_name" +
-		", projections.users10_machines.name" +
-		", projections.users10_humans.avatar_key" +
-		", projections.users10.type" +
+		", projections.users11_humans.email" +
+		", projections.users11_humans.first_name" +
+		", projections.users11_humans.last_name" +
+		", projections.users11_humans.display_name" +
+		", projections.users11_machines.name" +
+		", projections.users11_humans.avatar_key" +
+		", projections.users11.type" +
 		", COUNT(*) OVER () " +
 		"FROM projections.project_grant_members4 AS members " +
-		"LEFT JOIN projections.users10_humans " +
-		"ON members.user_id = projections.users10_humans.user_id " +
-		"AND members.instance_id = projections.users10_humans.instance_id " +
-		"LEFT JOIN projections.users10_machines " +
-		"ON members.user_id = projections.users10_machines.user_id " +
-		"AND members.instance_id = projections.users10_machines.instance_id " +
-		"LEFT JOIN projections.users10 " +
-		"ON members.user_id = projections.users10.id " +
-		"AND members.instance_id = projections.users10.instance_id " +
+		"LEFT JOIN projections.users11_humans " +
+		"ON members.user_id = projections.users11_humans.user_id " +
+		"AND members.instance_id = projections.users11_humans.instance_id " +
+		"LEFT JOIN projections.users11_machines " +
+		"ON members.user_id = projections.users11_machines.user_id " +
+		"AND members.instance_id = projections.users11_machines.instance_id " +
+		"LEFT JOIN projections.users11 " +
+		"ON members.user_id = projections.users11.id " +
+		"AND members.instance_id = projections.users11.instance_id " +
 		"LEFT JOIN projections.login_names3 " +
 		"ON members.user_id = projections.login_names3.user_id " +
 		"AND members.instance_id = projections.login_names3.instance_id " +
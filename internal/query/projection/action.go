@@ -14,7 +14,7 @@ import (
 )
 
 const (
-	ActionTable            = "projections.actions3"
+	ActionTable            = "projections.actions4"
 	ActionIDCol            = "id"
 	ActionCreationDateCol  = "creation_date"
 	ActionChangeDateCol    = "change_date"
@@ -27,6 +27,9 @@ const (
 	ActionTimeoutCol       = "timeout"
 	ActionAllowedToFailCol = "allowed_to_fail"
 	ActionOwnerRemovedCol  = "owner_removed"
+	ActionVersionCol       = "version"
+	ActionCanaryVersionCol = "canary_version"
+	ActionCanaryPercentCol = "canary_percentage"
 )
 
 type actionProjection struct{}
@@ -54,6 +57,9 @@ func (*actionProjection) Init() *old_handler.Check {
 			handler.NewColumn(ActionTimeoutCol, handler.ColumnTypeInt64, handler.Default(0)),
 			handler.NewColumn(ActionAllowedToFailCol, handler.ColumnTypeBool, handler.Default(false)),
 			handler.NewColumn(ActionOwnerRemovedCol, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(ActionVersionCol, handler.ColumnTypeInt64, handler.Default(0)),
+			handler.NewColumn(ActionCanaryVersionCol, handler.ColumnTypeInt64, handler.Default(0)),
+			handler.NewColumn(ActionCanaryPercentCol, handler.ColumnTypeInt64, handler.Default(0)),
 		},
 			handler.NewPrimaryKey(ActionInstanceIDCol, ActionIDCol),
 			handler.WithIndex(handler.NewIndex("resource_owner", []string{ActionResourceOwnerCol})),
@@ -75,6 +81,14 @@ func (p *actionProjection) Reducers() []handler.AggregateReducer {
 					Event:  action.ChangedEventType,
 					Reduce: p.reduceActionChanged,
 				},
+				{
+					Event:  action.VersionActivatedEventType,
+					Reduce: p.reduceActionVersionActivated,
+				},
+				{
+					Event:  action.CanaryRemovedEventType,
+					Reduce: p.reduceActionCanaryRemoved,
+				},
 				{
 					Event:  action.DeactivatedEventType,
 					Reduce: p.reduceActionDeactivated,
@@ -129,6 +143,7 @@ func (p *actionProjection) reduceActionAdded(event eventstore.Event) (*handler.S
 			handler.NewCol(ActionTimeoutCol, e.Timeout),
 			handler.NewCol(ActionAllowedToFailCol, e.AllowedToFail),
 			handler.NewCol(ActionStateCol, domain.ActionStateActive),
+			handler.NewCol(ActionVersionCol, e.Sequence()),
 		},
 	), nil
 }
@@ -145,6 +160,24 @@ func (p *actionProjection) reduceActionChanged(event eventstore.Event) (*handler
 	if e.Name != nil {
 		values = append(values, handler.NewCol(ActionNameCol, *e.Name))
 	}
+	// a staged canary doesn't change the current version
+	if e.CanaryPercentage != nil {
+		values = append(values,
+			handler.NewCol(ActionCanaryVersionCol, e.Sequence()),
+			handler.NewCol(ActionCanaryPercentCol, *e.CanaryPercentage),
+		)
+		return handler.NewUpdateStatement(
+			e,
+			values,
+			[]handler.Condition{
+				handler.NewCond(ActionIDCol, e.Aggregate().ID),
+				handler.NewCond(ActionInstanceIDCol, event.Aggregate().InstanceID),
+			},
+		), nil
+	}
+	if e.CreatesVersion() {
+		values = append(values, handler.NewCol(ActionVersionCol, e.Sequence()))
+	}
 	if e.Script != nil {
 		values = append(values, handler.NewCol(ActionScriptCol, *e.Script))
 	}
@@ -164,6 +197,50 @@ func (p *actionProjection) reduceActionChanged(event eventstore.Event) (*handler
 	), nil
 }
 
+func (p *actionProjection) reduceActionVersionActivated(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*action.VersionActivatedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Rt4sw", "reduce.wrong.event.type %s", action.VersionActivatedEventType)
+	}
+	return handler.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(ActionChangeDateCol, e.CreationDate()),
+			handler.NewCol(ActionSequenceCol, e.Sequence()),
+			handler.NewCol(ActionScriptCol, e.Script),
+			handler.NewCol(ActionTimeoutCol, e.Timeout),
+			handler.NewCol(ActionAllowedToFailCol, e.AllowedToFail),
+			handler.NewCol(ActionVersionCol, e.Version),
+			handler.NewCol(ActionCanaryVersionCol, 0),
+			handler.NewCol(ActionCanaryPercentCol, 0),
+		},
+		[]handler.Condition{
+			handler.NewCond(ActionIDCol, e.Aggregate().ID),
+			handler.NewCond(ActionInstanceIDCol, event.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *actionProjection) reduceActionCanaryRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*action.CanaryRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Mv3rq", "reduce.wrong.event.type %s", action.CanaryRemovedEventType)
+	}
+	return handler.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(ActionChangeDateCol, e.CreationDate()),
+			handler.NewCol(ActionSequenceCol, e.Sequence()),
+			handler.NewCol(ActionCanaryVersionCol, 0),
+			handler.NewCol(ActionCanaryPercentCol, 0),
+		},
+		[]handler.Condition{
+			handler.NewCond(ActionIDCol, e.Aggregate().ID),
+			handler.NewCond(ActionInstanceIDCol, event.Aggregate().InstanceID),
+		},
+	), nil
+}
+
 func (p *actionProjection) reduceActionDeactivated(event eventstore.Event) (*handler.Statement, error) {
 	e, ok := event.(*action.DeactivatedEvent)
 	if !ok {
@@ -42,7 +42,7 @@ func TestActionProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "INSERT INTO projections.actions3 (id, creation_date, change_date, resource_owner, instance_id, sequence, name, script, timeout, allowed_to_fail, action_state) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)",
+							expectedStmt: "INSERT INTO projections.actions4 (id, creation_date, change_date, resource_owner, instance_id, sequence, name, script, timeout, allowed_to_fail, action_state, version) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)",
 							expectedArgs: []interface{}{
 								"agg-id",
 								anyArg{},
@@ -55,6 +55,7 @@ func TestActionProjection_reduces(t *testing.T) {
 								3 * time.Second,
 								true,
 								domain.ActionStateActive,
+								uint64(15),
 							},
 						},
 					},
@@ -80,11 +81,12 @@ func TestActionProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "UPDATE projections.actions3 SET (change_date, sequence, name, script) = ($1, $2, $3, $4) WHERE (id = $5) AND (instance_id = $6)",
+							expectedStmt: "UPDATE projections.actions4 SET (change_date, sequence, name, version, script) = ($1, $2, $3, $4, $5) WHERE (id = $6) AND (instance_id = $7)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								uint64(15),
 								"name2",
+								uint64(15),
 								"name2(){}",
 								"agg-id",
 								"instance-id",
@@ -94,6 +96,109 @@ func TestActionProjection_reduces(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "reduceActionChanged canary",
+			args: args{
+				event: getEvent(
+					testEvent(
+						action.ChangedEventType,
+						action.AggregateType,
+						[]byte(`{"script":"name2(){}", "timeout": 3000000000, "allowedToFail": false, "canaryPercentage": 10}`),
+					),
+					action.ChangedEventMapper,
+				),
+			},
+			reduce: (&actionProjection{}).reduceActionChanged,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("action"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.actions4 SET (change_date, sequence, canary_version, canary_percentage) = ($1, $2, $3, $4) WHERE (id = $5) AND (instance_id = $6)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								uint64(15),
+								uint32(10),
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceActionVersionActivated",
+			args: args{
+				event: getEvent(
+					testEvent(
+						action.VersionActivatedEventType,
+						action.AggregateType,
+						[]byte(`{"version": 12, "script":"name(){}", "timeout": 3000000000, "allowedToFail": true}`),
+					),
+					action.VersionActivatedEventMapper,
+				),
+			},
+			reduce: (&actionProjection{}).reduceActionVersionActivated,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("action"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.actions4 SET (change_date, sequence, script, timeout, allowed_to_fail, version, canary_version, canary_percentage) = ($1, $2, $3, $4, $5, $6, $7, $8) WHERE (id = $9) AND (instance_id = $10)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"name(){}",
+								3 * time.Second,
+								true,
+								uint64(12),
+								0,
+								0,
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceActionCanaryRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						action.CanaryRemovedEventType,
+						action.AggregateType,
+						nil,
+					),
+					action.CanaryRemovedEventMapper,
+				),
+			},
+			reduce: (&actionProjection{}).reduceActionCanaryRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("action"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.actions4 SET (change_date, sequence, canary_version, canary_percentage) = ($1, $2, $3, $4) WHERE (id = $5) AND (instance_id = $6)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								0,
+								0,
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "reduceActionDeactivated",
 			args: args{
@@ -113,7 +218,7 @@ func TestActionProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "UPDATE projections.actions3 SET (change_date, sequence, action_state) = ($1, $2, $3) WHERE (id = $4) AND (instance_id = $5)",
+							expectedStmt: "UPDATE projections.actions4 SET (change_date, sequence, action_state) = ($1, $2, $3) WHERE (id = $4) AND (instance_id = $5)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								uint64(15),
@@ -145,7 +250,7 @@ func TestActionProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "UPDATE projections.actions3 SET (change_date, sequence, action_state) = ($1, $2, $3) WHERE (id = $4) AND (instance_id = $5)",
+							expectedStmt: "UPDATE projections.actions4 SET (change_date, sequence, action_state) = ($1, $2, $3) WHERE (id = $4) AND (instance_id = $5)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								uint64(15),
@@ -177,7 +282,7 @@ func TestActionProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.actions3 WHERE (id = $1) AND (instance_id = $2)",
+							expectedStmt: "DELETE FROM projections.actions4 WHERE (id = $1) AND (instance_id = $2)",
 							expectedArgs: []interface{}{
 								"agg-id",
 								"instance-id",
@@ -206,7 +311,7 @@ func TestActionProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.actions3 WHERE (instance_id = $1) AND (resource_owner = $2)",
+							expectedStmt: "DELETE FROM projections.actions4 WHERE (instance_id = $1) AND (resource_owner = $2)",
 							expectedArgs: []interface{}{
 								"instance-id",
 								"agg-id",
@@ -235,7 +340,7 @@ func TestActionProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.actions3 WHERE (instance_id = $1)",
+							expectedStmt: "DELETE FROM projections.actions4 WHERE (instance_id = $1)",
 							expectedArgs: []interface{}{
 								"agg-id",
 							},
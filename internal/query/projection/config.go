@@ -13,6 +13,15 @@ type Config struct {
 	Customizations        map[string]CustomConfig
 	HandleActiveInstances time.Duration
 	TransactionDuration   time.Duration
+	Readiness             ReadinessConfig
+}
+
+// ReadinessConfig defines the projections which must not lag behind the events for zitadel to be ready
+type ReadinessConfig struct {
+	// Projections are the names of the critical projections with or without schema, e.g. projections.users11 or users11
+	Projections []string
+	// MaxLag is the duration a critical projection may be behind the events, 0 disables the check
+	MaxLag time.Duration
 }
 
 type CustomConfig struct {
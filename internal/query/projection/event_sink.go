@@ -0,0 +1,137 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/eventsink"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+const (
+	EventSinkProjectionTable = "projections.event_sinks"
+
+	EventSinkColumnID             = "id"
+	EventSinkColumnCreationDate   = "creation_date"
+	EventSinkColumnChangeDate     = "change_date"
+	EventSinkColumnInstanceID     = "instance_id"
+	EventSinkColumnSequence       = "sequence"
+	EventSinkColumnName           = "name"
+	EventSinkColumnSinkType       = "sink_type"
+	EventSinkColumnEndpoint       = "endpoint"
+	EventSinkColumnTarget         = "target"
+	EventSinkColumnAggregateTypes = "aggregate_types"
+	EventSinkColumnEventTypes     = "event_types"
+	EventSinkColumnSigningKey     = "signing_key"
+)
+
+type eventSinkProjection struct{}
+
+func newEventSinkProjection(ctx context.Context, config handler.Config) *handler.Handler {
+	return handler.NewHandler(ctx, &config, new(eventSinkProjection))
+}
+
+func (*eventSinkProjection) Name() string {
+	return EventSinkProjectionTable
+}
+
+func (*eventSinkProjection) Init() *old_handler.Check {
+	return handler.NewTableCheck(
+		handler.NewTable([]*handler.InitColumn{
+			handler.NewColumn(EventSinkColumnID, handler.ColumnTypeText),
+			handler.NewColumn(EventSinkColumnCreationDate, handler.ColumnTypeTimestamp),
+			handler.NewColumn(EventSinkColumnChangeDate, handler.ColumnTypeTimestamp),
+			handler.NewColumn(EventSinkColumnInstanceID, handler.ColumnTypeText),
+			handler.NewColumn(EventSinkColumnSequence, handler.ColumnTypeInt64),
+			handler.NewColumn(EventSinkColumnName, handler.ColumnTypeText),
+			handler.NewColumn(EventSinkColumnSinkType, handler.ColumnTypeEnum),
+			handler.NewColumn(EventSinkColumnEndpoint, handler.ColumnTypeText),
+			handler.NewColumn(EventSinkColumnTarget, handler.ColumnTypeText),
+			handler.NewColumn(EventSinkColumnAggregateTypes, handler.ColumnTypeTextArray, handler.Nullable()),
+			handler.NewColumn(EventSinkColumnEventTypes, handler.ColumnTypeTextArray, handler.Nullable()),
+			handler.NewColumn(EventSinkColumnSigningKey, handler.ColumnTypeJSONB, handler.Nullable()),
+		},
+			handler.NewPrimaryKey(EventSinkColumnInstanceID, EventSinkColumnID),
+		),
+	)
+}
+
+func (p *eventSinkProjection) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: eventsink.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  eventsink.SetEventType,
+					Reduce: p.reduceSet,
+				},
+				{
+					Event:  eventsink.RemovedEventType,
+					Reduce: p.reduceRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(EventSinkColumnInstanceID),
+				},
+			},
+		},
+	}
+}
+
+func (p *eventSinkProjection) reduceSet(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*eventsink.SetEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	aggregateTypes := make(database.TextArray[string], len(e.AggregateTypes))
+	for i, aggregateType := range e.AggregateTypes {
+		aggregateTypes[i] = string(aggregateType)
+	}
+	eventTypes := make(database.TextArray[string], len(e.EventTypes))
+	for i, eventType := range e.EventTypes {
+		eventTypes[i] = string(eventType)
+	}
+	return handler.NewUpsertStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(EventSinkColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(EventSinkColumnID, e.Aggregate().ID),
+		},
+		[]handler.Column{
+			handler.NewCol(EventSinkColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(EventSinkColumnID, e.Aggregate().ID),
+			handler.NewCol(EventSinkColumnCreationDate, e.CreationDate()),
+			handler.NewCol(EventSinkColumnChangeDate, e.CreationDate()),
+			handler.NewCol(EventSinkColumnSequence, e.Sequence()),
+			handler.NewCol(EventSinkColumnName, e.Name),
+			handler.NewCol(EventSinkColumnSinkType, e.SinkType),
+			handler.NewCol(EventSinkColumnEndpoint, e.Endpoint),
+			handler.NewCol(EventSinkColumnTarget, e.Target),
+			handler.NewCol(EventSinkColumnAggregateTypes, aggregateTypes),
+			handler.NewCol(EventSinkColumnEventTypes, eventTypes),
+			handler.NewCol(EventSinkColumnSigningKey, e.SigningKey),
+		},
+	), nil
+}
+
+func (p *eventSinkProjection) reduceRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*eventsink.RemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(EventSinkColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(EventSinkColumnID, e.Aggregate().ID),
+		},
+	), nil
+}
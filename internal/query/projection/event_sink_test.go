@@ -0,0 +1,141 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/eventsink"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+func TestEventSinkProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceSet",
+			args: args{
+				event: getEvent(testEvent(
+					eventsink.SetEventType,
+					eventsink.AggregateType,
+					[]byte(`{
+						"name": "webhook",
+						"sinkType": 1,
+						"endpoint": "https://example.com/events",
+						"aggregateTypes": ["user"],
+						"eventTypes": ["user.human.added"],
+						"signingKey": {
+							"cryptoType": 0,
+							"algorithm": "RSA-265",
+							"keyId": "key-id"
+						}
+					}`),
+				), eventsink.SetEventMapper),
+			},
+			reduce: (&eventSinkProjection{}).reduceSet,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("event_sink"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.event_sinks (instance_id, id, creation_date, change_date, sequence, name, sink_type, endpoint, target, aggregate_types, event_types, signing_key) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) ON CONFLICT (instance_id, id) DO UPDATE SET (creation_date, change_date, sequence, name, sink_type, endpoint, target, aggregate_types, event_types, signing_key) = (EXCLUDED.creation_date, EXCLUDED.change_date, EXCLUDED.sequence, EXCLUDED.name, EXCLUDED.sink_type, EXCLUDED.endpoint, EXCLUDED.target, EXCLUDED.aggregate_types, EXCLUDED.event_types, EXCLUDED.signing_key)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"webhook",
+								domain.EventSinkTypeHTTP,
+								"https://example.com/events",
+								"",
+								database.TextArray[string]{"user"},
+								database.TextArray[string]{"user.human.added"},
+								&crypto.CryptoValue{
+									CryptoType: crypto.TypeEncryption,
+									Algorithm:  "RSA-265",
+									KeyID:      "key-id",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					eventsink.RemovedEventType,
+					eventsink.AggregateType,
+					[]byte(`{}`),
+				), eventsink.RemovedEventMapper),
+			},
+			reduce: (&eventSinkProjection{}).reduceRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("event_sink"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.event_sinks WHERE (instance_id = $1) AND (id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					instance.InstanceRemovedEventType,
+					instance.AggregateType,
+					nil,
+				), instance.InstanceRemovedEventMapper),
+			},
+			reduce: reduceInstanceRemovedHelper(EventSinkColumnInstanceID),
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.event_sinks WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if !errors.IsErrorInvalidArgument(err) {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, EventSinkProjectionTable, tt.want)
+		})
+	}
+}
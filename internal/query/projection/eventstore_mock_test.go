@@ -38,6 +38,16 @@ func (m *mockEventStore) Filter(ctx context.Context, queryFactory *eventstore.Se
 	return m.filterResponse[m.filterCounter-1], nil
 }
 
+func (m *mockEventStore) FilterStream(ctx context.Context, queryFactory *eventstore.SearchQueryBuilder, reduce eventstore.Reducer) error {
+	events, _ := m.Filter(ctx, queryFactory)
+	for _, event := range events {
+		if err := reduce(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (m *mockEventStore) Push(ctx context.Context, cmds ...eventstore.Command) ([]eventstore.Event, error) {
 	m.pushCounter++
 	return m.pushResponse[m.pushCounter-1], nil
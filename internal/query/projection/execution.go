@@ -0,0 +1,111 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	exec_repo "github.com/zitadel/zitadel/internal/repository/execution"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+const (
+	ExecutionProjectionTable = "projections.executions"
+
+	ExecutionColumnID           = "id"
+	ExecutionColumnCreationDate = "creation_date"
+	ExecutionColumnChangeDate   = "change_date"
+	ExecutionColumnInstanceID   = "instance_id"
+	ExecutionColumnSequence     = "sequence"
+	ExecutionColumnTargets      = "targets"
+)
+
+type executionProjection struct{}
+
+func newExecutionProjection(ctx context.Context, config handler.Config) *handler.Handler {
+	return handler.NewHandler(ctx, &config, new(executionProjection))
+}
+
+func (*executionProjection) Name() string {
+	return ExecutionProjectionTable
+}
+
+func (*executionProjection) Init() *old_handler.Check {
+	return handler.NewTableCheck(
+		handler.NewTable([]*handler.InitColumn{
+			handler.NewColumn(ExecutionColumnID, handler.ColumnTypeText),
+			handler.NewColumn(ExecutionColumnCreationDate, handler.ColumnTypeTimestamp),
+			handler.NewColumn(ExecutionColumnChangeDate, handler.ColumnTypeTimestamp),
+			handler.NewColumn(ExecutionColumnInstanceID, handler.ColumnTypeText),
+			handler.NewColumn(ExecutionColumnSequence, handler.ColumnTypeInt64),
+			handler.NewColumn(ExecutionColumnTargets, handler.ColumnTypeTextArray, handler.Nullable()),
+		},
+			handler.NewPrimaryKey(ExecutionColumnInstanceID, ExecutionColumnID),
+		),
+	)
+}
+
+func (p *executionProjection) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: exec_repo.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  exec_repo.SetEventType,
+					Reduce: p.reduceSet,
+				},
+				{
+					Event:  exec_repo.RemovedEventType,
+					Reduce: p.reduceRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(ExecutionColumnInstanceID),
+				},
+			},
+		},
+	}
+}
+
+func (p *executionProjection) reduceSet(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*exec_repo.SetEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewUpsertStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(ExecutionColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(ExecutionColumnID, e.Aggregate().ID),
+		},
+		[]handler.Column{
+			handler.NewCol(ExecutionColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(ExecutionColumnID, e.Aggregate().ID),
+			handler.NewCol(ExecutionColumnCreationDate, e.CreationDate()),
+			handler.NewCol(ExecutionColumnChangeDate, e.CreationDate()),
+			handler.NewCol(ExecutionColumnSequence, e.Sequence()),
+			handler.NewCol(ExecutionColumnTargets, database.TextArray[string](e.Targets)),
+		},
+	), nil
+}
+
+func (p *executionProjection) reduceRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*exec_repo.RemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(ExecutionColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(ExecutionColumnID, e.Aggregate().ID),
+		},
+	), nil
+}
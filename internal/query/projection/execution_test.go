@@ -0,0 +1,118 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	exec_repo "github.com/zitadel/zitadel/internal/repository/execution"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+func TestExecutionProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceSet",
+			args: args{
+				event: getEvent(testEvent(
+					exec_repo.SetEventType,
+					exec_repo.AggregateType,
+					[]byte(`{"targets": ["target1", "target2"]}`),
+				), exec_repo.SetEventMapper),
+			},
+			reduce: (&executionProjection{}).reduceSet,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("execution"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.executions (instance_id, id, creation_date, change_date, sequence, targets) VALUES ($1, $2, $3, $4, $5, $6) ON CONFLICT (instance_id, id) DO UPDATE SET (creation_date, change_date, sequence, targets) = (EXCLUDED.creation_date, EXCLUDED.change_date, EXCLUDED.sequence, EXCLUDED.targets)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								database.TextArray[string]{"target1", "target2"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					exec_repo.RemovedEventType,
+					exec_repo.AggregateType,
+					[]byte(`{}`),
+				), exec_repo.RemovedEventMapper),
+			},
+			reduce: (&executionProjection{}).reduceRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("execution"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.executions WHERE (instance_id = $1) AND (id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					instance.InstanceRemovedEventType,
+					instance.AggregateType,
+					nil,
+				), instance.InstanceRemovedEventMapper),
+			},
+			reduce: reduceInstanceRemovedHelper(ExecutionColumnInstanceID),
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.executions WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if !errors.IsErrorInvalidArgument(err) {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, ExecutionProjectionTable, tt.want)
+		})
+	}
+}
@@ -12,7 +12,7 @@ import (
 )
 
 const (
-	FlowTriggerTable             = "projections.flow_triggers3"
+	FlowTriggerTable             = "projections.flow_triggers4"
 	FlowTypeCol                  = "flow_type"
 	FlowChangeDateCol            = "change_date"
 	FlowSequenceCol              = "sequence"
@@ -21,6 +21,7 @@ const (
 	FlowInstanceIDCol            = "instance_id"
 	FlowActionTriggerSequenceCol = "trigger_sequence"
 	FlowActionIDCol              = "action_id"
+	FlowActionVersionCol         = "action_version"
 )
 
 type flowProjection struct{}
@@ -44,6 +45,7 @@ func (*flowProjection) Init() *old_handler.Check {
 			handler.NewColumn(FlowInstanceIDCol, handler.ColumnTypeText),
 			handler.NewColumn(FlowActionTriggerSequenceCol, handler.ColumnTypeInt64),
 			handler.NewColumn(FlowActionIDCol, handler.ColumnTypeText),
+			handler.NewColumn(FlowActionVersionCol, handler.ColumnTypeInt64, handler.Default(0)),
 		},
 			handler.NewPrimaryKey(FlowInstanceIDCol, FlowTypeCol, FlowTriggerTypeCol, FlowResourceOwnerCol, FlowActionIDCol),
 		),
@@ -106,6 +108,7 @@ func (p *flowProjection) reduceTriggerActionsSetEventType(event eventstore.Event
 				handler.NewCol(FlowTriggerTypeCol, e.TriggerType),
 				handler.NewCol(FlowActionIDCol, id),
 				handler.NewCol(FlowActionTriggerSequenceCol, i),
+				handler.NewCol(FlowActionVersionCol, e.ActionVersions[id]),
 			},
 		)
 	}
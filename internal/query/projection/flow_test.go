@@ -28,7 +28,7 @@ func TestFlowProjection_reduces(t *testing.T) {
 					testEvent(
 						org.TriggerActionsSetEventType,
 						org.AggregateType,
-						[]byte(`{"flowType": 1, "triggerType": 1, "actionIDs": ["id1", "id2"]}`),
+						[]byte(`{"flowType": 1, "triggerType": 1, "actionIDs": ["id1", "id2"], "actionVersions": {"id1": 3}}`),
 					), org.TriggerActionsSetEventMapper),
 			},
 			reduce: (&flowProjection{}).reduceTriggerActionsSetEventType,
@@ -38,7 +38,7 @@ func TestFlowProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.flow_triggers3 WHERE (flow_type = $1) AND (trigger_type = $2) AND (resource_owner = $3) AND (instance_id = $4)",
+							expectedStmt: "DELETE FROM projections.flow_triggers4 WHERE (flow_type = $1) AND (trigger_type = $2) AND (resource_owner = $3) AND (instance_id = $4)",
 							expectedArgs: []interface{}{
 								domain.FlowTypeExternalAuthentication,
 								domain.TriggerTypePostAuthentication,
@@ -47,7 +47,7 @@ func TestFlowProjection_reduces(t *testing.T) {
 							},
 						},
 						{
-							expectedStmt: "INSERT INTO projections.flow_triggers3 (resource_owner, instance_id, flow_type, change_date, sequence, trigger_type, action_id, trigger_sequence) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
+							expectedStmt: "INSERT INTO projections.flow_triggers4 (resource_owner, instance_id, flow_type, change_date, sequence, trigger_type, action_id, trigger_sequence, action_version) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
 							expectedArgs: []interface{}{
 								"ro-id",
 								"instance-id",
@@ -57,10 +57,11 @@ func TestFlowProjection_reduces(t *testing.T) {
 								domain.TriggerTypePostAuthentication,
 								"id1",
 								0,
+								uint64(3),
 							},
 						},
 						{
-							expectedStmt: "INSERT INTO projections.flow_triggers3 (resource_owner, instance_id, flow_type, change_date, sequence, trigger_type, action_id, trigger_sequence) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
+							expectedStmt: "INSERT INTO projections.flow_triggers4 (resource_owner, instance_id, flow_type, change_date, sequence, trigger_type, action_id, trigger_sequence, action_version) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
 							expectedArgs: []interface{}{
 								"ro-id",
 								"instance-id",
@@ -70,6 +71,7 @@ func TestFlowProjection_reduces(t *testing.T) {
 								domain.TriggerTypePostAuthentication,
 								"id2",
 								1,
+								uint64(0),
 							},
 						},
 					},
@@ -93,7 +95,7 @@ func TestFlowProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.flow_triggers3 WHERE (flow_type = $1) AND (resource_owner = $2) AND (instance_id = $3)",
+							expectedStmt: "DELETE FROM projections.flow_triggers4 WHERE (flow_type = $1) AND (resource_owner = $2) AND (instance_id = $3)",
 							expectedArgs: []interface{}{
 								domain.FlowTypeExternalAuthentication,
 								"ro-id",
@@ -121,7 +123,7 @@ func TestFlowProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.flow_triggers3 WHERE (instance_id = $1) AND (resource_owner = $2)",
+							expectedStmt: "DELETE FROM projections.flow_triggers4 WHERE (instance_id = $1) AND (resource_owner = $2)",
 							expectedArgs: []interface{}{
 								"instance-id",
 								"agg-id",
@@ -148,7 +150,7 @@ func TestFlowProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.flow_triggers3 WHERE (instance_id = $1)",
+							expectedStmt: "DELETE FROM projections.flow_triggers4 WHERE (instance_id = $1)",
 							expectedArgs: []interface{}{
 								"agg-id",
 							},
@@ -3,6 +3,7 @@ package projection
 import (
 	"context"
 
+	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
 	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
@@ -11,7 +12,7 @@ import (
 )
 
 const (
-	LimitsProjectionTable = "projections.limits"
+	LimitsProjectionTable = "projections.limits2"
 
 	LimitsColumnAggregateID   = "aggregate_id"
 	LimitsColumnCreationDate  = "creation_date"
@@ -20,7 +21,14 @@ const (
 	LimitsColumnInstanceID    = "instance_id"
 	LimitsColumnSequence      = "sequence"
 
-	LimitsColumnAuditLogRetention = "audit_log_retention"
+	LimitsColumnAuditLogRetention         = "audit_log_retention"
+	LimitsColumnActionMaxAllocations      = "action_max_allocations"
+	LimitsColumnActionMaxLoopIterations   = "action_max_loop_iterations"
+	LimitsColumnActionMaxOutputSize       = "action_max_output_size"
+	LimitsColumnActionMaxHTTPCalls        = "action_max_http_calls"
+	LimitsColumnActionMaxHTTPResponseSize = "action_max_http_response_size"
+	LimitsColumnActionMaxConcurrentRuns   = "action_max_concurrent_runs"
+	LimitsColumnActionAllowedDomains      = "action_allowed_domains"
 )
 
 type limitsProjection struct{}
@@ -43,6 +51,13 @@ func (*limitsProjection) Init() *old_handler.Check {
 			handler.NewColumn(LimitsColumnInstanceID, handler.ColumnTypeText),
 			handler.NewColumn(LimitsColumnSequence, handler.ColumnTypeInt64),
 			handler.NewColumn(LimitsColumnAuditLogRetention, handler.ColumnTypeInterval, handler.Nullable()),
+			handler.NewColumn(LimitsColumnActionMaxAllocations, handler.ColumnTypeInt64, handler.Nullable()),
+			handler.NewColumn(LimitsColumnActionMaxLoopIterations, handler.ColumnTypeInt64, handler.Nullable()),
+			handler.NewColumn(LimitsColumnActionMaxOutputSize, handler.ColumnTypeInt64, handler.Nullable()),
+			handler.NewColumn(LimitsColumnActionMaxHTTPCalls, handler.ColumnTypeInt64, handler.Nullable()),
+			handler.NewColumn(LimitsColumnActionMaxHTTPResponseSize, handler.ColumnTypeInt64, handler.Nullable()),
+			handler.NewColumn(LimitsColumnActionMaxConcurrentRuns, handler.ColumnTypeInt64, handler.Nullable()),
+			handler.NewColumn(LimitsColumnActionAllowedDomains, handler.ColumnTypeTextArray, handler.Nullable()),
 		},
 			handler.NewPrimaryKey(LimitsColumnInstanceID, LimitsColumnResourceOwner),
 		),
@@ -96,6 +111,27 @@ func (p *limitsProjection) reduceLimitsSet(event eventstore.Event) (*handler.Sta
 	if e.AuditLogRetention != nil {
 		updateCols = append(updateCols, handler.NewCol(LimitsColumnAuditLogRetention, *e.AuditLogRetention))
 	}
+	if e.ActionMaxAllocations != nil {
+		updateCols = append(updateCols, handler.NewCol(LimitsColumnActionMaxAllocations, *e.ActionMaxAllocations))
+	}
+	if e.ActionMaxLoopIterations != nil {
+		updateCols = append(updateCols, handler.NewCol(LimitsColumnActionMaxLoopIterations, *e.ActionMaxLoopIterations))
+	}
+	if e.ActionMaxOutputSize != nil {
+		updateCols = append(updateCols, handler.NewCol(LimitsColumnActionMaxOutputSize, *e.ActionMaxOutputSize))
+	}
+	if e.ActionMaxHTTPCalls != nil {
+		updateCols = append(updateCols, handler.NewCol(LimitsColumnActionMaxHTTPCalls, *e.ActionMaxHTTPCalls))
+	}
+	if e.ActionMaxHTTPResponseSize != nil {
+		updateCols = append(updateCols, handler.NewCol(LimitsColumnActionMaxHTTPResponseSize, *e.ActionMaxHTTPResponseSize))
+	}
+	if e.ActionMaxConcurrentRuns != nil {
+		updateCols = append(updateCols, handler.NewCol(LimitsColumnActionMaxConcurrentRuns, *e.ActionMaxConcurrentRuns))
+	}
+	if e.ActionAllowedDomains != nil {
+		updateCols = append(updateCols, handler.NewCol(LimitsColumnActionAllowedDomains, database.TextArray[string](*e.ActionAllowedDomains)))
+	}
 	return handler.NewUpsertStatement(e, conflictCols, updateCols), nil
 }
 
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
@@ -38,7 +39,7 @@ func TestLimitsProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "INSERT INTO projections.limits (instance_id, resource_owner, creation_date, change_date, sequence, aggregate_id, audit_log_retention) VALUES ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT (instance_id, resource_owner) DO UPDATE SET (creation_date, change_date, sequence, aggregate_id, audit_log_retention) = (EXCLUDED.creation_date, EXCLUDED.change_date, EXCLUDED.sequence, EXCLUDED.aggregate_id, EXCLUDED.audit_log_retention)",
+							expectedStmt: "INSERT INTO projections.limits2 (instance_id, resource_owner, creation_date, change_date, sequence, aggregate_id, audit_log_retention) VALUES ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT (instance_id, resource_owner) DO UPDATE SET (creation_date, change_date, sequence, aggregate_id, audit_log_retention) = (EXCLUDED.creation_date, EXCLUDED.change_date, EXCLUDED.sequence, EXCLUDED.aggregate_id, EXCLUDED.audit_log_retention)",
 							expectedArgs: []interface{}{
 								"instance-id",
 								"ro-id",
@@ -54,6 +55,51 @@ func TestLimitsProjection_reduces(t *testing.T) {
 			},
 		},
 
+		{
+			name: "reduceLimitsSet action limits",
+			args: args{
+				event: getEvent(testEvent(
+					limits.SetEventType,
+					limits.AggregateType,
+					[]byte(`{
+							"actionMaxAllocations": 1048576,
+							"actionMaxLoopIterations": 100000,
+							"actionMaxOutputSize": 65536,
+							"actionMaxHttpCalls": 5,
+							"actionMaxHttpResponseSize": 1024,
+							"actionMaxConcurrentRuns": 2,
+							"actionAllowedDomains": ["zitadel.com"]
+					}`),
+				), limits.SetEventMapper),
+			},
+			reduce: (&limitsProjection{}).reduceLimitsSet,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("limits"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.limits2 (instance_id, resource_owner, creation_date, change_date, sequence, aggregate_id, action_max_allocations, action_max_loop_iterations, action_max_output_size, action_max_http_calls, action_max_http_response_size, action_max_concurrent_runs, action_allowed_domains) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13) ON CONFLICT (instance_id, resource_owner) DO UPDATE SET (creation_date, change_date, sequence, aggregate_id, action_max_allocations, action_max_loop_iterations, action_max_output_size, action_max_http_calls, action_max_http_response_size, action_max_concurrent_runs, action_allowed_domains) = (EXCLUDED.creation_date, EXCLUDED.change_date, EXCLUDED.sequence, EXCLUDED.aggregate_id, EXCLUDED.action_max_allocations, EXCLUDED.action_max_loop_iterations, EXCLUDED.action_max_output_size, EXCLUDED.action_max_http_calls, EXCLUDED.action_max_http_response_size, EXCLUDED.action_max_concurrent_runs, EXCLUDED.action_allowed_domains)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"ro-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"agg-id",
+								uint64(1048576),
+								uint64(100000),
+								uint64(65536),
+								uint32(5),
+								uint64(1024),
+								uint32(2),
+								database.TextArray[string]{"zitadel.com"},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "reduceLimitsReset",
 			args: args{
@@ -70,7 +116,7 @@ func TestLimitsProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.limits WHERE (instance_id = $1) AND (resource_owner = $2)",
+							expectedStmt: "DELETE FROM projections.limits2 WHERE (instance_id = $1) AND (resource_owner = $2)",
 							expectedArgs: []interface{}{
 								"instance-id",
 								"ro-id",
@@ -14,7 +14,7 @@ import (
 )
 
 const (
-	LockoutPolicyTable = "projections.lockout_policies2"
+	LockoutPolicyTable = "projections.lockout_policies3"
 
 	LockoutPolicyIDCol                  = "id"
 	LockoutPolicyCreationDateCol        = "creation_date"
@@ -26,6 +26,9 @@ const (
 	LockoutPolicyInstanceIDCol          = "instance_id"
 	LockoutPolicyMaxPasswordAttemptsCol = "max_password_attempts"
 	LockoutPolicyShowLockOutFailuresCol = "show_failure"
+	LockoutPolicyMaxOTPAttemptsCol      = "max_otp_attempts"
+	LockoutPolicyAutoUnlockAfterCol     = "auto_unlock_after"
+	LockoutPolicyFailedAttemptDelayCol  = "failed_attempt_delay"
 	LockoutPolicyOwnerRemovedCol        = "owner_removed"
 )
 
@@ -52,6 +55,9 @@ func (*lockoutPolicyProjection) Init() *old_handler.Check {
 			handler.NewColumn(LockoutPolicyInstanceIDCol, handler.ColumnTypeText),
 			handler.NewColumn(LockoutPolicyMaxPasswordAttemptsCol, handler.ColumnTypeInt64),
 			handler.NewColumn(LockoutPolicyShowLockOutFailuresCol, handler.ColumnTypeBool),
+			handler.NewColumn(LockoutPolicyMaxOTPAttemptsCol, handler.ColumnTypeInt64, handler.Default(0)),
+			handler.NewColumn(LockoutPolicyAutoUnlockAfterCol, handler.ColumnTypeInt64, handler.Default(0)),
+			handler.NewColumn(LockoutPolicyFailedAttemptDelayCol, handler.ColumnTypeInt64, handler.Default(0)),
 			handler.NewColumn(LockoutPolicyOwnerRemovedCol, handler.ColumnTypeBool, handler.Default(false)),
 		},
 			handler.NewPrimaryKey(LockoutPolicyInstanceIDCol, LockoutPolicyIDCol),
@@ -126,6 +132,9 @@ func (p *lockoutPolicyProjection) reduceAdded(event eventstore.Event) (*handler.
 			handler.NewCol(LockoutPolicyStateCol, domain.PolicyStateActive),
 			handler.NewCol(LockoutPolicyMaxPasswordAttemptsCol, policyEvent.MaxPasswordAttempts),
 			handler.NewCol(LockoutPolicyShowLockOutFailuresCol, policyEvent.ShowLockOutFailures),
+			handler.NewCol(LockoutPolicyMaxOTPAttemptsCol, policyEvent.MaxOTPAttempts),
+			handler.NewCol(LockoutPolicyAutoUnlockAfterCol, policyEvent.AutoUnlockAfter),
+			handler.NewCol(LockoutPolicyFailedAttemptDelayCol, policyEvent.FailedAttemptDelay),
 			handler.NewCol(LockoutPolicyIsDefaultCol, isDefault),
 			handler.NewCol(LockoutPolicyResourceOwnerCol, policyEvent.Aggregate().ResourceOwner),
 			handler.NewCol(LockoutPolicyInstanceIDCol, policyEvent.Aggregate().InstanceID),
@@ -152,6 +161,15 @@ func (p *lockoutPolicyProjection) reduceChanged(event eventstore.Event) (*handle
 	if policyEvent.ShowLockOutFailures != nil {
 		cols = append(cols, handler.NewCol(LockoutPolicyShowLockOutFailuresCol, *policyEvent.ShowLockOutFailures))
 	}
+	if policyEvent.MaxOTPAttempts != nil {
+		cols = append(cols, handler.NewCol(LockoutPolicyMaxOTPAttemptsCol, *policyEvent.MaxOTPAttempts))
+	}
+	if policyEvent.AutoUnlockAfter != nil {
+		cols = append(cols, handler.NewCol(LockoutPolicyAutoUnlockAfterCol, *policyEvent.AutoUnlockAfter))
+	}
+	if policyEvent.FailedAttemptDelay != nil {
+		cols = append(cols, handler.NewCol(LockoutPolicyFailedAttemptDelayCol, *policyEvent.FailedAttemptDelay))
+	}
 	return handler.NewUpdateStatement(
 		&policyEvent,
 		cols,
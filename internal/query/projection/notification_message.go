@@ -0,0 +1,285 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/notification"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+const (
+	NotificationMessageProjectionTable = "projections.notification_messages"
+
+	NotificationMessageColumnID                  = "id"
+	NotificationMessageColumnCreationDate        = "creation_date"
+	NotificationMessageColumnChangeDate          = "change_date"
+	NotificationMessageColumnSequence            = "sequence"
+	NotificationMessageColumnResourceOwner       = "resource_owner"
+	NotificationMessageColumnInstanceID          = "instance_id"
+	NotificationMessageColumnUserID              = "user_id"
+	NotificationMessageColumnChannel             = "channel"
+	NotificationMessageColumnTriggeringEventType = "triggering_event_type"
+	NotificationMessageColumnRecipient           = "recipient"
+	NotificationMessageColumnState               = "state"
+	NotificationMessageColumnAttempts            = "attempts"
+	NotificationMessageColumnNextAttempt         = "next_attempt"
+	NotificationMessageColumnProviderID          = "provider_id"
+	NotificationMessageColumnProviderMessageID   = "provider_message_id"
+	NotificationMessageColumnError               = "error"
+)
+
+type notificationMessageProjection struct{}
+
+func newNotificationMessageProjection(ctx context.Context, config handler.Config) *handler.Handler {
+	return handler.NewHandler(ctx, &config, new(notificationMessageProjection))
+}
+
+func (*notificationMessageProjection) Name() string {
+	return NotificationMessageProjectionTable
+}
+
+func (*notificationMessageProjection) Init() *old_handler.Check {
+	return handler.NewTableCheck(
+		handler.NewTable([]*handler.InitColumn{
+			handler.NewColumn(NotificationMessageColumnID, handler.ColumnTypeText),
+			handler.NewColumn(NotificationMessageColumnCreationDate, handler.ColumnTypeTimestamp),
+			handler.NewColumn(NotificationMessageColumnChangeDate, handler.ColumnTypeTimestamp),
+			handler.NewColumn(NotificationMessageColumnSequence, handler.ColumnTypeInt64),
+			handler.NewColumn(NotificationMessageColumnResourceOwner, handler.ColumnTypeText),
+			handler.NewColumn(NotificationMessageColumnInstanceID, handler.ColumnTypeText),
+			handler.NewColumn(NotificationMessageColumnUserID, handler.ColumnTypeText, handler.Default("")),
+			handler.NewColumn(NotificationMessageColumnChannel, handler.ColumnTypeEnum),
+			handler.NewColumn(NotificationMessageColumnTriggeringEventType, handler.ColumnTypeText, handler.Default("")),
+			handler.NewColumn(NotificationMessageColumnRecipient, handler.ColumnTypeText),
+			handler.NewColumn(NotificationMessageColumnState, handler.ColumnTypeEnum),
+			handler.NewColumn(NotificationMessageColumnAttempts, handler.ColumnTypeInt64, handler.Default(0)),
+			handler.NewColumn(NotificationMessageColumnNextAttempt, handler.ColumnTypeTimestamp, handler.Nullable()),
+			handler.NewColumn(NotificationMessageColumnProviderID, handler.ColumnTypeText, handler.Default("")),
+			handler.NewColumn(NotificationMessageColumnProviderMessageID, handler.ColumnTypeText, handler.Default("")),
+			handler.NewColumn(NotificationMessageColumnError, handler.ColumnTypeText, handler.Default("")),
+		},
+			handler.NewPrimaryKey(NotificationMessageColumnInstanceID, NotificationMessageColumnID),
+			handler.WithIndex(handler.NewIndex("user_id", []string{NotificationMessageColumnUserID})),
+			handler.WithIndex(handler.NewIndex("state", []string{NotificationMessageColumnState, NotificationMessageColumnNextAttempt})),
+		),
+	)
+}
+
+func (p *notificationMessageProjection) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: notification.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  notification.QueuedEventType,
+					Reduce: p.reduceQueued,
+				},
+				{
+					Event:  notification.DeliveryStartedEventType,
+					Reduce: p.reduceDeliveryStarted,
+				},
+				{
+					Event:  notification.RetryScheduledEventType,
+					Reduce: p.reduceRetryScheduled,
+				},
+				{
+					Event:  notification.PostponedEventType,
+					Reduce: p.reducePostponed,
+				},
+				{
+					Event:  notification.SentEventType,
+					Reduce: p.reduceSent,
+				},
+				{
+					Event:  notification.BouncedEventType,
+					Reduce: p.reduceBounced,
+				},
+				{
+					Event:  notification.FailedEventType,
+					Reduce: p.reduceFailed,
+				},
+			},
+		},
+		{
+			Aggregate: user.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  user.UserRemovedType,
+					Reduce: p.reduceUserRemoved,
+				},
+				{
+					Event:  user.UserForgottenType,
+					Reduce: p.reduceUserRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: org.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(NotificationMessageColumnInstanceID),
+				},
+			},
+		},
+	}
+}
+
+func (p *notificationMessageProjection) reduceQueued(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*notification.QueuedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(NotificationMessageColumnID, e.Aggregate().ID),
+			handler.NewCol(NotificationMessageColumnCreationDate, e.CreationDate()),
+			handler.NewCol(NotificationMessageColumnChangeDate, e.CreationDate()),
+			handler.NewCol(NotificationMessageColumnSequence, e.Sequence()),
+			handler.NewCol(NotificationMessageColumnResourceOwner, e.Aggregate().ResourceOwner),
+			handler.NewCol(NotificationMessageColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(NotificationMessageColumnUserID, e.UserID),
+			handler.NewCol(NotificationMessageColumnChannel, e.Channel),
+			handler.NewCol(NotificationMessageColumnTriggeringEventType, e.TriggeringEventType),
+			handler.NewCol(NotificationMessageColumnRecipient, e.Recipient),
+			handler.NewCol(NotificationMessageColumnState, domain.NotificationMessageStateQueued),
+		},
+	), nil
+}
+
+func (p *notificationMessageProjection) reduceDeliveryStarted(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*notification.DeliveryStartedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return p.updateStatement(e,
+		handler.NewCol(NotificationMessageColumnState, domain.NotificationMessageStateSending),
+		handler.NewCol(NotificationMessageColumnAttempts, e.Attempt),
+	), nil
+}
+
+func (p *notificationMessageProjection) reduceRetryScheduled(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*notification.RetryScheduledEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return p.updateStatement(e,
+		handler.NewCol(NotificationMessageColumnState, domain.NotificationMessageStateQueued),
+		handler.NewCol(NotificationMessageColumnNextAttempt, e.NextAttempt),
+		handler.NewCol(NotificationMessageColumnProviderID, e.ProviderID),
+		handler.NewCol(NotificationMessageColumnError, e.Error),
+	), nil
+}
+
+func (p *notificationMessageProjection) reducePostponed(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*notification.PostponedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return p.updateStatement(e,
+		handler.NewCol(NotificationMessageColumnState, domain.NotificationMessageStateQueued),
+		handler.NewCol(NotificationMessageColumnAttempts, e.Attempt-1),
+		handler.NewCol(NotificationMessageColumnNextAttempt, e.NextAttempt),
+		handler.NewCol(NotificationMessageColumnProviderID, e.ProviderID),
+		handler.NewCol(NotificationMessageColumnError, e.Error),
+	), nil
+}
+
+func (p *notificationMessageProjection) reduceSent(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*notification.SentEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return p.updateStatement(e,
+		handler.NewCol(NotificationMessageColumnState, domain.NotificationMessageStateSent),
+		handler.NewCol(NotificationMessageColumnProviderID, e.ProviderID),
+		handler.NewCol(NotificationMessageColumnProviderMessageID, e.ProviderMessageID),
+		handler.NewCol(NotificationMessageColumnError, ""),
+	), nil
+}
+
+func (p *notificationMessageProjection) reduceBounced(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*notification.BouncedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return p.updateStatement(e,
+		handler.NewCol(NotificationMessageColumnState, domain.NotificationMessageStateBounced),
+		handler.NewCol(NotificationMessageColumnProviderID, e.ProviderID),
+		handler.NewCol(NotificationMessageColumnError, e.Error),
+	), nil
+}
+
+func (p *notificationMessageProjection) reduceFailed(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*notification.FailedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return p.updateStatement(e,
+		handler.NewCol(NotificationMessageColumnState, domain.NotificationMessageStateFailed),
+		handler.NewCol(NotificationMessageColumnProviderID, e.ProviderID),
+		handler.NewCol(NotificationMessageColumnError, e.Error),
+	), nil
+}
+
+func (p *notificationMessageProjection) updateStatement(event eventstore.Event, columns ...handler.Column) *handler.Statement {
+	return handler.NewUpdateStatement(
+		event,
+		append([]handler.Column{
+			handler.NewCol(NotificationMessageColumnChangeDate, event.CreatedAt()),
+			handler.NewCol(NotificationMessageColumnSequence, event.Sequence()),
+		}, columns...),
+		[]handler.Condition{
+			handler.NewCond(NotificationMessageColumnInstanceID, event.Aggregate().InstanceID),
+			handler.NewCond(NotificationMessageColumnID, event.Aggregate().ID),
+		},
+	)
+}
+
+// reduceUserRemoved deletes the messages of the user.
+// They are deleted again if the user is forgotten, as a message could have been queued after the user was removed.
+func (p *notificationMessageProjection) reduceUserRemoved(event eventstore.Event) (*handler.Statement, error) {
+	switch event.(type) {
+	case *user.UserRemovedEvent, *user.UserForgottenEvent:
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-ooZ4e", "reduce.wrong.event.type %v", []eventstore.EventType{user.UserRemovedType, user.UserForgottenType})
+	}
+	return handler.NewDeleteStatement(
+		event,
+		[]handler.Condition{
+			handler.NewCond(NotificationMessageColumnInstanceID, event.Aggregate().InstanceID),
+			handler.NewCond(NotificationMessageColumnUserID, event.Aggregate().ID),
+		},
+	), nil
+}
+
+func (p *notificationMessageProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*org.OrgRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(NotificationMessageColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(NotificationMessageColumnResourceOwner, e.Aggregate().ID),
+		},
+	), nil
+}
@@ -0,0 +1,406 @@
+package projection
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/notification"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func TestNotificationMessageProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceQueued",
+			args: args{
+				event: getEvent(
+					testEvent(
+						notification.QueuedEventType,
+						notification.AggregateType,
+						[]byte(`{
+						"userId": "user-id",
+						"channel": 0,
+						"triggeringEventType": "user.human.initialization.code.added",
+						"recipient": "user@example.com",
+						"subject": "Initialize User",
+						"content": {
+							"cryptoType": 0,
+							"algorithm": "RSA-265",
+							"keyId": "key-id"
+						}
+					}`),
+					), notification.QueuedEventMapper),
+			},
+			reduce: (&notificationMessageProjection{}).reduceQueued,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("notification"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.notification_messages (id, creation_date, change_date, sequence, resource_owner, instance_id, user_id, channel, triggering_event_type, recipient, state) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"ro-id",
+								"instance-id",
+								"user-id",
+								domain.NotificationTypeEmail,
+								user.HumanInitialCodeAddedType,
+								"user@example.com",
+								domain.NotificationMessageStateQueued,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceDeliveryStarted",
+			args: args{
+				event: getEvent(
+					testEvent(
+						notification.DeliveryStartedEventType,
+						notification.AggregateType,
+						[]byte(`{"attempt": 2}`),
+					), notification.DeliveryStartedEventMapper),
+			},
+			reduce: (&notificationMessageProjection{}).reduceDeliveryStarted,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("notification"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.notification_messages SET (change_date, sequence, state, attempts) = ($1, $2, $3, $4) WHERE (instance_id = $5) AND (id = $6)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.NotificationMessageStateSending,
+								uint16(2),
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceRetryScheduled",
+			args: args{
+				event: getEvent(
+					testEvent(
+						notification.RetryScheduledEventType,
+						notification.AggregateType,
+						[]byte(`{
+						"providerId": "smtp-id",
+						"error": "connection refused",
+						"nextAttempt": "2024-01-01T00:00:00Z"
+					}`),
+					), notification.RetryScheduledEventMapper),
+			},
+			reduce: (&notificationMessageProjection{}).reduceRetryScheduled,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("notification"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.notification_messages SET (change_date, sequence, state, next_attempt, provider_id, error) = ($1, $2, $3, $4, $5, $6) WHERE (instance_id = $7) AND (id = $8)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.NotificationMessageStateQueued,
+								time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+								"smtp-id",
+								"connection refused",
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reducePostponed",
+			args: args{
+				event: getEvent(
+					testEvent(
+						notification.PostponedEventType,
+						notification.AggregateType,
+						[]byte(`{
+						"attempt": 2,
+						"providerId": "smtp-id",
+						"error": "rate limit exceeded",
+						"nextAttempt": "2024-01-01T00:00:00Z"
+					}`),
+					), notification.PostponedEventMapper),
+			},
+			reduce: (&notificationMessageProjection{}).reducePostponed,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("notification"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.notification_messages SET (change_date, sequence, state, attempts, next_attempt, provider_id, error) = ($1, $2, $3, $4, $5, $6, $7) WHERE (instance_id = $8) AND (id = $9)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.NotificationMessageStateQueued,
+								uint16(1),
+								time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+								"smtp-id",
+								"rate limit exceeded",
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceSent",
+			args: args{
+				event: getEvent(
+					testEvent(
+						notification.SentEventType,
+						notification.AggregateType,
+						[]byte(`{
+						"providerId": "smtp-id",
+						"providerMessageId": "<message-id@zitadel>"
+					}`),
+					), notification.SentEventMapper),
+			},
+			reduce: (&notificationMessageProjection{}).reduceSent,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("notification"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.notification_messages SET (change_date, sequence, state, provider_id, provider_message_id, error) = ($1, $2, $3, $4, $5, $6) WHERE (instance_id = $7) AND (id = $8)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.NotificationMessageStateSent,
+								"smtp-id",
+								"<message-id@zitadel>",
+								"",
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceBounced",
+			args: args{
+				event: getEvent(
+					testEvent(
+						notification.BouncedEventType,
+						notification.AggregateType,
+						[]byte(`{
+						"providerId": "smtp-id",
+						"error": "550 mailbox unavailable"
+					}`),
+					), notification.BouncedEventMapper),
+			},
+			reduce: (&notificationMessageProjection{}).reduceBounced,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("notification"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.notification_messages SET (change_date, sequence, state, provider_id, error) = ($1, $2, $3, $4, $5) WHERE (instance_id = $6) AND (id = $7)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.NotificationMessageStateBounced,
+								"smtp-id",
+								"550 mailbox unavailable",
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceFailed",
+			args: args{
+				event: getEvent(
+					testEvent(
+						notification.FailedEventType,
+						notification.AggregateType,
+						[]byte(`{
+						"error": "no provider available"
+					}`),
+					), notification.FailedEventMapper),
+			},
+			reduce: (&notificationMessageProjection{}).reduceFailed,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("notification"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.notification_messages SET (change_date, sequence, state, provider_id, error) = ($1, $2, $3, $4, $5) WHERE (instance_id = $6) AND (id = $7)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.NotificationMessageStateFailed,
+								"",
+								"no provider available",
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "user reduceUserRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						user.UserRemovedType,
+						user.AggregateType,
+						nil,
+					), user.UserRemovedEventMapper),
+			},
+			reduce: (&notificationMessageProjection{}).reduceUserRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("user"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.notification_messages WHERE (instance_id = $1) AND (user_id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "user reduceUserRemoved forgotten",
+			args: args{
+				event: getEvent(
+					testEvent(
+						user.UserForgottenType,
+						user.AggregateType,
+						nil,
+					), user.UserForgottenEventMapper),
+			},
+			reduce: (&notificationMessageProjection{}).reduceUserRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("user"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.notification_messages WHERE (instance_id = $1) AND (user_id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceOwnerRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.OrgRemovedEventType,
+						org.AggregateType,
+						nil,
+					), org.OrgRemovedEventMapper),
+			},
+			reduce: (&notificationMessageProjection{}).reduceOwnerRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.notification_messages WHERE (instance_id = $1) AND (resource_owner = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						instance.InstanceRemovedEventType,
+						instance.AggregateType,
+						nil,
+					), instance.InstanceRemovedEventMapper),
+			},
+			reduce: reduceInstanceRemovedHelper(NotificationMessageColumnInstanceID),
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.notification_messages WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if !errors.IsErrorInvalidArgument(err) {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, NotificationMessageProjectionTable, tt.want)
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/settings"
+)
+
+const (
+	NotificationProviderOrderProjectionTable = "projections.notification_provider_orders"
+
+	NotificationProviderOrderColumnAggregateID   = "aggregate_id"
+	NotificationProviderOrderColumnCreationDate  = "creation_date"
+	NotificationProviderOrderColumnChangeDate    = "change_date"
+	NotificationProviderOrderColumnSequence      = "sequence"
+	NotificationProviderOrderColumnResourceOwner = "resource_owner"
+	NotificationProviderOrderColumnInstanceID    = "instance_id"
+	NotificationProviderOrderColumnChannel       = "channel"
+	NotificationProviderOrderColumnProviderIDs   = "provider_ids"
+	NotificationProviderOrderColumnIsDefault     = "is_default"
+	NotificationProviderOrderColumnSenderAddress = "email_sender_address"
+	NotificationProviderOrderColumnSenderName    = "email_sender_name"
+	NotificationProviderOrderColumnReplyTo       = "email_reply_to_address"
+)
+
+type notificationProviderOrderProjection struct{}
+
+func newNotificationProviderOrderProjection(ctx context.Context, config handler.Config) *handler.Handler {
+	return handler.NewHandler(ctx, &config, new(notificationProviderOrderProjection))
+}
+
+func (*notificationProviderOrderProjection) Name() string {
+	return NotificationProviderOrderProjectionTable
+}
+
+func (*notificationProviderOrderProjection) Init() *old_handler.Check {
+	return handler.NewTableCheck(
+		handler.NewTable([]*handler.InitColumn{
+			handler.NewColumn(NotificationProviderOrderColumnAggregateID, handler.ColumnTypeText),
+			handler.NewColumn(NotificationProviderOrderColumnCreationDate, handler.ColumnTypeTimestamp),
+			handler.NewColumn(NotificationProviderOrderColumnChangeDate, handler.ColumnTypeTimestamp),
+			handler.NewColumn(NotificationProviderOrderColumnSequence, handler.ColumnTypeInt64),
+			handler.NewColumn(NotificationProviderOrderColumnResourceOwner, handler.ColumnTypeText),
+			handler.NewColumn(NotificationProviderOrderColumnInstanceID, handler.ColumnTypeText),
+			handler.NewColumn(NotificationProviderOrderColumnChannel, handler.ColumnTypeEnum),
+			handler.NewColumn(NotificationProviderOrderColumnProviderIDs, handler.ColumnTypeTextArray, handler.Nullable()),
+			handler.NewColumn(NotificationProviderOrderColumnIsDefault, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(NotificationProviderOrderColumnSenderAddress, handler.ColumnTypeText, handler.Nullable()),
+			handler.NewColumn(NotificationProviderOrderColumnSenderName, handler.ColumnTypeText, handler.Nullable()),
+			handler.NewColumn(NotificationProviderOrderColumnReplyTo, handler.ColumnTypeText, handler.Nullable()),
+		},
+			handler.NewPrimaryKey(NotificationProviderOrderColumnInstanceID, NotificationProviderOrderColumnAggregateID, NotificationProviderOrderColumnChannel),
+		),
+	)
+}
+
+func (p *notificationProviderOrderProjection) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: instance.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  instance.NotificationProviderOrderSetEventType,
+					Reduce: p.reduceSet,
+				},
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(NotificationProviderOrderColumnInstanceID),
+				},
+			},
+		},
+		{
+			Aggregate: org.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  org.NotificationProviderOrderSetEventType,
+					Reduce: p.reduceSet,
+				},
+				{
+					Event:  org.NotificationProviderOrderRemovedEventType,
+					Reduce: p.reduceRemoved,
+				},
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+	}
+}
+
+func (p *notificationProviderOrderProjection) reduceSet(event eventstore.Event) (*handler.Statement, error) {
+	var (
+		setEvent  settings.NotificationProviderOrderSetEvent
+		isDefault bool
+	)
+	switch e := event.(type) {
+	case *instance.NotificationProviderOrderSetEvent:
+		setEvent = e.NotificationProviderOrderSetEvent
+		isDefault = true
+	case *org.NotificationProviderOrderSetEvent:
+		setEvent = e.NotificationProviderOrderSetEvent
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Rk4ow", "reduce.wrong.event.type %v", []eventstore.EventType{instance.NotificationProviderOrderSetEventType, org.NotificationProviderOrderSetEventType})
+	}
+	var sender settings.EmailSender
+	if setEvent.EmailSender != nil {
+		sender = *setEvent.EmailSender
+	}
+	return handler.NewUpsertStatement(
+		event,
+		[]handler.Column{
+			handler.NewCol(NotificationProviderOrderColumnInstanceID, setEvent.Aggregate().InstanceID),
+			handler.NewCol(NotificationProviderOrderColumnAggregateID, setEvent.Aggregate().ID),
+			handler.NewCol(NotificationProviderOrderColumnChannel, setEvent.Channel),
+		},
+		[]handler.Column{
+			handler.NewCol(NotificationProviderOrderColumnInstanceID, setEvent.Aggregate().InstanceID),
+			handler.NewCol(NotificationProviderOrderColumnAggregateID, setEvent.Aggregate().ID),
+			handler.NewCol(NotificationProviderOrderColumnChannel, setEvent.Channel),
+			handler.NewCol(NotificationProviderOrderColumnCreationDate, handler.OnlySetValueOnInsert(NotificationProviderOrderProjectionTable, setEvent.CreationDate())),
+			handler.NewCol(NotificationProviderOrderColumnChangeDate, setEvent.CreationDate()),
+			handler.NewCol(NotificationProviderOrderColumnSequence, setEvent.Sequence()),
+			handler.NewCol(NotificationProviderOrderColumnResourceOwner, setEvent.Aggregate().ResourceOwner),
+			handler.NewCol(NotificationProviderOrderColumnProviderIDs, database.TextArray[string](setEvent.ProviderIDs)),
+			handler.NewCol(NotificationProviderOrderColumnIsDefault, isDefault),
+			handler.NewCol(NotificationProviderOrderColumnSenderAddress, sender.Address),
+			handler.NewCol(NotificationProviderOrderColumnSenderName, sender.Name),
+			handler.NewCol(NotificationProviderOrderColumnReplyTo, sender.ReplyToAddress),
+		},
+	), nil
+}
+
+func (p *notificationProviderOrderProjection) reduceRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*org.NotificationProviderOrderRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(NotificationProviderOrderColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(NotificationProviderOrderColumnAggregateID, e.Aggregate().ID),
+			handler.NewCond(NotificationProviderOrderColumnChannel, e.Channel),
+		},
+	), nil
+}
+
+func (p *notificationProviderOrderProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*org.OrgRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(NotificationProviderOrderColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(NotificationProviderOrderColumnAggregateID, e.Aggregate().ID),
+		},
+	), nil
+}
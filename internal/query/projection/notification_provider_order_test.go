@@ -0,0 +1,244 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+func TestNotificationProviderOrderProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "instance reduceSet",
+			args: args{
+				event: getEvent(
+					testEvent(
+						instance.NotificationProviderOrderSetEventType,
+						instance.AggregateType,
+						[]byte(`{
+						"channel": 0,
+						"providerIds": ["smtp2", "smtp1"]
+					}`),
+					), instance.NotificationProviderOrderSetEventMapper),
+			},
+			reduce: (&notificationProviderOrderProjection{}).reduceSet,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.notification_provider_orders (instance_id, aggregate_id, channel, creation_date, change_date, sequence, resource_owner, provider_ids, is_default, email_sender_address, email_sender_name, email_reply_to_address) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) ON CONFLICT (instance_id, aggregate_id, channel) DO UPDATE SET (creation_date, change_date, sequence, resource_owner, provider_ids, is_default, email_sender_address, email_sender_name, email_reply_to_address) = (projections.notification_provider_orders.creation_date, EXCLUDED.change_date, EXCLUDED.sequence, EXCLUDED.resource_owner, EXCLUDED.provider_ids, EXCLUDED.is_default, EXCLUDED.email_sender_address, EXCLUDED.email_sender_name, EXCLUDED.email_reply_to_address)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+								domain.NotificationTypeEmail,
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"ro-id",
+								database.TextArray[string]{"smtp2", "smtp1"},
+								true,
+								"",
+								"",
+								"",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceSet",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.NotificationProviderOrderSetEventType,
+						org.AggregateType,
+						[]byte(`{
+						"channel": 1,
+						"providerIds": ["sms1"]
+					}`),
+					), org.NotificationProviderOrderSetEventMapper),
+			},
+			reduce: (&notificationProviderOrderProjection{}).reduceSet,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.notification_provider_orders (instance_id, aggregate_id, channel, creation_date, change_date, sequence, resource_owner, provider_ids, is_default, email_sender_address, email_sender_name, email_reply_to_address) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) ON CONFLICT (instance_id, aggregate_id, channel) DO UPDATE SET (creation_date, change_date, sequence, resource_owner, provider_ids, is_default, email_sender_address, email_sender_name, email_reply_to_address) = (projections.notification_provider_orders.creation_date, EXCLUDED.change_date, EXCLUDED.sequence, EXCLUDED.resource_owner, EXCLUDED.provider_ids, EXCLUDED.is_default, EXCLUDED.email_sender_address, EXCLUDED.email_sender_name, EXCLUDED.email_reply_to_address)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+								domain.NotificationTypeSms,
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"ro-id",
+								database.TextArray[string]{"sms1"},
+								false,
+								"",
+								"",
+								"",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceSet with email sender",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.NotificationProviderOrderSetEventType,
+						org.AggregateType,
+						[]byte(`{
+						"channel": 0,
+						"providerIds": ["smtp1"],
+						"emailSender": {
+							"address": "noreply@org.ch",
+							"name": "Org",
+							"replyToAddress": "support@org.ch"
+						}
+					}`),
+					), org.NotificationProviderOrderSetEventMapper),
+			},
+			reduce: (&notificationProviderOrderProjection{}).reduceSet,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.notification_provider_orders (instance_id, aggregate_id, channel, creation_date, change_date, sequence, resource_owner, provider_ids, is_default, email_sender_address, email_sender_name, email_reply_to_address) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) ON CONFLICT (instance_id, aggregate_id, channel) DO UPDATE SET (creation_date, change_date, sequence, resource_owner, provider_ids, is_default, email_sender_address, email_sender_name, email_reply_to_address) = (projections.notification_provider_orders.creation_date, EXCLUDED.change_date, EXCLUDED.sequence, EXCLUDED.resource_owner, EXCLUDED.provider_ids, EXCLUDED.is_default, EXCLUDED.email_sender_address, EXCLUDED.email_sender_name, EXCLUDED.email_reply_to_address)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+								domain.NotificationTypeEmail,
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"ro-id",
+								database.TextArray[string]{"smtp1"},
+								false,
+								"noreply@org.ch",
+								"Org",
+								"support@org.ch",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.NotificationProviderOrderRemovedEventType,
+						org.AggregateType,
+						[]byte(`{"channel": 1}`),
+					), org.NotificationProviderOrderRemovedEventMapper),
+			},
+			reduce: (&notificationProviderOrderProjection{}).reduceRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.notification_provider_orders WHERE (instance_id = $1) AND (aggregate_id = $2) AND (channel = $3)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+								domain.NotificationTypeSms,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceOwnerRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.OrgRemovedEventType,
+						org.AggregateType,
+						nil,
+					), org.OrgRemovedEventMapper),
+			},
+			reduce: (&notificationProviderOrderProjection{}).reduceOwnerRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.notification_provider_orders WHERE (instance_id = $1) AND (aggregate_id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						instance.InstanceRemovedEventType,
+						instance.AggregateType,
+						nil,
+					), instance.InstanceRemovedEventMapper),
+			},
+			reduce: reduceInstanceRemovedHelper(NotificationProviderOrderColumnInstanceID),
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.notification_provider_orders WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if !errors.IsErrorInvalidArgument(err) {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, NotificationProviderOrderProjectionTable, tt.want)
+		})
+	}
+}
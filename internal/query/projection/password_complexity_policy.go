@@ -14,7 +14,7 @@ import (
 )
 
 const (
-	PasswordComplexityTable = "projections.password_complexity_policies2"
+	PasswordComplexityTable = "projections.password_complexity_policies4"
 
 	ComplexityPolicyIDCol            = "id"
 	ComplexityPolicyCreationDateCol  = "creation_date"
@@ -29,6 +29,8 @@ const (
 	ComplexityPolicyHasUppercaseCol  = "has_uppercase"
 	ComplexityPolicyHasSymbolCol     = "has_symbol"
 	ComplexityPolicyHasNumberCol     = "has_number"
+	ComplexityPolicyHistoryDepthCol  = "history_depth"
+	ComplexityPolicyCheckBreachedCol = "check_breached"
 	ComplexityPolicyOwnerRemovedCol  = "owner_removed"
 )
 
@@ -58,6 +60,8 @@ func (*passwordComplexityProjection) Init() *old_handler.Check {
 			handler.NewColumn(ComplexityPolicyHasUppercaseCol, handler.ColumnTypeBool),
 			handler.NewColumn(ComplexityPolicyHasSymbolCol, handler.ColumnTypeBool),
 			handler.NewColumn(ComplexityPolicyHasNumberCol, handler.ColumnTypeBool),
+			handler.NewColumn(ComplexityPolicyHistoryDepthCol, handler.ColumnTypeInt64, handler.Default(0)),
+			handler.NewColumn(ComplexityPolicyCheckBreachedCol, handler.ColumnTypeBool, handler.Default(false)),
 			handler.NewColumn(ComplexityPolicyOwnerRemovedCol, handler.ColumnTypeBool, handler.Default(false)),
 		},
 			handler.NewPrimaryKey(ComplexityPolicyInstanceIDCol, ComplexityPolicyIDCol),
@@ -135,6 +139,8 @@ func (p *passwordComplexityProjection) reduceAdded(event eventstore.Event) (*han
 			handler.NewCol(ComplexityPolicyHasUppercaseCol, policyEvent.HasUppercase),
 			handler.NewCol(ComplexityPolicyHasSymbolCol, policyEvent.HasSymbol),
 			handler.NewCol(ComplexityPolicyHasNumberCol, policyEvent.HasNumber),
+			handler.NewCol(ComplexityPolicyHistoryDepthCol, policyEvent.HistoryDepth),
+			handler.NewCol(ComplexityPolicyCheckBreachedCol, policyEvent.CheckBreached),
 			handler.NewCol(ComplexityPolicyResourceOwnerCol, policyEvent.Aggregate().ResourceOwner),
 			handler.NewCol(ComplexityPolicyInstanceIDCol, policyEvent.Aggregate().InstanceID),
 			handler.NewCol(ComplexityPolicyIsDefaultCol, isDefault),
@@ -170,6 +176,12 @@ func (p *passwordComplexityProjection) reduceChanged(event eventstore.Event) (*h
 	if policyEvent.HasNumber != nil {
 		cols = append(cols, handler.NewCol(ComplexityPolicyHasNumberCol, *policyEvent.HasNumber))
 	}
+	if policyEvent.HistoryDepth != nil {
+		cols = append(cols, handler.NewCol(ComplexityPolicyHistoryDepthCol, *policyEvent.HistoryDepth))
+	}
+	if policyEvent.CheckBreached != nil {
+		cols = append(cols, handler.NewCol(ComplexityPolicyCheckBreachedCol, *policyEvent.CheckBreached))
+	}
 	return handler.NewUpdateStatement(
 		&policyEvent,
 		cols,
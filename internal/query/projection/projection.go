@@ -2,10 +2,12 @@ package projection
 
 import (
 	"context"
+	"strings"
 
 	internal_authz "github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
 )
 
@@ -56,6 +58,8 @@ var (
 	SecretGeneratorProjection           *handler.Handler
 	SMTPConfigProjection                *handler.Handler
 	SMSConfigProjection                 *handler.Handler
+	NotificationProviderOrderProjection *handler.Handler
+	NotificationMessageProjection       *handler.Handler
 	OIDCSettingsProjection              *handler.Handler
 	DebugNotificationProviderProjection *handler.Handler
 	KeyProjection                       *handler.Handler
@@ -71,6 +75,9 @@ var (
 	QuotaProjection                     *quotaProjection
 	LimitsProjection                    *handler.Handler
 	RestrictionsProjection              *handler.Handler
+	EventSinkProjection                 *handler.Handler
+	TargetProjection                    *handler.Handler
+	ExecutionProjection                 *handler.Handler
 )
 
 type projection interface {
@@ -79,10 +86,12 @@ type projection interface {
 }
 
 var (
-	projections []projection
+	projections     []projection
+	readinessConfig ReadinessConfig
 )
 
 func Create(ctx context.Context, sqlClient *database.DB, es handler.EventStore, config Config, keyEncryptionAlgorithm crypto.EncryptionAlgorithm, certEncryptionAlgorithm crypto.EncryptionAlgorithm, systemUsers map[string]*internal_authz.SystemAPIUser) error {
+	readinessConfig = config.Readiness
 	projectionConfig = handler.Config{
 		Client:                sqlClient,
 		Eventstore:            es,
@@ -133,6 +142,8 @@ func Create(ctx context.Context, sqlClient *database.DB, es handler.EventStore,
 	SecretGeneratorProjection = newSecretGeneratorProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["secret_generators"]))
 	SMTPConfigProjection = newSMTPConfigProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["smtp_configs"]))
 	SMSConfigProjection = newSMSConfigProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["sms_config"]))
+	NotificationProviderOrderProjection = newNotificationProviderOrderProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["notification_provider_orders"]))
+	NotificationMessageProjection = newNotificationMessageProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["notification_messages"]))
 	OIDCSettingsProjection = newOIDCSettingsProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["oidc_settings"]))
 	DebugNotificationProviderProjection = newDebugNotificationProviderProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["debug_notification_provider"]))
 	KeyProjection = newKeyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["keys"]), keyEncryptionAlgorithm, certEncryptionAlgorithm)
@@ -145,6 +156,9 @@ func Create(ctx context.Context, sqlClient *database.DB, es handler.EventStore,
 	QuotaProjection = newQuotaProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["quotas"]))
 	LimitsProjection = newLimitsProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["limits"]))
 	RestrictionsProjection = newRestrictionsProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["restrictions"]))
+	EventSinkProjection = newEventSinkProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["event_sinks"]))
+	TargetProjection = newTargetProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["targets"]))
+	ExecutionProjection = newExecutionProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["executions"]))
 	newProjectionsList()
 	return nil
 }
@@ -164,6 +178,49 @@ func Start(ctx context.Context) {
 	}
 }
 
+// Ready returns an error if a critical projection of the [ReadinessConfig] lags more than the max lag behind the events
+func Ready() error {
+	if readinessConfig.MaxLag == 0 {
+		return nil
+	}
+	for _, name := range readinessConfig.Projections {
+		for _, h := range Projections() {
+			if !isProjection(h.ProjectionName(), name) {
+				continue
+			}
+			if lag := h.Lag(); lag > readinessConfig.MaxLag {
+				return errors.ThrowUnavailablef(nil, "PROJE-Eim4o", "projection %s is %s behind", h.ProjectionName(), lag)
+			}
+		}
+	}
+	return nil
+}
+
+func isProjection(projectionName, name string) bool {
+	return projectionName == name || strings.TrimPrefix(projectionName, "projections.") == name
+}
+
+// Projections returns the handlers of all projections reducing to database statements
+func Projections() []*handler.Handler {
+	handlers := make([]*handler.Handler, 0, len(projections))
+	for _, p := range projections {
+		if h, ok := p.(*handler.Handler); ok {
+			handlers = append(handlers, h)
+		}
+	}
+	return handlers
+}
+
+// ProjectionByName returns the handler of the projection with the (table) name
+func ProjectionByName(name string) (*handler.Handler, bool) {
+	for _, h := range Projections() {
+		if h.ProjectionName() == name {
+			return h, true
+		}
+	}
+	return nil, false
+}
+
 func ApplyCustomConfig(customConfig CustomConfig) handler.Config {
 	return applyCustomConfig(projectionConfig, customConfig)
 }
@@ -238,6 +295,8 @@ func newProjectionsList() {
 		SecretGeneratorProjection,
 		SMTPConfigProjection,
 		SMSConfigProjection,
+		NotificationProviderOrderProjection,
+		NotificationMessageProjection,
 		OIDCSettingsProjection,
 		DebugNotificationProviderProjection,
 		KeyProjection,
@@ -250,5 +309,8 @@ func newProjectionsList() {
 		QuotaProjection.handler,
 		LimitsProjection,
 		RestrictionsProjection,
+		EventSinkProjection,
+		TargetProjection,
+		ExecutionProjection,
 	}
 }
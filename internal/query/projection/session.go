@@ -14,7 +14,7 @@ import (
 )
 
 const (
-	SessionsProjectionTable = "projections.sessions8"
+	SessionsProjectionTable = "projections.sessions10"
 
 	SessionColumnID                     = "id"
 	SessionColumnCreationDate           = "creation_date"
@@ -28,12 +28,14 @@ const (
 	SessionColumnUserResourceOwner      = "user_resource_owner"
 	SessionColumnUserCheckedAt          = "user_checked_at"
 	SessionColumnPasswordCheckedAt      = "password_checked_at"
+	SessionColumnPasswordExpired        = "password_expired"
 	SessionColumnIntentCheckedAt        = "intent_checked_at"
 	SessionColumnWebAuthNCheckedAt      = "webauthn_checked_at"
 	SessionColumnWebAuthNUserVerified   = "webauthn_user_verified"
 	SessionColumnTOTPCheckedAt          = "totp_checked_at"
 	SessionColumnOTPSMSCheckedAt        = "otp_sms_checked_at"
 	SessionColumnOTPEmailCheckedAt      = "otp_email_checked_at"
+	SessionColumnRecoveryCodeCheckedAt  = "recovery_code_checked_at"
 	SessionColumnMetadata               = "metadata"
 	SessionColumnTokenID                = "token_id"
 	SessionColumnUserAgentFingerprintID = "user_agent_fingerprint_id"
@@ -68,12 +70,14 @@ func (*sessionProjection) Init() *old_handler.Check {
 			handler.NewColumn(SessionColumnUserResourceOwner, handler.ColumnTypeText, handler.Nullable()),
 			handler.NewColumn(SessionColumnUserCheckedAt, handler.ColumnTypeTimestamp, handler.Nullable()),
 			handler.NewColumn(SessionColumnPasswordCheckedAt, handler.ColumnTypeTimestamp, handler.Nullable()),
+			handler.NewColumn(SessionColumnPasswordExpired, handler.ColumnTypeBool, handler.Nullable()),
 			handler.NewColumn(SessionColumnIntentCheckedAt, handler.ColumnTypeTimestamp, handler.Nullable()),
 			handler.NewColumn(SessionColumnWebAuthNCheckedAt, handler.ColumnTypeTimestamp, handler.Nullable()),
 			handler.NewColumn(SessionColumnWebAuthNUserVerified, handler.ColumnTypeBool, handler.Nullable()),
 			handler.NewColumn(SessionColumnTOTPCheckedAt, handler.ColumnTypeTimestamp, handler.Nullable()),
 			handler.NewColumn(SessionColumnOTPSMSCheckedAt, handler.ColumnTypeTimestamp, handler.Nullable()),
 			handler.NewColumn(SessionColumnOTPEmailCheckedAt, handler.ColumnTypeTimestamp, handler.Nullable()),
+			handler.NewColumn(SessionColumnRecoveryCodeCheckedAt, handler.ColumnTypeTimestamp, handler.Nullable()),
 			handler.NewColumn(SessionColumnMetadata, handler.ColumnTypeJSONB, handler.Nullable()),
 			handler.NewColumn(SessionColumnTokenID, handler.ColumnTypeText, handler.Nullable()),
 			handler.NewColumn(SessionColumnUserAgentFingerprintID, handler.ColumnTypeText, handler.Nullable()),
@@ -128,6 +132,10 @@ func (p *sessionProjection) Reducers() []handler.AggregateReducer {
 					Event:  session.OTPEmailCheckedType,
 					Reduce: p.reduceOTPEmailChecked,
 				},
+				{
+					Event:  session.RecoveryCodeCheckedType,
+					Reduce: p.reduceRecoveryCodeChecked,
+				},
 				{
 					Event:  session.TokenSetType,
 					Reduce: p.reduceTokenSet,
@@ -237,6 +245,7 @@ func (p *sessionProjection) reducePasswordChecked(event eventstore.Event) (*hand
 			handler.NewCol(SessionColumnChangeDate, e.CreationDate()),
 			handler.NewCol(SessionColumnSequence, e.Sequence()),
 			handler.NewCol(SessionColumnPasswordCheckedAt, e.CheckedAt),
+			handler.NewCol(SessionColumnPasswordExpired, e.Expired),
 		},
 		[]handler.Condition{
 			handler.NewCond(SessionColumnID, e.Aggregate().ID),
@@ -345,6 +354,26 @@ func (p *sessionProjection) reduceOTPEmailChecked(event eventstore.Event) (*hand
 	), nil
 }
 
+func (p *sessionProjection) reduceRecoveryCodeChecked(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*session.RecoveryCodeCheckedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(SessionColumnChangeDate, e.CreationDate()),
+			handler.NewCol(SessionColumnSequence, e.Sequence()),
+			handler.NewCol(SessionColumnRecoveryCodeCheckedAt, e.CheckedAt),
+		},
+		[]handler.Condition{
+			handler.NewCond(SessionColumnID, e.Aggregate().ID),
+			handler.NewCond(SessionColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
 func (p *sessionProjection) reduceTokenSet(event eventstore.Event) (*handler.Statement, error) {
 	e, ok := event.(*session.TokenSetEvent)
 	if !ok {
@@ -430,6 +459,7 @@ func (p *sessionProjection) reducePasswordChanged(event eventstore.Event) (*hand
 		e,
 		[]handler.Column{
 			handler.NewCol(SessionColumnPasswordCheckedAt, nil),
+			handler.NewCol(SessionColumnPasswordExpired, nil),
 		},
 		[]handler.Condition{
 			handler.NewCond(SessionColumnUserID, e.Aggregate().ID),
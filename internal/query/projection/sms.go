@@ -12,8 +12,9 @@ import (
 )
 
 const (
-	SMSConfigProjectionTable = "projections.sms_configs2"
+	SMSConfigProjectionTable = "projections.sms_configs3"
 	SMSTwilioTable           = SMSConfigProjectionTable + "_" + smsTwilioTableSuffix
+	SMSHTTPTable             = SMSConfigProjectionTable + "_" + smsHTTPTableSuffix
 
 	SMSColumnID            = "id"
 	SMSColumnAggregateID   = "aggregate_id"
@@ -30,6 +31,19 @@ const (
 	SMSTwilioConfigColumnSID          = "sid"
 	SMSTwilioConfigColumnSenderNumber = "sender_number"
 	SMSTwilioConfigColumnToken        = "token"
+
+	smsHTTPTableSuffix                    = "http"
+	SMSHTTPConfigColumnSMSID              = "sms_id"
+	SMSHTTPColumnInstanceID               = "instance_id"
+	SMSHTTPConfigColumnURL                = "url"
+	SMSHTTPConfigColumnMethod             = "method"
+	SMSHTTPConfigColumnHeaders            = "headers"
+	SMSHTTPConfigColumnBodyTemplate       = "body_template"
+	SMSHTTPConfigColumnAuthType           = "auth_type"
+	SMSHTTPConfigColumnUsername           = "auth_username"
+	SMSHTTPConfigColumnSecret             = "secret"
+	SMSHTTPConfigColumnSuccessCodes       = "success_status_codes"
+	SMSHTTPConfigColumnSuccessBodyPattern = "success_body_pattern"
 )
 
 type smsConfigProjection struct{}
@@ -67,6 +81,23 @@ func (*smsConfigProjection) Init() *old_handler.Check {
 			smsTwilioTableSuffix,
 			handler.WithForeignKey(handler.NewForeignKeyOfPublicKeys()),
 		),
+		handler.NewSuffixedTable([]*handler.InitColumn{
+			handler.NewColumn(SMSHTTPConfigColumnSMSID, handler.ColumnTypeText),
+			handler.NewColumn(SMSHTTPColumnInstanceID, handler.ColumnTypeText),
+			handler.NewColumn(SMSHTTPConfigColumnURL, handler.ColumnTypeText),
+			handler.NewColumn(SMSHTTPConfigColumnMethod, handler.ColumnTypeText),
+			handler.NewColumn(SMSHTTPConfigColumnHeaders, handler.ColumnTypeJSONB, handler.Nullable()),
+			handler.NewColumn(SMSHTTPConfigColumnBodyTemplate, handler.ColumnTypeText),
+			handler.NewColumn(SMSHTTPConfigColumnAuthType, handler.ColumnTypeEnum),
+			handler.NewColumn(SMSHTTPConfigColumnUsername, handler.ColumnTypeText),
+			handler.NewColumn(SMSHTTPConfigColumnSecret, handler.ColumnTypeJSONB, handler.Nullable()),
+			handler.NewColumn(SMSHTTPConfigColumnSuccessCodes, handler.ColumnTypeJSONB, handler.Nullable()),
+			handler.NewColumn(SMSHTTPConfigColumnSuccessBodyPattern, handler.ColumnTypeText),
+		},
+			handler.NewPrimaryKey(SMSHTTPColumnInstanceID, SMSHTTPConfigColumnSMSID),
+			smsHTTPTableSuffix,
+			handler.WithForeignKey(handler.NewForeignKeyOfPublicKeys()),
+		),
 	)
 }
 
@@ -87,6 +118,18 @@ func (p *smsConfigProjection) Reducers() []handler.AggregateReducer {
 					Event:  instance.SMSConfigTwilioTokenChangedEventType,
 					Reduce: p.reduceSMSConfigTwilioTokenChanged,
 				},
+				{
+					Event:  instance.SMSConfigHTTPAddedEventType,
+					Reduce: p.reduceSMSConfigHTTPAdded,
+				},
+				{
+					Event:  instance.SMSConfigHTTPChangedEventType,
+					Reduce: p.reduceSMSConfigHTTPChanged,
+				},
+				{
+					Event:  instance.SMSConfigHTTPSecretChangedEventType,
+					Reduce: p.reduceSMSConfigHTTPSecretChanged,
+				},
 				{
 					Event:  instance.SMSConfigActivatedEventType,
 					Reduce: p.reduceSMSConfigActivated,
@@ -210,6 +253,130 @@ func (p *smsConfigProjection) reduceSMSConfigTwilioTokenChanged(event eventstore
 	), nil
 }
 
+func (p *smsConfigProjection) reduceSMSConfigHTTPAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*instance.SMSConfigHTTPAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Wq5ti", "reduce.wrong.event.type %s", instance.SMSConfigHTTPAddedEventType)
+	}
+
+	return handler.NewMultiStatement(
+		e,
+		handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(SMSColumnID, e.ID),
+				handler.NewCol(SMSColumnAggregateID, e.Aggregate().ID),
+				handler.NewCol(SMSColumnCreationDate, e.CreationDate()),
+				handler.NewCol(SMSColumnChangeDate, e.CreationDate()),
+				handler.NewCol(SMSColumnResourceOwner, e.Aggregate().ResourceOwner),
+				handler.NewCol(SMSColumnInstanceID, e.Aggregate().InstanceID),
+				handler.NewCol(SMSColumnState, domain.SMSConfigStateInactive),
+				handler.NewCol(SMSColumnSequence, e.Sequence()),
+			},
+		),
+		handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(SMSHTTPConfigColumnSMSID, e.ID),
+				handler.NewCol(SMSHTTPColumnInstanceID, e.Aggregate().InstanceID),
+				handler.NewCol(SMSHTTPConfigColumnURL, e.URL),
+				handler.NewCol(SMSHTTPConfigColumnMethod, e.Method),
+				handler.NewJSONCol(SMSHTTPConfigColumnHeaders, e.Headers),
+				handler.NewCol(SMSHTTPConfigColumnBodyTemplate, e.BodyTemplate),
+				handler.NewCol(SMSHTTPConfigColumnAuthType, e.AuthType),
+				handler.NewCol(SMSHTTPConfigColumnUsername, e.Username),
+				handler.NewCol(SMSHTTPConfigColumnSecret, e.Secret),
+				handler.NewJSONCol(SMSHTTPConfigColumnSuccessCodes, e.SuccessStatusCodes),
+				handler.NewCol(SMSHTTPConfigColumnSuccessBodyPattern, e.SuccessBodyPattern),
+			},
+			handler.WithTableSuffix(smsHTTPTableSuffix),
+		),
+	), nil
+}
+
+func (p *smsConfigProjection) reduceSMSConfigHTTPChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*instance.SMSConfigHTTPChangedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ge2ys", "reduce.wrong.event.type %s", instance.SMSConfigHTTPChangedEventType)
+	}
+	columns := make([]handler.Column, 0)
+	if e.URL != nil {
+		columns = append(columns, handler.NewCol(SMSHTTPConfigColumnURL, *e.URL))
+	}
+	if e.Method != nil {
+		columns = append(columns, handler.NewCol(SMSHTTPConfigColumnMethod, *e.Method))
+	}
+	if e.Headers != nil {
+		columns = append(columns, handler.NewJSONCol(SMSHTTPConfigColumnHeaders, *e.Headers))
+	}
+	if e.BodyTemplate != nil {
+		columns = append(columns, handler.NewCol(SMSHTTPConfigColumnBodyTemplate, *e.BodyTemplate))
+	}
+	if e.AuthType != nil {
+		columns = append(columns, handler.NewCol(SMSHTTPConfigColumnAuthType, *e.AuthType))
+	}
+	if e.Username != nil {
+		columns = append(columns, handler.NewCol(SMSHTTPConfigColumnUsername, *e.Username))
+	}
+	if e.SuccessStatusCodes != nil {
+		columns = append(columns, handler.NewJSONCol(SMSHTTPConfigColumnSuccessCodes, *e.SuccessStatusCodes))
+	}
+	if e.SuccessBodyPattern != nil {
+		columns = append(columns, handler.NewCol(SMSHTTPConfigColumnSuccessBodyPattern, *e.SuccessBodyPattern))
+	}
+
+	return handler.NewMultiStatement(
+		e,
+		handler.AddUpdateStatement(
+			columns,
+			[]handler.Condition{
+				handler.NewCond(SMSHTTPConfigColumnSMSID, e.ID),
+				handler.NewCond(SMSHTTPColumnInstanceID, e.Aggregate().InstanceID),
+			},
+			handler.WithTableSuffix(smsHTTPTableSuffix),
+		),
+		handler.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(SMSColumnChangeDate, e.CreationDate()),
+				handler.NewCol(SMSColumnSequence, e.Sequence()),
+			},
+			[]handler.Condition{
+				handler.NewCond(SMSColumnID, e.ID),
+				handler.NewCond(SMSColumnInstanceID, e.Aggregate().InstanceID),
+			},
+		),
+	), nil
+}
+
+func (p *smsConfigProjection) reduceSMSConfigHTTPSecretChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*instance.SMSConfigHTTPSecretChangedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ob8ck", "reduce.wrong.event.type %s", instance.SMSConfigHTTPSecretChangedEventType)
+	}
+
+	return handler.NewMultiStatement(
+		e,
+		handler.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(SMSHTTPConfigColumnSecret, e.Secret),
+			},
+			[]handler.Condition{
+				handler.NewCond(SMSHTTPConfigColumnSMSID, e.ID),
+				handler.NewCond(SMSHTTPColumnInstanceID, e.Aggregate().InstanceID),
+			},
+			handler.WithTableSuffix(smsHTTPTableSuffix),
+		),
+		handler.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(SMSColumnChangeDate, e.CreationDate()),
+				handler.NewCol(SMSColumnSequence, e.Sequence()),
+			},
+			[]handler.Condition{
+				handler.NewCond(SMSColumnID, e.ID),
+				handler.NewCond(SMSColumnInstanceID, e.Aggregate().InstanceID),
+			},
+		),
+	), nil
+}
+
 func (p *smsConfigProjection) reduceSMSConfigActivated(event eventstore.Event) (*handler.Statement, error) {
 	e, ok := event.(*instance.SMSConfigActivatedEvent)
 	if !ok {
@@ -0,0 +1,122 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/target"
+)
+
+const (
+	TargetProjectionTable = "projections.targets"
+
+	TargetColumnID               = "id"
+	TargetColumnCreationDate     = "creation_date"
+	TargetColumnChangeDate       = "change_date"
+	TargetColumnInstanceID       = "instance_id"
+	TargetColumnSequence         = "sequence"
+	TargetColumnName             = "name"
+	TargetColumnEndpoint         = "endpoint"
+	TargetColumnTimeout          = "timeout"
+	TargetColumnInterruptOnError = "interrupt_on_error"
+	TargetColumnSigningKey       = "signing_key"
+)
+
+type targetProjection struct{}
+
+func newTargetProjection(ctx context.Context, config handler.Config) *handler.Handler {
+	return handler.NewHandler(ctx, &config, new(targetProjection))
+}
+
+func (*targetProjection) Name() string {
+	return TargetProjectionTable
+}
+
+func (*targetProjection) Init() *old_handler.Check {
+	return handler.NewTableCheck(
+		handler.NewTable([]*handler.InitColumn{
+			handler.NewColumn(TargetColumnID, handler.ColumnTypeText),
+			handler.NewColumn(TargetColumnCreationDate, handler.ColumnTypeTimestamp),
+			handler.NewColumn(TargetColumnChangeDate, handler.ColumnTypeTimestamp),
+			handler.NewColumn(TargetColumnInstanceID, handler.ColumnTypeText),
+			handler.NewColumn(TargetColumnSequence, handler.ColumnTypeInt64),
+			handler.NewColumn(TargetColumnName, handler.ColumnTypeText),
+			handler.NewColumn(TargetColumnEndpoint, handler.ColumnTypeText),
+			handler.NewColumn(TargetColumnTimeout, handler.ColumnTypeInt64),
+			handler.NewColumn(TargetColumnInterruptOnError, handler.ColumnTypeBool),
+			handler.NewColumn(TargetColumnSigningKey, handler.ColumnTypeJSONB, handler.Nullable()),
+		},
+			handler.NewPrimaryKey(TargetColumnInstanceID, TargetColumnID),
+		),
+	)
+}
+
+func (p *targetProjection) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: target.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  target.SetEventType,
+					Reduce: p.reduceSet,
+				},
+				{
+					Event:  target.RemovedEventType,
+					Reduce: p.reduceRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(TargetColumnInstanceID),
+				},
+			},
+		},
+	}
+}
+
+func (p *targetProjection) reduceSet(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*target.SetEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewUpsertStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(TargetColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(TargetColumnID, e.Aggregate().ID),
+		},
+		[]handler.Column{
+			handler.NewCol(TargetColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(TargetColumnID, e.Aggregate().ID),
+			handler.NewCol(TargetColumnCreationDate, e.CreationDate()),
+			handler.NewCol(TargetColumnChangeDate, e.CreationDate()),
+			handler.NewCol(TargetColumnSequence, e.Sequence()),
+			handler.NewCol(TargetColumnName, e.Name),
+			handler.NewCol(TargetColumnEndpoint, e.Endpoint),
+			handler.NewCol(TargetColumnTimeout, e.Timeout),
+			handler.NewCol(TargetColumnInterruptOnError, e.InterruptOnError),
+			handler.NewCol(TargetColumnSigningKey, e.SigningKey),
+		},
+	), nil
+}
+
+func (p *targetProjection) reduceRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*target.RemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(TargetColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(TargetColumnID, e.Aggregate().ID),
+		},
+	), nil
+}
@@ -15,7 +15,7 @@ import (
 )
 
 const (
-	UserTable        = "projections.users10"
+	UserTable        = "projections.users11"
 	UserHumanTable   = UserTable + "_" + UserHumanSuffix
 	UserMachineTable = UserTable + "_" + UserMachineSuffix
 	UserNotifyTable  = UserTable + "_" + UserNotifySuffix
@@ -51,6 +51,10 @@ const (
 	HumanPhoneCol           = "phone"
 	HumanIsPhoneVerifiedCol = "is_phone_verified"
 
+	// password
+	HumanPasswordChangedCol      = "password_changed"
+	HumanPasswordExpiryWarnedCol = "password_expiry_warned"
+
 	// machine
 	UserMachineSuffix         = "machines"
 	MachineUserIDCol          = "user_id"
@@ -112,6 +116,8 @@ func (*userProjection) Init() *old_handler.Check {
 			handler.NewColumn(HumanIsEmailVerifiedCol, handler.ColumnTypeBool, handler.Default(false)),
 			handler.NewColumn(HumanPhoneCol, handler.ColumnTypeText, handler.Nullable()),
 			handler.NewColumn(HumanIsPhoneVerifiedCol, handler.ColumnTypeBool, handler.Nullable()),
+			handler.NewColumn(HumanPasswordChangedCol, handler.ColumnTypeTimestamp, handler.Nullable()),
+			handler.NewColumn(HumanPasswordExpiryWarnedCol, handler.ColumnTypeTimestamp, handler.Nullable()),
 		},
 			handler.NewPrimaryKey(HumanUserInstanceIDCol, HumanUserIDCol),
 			UserHumanSuffix,
@@ -278,6 +284,14 @@ func (p *userProjection) Reducers() []handler.AggregateReducer {
 					Event:  user.HumanPasswordChangedType,
 					Reduce: p.reduceHumanPasswordChanged,
 				},
+				{
+					Event:  user.HumanPasswordExpiryWarningDueType,
+					Reduce: p.reduceHumanPasswordExpiryWarned,
+				},
+				{
+					Event:  user.HumanPasswordExpiryWarningSentType,
+					Reduce: p.reduceHumanPasswordExpiryWarned,
+				},
 				{
 					Event:  user.MachineSecretSetType,
 					Reduce: p.reduceMachineSecretSet,
@@ -341,6 +355,7 @@ func (p *userProjection) reduceHumanAdded(event eventstore.Event) (*handler.Stat
 				handler.NewCol(HumanGenderCol, &sql.NullInt16{Int16: int16(e.Gender), Valid: e.Gender.Specified()}),
 				handler.NewCol(HumanEmailCol, e.EmailAddress),
 				handler.NewCol(HumanPhoneCol, &sql.NullString{String: string(e.PhoneNumber), Valid: e.PhoneNumber != ""}),
+				handler.NewCol(HumanPasswordChangedCol, passwordChangedCol(e, user.SecretOrEncodedHash(e.Secret, e.EncodedHash) != "")),
 			},
 			handler.WithTableSuffix(UserHumanSuffix),
 		),
@@ -389,6 +404,7 @@ func (p *userProjection) reduceHumanRegistered(event eventstore.Event) (*handler
 				handler.NewCol(HumanGenderCol, &sql.NullInt16{Int16: int16(e.Gender), Valid: e.Gender.Specified()}),
 				handler.NewCol(HumanEmailCol, e.EmailAddress),
 				handler.NewCol(HumanPhoneCol, &sql.NullString{String: string(e.PhoneNumber), Valid: e.PhoneNumber != ""}),
+				handler.NewCol(HumanPasswordChangedCol, passwordChangedCol(e, user.SecretOrEncodedHash(e.Secret, e.EncodedHash) != "")),
 			},
 			handler.WithTableSuffix(UserHumanSuffix),
 		),
@@ -405,6 +421,13 @@ func (p *userProjection) reduceHumanRegistered(event eventstore.Event) (*handler
 	), nil
 }
 
+func passwordChangedCol(event eventstore.Event, passwordSet bool) *sql.NullTime {
+	if !passwordSet {
+		return &sql.NullTime{}
+	}
+	return &sql.NullTime{Time: event.CreatedAt(), Valid: true}
+}
+
 func (p *userProjection) reduceHumanInitCodeAdded(event eventstore.Event) (*handler.Statement, error) {
 	e, ok := event.(*user.HumanInitialCodeAddedEvent)
 	if !ok {
@@ -898,22 +921,56 @@ func (p *userProjection) reduceHumanAvatarRemoved(event eventstore.Event) (*hand
 	), nil
 }
 
-func (p *userProjection) reduceHumanPasswordChanged(event eventstore.Event) (*handler.Statement, error) {
-	e, ok := event.(*user.HumanPasswordChangedEvent)
-	if !ok {
-		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-jqXUY", "reduce.wrong.event.type %s", user.HumanPasswordChangedType)
+// reduceHumanPasswordExpiryWarned stores when the user was warned about the password expiry,
+// so the user isn't picked up again until the password is changed
+func (p *userProjection) reduceHumanPasswordExpiryWarned(event eventstore.Event) (*handler.Statement, error) {
+	switch event.(type) {
+	case *user.HumanPasswordExpiryWarningDueEvent, *user.HumanPasswordExpiryWarningSentEvent:
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ohv5e", "reduce.wrong.event.type %v", []eventstore.EventType{user.HumanPasswordExpiryWarningDueType, user.HumanPasswordExpiryWarningSentType})
 	}
 
 	return handler.NewUpdateStatement(
-		e,
+		event,
 		[]handler.Column{
-			handler.NewCol(NotifyPasswordSetCol, true),
+			handler.NewCol(HumanPasswordExpiryWarnedCol, event.CreatedAt()),
 		},
 		[]handler.Condition{
-			handler.NewCond(NotifyUserIDCol, e.Aggregate().ID),
-			handler.NewCond(NotifyInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(HumanUserIDCol, event.Aggregate().ID),
+			handler.NewCond(HumanUserInstanceIDCol, event.Aggregate().InstanceID),
 		},
-		handler.WithTableSuffix(UserNotifySuffix),
+		handler.WithTableSuffix(UserHumanSuffix),
+	), nil
+}
+
+func (p *userProjection) reduceHumanPasswordChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanPasswordChangedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-jqXUY", "reduce.wrong.event.type %s", user.HumanPasswordChangedType)
+	}
+
+	return handler.NewMultiStatement(
+		e,
+		handler.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(HumanPasswordChangedCol, e.CreationDate()),
+			},
+			[]handler.Condition{
+				handler.NewCond(HumanUserIDCol, e.Aggregate().ID),
+				handler.NewCond(HumanUserInstanceIDCol, e.Aggregate().InstanceID),
+			},
+			handler.WithTableSuffix(UserHumanSuffix),
+		),
+		handler.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(NotifyPasswordSetCol, true),
+			},
+			[]handler.Condition{
+				handler.NewCond(NotifyUserIDCol, e.Aggregate().ID),
+				handler.NewCond(NotifyInstanceIDCol, e.Aggregate().InstanceID),
+			},
+			handler.WithTableSuffix(UserNotifySuffix),
+		),
 	), nil
 }
 
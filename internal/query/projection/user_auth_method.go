@@ -98,6 +98,10 @@ func (p *userAuthMethodProjection) Reducers() []handler.AggregateReducer {
 					Event:  user.HumanOTPEmailAddedType,
 					Reduce: p.reduceAddAuthMethod,
 				},
+				{
+					Event:  user.HumanRecoveryCodesAddedType,
+					Reduce: p.reduceRecoveryCodesAdded,
+				},
 				{
 					Event:  user.HumanPasswordlessTokenRemovedType,
 					Reduce: p.reduceRemoveAuthMethod,
@@ -126,6 +130,10 @@ func (p *userAuthMethodProjection) Reducers() []handler.AggregateReducer {
 					Event:  user.HumanOTPEmailRemovedType,
 					Reduce: p.reduceRemoveAuthMethod,
 				},
+				{
+					Event:  user.HumanRecoveryCodesRemovedType,
+					Reduce: p.reduceRemoveAuthMethod,
+				},
 			},
 		},
 		{
@@ -255,6 +263,36 @@ func (p *userAuthMethodProjection) reduceAddAuthMethod(event eventstore.Event) (
 	), nil
 }
 
+// reduceRecoveryCodesAdded upserts the auth method, because a new batch of codes replaces the existing one
+func (p *userAuthMethodProjection) reduceRecoveryCodesAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*user.HumanRecoveryCodesAddedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewUpsertStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(UserAuthMethodInstanceIDCol, nil),
+			handler.NewCol(UserAuthMethodUserIDCol, nil),
+			handler.NewCol(UserAuthMethodTypeCol, nil),
+			handler.NewCol(UserAuthMethodTokenIDCol, nil),
+		},
+		[]handler.Column{
+			handler.NewCol(UserAuthMethodTokenIDCol, ""),
+			handler.NewCol(UserAuthMethodCreationDateCol, e.CreatedAt()),
+			handler.NewCol(UserAuthMethodChangeDateCol, e.CreatedAt()),
+			handler.NewCol(UserAuthMethodResourceOwnerCol, e.Aggregate().ResourceOwner),
+			handler.NewCol(UserAuthMethodInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCol(UserAuthMethodUserIDCol, e.Aggregate().ID),
+			handler.NewCol(UserAuthMethodSequenceCol, e.Sequence()),
+			handler.NewCol(UserAuthMethodStateCol, domain.MFAStateReady),
+			handler.NewCol(UserAuthMethodTypeCol, domain.UserAuthMethodTypeRecoveryCode),
+			handler.NewCol(UserAuthMethodNameCol, ""),
+		},
+	), nil
+}
+
 func (p *userAuthMethodProjection) reduceRemoveAuthMethod(event eventstore.Event) (*handler.Statement, error) {
 	var tokenID string
 	var methodType domain.UserAuthMethodType
@@ -272,11 +310,13 @@ func (p *userAuthMethodProjection) reduceRemoveAuthMethod(event eventstore.Event
 		methodType = domain.UserAuthMethodTypeOTPSMS
 	case *user.HumanOTPEmailRemovedEvent:
 		methodType = domain.UserAuthMethodTypeOTPEmail
+	case *user.HumanRecoveryCodesRemovedEvent:
+		methodType = domain.UserAuthMethodTypeRecoveryCode
 
 	default:
 		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-f92f", "reduce.wrong.event.type %v",
 			[]eventstore.EventType{user.HumanPasswordlessTokenAddedType, user.HumanU2FTokenAddedType, user.HumanMFAOTPRemovedType,
-				user.HumanOTPSMSRemovedType, user.HumanPhoneRemovedType, user.HumanOTPEmailRemovedType})
+				user.HumanOTPSMSRemovedType, user.HumanPhoneRemovedType, user.HumanOTPEmailRemovedType, user.HumanRecoveryCodesRemovedType})
 	}
 	conditions := []handler.Condition{
 		handler.NewCond(UserAuthMethodUserIDCol, event.Aggregate().ID),
@@ -0,0 +1,97 @@
+package query
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// ProjectionStatuses returns the progress of the projections per instance.
+// All projections are returned if projectionName is empty, all instances if instanceID is empty.
+func (q *Queries) ProjectionStatuses(ctx context.Context, projectionName, instanceID string) (statuses []*handler.Status, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	handlers := projection.Projections()
+	if projectionName != "" {
+		h, err := projectionHandler(projectionName)
+		if err != nil {
+			return nil, err
+		}
+		handlers = []*handler.Handler{h}
+	}
+	for _, h := range handlers {
+		status, err := h.Status(ctx, instanceID)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, status...)
+	}
+	return statuses, nil
+}
+
+// PauseProjection stops the processing of the projection for the instance or all instances if instanceID is empty
+func (q *Queries) PauseProjection(ctx context.Context, projectionName, instanceID string) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	h, err := projectionHandler(projectionName)
+	if err != nil {
+		return err
+	}
+	return h.Pause(ctx, instanceID)
+}
+
+// ResumeProjection continues the processing of the projection paused by [Queries.PauseProjection]
+func (q *Queries) ResumeProjection(ctx context.Context, projectionName, instanceID string) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	h, err := projectionHandler(projectionName)
+	if err != nil {
+		return err
+	}
+	return h.Resume(ctx, instanceID)
+}
+
+// RebuildProjection starts to replay all events of the instances into the projection, all instances if none are passed.
+// The rebuild runs in the background, its state is returned by [Queries.ProjectionStatuses]
+func (q *Queries) RebuildProjection(ctx context.Context, projectionName string, instanceIDs ...string) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	h, err := projectionHandler(projectionName)
+	if err != nil {
+		return err
+	}
+	return h.StartRebuild(ctx, instanceIDs...)
+}
+
+// SkipFailedEvent skips the failed event on the next processing of the projection
+func (q *Queries) SkipFailedEvent(ctx context.Context, projectionName, instanceID, aggregateType, aggregateID string, sequence uint64) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	h, err := projectionHandler(projectionName)
+	if err != nil {
+		return err
+	}
+	return h.SkipFailedEvent(ctx, instanceID, eventstore.AggregateType(aggregateType), aggregateID, sequence)
+}
+
+// ProjectionsReady returns an error if a critical projection lags too much behind the events
+func (q *Queries) ProjectionsReady(ctx context.Context) error {
+	return projection.Ready()
+}
+
+func projectionHandler(projectionName string) (*handler.Handler, error) {
+	h, ok := projection.ProjectionByName(projectionName)
+	if !ok {
+		return nil, errors.ThrowNotFound(nil, "QUERY-Ohd8u", "Errors.ProjectionName.Invalid")
+	}
+	return h, nil
+}
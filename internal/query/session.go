@@ -28,23 +28,24 @@ type Sessions struct {
 }
 
 type Session struct {
-	ID             string
-	CreationDate   time.Time
-	ChangeDate     time.Time
-	Sequence       uint64
-	State          domain.SessionState
-	ResourceOwner  string
-	Creator        string
-	UserFactor     SessionUserFactor
-	PasswordFactor SessionPasswordFactor
-	IntentFactor   SessionIntentFactor
-	WebAuthNFactor SessionWebAuthNFactor
-	TOTPFactor     SessionTOTPFactor
-	OTPSMSFactor   SessionOTPFactor
-	OTPEmailFactor SessionOTPFactor
-	Metadata       map[string][]byte
-	UserAgent      domain.UserAgent
-	Expiration     time.Time
+	ID                 string
+	CreationDate       time.Time
+	ChangeDate         time.Time
+	Sequence           uint64
+	State              domain.SessionState
+	ResourceOwner      string
+	Creator            string
+	UserFactor         SessionUserFactor
+	PasswordFactor     SessionPasswordFactor
+	IntentFactor       SessionIntentFactor
+	WebAuthNFactor     SessionWebAuthNFactor
+	TOTPFactor         SessionTOTPFactor
+	OTPSMSFactor       SessionOTPFactor
+	OTPEmailFactor     SessionOTPFactor
+	RecoveryCodeFactor SessionRecoveryCodeFactor
+	Metadata           map[string][]byte
+	UserAgent          domain.UserAgent
+	Expiration         time.Time
 }
 
 type SessionUserFactor struct {
@@ -57,6 +58,7 @@ type SessionUserFactor struct {
 
 type SessionPasswordFactor struct {
 	PasswordCheckedAt time.Time
+	Expired           bool
 }
 
 type SessionIntentFactor struct {
@@ -76,6 +78,10 @@ type SessionOTPFactor struct {
 	OTPCheckedAt time.Time
 }
 
+type SessionRecoveryCodeFactor struct {
+	RecoveryCodeCheckedAt time.Time
+}
+
 type SessionsSearchQueries struct {
 	SearchRequest
 	Queries []SearchQuery
@@ -142,6 +148,10 @@ var (
 		name:  projection.SessionColumnPasswordCheckedAt,
 		table: sessionsTable,
 	}
+	SessionColumnPasswordExpired = Column{
+		name:  projection.SessionColumnPasswordExpired,
+		table: sessionsTable,
+	}
 	SessionColumnIntentCheckedAt = Column{
 		name:  projection.SessionColumnIntentCheckedAt,
 		table: sessionsTable,
@@ -166,6 +176,10 @@ var (
 		name:  projection.SessionColumnOTPEmailCheckedAt,
 		table: sessionsTable,
 	}
+	SessionColumnRecoveryCodeCheckedAt = Column{
+		name:  projection.SessionColumnRecoveryCodeCheckedAt,
+		table: sessionsTable,
+	}
 	SessionColumnMetadata = Column{
 		name:  projection.SessionColumnMetadata,
 		table: sessionsTable,
@@ -296,12 +310,14 @@ func prepareSessionQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuil
 			LoginNameNameCol.identifier(),
 			HumanDisplayNameCol.identifier(),
 			SessionColumnPasswordCheckedAt.identifier(),
+			SessionColumnPasswordExpired.identifier(),
 			SessionColumnIntentCheckedAt.identifier(),
 			SessionColumnWebAuthNCheckedAt.identifier(),
 			SessionColumnWebAuthNUserVerified.identifier(),
 			SessionColumnTOTPCheckedAt.identifier(),
 			SessionColumnOTPSMSCheckedAt.identifier(),
 			SessionColumnOTPEmailCheckedAt.identifier(),
+			SessionColumnRecoveryCodeCheckedAt.identifier(),
 			SessionColumnMetadata.identifier(),
 			SessionColumnToken.identifier(),
 			SessionColumnUserAgentFingerprintID.identifier(),
@@ -317,23 +333,25 @@ func prepareSessionQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuil
 			session := new(Session)
 
 			var (
-				userID              sql.NullString
-				userResourceOwner   sql.NullString
-				userCheckedAt       sql.NullTime
-				loginName           sql.NullString
-				displayName         sql.NullString
-				passwordCheckedAt   sql.NullTime
-				intentCheckedAt     sql.NullTime
-				webAuthNCheckedAt   sql.NullTime
-				webAuthNUserPresent sql.NullBool
-				totpCheckedAt       sql.NullTime
-				otpSMSCheckedAt     sql.NullTime
-				otpEmailCheckedAt   sql.NullTime
-				metadata            database.Map[[]byte]
-				token               sql.NullString
-				userAgentIP         sql.NullString
-				userAgentHeader     database.Map[[]string]
-				expiration          sql.NullTime
+				userID                sql.NullString
+				userResourceOwner     sql.NullString
+				userCheckedAt         sql.NullTime
+				loginName             sql.NullString
+				displayName           sql.NullString
+				passwordCheckedAt     sql.NullTime
+				passwordExpired       sql.NullBool
+				intentCheckedAt       sql.NullTime
+				webAuthNCheckedAt     sql.NullTime
+				webAuthNUserPresent   sql.NullBool
+				totpCheckedAt         sql.NullTime
+				otpSMSCheckedAt       sql.NullTime
+				otpEmailCheckedAt     sql.NullTime
+				recoveryCodeCheckedAt sql.NullTime
+				metadata              database.Map[[]byte]
+				token                 sql.NullString
+				userAgentIP           sql.NullString
+				userAgentHeader       database.Map[[]string]
+				expiration            sql.NullTime
 			)
 
 			err := row.Scan(
@@ -350,12 +368,14 @@ func prepareSessionQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuil
 				&loginName,
 				&displayName,
 				&passwordCheckedAt,
+				&passwordExpired,
 				&intentCheckedAt,
 				&webAuthNCheckedAt,
 				&webAuthNUserPresent,
 				&totpCheckedAt,
 				&otpSMSCheckedAt,
 				&otpEmailCheckedAt,
+				&recoveryCodeCheckedAt,
 				&metadata,
 				&token,
 				&session.UserAgent.FingerprintID,
@@ -378,12 +398,14 @@ func prepareSessionQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuil
 			session.UserFactor.LoginName = loginName.String
 			session.UserFactor.DisplayName = displayName.String
 			session.PasswordFactor.PasswordCheckedAt = passwordCheckedAt.Time
+			session.PasswordFactor.Expired = passwordExpired.Bool
 			session.IntentFactor.IntentCheckedAt = intentCheckedAt.Time
 			session.WebAuthNFactor.WebAuthNCheckedAt = webAuthNCheckedAt.Time
 			session.WebAuthNFactor.UserVerified = webAuthNUserPresent.Bool
 			session.TOTPFactor.TOTPCheckedAt = totpCheckedAt.Time
 			session.OTPSMSFactor.OTPCheckedAt = otpSMSCheckedAt.Time
 			session.OTPEmailFactor.OTPCheckedAt = otpEmailCheckedAt.Time
+			session.RecoveryCodeFactor.RecoveryCodeCheckedAt = recoveryCodeCheckedAt.Time
 			session.Metadata = metadata
 			session.UserAgent.Header = http.Header(userAgentHeader)
 			if userAgentIP.Valid {
@@ -409,12 +431,14 @@ func prepareSessionsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBui
 			LoginNameNameCol.identifier(),
 			HumanDisplayNameCol.identifier(),
 			SessionColumnPasswordCheckedAt.identifier(),
+			SessionColumnPasswordExpired.identifier(),
 			SessionColumnIntentCheckedAt.identifier(),
 			SessionColumnWebAuthNCheckedAt.identifier(),
 			SessionColumnWebAuthNUserVerified.identifier(),
 			SessionColumnTOTPCheckedAt.identifier(),
 			SessionColumnOTPSMSCheckedAt.identifier(),
 			SessionColumnOTPEmailCheckedAt.identifier(),
+			SessionColumnRecoveryCodeCheckedAt.identifier(),
 			SessionColumnMetadata.identifier(),
 			SessionColumnExpiration.identifier(),
 			countColumn.identifier(),
@@ -429,20 +453,22 @@ func prepareSessionsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBui
 				session := new(Session)
 
 				var (
-					userID              sql.NullString
-					userResourceOwner   sql.NullString
-					userCheckedAt       sql.NullTime
-					loginName           sql.NullString
-					displayName         sql.NullString
-					passwordCheckedAt   sql.NullTime
-					intentCheckedAt     sql.NullTime
-					webAuthNCheckedAt   sql.NullTime
-					webAuthNUserPresent sql.NullBool
-					totpCheckedAt       sql.NullTime
-					otpSMSCheckedAt     sql.NullTime
-					otpEmailCheckedAt   sql.NullTime
-					metadata            database.Map[[]byte]
-					expiration          sql.NullTime
+					userID                sql.NullString
+					userResourceOwner     sql.NullString
+					userCheckedAt         sql.NullTime
+					loginName             sql.NullString
+					displayName           sql.NullString
+					passwordCheckedAt     sql.NullTime
+					passwordExpired       sql.NullBool
+					intentCheckedAt       sql.NullTime
+					webAuthNCheckedAt     sql.NullTime
+					webAuthNUserPresent   sql.NullBool
+					totpCheckedAt         sql.NullTime
+					otpSMSCheckedAt       sql.NullTime
+					otpEmailCheckedAt     sql.NullTime
+					recoveryCodeCheckedAt sql.NullTime
+					metadata              database.Map[[]byte]
+					expiration            sql.NullTime
 				)
 
 				err := rows.Scan(
@@ -459,12 +485,14 @@ func prepareSessionsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBui
 					&loginName,
 					&displayName,
 					&passwordCheckedAt,
+					&passwordExpired,
 					&intentCheckedAt,
 					&webAuthNCheckedAt,
 					&webAuthNUserPresent,
 					&totpCheckedAt,
 					&otpSMSCheckedAt,
 					&otpEmailCheckedAt,
+					&recoveryCodeCheckedAt,
 					&metadata,
 					&expiration,
 					&sessions.Count,
@@ -479,12 +507,14 @@ func prepareSessionsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBui
 				session.UserFactor.LoginName = loginName.String
 				session.UserFactor.DisplayName = displayName.String
 				session.PasswordFactor.PasswordCheckedAt = passwordCheckedAt.Time
+				session.PasswordFactor.Expired = passwordExpired.Bool
 				session.IntentFactor.IntentCheckedAt = intentCheckedAt.Time
 				session.WebAuthNFactor.WebAuthNCheckedAt = webAuthNCheckedAt.Time
 				session.WebAuthNFactor.UserVerified = webAuthNUserPresent.Bool
 				session.TOTPFactor.TOTPCheckedAt = totpCheckedAt.Time
 				session.OTPSMSFactor.OTPCheckedAt = otpSMSCheckedAt.Time
 				session.OTPEmailFactor.OTPCheckedAt = otpEmailCheckedAt.Time
+				session.RecoveryCodeFactor.RecoveryCodeCheckedAt = recoveryCodeCheckedAt.Time
 				session.Metadata = metadata
 				session.Expiration = expiration.Time
 
@@ -3,6 +3,7 @@ package query
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	errs "errors"
 	"time"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/call"
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/query/projection"
@@ -32,6 +34,7 @@ type SMSConfig struct {
 	Sequence      uint64
 
 	TwilioConfig *Twilio
+	HTTPConfig   *HTTP
 }
 
 type Twilio struct {
@@ -40,6 +43,18 @@ type Twilio struct {
 	SenderNumber string
 }
 
+type HTTP struct {
+	URL                string
+	Method             string
+	Headers            map[string]*crypto.CryptoValue
+	BodyTemplate       string
+	AuthType           domain.SMSHTTPAuthType
+	Username           string
+	Secret             *crypto.CryptoValue
+	SuccessStatusCodes []int
+	SuccessBodyPattern string
+}
+
 type SMSConfigsSearchQueries struct {
 	SearchRequest
 	Queries []SearchQuery
@@ -115,6 +130,53 @@ var (
 	}
 )
 
+var (
+	smsHTTPConfigsTable = table{
+		name:          projection.SMSHTTPTable,
+		instanceIDCol: projection.SMSHTTPColumnInstanceID,
+	}
+	SMSHTTPConfigColumnSMSID = Column{
+		name:  projection.SMSHTTPConfigColumnSMSID,
+		table: smsHTTPConfigsTable,
+	}
+	SMSHTTPConfigColumnURL = Column{
+		name:  projection.SMSHTTPConfigColumnURL,
+		table: smsHTTPConfigsTable,
+	}
+	SMSHTTPConfigColumnMethod = Column{
+		name:  projection.SMSHTTPConfigColumnMethod,
+		table: smsHTTPConfigsTable,
+	}
+	SMSHTTPConfigColumnHeaders = Column{
+		name:  projection.SMSHTTPConfigColumnHeaders,
+		table: smsHTTPConfigsTable,
+	}
+	SMSHTTPConfigColumnBodyTemplate = Column{
+		name:  projection.SMSHTTPConfigColumnBodyTemplate,
+		table: smsHTTPConfigsTable,
+	}
+	SMSHTTPConfigColumnAuthType = Column{
+		name:  projection.SMSHTTPConfigColumnAuthType,
+		table: smsHTTPConfigsTable,
+	}
+	SMSHTTPConfigColumnUsername = Column{
+		name:  projection.SMSHTTPConfigColumnUsername,
+		table: smsHTTPConfigsTable,
+	}
+	SMSHTTPConfigColumnSecret = Column{
+		name:  projection.SMSHTTPConfigColumnSecret,
+		table: smsHTTPConfigsTable,
+	}
+	SMSHTTPConfigColumnSuccessCodes = Column{
+		name:  projection.SMSHTTPConfigColumnSuccessCodes,
+		table: smsHTTPConfigsTable,
+	}
+	SMSHTTPConfigColumnSuccessBodyPattern = Column{
+		name:  projection.SMSHTTPConfigColumnSuccessBodyPattern,
+		table: smsHTTPConfigsTable,
+	}
+)
+
 func (q *Queries) SMSProviderConfigByID(ctx context.Context, id string) (config *SMSConfig, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -203,13 +265,26 @@ func prepareSMSConfigQuery(ctx context.Context, db prepareDatabase) (sq.SelectBu
 			SMSTwilioConfigColumnSID.identifier(),
 			SMSTwilioConfigColumnToken.identifier(),
 			SMSTwilioConfigColumnSenderNumber.identifier(),
+
+			SMSHTTPConfigColumnSMSID.identifier(),
+			SMSHTTPConfigColumnURL.identifier(),
+			SMSHTTPConfigColumnMethod.identifier(),
+			SMSHTTPConfigColumnHeaders.identifier(),
+			SMSHTTPConfigColumnBodyTemplate.identifier(),
+			SMSHTTPConfigColumnAuthType.identifier(),
+			SMSHTTPConfigColumnUsername.identifier(),
+			SMSHTTPConfigColumnSecret.identifier(),
+			SMSHTTPConfigColumnSuccessCodes.identifier(),
+			SMSHTTPConfigColumnSuccessBodyPattern.identifier(),
 		).From(smsConfigsTable.identifier()).
-			LeftJoin(join(SMSTwilioConfigColumnSMSID, SMSConfigColumnID) + db.Timetravel(call.Took(ctx))).
+			LeftJoin(join(SMSTwilioConfigColumnSMSID, SMSConfigColumnID)).
+			LeftJoin(join(SMSHTTPConfigColumnSMSID, SMSConfigColumnID) + db.Timetravel(call.Took(ctx))).
 			PlaceholderFormat(sq.Dollar), func(row *sql.Row) (*SMSConfig, error) {
 			config := new(SMSConfig)
 
 			var (
 				twilioConfig = sqlTwilioConfig{}
+				httpConfig   = sqlHTTPConfig{}
 			)
 
 			err := row.Scan(
@@ -225,6 +300,17 @@ func prepareSMSConfigQuery(ctx context.Context, db prepareDatabase) (sq.SelectBu
 				&twilioConfig.sid,
 				&twilioConfig.token,
 				&twilioConfig.senderNumber,
+
+				&httpConfig.smsID,
+				&httpConfig.url,
+				&httpConfig.method,
+				&httpConfig.headers,
+				&httpConfig.bodyTemplate,
+				&httpConfig.authType,
+				&httpConfig.username,
+				&httpConfig.secret,
+				&httpConfig.successStatusCodes,
+				&httpConfig.successBodyPattern,
 			)
 
 			if err != nil {
@@ -235,6 +321,9 @@ func prepareSMSConfigQuery(ctx context.Context, db prepareDatabase) (sq.SelectBu
 			}
 
 			twilioConfig.set(config)
+			if err = httpConfig.set(config); err != nil {
+				return nil, err
+			}
 
 			return config, nil
 		}
@@ -254,9 +343,21 @@ func prepareSMSConfigsQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 			SMSTwilioConfigColumnSID.identifier(),
 			SMSTwilioConfigColumnToken.identifier(),
 			SMSTwilioConfigColumnSenderNumber.identifier(),
+
+			SMSHTTPConfigColumnSMSID.identifier(),
+			SMSHTTPConfigColumnURL.identifier(),
+			SMSHTTPConfigColumnMethod.identifier(),
+			SMSHTTPConfigColumnHeaders.identifier(),
+			SMSHTTPConfigColumnBodyTemplate.identifier(),
+			SMSHTTPConfigColumnAuthType.identifier(),
+			SMSHTTPConfigColumnUsername.identifier(),
+			SMSHTTPConfigColumnSecret.identifier(),
+			SMSHTTPConfigColumnSuccessCodes.identifier(),
+			SMSHTTPConfigColumnSuccessBodyPattern.identifier(),
 			countColumn.identifier(),
 		).From(smsConfigsTable.identifier()).
-			LeftJoin(join(SMSTwilioConfigColumnSMSID, SMSConfigColumnID) + db.Timetravel(call.Took(ctx))).
+			LeftJoin(join(SMSTwilioConfigColumnSMSID, SMSConfigColumnID)).
+			LeftJoin(join(SMSHTTPConfigColumnSMSID, SMSConfigColumnID) + db.Timetravel(call.Took(ctx))).
 			PlaceholderFormat(sq.Dollar), func(row *sql.Rows) (*SMSConfigs, error) {
 			configs := &SMSConfigs{Configs: []*SMSConfig{}}
 
@@ -264,6 +365,7 @@ func prepareSMSConfigsQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 				config := new(SMSConfig)
 				var (
 					twilioConfig = sqlTwilioConfig{}
+					httpConfig   = sqlHTTPConfig{}
 				)
 
 				err := row.Scan(
@@ -279,6 +381,17 @@ func prepareSMSConfigsQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 					&twilioConfig.sid,
 					&twilioConfig.token,
 					&twilioConfig.senderNumber,
+
+					&httpConfig.smsID,
+					&httpConfig.url,
+					&httpConfig.method,
+					&httpConfig.headers,
+					&httpConfig.bodyTemplate,
+					&httpConfig.authType,
+					&httpConfig.username,
+					&httpConfig.secret,
+					&httpConfig.successStatusCodes,
+					&httpConfig.successBodyPattern,
 					&configs.Count,
 				)
 
@@ -287,6 +400,9 @@ func prepareSMSConfigsQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 				}
 
 				twilioConfig.set(config)
+				if err = httpConfig.set(config); err != nil {
+					return nil, err
+				}
 
 				configs.Configs = append(configs.Configs, config)
 			}
@@ -312,3 +428,40 @@ func (c sqlTwilioConfig) set(smsConfig *SMSConfig) {
 		SenderNumber: c.senderNumber.String,
 	}
 }
+
+type sqlHTTPConfig struct {
+	smsID              sql.NullString
+	url                sql.NullString
+	method             sql.NullString
+	headers            database.Map[*crypto.CryptoValue]
+	bodyTemplate       sql.NullString
+	authType           sql.NullInt32
+	username           sql.NullString
+	secret             *crypto.CryptoValue
+	successStatusCodes []byte
+	successBodyPattern sql.NullString
+}
+
+func (c sqlHTTPConfig) set(smsConfig *SMSConfig) error {
+	if !c.smsID.Valid {
+		return nil
+	}
+	var successStatusCodes []int
+	if len(c.successStatusCodes) > 0 {
+		if err := json.Unmarshal(c.successStatusCodes, &successStatusCodes); err != nil {
+			return errors.ThrowInternal(err, "QUERY-Lr6vb", "Errors.Internal")
+		}
+	}
+	smsConfig.HTTPConfig = &HTTP{
+		URL:                c.url.String,
+		Method:             c.method.String,
+		Headers:            c.headers,
+		BodyTemplate:       c.bodyTemplate.String,
+		AuthType:           domain.SMSHTTPAuthType(c.authType.Int32),
+		Username:           c.username.String,
+		Secret:             c.secret,
+		SuccessStatusCodes: successStatusCodes,
+		SuccessBodyPattern: c.successBodyPattern.String,
+	}
+	return nil
+}
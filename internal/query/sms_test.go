@@ -14,38 +14,64 @@ import (
 )
 
 var (
-	expectedSMSConfigQuery = regexp.QuoteMeta(`SELECT projections.sms_configs2.id,` +
-		` projections.sms_configs2.aggregate_id,` +
-		` projections.sms_configs2.creation_date,` +
-		` projections.sms_configs2.change_date,` +
-		` projections.sms_configs2.resource_owner,` +
-		` projections.sms_configs2.state,` +
-		` projections.sms_configs2.sequence,` +
+	expectedSMSConfigQuery = regexp.QuoteMeta(`SELECT projections.sms_configs3.id,` +
+		` projections.sms_configs3.aggregate_id,` +
+		` projections.sms_configs3.creation_date,` +
+		` projections.sms_configs3.change_date,` +
+		` projections.sms_configs3.resource_owner,` +
+		` projections.sms_configs3.state,` +
+		` projections.sms_configs3.sequence,` +
 
 		// twilio config
-		` projections.sms_configs2_twilio.sms_id,` +
-		` projections.sms_configs2_twilio.sid,` +
-		` projections.sms_configs2_twilio.token,` +
-		` projections.sms_configs2_twilio.sender_number` +
-		` FROM projections.sms_configs2` +
-		` LEFT JOIN projections.sms_configs2_twilio ON projections.sms_configs2.id = projections.sms_configs2_twilio.sms_id AND projections.sms_configs2.instance_id = projections.sms_configs2_twilio.instance_id` +
+		` projections.sms_configs3_twilio.sms_id,` +
+		` projections.sms_configs3_twilio.sid,` +
+		` projections.sms_configs3_twilio.token,` +
+		` projections.sms_configs3_twilio.sender_number,` +
+
+		// http config
+		` projections.sms_configs3_http.sms_id,` +
+		` projections.sms_configs3_http.url,` +
+		` projections.sms_configs3_http.method,` +
+		` projections.sms_configs3_http.headers,` +
+		` projections.sms_configs3_http.body_template,` +
+		` projections.sms_configs3_http.auth_type,` +
+		` projections.sms_configs3_http.auth_username,` +
+		` projections.sms_configs3_http.secret,` +
+		` projections.sms_configs3_http.success_status_codes,` +
+		` projections.sms_configs3_http.success_body_pattern` +
+		` FROM projections.sms_configs3` +
+		` LEFT JOIN projections.sms_configs3_twilio ON projections.sms_configs3.id = projections.sms_configs3_twilio.sms_id AND projections.sms_configs3.instance_id = projections.sms_configs3_twilio.instance_id` +
+		` LEFT JOIN projections.sms_configs3_http ON projections.sms_configs3.id = projections.sms_configs3_http.sms_id AND projections.sms_configs3.instance_id = projections.sms_configs3_http.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
-	expectedSMSConfigsQuery = regexp.QuoteMeta(`SELECT projections.sms_configs2.id,` +
-		` projections.sms_configs2.aggregate_id,` +
-		` projections.sms_configs2.creation_date,` +
-		` projections.sms_configs2.change_date,` +
-		` projections.sms_configs2.resource_owner,` +
-		` projections.sms_configs2.state,` +
-		` projections.sms_configs2.sequence,` +
+	expectedSMSConfigsQuery = regexp.QuoteMeta(`SELECT projections.sms_configs3.id,` +
+		` projections.sms_configs3.aggregate_id,` +
+		` projections.sms_configs3.creation_date,` +
+		` projections.sms_configs3.change_date,` +
+		` projections.sms_configs3.resource_owner,` +
+		` projections.sms_configs3.state,` +
+		` projections.sms_configs3.sequence,` +
 
 		// twilio config
-		` projections.sms_configs2_twilio.sms_id,` +
-		` projections.sms_configs2_twilio.sid,` +
-		` projections.sms_configs2_twilio.token,` +
-		` projections.sms_configs2_twilio.sender_number,` +
+		` projections.sms_configs3_twilio.sms_id,` +
+		` projections.sms_configs3_twilio.sid,` +
+		` projections.sms_configs3_twilio.token,` +
+		` projections.sms_configs3_twilio.sender_number,` +
+
+		// http config
+		` projections.sms_configs3_http.sms_id,` +
+		` projections.sms_configs3_http.url,` +
+		` projections.sms_configs3_http.method,` +
+		` projections.sms_configs3_http.headers,` +
+		` projections.sms_configs3_http.body_template,` +
+		` projections.sms_configs3_http.auth_type,` +
+		` projections.sms_configs3_http.auth_username,` +
+		` projections.sms_configs3_http.secret,` +
+		` projections.sms_configs3_http.success_status_codes,` +
+		` projections.sms_configs3_http.success_body_pattern,` +
 		` COUNT(*) OVER ()` +
-		` FROM projections.sms_configs2` +
-		` LEFT JOIN projections.sms_configs2_twilio ON projections.sms_configs2.id = projections.sms_configs2_twilio.sms_id AND projections.sms_configs2.instance_id = projections.sms_configs2_twilio.instance_id` +
+		` FROM projections.sms_configs3` +
+		` LEFT JOIN projections.sms_configs3_twilio ON projections.sms_configs3.id = projections.sms_configs3_twilio.sms_id AND projections.sms_configs3.instance_id = projections.sms_configs3_twilio.instance_id` +
+		` LEFT JOIN projections.sms_configs3_http ON projections.sms_configs3.id = projections.sms_configs3_http.sms_id AND projections.sms_configs3.instance_id = projections.sms_configs3_http.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
 
 	smsConfigCols = []string{
@@ -61,6 +87,17 @@ var (
 		"sid",
 		"token",
 		"sender-number",
+		// http config
+		"sms_id",
+		"url",
+		"method",
+		"headers",
+		"body_template",
+		"auth_type",
+		"auth_username",
+		"secret",
+		"success_status_codes",
+		"success_body_pattern",
 	}
 	smsConfigsCols = append(smsConfigCols, "count")
 )
@@ -109,6 +146,17 @@ func Test_SMSConfigssPrepare(t *testing.T) {
 							"sid",
 							&crypto.CryptoValue{},
 							"sender-number",
+							// http config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -156,6 +204,17 @@ func Test_SMSConfigssPrepare(t *testing.T) {
 							"sid",
 							&crypto.CryptoValue{},
 							"sender-number",
+							// http config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 						{
 							"sms-id2",
@@ -170,6 +229,17 @@ func Test_SMSConfigssPrepare(t *testing.T) {
 							"sid2",
 							&crypto.CryptoValue{},
 							"sender-number2",
+							// http config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -284,6 +354,17 @@ func Test_SMSConfigPrepare(t *testing.T) {
 						"sid",
 						&crypto.CryptoValue{},
 						"sender-number",
+						// http config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -302,6 +383,67 @@ func Test_SMSConfigPrepare(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "prepareSMSConfigQuery http config",
+			prepare: prepareSMSConfigQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					expectedSMSConfigQuery,
+					smsConfigCols,
+					[]driver.Value{
+						"sms-id",
+						"agg-id",
+						testNow,
+						testNow,
+						"ro",
+						domain.SMSConfigStateInactive,
+						uint64(20211109),
+						// twilio config
+						nil,
+						nil,
+						nil,
+						nil,
+						// http config
+						"sms-id",
+						"https://sms.local/send",
+						"POST",
+						[]byte(`{"X-Api-Version":{"CryptoType":0,"Algorithm":"enc","KeyID":"id","Crypted":"WyIyIl0="}}`),
+						"{{.Content}}",
+						domain.SMSHTTPAuthTypeBearer,
+						"",
+						&crypto.CryptoValue{},
+						[]byte(`[202]`),
+						"queued",
+					},
+				),
+			},
+			object: &SMSConfig{
+				ID:            "sms-id",
+				AggregateID:   "agg-id",
+				CreationDate:  testNow,
+				ChangeDate:    testNow,
+				ResourceOwner: "ro",
+				State:         domain.SMSConfigStateInactive,
+				Sequence:      20211109,
+				HTTPConfig: &HTTP{
+					URL:    "https://sms.local/send",
+					Method: "POST",
+					Headers: map[string]*crypto.CryptoValue{
+						"X-Api-Version": {
+							CryptoType: crypto.TypeEncryption,
+							Algorithm:  "enc",
+							KeyID:      "id",
+							Crypted:    []byte(`["2"]`),
+						},
+					},
+					BodyTemplate:       "{{.Content}}",
+					AuthType:           domain.SMSHTTPAuthTypeBearer,
+					Secret:             &crypto.CryptoValue{},
+					SuccessStatusCodes: []int{202},
+					SuccessBodyPattern: "queued",
+				},
+			},
+		},
 		{
 			name:    "prepareSMSConfigQuery sql err",
 			prepare: prepareSMSConfigQuery,
@@ -21,6 +21,10 @@ var (
 		name:          projection.SMTPConfigProjectionTable,
 		instanceIDCol: projection.SMTPConfigColumnInstanceID,
 	}
+	SMTPConfigColumnID = Column{
+		name:  projection.SMTPConfigColumnID,
+		table: smtpConfigsTable,
+	}
 	SMTPConfigColumnAggregateID = Column{
 		name:  projection.SMTPConfigColumnAggregateID,
 		table: smtpConfigsTable,
@@ -80,7 +84,21 @@ type SMTPConfigs struct {
 	SMTPConfigs []*SMTPConfig
 }
 
+type SMTPConfigsSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *SMTPConfigsSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
 type SMTPConfig struct {
+	ID            string
 	AggregateID   string
 	CreationDate  time.Time
 	ChangeDate    time.Time
@@ -96,14 +114,16 @@ type SMTPConfig struct {
 	Password       *crypto.CryptoValue
 }
 
-func (q *Queries) SMTPConfigByAggregateID(ctx context.Context, aggregateID string) (config *SMTPConfig, err error) {
+// SMTPConfigByID returns the SMTP config of the instance with the id,
+// the first config of an instance has the id of the instance.
+func (q *Queries) SMTPConfigByID(ctx context.Context, id string) (config *SMTPConfig, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
 	stmt, scan := prepareSMTPConfigQuery(ctx, q.client)
 	query, args, err := stmt.Where(sq.Eq{
-		SMTPConfigColumnAggregateID.identifier(): aggregateID,
-		SMTPConfigColumnInstanceID.identifier():  authz.GetInstance(ctx).InstanceID(),
+		SMTPConfigColumnID.identifier():         id,
+		SMTPConfigColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
 	}).ToSql()
 	if err != nil {
 		return nil, errors.ThrowInternal(err, "QUERY-3m9sl", "Errors.Query.SQLStatment")
@@ -116,10 +136,38 @@ func (q *Queries) SMTPConfigByAggregateID(ctx context.Context, aggregateID strin
 	return config, err
 }
 
+// SearchSMTPConfigs returns the SMTP configs of the instance in the order they were added
+func (q *Queries) SearchSMTPConfigs(ctx context.Context, queries *SMTPConfigsSearchQueries) (configs *SMTPConfigs, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareSMTPConfigsQuery(ctx, q.client)
+	stmt, args, err := queries.toQuery(query).
+		Where(sq.Eq{
+			SMTPConfigColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+		}).
+		OrderBy(SMTPConfigColumnCreationDate.identifier()).
+		ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Zq3cm", "Errors.Query.InvalidRequest")
+	}
+
+	err = q.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		configs, err = scan(rows)
+		return err
+	}, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ha6ex", "Errors.Internal")
+	}
+	configs.State, err = q.latestState(ctx, smtpConfigsTable)
+	return configs, err
+}
+
 func prepareSMTPConfigQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*SMTPConfig, error)) {
 	password := new(crypto.CryptoValue)
 
 	return sq.Select(
+			SMTPConfigColumnID.identifier(),
 			SMTPConfigColumnAggregateID.identifier(),
 			SMTPConfigColumnCreationDate.identifier(),
 			SMTPConfigColumnChangeDate.identifier(),
@@ -137,6 +185,7 @@ func prepareSMTPConfigQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 		func(row *sql.Row) (*SMTPConfig, error) {
 			config := new(SMTPConfig)
 			err := row.Scan(
+				&config.ID,
 				&config.AggregateID,
 				&config.CreationDate,
 				&config.ChangeDate,
@@ -160,3 +209,53 @@ func prepareSMTPConfigQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 			return config, nil
 		}
 }
+
+func prepareSMTPConfigsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*SMTPConfigs, error)) {
+	return sq.Select(
+			SMTPConfigColumnID.identifier(),
+			SMTPConfigColumnAggregateID.identifier(),
+			SMTPConfigColumnCreationDate.identifier(),
+			SMTPConfigColumnChangeDate.identifier(),
+			SMTPConfigColumnResourceOwner.identifier(),
+			SMTPConfigColumnSequence.identifier(),
+			SMTPConfigColumnTLS.identifier(),
+			SMTPConfigColumnSenderAddress.identifier(),
+			SMTPConfigColumnSenderName.identifier(),
+			SMTPConfigColumnReplyToAddress.identifier(),
+			SMTPConfigColumnSMTPHost.identifier(),
+			SMTPConfigColumnSMTPUser.identifier(),
+			SMTPConfigColumnSMTPPassword.identifier(),
+			countColumn.identifier(),
+		).
+			From(smtpConfigsTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*SMTPConfigs, error) {
+			configs := &SMTPConfigs{SMTPConfigs: []*SMTPConfig{}}
+			for rows.Next() {
+				config := new(SMTPConfig)
+				password := new(crypto.CryptoValue)
+				err := rows.Scan(
+					&config.ID,
+					&config.AggregateID,
+					&config.CreationDate,
+					&config.ChangeDate,
+					&config.ResourceOwner,
+					&config.Sequence,
+					&config.TLS,
+					&config.SenderAddress,
+					&config.SenderName,
+					&config.ReplyToAddress,
+					&config.Host,
+					&config.User,
+					&password,
+					&configs.Count,
+				)
+				if err != nil {
+					return nil, errors.ThrowInternal(err, "QUERY-Vd2tk", "Errors.Internal")
+				}
+				config.Password = password
+				configs.SMTPConfigs = append(configs.SMTPConfigs, config)
+			}
+			return configs, nil
+		}
+}
@@ -13,21 +13,23 @@ import (
 )
 
 var (
-	prepareSMTPConfigStmt = `SELECT projections.smtp_configs1.aggregate_id,` +
-		` projections.smtp_configs1.creation_date,` +
-		` projections.smtp_configs1.change_date,` +
-		` projections.smtp_configs1.resource_owner,` +
-		` projections.smtp_configs1.sequence,` +
-		` projections.smtp_configs1.tls,` +
-		` projections.smtp_configs1.sender_address,` +
-		` projections.smtp_configs1.sender_name,` +
-		` projections.smtp_configs1.reply_to_address,` +
-		` projections.smtp_configs1.host,` +
-		` projections.smtp_configs1.username,` +
-		` projections.smtp_configs1.password` +
-		` FROM projections.smtp_configs1` +
+	prepareSMTPConfigStmt = `SELECT projections.smtp_configs2.id,` +
+		` projections.smtp_configs2.aggregate_id,` +
+		` projections.smtp_configs2.creation_date,` +
+		` projections.smtp_configs2.change_date,` +
+		` projections.smtp_configs2.resource_owner,` +
+		` projections.smtp_configs2.sequence,` +
+		` projections.smtp_configs2.tls,` +
+		` projections.smtp_configs2.sender_address,` +
+		` projections.smtp_configs2.sender_name,` +
+		` projections.smtp_configs2.reply_to_address,` +
+		` projections.smtp_configs2.host,` +
+		` projections.smtp_configs2.username,` +
+		` projections.smtp_configs2.password` +
+		` FROM projections.smtp_configs2` +
 		` AS OF SYSTEM TIME '-1 ms'`
 	prepareSMTPConfigCols = []string{
+		"id",
 		"aggregate_id",
 		"creation_date",
 		"change_date",
@@ -41,6 +43,23 @@ var (
 		"smtp_user",
 		"smtp_password",
 	}
+	prepareSMTPConfigsStmt = `SELECT projections.smtp_configs2.id,` +
+		` projections.smtp_configs2.aggregate_id,` +
+		` projections.smtp_configs2.creation_date,` +
+		` projections.smtp_configs2.change_date,` +
+		` projections.smtp_configs2.resource_owner,` +
+		` projections.smtp_configs2.sequence,` +
+		` projections.smtp_configs2.tls,` +
+		` projections.smtp_configs2.sender_address,` +
+		` projections.smtp_configs2.sender_name,` +
+		` projections.smtp_configs2.reply_to_address,` +
+		` projections.smtp_configs2.host,` +
+		` projections.smtp_configs2.username,` +
+		` projections.smtp_configs2.password,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.smtp_configs2` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	prepareSMTPConfigsCols = append(prepareSMTPConfigCols, "count")
 )
 
 func Test_SMTPConfigsPrepares(t *testing.T) {
@@ -80,6 +99,7 @@ func Test_SMTPConfigsPrepares(t *testing.T) {
 					regexp.QuoteMeta(prepareSMTPConfigStmt),
 					prepareSMTPConfigCols,
 					[]driver.Value{
+						"config-id",
 						"agg-id",
 						testNow,
 						testNow,
@@ -96,6 +116,7 @@ func Test_SMTPConfigsPrepares(t *testing.T) {
 				),
 			},
 			object: &SMTPConfig{
+				ID:             "config-id",
 				AggregateID:    "agg-id",
 				CreationDate:   testNow,
 				ChangeDate:     testNow,
@@ -127,6 +148,114 @@ func Test_SMTPConfigsPrepares(t *testing.T) {
 			},
 			object: (*SMTPConfig)(nil),
 		},
+		{
+			name:    "prepareSMTPConfigsQuery no result",
+			prepare: prepareSMTPConfigsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareSMTPConfigsStmt),
+					nil,
+					nil,
+				),
+			},
+			object: &SMTPConfigs{SMTPConfigs: []*SMTPConfig{}},
+		},
+		{
+			name:    "prepareSMTPConfigsQuery multiple result",
+			prepare: prepareSMTPConfigsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareSMTPConfigsStmt),
+					prepareSMTPConfigsCols,
+					[][]driver.Value{
+						{
+							"agg-id",
+							"agg-id",
+							testNow,
+							testNow,
+							"ro",
+							uint64(20211108),
+							true,
+							"sender",
+							"name",
+							"reply-to",
+							"host",
+							"user",
+							&crypto.CryptoValue{},
+						},
+						{
+							"config-id",
+							"agg-id",
+							testNow,
+							testNow,
+							"ro",
+							uint64(20211109),
+							false,
+							"sender2",
+							"name2",
+							"",
+							"host2",
+							"user2",
+							&crypto.CryptoValue{},
+						},
+					},
+				),
+			},
+			object: &SMTPConfigs{
+				SearchResponse: SearchResponse{
+					Count: 2,
+				},
+				SMTPConfigs: []*SMTPConfig{
+					{
+						ID:             "agg-id",
+						AggregateID:    "agg-id",
+						CreationDate:   testNow,
+						ChangeDate:     testNow,
+						ResourceOwner:  "ro",
+						Sequence:       20211108,
+						TLS:            true,
+						SenderAddress:  "sender",
+						SenderName:     "name",
+						ReplyToAddress: "reply-to",
+						Host:           "host",
+						User:           "user",
+						Password:       &crypto.CryptoValue{},
+					},
+					{
+						ID:             "config-id",
+						AggregateID:    "agg-id",
+						CreationDate:   testNow,
+						ChangeDate:     testNow,
+						ResourceOwner:  "ro",
+						Sequence:       20211109,
+						TLS:            false,
+						SenderAddress:  "sender2",
+						SenderName:     "name2",
+						ReplyToAddress: "",
+						Host:           "host2",
+						User:           "user2",
+						Password:       &crypto.CryptoValue{},
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareSMTPConfigsQuery sql err",
+			prepare: prepareSMTPConfigsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareSMTPConfigsStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*SMTPConfigs)(nil),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,150 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+var (
+	targetsTable = table{
+		name:          projection.TargetProjectionTable,
+		instanceIDCol: projection.TargetColumnInstanceID,
+	}
+	TargetColumnID = Column{
+		name:  projection.TargetColumnID,
+		table: targetsTable,
+	}
+	TargetColumnCreationDate = Column{
+		name:  projection.TargetColumnCreationDate,
+		table: targetsTable,
+	}
+	TargetColumnChangeDate = Column{
+		name:  projection.TargetColumnChangeDate,
+		table: targetsTable,
+	}
+	TargetColumnInstanceID = Column{
+		name:  projection.TargetColumnInstanceID,
+		table: targetsTable,
+	}
+	TargetColumnSequence = Column{
+		name:  projection.TargetColumnSequence,
+		table: targetsTable,
+	}
+	TargetColumnName = Column{
+		name:  projection.TargetColumnName,
+		table: targetsTable,
+	}
+	TargetColumnEndpoint = Column{
+		name:  projection.TargetColumnEndpoint,
+		table: targetsTable,
+	}
+	TargetColumnTimeout = Column{
+		name:  projection.TargetColumnTimeout,
+		table: targetsTable,
+	}
+	TargetColumnInterruptOnError = Column{
+		name:  projection.TargetColumnInterruptOnError,
+		table: targetsTable,
+	}
+	TargetColumnSigningKey = Column{
+		name:  projection.TargetColumnSigningKey,
+		table: targetsTable,
+	}
+)
+
+type Targets struct {
+	SearchResponse
+	Targets []*Target
+}
+
+type Target struct {
+	ID           string
+	CreationDate time.Time
+	ChangeDate   time.Time
+	InstanceID   string
+	Sequence     uint64
+
+	Name             string
+	Endpoint         string
+	Timeout          time.Duration
+	InterruptOnError bool
+	SigningKey       *crypto.CryptoValue
+}
+
+// Targets returns all targets of the instance
+func (q *Queries) Targets(ctx context.Context) (targets *Targets, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareTargetsQuery(ctx, q.client)
+	stmt, args, err := query.Where(sq.Eq{
+		TargetColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).OrderBy(TargetColumnCreationDate.identifier()).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-ooG1a", "Errors.Query.InvalidRequest")
+	}
+
+	err = q.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		targets, err = scan(rows)
+		return err
+	}, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	targets.State, err = q.latestState(ctx, targetsTable)
+	return targets, err
+}
+
+func prepareTargetsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*Targets, error)) {
+	return sq.Select(
+			TargetColumnID.identifier(),
+			TargetColumnCreationDate.identifier(),
+			TargetColumnChangeDate.identifier(),
+			TargetColumnInstanceID.identifier(),
+			TargetColumnSequence.identifier(),
+			TargetColumnName.identifier(),
+			TargetColumnEndpoint.identifier(),
+			TargetColumnTimeout.identifier(),
+			TargetColumnInterruptOnError.identifier(),
+			TargetColumnSigningKey.identifier(),
+			countColumn.identifier(),
+		).From(targetsTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*Targets, error) {
+			targets := &Targets{Targets: []*Target{}}
+			for rows.Next() {
+				target := new(Target)
+				err := rows.Scan(
+					&target.ID,
+					&target.CreationDate,
+					&target.ChangeDate,
+					&target.InstanceID,
+					&target.Sequence,
+					&target.Name,
+					&target.Endpoint,
+					&target.Timeout,
+					&target.InterruptOnError,
+					&target.SigningKey,
+					&targets.Count,
+				)
+				if err != nil {
+					return nil, errors.ThrowInternal(err, "QUERY-Iej3e", "Errors.Internal")
+				}
+				targets.Targets = append(targets.Targets, target)
+			}
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Eew5a", "Errors.Query.CloseRows")
+			}
+			return targets, nil
+		}
+}
@@ -0,0 +1,157 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+)
+
+var (
+	expectedTargetsQuery = regexp.QuoteMeta(`SELECT projections.targets.id,` +
+		` projections.targets.creation_date,` +
+		` projections.targets.change_date,` +
+		` projections.targets.instance_id,` +
+		` projections.targets.sequence,` +
+		` projections.targets.name,` +
+		` projections.targets.endpoint,` +
+		` projections.targets.timeout,` +
+		` projections.targets.interrupt_on_error,` +
+		` projections.targets.signing_key,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.targets` +
+		` AS OF SYSTEM TIME '-1 ms'`)
+
+	targetsCols = []string{
+		"id",
+		"creation_date",
+		"change_date",
+		"instance_id",
+		"sequence",
+		"name",
+		"endpoint",
+		"timeout",
+		"interrupt_on_error",
+		"signing_key",
+		"count",
+	}
+)
+
+func Test_TargetsPrepare(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareTargetsQuery no result",
+			prepare: prepareTargetsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					expectedTargetsQuery,
+					nil,
+					nil,
+				),
+			},
+			object: &Targets{Targets: []*Target{}},
+		},
+		{
+			name:    "prepareTargetsQuery multiple result",
+			prepare: prepareTargetsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					expectedTargetsQuery,
+					targetsCols,
+					[][]driver.Value{
+						{
+							"target-1",
+							testNow,
+							testNow,
+							"instance-id",
+							uint64(20211109),
+							"target",
+							"https://example.com",
+							int64(time.Second),
+							true,
+							&crypto.CryptoValue{},
+						},
+						{
+							"target-2",
+							testNow,
+							testNow,
+							"instance-id",
+							uint64(20211110),
+							"other",
+							"https://other.example.com",
+							int64(5 * time.Second),
+							false,
+							&crypto.CryptoValue{},
+						},
+					},
+				),
+			},
+			object: &Targets{
+				SearchResponse: SearchResponse{
+					Count: 2,
+				},
+				Targets: []*Target{
+					{
+						ID:               "target-1",
+						CreationDate:     testNow,
+						ChangeDate:       testNow,
+						InstanceID:       "instance-id",
+						Sequence:         20211109,
+						Name:             "target",
+						Endpoint:         "https://example.com",
+						Timeout:          time.Second,
+						InterruptOnError: true,
+						SigningKey:       &crypto.CryptoValue{},
+					},
+					{
+						ID:           "target-2",
+						CreationDate: testNow,
+						ChangeDate:   testNow,
+						InstanceID:   "instance-id",
+						Sequence:     20211110,
+						Name:         "other",
+						Endpoint:     "https://other.example.com",
+						Timeout:      5 * time.Second,
+						SigningKey:   &crypto.CryptoValue{},
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareTargetsQuery sql err",
+			prepare: prepareTargetsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					expectedTargetsQuery,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*Targets)(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
@@ -52,6 +52,7 @@ type Human struct {
 	IsEmailVerified   bool                `json:"is_email_verified,omitempty"`
 	Phone             domain.PhoneNumber  `json:"phone,omitempty"`
 	IsPhoneVerified   bool                `json:"is_phone_verified,omitempty"`
+	PasswordChanged   time.Time           `json:"password_changed,omitempty"`
 }
 
 type Profile struct {
@@ -251,6 +252,14 @@ var (
 		name:  projection.HumanIsPhoneVerifiedCol,
 		table: humanTable,
 	}
+	HumanPasswordChangedCol = Column{
+		name:  projection.HumanPasswordChangedCol,
+		table: humanTable,
+	}
+	HumanPasswordExpiryWarnedCol = Column{
+		name:  projection.HumanPasswordExpiryWarnedCol,
+		table: humanTable,
+	}
 )
 
 var (
@@ -738,6 +747,7 @@ func prepareUserQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder
 			HumanIsEmailVerifiedCol.identifier(),
 			HumanPhoneCol.identifier(),
 			HumanIsPhoneVerifiedCol.identifier(),
+			HumanPasswordChangedCol.identifier(),
 			MachineUserIDCol.identifier(),
 			MachineNameCol.identifier(),
 			MachineDescriptionCol.identifier(),
@@ -774,6 +784,7 @@ func prepareUserQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder
 			isEmailVerified := sql.NullBool{}
 			phone := sql.NullString{}
 			isPhoneVerified := sql.NullBool{}
+			passwordChanged := sql.NullTime{}
 
 			machineID := sql.NullString{}
 			name := sql.NullString{}
@@ -804,6 +815,7 @@ func prepareUserQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder
 				&isEmailVerified,
 				&phone,
 				&isPhoneVerified,
+				&passwordChanged,
 				&machineID,
 				&name,
 				&description,
@@ -834,6 +846,7 @@ func prepareUserQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder
 					IsEmailVerified:   isEmailVerified.Bool,
 					Phone:             domain.PhoneNumber(phone.String),
 					IsPhoneVerified:   isPhoneVerified.Bool,
+					PasswordChanged:   passwordChanged.Time,
 				}
 			} else if machineID.Valid {
 				u.Machine = &Machine{
@@ -1208,6 +1221,7 @@ func prepareUsersQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilde
 			HumanIsEmailVerifiedCol.identifier(),
 			HumanPhoneCol.identifier(),
 			HumanIsPhoneVerifiedCol.identifier(),
+			HumanPasswordChangedCol.identifier(),
 			MachineUserIDCol.identifier(),
 			MachineNameCol.identifier(),
 			MachineDescriptionCol.identifier(),
@@ -1246,6 +1260,7 @@ func prepareUsersQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilde
 				isEmailVerified := sql.NullBool{}
 				phone := sql.NullString{}
 				isPhoneVerified := sql.NullBool{}
+				passwordChanged := sql.NullTime{}
 
 				machineID := sql.NullString{}
 				name := sql.NullString{}
@@ -1276,6 +1291,7 @@ func prepareUsersQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilde
 					&isEmailVerified,
 					&phone,
 					&isPhoneVerified,
+					&passwordChanged,
 					&machineID,
 					&name,
 					&description,
@@ -1305,6 +1321,7 @@ func prepareUsersQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilde
 						IsEmailVerified:   isEmailVerified.Bool,
 						Phone:             domain.PhoneNumber(phone.String),
 						IsPhoneVerified:   isPhoneVerified.Bool,
+						PasswordChanged:   passwordChanged.Time,
 					}
 				} else if machineID.Valid {
 					u.Machine = &Machine{
@@ -0,0 +1,116 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+const (
+	passwordExpiryOrgPolicyTableAlias     = "org_policy"
+	passwordExpiryDefaultPolicyTableAlias = "default_policy"
+)
+
+var (
+	passwordExpiryOrgPolicyTable     = passwordAgeTable.setAlias(passwordExpiryOrgPolicyTableAlias)
+	passwordExpiryDefaultPolicyTable = passwordAgeTable.setAlias(passwordExpiryDefaultPolicyTableAlias)
+
+	passwordExpiryMaxAgeDays = "COALESCE(" +
+		PasswordAgeColMaxAge.setTable(passwordExpiryOrgPolicyTable).identifier() + ", " +
+		PasswordAgeColMaxAge.setTable(passwordExpiryDefaultPolicyTable).identifier() + ", 0)"
+	passwordExpiryWarnDays = "COALESCE(" +
+		PasswordAgeColWarnDays.setTable(passwordExpiryOrgPolicyTable).identifier() + ", " +
+		PasswordAgeColWarnDays.setTable(passwordExpiryDefaultPolicyTable).identifier() + ", 0)"
+)
+
+type PasswordExpiryWarnings struct {
+	PasswordExpiryWarnings []*PasswordExpiryWarning
+}
+
+// PasswordExpiryWarning is a user whose password will expire within the warning period of the password age policy
+type PasswordExpiryWarning struct {
+	UserID        string
+	ResourceOwner string
+	ExpiryDate    time.Time
+}
+
+// DuePasswordExpiryWarnings returns the users of the instance whose password will expire within the warning period
+// of the password age policy at now and who weren't warned about their current password yet.
+func (q *Queries) DuePasswordExpiryWarnings(ctx context.Context, now time.Time, limit uint64) (warnings *PasswordExpiryWarnings, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := preparePasswordExpiryWarningsQuery(ctx, q.client)
+	stmt, args, err := query.
+		Where(sq.Eq{
+			UserInstanceIDCol.identifier(): authz.GetInstance(ctx).InstanceID(),
+			UserStateCol.identifier():      domain.UserStateActive,
+		}).
+		Where(sq.NotEq{HumanPasswordChangedCol.identifier(): nil}).
+		Where(sq.Or{
+			sq.Eq{HumanPasswordExpiryWarnedCol.identifier(): nil},
+			sq.Expr(HumanPasswordExpiryWarnedCol.identifier() + " < " + HumanPasswordChangedCol.identifier()),
+		}).
+		Where(sq.Expr(passwordExpiryMaxAgeDays + " > 0")).
+		Where(sq.Expr(passwordExpiryWarnDays + " > 0")).
+		Where(sq.Expr(HumanPasswordChangedCol.identifier()+" + "+passwordExpiryMaxAgeDays+" * INTERVAL '1 day' > ?", now)).
+		Where(sq.Expr(HumanPasswordChangedCol.identifier()+" + ("+passwordExpiryMaxAgeDays+" - "+passwordExpiryWarnDays+") * INTERVAL '1 day' <= ?", now)).
+		OrderBy(HumanPasswordChangedCol.identifier()).
+		Limit(limit).
+		ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-uGh4a", "Errors.Query.InvalidRequest")
+	}
+
+	err = q.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		warnings, err = scan(rows)
+		return err
+	}, stmt, args...)
+	return warnings, err
+}
+
+func preparePasswordExpiryWarningsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*PasswordExpiryWarnings, error)) {
+	return sq.Select(
+			UserIDCol.identifier(),
+			UserResourceOwnerCol.identifier(),
+			HumanPasswordChangedCol.identifier(),
+			passwordExpiryMaxAgeDays,
+		).From(humanTable.identifier()).
+			Join(join(UserIDCol, HumanUserIDCol)).
+			LeftJoin(join(PasswordAgeColID.setTable(passwordExpiryOrgPolicyTable), UserResourceOwnerCol)).
+			LeftJoin(join(PasswordAgeColID.setTable(passwordExpiryDefaultPolicyTable), UserInstanceIDCol) + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*PasswordExpiryWarnings, error) {
+			warnings := &PasswordExpiryWarnings{PasswordExpiryWarnings: []*PasswordExpiryWarning{}}
+			for rows.Next() {
+				warning := new(PasswordExpiryWarning)
+				var (
+					passwordChanged time.Time
+					maxAgeDays      uint64
+				)
+				err := rows.Scan(
+					&warning.UserID,
+					&warning.ResourceOwner,
+					&passwordChanged,
+					&maxAgeDays,
+				)
+				if err != nil {
+					return nil, errors.ThrowInternal(err, "QUERY-Iej7a", "Errors.Internal")
+				}
+				warning.ExpiryDate = (&domain.PasswordAgePolicy{MaxAgeDays: maxAgeDays}).PasswordExpiryDate(passwordChanged)
+				warnings.PasswordExpiryWarnings = append(warnings.PasswordExpiryWarnings, warning)
+			}
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-ohB4e", "Errors.Query.CloseRows")
+			}
+			return warnings, nil
+		}
+}
@@ -0,0 +1,116 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+)
+
+var (
+	expectedPasswordExpiryWarningsQuery = regexp.QuoteMeta(`SELECT projections.users11.id,` +
+		` projections.users11.resource_owner,` +
+		` projections.users11_humans.password_changed,` +
+		` COALESCE(org_policy.max_age_days, default_policy.max_age_days, 0)` +
+		` FROM projections.users11_humans` +
+		` JOIN projections.users11 ON projections.users11_humans.user_id = projections.users11.id AND projections.users11_humans.instance_id = projections.users11.instance_id` +
+		` LEFT JOIN projections.password_age_policies2 AS org_policy ON projections.users11.resource_owner = org_policy.id AND projections.users11.instance_id = org_policy.instance_id` +
+		` LEFT JOIN projections.password_age_policies2 AS default_policy ON projections.users11.instance_id = default_policy.id AND projections.users11.instance_id = default_policy.instance_id` +
+		` AS OF SYSTEM TIME '-1 ms'`)
+
+	passwordExpiryWarningsCols = []string{
+		"id",
+		"resource_owner",
+		"password_changed",
+		"max_age_days",
+	}
+)
+
+func Test_PasswordExpiryWarningsPrepare(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "preparePasswordExpiryWarningsQuery no result",
+			prepare: preparePasswordExpiryWarningsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					expectedPasswordExpiryWarningsQuery,
+					nil,
+					nil,
+				),
+			},
+			object: &PasswordExpiryWarnings{PasswordExpiryWarnings: []*PasswordExpiryWarning{}},
+		},
+		{
+			name:    "preparePasswordExpiryWarningsQuery multiple result",
+			prepare: preparePasswordExpiryWarningsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					expectedPasswordExpiryWarningsQuery,
+					passwordExpiryWarningsCols,
+					[][]driver.Value{
+						{
+							"user-id",
+							"org-id",
+							testNow,
+							uint64(30),
+						},
+						{
+							"user-id2",
+							"org-id2",
+							testNow,
+							uint64(90),
+						},
+					},
+				),
+			},
+			object: &PasswordExpiryWarnings{
+				PasswordExpiryWarnings: []*PasswordExpiryWarning{
+					{
+						UserID:        "user-id",
+						ResourceOwner: "org-id",
+						ExpiryDate:    testNow.Add(30 * 24 * time.Hour),
+					},
+					{
+						UserID:        "user-id2",
+						ResourceOwner: "org-id2",
+						ExpiryDate:    testNow.Add(90 * 24 * time.Hour),
+					},
+				},
+			},
+		},
+		{
+			name:    "preparePasswordExpiryWarningsQuery sql err",
+			prepare: preparePasswordExpiryWarningsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					expectedPasswordExpiryWarningsQuery,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*PasswordExpiryWarnings)(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package query
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// GetHumanRecoveryCodesRemaining returns the amount of recovery codes of the user which are not used yet
+func (q *Queries) GetHumanRecoveryCodesRemaining(ctx context.Context, userID, resourceOwner string) (_ int, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" {
+		return 0, errors.ThrowPreconditionFailed(nil, "QUERY-Rc0dS", "Errors.User.UserIDMissing")
+	}
+	readModel := NewHumanRecoveryCodesReadModel(userID, resourceOwner)
+	if err = q.eventstore.FilterToQueryReducer(ctx, readModel); err != nil {
+		return 0, err
+	}
+	if readModel.State != domain.MFAStateReady {
+		return 0, nil
+	}
+	return readModel.Remaining, nil
+}
+
+type HumanRecoveryCodesReadModel struct {
+	*eventstore.ReadModel
+
+	State     domain.MFAState
+	Remaining int
+}
+
+func (rm *HumanRecoveryCodesReadModel) AppendEvents(events ...eventstore.Event) {
+	rm.ReadModel.AppendEvents(events...)
+}
+
+func NewHumanRecoveryCodesReadModel(userID, resourceOwner string) *HumanRecoveryCodesReadModel {
+	return &HumanRecoveryCodesReadModel{
+		ReadModel: &eventstore.ReadModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (rm *HumanRecoveryCodesReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch e := event.(type) {
+		case *user.HumanRecoveryCodesAddedEvent:
+			rm.State = domain.MFAStateReady
+			rm.Remaining = len(e.EncodedHashes)
+		case *user.HumanRecoveryCodeCheckSucceededEvent:
+			if rm.Remaining > 0 {
+				rm.Remaining--
+			}
+		case *user.HumanRecoveryCodesRemovedEvent, *user.UserRemovedEvent:
+			rm.State = domain.MFAStateRemoved
+			rm.Remaining = 0
+		}
+	}
+	return rm.ReadModel.Reduce()
+}
+
+func (rm *HumanRecoveryCodesReadModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AwaitOpenTransactions().
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(rm.AggregateID).
+		EventTypes(user.HumanRecoveryCodesAddedType,
+			user.HumanRecoveryCodeCheckSucceededType,
+			user.HumanRecoveryCodesRemovedType,
+			user.UserRemovedType).
+		Builder()
+
+	if rm.ResourceOwner != "" {
+		query.ResourceOwner(rm.ResourceOwner)
+	}
+	return query
+}
@@ -89,7 +89,16 @@ type ChangedEvent struct {
 	Script        *string        `json:"script,omitempty"`
 	Timeout       *time.Duration `json:"timeout,omitempty"`
 	AllowedToFail *bool          `json:"allowedToFail,omitempty"`
-	oldName       string
+	// CanaryPercentage is set if the change creates a version which is staged as canary,
+	// the current version is kept and the event contains the whole content of the new version
+	CanaryPercentage *uint32 `json:"canaryPercentage,omitempty"`
+	oldName          string
+}
+
+// CreatesVersion returns if the change creates a new version of the action,
+// which is the case if the script, the timeout or allowed to fail is changed
+func (e *ChangedEvent) CreatesVersion() bool {
+	return e.Script != nil || e.Timeout != nil || e.AllowedToFail != nil
 }
 
 func (e *ChangedEvent) Payload() interface{} {
@@ -154,6 +163,17 @@ func ChangeAllowedToFail(allowedToFail bool) func(event *ChangedEvent) {
 	}
 }
 
+// ChangeCanary stages the new version as canary,
+// the content of the version must be passed completely
+func ChangeCanary(percentage uint32, script string, timeout time.Duration, allowedToFail bool) func(event *ChangedEvent) {
+	return func(e *ChangedEvent) {
+		e.CanaryPercentage = &percentage
+		e.Script = &script
+		e.Timeout = &timeout
+		e.AllowedToFail = &allowedToFail
+	}
+}
+
 func ChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
 	e := &ChangedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
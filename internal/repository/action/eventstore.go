@@ -7,5 +7,7 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, ChangedEventType, ChangedEventMapper).
 		RegisterFilterEventMapper(AggregateType, DeactivatedEventType, DeactivatedEventMapper).
 		RegisterFilterEventMapper(AggregateType, ReactivatedEventType, ReactivatedEventMapper).
-		RegisterFilterEventMapper(AggregateType, RemovedEventType, RemovedEventMapper)
+		RegisterFilterEventMapper(AggregateType, RemovedEventType, RemovedEventMapper).
+		RegisterFilterEventMapper(AggregateType, VersionActivatedEventType, VersionActivatedEventMapper).
+		RegisterFilterEventMapper(AggregateType, CanaryRemovedEventType, CanaryRemovedEventMapper)
 }
@@ -0,0 +1,97 @@
+package action
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	VersionActivatedEventType = eventTypePrefix + "version.activated"
+	CanaryRemovedEventType    = eventTypePrefix + "canary.removed"
+)
+
+// VersionActivatedEvent sets a previous version as current version (rollback)
+// or promotes the canary, a running canary is ended in both cases.
+// It contains the content of the version, so the projections don't need to know the previous events.
+type VersionActivatedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Version       uint64        `json:"version"`
+	Script        string        `json:"script,omitempty"`
+	Timeout       time.Duration `json:"timeout,omitempty"`
+	AllowedToFail bool          `json:"allowedToFail"`
+}
+
+func (e *VersionActivatedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *VersionActivatedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewVersionActivatedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	version uint64,
+	script string,
+	timeout time.Duration,
+	allowedToFail bool,
+) *VersionActivatedEvent {
+	return &VersionActivatedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			VersionActivatedEventType,
+		),
+		Version:       version,
+		Script:        script,
+		Timeout:       timeout,
+		AllowedToFail: allowedToFail,
+	}
+}
+
+func VersionActivatedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &VersionActivatedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "ACTION-Sfe2r", "unable to unmarshal action version activated")
+	}
+
+	return e, nil
+}
+
+// CanaryRemovedEvent ends a running canary without changing the current version
+type CanaryRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *CanaryRemovedEvent) Payload() interface{} {
+	return nil
+}
+
+func (e *CanaryRemovedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewCanaryRemovedEvent(ctx context.Context, aggregate *eventstore.Aggregate) *CanaryRemovedEvent {
+	return &CanaryRemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			CanaryRemovedEventType,
+		),
+	}
+}
+
+func CanaryRemovedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	return &CanaryRemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
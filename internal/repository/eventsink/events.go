@@ -0,0 +1,102 @@
+package eventsink
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	eventTypePrefix  = eventstore.EventType("event_sink.")
+	SetEventType     = eventTypePrefix + "set"
+	RemovedEventType = eventTypePrefix + "removed"
+)
+
+// SetEvent describes that a sink is added or replaced and always contains the whole configuration
+type SetEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	Name     string               `json:"name"`
+	SinkType domain.EventSinkType `json:"sinkType"`
+	Endpoint string               `json:"endpoint"`
+	// Target is the NATS subject or the Kafka topic
+	Target         string                     `json:"target,omitempty"`
+	AggregateTypes []eventstore.AggregateType `json:"aggregateTypes,omitempty"`
+	EventTypes     []eventstore.EventType     `json:"eventTypes,omitempty"`
+	// SigningKey is used to sign the payloads of HTTP sinks
+	SigningKey *crypto.CryptoValue `json:"signingKey,omitempty"`
+}
+
+func (e *SetEvent) Payload() any {
+	return e
+}
+
+func (e *SetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *SetEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = b
+}
+
+func NewSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	name string,
+	sinkType domain.EventSinkType,
+	endpoint,
+	target string,
+	aggregateTypes []eventstore.AggregateType,
+	eventTypes []eventstore.EventType,
+	signingKey *crypto.CryptoValue,
+) *SetEvent {
+	return &SetEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SetEventType,
+		),
+		Name:           name,
+		SinkType:       sinkType,
+		Endpoint:       endpoint,
+		Target:         target,
+		AggregateTypes: aggregateTypes,
+		EventTypes:     eventTypes,
+		SigningKey:     signingKey,
+	}
+}
+
+var SetEventMapper = eventstore.GenericEventMapper[SetEvent]
+
+type RemovedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+}
+
+func (e *RemovedEvent) Payload() any {
+	return e
+}
+
+func (e *RemovedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *RemovedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = b
+}
+
+func NewRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+) *RemovedEvent {
+	return &RemovedEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			RemovedEventType,
+		),
+	}
+}
+
+var RemovedEventMapper = eventstore.GenericEventMapper[RemovedEvent]
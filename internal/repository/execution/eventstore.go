@@ -0,0 +1,10 @@
+package execution
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+func RegisterEventMappers(es *eventstore.Eventstore) {
+	es.RegisterFilterEventMapper(AggregateType, SetEventType, SetEventMapper).
+		RegisterFilterEventMapper(AggregateType, RemovedEventType, RemovedEventMapper)
+}
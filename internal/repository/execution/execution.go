@@ -0,0 +1,82 @@
+package execution
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	eventTypePrefix  = eventstore.EventType("execution.")
+	SetEventType     = eventTypePrefix + "set"
+	RemovedEventType = eventTypePrefix + "removed"
+)
+
+// SetEvent describes that the targets of the execution are set,
+// the id of the aggregate is the id of the execution (see [domain.ExecutionID])
+type SetEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	// Targets are the ids of the targets called in order
+	Targets []string `json:"targets"`
+}
+
+func (e *SetEvent) Payload() any {
+	return e
+}
+
+func (e *SetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *SetEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = b
+}
+
+func NewSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	targets []string,
+) *SetEvent {
+	return &SetEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SetEventType,
+		),
+		Targets: targets,
+	}
+}
+
+var SetEventMapper = eventstore.GenericEventMapper[SetEvent]
+
+type RemovedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+}
+
+func (e *RemovedEvent) Payload() any {
+	return e
+}
+
+func (e *RemovedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *RemovedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = b
+}
+
+func NewRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+) *RemovedEvent {
+	return &RemovedEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			RemovedEventType,
+		),
+	}
+}
+
+var RemovedEventMapper = eventstore.GenericEventMapper[RemovedEvent]
@@ -20,6 +20,9 @@ type TriggerActionsSetEvent struct {
 	FlowType    domain.FlowType    `json:"flowType"`
 	TriggerType domain.TriggerType `json:"triggerType"`
 	ActionIDs   []string           `json:"actionIDs"`
+	// ActionVersions pins actions of the trigger to a version by their id,
+	// the current version is executed for the actions not contained
+	ActionVersions map[string]uint64 `json:"actionVersions,omitempty"`
 }
 
 func (e *TriggerActionsSetEvent) Payload() interface{} {
@@ -35,12 +38,14 @@ func NewTriggerActionsSetEvent(
 	flowType domain.FlowType,
 	triggerType domain.TriggerType,
 	actionIDs []string,
+	actionVersions map[string]uint64,
 ) *TriggerActionsSetEvent {
 	return &TriggerActionsSetEvent{
-		BaseEvent:   *base,
-		FlowType:    flowType,
-		TriggerType: triggerType,
-		ActionIDs:   actionIDs,
+		BaseEvent:      *base,
+		FlowType:       flowType,
+		TriggerType:    triggerType,
+		ActionIDs:      actionIDs,
+		ActionVersions: actionVersions,
 	}
 }
 
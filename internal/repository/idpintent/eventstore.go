@@ -10,5 +10,6 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, SAMLSucceededEventType, SAMLSucceededEventMapper).
 		RegisterFilterEventMapper(AggregateType, SAMLRequestEventType, SAMLRequestEventMapper).
 		RegisterFilterEventMapper(AggregateType, LDAPSucceededEventType, LDAPSucceededEventMapper).
-		RegisterFilterEventMapper(AggregateType, FailedEventType, FailedEventMapper)
+		RegisterFilterEventMapper(AggregateType, FailedEventType, FailedEventMapper).
+		RegisterFilterEventMapper(AggregateType, ActionsRunEventType, ActionsRunEventMapper)
 }
@@ -16,6 +16,7 @@ const (
 	SAMLRequestEventType   = instanceEventTypePrefix + "saml.requested"
 	LDAPSucceededEventType = instanceEventTypePrefix + "ldap.succeeded"
 	FailedEventType        = instanceEventTypePrefix + "failed"
+	ActionsRunEventType    = instanceEventTypePrefix + "actions.run"
 )
 
 type StartedEvent struct {
@@ -317,3 +318,55 @@ func FailedEventMapper(event eventstore.Event) (eventstore.Event, error) {
 
 	return e, nil
 }
+
+// ActionsRunEvent stores the result of the post authentication actions,
+// which are run once per intent
+type ActionsRunEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	IDPUserName string            `json:"idpUserName,omitempty"`
+	Metadata    []*ActionMetadata `json:"metadata,omitempty"`
+}
+
+type ActionMetadata struct {
+	Key   string `json:"key"`
+	Value []byte `json:"value"`
+}
+
+func NewActionsRunEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	idpUserName string,
+	metadata []*ActionMetadata,
+) *ActionsRunEvent {
+	return &ActionsRunEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ActionsRunEventType,
+		),
+		IDPUserName: idpUserName,
+		Metadata:    metadata,
+	}
+}
+
+func (e *ActionsRunEvent) Payload() interface{} {
+	return e
+}
+
+func (e *ActionsRunEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func ActionsRunEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &ActionsRunEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "IDP-Ohsh3", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
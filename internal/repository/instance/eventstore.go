@@ -16,9 +16,13 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, SMTPConfigChangedEventType, SMTPConfigChangedEventMapper).
 		RegisterFilterEventMapper(AggregateType, SMTPConfigPasswordChangedEventType, SMTPConfigPasswordChangedEventMapper).
 		RegisterFilterEventMapper(AggregateType, SMTPConfigRemovedEventType, SMTPConfigRemovedEventMapper).
+		RegisterFilterEventMapper(AggregateType, NotificationProviderOrderSetEventType, NotificationProviderOrderSetEventMapper).
 		RegisterFilterEventMapper(AggregateType, SMSConfigTwilioAddedEventType, SMSConfigTwilioAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, SMSConfigTwilioChangedEventType, SMSConfigTwilioChangedEventMapper).
 		RegisterFilterEventMapper(AggregateType, SMSConfigTwilioTokenChangedEventType, SMSConfigTwilioTokenChangedEventMapper).
+		RegisterFilterEventMapper(AggregateType, SMSConfigHTTPAddedEventType, SMSConfigHTTPAddedEventMapper).
+		RegisterFilterEventMapper(AggregateType, SMSConfigHTTPChangedEventType, SMSConfigHTTPChangedEventMapper).
+		RegisterFilterEventMapper(AggregateType, SMSConfigHTTPSecretChangedEventType, SMSConfigHTTPSecretChangedEventMapper).
 		RegisterFilterEventMapper(AggregateType, SMSConfigActivatedEventType, SMSConfigActivatedEventMapper).
 		RegisterFilterEventMapper(AggregateType, SMSConfigDeactivatedEventType, SMSConfigDeactivatedEventMapper).
 		RegisterFilterEventMapper(AggregateType, SMSConfigRemovedEventType, SMSConfigRemovedEventMapper).
@@ -0,0 +1,45 @@
+package instance
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/settings"
+)
+
+var (
+	NotificationProviderOrderSetEventType = instanceEventTypePrefix + settings.NotificationProviderOrderPrefix + settings.NotificationProviderOrderSet
+)
+
+type NotificationProviderOrderSetEvent struct {
+	settings.NotificationProviderOrderSetEvent
+}
+
+func NewNotificationProviderOrderSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	channel domain.NotificationType,
+	providerIDs []string,
+) *NotificationProviderOrderSetEvent {
+	return &NotificationProviderOrderSetEvent{
+		NotificationProviderOrderSetEvent: *settings.NewNotificationProviderOrderSetEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				NotificationProviderOrderSetEventType),
+			channel,
+			providerIDs,
+			nil,
+		),
+	}
+}
+
+func NotificationProviderOrderSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := settings.NotificationProviderOrderSetEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NotificationProviderOrderSetEvent{NotificationProviderOrderSetEvent: *e.(*settings.NotificationProviderOrderSetEvent)}, nil
+}
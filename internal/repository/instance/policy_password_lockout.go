@@ -2,6 +2,7 @@ package instance
 
 import (
 	"context"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/repository/policy"
@@ -21,6 +22,9 @@ func NewLockoutPolicyAddedEvent(
 	aggregate *eventstore.Aggregate,
 	maxAttempts uint64,
 	showLockoutFailure bool,
+	maxOTPAttempts uint64,
+	autoUnlockAfter time.Duration,
+	failedAttemptDelay time.Duration,
 ) *LockoutPolicyAddedEvent {
 	return &LockoutPolicyAddedEvent{
 		LockoutPolicyAddedEvent: *policy.NewLockoutPolicyAddedEvent(
@@ -29,7 +33,10 @@ func NewLockoutPolicyAddedEvent(
 				aggregate,
 				LockoutPolicyAddedEventType),
 			maxAttempts,
-			showLockoutFailure),
+			showLockoutFailure,
+			maxOTPAttempts,
+			autoUnlockAfter,
+			failedAttemptDelay),
 	}
 }
 
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
 )
@@ -14,6 +15,10 @@ const (
 	SMSConfigTwilioAddedEventType        = instanceEventTypePrefix + smsConfigPrefix + smsConfigTwilioPrefix + "added"
 	SMSConfigTwilioChangedEventType      = instanceEventTypePrefix + smsConfigPrefix + smsConfigTwilioPrefix + "changed"
 	SMSConfigTwilioTokenChangedEventType = instanceEventTypePrefix + smsConfigPrefix + smsConfigTwilioPrefix + "token.changed"
+	smsConfigHTTPPrefix                  = "http."
+	SMSConfigHTTPAddedEventType          = instanceEventTypePrefix + smsConfigPrefix + smsConfigHTTPPrefix + "added"
+	SMSConfigHTTPChangedEventType        = instanceEventTypePrefix + smsConfigPrefix + smsConfigHTTPPrefix + "changed"
+	SMSConfigHTTPSecretChangedEventType  = instanceEventTypePrefix + smsConfigPrefix + smsConfigHTTPPrefix + "secret.changed"
 	SMSConfigActivatedEventType          = instanceEventTypePrefix + smsConfigPrefix + smsConfigTwilioPrefix + "activated"
 	SMSConfigDeactivatedEventType        = instanceEventTypePrefix + smsConfigPrefix + smsConfigTwilioPrefix + "deactivated"
 	SMSConfigRemovedEventType            = instanceEventTypePrefix + smsConfigPrefix + smsConfigTwilioPrefix + "removed"
@@ -178,6 +183,225 @@ func SMSConfigTwilioTokenChangedEventMapper(event eventstore.Event) (eventstore.
 	return smtpConfigTokenChagned, nil
 }
 
+type SMSConfigHTTPAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID                 string                         `json:"id,omitempty"`
+	URL                string                         `json:"url,omitempty"`
+	Method             string                         `json:"method,omitempty"`
+	Headers            map[string]*crypto.CryptoValue `json:"headers,omitempty"`
+	BodyTemplate       string                         `json:"bodyTemplate,omitempty"`
+	AuthType           domain.SMSHTTPAuthType         `json:"authType,omitempty"`
+	Username           string                         `json:"username,omitempty"`
+	Secret             *crypto.CryptoValue            `json:"secret,omitempty"`
+	SuccessStatusCodes []int                          `json:"successStatusCodes,omitempty"`
+	SuccessBodyPattern string                         `json:"successBodyPattern,omitempty"`
+}
+
+func NewSMSConfigHTTPAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	url,
+	method string,
+	headers map[string]*crypto.CryptoValue,
+	bodyTemplate string,
+	authType domain.SMSHTTPAuthType,
+	username string,
+	secret *crypto.CryptoValue,
+	successStatusCodes []int,
+	successBodyPattern string,
+) *SMSConfigHTTPAddedEvent {
+	return &SMSConfigHTTPAddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SMSConfigHTTPAddedEventType,
+		),
+		ID:                 id,
+		URL:                url,
+		Method:             method,
+		Headers:            headers,
+		BodyTemplate:       bodyTemplate,
+		AuthType:           authType,
+		Username:           username,
+		Secret:             secret,
+		SuccessStatusCodes: successStatusCodes,
+		SuccessBodyPattern: successBodyPattern,
+	}
+}
+
+func (e *SMSConfigHTTPAddedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *SMSConfigHTTPAddedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func SMSConfigHTTPAddedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	smsConfigAdded := &SMSConfigHTTPAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := event.Unmarshal(smsConfigAdded)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "IAM-Fd3ka", "unable to unmarshal sms config http added")
+	}
+
+	return smsConfigAdded, nil
+}
+
+type SMSConfigHTTPChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID                 string                          `json:"id,omitempty"`
+	URL                *string                         `json:"url,omitempty"`
+	Method             *string                         `json:"method,omitempty"`
+	Headers            *map[string]*crypto.CryptoValue `json:"headers,omitempty"`
+	BodyTemplate       *string                         `json:"bodyTemplate,omitempty"`
+	AuthType           *domain.SMSHTTPAuthType         `json:"authType,omitempty"`
+	Username           *string                         `json:"username,omitempty"`
+	SuccessStatusCodes *[]int                          `json:"successStatusCodes,omitempty"`
+	SuccessBodyPattern *string                         `json:"successBodyPattern,omitempty"`
+}
+
+func NewSMSConfigHTTPChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	changes []SMSConfigHTTPChanges,
+) (*SMSConfigHTTPChangedEvent, error) {
+	if len(changes) == 0 {
+		return nil, errors.ThrowPreconditionFailed(nil, "IAM-Lq7rd", "Errors.NoChangesFound")
+	}
+	changeEvent := &SMSConfigHTTPChangedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SMSConfigHTTPChangedEventType,
+		),
+		ID: id,
+	}
+	for _, change := range changes {
+		change(changeEvent)
+	}
+	return changeEvent, nil
+}
+
+type SMSConfigHTTPChanges func(event *SMSConfigHTTPChangedEvent)
+
+func ChangeSMSConfigHTTPURL(url string) func(event *SMSConfigHTTPChangedEvent) {
+	return func(e *SMSConfigHTTPChangedEvent) {
+		e.URL = &url
+	}
+}
+
+func ChangeSMSConfigHTTPMethod(method string) func(event *SMSConfigHTTPChangedEvent) {
+	return func(e *SMSConfigHTTPChangedEvent) {
+		e.Method = &method
+	}
+}
+
+func ChangeSMSConfigHTTPHeaders(headers map[string]*crypto.CryptoValue) func(event *SMSConfigHTTPChangedEvent) {
+	return func(e *SMSConfigHTTPChangedEvent) {
+		e.Headers = &headers
+	}
+}
+
+func ChangeSMSConfigHTTPBodyTemplate(bodyTemplate string) func(event *SMSConfigHTTPChangedEvent) {
+	return func(e *SMSConfigHTTPChangedEvent) {
+		e.BodyTemplate = &bodyTemplate
+	}
+}
+
+func ChangeSMSConfigHTTPAuthType(authType domain.SMSHTTPAuthType) func(event *SMSConfigHTTPChangedEvent) {
+	return func(e *SMSConfigHTTPChangedEvent) {
+		e.AuthType = &authType
+	}
+}
+
+func ChangeSMSConfigHTTPUsername(username string) func(event *SMSConfigHTTPChangedEvent) {
+	return func(e *SMSConfigHTTPChangedEvent) {
+		e.Username = &username
+	}
+}
+
+func ChangeSMSConfigHTTPSuccessStatusCodes(codes []int) func(event *SMSConfigHTTPChangedEvent) {
+	return func(e *SMSConfigHTTPChangedEvent) {
+		e.SuccessStatusCodes = &codes
+	}
+}
+
+func ChangeSMSConfigHTTPSuccessBodyPattern(pattern string) func(event *SMSConfigHTTPChangedEvent) {
+	return func(e *SMSConfigHTTPChangedEvent) {
+		e.SuccessBodyPattern = &pattern
+	}
+}
+
+func (e *SMSConfigHTTPChangedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *SMSConfigHTTPChangedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func SMSConfigHTTPChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	smsConfigChanged := &SMSConfigHTTPChangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := event.Unmarshal(smsConfigChanged)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "IAM-Ag2tu", "unable to unmarshal sms config http changed")
+	}
+
+	return smsConfigChanged, nil
+}
+
+type SMSConfigHTTPSecretChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID     string              `json:"id,omitempty"`
+	Secret *crypto.CryptoValue `json:"secret,omitempty"`
+}
+
+func NewSMSConfigHTTPSecretChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	secret *crypto.CryptoValue,
+) *SMSConfigHTTPSecretChangedEvent {
+	return &SMSConfigHTTPSecretChangedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SMSConfigHTTPSecretChangedEventType,
+		),
+		ID:     id,
+		Secret: secret,
+	}
+}
+
+func (e *SMSConfigHTTPSecretChangedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *SMSConfigHTTPSecretChangedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func SMSConfigHTTPSecretChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	secretChanged := &SMSConfigHTTPSecretChangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := event.Unmarshal(secretChanged)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "IAM-Ys5mk", "unable to unmarshal sms config http secret changed")
+	}
+
+	return secretChanged, nil
+}
+
 type SMSConfigActivatedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 	ID                   string `json:"id,omitempty"`
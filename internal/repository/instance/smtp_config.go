@@ -19,6 +19,7 @@ const (
 type SMTPConfigAddedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
+	ID             string              `json:"id,omitempty"`
 	SenderAddress  string              `json:"senderAddress,omitempty"`
 	SenderName     string              `json:"senderName,omitempty"`
 	ReplyToAddress string              `json:"replyToAddress,omitempty"`
@@ -31,6 +32,7 @@ type SMTPConfigAddedEvent struct {
 func NewSMTPConfigAddedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
+	id string,
 	tls bool,
 	senderAddress,
 	senderName,
@@ -45,6 +47,7 @@ func NewSMTPConfigAddedEvent(
 			aggregate,
 			SMTPConfigAddedEventType,
 		),
+		ID:             id,
 		TLS:            tls,
 		SenderAddress:  senderAddress,
 		SenderName:     senderName,
@@ -71,6 +74,7 @@ func SMTPConfigAddedEventMapper(event eventstore.Event) (eventstore.Event, error
 	if err != nil {
 		return nil, errors.ThrowInternal(err, "IAM-39fks", "unable to unmarshal smtp config added")
 	}
+	smtpConfigAdded.ID = smtpConfigID(smtpConfigAdded.ID, event)
 
 	return smtpConfigAdded, nil
 }
@@ -78,6 +82,7 @@ func SMTPConfigAddedEventMapper(event eventstore.Event) (eventstore.Event, error
 type SMTPConfigChangedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
+	ID             string  `json:"id,omitempty"`
 	FromAddress    *string `json:"senderAddress,omitempty"`
 	FromName       *string `json:"senderName,omitempty"`
 	ReplyToAddress *string `json:"replyToAddress,omitempty"`
@@ -97,6 +102,7 @@ func (e *SMTPConfigChangedEvent) UniqueConstraints() []*eventstore.UniqueConstra
 func NewSMTPConfigChangeEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
+	id string,
 	changes []SMTPConfigChanges,
 ) (*SMTPConfigChangedEvent, error) {
 	if len(changes) == 0 {
@@ -108,6 +114,7 @@ func NewSMTPConfigChangeEvent(
 			aggregate,
 			SMTPConfigChangedEventType,
 		),
+		ID: id,
 	}
 	for _, change := range changes {
 		change(changeEvent)
@@ -162,6 +169,7 @@ func SMTPConfigChangedEventMapper(event eventstore.Event) (eventstore.Event, err
 	if err != nil {
 		return nil, errors.ThrowInternal(err, "IAM-m09oo", "unable to unmarshal smtp changed")
 	}
+	e.ID = smtpConfigID(e.ID, event)
 
 	return e, nil
 }
@@ -169,12 +177,14 @@ func SMTPConfigChangedEventMapper(event eventstore.Event) (eventstore.Event, err
 type SMTPConfigPasswordChangedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
+	ID       string              `json:"id,omitempty"`
 	Password *crypto.CryptoValue `json:"password,omitempty"`
 }
 
 func NewSMTPConfigPasswordChangedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
+	id string,
 	password *crypto.CryptoValue,
 ) *SMTPConfigPasswordChangedEvent {
 	return &SMTPConfigPasswordChangedEvent{
@@ -183,6 +193,7 @@ func NewSMTPConfigPasswordChangedEvent(
 			aggregate,
 			SMTPConfigPasswordChangedEventType,
 		),
+		ID:       id,
 		Password: password,
 	}
 }
@@ -203,17 +214,21 @@ func SMTPConfigPasswordChangedEventMapper(event eventstore.Event) (eventstore.Ev
 	if err != nil {
 		return nil, errors.ThrowInternal(err, "IAM-99iNF", "unable to unmarshal smtp config password changed")
 	}
+	smtpConfigPasswordChagned.ID = smtpConfigID(smtpConfigPasswordChagned.ID, event)
 
 	return smtpConfigPasswordChagned, nil
 }
 
 type SMTPConfigRemovedEvent struct {
 	eventstore.BaseEvent `json:"-"`
+
+	ID string `json:"id,omitempty"`
 }
 
 func NewSMTPConfigRemovedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
+	id string,
 ) *SMTPConfigRemovedEvent {
 	return &SMTPConfigRemovedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -221,6 +236,7 @@ func NewSMTPConfigRemovedEvent(
 			aggregate,
 			SMTPConfigRemovedEventType,
 		),
+		ID: id,
 	}
 }
 
@@ -240,6 +256,17 @@ func SMTPConfigRemovedEventMapper(event eventstore.Event) (eventstore.Event, err
 	if err != nil {
 		return nil, errors.ThrowInternal(err, "IAM-DVw1s", "unable to unmarshal smtp config removed")
 	}
+	smtpConfigRemoved.ID = smtpConfigID(smtpConfigRemoved.ID, event)
 
 	return smtpConfigRemoved, nil
 }
+
+// smtpConfigID returns the id of the config the event belongs to.
+// Events created before an instance could have multiple configs don't contain an id,
+// they belong to the config with the id of the instance.
+func smtpConfigID(id string, event eventstore.Event) string {
+	if id != "" {
+		return id
+	}
+	return event.Aggregate().ID
+}
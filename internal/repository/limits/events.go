@@ -17,6 +17,14 @@ const (
 type SetEvent struct {
 	*eventstore.BaseEvent `json:"-"`
 	AuditLogRetention     *time.Duration `json:"auditLogRetention,omitempty"`
+	// the action limits restrict the resources a single action run can use
+	ActionMaxAllocations      *uint64   `json:"actionMaxAllocations,omitempty"`
+	ActionMaxLoopIterations   *uint64   `json:"actionMaxLoopIterations,omitempty"`
+	ActionMaxOutputSize       *uint64   `json:"actionMaxOutputSize,omitempty"`
+	ActionMaxHTTPCalls        *uint32   `json:"actionMaxHttpCalls,omitempty"`
+	ActionMaxHTTPResponseSize *uint64   `json:"actionMaxHttpResponseSize,omitempty"`
+	ActionMaxConcurrentRuns   *uint32   `json:"actionMaxConcurrentRuns,omitempty"`
+	ActionAllowedDomains      *[]string `json:"actionAllowedDomains,omitempty"`
 }
 
 func (e *SetEvent) Payload() any {
@@ -52,6 +60,48 @@ func ChangeAuditLogRetention(auditLogRetention *time.Duration) LimitsChange {
 	}
 }
 
+func ChangeActionMaxAllocations(maxAllocations *uint64) LimitsChange {
+	return func(e *SetEvent) {
+		e.ActionMaxAllocations = maxAllocations
+	}
+}
+
+func ChangeActionMaxLoopIterations(maxLoopIterations *uint64) LimitsChange {
+	return func(e *SetEvent) {
+		e.ActionMaxLoopIterations = maxLoopIterations
+	}
+}
+
+func ChangeActionMaxOutputSize(maxOutputSize *uint64) LimitsChange {
+	return func(e *SetEvent) {
+		e.ActionMaxOutputSize = maxOutputSize
+	}
+}
+
+func ChangeActionMaxHTTPCalls(maxHTTPCalls *uint32) LimitsChange {
+	return func(e *SetEvent) {
+		e.ActionMaxHTTPCalls = maxHTTPCalls
+	}
+}
+
+func ChangeActionMaxHTTPResponseSize(maxHTTPResponseSize *uint64) LimitsChange {
+	return func(e *SetEvent) {
+		e.ActionMaxHTTPResponseSize = maxHTTPResponseSize
+	}
+}
+
+func ChangeActionMaxConcurrentRuns(maxConcurrentRuns *uint32) LimitsChange {
+	return func(e *SetEvent) {
+		e.ActionMaxConcurrentRuns = maxConcurrentRuns
+	}
+}
+
+func ChangeActionAllowedDomains(allowedDomains []string) LimitsChange {
+	return func(e *SetEvent) {
+		e.ActionAllowedDomains = &allowedDomains
+	}
+}
+
 var SetEventMapper = eventstore.GenericEventMapper[SetEvent]
 
 type ResetEvent struct {
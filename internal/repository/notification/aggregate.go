@@ -0,0 +1,28 @@
+package notification
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	AggregateType    = "notification"
+	AggregateVersion = "v1"
+)
+
+type Aggregate struct {
+	eventstore.Aggregate
+}
+
+// NewAggregate returns the aggregate of a message in the notification outbox,
+// the id is used as idempotency key of the message
+func NewAggregate(id, resourceOwner, instanceID string) *Aggregate {
+	return &Aggregate{
+		Aggregate: eventstore.Aggregate{
+			Type:          AggregateType,
+			Version:       AggregateVersion,
+			ID:            id,
+			InstanceID:    instanceID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
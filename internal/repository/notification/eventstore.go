@@ -0,0 +1,15 @@
+package notification
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+func RegisterEventMappers(es *eventstore.Eventstore) {
+	es.RegisterFilterEventMapper(AggregateType, QueuedEventType, QueuedEventMapper).
+		RegisterFilterEventMapper(AggregateType, DeliveryStartedEventType, DeliveryStartedEventMapper).
+		RegisterFilterEventMapper(AggregateType, RetryScheduledEventType, RetryScheduledEventMapper).
+		RegisterFilterEventMapper(AggregateType, PostponedEventType, PostponedEventMapper).
+		RegisterFilterEventMapper(AggregateType, SentEventType, SentEventMapper).
+		RegisterFilterEventMapper(AggregateType, BouncedEventType, BouncedEventMapper).
+		RegisterFilterEventMapper(AggregateType, FailedEventType, FailedEventMapper)
+}
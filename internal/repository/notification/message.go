@@ -0,0 +1,376 @@
+package notification
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	eventTypePrefix          = eventstore.EventType("notification.")
+	QueuedEventType          = eventTypePrefix + "queued"
+	DeliveryStartedEventType = eventTypePrefix + "delivery.started"
+	RetryScheduledEventType  = eventTypePrefix + "retry.scheduled"
+	PostponedEventType       = eventTypePrefix + "delivery.postponed"
+	SentEventType            = eventTypePrefix + "sent"
+	BouncedEventType         = eventTypePrefix + "bounced"
+	FailedEventType          = eventTypePrefix + "failed"
+
+	deliveryUniqueType = "notification_delivery"
+)
+
+// NewAddDeliveryUniqueConstraint ensures that an attempt is only started once,
+// even if multiple workers pick up the same message
+func NewAddDeliveryUniqueConstraint(id string, attempt uint16) *eventstore.UniqueConstraint {
+	return eventstore.NewAddEventUniqueConstraint(
+		deliveryUniqueType,
+		deliveryUniqueField(id, attempt),
+		"Errors.Notification.Message.DeliveryAlreadyStarted",
+	)
+}
+
+func NewRemoveDeliveryUniqueConstraint(id string, attempt uint16) *eventstore.UniqueConstraint {
+	return eventstore.NewRemoveUniqueConstraint(
+		deliveryUniqueType,
+		deliveryUniqueField(id, attempt),
+	)
+}
+
+func deliveryUniqueField(id string, attempt uint16) string {
+	return id + ":" + strconv.Itoa(int(attempt))
+}
+
+// QueuedEvent describes that a message to a user was added to the outbox
+type QueuedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	UserID  string                  `json:"userId,omitempty"`
+	Channel domain.NotificationType `json:"channel"`
+	// TriggeringEventType is the type of the event the message was created for
+	TriggeringEventType eventstore.EventType `json:"triggeringEventType,omitempty"`
+	Recipient           string               `json:"recipient"`
+	Subject             string               `json:"subject,omitempty"`
+	// Content is encrypted as it might contain codes and links
+	Content *crypto.CryptoValue `json:"content"`
+}
+
+func (e *QueuedEvent) Payload() any {
+	return e
+}
+
+func (e *QueuedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *QueuedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = b
+}
+
+// PersonalDataFields implements [eventstore.PersonalDataCommand]
+func (e *QueuedEvent) PersonalDataFields() []string {
+	return []string{"recipient"}
+}
+
+// PersonalDataOwnerID implements [eventstore.PersonalDataOwner],
+// the recipient is sealed with the key of the user, so it's crypto-shredded if the user is forgotten
+func (e *QueuedEvent) PersonalDataOwnerID() string {
+	return e.UserID
+}
+
+func NewQueuedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	userID string,
+	channel domain.NotificationType,
+	triggeringEventType eventstore.EventType,
+	recipient,
+	subject string,
+	content *crypto.CryptoValue,
+) *QueuedEvent {
+	return &QueuedEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			QueuedEventType,
+		),
+		UserID:              userID,
+		Channel:             channel,
+		TriggeringEventType: triggeringEventType,
+		Recipient:           recipient,
+		Subject:             subject,
+		Content:             content,
+	}
+}
+
+var QueuedEventMapper = eventstore.GenericEventMapper[QueuedEvent]
+
+// DeliveryStartedEvent describes that a worker started to hand over the message to a provider
+type DeliveryStartedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	Attempt uint16 `json:"attempt"`
+}
+
+func (e *DeliveryStartedEvent) Payload() any {
+	return e
+}
+
+func (e *DeliveryStartedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return []*eventstore.UniqueConstraint{
+		NewAddDeliveryUniqueConstraint(e.Aggregate().ID, e.Attempt),
+	}
+}
+
+func (e *DeliveryStartedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = b
+}
+
+func NewDeliveryStartedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	attempt uint16,
+) *DeliveryStartedEvent {
+	return &DeliveryStartedEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			DeliveryStartedEventType,
+		),
+		Attempt: attempt,
+	}
+}
+
+var DeliveryStartedEventMapper = eventstore.GenericEventMapper[DeliveryStartedEvent]
+
+// RetryScheduledEvent describes that an attempt failed and the message will be sent again after NextAttempt
+type RetryScheduledEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	ProviderID  string    `json:"providerId,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	NextAttempt time.Time `json:"nextAttempt"`
+}
+
+func (e *RetryScheduledEvent) Payload() any {
+	return e
+}
+
+func (e *RetryScheduledEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *RetryScheduledEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = b
+}
+
+func NewRetryScheduledEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	providerID,
+	err string,
+	nextAttempt time.Time,
+) *RetryScheduledEvent {
+	return &RetryScheduledEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			RetryScheduledEventType,
+		),
+		ProviderID:  providerID,
+		Error:       err,
+		NextAttempt: nextAttempt,
+	}
+}
+
+var RetryScheduledEventMapper = eventstore.GenericEventMapper[RetryScheduledEvent]
+
+// PostponedEvent describes that the message was not passed to a provider because the rate limits of the providers were exceeded.
+// The started attempt is not counted, so throttled messages don't reach the maximum attempts.
+type PostponedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	// Attempt is the started attempt which is released
+	Attempt     uint16    `json:"attempt"`
+	ProviderID  string    `json:"providerId,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	NextAttempt time.Time `json:"nextAttempt"`
+}
+
+func (e *PostponedEvent) Payload() any {
+	return e
+}
+
+func (e *PostponedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return []*eventstore.UniqueConstraint{
+		NewRemoveDeliveryUniqueConstraint(e.Aggregate().ID, e.Attempt),
+	}
+}
+
+func (e *PostponedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = b
+}
+
+func NewPostponedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	attempt uint16,
+	providerID,
+	err string,
+	nextAttempt time.Time,
+) *PostponedEvent {
+	return &PostponedEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			PostponedEventType,
+		),
+		Attempt:     attempt,
+		ProviderID:  providerID,
+		Error:       err,
+		NextAttempt: nextAttempt,
+	}
+}
+
+var PostponedEventMapper = eventstore.GenericEventMapper[PostponedEvent]
+
+// SentEvent describes that a provider accepted the message
+type SentEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	ProviderID        string `json:"providerId,omitempty"`
+	ProviderMessageID string `json:"providerMessageId,omitempty"`
+
+	attempts uint16
+}
+
+func (e *SentEvent) Payload() any {
+	return e
+}
+
+func (e *SentEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return removeDeliveryUniqueConstraints(e.Aggregate().ID, e.attempts)
+}
+
+func (e *SentEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = b
+}
+
+func NewSentEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	attempts uint16,
+	providerID,
+	providerMessageID string,
+) *SentEvent {
+	return &SentEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SentEventType,
+		),
+		ProviderID:        providerID,
+		ProviderMessageID: providerMessageID,
+		attempts:          attempts,
+	}
+}
+
+var SentEventMapper = eventstore.GenericEventMapper[SentEvent]
+
+// BouncedEvent describes that the provider rejected the message permanently, e.g. because the recipient does not exist
+type BouncedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	ProviderID string `json:"providerId,omitempty"`
+	Error      string `json:"error,omitempty"`
+
+	attempts uint16
+}
+
+func (e *BouncedEvent) Payload() any {
+	return e
+}
+
+func (e *BouncedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return removeDeliveryUniqueConstraints(e.Aggregate().ID, e.attempts)
+}
+
+func (e *BouncedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = b
+}
+
+func NewBouncedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	attempts uint16,
+	providerID,
+	err string,
+) *BouncedEvent {
+	return &BouncedEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			BouncedEventType,
+		),
+		ProviderID: providerID,
+		Error:      err,
+		attempts:   attempts,
+	}
+}
+
+var BouncedEventMapper = eventstore.GenericEventMapper[BouncedEvent]
+
+// FailedEvent describes that the message could not be delivered within the maximum attempts
+type FailedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	ProviderID string `json:"providerId,omitempty"`
+	Error      string `json:"error,omitempty"`
+
+	attempts uint16
+}
+
+func (e *FailedEvent) Payload() any {
+	return e
+}
+
+func (e *FailedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return removeDeliveryUniqueConstraints(e.Aggregate().ID, e.attempts)
+}
+
+func (e *FailedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = b
+}
+
+func NewFailedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	attempts uint16,
+	providerID,
+	err string,
+) *FailedEvent {
+	return &FailedEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			FailedEventType,
+		),
+		ProviderID: providerID,
+		Error:      err,
+		attempts:   attempts,
+	}
+}
+
+var FailedEventMapper = eventstore.GenericEventMapper[FailedEvent]
+
+// removeDeliveryUniqueConstraints releases the claims of all attempts as soon as the message is done
+func removeDeliveryUniqueConstraints(id string, attempts uint16) []*eventstore.UniqueConstraint {
+	constraints := make([]*eventstore.UniqueConstraint, attempts)
+	for i := range constraints {
+		constraints[i] = NewRemoveDeliveryUniqueConstraint(id, uint16(i+1))
+	}
+	return constraints
+}
@@ -24,6 +24,7 @@ func NewTriggerActionsSetEvent(
 	flowType domain.FlowType,
 	triggerType domain.TriggerType,
 	actionIDs []string,
+	actionVersions map[string]uint64,
 ) *TriggerActionsSetEvent {
 	return &TriggerActionsSetEvent{
 		TriggerActionsSetEvent: *flow.NewTriggerActionsSetEvent(
@@ -33,7 +34,8 @@ func NewTriggerActionsSetEvent(
 				TriggerActionsSetEventType),
 			flowType,
 			triggerType,
-			actionIDs),
+			actionIDs,
+			actionVersions),
 	}
 }
 
@@ -0,0 +1,76 @@
+package org
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/settings"
+)
+
+var (
+	NotificationProviderOrderSetEventType     = orgEventTypePrefix + settings.NotificationProviderOrderPrefix + settings.NotificationProviderOrderSet
+	NotificationProviderOrderRemovedEventType = orgEventTypePrefix + settings.NotificationProviderOrderPrefix + settings.NotificationProviderOrderRemoved
+)
+
+type NotificationProviderOrderSetEvent struct {
+	settings.NotificationProviderOrderSetEvent
+}
+
+func NewNotificationProviderOrderSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	channel domain.NotificationType,
+	providerIDs []string,
+	emailSender *settings.EmailSender,
+) *NotificationProviderOrderSetEvent {
+	return &NotificationProviderOrderSetEvent{
+		NotificationProviderOrderSetEvent: *settings.NewNotificationProviderOrderSetEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				NotificationProviderOrderSetEventType),
+			channel,
+			providerIDs,
+			emailSender,
+		),
+	}
+}
+
+func NotificationProviderOrderSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := settings.NotificationProviderOrderSetEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NotificationProviderOrderSetEvent{NotificationProviderOrderSetEvent: *e.(*settings.NotificationProviderOrderSetEvent)}, nil
+}
+
+type NotificationProviderOrderRemovedEvent struct {
+	settings.NotificationProviderOrderRemovedEvent
+}
+
+func NewNotificationProviderOrderRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	channel domain.NotificationType,
+) *NotificationProviderOrderRemovedEvent {
+	return &NotificationProviderOrderRemovedEvent{
+		NotificationProviderOrderRemovedEvent: *settings.NewNotificationProviderOrderRemovedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				NotificationProviderOrderRemovedEventType),
+			channel,
+		),
+	}
+}
+
+func NotificationProviderOrderRemovedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := settings.NotificationProviderOrderRemovedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NotificationProviderOrderRemovedEvent{NotificationProviderOrderRemovedEvent: *e.(*settings.NotificationProviderOrderRemovedEvent)}, nil
+}
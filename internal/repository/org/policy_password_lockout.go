@@ -2,6 +2,7 @@ package org
 
 import (
 	"context"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/repository/policy"
@@ -22,6 +23,9 @@ func NewLockoutPolicyAddedEvent(
 	aggregate *eventstore.Aggregate,
 	maxAttempts uint64,
 	showLockoutFailure bool,
+	maxOTPAttempts uint64,
+	autoUnlockAfter time.Duration,
+	failedAttemptDelay time.Duration,
 ) *LockoutPolicyAddedEvent {
 	return &LockoutPolicyAddedEvent{
 		LockoutPolicyAddedEvent: *policy.NewLockoutPolicyAddedEvent(
@@ -30,7 +34,10 @@ func NewLockoutPolicyAddedEvent(
 				aggregate,
 				LockoutPolicyAddedEventType),
 			maxAttempts,
-			showLockoutFailure),
+			showLockoutFailure,
+			maxOTPAttempts,
+			autoUnlockAfter,
+			failedAttemptDelay),
 	}
 }
 
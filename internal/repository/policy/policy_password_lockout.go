@@ -1,6 +1,8 @@
 package policy
 
 import (
+	"time"
+
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
 )
@@ -14,8 +16,11 @@ const (
 type LockoutPolicyAddedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	MaxPasswordAttempts uint64 `json:"maxPasswordAttempts,omitempty"`
-	ShowLockOutFailures bool   `json:"showLockOutFailures,omitempty"`
+	MaxPasswordAttempts uint64        `json:"maxPasswordAttempts,omitempty"`
+	ShowLockOutFailures bool          `json:"showLockOutFailures,omitempty"`
+	MaxOTPAttempts      uint64        `json:"maxOTPAttempts,omitempty"`
+	AutoUnlockAfter     time.Duration `json:"autoUnlockAfter,omitempty"`
+	FailedAttemptDelay  time.Duration `json:"failedAttemptDelay,omitempty"`
 }
 
 func (e *LockoutPolicyAddedEvent) Payload() interface{} {
@@ -30,12 +35,18 @@ func NewLockoutPolicyAddedEvent(
 	base *eventstore.BaseEvent,
 	maxAttempts uint64,
 	showLockOutFailures bool,
+	maxOTPAttempts uint64,
+	autoUnlockAfter time.Duration,
+	failedAttemptDelay time.Duration,
 ) *LockoutPolicyAddedEvent {
 
 	return &LockoutPolicyAddedEvent{
 		BaseEvent:           *base,
 		MaxPasswordAttempts: maxAttempts,
 		ShowLockOutFailures: showLockOutFailures,
+		MaxOTPAttempts:      maxOTPAttempts,
+		AutoUnlockAfter:     autoUnlockAfter,
+		FailedAttemptDelay:  failedAttemptDelay,
 	}
 }
 
@@ -55,8 +66,11 @@ func LockoutPolicyAddedEventMapper(event eventstore.Event) (eventstore.Event, er
 type LockoutPolicyChangedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	MaxPasswordAttempts *uint64 `json:"maxPasswordAttempts,omitempty"`
-	ShowLockOutFailures *bool   `json:"showLockOutFailures,omitempty"`
+	MaxPasswordAttempts *uint64        `json:"maxPasswordAttempts,omitempty"`
+	ShowLockOutFailures *bool          `json:"showLockOutFailures,omitempty"`
+	MaxOTPAttempts      *uint64        `json:"maxOTPAttempts,omitempty"`
+	AutoUnlockAfter     *time.Duration `json:"autoUnlockAfter,omitempty"`
+	FailedAttemptDelay  *time.Duration `json:"failedAttemptDelay,omitempty"`
 }
 
 func (e *LockoutPolicyChangedEvent) Payload() interface{} {
@@ -97,6 +111,24 @@ func ChangeShowLockOutFailures(showLockOutFailures bool) func(*LockoutPolicyChan
 	}
 }
 
+func ChangeMaxOTPAttempts(maxAttempts uint64) func(*LockoutPolicyChangedEvent) {
+	return func(e *LockoutPolicyChangedEvent) {
+		e.MaxOTPAttempts = &maxAttempts
+	}
+}
+
+func ChangeAutoUnlockAfter(autoUnlockAfter time.Duration) func(*LockoutPolicyChangedEvent) {
+	return func(e *LockoutPolicyChangedEvent) {
+		e.AutoUnlockAfter = &autoUnlockAfter
+	}
+}
+
+func ChangeFailedAttemptDelay(failedAttemptDelay time.Duration) func(*LockoutPolicyChangedEvent) {
+	return func(e *LockoutPolicyChangedEvent) {
+		e.FailedAttemptDelay = &failedAttemptDelay
+	}
+}
+
 func LockoutPolicyChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
 	e := &LockoutPolicyChangedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
@@ -12,24 +12,25 @@ import (
 )
 
 const (
-	sessionEventPrefix     = "session."
-	AddedType              = sessionEventPrefix + "added"
-	UserCheckedType        = sessionEventPrefix + "user.checked"
-	PasswordCheckedType    = sessionEventPrefix + "password.checked"
-	IntentCheckedType      = sessionEventPrefix + "intent.checked"
-	WebAuthNChallengedType = sessionEventPrefix + "webAuthN.challenged"
-	WebAuthNCheckedType    = sessionEventPrefix + "webAuthN.checked"
-	TOTPCheckedType        = sessionEventPrefix + "totp.checked"
-	OTPSMSChallengedType   = sessionEventPrefix + "otp.sms.challenged"
-	OTPSMSSentType         = sessionEventPrefix + "otp.sms.sent"
-	OTPSMSCheckedType      = sessionEventPrefix + "otp.sms.checked"
-	OTPEmailChallengedType = sessionEventPrefix + "otp.email.challenged"
-	OTPEmailSentType       = sessionEventPrefix + "otp.email.sent"
-	OTPEmailCheckedType    = sessionEventPrefix + "otp.email.checked"
-	TokenSetType           = sessionEventPrefix + "token.set"
-	MetadataSetType        = sessionEventPrefix + "metadata.set"
-	LifetimeSetType        = sessionEventPrefix + "lifetime.set"
-	TerminateType          = sessionEventPrefix + "terminated"
+	sessionEventPrefix      = "session."
+	AddedType               = sessionEventPrefix + "added"
+	UserCheckedType         = sessionEventPrefix + "user.checked"
+	PasswordCheckedType     = sessionEventPrefix + "password.checked"
+	IntentCheckedType       = sessionEventPrefix + "intent.checked"
+	WebAuthNChallengedType  = sessionEventPrefix + "webAuthN.challenged"
+	WebAuthNCheckedType     = sessionEventPrefix + "webAuthN.checked"
+	TOTPCheckedType         = sessionEventPrefix + "totp.checked"
+	OTPSMSChallengedType    = sessionEventPrefix + "otp.sms.challenged"
+	OTPSMSSentType          = sessionEventPrefix + "otp.sms.sent"
+	OTPSMSCheckedType       = sessionEventPrefix + "otp.sms.checked"
+	OTPEmailChallengedType  = sessionEventPrefix + "otp.email.challenged"
+	OTPEmailSentType        = sessionEventPrefix + "otp.email.sent"
+	OTPEmailCheckedType     = sessionEventPrefix + "otp.email.checked"
+	RecoveryCodeCheckedType = sessionEventPrefix + "recoverycode.checked"
+	TokenSetType            = sessionEventPrefix + "token.set"
+	MetadataSetType         = sessionEventPrefix + "metadata.set"
+	LifetimeSetType         = sessionEventPrefix + "lifetime.set"
+	TerminateType           = sessionEventPrefix + "terminated"
 )
 
 type AddedEvent struct {
@@ -122,7 +123,12 @@ func UserCheckedEventMapper(event eventstore.Event) (eventstore.Event, error) {
 type PasswordCheckedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	CheckedAt time.Time `json:"checkedAt"`
+	// UserID and UserResourceOwner identify the user whose password was checked,
+	// so handlers don't depend on the state of the session projection
+	UserID            string    `json:"userID,omitempty"`
+	UserResourceOwner string    `json:"userResourceOwner,omitempty"`
+	CheckedAt         time.Time `json:"checkedAt"`
+	Expired           bool      `json:"expired,omitempty"`
 }
 
 func (e *PasswordCheckedEvent) Payload() interface{} {
@@ -136,7 +142,10 @@ func (e *PasswordCheckedEvent) UniqueConstraints() []*eventstore.UniqueConstrain
 func NewPasswordCheckedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
+	userID,
+	userResourceOwner string,
 	checkedAt time.Time,
+	expired bool,
 ) *PasswordCheckedEvent {
 	return &PasswordCheckedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -144,7 +153,10 @@ func NewPasswordCheckedEvent(
 			aggregate,
 			PasswordCheckedType,
 		),
-		CheckedAt: checkedAt,
+		UserID:            userID,
+		UserResourceOwner: userResourceOwner,
+		CheckedAt:         checkedAt,
+		Expired:           expired,
 	}
 }
 
@@ -312,6 +324,39 @@ func NewTOTPCheckedEvent(
 	}
 }
 
+type RecoveryCodeCheckedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	CheckedAt time.Time `json:"checkedAt"`
+}
+
+func (e *RecoveryCodeCheckedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *RecoveryCodeCheckedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *RecoveryCodeCheckedEvent) SetBaseEvent(base *eventstore.BaseEvent) {
+	e.BaseEvent = *base
+}
+
+func NewRecoveryCodeCheckedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	checkedAt time.Time,
+) *RecoveryCodeCheckedEvent {
+	return &RecoveryCodeCheckedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			RecoveryCodeCheckedType,
+		),
+		CheckedAt: checkedAt,
+	}
+}
+
 type OTPSMSChallengedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
@@ -0,0 +1,104 @@
+package settings
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	NotificationProviderOrderPrefix  = "notification.provider.order."
+	NotificationProviderOrderSet     = "set"
+	NotificationProviderOrderRemoved = "removed"
+)
+
+// NotificationProviderOrderSetEvent defines the providers used to send notifications of a channel.
+// The message is sent using the first provider, the following providers are used if the previous one can't be reached.
+type NotificationProviderOrderSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Channel     domain.NotificationType `json:"channel"`
+	ProviderIDs []string                `json:"providerIds,omitempty"`
+	// EmailSender overrides the sender of the SMTP providers, it's only set on organizations
+	EmailSender *EmailSender `json:"emailSender,omitempty"`
+}
+
+// EmailSender is used instead of the sender of the SMTP providers,
+// so the emails to the users of an organization are sent from its own domain
+type EmailSender struct {
+	Address        string `json:"address,omitempty"`
+	Name           string `json:"name,omitempty"`
+	ReplyToAddress string `json:"replyToAddress,omitempty"`
+}
+
+func (e *NotificationProviderOrderSetEvent) Payload() interface{} {
+	return e
+}
+
+func (e *NotificationProviderOrderSetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewNotificationProviderOrderSetEvent(
+	base *eventstore.BaseEvent,
+	channel domain.NotificationType,
+	providerIDs []string,
+	emailSender *EmailSender,
+) *NotificationProviderOrderSetEvent {
+	return &NotificationProviderOrderSetEvent{
+		BaseEvent:   *base,
+		Channel:     channel,
+		ProviderIDs: providerIDs,
+		EmailSender: emailSender,
+	}
+}
+
+func NotificationProviderOrderSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &NotificationProviderOrderSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "SET-Jx4pq", "unable to unmarshal notification provider order set")
+	}
+
+	return e, nil
+}
+
+type NotificationProviderOrderRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Channel domain.NotificationType `json:"channel"`
+}
+
+func (e *NotificationProviderOrderRemovedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *NotificationProviderOrderRemovedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewNotificationProviderOrderRemovedEvent(
+	base *eventstore.BaseEvent,
+	channel domain.NotificationType,
+) *NotificationProviderOrderRemovedEvent {
+	return &NotificationProviderOrderRemovedEvent{
+		BaseEvent: *base,
+		Channel:   channel,
+	}
+}
+
+func NotificationProviderOrderRemovedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &NotificationProviderOrderRemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "SET-Ve8nr", "unable to unmarshal notification provider order removed")
+	}
+
+	return e, nil
+}
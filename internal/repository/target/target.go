@@ -0,0 +1,96 @@
+package target
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	eventTypePrefix  = eventstore.EventType("target.")
+	SetEventType     = eventTypePrefix + "set"
+	RemovedEventType = eventTypePrefix + "removed"
+)
+
+// SetEvent describes that a target is added or replaced and always contains the whole configuration
+type SetEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	Name     string        `json:"name"`
+	Endpoint string        `json:"endpoint"`
+	Timeout  time.Duration `json:"timeout"`
+	// InterruptOnError stops the execution of the call or the event if the target fails
+	InterruptOnError bool `json:"interruptOnError,omitempty"`
+	// SigningKey is used to sign the payloads sent to the target
+	SigningKey *crypto.CryptoValue `json:"signingKey"`
+}
+
+func (e *SetEvent) Payload() any {
+	return e
+}
+
+func (e *SetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *SetEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = b
+}
+
+func NewSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	name,
+	endpoint string,
+	timeout time.Duration,
+	interruptOnError bool,
+	signingKey *crypto.CryptoValue,
+) *SetEvent {
+	return &SetEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SetEventType,
+		),
+		Name:             name,
+		Endpoint:         endpoint,
+		Timeout:          timeout,
+		InterruptOnError: interruptOnError,
+		SigningKey:       signingKey,
+	}
+}
+
+var SetEventMapper = eventstore.GenericEventMapper[SetEvent]
+
+type RemovedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+}
+
+func (e *RemovedEvent) Payload() any {
+	return e
+}
+
+func (e *RemovedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *RemovedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = b
+}
+
+func NewRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+) *RemovedEvent {
+	return &RemovedEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			RemovedEventType,
+		),
+	}
+}
+
+var RemovedEventMapper = eventstore.GenericEventMapper[RemovedEvent]
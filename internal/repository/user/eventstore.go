@@ -5,6 +5,8 @@ import (
 )
 
 func RegisterEventMappers(es *eventstore.Eventstore) {
+	registerPersonalDataFields(es)
+	es.RegisterUpcaster(AggregateType, HumanPasswordChangedType, 2, humanPasswordChangedUpcaster)
 	es.RegisterFilterEventMapper(AggregateType, UserV1AddedType, HumanAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, UserV1RegisteredType, HumanRegisteredEventMapper).
 		RegisterFilterEventMapper(AggregateType, UserV1InitialCodeAddedType, HumanInitialCodeAddedEventMapper).
@@ -41,6 +43,7 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, UserDeactivatedType, UserDeactivatedEventMapper).
 		RegisterFilterEventMapper(AggregateType, UserReactivatedType, UserReactivatedEventMapper).
 		RegisterFilterEventMapper(AggregateType, UserRemovedType, UserRemovedEventMapper).
+		RegisterFilterEventMapper(AggregateType, UserForgottenType, UserForgottenEventMapper).
 		RegisterFilterEventMapper(AggregateType, UserTokenAddedType, UserTokenAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, UserTokenRemovedType, UserTokenRemovedEventMapper).
 		RegisterFilterEventMapper(AggregateType, UserDomainClaimedType, DomainClaimedEventMapper).
@@ -60,6 +63,9 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, HumanPasswordCodeAddedType, HumanPasswordCodeAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanPasswordCodeSentType, HumanPasswordCodeSentEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanPasswordChangeSentType, HumanPasswordChangeSentEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanPasswordExpiryWarningDueType, HumanPasswordExpiryWarningDueEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanPasswordExpiryWarningSentType, HumanPasswordExpiryWarningSentEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanPasswordBreachedType, HumanPasswordBreachedEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanPasswordCheckSucceededType, HumanPasswordCheckSucceededEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanPasswordCheckFailedType, HumanPasswordCheckFailedEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanPasswordHashUpdatedType, eventstore.GenericEventMapper[HumanPasswordHashUpdatedEvent]).
@@ -101,6 +107,11 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, HumanOTPEmailCodeSentType, eventstore.GenericEventMapper[HumanOTPEmailCodeSentEvent]).
 		RegisterFilterEventMapper(AggregateType, HumanOTPEmailCheckSucceededType, eventstore.GenericEventMapper[HumanOTPEmailCheckSucceededEvent]).
 		RegisterFilterEventMapper(AggregateType, HumanOTPEmailCheckFailedType, eventstore.GenericEventMapper[HumanOTPEmailCheckFailedEvent]).
+		RegisterFilterEventMapper(AggregateType, HumanRecoveryCodesAddedType, eventstore.GenericEventMapper[HumanRecoveryCodesAddedEvent]).
+		RegisterFilterEventMapper(AggregateType, HumanRecoveryCodesRemovedType, eventstore.GenericEventMapper[HumanRecoveryCodesRemovedEvent]).
+		RegisterFilterEventMapper(AggregateType, HumanRecoveryCodeCheckSucceededType, eventstore.GenericEventMapper[HumanRecoveryCodeCheckSucceededEvent]).
+		RegisterFilterEventMapper(AggregateType, HumanRecoveryCodeCheckFailedType, eventstore.GenericEventMapper[HumanRecoveryCodeCheckFailedEvent]).
+		RegisterFilterEventMapper(AggregateType, HumanRecoveryCodeUsedNotificationSentType, eventstore.GenericEventMapper[HumanRecoveryCodeUsedNotificationSentEvent]).
 		RegisterFilterEventMapper(AggregateType, HumanU2FTokenAddedType, HumanU2FAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanU2FTokenVerifiedType, HumanU2FVerifiedEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanU2FTokenSignCountChangedType, HumanU2FSignCountChangedEventMapper).
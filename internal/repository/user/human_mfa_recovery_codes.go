@@ -0,0 +1,185 @@
+package user
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	recoveryCodesEventPrefix                  = mfaEventPrefix + "recoverycodes."
+	HumanRecoveryCodesAddedType               = recoveryCodesEventPrefix + "added"
+	HumanRecoveryCodesRemovedType             = recoveryCodesEventPrefix + "removed"
+	HumanRecoveryCodeCheckSucceededType       = recoveryCodesEventPrefix + "check.succeeded"
+	HumanRecoveryCodeCheckFailedType          = recoveryCodesEventPrefix + "check.failed"
+	HumanRecoveryCodeUsedNotificationSentType = recoveryCodesEventPrefix + "used.notification.sent"
+)
+
+// HumanRecoveryCodesAddedEvent adds a new batch of recovery codes.
+// Any previously generated codes are replaced by the new batch.
+type HumanRecoveryCodesAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	EncodedHashes []string `json:"encodedHashes,omitempty"`
+}
+
+func (e *HumanRecoveryCodesAddedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *HumanRecoveryCodesAddedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *HumanRecoveryCodesAddedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewHumanRecoveryCodesAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	encodedHashes []string,
+) *HumanRecoveryCodesAddedEvent {
+	return &HumanRecoveryCodesAddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanRecoveryCodesAddedType,
+		),
+		EncodedHashes: encodedHashes,
+	}
+}
+
+type HumanRecoveryCodesRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *HumanRecoveryCodesRemovedEvent) Payload() interface{} {
+	return nil
+}
+
+func (e *HumanRecoveryCodesRemovedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *HumanRecoveryCodesRemovedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewHumanRecoveryCodesRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+) *HumanRecoveryCodesRemovedEvent {
+	return &HumanRecoveryCodesRemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanRecoveryCodesRemovedType,
+		),
+	}
+}
+
+// HumanRecoveryCodeCheckSucceededEvent marks the code at CodeIndex of the current batch as used.
+type HumanRecoveryCodeCheckSucceededEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	CodeIndex         int    `json:"codeIndex"`
+	TriggeredAtOrigin string `json:"triggerOrigin,omitempty"`
+	*AuthRequestInfo
+}
+
+func (e *HumanRecoveryCodeCheckSucceededEvent) Payload() interface{} {
+	return e
+}
+
+func (e *HumanRecoveryCodeCheckSucceededEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *HumanRecoveryCodeCheckSucceededEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func (e *HumanRecoveryCodeCheckSucceededEvent) TriggerOrigin() string {
+	return e.TriggeredAtOrigin
+}
+
+func NewHumanRecoveryCodeCheckSucceededEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	codeIndex int,
+	info *AuthRequestInfo,
+) *HumanRecoveryCodeCheckSucceededEvent {
+	return &HumanRecoveryCodeCheckSucceededEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanRecoveryCodeCheckSucceededType,
+		),
+		CodeIndex:         codeIndex,
+		TriggeredAtOrigin: http.ComposedOrigin(ctx),
+		AuthRequestInfo:   info,
+	}
+}
+
+type HumanRecoveryCodeCheckFailedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+	*AuthRequestInfo
+}
+
+func (e *HumanRecoveryCodeCheckFailedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *HumanRecoveryCodeCheckFailedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *HumanRecoveryCodeCheckFailedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewHumanRecoveryCodeCheckFailedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	info *AuthRequestInfo,
+) *HumanRecoveryCodeCheckFailedEvent {
+	return &HumanRecoveryCodeCheckFailedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanRecoveryCodeCheckFailedType,
+		),
+		AuthRequestInfo: info,
+	}
+}
+
+type HumanRecoveryCodeUsedNotificationSentEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *HumanRecoveryCodeUsedNotificationSentEvent) Payload() interface{} {
+	return nil
+}
+
+func (e *HumanRecoveryCodeUsedNotificationSentEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *HumanRecoveryCodeUsedNotificationSentEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewHumanRecoveryCodeUsedNotificationSentEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+) *HumanRecoveryCodeUsedNotificationSentEvent {
+	return &HumanRecoveryCodeUsedNotificationSentEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanRecoveryCodeUsedNotificationSentType,
+		),
+	}
+}
@@ -2,6 +2,7 @@ package user
 
 import (
 	"context"
+	"encoding/base64"
 	"time"
 
 	"github.com/zitadel/zitadel/internal/api/http"
@@ -12,14 +13,17 @@ import (
 )
 
 const (
-	passwordEventPrefix             = humanEventPrefix + "password."
-	HumanPasswordChangedType        = passwordEventPrefix + "changed"
-	HumanPasswordChangeSentType     = passwordEventPrefix + "change.sent"
-	HumanPasswordCodeAddedType      = passwordEventPrefix + "code.added"
-	HumanPasswordCodeSentType       = passwordEventPrefix + "code.sent"
-	HumanPasswordCheckSucceededType = passwordEventPrefix + "check.succeeded"
-	HumanPasswordCheckFailedType    = passwordEventPrefix + "check.failed"
-	HumanPasswordHashUpdatedType    = passwordEventPrefix + "hash.updated"
+	passwordEventPrefix                = humanEventPrefix + "password."
+	HumanPasswordChangedType           = passwordEventPrefix + "changed"
+	HumanPasswordChangeSentType        = passwordEventPrefix + "change.sent"
+	HumanPasswordCodeAddedType         = passwordEventPrefix + "code.added"
+	HumanPasswordCodeSentType          = passwordEventPrefix + "code.sent"
+	HumanPasswordCheckSucceededType    = passwordEventPrefix + "check.succeeded"
+	HumanPasswordCheckFailedType       = passwordEventPrefix + "check.failed"
+	HumanPasswordHashUpdatedType       = passwordEventPrefix + "hash.updated"
+	HumanPasswordExpiryWarningDueType  = passwordEventPrefix + "expiry.warning.due"
+	HumanPasswordExpiryWarningSentType = passwordEventPrefix + "expiry.warning.sent"
+	HumanPasswordBreachedType          = passwordEventPrefix + "breached"
 )
 
 type HumanPasswordChangedEvent struct {
@@ -46,6 +50,12 @@ func (e *HumanPasswordChangedEvent) TriggerOrigin() string {
 	return e.TriggeredAtOrigin
 }
 
+// Revision implements [eventstore.Command].
+// Revision 3 stores the encoded hash instead of the secret.
+func (e *HumanPasswordChangedEvent) Revision() uint16 {
+	return 3
+}
+
 func NewHumanPasswordChangedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
@@ -66,6 +76,22 @@ func NewHumanPasswordChangedEvent(
 	}
 }
 
+// humanPasswordChangedUpcaster moves the hash of the secret of revision 2 to the encoded hash of revision 3
+var humanPasswordChangedUpcaster = eventstore.JSONUpcaster(func(payload map[string]any) error {
+	secret, ok := payload["secret"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	delete(payload, "secret")
+	crypted, _ := secret["Crypted"].(string)
+	hash, err := base64.StdEncoding.DecodeString(crypted)
+	if err != nil {
+		return errors.ThrowInternal(err, "USER-ohG4a", "unable to decode password hash")
+	}
+	payload["encodedHash"] = string(hash)
+	return nil
+})
+
 func HumanPasswordChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
 	humanAdded := &HumanPasswordChangedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
@@ -203,6 +229,105 @@ func HumanPasswordChangeSentEventMapper(event eventstore.Event) (eventstore.Even
 	}, nil
 }
 
+// HumanPasswordExpiryWarningDueEvent is pushed if the password of the user will expire
+// within the warning period of the password age policy, so the user is notified ahead of the expiry.
+type HumanPasswordExpiryWarningDueEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ExpiryDate time.Time `json:"expiryDate"`
+}
+
+func (e *HumanPasswordExpiryWarningDueEvent) Payload() interface{} {
+	return e
+}
+
+func (e *HumanPasswordExpiryWarningDueEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewHumanPasswordExpiryWarningDueEvent(ctx context.Context, aggregate *eventstore.Aggregate, expiryDate time.Time) *HumanPasswordExpiryWarningDueEvent {
+	return &HumanPasswordExpiryWarningDueEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanPasswordExpiryWarningDueType,
+		),
+		ExpiryDate: expiryDate,
+	}
+}
+
+func HumanPasswordExpiryWarningDueEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	dueEvent := &HumanPasswordExpiryWarningDueEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := event.Unmarshal(dueEvent)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Eih4o", "unable to unmarshal human password expiry warning due")
+	}
+	return dueEvent, nil
+}
+
+type HumanPasswordExpiryWarningSentEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *HumanPasswordExpiryWarningSentEvent) Payload() interface{} {
+	return nil
+}
+
+func (e *HumanPasswordExpiryWarningSentEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewHumanPasswordExpiryWarningSentEvent(ctx context.Context, aggregate *eventstore.Aggregate) *HumanPasswordExpiryWarningSentEvent {
+	return &HumanPasswordExpiryWarningSentEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanPasswordExpiryWarningSentType,
+		),
+	}
+}
+
+func HumanPasswordExpiryWarningSentEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	return &HumanPasswordExpiryWarningSentEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
+
+// HumanPasswordBreachedEvent is pushed if the current password was found in a data breach on login.
+// The user is required to change the password.
+type HumanPasswordBreachedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *HumanPasswordBreachedEvent) Payload() interface{} {
+	return nil
+}
+
+func (e *HumanPasswordBreachedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewHumanPasswordBreachedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+) *HumanPasswordBreachedEvent {
+	return &HumanPasswordBreachedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanPasswordBreachedType,
+		),
+	}
+}
+
+func HumanPasswordBreachedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	return &HumanPasswordBreachedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
+
 type HumanPasswordCheckSucceededEvent struct {
 	eventstore.BaseEvent `json:"-"`
 	*AuthRequestInfo
@@ -0,0 +1,71 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+func TestHumanPasswordChangedEvent_upcast(t *testing.T) {
+	tests := []struct {
+		name         string
+		event        *repository.Event
+		wantHash     string
+		wantRequired bool
+	}{
+		{
+			name: "revision 2 with secret",
+			event: &repository.Event{
+				InstanceID:    "instance1",
+				AggregateID:   "user1",
+				AggregateType: AggregateType,
+				Typ:           HumanPasswordChangedType,
+				Rev:           2,
+				Data:          []byte(`{"secret":{"CryptoType":1,"Algorithm":"bcrypt","KeyID":"","Crypted":"JDJhJDE0JGhxZG0ua0gwbzdYS0ROSHBndTVEUy5xbWhySzh6SjNiQVNWWjNBcU1FYVFnWnEzd1lvRElT"},"changeRequired":true,"userAgentID":"agent1"}`),
+			},
+			wantHash:     "$2a$14$hqdm.kH0o7XKDNHpgu5DS.qmhrK8zJ3bASVZ3AqMEaQgZq3wYoDIS",
+			wantRequired: true,
+		},
+		{
+			name: "revision 2 with encoded hash",
+			event: &repository.Event{
+				InstanceID:    "instance1",
+				AggregateID:   "user1",
+				AggregateType: AggregateType,
+				Typ:           HumanPasswordChangedType,
+				Rev:           2,
+				Data:          []byte(`{"encodedHash":"$2a$14$hqdm.kH0o7XKDNHpgu5DS.qmhrK8zJ3bASVZ3AqMEaQgZq3wYoDIS","changeRequired":false}`),
+			},
+			wantHash: "$2a$14$hqdm.kH0o7XKDNHpgu5DS.qmhrK8zJ3bASVZ3AqMEaQgZq3wYoDIS",
+		},
+		{
+			name: "revision 3",
+			event: &repository.Event{
+				InstanceID:    "instance1",
+				AggregateID:   "user1",
+				AggregateType: AggregateType,
+				Typ:           HumanPasswordChangedType,
+				Rev:           3,
+				Data:          []byte(`{"encodedHash":"$2a$14$hqdm.kH0o7XKDNHpgu5DS.qmhrK8zJ3bASVZ3AqMEaQgZq3wYoDIS","changeRequired":true}`),
+			},
+			wantHash:     "$2a$14$hqdm.kH0o7XKDNHpgu5DS.qmhrK8zJ3bASVZ3AqMEaQgZq3wYoDIS",
+			wantRequired: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := filterUserEvents(t, nil, tt.event)
+			require.NoError(t, err)
+			require.Len(t, events, 1)
+			changed, ok := events[0].(*HumanPasswordChangedEvent)
+			require.True(t, ok, "unexpected type of event: %T", events[0])
+			assert.Nil(t, changed.Secret)
+			assert.Equal(t, tt.wantHash, changed.EncodedHash)
+			assert.Equal(t, tt.wantRequired, changed.ChangeRequired)
+			assert.Equal(t, uint16(3), changed.Revision())
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package user
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	profilePersonalDataFields  = []string{"firstName", "lastName", "nickName", "displayName"}
+	addressPersonalDataFields  = []string{"country", "locality", "postalCode", "region", "streetAddress"}
+	humanPersonalDataFields    = append(append([]string{"userName", "email", "phone"}, profilePersonalDataFields...), addressPersonalDataFields...)
+	userNamePersonalDataFields = []string{"userName"}
+)
+
+// registerPersonalDataFields registers the personal data of the user events,
+// so it's redacted in events pushed before crypto-shredding was enabled if the user is forgotten
+func registerPersonalDataFields(es *eventstore.Eventstore) {
+	es.RegisterPersonalDataFields(UserV1AddedType, humanPersonalDataFields...).
+		RegisterPersonalDataFields(UserV1RegisteredType, humanPersonalDataFields...).
+		RegisterPersonalDataFields(HumanAddedType, humanPersonalDataFields...).
+		RegisterPersonalDataFields(HumanRegisteredType, humanPersonalDataFields...).
+		RegisterPersonalDataFields(UserV1EmailChangedType, "email").
+		RegisterPersonalDataFields(HumanEmailChangedType, "email").
+		RegisterPersonalDataFields(UserV1PhoneChangedType, "phone").
+		RegisterPersonalDataFields(HumanPhoneChangedType, "phone").
+		RegisterPersonalDataFields(UserV1ProfileChangedType, profilePersonalDataFields...).
+		RegisterPersonalDataFields(HumanProfileChangedType, profilePersonalDataFields...).
+		RegisterPersonalDataFields(UserV1AddressChangedType, addressPersonalDataFields...).
+		RegisterPersonalDataFields(HumanAddressChangedType, addressPersonalDataFields...).
+		RegisterPersonalDataFields(UserUserNameChangedType, userNamePersonalDataFields...).
+		RegisterPersonalDataFields(UserDomainClaimedType, userNamePersonalDataFields...)
+}
+
+// PersonalDataFields implements [eventstore.PersonalDataCommand]
+func (e *HumanAddedEvent) PersonalDataFields() []string {
+	return humanPersonalDataFields
+}
+
+// PersonalDataFields implements [eventstore.PersonalDataCommand]
+func (e *HumanRegisteredEvent) PersonalDataFields() []string {
+	return humanPersonalDataFields
+}
+
+// PersonalDataFields implements [eventstore.PersonalDataCommand]
+func (e *HumanEmailChangedEvent) PersonalDataFields() []string {
+	return []string{"email"}
+}
+
+// PersonalDataFields implements [eventstore.PersonalDataCommand]
+func (e *HumanPhoneChangedEvent) PersonalDataFields() []string {
+	return []string{"phone"}
+}
+
+// PersonalDataFields implements [eventstore.PersonalDataCommand]
+func (e *HumanProfileChangedEvent) PersonalDataFields() []string {
+	return profilePersonalDataFields
+}
+
+// PersonalDataFields implements [eventstore.PersonalDataCommand]
+func (e *HumanAddressChangedEvent) PersonalDataFields() []string {
+	return addressPersonalDataFields
+}
+
+// PersonalDataFields implements [eventstore.PersonalDataCommand]
+func (e *UsernameChangedEvent) PersonalDataFields() []string {
+	return userNamePersonalDataFields
+}
+
+// PersonalDataFields implements [eventstore.PersonalDataCommand]
+func (e *DomainClaimedEvent) PersonalDataFields() []string {
+	return userNamePersonalDataFields
+}
@@ -0,0 +1,149 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/eventstore/repository/mock"
+	"github.com/zitadel/zitadel/internal/repository/notification"
+)
+
+type memoryDataKeyStorage struct {
+	keys      map[string]*crypto.CryptoValue
+	destroyed map[string][]string
+}
+
+func (m *memoryDataKeyStorage) CreateDataKey(_ context.Context, _ *sql.Tx, instanceID, ownerID string, key *crypto.CryptoValue) (*crypto.CryptoValue, error) {
+	if existing, ok := m.keys[instanceID+ownerID]; ok {
+		return existing, nil
+	}
+	m.keys[instanceID+ownerID] = key
+	return key, nil
+}
+
+func (m *memoryDataKeyStorage) ReadDataKey(_ context.Context, instanceID, ownerID string) (*crypto.CryptoValue, error) {
+	key, ok := m.keys[instanceID+ownerID]
+	if !ok {
+		return nil, errors.ThrowNotFound(nil, "TEST-Jae4o", "not found")
+	}
+	return key, nil
+}
+
+func (m *memoryDataKeyStorage) DestroyDataKey(_ context.Context, instanceID, ownerID string) error {
+	delete(m.keys, instanceID+ownerID)
+	m.destroyed[instanceID] = append(m.destroyed[instanceID], ownerID)
+	return nil
+}
+
+func (m *memoryDataKeyStorage) DestroyedDataKeys(_ context.Context, instanceID string) ([]string, error) {
+	return m.destroyed[instanceID], nil
+}
+
+func storedEvent(t *testing.T, keys eventstore.PersonalDataKeys, command eventstore.Command) *repository.Event {
+	data, err := eventstore.EventData(command)
+	require.NoError(t, err)
+	if personalData, ok := command.(eventstore.PersonalDataCommand); ok && keys != nil {
+		data, err = eventstore.SealPersonalData(context.Background(), nil, keys, personalData, data)
+		require.NoError(t, err)
+	}
+	return &repository.Event{
+		InstanceID:    command.Aggregate().InstanceID,
+		AggregateID:   command.Aggregate().ID,
+		AggregateType: command.Aggregate().Type,
+		Typ:           command.Type(),
+		Data:          data,
+	}
+}
+
+func filterUserEvents(t *testing.T, keys eventstore.PersonalDataKeys, events ...eventstore.Event) ([]eventstore.Event, error) {
+	es := eventstore.NewEventstore(&eventstore.Config{
+		Querier:          mock.NewRepo(t).ExpectFilterEvents(events...).MockQuerier,
+		PersonalDataKeys: keys,
+	})
+	RegisterEventMappers(es)
+	return es.Filter(context.Background(), eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).InstanceID("instance1").AddQuery().AggregateTypes(AggregateType).Builder())
+}
+
+func TestPersonalData_cryptoShredding(t *testing.T) {
+	keys := crypto.NewDataKeys(&memoryDataKeyStorage{keys: map[string]*crypto.CryptoValue{}, destroyed: map[string][]string{}}, crypto.CreateMockEncryptionAlg(gomock.NewController(t)))
+	aggregate := &eventstore.Aggregate{ID: "user1", Type: AggregateType, ResourceOwner: "org1", InstanceID: "instance1"}
+
+	added := storedEvent(t, keys, NewHumanAddedEvent(context.Background(), aggregate, "username", "firstname", "lastname", "", "displayname", language.German, domain.GenderFemale, "email@test.ch", false))
+	assert.NotContains(t, string(added.Data), "username")
+	assert.NotContains(t, string(added.Data), "firstname")
+	assert.NotContains(t, string(added.Data), "email@test.ch")
+	// pushed before crypto-shredding was enabled
+	plainChanged := storedEvent(t, nil, NewHumanEmailChangedEvent(context.Background(), aggregate, "plain@test.ch"))
+
+	events, err := filterUserEvents(t, keys, added, plainChanged)
+	require.NoError(t, err)
+	humanAdded := events[0].(*HumanAddedEvent)
+	assert.Equal(t, "username", humanAdded.UserName)
+	assert.Equal(t, "firstname", humanAdded.FirstName)
+	assert.Equal(t, domain.EmailAddress("email@test.ch"), humanAdded.EmailAddress)
+	assert.Equal(t, domain.GenderFemale, humanAdded.Gender)
+	assert.Contains(t, string(humanAdded.DataAsBytes()), "email@test.ch", "payload must be opened")
+	assert.Equal(t, domain.EmailAddress("plain@test.ch"), events[1].(*HumanEmailChangedEvent).EmailAddress)
+
+	require.NoError(t, keys.Destroy(context.Background(), "instance1", "user1"))
+	events, err = filterUserEvents(t, keys, added, plainChanged)
+	require.NoError(t, err)
+	humanAdded = events[0].(*HumanAddedEvent)
+	assert.Equal(t, eventstore.RedactedPersonalData, humanAdded.UserName)
+	assert.Equal(t, eventstore.RedactedPersonalData, humanAdded.FirstName)
+	assert.Equal(t, eventstore.RedactedPersonalData, humanAdded.DisplayName)
+	assert.Empty(t, humanAdded.NickName, "fields which were not set must stay empty")
+	assert.Equal(t, domain.EmailAddress(eventstore.RedactedPersonalData), humanAdded.EmailAddress)
+	assert.Equal(t, domain.GenderFemale, humanAdded.Gender)
+	assert.NotContains(t, string(humanAdded.DataAsBytes()), "personalData")
+	assert.Equal(t, domain.EmailAddress(eventstore.RedactedPersonalData), events[1].(*HumanEmailChangedEvent).EmailAddress, "plain personal data must be redacted")
+}
+
+func TestPersonalData_missingKeys(t *testing.T) {
+	keys := crypto.NewDataKeys(&memoryDataKeyStorage{keys: map[string]*crypto.CryptoValue{}, destroyed: map[string][]string{}}, crypto.CreateMockEncryptionAlg(gomock.NewController(t)))
+	aggregate := &eventstore.Aggregate{ID: "user1", Type: AggregateType, ResourceOwner: "org1", InstanceID: "instance1"}
+	changed := storedEvent(t, keys, NewUsernameChangedEvent(context.Background(), aggregate, "old", "new", false))
+
+	_, err := filterUserEvents(t, nil, changed)
+	assert.Error(t, err, "sealed events must not be reduced without keys")
+}
+
+func TestPersonalData_otherOwner(t *testing.T) {
+	keys := crypto.NewDataKeys(&memoryDataKeyStorage{keys: map[string]*crypto.CryptoValue{}, destroyed: map[string][]string{}}, crypto.CreateMockEncryptionAlg(gomock.NewController(t)))
+	aggregate := &notification.NewAggregate("user:user1:15", "org1", "instance1").Aggregate
+	queued := storedEvent(t, keys, notification.NewQueuedEvent(context.Background(), aggregate, "user1", domain.NotificationTypeEmail, HumanInitialCodeAddedType, "email@test.ch", "subject", &crypto.CryptoValue{}))
+	assert.NotContains(t, string(queued.Data), "email@test.ch")
+
+	filter := func() *notification.QueuedEvent {
+		es := eventstore.NewEventstore(&eventstore.Config{
+			Querier:          mock.NewRepo(t).ExpectFilterEvents(queued).MockQuerier,
+			PersonalDataKeys: keys,
+		})
+		notification.RegisterEventMappers(es)
+		events, err := es.Filter(context.Background(), eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).InstanceID("instance1").AddQuery().AggregateTypes(notification.AggregateType).Builder())
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		return events[0].(*notification.QueuedEvent)
+	}
+	assert.Equal(t, "email@test.ch", filter().Recipient)
+
+	// the recipient is sealed with the key of the user, not of the message
+	require.NoError(t, keys.Destroy(context.Background(), "instance1", "user:user1:15"))
+	assert.Equal(t, "email@test.ch", filter().Recipient)
+	require.NoError(t, keys.Destroy(context.Background(), "instance1", "user1"))
+	event := filter()
+	assert.Equal(t, eventstore.RedactedPersonalData, event.Recipient)
+	assert.Equal(t, "user1", event.UserID)
+	assert.Equal(t, "subject", event.Subject)
+}
@@ -23,6 +23,7 @@ const (
 	UserDomainClaimedType     = userEventTypePrefix + "domain.claimed"
 	UserDomainClaimedSentType = userEventTypePrefix + "domain.claimed.sent"
 	UserUserNameChangedType   = userEventTypePrefix + "username.changed"
+	UserForgottenType         = userEventTypePrefix + "forgotten"
 )
 
 func NewAddUsernameUniqueConstraint(userName, resourceOwner string, userLoginMustBeDomain bool) *eventstore.UniqueConstraint {
@@ -268,6 +269,36 @@ func UserTokenAddedEventMapper(event eventstore.Event) (eventstore.Event, error)
 	return tokenAdded, nil
 }
 
+// UserForgottenEvent is pushed after the data key of the removed user is destroyed.
+// The personal data of the previous events is redacted.
+type UserForgottenEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *UserForgottenEvent) Payload() interface{} {
+	return nil
+}
+
+func (e *UserForgottenEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewUserForgottenEvent(ctx context.Context, aggregate *eventstore.Aggregate) *UserForgottenEvent {
+	return &UserForgottenEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			UserForgottenType,
+		),
+	}
+}
+
+func UserForgottenEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	return &UserForgottenEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
+
 type UserTokenRemovedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
@@ -22,6 +22,7 @@ type Metrics struct {
 	Counters          sync.Map
 	UpDownSumObserver sync.Map
 	ValueObservers    sync.Map
+	Histograms        sync.Map
 }
 
 func NewMetrics(meterName string) (metrics.Metrics, error) {
@@ -100,10 +101,49 @@ func (m *Metrics) RegisterValueObserver(name, description string, callbackFunc m
 	return nil
 }
 
+func (m *Metrics) RegisterHistogram(name, description, unit string, buckets []float64) error {
+	if _, exists := m.Histograms.Load(name); exists {
+		return nil
+	}
+	options := []metric.Float64HistogramOption{metric.WithDescription(description)}
+	if unit != "" {
+		options = append(options, metric.WithUnit(unit))
+	}
+	if len(buckets) > 0 {
+		options = append(options, metric.WithExplicitBucketBoundaries(buckets...))
+	}
+	histogram, err := m.Meter.Float64Histogram(name, options...)
+	if err != nil {
+		return err
+	}
+	m.Histograms.Store(name, histogram)
+	return nil
+}
+
+func (m *Metrics) RecordHistogram(ctx context.Context, name string, value float64, labels map[string]attribute.Value) error {
+	histogram, exists := m.Histograms.Load(name)
+	if !exists {
+		return caos_errs.ThrowNotFound(nil, "METER-Vei3u", "Errors.Metrics.Histogram.NotFound")
+	}
+	histogram.(metric.Float64Histogram).Record(ctx, value, MapToRecordOption(labels)...)
+	return nil
+}
+
 func MapToAddOption(labels map[string]attribute.Value) []metric.AddOption {
 	if labels == nil {
 		return nil
 	}
+	return []metric.AddOption{metric.WithAttributes(mapToAttributes(labels)...)}
+}
+
+func MapToRecordOption(labels map[string]attribute.Value) []metric.RecordOption {
+	if labels == nil {
+		return nil
+	}
+	return []metric.RecordOption{metric.WithAttributes(mapToAttributes(labels)...)}
+}
+
+func mapToAttributes(labels map[string]attribute.Value) []attribute.KeyValue {
 	keyValues := make([]attribute.KeyValue, 0, len(labels))
 	for key, value := range labels {
 		keyValues = append(keyValues, attribute.KeyValue{
@@ -111,5 +151,5 @@ func MapToAddOption(labels map[string]attribute.Value) []metric.AddOption {
 			Value: value,
 		})
 	}
-	return []metric.AddOption{metric.WithAttributes(keyValues...)}
+	return keyValues
 }
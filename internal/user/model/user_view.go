@@ -51,6 +51,7 @@ type HumanView struct {
 	OTPState                 MFAState
 	OTPSMSAdded              bool
 	OTPEmailAdded            bool
+	RecoveryCodesRemaining   int
 	U2FTokens                []*WebAuthNView
 	PasswordlessTokens       []*WebAuthNView
 	MFAMaxSetUp              domain.MFALevel
@@ -206,6 +207,10 @@ func (u *UserView) MFATypesAllowed(level domain.MFALevel, policy *domain.LoginPo
 					if u.OTPEmailAdded {
 						types = append(types, domain.MFATypeOTPEmail)
 					}
+				case domain.SecondFactorTypeRecoveryCodes:
+					if u.RecoveryCodesRemaining > 0 {
+						types = append(types, domain.MFATypeRecoveryCode)
+					}
 				}
 			}
 		}
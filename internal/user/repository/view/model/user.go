@@ -90,6 +90,7 @@ type HumanView struct {
 	OTPState                 int32          `json:"-" gorm:"column:otp_state"`
 	OTPSMSAdded              bool           `json:"-" gorm:"column:otp_sms_added"`
 	OTPEmailAdded            bool           `json:"-" gorm:"column:otp_email_added"`
+	RecoveryCodesRemaining   int            `json:"-" gorm:"column:recovery_codes_remaining"`
 	U2FTokens                WebAuthNTokens `json:"-" gorm:"column:u2f_tokens"`
 	MFAMaxSetUp              int32          `json:"-" gorm:"column:mfa_max_set_up"`
 	MFAInitSkipped           time.Time      `json:"-" gorm:"column:mfa_init_skipped"`
@@ -181,6 +182,7 @@ func UserToModel(user *UserView) *model.UserView {
 			OTPState:                 model.MFAState(user.OTPState),
 			OTPSMSAdded:              user.OTPSMSAdded,
 			OTPEmailAdded:            user.OTPEmailAdded,
+			RecoveryCodesRemaining:   user.RecoveryCodesRemaining,
 			MFAMaxSetUp:              domain.MFALevel(user.MFAMaxSetUp),
 			MFAInitSkipped:           user.MFAInitSkipped,
 			InitRequired:             user.InitRequired,
@@ -264,6 +266,8 @@ func (u *UserView) AppendEvent(event eventstore.Event) (err error) {
 	case user.UserV1PasswordChangedType,
 		user.HumanPasswordChangedType:
 		err = u.setPasswordData(event)
+	case user.HumanPasswordBreachedType:
+		u.PasswordChangeRequired = true
 	case user.HumanPasswordlessTokenAddedType:
 		err = u.addPasswordlessToken(event)
 	case user.HumanPasswordlessTokenVerifiedType:
@@ -341,6 +345,14 @@ func (u *UserView) AppendEvent(event eventstore.Event) (err error) {
 	case user.HumanOTPEmailRemovedType:
 		u.OTPEmailAdded = false
 		u.MFAInitSkipped = time.Time{}
+	case user.HumanRecoveryCodesAddedType:
+		err = u.setRecoveryCodes(event)
+	case user.HumanRecoveryCodeCheckSucceededType:
+		if u.RecoveryCodesRemaining > 0 {
+			u.RecoveryCodesRemaining--
+		}
+	case user.HumanRecoveryCodesRemovedType:
+		u.RecoveryCodesRemaining = 0
 	case user.HumanU2FTokenAddedType:
 		err = u.addU2FToken(event)
 	case user.HumanU2FTokenVerifiedType:
@@ -406,6 +418,18 @@ func (u *UserView) setPasswordData(event eventstore.Event) error {
 	return nil
 }
 
+func (u *UserView) setRecoveryCodes(event eventstore.Event) error {
+	codes := new(struct {
+		EncodedHashes []string `json:"encodedHashes"`
+	})
+	if err := event.Unmarshal(codes); err != nil {
+		logging.Log("MODEL-Rc2fa").WithError(err).Error("could not unmarshal event data")
+		return errors.ThrowInternal(nil, "MODEL-Rc3fb", "could not unmarshal data")
+	}
+	u.RecoveryCodesRemaining = len(codes.EncodedHashes)
+	return nil
+}
+
 func (u *UserView) addPasswordlessToken(event eventstore.Event) error {
 	token, err := webAuthNViewFromEvent(event)
 	if err != nil {
@@ -565,6 +589,7 @@ func (u *UserView) EventTypes() []eventstore.EventType {
 		user.UserRemovedType,
 		user.UserV1PasswordChangedType,
 		user.HumanPasswordChangedType,
+		user.HumanPasswordBreachedType,
 		user.HumanPasswordlessTokenAddedType,
 		user.HumanPasswordlessTokenVerifiedType,
 		user.HumanPasswordlessTokenRemovedType,
@@ -599,6 +624,9 @@ func (u *UserView) EventTypes() []eventstore.EventType {
 		user.HumanOTPSMSRemovedType,
 		user.HumanOTPEmailAddedType,
 		user.HumanOTPEmailRemovedType,
+		user.HumanRecoveryCodesAddedType,
+		user.HumanRecoveryCodeCheckSucceededType,
+		user.HumanRecoveryCodesRemovedType,
 		user.HumanU2FTokenAddedType,
 		user.HumanU2FTokenVerifiedType,
 		user.HumanU2FTokenRemovedType,
@@ -155,6 +155,8 @@ func (v *UserSessionView) AppendEvent(event eventstore.Event) error {
 		if v.UserAgentID == data.UserAgentID {
 			v.setSecondFactorVerification(event.CreatedAt(), domain.MFATypeOTPEmail)
 		}
+	case user.HumanRecoveryCodeCheckSucceededType:
+		v.setSecondFactorVerification(event.CreatedAt(), domain.MFATypeRecoveryCode)
 	case user.UserV1MFAOTPCheckFailedType,
 		user.UserV1MFAOTPRemovedType,
 		user.HumanMFAOTPCheckFailedType,
@@ -162,7 +164,8 @@ func (v *UserSessionView) AppendEvent(event eventstore.Event) error {
 		user.HumanU2FTokenCheckFailedType,
 		user.HumanU2FTokenRemovedType,
 		user.HumanOTPSMSCheckFailedType,
-		user.HumanOTPEmailCheckFailedType:
+		user.HumanOTPEmailCheckFailedType,
+		user.HumanRecoveryCodeCheckFailedType:
 		v.SecondFactorVerification = time.Time{}
 	case user.HumanU2FTokenVerifiedType:
 		data := new(es_model.WebAuthNVerify)
@@ -240,6 +243,8 @@ func (v *UserSessionView) EventTypes() []eventstore.EventType {
 		user.HumanOTPSMSCheckFailedType,
 		user.HumanOTPEmailCheckSucceededType,
 		user.HumanOTPEmailCheckFailedType,
+		user.HumanRecoveryCodeCheckSucceededType,
+		user.HumanRecoveryCodeCheckFailedType,
 		user.HumanU2FTokenCheckFailedType,
 		user.HumanU2FTokenRemovedType,
 		user.HumanU2FTokenVerifiedType,
@@ -42,6 +42,18 @@ func EventsToPb(ctx context.Context, events []*query.Event) (_ *ListEventsRespon
 	}, nil
 }
 
+func StreamedEventToPb(event *query.StreamedEvent) (*StreamEventsResponse, error) {
+	res, err := event_grpc.EventToPb(event.Event)
+	if err != nil {
+		return nil, err
+	}
+	return &StreamEventsResponse{
+		Event:    res,
+		Position: event.Cursor.Position,
+		Offset:   uint32(event.Cursor.Offset),
+	}, nil
+}
+
 func (resp *ListEventTypesResponse) Localizers() []middleware.Localizer {
 	if resp == nil {
 		return nil